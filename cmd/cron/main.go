@@ -1,20 +1,49 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"murakali/config"
+	"murakali/internal/constant"
+	"murakali/internal/model"
+	"murakali/pkg/jobauth"
 	"murakali/pkg/logger"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
-
-	"github.com/robfig/cron/v3"
+	"sort"
+	"time"
 )
 
+// jobPaths are the maintenance endpoints behind JobAuthMiddleware. The API
+// schedules these jobs itself; this command triggers one of them once from
+// outside, for an external scheduler or an operator without an admin token.
+var jobPaths = map[string]string{
+	constant.JobOrderDelivered:     "/api/v1/seller/delivery",
+	constant.JobTransactionExpired: "/api/v1/seller/expired",
+	constant.JobRejectedRefund:     "/api/v1/user/rejected-refund",
+	constant.JobProductMetadata:    "/api/v1/product/metadata",
+}
+
 func main() {
-	log.Println("Starting cron server")
+	baseURL := flag.String("url", "", "base URL of the API, defaults to https://<DOMAIN>")
+	flag.Usage = func() {
+		names := make([]string, 0, len(jobPaths))
+		for name := range jobPaths {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: cron [-url base] <job>\njobs: %v\n", names)
+	}
+	flag.Parse()
+
+	path, ok := jobPaths[flag.Arg(0)]
+	if flag.NArg() != 1 || !ok {
+		flag.Usage()
+		os.Exit(2)
+	}
+
 	cfgFile, err := config.LoadConfig()
 	if err != nil {
 		log.Fatalf("LoadConfig: %v", err)
@@ -30,137 +59,49 @@ func main() {
 	appLogger.InitLogger()
 	appLogger.Infof("AppVersion: %s, LogLevel: %s, Mode: %s", cfg.Server.AppVersion, cfg.Logger.Level, cfg.Server.Mode)
 
-	cronJob := cron.New()
-	defer cronJob.Stop()
-
-	_, err = cronJob.AddFunc("@every 1m", func() {
-		updateOnDelivery(cfg, appLogger)
-	})
-	if err != nil {
-		appLogger.Warn("FatalConfig: %v", err)
-	}
-
-	_, err = cronJob.AddFunc("@every 1m", func() {
-		updateExpiredAt(cfg, appLogger)
-	})
-	if err != nil {
-		appLogger.Warn("FatalConfig: %v", err)
-	}
-
-	_, err = cronJob.AddFunc("@every 1m", func() {
-		updateRejectedRefund(cfg, appLogger)
-	})
-	if err != nil {
-		appLogger.Warn("FatalConfig: %v", err)
+	if *baseURL == "" {
+		*baseURL = fmt.Sprintf("https://%s", cfg.Server.Domain)
 	}
 
-	_, err = cronJob.AddFunc("@every 1h", func() {
-		updateProductMetadata(cfg, appLogger)
-	})
-	if err != nil {
-		appLogger.Warn("FatalConfig: %v", err)
+	if err := runJob(cfg, appLogger, *baseURL, flag.Arg(0), path); err != nil {
+		appLogger.Fatalf("cron %s: %s", flag.Arg(0), err)
 	}
-
-	go cronJob.Start()
-
-	sig := make(chan os.Signal, 1)
-	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
-	<-sig
-	appLogger.Info("Cron stop")
 }
 
-func updateOnDelivery(cfg *config.Config, appLogger logger.Logger) {
-	appLogger.Info("cron update delivery start")
-	url := fmt.Sprintf("https://%s/api/v1/seller/delivery", cfg.Server.Domain)
-	req, err := http.NewRequest("POST", url, http.NoBody)
-	if err != nil {
-		appLogger.Warnf("request error: ", err.Error())
-		return
-	}
-
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		appLogger.Warn("response error: ", err.Error())
-		return
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		appLogger.Warn("status code error: ", res.StatusCode)
-		return
-	}
-
-	appLogger.Infof("update delivery success")
+type jobResponse struct {
+	Message string          `json:"message"`
+	Data    model.JobReport `json:"data"`
 }
 
-func updateProductMetadata(cfg *config.Config, appLogger logger.Logger) {
-	appLogger.Info("cron update product metadata")
-	url := fmt.Sprintf("https://%s/api/v1/product/metadata", cfg.Server.Domain)
-	req, err := http.NewRequest("POST", url, http.NoBody)
+// runJob calls one maintenance endpoint of the API, signed with the shared job
+// secret, and logs the run report it answers with.
+func runJob(cfg *config.Config, appLogger logger.Logger, baseURL, name, path string) error {
+	appLogger.Infof("cron %s start", name)
+	req, err := http.NewRequest(http.MethodPost, baseURL+path, http.NoBody)
 	if err != nil {
-		appLogger.Warnf("request error: ", err.Error())
-		return
+		return err
 	}
 
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		appLogger.Warn("response error: ", err.Error())
-		return
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		appLogger.Warn("status code error: ", res.StatusCode)
-		return
-	}
-
-	appLogger.Infof("update product metadata success")
-}
-
-func updateExpiredAt(cfg *config.Config, appLogger logger.Logger) {
-	appLogger.Info("cron update expired at start")
-	url := fmt.Sprintf("https://%s/api/v1/seller/expired", cfg.Server.Domain)
-	req, err := http.NewRequest("POST", url, http.NoBody)
-	if err != nil {
-		appLogger.Warnf("request error: ", err.Error())
-		return
+	if err := jobauth.SignRequest(req, cfg.Server.JobSecretKey, nil, time.Now()); err != nil {
+		return err
 	}
 
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {
-		appLogger.Warn("response error: ", err.Error())
-		return
+		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode != http.StatusOK {
-		appLogger.Warn("status code error: ", res.StatusCode)
-		return
-	}
-
-	appLogger.Infof("update expired success")
-}
-
-func updateRejectedRefund(cfg *config.Config, appLogger logger.Logger) {
-	appLogger.Info("cron update rejected at start")
-	url := fmt.Sprintf("https://%s/api/v1/user/rejected-refund", cfg.Server.Domain)
-	req, err := http.NewRequest("POST", url, http.NoBody)
-	if err != nil {
-		appLogger.Warnf("request error: ", err.Error())
-		return
-	}
-
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		appLogger.Warn("response error: ", err.Error())
-		return
+	var result jobResponse
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode response with status code %d: %w", res.StatusCode, err)
 	}
-	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		appLogger.Warn("status code error: ", res.StatusCode)
-		return
+		return fmt.Errorf("status code %d: %s, %d rows affected in %dms",
+			res.StatusCode, result.Message, result.Data.RowsAffected, result.Data.DurationMs)
 	}
 
-	appLogger.Infof("update rejected success")
+	appLogger.Infof("cron %s success, %d rows affected in %dms", name, result.Data.RowsAffected, result.Data.DurationMs)
+	return nil
 }
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"murakali/pkg/jwt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// jwtkey writes a new signing key to the key directory. Its id is the UTC
+// time, so the keyring picks it up as the newest key on the next start when
+// JWT_ACTIVE_KEY_ID is left empty.
+func main() {
+	dir := flag.String("dir", "keys", "directory of the JWT keyring")
+	alg := flag.String("alg", jwt.AlgEdDSA, "signing algorithm, EdDSA or RS256")
+	flag.Parse()
+
+	id := time.Now().UTC().Format("20060102T150405Z")
+	key, err := jwt.GenerateKey(id, *alg)
+	if err != nil {
+		log.Fatalf("GenerateKey: %v", err)
+	}
+
+	data, err := key.PrivatePEM()
+	if err != nil {
+		log.Fatalf("PrivatePEM: %v", err)
+	}
+
+	if err := os.MkdirAll(*dir, 0o700); err != nil {
+		log.Fatalf("MkdirAll: %v", err)
+	}
+
+	path := filepath.Join(*dir, id+".pem")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		log.Fatalf("WriteFile: %v", err)
+	}
+
+	log.Printf("Wrote %s key %s", *alg, path)
+}
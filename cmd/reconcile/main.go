@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"log"
+	"murakali/config"
+	ledgerRepository "murakali/internal/module/ledger/repository"
+	ledgerUseCase "murakali/internal/module/ledger/usecase"
+	"murakali/pkg/logger"
+	"murakali/pkg/postgre"
+	"os"
+)
+
+func main() {
+	log.Println("Starting wallet reconciliation")
+	cfgFile, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("LoadConfig: %v", err)
+	}
+
+	cfg, err := config.ParseConfig(cfgFile)
+	if err != nil {
+		log.Fatalf("ParseConfig: %v", err)
+	}
+
+	appLogger := logger.NewAPILogger(cfg)
+
+	appLogger.InitLogger()
+	appLogger.Infof("AppVersion: %s, LogLevel: %s, Mode: %s", cfg.Server.AppVersion, cfg.Logger.Level, cfg.Server.Mode)
+
+	pgDB, err := postgre.NewPG(cfg, appLogger)
+	if err != nil {
+		appLogger.Fatalf("Postgresql init: %s", err)
+	}
+	appLogger.Infof("Postgres connected")
+
+	ledgerRepo := ledgerRepository.NewLedgerRepository(pgDB)
+	ledgerUC := ledgerUseCase.NewLedgerUseCase(cfg, postgre.NewTxRepository(pgDB), ledgerRepo)
+
+	mismatches, err := ledgerUC.ReconcileWallets(context.Background())
+	if err != nil {
+		appLogger.Fatalf("reconcile wallets: %s", err)
+	}
+
+	for _, mismatch := range mismatches {
+		appLogger.Warnf("wallet %s of user %s: stored balance %s, ledger balance %s, difference %s",
+			mismatch.WalletID, mismatch.UserID, mismatch.StoredBalance, mismatch.LedgerBalance, mismatch.Difference())
+	}
+
+	if len(mismatches) > 0 {
+		appLogger.Errorf("Reconciliation found %d mismatched wallets", len(mismatches))
+		os.Exit(1)
+	}
+
+	appLogger.Infof("Reconciliation done, every wallet matches the ledger")
+}
@@ -9,12 +9,13 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig
-	JWT      JWTConfig
-	Postgres PostgresConfig
-	Redis    RedisConfig
-	Logger   LoggerConfig
-	External ExternalConfig
+	Server    ServerConfig
+	JWT       JWTConfig
+	Postgres  PostgresConfig
+	Redis     RedisConfig
+	Logger    LoggerConfig
+	External  ExternalConfig
+	RateLimit RateLimitConfig
 }
 
 type ServerConfig struct {
@@ -27,10 +28,18 @@ type ServerConfig struct {
 	WriteTimeout      time.Duration `mapstructure:"WRITE_TIMEOUT"`
 	CtxDefaultTimeout time.Duration `mapstructure:"CTX_DEFAULT_TIMEOUT"`
 	Debug             bool          `mapstructure:"DEBUG"`
+	JobSecretKey      string        `mapstructure:"JOB_SECRET_KEY"`
+	// TrustedProxies lists the comma separated IPs or CIDRs of the proxies in
+	// front of the API. Only they may set X-Forwarded-For; when empty the
+	// client IP is always the peer address.
+	TrustedProxies string `mapstructure:"TRUSTED_PROXIES"`
 }
 
+// JWTConfig points at the directory of <kid>.pem signing keys, see
+// jwt.LoadKeyring. ActiveKeyID may be left empty to sign with the newest key.
 type JWTConfig struct {
-	JwtSecretKey  string `mapstructure:"JWT_SECRET_KEY"`
+	KeyDir        string `mapstructure:"JWT_KEY_DIR"`
+	ActiveKeyID   string `mapstructure:"JWT_ACTIVE_KEY_ID"`
 	JwtIssuer     string `mapstructure:"JWT_ISSUER"`
 	AccessExpMin  int    `mapstructure:"ACCESS_EXP_MIN"`
 	RefreshExpMin int    `mapstructure:"REFRESH_EXP_MIN"`
@@ -59,6 +68,15 @@ type LoggerConfig struct {
 	Level             string `mapstructure:"LOGGER_LEVEL"`
 }
 
+// RateLimitConfig holds the request limits of the API and its route groups as
+// "<requests>/<period>" values, see middleware.ParseRateLimit.
+type RateLimitConfig struct {
+	Global   string `mapstructure:"RATE_LIMIT_GLOBAL"`
+	Auth     string `mapstructure:"RATE_LIMIT_AUTH"`
+	Product  string `mapstructure:"RATE_LIMIT_PRODUCT"`
+	Location string `mapstructure:"RATE_LIMIT_LOCATION"`
+}
+
 type ExternalConfig struct {
 	SlpURL             string `mapstructure:"SLP_URL"`
 	SlpAPIKey          string `mapstructure:"SLP_API_KEY"`
@@ -67,10 +85,13 @@ type ExternalConfig struct {
 	SMTPPort           string `mapstructure:"SMTP_PORT"`
 	SMTPPassword       string `mapstructure:"SMTP_PASSWORD"`
 	SMTPFrom           string `mapstructure:"SMTP_FROM"`
+	Mailer             string `mapstructure:"MAILER"`
+	MailerDir          string `mapstructure:"MAILER_DIR"`
 	CloudinaryURL      string `mapstructure:"CLOUDINARY_URL"`
 	OngkirAPIURL       string `mapstructure:"ONGKIR_API_URL"`
 	OngkirAPIKey       string `mapstructure:"ONGKIR_API_KEY"`
 	KodePosURL         string `mapstructure:"KODE_POS_URL"`
+	ShippingProvider   string `mapstructure:"SHIPPING_PROVIDER"`
 	GoogleClientID     string `mapstructure:"GOOGLE_OAUTH_CLIENT_ID"`
 	GoogleClientSecret string `mapstructure:"GOOGLE_OAUTH_CLIENT_SECRET"`
 	GoogleRedirectURL  string `mapstructure:"GOOGLE_OAUTH_REDIRECT_URL"`
@@ -125,5 +146,10 @@ func ParseConfig(v *viper.Viper) (*Config, error) {
 		return nil, err
 	}
 
+	if err := v.Unmarshal(&c.RateLimit); err != nil {
+		log.Printf("unable to decode into struct, %v", err)
+		return nil, err
+	}
+
 	return &c, nil
 }
@@ -22,6 +22,36 @@ const (
 	RoleSeller = 2
 	RoleAdmin  = 3
 
+	PermissionShopManage      = "shop:manage"
+	PermissionProductManage   = "product:manage"
+	PermissionOrderManage     = "order:manage"
+	PermissionPromotionManage = "promotion:manage"
+	PermissionRefundRespond   = "refund:respond"
+	PermissionRefundRead      = "refund:read"
+	PermissionRefundApprove   = "refund:approve"
+	PermissionVoucherManage   = "voucher:manage"
+	PermissionCategoryManage  = "category:manage"
+	PermissionBannerManage    = "banner:manage"
+	PermissionMediaUpload     = "media:upload"
+	PermissionJobManage       = "job:manage"
+	PermissionAuditRead       = "audit:read"
+	PermissionReviewModerate  = "review:moderate"
+
+	AuditActionEmailChange      = "user.email.change"
+	AuditActionPasswordChange   = "user.password.change"
+	AuditActionWalletPinChange  = "wallet.pin.change"
+	AuditActionSealabsPayAdd    = "sealabs_pay.add"
+	AuditActionSealabsPayDelete = "sealabs_pay.delete"
+	AuditActionRefundApprove    = "refund.approve"
+	AuditActionReviewHide       = "review.hide"
+	AuditActionReviewRestore    = "review.restore"
+	AuditTargetUser             = "user"
+	AuditTargetWallet           = "wallet"
+	AuditTargetSealabsPay       = "sealabs_pay"
+	AuditTargetRefund           = "refund"
+	AuditTargetReview           = "review"
+	AuditRedacted               = "[redacted]"
+
 	ImgMaxSize = 500000
 
 	SLPStatusPaid      = "TXN_PAID"
@@ -29,16 +59,71 @@ const (
 	SLPStatusCanceled  = "TXN_FAILED"
 	SLPMessageCanceled = "Transaction Canceled by user"
 
+	SLPCallbackTypePayment = "slp-payment"
+	SLPCallbackTypeWallet  = "wallet-payment"
+	SLPCallbackWindowMin   = 5
+	SLPCallbackNonceKey    = "payment:callback:nonce"
+
+	JobSignatureWindowMin = 5
+	JobNonceKey           = "job:nonce"
+	JobOrderDelivered     = "order-delivered"
+	JobTransactionExpired = "transaction-expired"
+	JobRejectedRefund     = "rejected-refund"
+	JobProductMetadata    = "product-metadata"
+	JobEmailOutbox        = "email-outbox"
+	JobLockKey            = "job:lock"
+	JobLockTTLMin         = 10
+	JobSlotKey            = "job:slot"
+	JobMaxAttempts        = 3
+	JobRetryBackoffSec    = 2
+	JobStatusRunning      = "running"
+	JobStatusSuccess      = "success"
+	JobStatusFailed       = "failed"
+	JobTriggerSchedule    = "schedule"
+
+	EmailStatusPending       = "pending"
+	EmailStatusSent          = "sent"
+	EmailStatusDead          = "dead"
+	EmailOutboxBatchSize     = 50
+	EmailOutboxMaxAttempts   = 8
+	EmailOutboxBackoffSec    = 30
+	EmailOutboxMaxBackoffMin = 60
+	EmailOutboxLeaseMin      = 5
+
+	IdempotencyKey     = "idempotency"
+	IdempotencyTTLHour = 24
+	IdempotencyLockSec = 60
+	IdempotencyWaitSec = 10
+
+	TOTPIssuer               = "Murakali"
+	TOTPRecoveryCodeCount    = 10
+	TwoFactorChallengeExpMin = 5
+
+	RateLimitKey      = "ratelimit"
+	RateLimitGlobal   = "600/1m"
+	RateLimitAuth     = "30/1m"
+	RateLimitProduct  = "300/1m"
+	RateLimitLocation = "60/1m"
+
+	ProductFacetsKey    = "product:facets"
+	ProductFacetsTTLSec = 120
+
+	SearchSuggestMinLength     = 2
+	SearchSuggestLimit         = 5
+	SearchTrendingKey          = "search:trending"
+	SearchTrendingTTLDay       = 7
+	SearchTrendingLimitDefault = 10
+	SearchTrendingLimitMax     = 50
+
+	VoucherLimitPerUserDefault = 1
+
 	TRUE  = "true"
 	FALSE = "false"
 	ASC   = "asc"
 	DESC  = "desc"
 
-	LoginOauth     = "/login"
-	RegisterOauth  = "/register"
-	ONGKIR_API_URL = "https://api.rajaongkir.com/starter"
-	KODE_POS_URL   = "https://kode-pos-murakali.vercel.app/"
-	ONGKIR_API_KEY = "9bc050f97d720a0477556cfa4d8f6de5"
+	LoginOauth    = "/login"
+	RegisterOauth = "/register"
 
 	OrderStatusWaitingToPay     = 1
 	OrderStatusWaitingForSeller = 2
@@ -49,4 +134,10 @@ const (
 	OrderStatusCompleted        = 7
 	OrderStatusCanceled         = 8
 	OrderStatusRefunded         = 9
+
+	LedgerAccountBuyerWallet    = "buyer_wallet"
+	LedgerAccountSellerWallet   = "seller_wallet"
+	LedgerAccountEscrow         = "escrow"
+	LedgerAccountSeaLabsPay     = "sealabs_pay"
+	LedgerAccountOpeningBalance = "opening_balance"
 )
@@ -10,7 +10,7 @@ import (
 
 func (mw *MWManager) AuthJWTMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		claim, err := jwt.ExtractJWTFromRequest(c.Request, mw.RedisClient, mw.cfg.JWT.JwtSecretKey)
+		claim, err := jwt.ExtractJWTFromRequest(c.Request, mw.sessions, mw.keys)
 		if err != nil {
 			response.ErrorResponse(c.Writer, response.ForbiddenMessage, http.StatusForbidden)
 			c.Abort()
@@ -26,6 +26,8 @@ func (mw *MWManager) AuthJWTMiddleware() gin.HandlerFunc {
 		mw.log.Infof("body middleware bearerHeader %s", claim["id"].(string))
 		c.Set("userID", claim["id"].(string))
 		c.Set("roleID", claim["role_id"].(float64))
+		c.Set("permissions", claimPermissions(claim))
+		c.Set("sessionID", claim["sid"].(string))
 		c.Next()
 	}
 }
@@ -0,0 +1,200 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"murakali/internal/constant"
+	"murakali/pkg/response"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	idempotencyStateProcessing = "processing"
+	idempotencyStateDone       = "done"
+	idempotencyMaxKeyLength    = 255
+	idempotencyPollInterval    = 100 * time.Millisecond
+)
+
+// idempotencyWait is how long a retry waits for the first request to finish
+// before giving up with a conflict.
+var idempotencyWait = time.Duration(constant.IdempotencyWaitSec) * time.Second
+
+type idempotencyRecord struct {
+	State       string `json:"state"`
+	RequestHash string `json:"request_hash"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware makes a route safe to retry with the same
+// Idempotency-Key header. The first request for a (user, route, key) runs the
+// handler and its response is kept; later requests with the same body get that
+// response replayed, and requests with a different body are rejected. A request
+// that arrives while the first one is still running waits for it to finish.
+// Server errors are not kept so the client can retry them. Requests without the
+// header are handled as before. It must run after AuthJWTMiddleware.
+func (mw *MWManager) IdempotencyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idempotencyKey := c.GetHeader(IdempotencyKeyHeader)
+		if idempotencyKey == "" {
+			c.Next()
+			return
+		}
+
+		userID, exist := c.Get("userID")
+		if !exist {
+			response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+			c.Abort()
+			return
+		}
+
+		if len(idempotencyKey) > idempotencyMaxKeyLength {
+			response.ErrorResponse(c.Writer, response.IdempotencyKeyInvalid, http.StatusBadRequest)
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		hash := sha256.Sum256(body)
+		requestHash := hex.EncodeToString(hash[:])
+		key := fmt.Sprintf("%s:%s:%s %s:%s", constant.IdempotencyKey, userID, c.Request.Method, c.FullPath(), idempotencyKey)
+
+		record, acquired, err := mw.waitIdempotencyRecord(c, key, requestHash)
+		if err != nil {
+			mw.log.Errorf("idempotency redis: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			c.Abort()
+			return
+		}
+
+		if !acquired {
+			switch {
+			case record == nil:
+				response.ErrorResponse(c.Writer, response.IdempotencyKeyInProgress, http.StatusConflict)
+			case record.RequestHash != requestHash:
+				response.ErrorResponse(c.Writer, response.IdempotencyKeyReused, http.StatusUnprocessableEntity)
+			default:
+				c.Header(IdempotencyReplayedHeader, constant.TRUE)
+				c.Data(record.StatusCode, record.ContentType, record.Body)
+			}
+			c.Abort()
+			return
+		}
+
+		writer := &idempotencyWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = writer
+		c.Next()
+
+		mw.saveIdempotencyRecord(c, key, &idempotencyRecord{
+			State:       idempotencyStateDone,
+			RequestHash: requestHash,
+			StatusCode:  writer.Status(),
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		})
+	}
+}
+
+// waitIdempotencyRecord either claims key for this request or returns the
+// record of the request that claimed it first. While that request is still
+// processing the same body it polls until it is done or the wait runs out, in
+// which case the record is nil.
+func (mw *MWManager) waitIdempotencyRecord(c *gin.Context, key, requestHash string) (*idempotencyRecord, bool, error) {
+	processing, err := json.Marshal(idempotencyRecord{State: idempotencyStateProcessing, RequestHash: requestHash})
+	if err != nil {
+		return nil, false, err
+	}
+
+	lockTTL := time.Duration(constant.IdempotencyLockSec) * time.Second
+	deadline := time.Now().Add(idempotencyWait)
+	for {
+		acquired, err := mw.RedisClient.SetNX(c, key, processing, lockTTL).Result()
+		if err != nil {
+			return nil, false, err
+		}
+
+		if acquired {
+			return nil, true, nil
+		}
+
+		value, err := mw.RedisClient.Get(c, key).Bytes()
+		if err != nil && err != redis.Nil {
+			return nil, false, err
+		}
+
+		if err == nil {
+			var record idempotencyRecord
+			if err := json.Unmarshal(value, &record); err != nil {
+				return nil, false, err
+			}
+
+			if record.State == idempotencyStateDone || record.RequestHash != requestHash {
+				return &record, false, nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return nil, false, nil
+		}
+
+		select {
+		case <-c.Request.Context().Done():
+			return nil, false, c.Request.Context().Err()
+		case <-time.After(idempotencyPollInterval):
+		}
+	}
+}
+
+func (mw *MWManager) saveIdempotencyRecord(c *gin.Context, key string, record *idempotencyRecord) {
+	if record.StatusCode >= http.StatusInternalServerError {
+		if err := mw.RedisClient.Del(c, key).Err(); err != nil {
+			mw.log.Errorf("idempotency redis: %s", err)
+		}
+		return
+	}
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		mw.log.Errorf("idempotency marshal: %s", err)
+		return
+	}
+
+	ttl := time.Duration(constant.IdempotencyTTLHour) * time.Hour
+	if err := mw.RedisClient.Set(c, key, value, ttl).Err(); err != nil {
+		mw.log.Errorf("idempotency redis: %s", err)
+	}
+}
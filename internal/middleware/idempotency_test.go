@@ -0,0 +1,228 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type idempotencyRequest struct {
+	userID string
+	key    string
+	body   string
+}
+
+func newIdempotencyRouter(mw *MWManager, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if userID := c.GetHeader("X-User"); userID != "" {
+			c.Set("userID", userID)
+		}
+		c.Next()
+	})
+	r.POST("/transaction", mw.IdempotencyMiddleware(), handler)
+
+	return r
+}
+
+func serveIdempotency(r *gin.Engine, req idempotencyRequest) *httptest.ResponseRecorder {
+	httpReq := httptest.NewRequest(http.MethodPost, "/transaction", strings.NewReader(req.body))
+	httpReq.Header.Set("X-User", req.userID)
+	if req.key != "" {
+		httpReq.Header.Set(IdempotencyKeyHeader, req.key)
+	}
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httpReq)
+
+	return rr
+}
+
+func TestMWManager_IdempotencyMiddleware(t *testing.T) {
+	testCase := []struct {
+		name             string
+		status           int
+		requests         []idempotencyRequest
+		expectedStatus   []int
+		expectedReplayed []bool
+		expectedCalls    int32
+	}{
+		{
+			name:   "replay the stored response",
+			status: http.StatusCreated,
+			requests: []idempotencyRequest{
+				{userID: "user-a", key: "key-1", body: `{"cart":1}`},
+				{userID: "user-a", key: "key-1", body: `{"cart":1}`},
+			},
+			expectedStatus:   []int{http.StatusCreated, http.StatusCreated},
+			expectedReplayed: []bool{false, true},
+			expectedCalls:    1,
+		},
+		{
+			name:   "error same key with a different body",
+			status: http.StatusCreated,
+			requests: []idempotencyRequest{
+				{userID: "user-a", key: "key-1", body: `{"cart":1}`},
+				{userID: "user-a", key: "key-1", body: `{"cart":2}`},
+			},
+			expectedStatus:   []int{http.StatusCreated, http.StatusUnprocessableEntity},
+			expectedReplayed: []bool{false, false},
+			expectedCalls:    1,
+		},
+		{
+			name:   "server error is not stored",
+			status: http.StatusInternalServerError,
+			requests: []idempotencyRequest{
+				{userID: "user-a", key: "key-1", body: `{"cart":1}`},
+				{userID: "user-a", key: "key-1", body: `{"cart":1}`},
+			},
+			expectedStatus:   []int{http.StatusInternalServerError, http.StatusInternalServerError},
+			expectedReplayed: []bool{false, false},
+			expectedCalls:    2,
+		},
+		{
+			name:   "client error is stored",
+			status: http.StatusBadRequest,
+			requests: []idempotencyRequest{
+				{userID: "user-a", key: "key-1", body: `{"cart":1}`},
+				{userID: "user-a", key: "key-1", body: `{"cart":1}`},
+			},
+			expectedStatus:   []int{http.StatusBadRequest, http.StatusBadRequest},
+			expectedReplayed: []bool{false, true},
+			expectedCalls:    1,
+		},
+		{
+			name:   "keys are scoped per user",
+			status: http.StatusCreated,
+			requests: []idempotencyRequest{
+				{userID: "user-a", key: "key-1", body: `{"cart":1}`},
+				{userID: "user-b", key: "key-1", body: `{"cart":2}`},
+			},
+			expectedStatus:   []int{http.StatusCreated, http.StatusCreated},
+			expectedReplayed: []bool{false, false},
+			expectedCalls:    2,
+		},
+		{
+			name:   "without a key every request runs",
+			status: http.StatusCreated,
+			requests: []idempotencyRequest{
+				{userID: "user-a", body: `{"cart":1}`},
+				{userID: "user-a", body: `{"cart":1}`},
+			},
+			expectedStatus:   []int{http.StatusCreated, http.StatusCreated},
+			expectedReplayed: []bool{false, false},
+			expectedCalls:    2,
+		},
+		{
+			name:   "error key without a signed in user",
+			status: http.StatusCreated,
+			requests: []idempotencyRequest{
+				{key: "key-1", body: `{"cart":1}`},
+			},
+			expectedStatus:   []int{http.StatusUnauthorized},
+			expectedReplayed: []bool{false},
+			expectedCalls:    0,
+		},
+		{
+			name:   "error key too long",
+			status: http.StatusCreated,
+			requests: []idempotencyRequest{
+				{userID: "user-a", key: strings.Repeat("k", idempotencyMaxKeyLength+1), body: `{"cart":1}`},
+			},
+			expectedStatus:   []int{http.StatusBadRequest},
+			expectedReplayed: []bool{false},
+			expectedCalls:    0,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			mw, _ := newRedisMW(t)
+			var calls int32
+			r := newIdempotencyRouter(mw, func(c *gin.Context) {
+				n := atomic.AddInt32(&calls, 1)
+				c.JSON(tc.status, gin.H{"call": n, "user": c.GetString("userID")})
+			})
+
+			var first string
+			for i, req := range tc.requests {
+				rr := serveIdempotency(r, req)
+				assert.Equal(t, tc.expectedStatus[i], rr.Code)
+				assert.Equal(t, tc.expectedReplayed[i], rr.Header().Get(IdempotencyReplayedHeader) == "true")
+				if i == 0 {
+					first = rr.Body.String()
+				} else if tc.expectedReplayed[i] {
+					assert.Equal(t, first, rr.Body.String())
+					assert.Equal(t, "application/json; charset=utf-8", rr.Header().Get("Content-Type"))
+				}
+			}
+			assert.Equal(t, tc.expectedCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestMWManager_IdempotencyMiddlewareInFlight(t *testing.T) {
+	mw, _ := newRedisMW(t)
+	var calls int32
+	started, release := make(chan struct{}), make(chan struct{})
+	r := newIdempotencyRouter(mw, func(c *gin.Context) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+			<-release
+		}
+		c.JSON(http.StatusCreated, gin.H{"invoice": "INV-1"})
+	})
+
+	req := idempotencyRequest{userID: "user-a", key: "key-1", body: `{"cart":1}`}
+	first := make(chan *httptest.ResponseRecorder)
+	go func() { first <- serveIdempotency(r, req) }()
+	<-started
+
+	// a different body is rejected right away, even while the first runs
+	rr := serveIdempotency(r, idempotencyRequest{userID: "user-a", key: "key-1", body: `{"cart":2}`})
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	// the same body waits for the first request and gets its response
+	second := make(chan *httptest.ResponseRecorder)
+	go func() { second <- serveIdempotency(r, req) }()
+	time.Sleep(3 * idempotencyPollInterval)
+	close(release)
+
+	rrFirst, rrSecond := <-first, <-second
+	assert.Equal(t, http.StatusCreated, rrFirst.Code)
+	assert.Equal(t, http.StatusCreated, rrSecond.Code)
+	assert.Equal(t, "true", rrSecond.Header().Get(IdempotencyReplayedHeader))
+	assert.Equal(t, rrFirst.Body.String(), rrSecond.Body.String())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestMWManager_IdempotencyMiddlewareInFlightTimeout(t *testing.T) {
+	wait := idempotencyWait
+	idempotencyWait = 2 * idempotencyPollInterval
+	defer func() { idempotencyWait = wait }()
+
+	mw, _ := newRedisMW(t)
+	started, release := make(chan struct{}), make(chan struct{})
+	r := newIdempotencyRouter(mw, func(c *gin.Context) {
+		close(started)
+		<-release
+		c.JSON(http.StatusCreated, gin.H{"invoice": "INV-1"})
+	})
+
+	req := idempotencyRequest{userID: "user-a", key: "key-1", body: `{"cart":1}`}
+	first := make(chan *httptest.ResponseRecorder)
+	go func() { first <- serveIdempotency(r, req) }()
+	<-started
+
+	rr := serveIdempotency(r, req)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	close(release)
+	assert.Equal(t, http.StatusCreated, (<-first).Code)
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"murakali/internal/constant"
+	"murakali/pkg/jobauth"
+	"murakali/pkg/response"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobAuthMiddleware only lets through maintenance job requests signed by
+// cmd/cron with the shared job secret. Each nonce is accepted once. The jobs
+// also run on the in-process scheduler, these routes only serve one-off
+// triggers from outside the API.
+func (mw *MWManager) JobAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		window := time.Duration(constant.JobSignatureWindowMin) * time.Minute
+		nonce, err := jobauth.Verify(c.Request, mw.cfg.Server.JobSecretKey, body, time.Now(), window)
+		if err != nil {
+			mw.log.Warnf("job request %s rejected: %s", c.Request.URL.Path, err)
+			response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+			c.Abort()
+			return
+		}
+
+		key := fmt.Sprintf("%s:%s", constant.JobNonceKey, nonce)
+		isNew, err := mw.RedisClient.SetNX(c, key, c.Request.URL.Path, window*2).Result()
+		if err != nil {
+			mw.log.Errorf("job nonce redis: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			c.Abort()
+			return
+		}
+
+		if !isNew {
+			response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
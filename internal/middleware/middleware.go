@@ -3,7 +3,9 @@ package middleware
 import (
 	"github.com/go-redis/redis/v8"
 	"murakali/config"
+	"murakali/pkg/jwt"
 	"murakali/pkg/logger"
+	"murakali/pkg/session"
 )
 
 type MWManager struct {
@@ -11,8 +13,11 @@ type MWManager struct {
 	origins     []string
 	log         logger.Logger
 	RedisClient *redis.Client
+	sessions    session.Store
+	keys        *jwt.Keyring
 }
 
-func NewMiddlewareManager(cfg *config.Config, origins []string, log logger.Logger, redisClient *redis.Client) *MWManager {
-	return &MWManager{cfg: cfg, origins: origins, log: log, RedisClient: redisClient}
+func NewMiddlewareManager(cfg *config.Config, origins []string, log logger.Logger, redisClient *redis.Client,
+	sessions session.Store, keys *jwt.Keyring) *MWManager {
+	return &MWManager{cfg: cfg, origins: origins, log: log, RedisClient: redisClient, sessions: sessions, keys: keys}
 }
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"murakali/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission lets a request through when the role of the signed in
+// user grants permission. It has to run after AuthJWTMiddleware.
+func (mw *MWManager) RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasPermission(c, permission) {
+			response.ErrorResponse(c.Writer, response.ForbiddenMessage, http.StatusForbidden)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// HasPermission reports whether the access token of the request carries
+// permission.
+func HasPermission(c *gin.Context, permission string) bool {
+	value, _ := c.Get("permissions")
+	permissions, _ := value.([]string)
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+func claimPermissions(claim map[string]interface{}) []string {
+	values, _ := claim["perms"].([]interface{})
+	permissions := make([]string, 0, len(values))
+	for _, value := range values {
+		if permission, ok := value.(string); ok {
+			permissions = append(permissions, permission)
+		}
+	}
+
+	return permissions
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMWManager_RequirePermission(t *testing.T) {
+	testCase := []struct {
+		name        string
+		permissions interface{}
+		expected    int
+	}{
+		{name: "granted", permissions: []string{"refund:read", "refund:approve"}, expected: http.StatusOK},
+		{name: "not granted", permissions: []string{"refund:read"}, expected: http.StatusForbidden},
+		{name: "no permissions", permissions: []string{}, expected: http.StatusForbidden},
+		{name: "not signed in", permissions: nil, expected: http.StatusForbidden},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			rr := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(rr)
+			r.Use(func(c *gin.Context) {
+				if tc.permissions != nil {
+					c.Set("permissions", tc.permissions)
+				}
+				c.Next()
+			})
+			r.POST("/refund/:id", (&MWManager{}).RequirePermission("refund:approve"), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/refund/1", http.NoBody))
+			assert.Equal(t, tc.expected, rr.Code)
+		})
+	}
+}
+
+func Test_claimPermissions(t *testing.T) {
+	assert.Equal(t, []string{"shop:manage", "product:manage"},
+		claimPermissions(map[string]interface{}{"perms": []interface{}{"shop:manage", "product:manage"}}))
+	assert.Equal(t, []string{}, claimPermissions(map[string]interface{}{"perms": nil}))
+	assert.Equal(t, []string{}, claimPermissions(map[string]interface{}{}))
+}
@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"fmt"
+	"murakali/internal/constant"
+	"murakali/pkg/jwt"
+	"murakali/pkg/response"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RateLimitPolicyHeader    = "RateLimit-Policy"
+
+	rateLimitOff = "off"
+)
+
+// RateLimitHeaders are the headers a browser client needs exposed to pace itself.
+var RateLimitHeaders = []string{RateLimitLimitHeader, RateLimitRemainingHeader, RateLimitResetHeader, RateLimitPolicyHeader}
+
+// RateLimit allows Limit requests per Period for every identity. Requests are
+// spread evenly over the period, with bursts of up to Limit requests.
+type RateLimit struct {
+	Limit  int
+	Period time.Duration
+}
+
+// ParseRateLimit reads a limit written as "<requests>/<period>", such as
+// "60/1m". An empty value falls back to fallback, and "off" disables the limit.
+func ParseRateLimit(value, fallback string) (*RateLimit, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		value = fallback
+	}
+
+	if value == rateLimitOff {
+		return nil, nil
+	}
+
+	requests, period, found := strings.Cut(value, "/")
+	if !found {
+		return nil, fmt.Errorf("invalid rate limit %q", value)
+	}
+
+	limit, err := strconv.Atoi(requests)
+	if err != nil || limit <= 0 {
+		return nil, fmt.Errorf("invalid rate limit %q", value)
+	}
+
+	duration, err := time.ParseDuration(period)
+	if err != nil || duration <= 0 {
+		return nil, fmt.Errorf("invalid rate limit %q", value)
+	}
+
+	return &RateLimit{Limit: limit, Period: duration}, nil
+}
+
+// emissionInterval is the time one request takes up in the bucket, rounded up
+// to whole milliseconds so the script only deals with integers.
+func (l *RateLimit) emissionInterval() int64 {
+	ms := l.Period.Milliseconds()
+	return (ms + int64(l.Limit) - 1) / int64(l.Limit)
+}
+
+// gcraScript implements the generic cell rate algorithm. The key holds the
+// theoretical arrival time of the next request and expires once the bucket
+// is full again. It returns whether the request is allowed, the remaining
+// requests, the wait before a retry and the wait until the bucket is full,
+// all times in milliseconds. The time is read from the Redis clock, so
+// replicas with skewed clocks still share one timeline per key.
+var gcraScript = redis.NewScript(`
+redis.replicate_commands()
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local emission = tonumber(ARGV[1])
+local tolerance = emission * tonumber(ARGV[2])
+local tat = tonumber(redis.call("GET", KEYS[1]) or now)
+if tat < now then
+	tat = now
+end
+local nextTat = tat + emission
+local allowAt = nextTat - tolerance
+if allowAt > now then
+	return {0, 0, allowAt - now, tat - now}
+end
+redis.call("SET", KEYS[1], nextTat, "PX", nextTat - now)
+return {1, math.floor((now - allowAt) / emission), 0, nextTat - now}
+`)
+
+// RateLimitMiddleware limits the requests of a route group per identity, the
+// user of a valid bearer token or else the client IP, so the limit holds
+// across replicas sharing the same Redis. Every response carries the
+// RateLimit-* headers and rejected requests also get Retry-After. When Redis is
+// unavailable requests are let through rather than failing the API.
+func (mw *MWManager) RateLimitMiddleware(group string, limit *RateLimit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit == nil {
+			c.Next()
+			return
+		}
+
+		key := fmt.Sprintf("%s:%s:%s", constant.RateLimitKey, group, mw.rateLimitIdentity(c))
+		result, err := gcraScript.Run(c, mw.RedisClient, []string{key},
+			limit.emissionInterval(), limit.Limit).Int64Slice()
+		if err != nil {
+			mw.log.Errorf("rate limit redis: %s", err)
+			c.Next()
+			return
+		}
+
+		allowed, remaining, retryAfter, reset := result[0] == 1, result[1], result[2], result[3]
+		header := c.Writer.Header()
+		header.Set(RateLimitLimitHeader, strconv.Itoa(limit.Limit))
+		header.Set(RateLimitRemainingHeader, strconv.FormatInt(remaining, 10))
+		header.Set(RateLimitResetHeader, strconv.FormatInt(ceilSeconds(reset), 10))
+		header.Set(RateLimitPolicyHeader, fmt.Sprintf("%d;w=%d", limit.Limit, int64(limit.Period/time.Second)))
+
+		if !allowed {
+			response.TooManyRequestsResponse(c.Writer, response.TooManyRequestsMessage, int(ceilSeconds(retryAfter)))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimitIdentity only checks the token signature. Whether its session is
+// still valid is left to AuthJWTMiddleware, a revoked token merely keeps
+// counting against its user.
+func (mw *MWManager) rateLimitIdentity(c *gin.Context) string {
+	if userID, exist := c.Get("userID"); exist {
+		return fmt.Sprintf("user:%s", userID)
+	}
+
+	if tokenString := jwt.ExtractBearerToken(c.Request); tokenString != "" {
+		if claims, err := jwt.ExtractJWT(tokenString, mw.keys, jwt.AudienceAccess); err == nil {
+			if userID, ok := claims["id"].(string); ok && userID != "" {
+				return fmt.Sprintf("user:%s", userID)
+			}
+		}
+	}
+
+	return fmt.Sprintf("ip:%s", c.ClientIP())
+}
+
+func ceilSeconds(ms int64) int64 {
+	if ms <= 0 {
+		return 0
+	}
+
+	return (ms + 999) / 1000
+}
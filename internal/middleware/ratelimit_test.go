@@ -0,0 +1,196 @@
+package middleware
+
+import (
+	"context"
+	"murakali/config"
+	"murakali/pkg/jwt"
+	"murakali/pkg/jwt/jwttest"
+	"murakali/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	testCase := []struct {
+		name        string
+		value       string
+		expected    *RateLimit
+		expectedErr bool
+	}{
+		{name: "requests per minute", value: "60/1m", expected: &RateLimit{Limit: 60, Period: time.Minute}},
+		{name: "empty uses fallback", value: "", expected: &RateLimit{Limit: 30, Period: time.Minute}},
+		{name: "off disables", value: "off", expected: nil},
+		{name: "missing period", value: "60", expectedErr: true},
+		{name: "invalid requests", value: "0/1m", expectedErr: true},
+		{name: "invalid period", value: "60/minute", expectedErr: true},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			limit, err := ParseRateLimit(tc.value, "30/1m")
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, limit)
+		})
+	}
+}
+
+func TestRateLimit_emissionInterval(t *testing.T) {
+	assert.Equal(t, int64(1000), (&RateLimit{Limit: 60, Period: time.Minute}).emissionInterval())
+	assert.Equal(t, int64(334), (&RateLimit{Limit: 3, Period: time.Second}).emissionInterval())
+}
+
+func newRedisMW(t *testing.T) (*MWManager, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	appLogger := logger.NewAPILogger(&config.Config{})
+	appLogger.InitLogger()
+
+	return &MWManager{
+		log:         appLogger,
+		RedisClient: redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		keys:        jwttest.NewKeyring("a"),
+	}, mr
+}
+
+func Test_gcraScript(t *testing.T) {
+	mw, mr := newRedisMW(t)
+	limit := &RateLimit{Limit: 3, Period: 3 * time.Second}
+	start := time.UnixMilli(1700000000000)
+
+	// the script reads the Redis clock, the app clock is never passed in
+	run := func(elapsed int64) []int64 {
+		mr.SetTime(start.Add(time.Duration(elapsed) * time.Millisecond))
+		result, err := gcraScript.Run(context.Background(), mw.RedisClient, []string{"ratelimit:test"},
+			limit.emissionInterval(), limit.Limit).Int64Slice()
+		require.NoError(t, err)
+		return result
+	}
+
+	// allowed, remaining, retry after, reset
+	assert.Equal(t, []int64{1, 2, 0, 1000}, run(0))
+	assert.Equal(t, []int64{1, 1, 0, 2000}, run(0))
+	assert.Equal(t, []int64{1, 0, 0, 3000}, run(0))
+	assert.Equal(t, []int64{0, 0, 1000, 3000}, run(0))
+	assert.Equal(t, []int64{1, 0, 0, 3000}, run(1000))
+	assert.Equal(t, []int64{1, 2, 0, 1000}, run(10000))
+}
+
+func TestMWManager_RateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mw, _ := newRedisMW(t)
+	token, err := jwt.GenerateJWTAccessToken("user-a", 1, nil, "session", &config.Config{JWT: config.JWTConfig{AccessExpMin: 5}}, mw.keys)
+	require.NoError(t, err)
+
+	r := gin.New()
+	require.NoError(t, r.SetTrustedProxies(nil))
+	r.GET("/", mw.RateLimitMiddleware("test", &RateLimit{Limit: 2, Period: time.Minute}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	serve := func(remoteAddr, forwardedFor, bearer string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		if bearer != "" {
+			req.Header.Set("Authorization", "Bearer "+bearer)
+		}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := serve("10.0.0.1:1234", "", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "2", rr.Header().Get(RateLimitLimitHeader))
+	assert.Equal(t, "1", rr.Header().Get(RateLimitRemainingHeader))
+	assert.Equal(t, "30", rr.Header().Get(RateLimitResetHeader))
+	assert.Equal(t, "2;w=60", rr.Header().Get(RateLimitPolicyHeader))
+	assert.Empty(t, rr.Header().Get("Retry-After"))
+
+	rr = serve("10.0.0.1:1234", "", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "0", rr.Header().Get(RateLimitRemainingHeader))
+
+	rr = serve("10.0.0.1:1234", "", "")
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "30", rr.Header().Get("Retry-After"))
+	assert.Equal(t, "0", rr.Header().Get(RateLimitRemainingHeader))
+	assert.Equal(t, "60", rr.Header().Get(RateLimitResetHeader))
+
+	// X-Forwarded-For of an untrusted peer does not get a fresh bucket
+	rr = serve("10.0.0.1:1234", "203.0.113.7", "")
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+
+	rr = serve("10.0.0.2:1234", "", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "1", rr.Header().Get(RateLimitRemainingHeader))
+
+	// a signed in user is limited on its own bucket wherever it comes from
+	rr = serve("10.0.0.1:1234", "", token.Token)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "1", rr.Header().Get(RateLimitRemainingHeader))
+
+	rr = serve("10.0.0.3:1234", "", token.Token)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "0", rr.Header().Get(RateLimitRemainingHeader))
+
+	rr = serve("10.0.0.4:1234", "", token.Token)
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+}
+
+func TestMWManager_RateLimitMiddlewareTrustedProxy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mw, _ := newRedisMW(t)
+
+	r := gin.New()
+	require.NoError(t, r.SetTrustedProxies([]string{"10.0.0.1"}))
+	r.GET("/", mw.RateLimitMiddleware("test", &RateLimit{Limit: 1, Period: time.Minute}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for _, tc := range []struct {
+		forwardedFor string
+		expected     int
+	}{
+		{forwardedFor: "203.0.113.7", expected: http.StatusOK},
+		{forwardedFor: "203.0.113.7", expected: http.StatusTooManyRequests},
+		{forwardedFor: "203.0.113.8", expected: http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-Forwarded-For", tc.forwardedFor)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, tc.expected, rr.Code, tc.forwardedFor)
+	}
+}
+
+func TestMWManager_RateLimitMiddlewareRedisDown(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mw, mr := newRedisMW(t)
+	mr.Close()
+
+	r := gin.New()
+	r.GET("/", mw.RateLimitMiddleware("test", &RateLimit{Limit: 1, Period: time.Minute}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, rr.Header().Get(RateLimitLimitHeader))
+}
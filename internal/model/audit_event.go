@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEvent records who did what to which target. Events are only ever
+// inserted, the table rejects updates and deletes.
+type AuditEvent struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	ActorID    string    `json:"actor_id" db:"actor_id"`
+	Action     string    `json:"action" db:"action"`
+	TargetType string    `json:"target_type" db:"target_type"`
+	TargetID   string    `json:"target_id" db:"target_id"`
+	IP         string    `json:"ip" db:"ip"`
+	UserAgent  string    `json:"user_agent" db:"user_agent"`
+	Diff       AuditDiff `json:"diff" db:"diff"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// AuditDiff maps each changed field of the target to its old and new value.
+// Secrets such as passwords are recorded as constant.AuditRedacted.
+type AuditDiff map[string]AuditChange
+
+type AuditChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
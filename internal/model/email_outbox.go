@@ -0,0 +1,23 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EmailOutbox struct {
+	ID            uuid.UUID    `json:"id" db:"id" binding:"omitempty"`
+	Recipient     string       `json:"recipient" db:"recipient" binding:"omitempty"`
+	Subject       string       `json:"subject" db:"subject" binding:"omitempty"`
+	Template      string       `json:"template" db:"template" binding:"omitempty"`
+	HTMLBody      string       `json:"html_body" db:"html_body" binding:"omitempty"`
+	TextBody      string       `json:"text_body" db:"text_body" binding:"omitempty"`
+	Status        string       `json:"status" db:"status" binding:"omitempty"`
+	Attempts      int          `json:"attempts" db:"attempts" binding:"omitempty"`
+	LastError     string       `json:"last_error" db:"last_error" binding:"omitempty"`
+	NextAttemptAt time.Time    `json:"next_attempt_at" db:"next_attempt_at" binding:"omitempty"`
+	SentAt        sql.NullTime `json:"sent_at" db:"sent_at" binding:"omitempty"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at" binding:"omitempty"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+type JobReport struct {
+	Job          string    `json:"job"`
+	RowsAffected int64     `json:"rows_affected"`
+	StartedAt    time.Time `json:"started_at"`
+	FinishedAt   time.Time `json:"finished_at"`
+	DurationMs   int64     `json:"duration_ms"`
+}
+
+func NewJobReport(job string) *JobReport {
+	return &JobReport{Job: job, StartedAt: time.Now()}
+}
+
+// Finish stamps the end of the run. It is safe to call on a failed run so the
+// report still shows how far the job got.
+func (r *JobReport) Finish() *JobReport {
+	r.FinishedAt = time.Now()
+	r.DurationMs = r.FinishedAt.Sub(r.StartedAt).Milliseconds()
+
+	return r
+}
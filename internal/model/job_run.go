@@ -0,0 +1,26 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobRun struct {
+	ID           uuid.UUID    `json:"id" db:"id" binding:"omitempty"`
+	Job          string       `json:"job" db:"job" binding:"omitempty"`
+	Status       string       `json:"status" db:"status" binding:"omitempty"`
+	TriggeredBy  string       `json:"triggered_by" db:"triggered_by" binding:"omitempty"`
+	Attempt      int          `json:"attempt" db:"attempt" binding:"omitempty"`
+	RowsAffected int64        `json:"rows_affected" db:"rows_affected" binding:"omitempty"`
+	Error        string       `json:"error" db:"error" binding:"omitempty"`
+	StartedAt    time.Time    `json:"started_at" db:"started_at" binding:"omitempty"`
+	FinishedAt   sql.NullTime `json:"finished_at" db:"finished_at" binding:"omitempty"`
+	DurationMs   int64        `json:"duration_ms" db:"duration_ms" binding:"omitempty"`
+}
+
+type JobDefinition struct {
+	Name     string `json:"name"`
+	Schedule string `json:"schedule"`
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LedgerAccount is either a wallet account (WalletID set) or a system account
+// such as the SeaLabs Pay clearing account.
+type LedgerAccount struct {
+	ID          uuid.UUID  `json:"id" db:"id" binding:"omitempty"`
+	Code        string     `json:"code" db:"code" binding:"omitempty"`
+	AccountType string     `json:"account_type" db:"account_type" binding:"omitempty"`
+	WalletID    *uuid.UUID `json:"wallet_id" db:"wallet_id" binding:"omitempty"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at" binding:"omitempty"`
+}
+
+type JournalEntry struct {
+	ID            uuid.UUID      `json:"id" db:"id" binding:"omitempty"`
+	TransactionID uuid.NullUUID  `json:"transaction_id" db:"transaction_id" binding:"omitempty"`
+	Description   string         `json:"description" db:"description" binding:"omitempty"`
+	CreatedAt     time.Time      `json:"created_at" db:"created_at" binding:"omitempty"`
+	Lines         []*JournalLine `json:"lines" binding:"omitempty"`
+}
+
+type JournalLine struct {
+	ID             uuid.UUID     `json:"id" db:"id" binding:"omitempty"`
+	JournalEntryID uuid.UUID     `json:"journal_entry_id" db:"journal_entry_id" binding:"omitempty"`
+	Account        LedgerAccount `json:"account" binding:"omitempty"`
+	Debit          Money         `json:"debit" db:"debit" binding:"omitempty"`
+	Credit         Money         `json:"credit" db:"credit" binding:"omitempty"`
+}
+
+// NewTransferEntry moves amount out of from and into to. Wallet accounts hold
+// what the marketplace owes a user, so a credit raises their balance.
+func NewTransferEntry(transactionID uuid.UUID, description string, from, to LedgerAccount, amount Money) *JournalEntry {
+	return &JournalEntry{
+		TransactionID: uuid.NullUUID{UUID: transactionID, Valid: transactionID != uuid.Nil},
+		Description:   description,
+		Lines: []*JournalLine{
+			{Account: from, Debit: amount},
+			{Account: to, Credit: amount},
+		},
+	}
+}
+
+type WalletReconciliation struct {
+	WalletID      uuid.UUID `json:"wallet_id" db:"wallet_id" binding:"omitempty"`
+	UserID        uuid.UUID `json:"user_id" db:"user_id" binding:"omitempty"`
+	StoredBalance Money     `json:"stored_balance" db:"stored_balance" binding:"omitempty"`
+	LedgerBalance Money     `json:"ledger_balance" db:"ledger_balance" binding:"omitempty"`
+}
+
+func (w *WalletReconciliation) Difference() Money {
+	return w.StoredBalance - w.LedgerBalance
+}
@@ -0,0 +1,155 @@
+package model
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// MoneyScale is the number of minor units (sen) in one rupiah.
+const MoneyScale = 100
+
+// Money is an exact rupiah amount in minor units. It is stored as BIGINT and
+// travels over JSON as a rupiah number with at most two decimals, so 1500050
+// is written as 15000.5.
+//
+// Rounding rules:
+//   - rupiah values given as floats or JSON numbers are rounded half away from
+//     zero to the nearest sen.
+//   - percentage discounts are rounded down to a whole rupiah, so a discount
+//     never exceeds its advertised percentage.
+//   - amounts sent to SeaLabs Pay are rounded half up to a whole rupiah.
+type Money int64
+
+func NewMoney(rupiah int64) Money {
+	return Money(rupiah * MoneyScale)
+}
+
+func NewMoneyFromFloat(rupiah float64) Money {
+	return Money(math.Round(rupiah * MoneyScale))
+}
+
+// Rupiah returns the amount rounded half up to a whole rupiah.
+func (m Money) Rupiah() int64 {
+	if m < 0 {
+		return -(-m).Rupiah()
+	}
+
+	return int64((m + MoneyScale/2) / MoneyScale)
+}
+
+func (m Money) Mul(n int) Money {
+	return m * Money(n)
+}
+
+// Percent returns p percent of m rounded down to a whole rupiah.
+func (m Money) Percent(p float64) Money {
+	if m <= 0 || p <= 0 {
+		return 0
+	}
+
+	rat, ok := new(big.Rat).SetString(strconv.FormatFloat(p, 'f', -1, 64))
+	if !ok {
+		return 0
+	}
+
+	rat.Mul(rat, new(big.Rat).SetInt64(int64(m)))
+	rat.Quo(rat, new(big.Rat).SetInt64(100*MoneyScale))
+	whole := new(big.Int).Quo(rat.Num(), rat.Denom())
+
+	return NewMoney(whole.Int64())
+}
+
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign = "-"
+		m = -m
+	}
+
+	whole, sen := int64(m)/MoneyScale, int64(m)%MoneyScale
+	switch {
+	case sen == 0:
+		return fmt.Sprintf("%s%d", sign, whole)
+	case sen%10 == 0:
+		return fmt.Sprintf("%s%d.%d", sign, whole, sen/10)
+	default:
+		return fmt.Sprintf("%s%d.%02d", sign, whole, sen)
+	}
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return fmt.Errorf("money: invalid amount %s", data)
+	}
+
+	*m = moneyFromRat(rat.Mul(rat, new(big.Rat).SetInt64(MoneyScale)))
+
+	return nil
+}
+
+// Scan reads a BIGINT column, or the NUMERIC returned by SUM over one.
+func (m *Money) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+	case int64:
+		*m = Money(v)
+	case float64:
+		*m = Money(math.Round(v))
+	case []byte:
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	default:
+		return fmt.Errorf("money: cannot scan %T", src)
+	}
+
+	return nil
+}
+
+func (m *Money) scanString(value string) error {
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return fmt.Errorf("money: cannot scan %q", value)
+	}
+
+	*m = moneyFromRat(rat)
+
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return int64(m), nil
+}
+
+// moneyFromRat rounds a minor unit amount half away from zero.
+func moneyFromRat(rat *big.Rat) Money {
+	num, denom := new(big.Int).Abs(rat.Num()), rat.Denom()
+	q, r := new(big.Int).QuoRem(num, denom, new(big.Int))
+	if r.Mul(r, big.NewInt(2)).Cmp(denom) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+
+	if rat.Sign() < 0 {
+		q.Neg(q)
+	}
+
+	return Money(q.Int64())
+}
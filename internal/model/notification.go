@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationPreference struct {
+	UserID      uuid.UUID `json:"user_id" db:"user_id"`
+	OrderEmail  bool      `json:"order_email" db:"order_email"`
+	RefundEmail bool      `json:"refund_email" db:"refund_email"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NotificationRecipient is one party of an order, with the preferences that
+// decide whether it is told about the order.
+type NotificationRecipient struct {
+	Email       string
+	Name        string
+	OrderEmail  bool
+	RefundEmail bool
+}
+
+// OrderRecipients are the buyer and the seller of an order.
+type OrderRecipients struct {
+	OrderID  uuid.UUID
+	ShopName string
+	Buyer    NotificationRecipient
+	Seller   NotificationRecipient
+}
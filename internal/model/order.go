@@ -11,8 +11,8 @@ type Order struct {
 	OrderID            string         `json:"order_id"`
 	TransactionID      string         `json:"transaction_id"`
 	OrderStatus        int            `json:"order_status"`
-	TotalPrice         *float64       `json:"total_price"`
-	DeliveryFee        *float64       `json:"delivery_fee"`
+	TotalPrice         *Money         `json:"total_price"`
+	DeliveryFee        *Money         `json:"delivery_fee"`
 	ResiNumber         *string        `json:"resi_no"`
 	ShopID             string         `json:"shop_id"`
 	ShopName           string         `json:"shop_name"`
@@ -45,8 +45,8 @@ type OrderModel struct {
 	CourierID     uuid.UUID    `json:"courier_id" db:"courier_id" binding:"omitempty"`
 	VoucherShopID *uuid.UUID   `json:"voucher_shop_id" db:"courier_id" binding:"omitempty"`
 	OrderStatusID int          `json:"order_status_id" db:"order_status_id" binding:"omitempty"`
-	TotalPrice    float64      `json:"total_price" db:"total_price" binding:"omitempty"`
-	DeliveryFee   float64      `json:"delivery_fee" db:"delivery_fee" binding:"omitempty"`
+	TotalPrice    Money        `json:"total_price" db:"total_price" binding:"omitempty"`
+	DeliveryFee   Money        `json:"delivery_fee" db:"delivery_fee" binding:"omitempty"`
 	ResiNo        *string      `json:"resi_no" db:"resi_no" binding:"omitempty"`
 	BuyerAddress  string       `json:"buyer_address" db:"buyer_address" binding:"omitempty"`
 	ShopAddress   string       `json:"shop_address" db:"shop_address" binding:"omitempty"`
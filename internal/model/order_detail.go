@@ -1,13 +1,15 @@
 package model
 
 type OrderDetail struct {
+	OrderItemID      string            `json:"order_item_id"`
+	IsReview         bool              `json:"is_review"`
 	ProductDetailID  string            `json:"product_detail_id"`
 	ProductID        string            `json:"product_id"`
 	ProductTitle     string            `json:"product_title"`
 	ProductWeight    float64           `json:"product_weight"`
 	ProductDetailURL *string           `json:"product_detail_url"`
 	OrderQuantity    int               `json:"order_quantity"`
-	ItemPrice        *float64          `json:"order_item_price"`
-	TotalPrice       *float64          `json:"order_total_price"`
+	ItemPrice        *Money            `json:"order_item_price"`
+	TotalPrice       *Money            `json:"order_total_price"`
 	Variant          map[string]string `json:"variant"`
 }
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PaymentCallback struct {
+	ID            uuid.UUID    `json:"id" db:"id" binding:"omitempty"`
+	TransactionID uuid.UUID    `json:"transaction_id" db:"transaction_id" binding:"omitempty"`
+	CallbackType  string       `json:"callback_type" db:"callback_type" binding:"omitempty"`
+	Payload       string       `json:"payload" db:"payload" binding:"omitempty"`
+	Signature     string       `json:"signature" db:"signature" binding:"omitempty"`
+	Nonce         string       `json:"nonce" db:"nonce" binding:"omitempty"`
+	SignedAt      sql.NullTime `json:"signed_at" db:"signed_at" binding:"omitempty"`
+	IsAccepted    bool         `json:"is_accepted" db:"is_accepted" binding:"omitempty"`
+	RejectReason  string       `json:"reject_reason" db:"reject_reason" binding:"omitempty"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at" binding:"omitempty"`
+}
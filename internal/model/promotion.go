@@ -12,9 +12,9 @@ type Promotion struct {
 	Name               string       `json:"name" db:"name" binding:"omitempty"`
 	ProductID          uuid.UUID    `json:"product_id" db:"product_id" binding:"omitempty"`
 	DiscountPercentage *float64     `json:"discount_percentage" db:"discount_percentage" binding:"omitempty"`
-	DiscountFixPrice   *float64     `json:"discount_fix_price" db:"discount_fix_price" binding:"omitempty"`
-	MinProductPrice    *float64     `json:"min_product_price" db:"min_product_price" binding:"omitempty"`
-	MaxDiscountPrice   *float64     `json:"max_discount_price" db:"max_discount_price" binding:"omitempty"`
+	DiscountFixPrice   *Money       `json:"discount_fix_price" db:"discount_fix_price" binding:"omitempty"`
+	MinProductPrice    *Money       `json:"min_product_price" db:"min_product_price" binding:"omitempty"`
+	MaxDiscountPrice   *Money       `json:"max_discount_price" db:"max_discount_price" binding:"omitempty"`
 	Quota              int          `json:"quota" db:"quota" binding:"omitempty"`
 	MaxQuantity        int          `json:"max_quantity" db:"max_quantity" binding:"omitempty"`
 	ActivedDate        time.Time    `json:"actived_date" db:"actived_date" binding:"omitempty"`
@@ -23,3 +23,14 @@ type Promotion struct {
 	UpdatedAt          sql.NullTime `json:"updated_at" db:"updated_at" binding:"omitempty"`
 	DeletedAt          sql.NullTime `json:"deleted_at" db:"deleted_at" binding:"omitempty"`
 }
+
+// PromotionClaim is the promotion quota an order took at checkout.
+type PromotionClaim struct {
+	ID            uuid.UUID    `json:"id" db:"id"`
+	PromotionID   uuid.UUID    `json:"promotion_id" db:"promotion_id"`
+	TransactionID uuid.UUID    `json:"transaction_id" db:"transaction_id"`
+	OrderID       uuid.UUID    `json:"order_id" db:"order_id"`
+	Quantity      int          `json:"quantity" db:"quantity"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+	ReleasedAt    sql.NullTime `json:"released_at" db:"released_at"`
+}
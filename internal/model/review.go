@@ -0,0 +1,30 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Review struct {
+	ID        uuid.UUID    `json:"id" db:"id" binding:"omitempty"`
+	UserID    uuid.UUID    `json:"user_id" db:"user_id" binding:"omitempty"`
+	ProductID uuid.UUID    `json:"product_id" db:"product_id" binding:"omitempty"`
+	Comment   *string      `json:"comment" db:"comment" binding:"omitempty"`
+	Rating    int          `json:"rating" db:"rating" binding:"omitempty"`
+	ImageURL  *string      `json:"image_url" db:"image_url" binding:"omitempty"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at" binding:"omitempty"`
+	HiddenAt  sql.NullTime `json:"hidden_at" db:"hidden_at" binding:"omitempty"`
+}
+
+// ReviewModeration is a review in the moderation queue together with the
+// reports about it that are not resolved yet.
+type ReviewModeration struct {
+	Review
+	ProductTitle   string       `json:"product_title"`
+	Username       string       `json:"username"`
+	ReportCount    int          `json:"report_count"`
+	ReportReasons  []string     `json:"report_reasons"`
+	LastReportedAt sql.NullTime `json:"last_reported_at"`
+}
@@ -3,8 +3,16 @@ package model
 import "time"
 
 type GoogleAuthToken struct {
-	Token         *Token
-	RegisterToken *string
+	Token          *Token
+	RegisterToken  *string
+	ChallengeToken *string
+}
+
+// LoginToken holds either the tokens of the new session or, for an account
+// with 2FA, the challenge token to finish signing in with.
+type LoginToken struct {
+	Token          *Token
+	ChallengeToken *string
 }
 
 type Token struct {
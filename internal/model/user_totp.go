@@ -0,0 +1,21 @@
+package model
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// UserTOTP is the authenticator of a user. It only protects the account once
+// ConfirmedAt is set, an unconfirmed one is an enrollment in progress.
+type UserTOTP struct {
+	UserID       uuid.UUID    `json:"user_id" db:"user_id"`
+	Secret       string       `json:"-" db:"secret"`
+	LastUsedStep int64        `json:"-" db:"last_used_step"`
+	ConfirmedAt  sql.NullTime `json:"confirmed_at" db:"confirmed_at"`
+}
+
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
@@ -15,10 +15,21 @@ type Voucher struct {
 	ActivedDate        time.Time    `json:"actived_date" db:"actived_date" binding:"omitempty"`
 	ExpiredDate        time.Time    `json:"expired_date" db:"expired_date" binding:"omitempty"`
 	DiscountPercentage *float64     `json:"discount_percentage" db:"discount_percentage" binding:"omitempty"`
-	DiscountFixPrice   *float64     `json:"discount_fix_price" db:"discount_fix_price" binding:"omitempty"`
-	MinProductPrice    *float64     `json:"min_product_price" db:"min_product_price" binding:"omitempty"`
-	MaxDiscountPrice   *float64     `json:"max_discount_price" db:"max_discount_price" binding:"omitempty"`
+	DiscountFixPrice   *Money       `json:"discount_fix_price" db:"discount_fix_price" binding:"omitempty"`
+	MinProductPrice    *Money       `json:"min_product_price" db:"min_product_price" binding:"omitempty"`
+	MaxDiscountPrice   *Money       `json:"max_discount_price" db:"max_discount_price" binding:"omitempty"`
+	LimitPerUser       int          `json:"limit_per_user" db:"limit_per_user" binding:"omitempty"`
 	CreatedAt          time.Time    `json:"created_at" db:"created_at" binding:"omitempty"`
 	UpdatedAt          sql.NullTime `json:"updated_at" db:"updated_at" binding:"omitempty"`
 	DeletedAt          sql.NullTime `json:"deleted_at" db:"deleted_at" binding:"omitempty"`
 }
+
+type VoucherRedemption struct {
+	ID            uuid.UUID    `json:"id" db:"id"`
+	VoucherID     uuid.UUID    `json:"voucher_id" db:"voucher_id"`
+	UserID        uuid.UUID    `json:"user_id" db:"user_id"`
+	TransactionID uuid.UUID    `json:"transaction_id" db:"transaction_id"`
+	OrderID       *uuid.UUID   `json:"order_id" db:"order_id"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+	ReleasedAt    sql.NullTime `json:"released_at" db:"released_at"`
+}
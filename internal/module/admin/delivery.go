@@ -19,4 +19,7 @@ type Handlers interface {
 	AddBanner(c *gin.Context)
 	DeleteBanner(c *gin.Context)
 	EditBanner(c *gin.Context)
+	GetModerationReviews(c *gin.Context)
+	HideReview(c *gin.Context)
+	RestoreReview(c *gin.Context)
 }
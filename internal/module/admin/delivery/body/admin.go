@@ -7,6 +7,7 @@ const (
 	InvalidEmailFormatMessage    = "Invalid email format."
 	InvalidDateFormatMessage     = "Invalid date format."
 	InvalidOTPFormatMessage      = "Invalid OTP."
+	InvalidLimitPerUserMessage   = "Limit per user cannot be negative."
 	InvalidPasswordFormatMessage = "Password must contain at least 8-40 characters," +
 		"at least 1 number, 1 Upper case, 1 special character, and not contains username"
 	InvalidPasswordSameOldPasswordMessage = "Your new password cannot be the same as your old password."
@@ -17,6 +18,12 @@ const (
 	UpdateProductFailed                   = "Update product failed"
 	ImageIsEmpty                          = "image cannot be empty"
 	CategoryIsBeingUsed                   = "Category is being used"
+	ReviewNotFound                        = "Review not found"
+	ReviewAlreadyHidden                   = "Review is already hidden"
+	ReviewNotHidden                       = "Review is not hidden"
+
+	ReviewStatusReported = "reported"
+	ReviewStatusHidden   = "hidden"
 )
 
 type UnprocessableEntity struct {
@@ -11,6 +11,7 @@ import (
 	"murakali/pkg/logger"
 	"murakali/pkg/pagination"
 	"murakali/pkg/response"
+	"murakali/pkg/session"
 	"net/http"
 	"strconv"
 	"strings"
@@ -106,6 +107,103 @@ func (h *adminHandlers) GetRefunds(c *gin.Context) {
 	response.SuccessResponse(c.Writer, refunds, http.StatusOK)
 }
 
+func (h *adminHandlers) GetModerationReviews(c *gin.Context) {
+	pgn := &pagination.Pagination{}
+	h.ValidateQueryPagination(c, pgn)
+
+	sort := c.DefaultQuery("sort", "")
+	sort = strings.ToLower(sort)
+	var sortFilter string
+	switch sort {
+	case constant.ASC:
+		sortFilter = sort
+	default:
+		sortFilter = constant.DESC
+	}
+
+	status := c.DefaultQuery("status", "")
+	switch status {
+	case body.ReviewStatusHidden:
+		status = body.ReviewStatusHidden
+	default:
+		status = body.ReviewStatusReported
+	}
+
+	sortFilter = `count("rp"."id") DESC, "r"."created_at" ` + sortFilter
+	reviews, err := h.adminUC.GetModerationReviews(c, status, sortFilter, pgn)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerAdmin, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, reviews, http.StatusOK)
+}
+
+func (h *adminHandlers) HideReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	client := session.Client{IP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+	if err := h.adminUC.HideReview(c, reviewID.String(), userID.(string), client); err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerAdmin, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, nil, http.StatusOK)
+}
+
+func (h *adminHandlers) RestoreReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	client := session.Client{IP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+	if err := h.adminUC.RestoreReview(c, reviewID.String(), userID.(string), client); err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerAdmin, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, nil, http.StatusOK)
+}
+
 func (h *adminHandlers) CreateVoucher(c *gin.Context) {
 	var requestBody body.CreateVoucherRequest
 	if err := c.ShouldBind(&requestBody); err != nil {
@@ -165,7 +263,14 @@ func (h *adminHandlers) RefundOrder(c *gin.Context) {
 		return
 	}
 
-	if err := h.adminUC.RefundOrder(c, refundID.String()); err != nil {
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	client := session.Client{IP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+	if err := h.adminUC.RefundOrder(c, refundID.String(), userID.(string), client); err != nil {
 		var e *httperror.Error
 		if !errors.As(err, &e) {
 			h.logger.Errorf("HandlerAdmin, Error: %s", err)
@@ -1594,7 +1594,7 @@ func TestAdminHandlers_RefundOrder(t *testing.T) {
 			name:  "Success Refund Order",
 			param: "8302755e-25c5-4523-8498-7dc8b9e3a098",
 			mock: func(s *mocks.UseCase) {
-				s.On("RefundOrder", mock.Anything, mock.Anything).Return(nil)
+				s.On("RefundOrder", mock.Anything, mock.Anything, "123456", mock.Anything).Return(nil)
 			},
 			expected:   http.StatusOK,
 			authorized: true,
@@ -1610,7 +1610,7 @@ func TestAdminHandlers_RefundOrder(t *testing.T) {
 			name:  "Failed Refund Order",
 			param: "8302755e-25c5-4523-8498-7dc8b9e3a098",
 			mock: func(s *mocks.UseCase) {
-				s.On("RefundOrder", mock.Anything, mock.Anything).Return(fmt.Errorf("error"))
+				s.On("RefundOrder", mock.Anything, mock.Anything, "123456", mock.Anything).Return(fmt.Errorf("error"))
 			},
 			expected:   http.StatusInternalServerError,
 			authorized: true,
@@ -1619,11 +1619,18 @@ func TestAdminHandlers_RefundOrder(t *testing.T) {
 			name:  "Custom Refund Order",
 			param: "8302755e-25c5-4523-8498-7dc8b9e3a098",
 			mock: func(s *mocks.UseCase) {
-				s.On("RefundOrder", mock.Anything, mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
+				s.On("RefundOrder", mock.Anything, mock.Anything, "123456", mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected:   http.StatusBadRequest,
 			authorized: true,
 		},
+		{
+			name:       "Unauthorized Refund Order",
+			param:      "8302755e-25c5-4523-8498-7dc8b9e3a098",
+			mock:       func(s *mocks.UseCase) {},
+			expected:   http.StatusUnauthorized,
+			authorized: false,
+		},
 	}
 
 	for _, tc := range testCase {
@@ -1665,6 +1672,7 @@ func TestAdminHandlers_RefundOrder(t *testing.T) {
 
 			h := NewAdminHandlers(cfg, s, appLogger)
 
+			c.Request = r
 			tc.mock(s)
 			h.RefundOrder(c)
 
@@ -1672,3 +1680,171 @@ func TestAdminHandlers_RefundOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestAdminHandlers_GetModerationReviews(t *testing.T) {
+	testCase := []struct {
+		name     string
+		queries  map[string]string
+		mock     func(s *mocks.UseCase)
+		expected int
+	}{
+		{
+			name: "Success Get Reported Reviews",
+			mock: func(s *mocks.UseCase) {
+				s.On("GetModerationReviews", mock.Anything, body.ReviewStatusReported, mock.Anything, mock.Anything).
+					Return(&pagination.Pagination{}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name: "Success Get Hidden Reviews",
+			queries: map[string]string{
+				"status": "hidden",
+				"sort":   "asc",
+			},
+			mock: func(s *mocks.UseCase) {
+				s.On("GetModerationReviews", mock.Anything, body.ReviewStatusHidden, mock.Anything, mock.Anything).
+					Return(&pagination.Pagination{}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name: "Failed Get Moderation Reviews",
+			mock: func(s *mocks.UseCase) {
+				s.On("GetModerationReviews", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("error"))
+			},
+			expected: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/admin/review", nil)
+			r.Header = make(http.Header)
+
+			c.Request = r
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			if len(tc.queries) > 0 {
+				u := url.Values{}
+				for key, value := range tc.queries {
+					u.Set(key, value)
+				}
+				c.Request.URL.RawQuery = u.Encode()
+			}
+
+			s := mocks.NewUseCase(t)
+
+			cfg := &config.Config{
+				Logger: config.LoggerConfig{
+					Development:       true,
+					DisableCaller:     false,
+					DisableStacktrace: false,
+					Encoding:          "json",
+					Level:             "info",
+				},
+			}
+
+			appLogger := logger.NewAPILogger(cfg)
+			appLogger.InitLogger()
+
+			h := NewAdminHandlers(cfg, s, appLogger)
+
+			tc.mock(s)
+			h.GetModerationReviews(c)
+
+			assert.Equal(t, rr.Code, tc.expected)
+		})
+	}
+}
+
+func TestAdminHandlers_HideReview(t *testing.T) {
+	testCase := []struct {
+		name       string
+		param      string
+		mock       func(s *mocks.UseCase)
+		expected   int
+		authorized bool
+	}{
+		{
+			name:  "Success Hide Review",
+			param: "8302755e-25c5-4523-8498-7dc8b9e3a098",
+			mock: func(s *mocks.UseCase) {
+				s.On("HideReview", mock.Anything, "8302755e-25c5-4523-8498-7dc8b9e3a098", "123456", mock.Anything).Return(nil)
+			},
+			expected:   http.StatusOK,
+			authorized: true,
+		},
+		{
+			name:       "Invalid Review ID",
+			param:      "123",
+			mock:       func(s *mocks.UseCase) {},
+			expected:   http.StatusBadRequest,
+			authorized: true,
+		},
+		{
+			name:  "Custom Hide Review",
+			param: "8302755e-25c5-4523-8498-7dc8b9e3a098",
+			mock: func(s *mocks.UseCase) {
+				s.On("HideReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(httperror.New(http.StatusBadRequest, body.ReviewAlreadyHidden))
+			},
+			expected:   http.StatusBadRequest,
+			authorized: true,
+		},
+		{
+			name:       "Unauthorized Hide Review",
+			param:      "8302755e-25c5-4523-8498-7dc8b9e3a098",
+			mock:       func(s *mocks.UseCase) {},
+			expected:   http.StatusUnauthorized,
+			authorized: false,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+
+			r := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/admin/review/%s/hide", tc.param), nil)
+			r.Header = make(http.Header)
+
+			if tc.authorized {
+				c.Set("userID", "123456")
+			}
+
+			c.Params = []gin.Param{
+				{
+					Key:   "id",
+					Value: tc.param,
+				},
+			}
+
+			s := mocks.NewUseCase(t)
+
+			cfg := &config.Config{
+				Logger: config.LoggerConfig{
+					Development:       true,
+					DisableCaller:     false,
+					DisableStacktrace: false,
+					Encoding:          "json",
+					Level:             "info",
+				},
+			}
+
+			appLogger := logger.NewAPILogger(cfg)
+			appLogger.InitLogger()
+
+			h := NewAdminHandlers(cfg, s, appLogger)
+
+			c.Request = r
+			tc.mock(s)
+			h.HideReview(c)
+
+			assert.Equal(t, rr.Code, tc.expected)
+		})
+	}
+}
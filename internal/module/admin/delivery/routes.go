@@ -1,6 +1,7 @@
 package delivery
 
 import (
+	"murakali/internal/constant"
 	"murakali/internal/middleware"
 	"murakali/internal/module/admin"
 
@@ -10,24 +11,32 @@ import (
 func MapAdminRoutes(adminGroup *gin.RouterGroup, h admin.Handlers, mw *middleware.MWManager) {
 	adminGroup.GET("/banner", h.GetBanner)
 	adminGroup.Use(mw.AuthJWTMiddleware())
-	adminGroup.Use(mw.AdminJWTMiddleware())
-	adminGroup.GET("/voucher", h.GetAllVoucher)
-	adminGroup.POST("/voucher", h.CreateVoucher)
-	adminGroup.PUT("/voucher", h.UpdateVoucher)
-	adminGroup.GET("/voucher/:id", h.GetDetailVoucher)
-	adminGroup.DELETE("/voucher/:id", h.DeleteVoucher)
-
-	adminGroup.GET("/refund", h.GetRefunds)
-	adminGroup.POST("/refund/:id", h.RefundOrder)
-
-	adminGroup.GET("/category", h.GetCategories)
-	adminGroup.POST("/category", h.AddCategory)
-	adminGroup.PUT("/category", h.EditCategory)
-	adminGroup.DELETE("/category/:id", h.DeleteCategory)
-
-	adminGroup.POST("/banner", h.AddBanner)
-	adminGroup.PUT("/banner", h.EditBanner)
-	adminGroup.DELETE("/banner/:id", h.DeleteBanner)
-
-	adminGroup.POST("/picture", h.UploadProductPicture)
+
+	voucher := mw.RequirePermission(constant.PermissionVoucherManage)
+	adminGroup.GET("/voucher", voucher, h.GetAllVoucher)
+	adminGroup.POST("/voucher", voucher, h.CreateVoucher)
+	adminGroup.PUT("/voucher", voucher, h.UpdateVoucher)
+	adminGroup.GET("/voucher/:id", voucher, h.GetDetailVoucher)
+	adminGroup.DELETE("/voucher/:id", voucher, h.DeleteVoucher)
+
+	adminGroup.GET("/refund", mw.RequirePermission(constant.PermissionRefundRead), h.GetRefunds)
+	adminGroup.POST("/refund/:id", mw.RequirePermission(constant.PermissionRefundApprove), h.RefundOrder)
+
+	category := mw.RequirePermission(constant.PermissionCategoryManage)
+	adminGroup.GET("/category", category, h.GetCategories)
+	adminGroup.POST("/category", category, h.AddCategory)
+	adminGroup.PUT("/category", category, h.EditCategory)
+	adminGroup.DELETE("/category/:id", category, h.DeleteCategory)
+
+	banner := mw.RequirePermission(constant.PermissionBannerManage)
+	adminGroup.POST("/banner", banner, h.AddBanner)
+	adminGroup.PUT("/banner", banner, h.EditBanner)
+	adminGroup.DELETE("/banner/:id", banner, h.DeleteBanner)
+
+	adminGroup.POST("/picture", mw.RequirePermission(constant.PermissionMediaUpload), h.UploadProductPicture)
+
+	review := mw.RequirePermission(constant.PermissionReviewModerate)
+	adminGroup.GET("/review", review, h.GetModerationReviews)
+	adminGroup.PATCH("/review/:id/hide", review, h.HideReview)
+	adminGroup.PATCH("/review/:id/restore", review, h.RestoreReview)
 }
@@ -264,6 +264,29 @@ func (_m *Repository) GetCategories(ctx context.Context) ([]*body.CategoryRespon
 	return r0, r1
 }
 
+// GetModerationReviews provides a mock function with given fields: ctx, status, sortFilter, pgn
+func (_m *Repository) GetModerationReviews(ctx context.Context, status string, sortFilter string, pgn *pagination.Pagination) ([]*model.ReviewModeration, error) {
+	ret := _m.Called(ctx, status, sortFilter, pgn)
+
+	var r0 []*model.ReviewModeration
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *pagination.Pagination) []*model.ReviewModeration); ok {
+		r0 = rf(ctx, status, sortFilter, pgn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ReviewModeration)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *pagination.Pagination) error); ok {
+		r1 = rf(ctx, status, sortFilter, pgn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOrderByID provides a mock function with given fields: ctx, orderID
 func (_m *Repository) GetOrderByID(ctx context.Context, orderID string) (*model.OrderModel, error) {
 	ret := _m.Called(ctx, orderID)
@@ -310,29 +333,6 @@ func (_m *Repository) GetOrderItemsByOrderID(ctx context.Context, tx postgre.Tra
 	return r0, r1
 }
 
-// GetProductDetailByID provides a mock function with given fields: ctx, tx, productDetailID
-func (_m *Repository) GetProductDetailByID(ctx context.Context, tx postgre.Transaction, productDetailID string) (*model.ProductDetail, error) {
-	ret := _m.Called(ctx, tx, productDetailID)
-
-	var r0 *model.ProductDetail
-	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) *model.ProductDetail); ok {
-		r0 = rf(ctx, tx, productDetailID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.ProductDetail)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string) error); ok {
-		r1 = rf(ctx, tx, productDetailID)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // GetRefundByID provides a mock function with given fields: ctx, refundID
 func (_m *Repository) GetRefundByID(ctx context.Context, refundID string) (*model.Refund, error) {
 	ret := _m.Called(ctx, refundID)
@@ -379,6 +379,50 @@ func (_m *Repository) GetRefunds(ctx context.Context, sortFilter string, pgn *pa
 	return r0, r1
 }
 
+// GetReviewByID provides a mock function with given fields: ctx, reviewID
+func (_m *Repository) GetReviewByID(ctx context.Context, reviewID string) (*model.Review, error) {
+	ret := _m.Called(ctx, reviewID)
+
+	var r0 *model.Review
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Review); ok {
+		r0 = rf(ctx, reviewID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Review)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reviewID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTotalModerationReviews provides a mock function with given fields: ctx, status
+func (_m *Repository) GetTotalModerationReviews(ctx context.Context, status string) (int64, error) {
+	ret := _m.Called(ctx, status)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, status)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTotalRefunds provides a mock function with given fields: ctx
 func (_m *Repository) GetTotalRefunds(ctx context.Context) (int64, error) {
 	ret := _m.Called(ctx)
@@ -467,6 +511,20 @@ func (_m *Repository) GetWalletByUserID(ctx context.Context, tx postgre.Transact
 	return r0, r1
 }
 
+// IncreaseProductDetailStock provides a mock function with given fields: ctx, tx, productDetailID, quantity
+func (_m *Repository) IncreaseProductDetailStock(ctx context.Context, tx postgre.Transaction, productDetailID string, quantity int) error {
+	ret := _m.Called(ctx, tx, productDetailID, quantity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string, int) error); ok {
+		r0 = rf(ctx, tx, productDetailID, quantity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertWalletHistory provides a mock function with given fields: ctx, tx, walletHistory
 func (_m *Repository) InsertWalletHistory(ctx context.Context, tx postgre.Transaction, walletHistory *model.WalletHistory) error {
 	ret := _m.Called(ctx, tx, walletHistory)
@@ -481,13 +539,13 @@ func (_m *Repository) InsertWalletHistory(ctx context.Context, tx postgre.Transa
 	return r0
 }
 
-// UpdateOrderStatus provides a mock function with given fields: ctx, tx, order
-func (_m *Repository) UpdateOrderStatus(ctx context.Context, tx postgre.Transaction, order *model.OrderModel) error {
-	ret := _m.Called(ctx, tx, order)
+// ResolveReviewReports provides a mock function with given fields: ctx, tx, reviewID
+func (_m *Repository) ResolveReviewReports(ctx context.Context, tx postgre.Transaction, reviewID string) error {
+	ret := _m.Called(ctx, tx, reviewID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.OrderModel) error); ok {
-		r0 = rf(ctx, tx, order)
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) error); ok {
+		r0 = rf(ctx, tx, reviewID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -495,13 +553,13 @@ func (_m *Repository) UpdateOrderStatus(ctx context.Context, tx postgre.Transact
 	return r0
 }
 
-// UpdateProductDetailStock provides a mock function with given fields: ctx, tx, productDetailData
-func (_m *Repository) UpdateProductDetailStock(ctx context.Context, tx postgre.Transaction, productDetailData *model.ProductDetail) error {
-	ret := _m.Called(ctx, tx, productDetailData)
+// UpdateOrderStatus provides a mock function with given fields: ctx, tx, order
+func (_m *Repository) UpdateOrderStatus(ctx context.Context, tx postgre.Transaction, order *model.OrderModel) error {
+	ret := _m.Called(ctx, tx, order)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.ProductDetail) error); ok {
-		r0 = rf(ctx, tx, productDetailData)
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.OrderModel) error); ok {
+		r0 = rf(ctx, tx, order)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -523,13 +581,13 @@ func (_m *Repository) UpdateRefund(ctx context.Context, tx postgre.Transaction,
 	return r0
 }
 
-// UpdateVoucher provides a mock function with given fields: ctx, voucherShop
-func (_m *Repository) UpdateVoucher(ctx context.Context, voucherShop *model.Voucher) error {
-	ret := _m.Called(ctx, voucherShop)
+// UpdateReviewHidden provides a mock function with given fields: ctx, tx, review
+func (_m *Repository) UpdateReviewHidden(ctx context.Context, tx postgre.Transaction, review *model.Review) error {
+	ret := _m.Called(ctx, tx, review)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *model.Voucher) error); ok {
-		r0 = rf(ctx, voucherShop)
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.Review) error); ok {
+		r0 = rf(ctx, tx, review)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -537,13 +595,13 @@ func (_m *Repository) UpdateVoucher(ctx context.Context, voucherShop *model.Vouc
 	return r0
 }
 
-// UpdateWalletBalance provides a mock function with given fields: ctx, tx, wallet
-func (_m *Repository) UpdateWalletBalance(ctx context.Context, tx postgre.Transaction, wallet *model.Wallet) error {
-	ret := _m.Called(ctx, tx, wallet)
+// UpdateVoucher provides a mock function with given fields: ctx, voucherShop
+func (_m *Repository) UpdateVoucher(ctx context.Context, voucherShop *model.Voucher) error {
+	ret := _m.Called(ctx, voucherShop)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.Wallet) error); ok {
-		r0 = rf(ctx, tx, wallet)
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Voucher) error); ok {
+		r0 = rf(ctx, voucherShop)
 	} else {
 		r0 = ret.Error(0)
 	}
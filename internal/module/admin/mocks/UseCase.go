@@ -11,6 +11,8 @@ import (
 	model "murakali/internal/model"
 
 	pagination "murakali/pkg/pagination"
+
+	session "murakali/pkg/session"
 )
 
 // UseCase is an autogenerated mock type for the UseCase type
@@ -222,6 +224,29 @@ func (_m *UseCase) GetDetailVoucher(ctx context.Context, voucherID string) (*mod
 	return r0, r1
 }
 
+// GetModerationReviews provides a mock function with given fields: ctx, status, sortFilter, pgn
+func (_m *UseCase) GetModerationReviews(ctx context.Context, status string, sortFilter string, pgn *pagination.Pagination) (*pagination.Pagination, error) {
+	ret := _m.Called(ctx, status, sortFilter, pgn)
+
+	var r0 *pagination.Pagination
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *pagination.Pagination) *pagination.Pagination); ok {
+		r0 = rf(ctx, status, sortFilter, pgn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pagination.Pagination)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *pagination.Pagination) error); ok {
+		r1 = rf(ctx, status, sortFilter, pgn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRefunds provides a mock function with given fields: ctx, sortFilter, pgn
 func (_m *UseCase) GetRefunds(ctx context.Context, sortFilter string, pgn *pagination.Pagination) (*pagination.Pagination, error) {
 	ret := _m.Called(ctx, sortFilter, pgn)
@@ -245,13 +270,41 @@ func (_m *UseCase) GetRefunds(ctx context.Context, sortFilter string, pgn *pagin
 	return r0, r1
 }
 
-// RefundOrder provides a mock function with given fields: ctx, refundID
-func (_m *UseCase) RefundOrder(ctx context.Context, refundID string) error {
-	ret := _m.Called(ctx, refundID)
+// HideReview provides a mock function with given fields: ctx, reviewID, adminID, client
+func (_m *UseCase) HideReview(ctx context.Context, reviewID string, adminID string, client session.Client) error {
+	ret := _m.Called(ctx, reviewID, adminID, client)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, refundID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, session.Client) error); ok {
+		r0 = rf(ctx, reviewID, adminID, client)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefundOrder provides a mock function with given fields: ctx, refundID, adminID, client
+func (_m *UseCase) RefundOrder(ctx context.Context, refundID string, adminID string, client session.Client) error {
+	ret := _m.Called(ctx, refundID, adminID, client)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, session.Client) error); ok {
+		r0 = rf(ctx, refundID, adminID, client)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RestoreReview provides a mock function with given fields: ctx, reviewID, adminID, client
+func (_m *UseCase) RestoreReview(ctx context.Context, reviewID string, adminID string, client session.Client) error {
+	ret := _m.Called(ctx, reviewID, adminID, client)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, session.Client) error); ok {
+		r0 = rf(ctx, reviewID, adminID, client)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -23,11 +23,9 @@ type Repository interface {
 	UpdateRefund(ctx context.Context, tx postgre.Transaction, refund *model.Refund) error
 	UpdateOrderStatus(ctx context.Context, tx postgre.Transaction, order *model.OrderModel) error
 	GetOrderItemsByOrderID(ctx context.Context, tx postgre.Transaction, orderID string) ([]*model.OrderItem, error)
-	GetProductDetailByID(ctx context.Context, tx postgre.Transaction, productDetailID string) (*model.ProductDetail, error)
-	UpdateProductDetailStock(ctx context.Context, tx postgre.Transaction, productDetailData *model.ProductDetail) error
+	IncreaseProductDetailStock(ctx context.Context, tx postgre.Transaction, productDetailID string, quantity int) error
 	GetWalletByUserID(ctx context.Context, tx postgre.Transaction, userID string) (*model.Wallet, error)
 	InsertWalletHistory(ctx context.Context, tx postgre.Transaction, walletHistory *model.WalletHistory) error
-	UpdateWalletBalance(ctx context.Context, tx postgre.Transaction, wallet *model.Wallet) error
 	GetCategories(ctx context.Context) ([]*body.CategoryResponse, error)
 	AddCategory(ctx context.Context, requestBody body.CategoryRequest) error
 	DeleteCategory(ctx context.Context, categoryID string) error
@@ -38,4 +36,9 @@ type Repository interface {
 	AddBanner(ctx context.Context, requestBody body.BannerRequest) error
 	DeleteBanner(ctx context.Context, bannerID string) error
 	EditBanner(ctx context.Context, requestBody body.BannerIDRequest) error
+	GetTotalModerationReviews(ctx context.Context, status string) (int64, error)
+	GetModerationReviews(ctx context.Context, status, sortFilter string, pgn *pagination.Pagination) ([]*model.ReviewModeration, error)
+	GetReviewByID(ctx context.Context, reviewID string) (*model.Review, error)
+	UpdateReviewHidden(ctx context.Context, tx postgre.Transaction, review *model.Review) error
+	ResolveReviewReports(ctx context.Context, tx postgre.Transaction, reviewID string) error
 }
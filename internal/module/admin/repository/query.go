@@ -17,10 +17,29 @@ const (
 	INNER JOIN "order" as "o" on "r"."order_id" = "o"."id"
 	WHERE "accepted_at" IS NOT NULL AND "rejected_at" IS NULL AND "refunded_at" IS NULL`
 
+	GetTotalModerationReviewsQuery = `SELECT count(r.id) FROM "review" as "r" WHERE "r"."deleted_at" IS NULL`
+	GetModerationReviewsQuery      = `SELECT
+	"r"."id", "r"."user_id", "r"."product_id", "r"."comment", "r"."rating", "r"."image_url", "r"."created_at", "r"."hidden_at",
+	"p"."title", "u"."username", count("rp"."id"),
+	COALESCE(array_agg("rp"."reason") FILTER (WHERE "rp"."id" IS NOT NULL), '{}'), max("rp"."created_at")
+	FROM "review" as "r"
+	INNER JOIN "product" as "p" on "p"."id" = "r"."product_id"
+	INNER JOIN "user" as "u" on "u"."id" = "r"."user_id"
+	LEFT JOIN "review_report" as "rp" on "rp"."review_id" = "r"."id" AND "rp"."resolved_at" IS NULL
+	WHERE "r"."deleted_at" IS NULL`
+	FilterReviewReported = ` AND EXISTS (SELECT 1 FROM "review_report" WHERE "review_id" = "r"."id" AND "resolved_at" IS NULL)`
+	FilterReviewHidden   = ` AND "r"."hidden_at" IS NOT NULL`
+	GroupByReview        = ` GROUP BY "r"."id", "p"."id", "u"."id"`
+
+	GetReviewByIDQuery = `SELECT "id", "user_id", "product_id", "comment", "rating", "image_url", "created_at", "hidden_at"
+	FROM "review" WHERE "id" = $1 AND "deleted_at" IS NULL`
+	UpdateReviewHiddenQuery   = `UPDATE "review" SET "hidden_at" = $1, "moderated_at" = now() WHERE "id" = $2`
+	ResolveReviewReportsQuery = `UPDATE "review_report" SET "resolved_at" = now() WHERE "review_id" = $1 AND "resolved_at" IS NULL`
+
 	GetAllVoucherQuery = `
 	SELECT "v"."id", "v"."code", "v"."quota", "v"."actived_date", "v"."expired_date",
 		"v"."discount_percentage", "v"."discount_fix_price", "v"."min_product_price", "v"."max_discount_price",
-		"v"."created_at", "v"."updated_at",  "v"."deleted_at"
+		"v"."limit_per_user", "v"."created_at", "v"."updated_at",  "v"."deleted_at"
 	FROM "voucher" as "v"
 	WHERE "v"."shop_id"  IS NULL 
 	AND "v"."deleted_at" IS NULL
@@ -39,15 +58,16 @@ const (
 	 AND (now() > "v"."actived_date" AND  now() > "v"."expired_date")  `
 
 	CreateVoucherQuery = `INSERT INTO "voucher" 
-    	( code, quota, actived_date, expired_date, discount_percentage, discount_fix_price, min_product_price, max_discount_price)
-    	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    	( code, quota, actived_date, expired_date, discount_percentage, discount_fix_price, min_product_price, max_discount_price,
+    	limit_per_user)
+    	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 	DeleteVoucherQuery = `UPDATE "voucher" set deleted_at = now() WHERE "id" = $1 AND "shop_id"  IS NULL  AND "deleted_at" IS NULL`
 
 	GetVoucherByID = `
 	SELECT "v"."id",  "v"."code", "v"."quota", "v"."actived_date", "v"."expired_date",
 		"v"."discount_percentage", "v"."discount_fix_price", "v"."min_product_price", "v"."max_discount_price",
-		"v"."created_at", "v"."updated_at",  "v"."deleted_at"
+		"v"."limit_per_user", "v"."created_at", "v"."updated_at",  "v"."deleted_at"
 	FROM "voucher" as "v"
 	WHERE "v"."id"  = $1 AND "v"."shop_id" IS NULL  AND "v"."deleted_at" IS NULL
 	`
@@ -58,20 +78,18 @@ const (
 
 	UpdateVoucherQuery = `
 		UPDATE "voucher" SET "quota" = $1, "actived_date" = $2, "expired_date" = $3, "discount_percentage" = $4,
-			"discount_fix_price" = $5, "min_product_price" = $6, "max_discount_price" = $7, "updated_at" = now()
-		WHERE "id" = $8
+			"discount_fix_price" = $5, "min_product_price" = $6, "max_discount_price" = $7, "limit_per_user" = $8, "updated_at" = now()
+		WHERE "id" = $9
 	`
 
-	CreateWalletHistoryQuery      = `INSERT INTO "wallet_history" (transaction_id, wallet_id, "from", "to", description, amount, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`
-	UpdateRefundQuery             = `UPDATE "refund" SET "refunded_at" = $1 WHERE "id" = $2`
-	UpdateOrderByID               = `UPDATE "order" SET "order_status_id" = $1 WHERE "id" = $2`
-	UpdateProductDetailStockQuery = `UPDATE "product_detail" SET "stock" = $1, "updated_at" = now() WHERE "id" = $2;`
-	UpdateWalletBalanceQuery      = `UPDATE "wallet" SET "balance" = $1, "updated_at" = $2 WHERE "id" = $3`
+	CreateWalletHistoryQuery        = `INSERT INTO "wallet_history" (transaction_id, wallet_id, "from", "to", description, amount, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	UpdateRefundQuery               = `UPDATE "refund" SET "refunded_at" = $1 WHERE "id" = $2`
+	UpdateOrderByID                 = `UPDATE "order" SET "order_status_id" = $1 WHERE "id" = $2`
+	IncreaseProductDetailStockQuery = `UPDATE "product_detail" SET "stock" = "stock" + $1, "updated_at" = now() WHERE "id" = $2;`
 
 	GetOrderByOrderIDQuery      = `SELECT o.id,o.order_status_id, o.user_id, o.transaction_id,o.total_price,o.delivery_fee,o.resi_no,o.created_at from "order" o WHERE o.id = $1`
 	GetOrderItemsByOrderIDQuery = `SELECT "id", "order_id", "product_detail_id", "quantity", "item_price", "total_price" FROM "order_item" WHERE "order_id" = $1`
-	GetProductDetailByIDQuery   = `SELECT "id", "price", "stock", "weight", "size", "hazardous", "condition", "bulk_price" FROM "product_detail" WHERE "id" = $1 AND "deleted_at" IS NULL;`
-	GetWalletByUserIDQuery      = `SELECT "id", "user_id", "balance", "pin", "attempt_count", "attempt_at", "unlocked_at", "active_date" FROM "wallet" WHERE "user_id" = $1 AND "deleted_at" IS NULL`
+	GetWalletByUserIDQuery      = `SELECT "id", "user_id", "balance", "pin", "active_date" FROM "wallet" WHERE "user_id" = $1 AND "deleted_at" IS NULL`
 
 	GetCategoriesQuery = `WITH RECURSIVE ctgry AS (
 		SELECT id, parent_id, name, photo_url, created_at, updated_at, deleted_at, 1 as level
@@ -11,6 +11,7 @@ import (
 	"murakali/pkg/postgre"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/lib/pq"
 )
 
 type adminRepo struct {
@@ -104,6 +105,7 @@ func (r *adminRepo) GetAllVoucher(ctx context.Context, voucherStatusID, sortFilt
 			&voucher.DiscountFixPrice,
 			&voucher.MinProductPrice,
 			&voucher.MaxDiscountPrice,
+			&voucher.LimitPerUser,
 			&voucher.CreatedAt,
 			&voucher.UpdatedAt,
 			&voucher.DeletedAt,
@@ -168,6 +170,95 @@ func (r *adminRepo) GetRefunds(ctx context.Context, sortFilter string, pgn *pagi
 	return refunds, nil
 }
 
+func reviewStatusFilter(status string) string {
+	if status == body.ReviewStatusHidden {
+		return FilterReviewHidden
+	}
+
+	return FilterReviewReported
+}
+
+func (r *adminRepo) GetTotalModerationReviews(ctx context.Context, status string) (int64, error) {
+	var total int64
+	if err := r.PSQL.QueryRowContext(ctx, GetTotalModerationReviewsQuery+reviewStatusFilter(status)).Scan(&total); err != nil {
+		return -1, err
+	}
+
+	return total, nil
+}
+
+func (r *adminRepo) GetModerationReviews(ctx context.Context, status, sortFilter string,
+	pgn *pagination.Pagination) ([]*model.ReviewModeration, error) {
+	reviews := make([]*model.ReviewModeration, 0)
+
+	queryOrderBySomething := fmt.Sprintf(OrderBySomething, sortFilter, pgn.GetLimit(), pgn.GetOffset())
+	res, err := r.PSQL.QueryContext(ctx, GetModerationReviewsQuery+reviewStatusFilter(status)+GroupByReview+queryOrderBySomething)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		review := model.ReviewModeration{}
+		if errScan := res.Scan(
+			&review.ID,
+			&review.UserID,
+			&review.ProductID,
+			&review.Comment,
+			&review.Rating,
+			&review.ImageURL,
+			&review.CreatedAt,
+			&review.HiddenAt,
+			&review.ProductTitle,
+			&review.Username,
+			&review.ReportCount,
+			(*pq.StringArray)(&review.ReportReasons),
+			&review.LastReportedAt); errScan != nil {
+			return nil, errScan
+		}
+
+		reviews = append(reviews, &review)
+	}
+	if res.Err() != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r *adminRepo) GetReviewByID(ctx context.Context, reviewID string) (*model.Review, error) {
+	var review model.Review
+	if err := r.PSQL.QueryRowContext(ctx, GetReviewByIDQuery, reviewID).Scan(
+		&review.ID,
+		&review.UserID,
+		&review.ProductID,
+		&review.Comment,
+		&review.Rating,
+		&review.ImageURL,
+		&review.CreatedAt,
+		&review.HiddenAt); err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+func (r *adminRepo) UpdateReviewHidden(ctx context.Context, tx postgre.Transaction, review *model.Review) error {
+	if _, err := tx.ExecContext(ctx, UpdateReviewHiddenQuery, review.HiddenAt, review.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *adminRepo) ResolveReviewReports(ctx context.Context, tx postgre.Transaction, reviewID string) error {
+	if _, err := tx.ExecContext(ctx, ResolveReviewReportsQuery, reviewID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *adminRepo) GetVoucherByID(ctx context.Context, voucherID string) (*model.Voucher, error) {
 	var voucher model.Voucher
 	if err := r.PSQL.QueryRowContext(ctx, GetVoucherByID, voucherID).Scan(
@@ -180,6 +271,7 @@ func (r *adminRepo) GetVoucherByID(ctx context.Context, voucherID string) (*mode
 		&voucher.DiscountFixPrice,
 		&voucher.MinProductPrice,
 		&voucher.MaxDiscountPrice,
+		&voucher.LimitPerUser,
 		&voucher.CreatedAt,
 		&voucher.UpdatedAt,
 		&voucher.DeletedAt,
@@ -234,7 +326,8 @@ func (r *adminRepo) CreateVoucher(ctx context.Context, voucherShop *model.Vouche
 		voucherShop.DiscountPercentage,
 		voucherShop.DiscountFixPrice,
 		voucherShop.MinProductPrice,
-		voucherShop.MaxDiscountPrice); err != nil {
+		voucherShop.MaxDiscountPrice,
+		voucherShop.LimitPerUser); err != nil {
 		return err
 	}
 	return nil
@@ -249,6 +342,7 @@ func (r *adminRepo) UpdateVoucher(ctx context.Context, voucherShop *model.Vouche
 		voucherShop.DiscountFixPrice,
 		voucherShop.MinProductPrice,
 		voucherShop.MaxDiscountPrice,
+		voucherShop.LimitPerUser,
 		voucherShop.ID); err != nil {
 		return err
 	}
@@ -302,28 +396,10 @@ func (r *adminRepo) GetOrderItemsByOrderID(ctx context.Context, tx postgre.Trans
 	return orderItems, nil
 }
 
-func (r *adminRepo) GetProductDetailByID(ctx context.Context, tx postgre.Transaction, productDetailID string) (*model.ProductDetail, error) {
-	var pd model.ProductDetail
-	if err := tx.QueryRowContext(ctx, GetProductDetailByIDQuery, productDetailID).Scan(
-		&pd.ID,
-		&pd.Price,
-		&pd.Stock,
-		&pd.Size,
-		&pd.Weight,
-		&pd.Hazardous,
-		&pd.Condition,
-		&pd.BulkPrice); err != nil {
-		return nil, err
-	}
-
-	return &pd, nil
-}
-
 func (r *adminRepo) GetWalletByUserID(ctx context.Context, tx postgre.Transaction, userID string) (*model.Wallet, error) {
 	var walletModel model.Wallet
 	if err := tx.QueryRowContext(ctx, GetWalletByUserIDQuery, userID).Scan(&walletModel.ID, &walletModel.UserID,
-		&walletModel.Balance, &walletModel.PIN, &walletModel.AttemptCount,
-		&walletModel.AttemptAt, &walletModel.UnlockedAt, &walletModel.ActiveDate); err != nil {
+		&walletModel.Balance, &walletModel.PIN, &walletModel.ActiveDate); err != nil {
 		return nil, err
 	}
 
@@ -339,18 +415,8 @@ func (r *adminRepo) InsertWalletHistory(ctx context.Context, tx postgre.Transact
 	return nil
 }
 
-func (r *adminRepo) UpdateWalletBalance(ctx context.Context, tx postgre.Transaction, wallet *model.Wallet) error {
-	_, err := tx.ExecContext(ctx, UpdateWalletBalanceQuery, wallet.Balance, wallet.UpdatedAt, wallet.ID)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (r *adminRepo) UpdateProductDetailStock(ctx context.Context, tx postgre.Transaction,
-	productDetailData *model.ProductDetail) error {
-	_, err := tx.ExecContext(ctx, UpdateProductDetailStockQuery, productDetailData.Stock, productDetailData.ID)
+func (r *adminRepo) IncreaseProductDetailStock(ctx context.Context, tx postgre.Transaction, productDetailID string, quantity int) error {
+	_, err := tx.ExecContext(ctx, IncreaseProductDetailStockQuery, quantity, productDetailID)
 	if err != nil {
 		return err
 	}
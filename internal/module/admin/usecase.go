@@ -5,6 +5,7 @@ import (
 	"murakali/internal/model"
 	"murakali/internal/module/admin/delivery/body"
 	"murakali/pkg/pagination"
+	"murakali/pkg/session"
 )
 
 type UseCase interface {
@@ -14,7 +15,7 @@ type UseCase interface {
 	GetDetailVoucher(ctx context.Context, voucherID string) (*model.Voucher, error)
 	DeleteVoucher(ctx context.Context, voucherID string) error
 	GetRefunds(ctx context.Context, sortFilter string, pgn *pagination.Pagination) (*pagination.Pagination, error)
-	RefundOrder(ctx context.Context, refundID string) error
+	RefundOrder(ctx context.Context, refundID, adminID string, client session.Client) error
 	GetCategories(ctx context.Context) ([]*body.CategoryResponse, error)
 	AddCategory(ctx context.Context, requestBody body.CategoryRequest) error
 	DeleteCategory(ctx context.Context, categoryID string) error
@@ -23,4 +24,7 @@ type UseCase interface {
 	AddBanner(ctx context.Context, requestBody body.BannerRequest) error
 	DeleteBanner(ctx context.Context, bannerID string) error
 	EditBanner(ctx context.Context, requestBody body.BannerIDRequest) error
+	GetModerationReviews(ctx context.Context, status, sortFilter string, pgn *pagination.Pagination) (*pagination.Pagination, error)
+	HideReview(ctx context.Context, reviewID, adminID string, client session.Client) error
+	RestoreReview(ctx context.Context, reviewID, adminID string, client session.Client) error
 }
@@ -9,10 +9,14 @@ import (
 	"murakali/internal/model"
 	"murakali/internal/module/admin"
 	"murakali/internal/module/admin/delivery/body"
+	"murakali/internal/module/audit"
+	"murakali/internal/module/ledger"
+	"murakali/internal/module/notification"
 	"murakali/pkg/httperror"
 	"murakali/pkg/pagination"
 	"murakali/pkg/postgre"
 	"murakali/pkg/response"
+	"murakali/pkg/session"
 	"net/http"
 	"time"
 )
@@ -21,10 +25,14 @@ type adminUC struct {
 	cfg       *config.Config
 	txRepo    *postgre.TxRepo
 	adminRepo admin.Repository
+	ledger    ledger.UseCase
+	audit     audit.UseCase
+	notify    notification.UseCase
 }
 
-func NewAdminUseCase(cfg *config.Config, txRepo *postgre.TxRepo, adminRepo admin.Repository) admin.UseCase {
-	return &adminUC{cfg: cfg, txRepo: txRepo, adminRepo: adminRepo}
+func NewAdminUseCase(cfg *config.Config, txRepo *postgre.TxRepo, adminRepo admin.Repository, ledgerUC ledger.UseCase,
+	auditUC audit.UseCase, notificationUC notification.UseCase) admin.UseCase {
+	return &adminUC{cfg: cfg, txRepo: txRepo, adminRepo: adminRepo, ledger: ledgerUC, audit: auditUC, notify: notificationUC}
 }
 
 func (u *adminUC) GetAllVoucher(ctx context.Context, voucherStatusID, sortFilter string, pgn *pagination.Pagination) (*pagination.Pagination, error) {
@@ -66,7 +74,7 @@ func (u *adminUC) GetRefunds(ctx context.Context, sortFilter string, pgn *pagina
 	return pgn, nil
 }
 
-func (u *adminUC) RefundOrder(ctx context.Context, refundID string) error {
+func (u *adminUC) RefundOrder(ctx context.Context, refundID, adminID string, client session.Client) error {
 	refund, err := u.adminRepo.GetRefundByID(ctx, refundID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -89,6 +97,7 @@ func (u *adminUC) RefundOrder(ctx context.Context, refundID string) error {
 		return err
 	}
 
+	previousStatus := order.OrderStatusID
 	errTx := u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
 		refund.RefundedAt.Valid = true
 		refund.RefundedAt.Time = time.Now()
@@ -102,19 +111,17 @@ func (u *adminUC) RefundOrder(ctx context.Context, refundID string) error {
 			return errStatus
 		}
 
+		if errNotify := u.notify.NotifyOrderStatus(ctx, tx, order.ID.String(), order.OrderStatusID); errNotify != nil {
+			return errNotify
+		}
+
 		orderItems, err := u.adminRepo.GetOrderItemsByOrderID(ctx, tx, order.ID.String())
 		if err != nil {
 			return err
 		}
 
 		for _, item := range orderItems {
-			productDetailData, errData := u.adminRepo.GetProductDetailByID(ctx, tx, item.ProductDetailID.String())
-			if errData != nil {
-				return errData
-			}
-
-			productDetailData.Stock += float64(item.Quantity)
-			errProduct := u.adminRepo.UpdateProductDetailStock(ctx, tx, productDetailData)
+			errProduct := u.adminRepo.IncreaseProductDetailStock(ctx, tx, item.ProductDetailID.String(), item.Quantity)
 			if errProduct != nil {
 				return errProduct
 			}
@@ -132,25 +139,11 @@ func (u *adminUC) RefundOrder(ctx context.Context, refundID string) error {
 			return err
 		}
 
-		walletMarketplace.Balance -= totalReduce
-		walletMarketplace.UpdatedAt.Valid = true
-		walletMarketplace.UpdatedAt.Time = time.Now()
-		if errWallet := u.adminRepo.UpdateWalletBalance(ctx, tx, walletMarketplace); errWallet != nil {
-			return errWallet
-		}
-
 		walletUser, err := u.adminRepo.GetWalletByUserID(ctx, tx, order.UserID.String())
 		if err != nil {
 			return err
 		}
 
-		walletUser.Balance += totalReduce
-		walletUser.UpdatedAt.Valid = true
-		walletUser.UpdatedAt.Time = time.Now()
-		if err := u.adminRepo.UpdateWalletBalance(ctx, tx, walletUser); err != nil {
-			return err
-		}
-
 		walletMarketplaceHistory := &model.WalletHistory{
 			TransactionID: order.TransactionID,
 			WalletID:      walletMarketplace.ID,
@@ -177,7 +170,21 @@ func (u *adminUC) RefundOrder(ctx context.Context, refundID string) error {
 			return err
 		}
 
-		return nil
+		entry := model.NewTransferEntry(order.TransactionID, walletUserHistory.Description,
+			model.LedgerAccount{AccountType: constant.LedgerAccountEscrow},
+			model.LedgerAccount{AccountType: constant.LedgerAccountBuyerWallet, WalletID: &walletUser.ID},
+			totalReduce)
+
+		if err := u.ledger.PostEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+
+		return u.audit.Record(ctx, tx, audit.NewEvent(adminID, client, constant.AuditActionRefundApprove,
+			constant.AuditTargetRefund, refundID, model.AuditDiff{
+				"refunded_at":     {From: nil, To: refund.RefundedAt.Time},
+				"order_status_id": {From: previousStatus, To: order.OrderStatusID},
+				"amount":          {From: nil, To: totalReduce},
+			}))
 	})
 	if errTx != nil {
 		return errTx
@@ -186,6 +193,86 @@ func (u *adminUC) RefundOrder(ctx context.Context, refundID string) error {
 	return nil
 }
 
+func (u *adminUC) GetModerationReviews(ctx context.Context, status, sortFilter string,
+	pgn *pagination.Pagination) (*pagination.Pagination, error) {
+	totalRows, err := u.adminRepo.GetTotalModerationReviews(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(math.Ceil(float64(totalRows) / float64(pgn.Limit)))
+	pgn.TotalRows = totalRows
+	pgn.TotalPages = totalPages
+
+	reviews, err := u.adminRepo.GetModerationReviews(ctx, status, sortFilter, pgn)
+	if err != nil {
+		return nil, err
+	}
+
+	pgn.Rows = reviews
+	return pgn, nil
+}
+
+// HideReview takes a review out of the product page and its rating, and
+// resolves the reports about it.
+func (u *adminUC) HideReview(ctx context.Context, reviewID, adminID string, client session.Client) error {
+	return u.moderateReview(ctx, reviewID, adminID, client, true)
+}
+
+// RestoreReview shows a hidden review again. Reports about it made while it
+// was hidden are resolved as well.
+func (u *adminUC) RestoreReview(ctx context.Context, reviewID, adminID string, client session.Client) error {
+	return u.moderateReview(ctx, reviewID, adminID, client, false)
+}
+
+func (u *adminUC) moderateReview(ctx context.Context, reviewID, adminID string, client session.Client, hide bool) error {
+	review, err := u.adminRepo.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return httperror.New(http.StatusBadRequest, body.ReviewNotFound)
+		}
+
+		return err
+	}
+
+	if hide && review.HiddenAt.Valid {
+		return httperror.New(http.StatusBadRequest, body.ReviewAlreadyHidden)
+	}
+
+	if !hide && !review.HiddenAt.Valid {
+		return httperror.New(http.StatusBadRequest, body.ReviewNotHidden)
+	}
+
+	action := constant.AuditActionReviewRestore
+	previousHiddenAt := review.HiddenAt
+	review.HiddenAt = sql.NullTime{}
+	if hide {
+		action = constant.AuditActionReviewHide
+		review.HiddenAt = sql.NullTime{Time: time.Now(), Valid: true}
+	}
+
+	return u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		if err := u.adminRepo.UpdateReviewHidden(ctx, tx, review); err != nil {
+			return err
+		}
+
+		if err := u.adminRepo.ResolveReviewReports(ctx, tx, reviewID); err != nil {
+			return err
+		}
+
+		return u.audit.Record(ctx, tx, audit.NewEvent(adminID, client, action, constant.AuditTargetReview, reviewID,
+			model.AuditDiff{"hidden_at": {From: nullTime(previousHiddenAt), To: nullTime(review.HiddenAt)}}))
+	})
+}
+
+func nullTime(t sql.NullTime) interface{} {
+	if !t.Valid {
+		return nil
+	}
+
+	return t.Time
+}
+
 func (u *adminUC) CreateVoucher(ctx context.Context, requestBody body.CreateVoucherRequest) error {
 	count, _ := u.adminRepo.CountCodeVoucher(ctx, requestBody.Code)
 	if count > 0 {
@@ -201,6 +288,7 @@ func (u *adminUC) CreateVoucher(ctx context.Context, requestBody body.CreateVouc
 		DiscountFixPrice:   &requestBody.DiscountFixPrice,
 		MinProductPrice:    &requestBody.MinProductPrice,
 		MaxDiscountPrice:   &requestBody.MaxDiscountPrice,
+		LimitPerUser:       requestBody.LimitPerUser,
 	}
 
 	err := u.adminRepo.CreateVoucher(ctx, voucherShop)
@@ -228,6 +316,7 @@ func (u *adminUC) UpdateVoucher(ctx context.Context, requestBody body.UpdateVouc
 	voucherShop.DiscountFixPrice = &requestBody.DiscountFixPrice
 	voucherShop.MinProductPrice = &requestBody.MinProductPrice
 	voucherShop.MaxDiscountPrice = &requestBody.MaxDiscountPrice
+	voucherShop.LimitPerUser = requestBody.LimitPerUser
 
 	err := u.adminRepo.UpdateVoucher(ctx, voucherShop)
 	if err != nil {
@@ -5,13 +5,18 @@ import (
 	"database/sql"
 	"fmt"
 	"murakali/config"
+	"murakali/internal/constant"
 	"murakali/internal/model"
 	"murakali/internal/module/admin/delivery/body"
 	"murakali/internal/module/admin/mocks"
+	auditMocks "murakali/internal/module/audit/mocks"
+	ledgerMocks "murakali/internal/module/ledger/mocks"
+	notificationMocks "murakali/internal/module/notification/mocks"
 	"murakali/pkg/httperror"
 	"murakali/pkg/pagination"
 	"murakali/pkg/postgre"
 	"murakali/pkg/response"
+	"murakali/pkg/session"
 	"net/http"
 	"testing"
 	"time"
@@ -59,7 +64,7 @@ func TestAdminUC_GetAllVoucher(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetAllVoucher(context.Background(), "123", "123", &pagination.Pagination{})
@@ -107,7 +112,7 @@ func TestAdminUC_GetRefunds(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetRefunds(context.Background(), "123", &pagination.Pagination{})
@@ -120,6 +125,7 @@ func TestAdminUC_GetRefunds(t *testing.T) {
 
 func TestAdminUC_CreateVoucher(t *testing.T) {
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	datestring := "02-01-2006 15:04:05"
 	date, _ := time.Parse("02-01-2006 15:04:05", datestring)
 	testCase := []struct {
@@ -137,9 +143,9 @@ func TestAdminUC_CreateVoucher(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("CountCodeVoucher", mock.Anything, mock.Anything).Return(int64(0), nil)
@@ -155,9 +161,9 @@ func TestAdminUC_CreateVoucher(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("CountCodeVoucher", mock.Anything, mock.Anything).Return(int64(1), nil)
@@ -172,9 +178,9 @@ func TestAdminUC_CreateVoucher(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("CountCodeVoucher", mock.Anything, mock.Anything).Return(int64(0), nil)
@@ -189,7 +195,7 @@ func TestAdminUC_CreateVoucher(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			err := u.CreateVoucher(context.Background(), body.CreateVoucherRequest{
@@ -198,9 +204,9 @@ func TestAdminUC_CreateVoucher(t *testing.T) {
 				ActivedDate:        "02-01-2006 15:04:05",
 				ExpiredDate:        "02-01-2006 15:04:05",
 				DiscountPercentage: temp,
-				DiscountFixPrice:   temp,
-				MinProductPrice:    temp,
-				MaxDiscountPrice:   temp,
+				DiscountFixPrice:   tempMoney,
+				MinProductPrice:    tempMoney,
+				MaxDiscountPrice:   tempMoney,
 			})
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
@@ -211,6 +217,7 @@ func TestAdminUC_CreateVoucher(t *testing.T) {
 
 func TestAdminUC_UpdateVoucher(t *testing.T) {
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	datestring := "02-01-2006 15:04:05"
 	date, _ := time.Parse("02-01-2006 15:04:05", datestring)
 	testCase := []struct {
@@ -228,9 +235,9 @@ func TestAdminUC_UpdateVoucher(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetVoucherByID", mock.Anything, mock.Anything).Return(&model.Voucher{}, nil)
@@ -246,9 +253,9 @@ func TestAdminUC_UpdateVoucher(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetVoucherByID", mock.Anything, mock.Anything).Return(nil, httperror.New(http.StatusBadRequest, body.VoucherSellerNotFoundMessage))
@@ -263,9 +270,9 @@ func TestAdminUC_UpdateVoucher(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetVoucherByID", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
@@ -280,9 +287,9 @@ func TestAdminUC_UpdateVoucher(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetVoucherByID", mock.Anything, mock.Anything).Return(&model.Voucher{}, nil)
@@ -297,7 +304,7 @@ func TestAdminUC_UpdateVoucher(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			err := u.UpdateVoucher(context.Background(), body.UpdateVoucherRequest{
@@ -306,9 +313,9 @@ func TestAdminUC_UpdateVoucher(t *testing.T) {
 				ActivedDate:        "02-01-2006 15:04:05",
 				ExpiredDate:        "02-01-2006 15:04:05",
 				DiscountPercentage: temp,
-				DiscountFixPrice:   temp,
-				MinProductPrice:    temp,
-				MaxDiscountPrice:   temp,
+				DiscountFixPrice:   tempMoney,
+				MinProductPrice:    tempMoney,
+				MaxDiscountPrice:   tempMoney,
 			})
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
@@ -319,6 +326,7 @@ func TestAdminUC_UpdateVoucher(t *testing.T) {
 
 func TestAdminUC_GetDetailVoucher(t *testing.T) {
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	datestring := "02-01-2006 15:04:05"
 	date, _ := time.Parse("02-01-2006 15:04:05", datestring)
 	testCase := []struct {
@@ -336,9 +344,9 @@ func TestAdminUC_GetDetailVoucher(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetVoucherByID", mock.Anything, mock.Anything).Return(&model.Voucher{}, nil)
@@ -353,9 +361,9 @@ func TestAdminUC_GetDetailVoucher(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetVoucherByID", mock.Anything, mock.Anything).Return(nil, httperror.New(http.StatusBadRequest, body.VoucherSellerNotFoundMessage))
@@ -370,9 +378,9 @@ func TestAdminUC_GetDetailVoucher(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetVoucherByID", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
@@ -386,7 +394,7 @@ func TestAdminUC_GetDetailVoucher(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetDetailVoucher(context.Background(), "123")
@@ -399,6 +407,7 @@ func TestAdminUC_GetDetailVoucher(t *testing.T) {
 
 func TestAdminUC_DeleteVoucher(t *testing.T) {
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	datestring := "02-01-2006 15:04:05"
 	date, _ := time.Parse("02-01-2006 15:04:05", datestring)
 	testCase := []struct {
@@ -416,9 +425,9 @@ func TestAdminUC_DeleteVoucher(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetVoucherByID", mock.Anything, mock.Anything).Return(&model.Voucher{}, nil)
@@ -434,9 +443,9 @@ func TestAdminUC_DeleteVoucher(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetVoucherByID", mock.Anything, mock.Anything).Return(nil, httperror.New(http.StatusBadRequest, body.VoucherSellerNotFoundMessage))
@@ -451,9 +460,9 @@ func TestAdminUC_DeleteVoucher(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetVoucherByID", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
@@ -468,9 +477,9 @@ func TestAdminUC_DeleteVoucher(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetVoucherByID", mock.Anything, mock.Anything).Return(&model.Voucher{}, nil)
@@ -485,7 +494,7 @@ func TestAdminUC_DeleteVoucher(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			err := u.DeleteVoucher(context.Background(), "123")
@@ -498,6 +507,7 @@ func TestAdminUC_DeleteVoucher(t *testing.T) {
 
 func TestAdminUC_GetCategories(t *testing.T) {
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	datestring := "02-01-2006 15:04:05"
 	date, _ := time.Parse("02-01-2006 15:04:05", datestring)
 	testCase := []struct {
@@ -515,9 +525,9 @@ func TestAdminUC_GetCategories(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetCategories", mock.Anything).Return([]*body.CategoryResponse{}, nil)
@@ -532,9 +542,9 @@ func TestAdminUC_GetCategories(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetCategories", mock.Anything).Return([]*body.CategoryResponse{}, fmt.Errorf("test"))
@@ -548,7 +558,7 @@ func TestAdminUC_GetCategories(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetCategories(context.Background())
@@ -561,6 +571,7 @@ func TestAdminUC_GetCategories(t *testing.T) {
 
 func TestAdminUC_AddCategory(t *testing.T) {
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	datestring := "02-01-2006 15:04:05"
 	date, _ := time.Parse("02-01-2006 15:04:05", datestring)
 	testCase := []struct {
@@ -578,9 +589,9 @@ func TestAdminUC_AddCategory(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("AddCategory", mock.Anything, mock.Anything).Return(nil)
@@ -595,9 +606,9 @@ func TestAdminUC_AddCategory(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("AddCategory", mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
@@ -611,7 +622,7 @@ func TestAdminUC_AddCategory(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			err := u.AddCategory(context.Background(), body.CategoryRequest{
@@ -630,6 +641,7 @@ func TestAdminUC_AddCategory(t *testing.T) {
 
 func TestAdminUC_DeleteCategory(t *testing.T) {
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	datestring := "02-01-2006 15:04:05"
 	date, _ := time.Parse("02-01-2006 15:04:05", datestring)
 	testCase := []struct {
@@ -647,9 +659,9 @@ func TestAdminUC_DeleteCategory(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("CountProductCategory", mock.Anything, mock.Anything).Return(0, nil)
@@ -666,9 +678,9 @@ func TestAdminUC_DeleteCategory(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("CountProductCategory", mock.Anything, mock.Anything).Return(0, fmt.Errorf("test"))
@@ -683,9 +695,9 @@ func TestAdminUC_DeleteCategory(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("CountProductCategory", mock.Anything, mock.Anything).Return(1, nil)
@@ -700,9 +712,9 @@ func TestAdminUC_DeleteCategory(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("CountProductCategory", mock.Anything, mock.Anything).Return(0, nil)
@@ -718,9 +730,9 @@ func TestAdminUC_DeleteCategory(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("CountProductCategory", mock.Anything, mock.Anything).Return(0, nil)
@@ -736,9 +748,9 @@ func TestAdminUC_DeleteCategory(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("CountProductCategory", mock.Anything, mock.Anything).Return(0, nil)
@@ -754,7 +766,7 @@ func TestAdminUC_DeleteCategory(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			err := u.DeleteCategory(context.Background(), "asd")
@@ -767,6 +779,7 @@ func TestAdminUC_DeleteCategory(t *testing.T) {
 
 func TestAdminUC_GetBanner(t *testing.T) {
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	datestring := "02-01-2006 15:04:05"
 	date, _ := time.Parse("02-01-2006 15:04:05", datestring)
 	testCase := []struct {
@@ -784,9 +797,9 @@ func TestAdminUC_GetBanner(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetBanner", mock.Anything).Return([]*body.BannerResponse{}, nil)
@@ -801,9 +814,9 @@ func TestAdminUC_GetBanner(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetBanner", mock.Anything).Return(nil, fmt.Errorf("test"))
@@ -817,7 +830,7 @@ func TestAdminUC_GetBanner(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetBanner(context.Background())
@@ -830,6 +843,7 @@ func TestAdminUC_GetBanner(t *testing.T) {
 
 func TestAdminUC_EditCategory(t *testing.T) {
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	datestring := "02-01-2006 15:04:05"
 	date, _ := time.Parse("02-01-2006 15:04:05", datestring)
 	testCase := []struct {
@@ -847,9 +861,9 @@ func TestAdminUC_EditCategory(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("EditCategory", mock.Anything, mock.Anything).Return(nil)
@@ -864,9 +878,9 @@ func TestAdminUC_EditCategory(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("EditCategory", mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
@@ -880,7 +894,7 @@ func TestAdminUC_EditCategory(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			err := u.EditCategory(context.Background(), body.CategoryRequest{
@@ -899,6 +913,7 @@ func TestAdminUC_EditCategory(t *testing.T) {
 
 func TestAdminUC_AddBanner(t *testing.T) {
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	datestring := "02-01-2006 15:04:05"
 	date, _ := time.Parse("02-01-2006 15:04:05", datestring)
 	testCase := []struct {
@@ -916,9 +931,9 @@ func TestAdminUC_AddBanner(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("AddBanner", mock.Anything, mock.Anything).Return(nil)
@@ -933,9 +948,9 @@ func TestAdminUC_AddBanner(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("AddBanner", mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
@@ -949,7 +964,7 @@ func TestAdminUC_AddBanner(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			err := u.AddBanner(context.Background(), body.BannerRequest{})
@@ -962,6 +977,7 @@ func TestAdminUC_AddBanner(t *testing.T) {
 
 func TestAdminUC_DeleteBanner(t *testing.T) {
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	datestring := "02-01-2006 15:04:05"
 	date, _ := time.Parse("02-01-2006 15:04:05", datestring)
 	testCase := []struct {
@@ -979,9 +995,9 @@ func TestAdminUC_DeleteBanner(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("DeleteBanner", mock.Anything, mock.Anything).Return(nil)
@@ -996,9 +1012,9 @@ func TestAdminUC_DeleteBanner(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("DeleteBanner", mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
@@ -1012,7 +1028,7 @@ func TestAdminUC_DeleteBanner(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			err := u.DeleteBanner(context.Background(), "123")
@@ -1025,6 +1041,7 @@ func TestAdminUC_DeleteBanner(t *testing.T) {
 
 func TestAdminUC_EditBanner(t *testing.T) {
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	datestring := "02-01-2006 15:04:05"
 	date, _ := time.Parse("02-01-2006 15:04:05", datestring)
 	testCase := []struct {
@@ -1042,9 +1059,9 @@ func TestAdminUC_EditBanner(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("EditBanner", mock.Anything, mock.Anything).Return(nil)
@@ -1059,9 +1076,9 @@ func TestAdminUC_EditBanner(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("EditBanner", mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
@@ -1075,7 +1092,7 @@ func TestAdminUC_EditBanner(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			err := u.EditBanner(context.Background(), body.BannerIDRequest{})
@@ -1091,6 +1108,7 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 	var booll *bool
 	boolltrue := true
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	var test *string
 	var date2 sql.NullTime
 	var date3 sql.NullTime
@@ -1105,7 +1123,7 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 		name        string
 		body        model.Voucher
 		userID      string
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase)
 		expectedErr error
 	}{
 		{
@@ -1116,11 +1134,11 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(&model.Refund{
 					ID:             ID,
 					OrderID:        ID,
@@ -1135,6 +1153,7 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				r.On("GetOrderByID", mock.Anything, mock.Anything).Return(&model.OrderModel{}, nil)
 				r.On("UpdateRefund", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, mock.Anything, constant.OrderStatusRefunded).Return(nil)
 				r.On("GetOrderItemsByOrderID", mock.Anything, mock.Anything, mock.Anything).Return([]*model.OrderItem{
 					{
 						OrderID:         ID,
@@ -1146,12 +1165,11 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 						IsReview:        true,
 					},
 				}, nil)
-				r.On("GetProductDetailByID", mock.Anything, mock.Anything, mock.Anything).Return(&model.ProductDetail{}, nil)
-				r.On("UpdateProductDetailStock", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("IncreaseProductDetailStock", mock.Anything, mock.Anything, ID.String(), 123).Return(nil)
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything, mock.Anything).Return(&model.Wallet{}, nil)
-				r.On("UpdateWalletBalance", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-				r.On("InsertWalletHistory", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("InsertWalletHistory", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				l.On("PostEntry", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				a.On("Record", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			},
 			expectedErr: nil,
 		},
@@ -1163,11 +1181,11 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("123"))
 			},
 			expectedErr: fmt.Errorf("123"),
@@ -1180,11 +1198,11 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
 			},
 			expectedErr: httperror.New(http.StatusBadRequest, response.RefundNotFound),
@@ -1197,11 +1215,11 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(&model.Refund{
 					ID:             ID,
 					OrderID:        ID,
@@ -1224,11 +1242,11 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(&model.Refund{
 					ID:             ID,
 					OrderID:        ID,
@@ -1251,11 +1269,11 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(&model.Refund{}, nil)
 				r.On("GetOrderByID", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
 			},
@@ -1269,11 +1287,11 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(&model.Refund{}, nil)
 				r.On("GetOrderByID", mock.Anything, mock.Anything).Return(&model.OrderModel{}, nil)
 				r.On("UpdateRefund", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
@@ -1288,11 +1306,11 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(&model.Refund{}, nil)
 				r.On("GetOrderByID", mock.Anything, mock.Anything).Return(&model.OrderModel{}, nil)
 				r.On("UpdateRefund", mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -1308,15 +1326,16 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(&model.Refund{}, nil)
 				r.On("GetOrderByID", mock.Anything, mock.Anything).Return(&model.OrderModel{}, nil)
 				r.On("UpdateRefund", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, mock.Anything, constant.OrderStatusRefunded).Return(nil)
 				r.On("GetOrderItemsByOrderID", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
 
 			},
@@ -1330,15 +1349,16 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(&model.Refund{}, nil)
 				r.On("GetOrderByID", mock.Anything, mock.Anything).Return(&model.OrderModel{}, nil)
 				r.On("UpdateRefund", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, mock.Anything, constant.OrderStatusRefunded).Return(nil)
 				r.On("GetOrderItemsByOrderID", mock.Anything, mock.Anything, mock.Anything).Return([]*model.OrderItem{
 					{
 						OrderID:         ID,
@@ -1350,7 +1370,7 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 						IsReview:        true,
 					},
 				}, nil)
-				r.On("GetProductDetailByID", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
+				r.On("IncreaseProductDetailStock", mock.Anything, mock.Anything, ID.String(), 123).Return(fmt.Errorf("test"))
 			},
 			expectedErr: fmt.Errorf("test"),
 		},
@@ -1362,15 +1382,16 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(&model.Refund{}, nil)
 				r.On("GetOrderByID", mock.Anything, mock.Anything).Return(&model.OrderModel{}, nil)
 				r.On("UpdateRefund", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, mock.Anything, constant.OrderStatusRefunded).Return(nil)
 				r.On("GetOrderItemsByOrderID", mock.Anything, mock.Anything, mock.Anything).Return([]*model.OrderItem{
 					{
 						OrderID:         ID,
@@ -1382,8 +1403,7 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 						IsReview:        true,
 					},
 				}, nil)
-				r.On("GetProductDetailByID", mock.Anything, mock.Anything, mock.Anything).Return(&model.ProductDetail{}, nil)
-				r.On("UpdateProductDetailStock", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
+				r.On("IncreaseProductDetailStock", mock.Anything, mock.Anything, ID.String(), 123).Return(fmt.Errorf("test"))
 			},
 
 			expectedErr: fmt.Errorf("test"),
@@ -1396,15 +1416,16 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(&model.Refund{}, nil)
 				r.On("GetOrderByID", mock.Anything, mock.Anything).Return(&model.OrderModel{}, nil)
 				r.On("UpdateRefund", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, mock.Anything, constant.OrderStatusRefunded).Return(nil)
 				r.On("GetOrderItemsByOrderID", mock.Anything, mock.Anything, mock.Anything).Return([]*model.OrderItem{
 					{
 						OrderID:         ID,
@@ -1416,8 +1437,7 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 						IsReview:        true,
 					},
 				}, nil)
-				r.On("GetProductDetailByID", mock.Anything, mock.Anything, mock.Anything).Return(&model.ProductDetail{}, nil)
-				r.On("UpdateProductDetailStock", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("IncreaseProductDetailStock", mock.Anything, mock.Anything, ID.String(), 123).Return(nil)
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
 			},
 			expectedErr: fmt.Errorf("test"),
@@ -1430,15 +1450,16 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(&model.Refund{}, nil)
 				r.On("GetOrderByID", mock.Anything, mock.Anything).Return(&model.OrderModel{}, nil)
 				r.On("UpdateRefund", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, mock.Anything, constant.OrderStatusRefunded).Return(nil)
 				r.On("GetOrderItemsByOrderID", mock.Anything, mock.Anything, mock.Anything).Return([]*model.OrderItem{
 					{
 						OrderID:         ID,
@@ -1450,10 +1471,10 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 						IsReview:        true,
 					},
 				}, nil)
-				r.On("GetProductDetailByID", mock.Anything, mock.Anything, mock.Anything).Return(&model.ProductDetail{}, nil)
-				r.On("UpdateProductDetailStock", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("IncreaseProductDetailStock", mock.Anything, mock.Anything, ID.String(), 123).Return(nil)
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything, mock.Anything).Return(&model.Wallet{}, nil)
-				r.On("UpdateWalletBalance", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
+				r.On("InsertWalletHistory", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				l.On("PostEntry", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
 			},
 			expectedErr: fmt.Errorf("test"),
 		},
@@ -1465,15 +1486,16 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(&model.Refund{}, nil)
 				r.On("GetOrderByID", mock.Anything, mock.Anything).Return(&model.OrderModel{}, nil)
 				r.On("UpdateRefund", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, mock.Anything, constant.OrderStatusRefunded).Return(nil)
 				r.On("GetOrderItemsByOrderID", mock.Anything, mock.Anything, mock.Anything).Return([]*model.OrderItem{
 					{
 						OrderID:         ID,
@@ -1485,10 +1507,8 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 						IsReview:        true,
 					},
 				}, nil)
-				r.On("GetProductDetailByID", mock.Anything, mock.Anything, mock.Anything).Return(&model.ProductDetail{}, nil)
-				r.On("UpdateProductDetailStock", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("IncreaseProductDetailStock", mock.Anything, mock.Anything, ID.String(), 123).Return(nil)
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything, mock.Anything).Once().Return(&model.Wallet{}, nil)
-				r.On("UpdateWalletBalance", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil, fmt.Errorf("test"))
 			},
 			expectedErr: fmt.Errorf("test"),
@@ -1501,15 +1521,16 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(&model.Refund{}, nil)
 				r.On("GetOrderByID", mock.Anything, mock.Anything).Return(&model.OrderModel{}, nil)
 				r.On("UpdateRefund", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, mock.Anything, constant.OrderStatusRefunded).Return(nil)
 				r.On("GetOrderItemsByOrderID", mock.Anything, mock.Anything, mock.Anything).Return([]*model.OrderItem{
 					{
 						OrderID:         ID,
@@ -1521,13 +1542,13 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 						IsReview:        true,
 					},
 				}, nil)
-				r.On("GetProductDetailByID", mock.Anything, mock.Anything, mock.Anything).Return(&model.ProductDetail{}, nil)
-				r.On("UpdateProductDetailStock", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("IncreaseProductDetailStock", mock.Anything, mock.Anything, ID.String(), 123).Return(nil)
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything, mock.Anything).Return(&model.Wallet{}, nil)
-				r.On("UpdateWalletBalance", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil)
-				r.On("UpdateWalletBalance", mock.Anything, mock.Anything, mock.Anything).Once().Return(fmt.Errorf("test"))
+				r.On("InsertWalletHistory", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				l.On("PostEntry", mock.Anything, mock.Anything, mock.Anything).
+					Return(httperror.New(http.StatusBadRequest, response.WalletBalanceNotEnough))
 			},
-			expectedErr: fmt.Errorf("test"),
+			expectedErr: httperror.New(http.StatusBadRequest, response.WalletBalanceNotEnough),
 		},
 		{
 			name: "success update voucher",
@@ -1537,15 +1558,16 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(&model.Refund{}, nil)
 				r.On("GetOrderByID", mock.Anything, mock.Anything).Return(&model.OrderModel{}, nil)
 				r.On("UpdateRefund", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, mock.Anything, constant.OrderStatusRefunded).Return(nil)
 				r.On("GetOrderItemsByOrderID", mock.Anything, mock.Anything, mock.Anything).Return([]*model.OrderItem{
 					{
 						OrderID:         ID,
@@ -1557,10 +1579,8 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 						IsReview:        true,
 					},
 				}, nil)
-				r.On("GetProductDetailByID", mock.Anything, mock.Anything, mock.Anything).Return(&model.ProductDetail{}, nil)
-				r.On("UpdateProductDetailStock", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("IncreaseProductDetailStock", mock.Anything, mock.Anything, ID.String(), 123).Return(nil)
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything, mock.Anything).Return(&model.Wallet{}, nil)
-				r.On("UpdateWalletBalance", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("InsertWalletHistory", mock.Anything, mock.Anything, mock.Anything).Once().Return(fmt.Errorf("test"))
 			},
 			expectedErr: fmt.Errorf("test"),
@@ -1573,15 +1593,16 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 				ActivedDate:        date,
 				ExpiredDate:        date,
 				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+				DiscountFixPrice:   &tempMoney,
+				MinProductPrice:    &tempMoney,
+				MaxDiscountPrice:   &tempMoney,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, a *auditMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetRefundByID", mock.Anything, mock.Anything).Return(&model.Refund{}, nil)
 				r.On("GetOrderByID", mock.Anything, mock.Anything).Return(&model.OrderModel{}, nil)
 				r.On("UpdateRefund", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, mock.Anything, constant.OrderStatusRefunded).Return(nil)
 				r.On("GetOrderItemsByOrderID", mock.Anything, mock.Anything, mock.Anything).Return([]*model.OrderItem{
 					{
 						OrderID:         ID,
@@ -1593,10 +1614,8 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 						IsReview:        true,
 					},
 				}, nil)
-				r.On("GetProductDetailByID", mock.Anything, mock.Anything, mock.Anything).Return(&model.ProductDetail{}, nil)
-				r.On("UpdateProductDetailStock", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("IncreaseProductDetailStock", mock.Anything, mock.Anything, ID.String(), 123).Return(nil)
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything, mock.Anything).Return(&model.Wallet{}, nil)
-				r.On("UpdateWalletBalance", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("InsertWalletHistory", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil)
 				r.On("InsertWalletHistory", mock.Anything, mock.Anything, mock.Anything).Once().Return(fmt.Errorf("test"))
 
@@ -1610,10 +1629,13 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			l := ledgerMocks.NewUseCase(t)
+			a := auditMocks.NewUseCase(t)
+			n := notificationMocks.NewUseCase(t)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, l, a, n)
 
-			tc.mock(t, r)
-			err := u.RefundOrder(context.Background(), "123")
+			tc.mock(t, r, l, a, n)
+			err := u.RefundOrder(context.Background(), "123", "admin", session.Client{})
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
 			}
@@ -1621,3 +1643,178 @@ func TestAdminUC_RefundOrder(t *testing.T) {
 	}
 
 }
+
+func TestAdminUC_GetModerationReviews(t *testing.T) {
+	testCase := []struct {
+		name        string
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name: "success get reported reviews",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTotalModerationReviews", mock.Anything, body.ReviewStatusReported).Return(int64(1), nil)
+				r.On("GetModerationReviews", mock.Anything, body.ReviewStatusReported, mock.Anything, mock.Anything).
+					Return([]*model.ReviewModeration{{ReportCount: 2}}, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error GetTotalModerationReviews",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTotalModerationReviews", mock.Anything, mock.Anything).Return(int64(0), fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
+		{
+			name: "error GetModerationReviews",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTotalModerationReviews", mock.Anything, mock.Anything).Return(int64(1), nil)
+				r.On("GetModerationReviews", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := NewAdminUseCase(&config.Config{}, nil, r, nil, nil, nil)
+
+			tc.mock(t, r)
+			_, err := u.GetModerationReviews(context.Background(), body.ReviewStatusReported, "", &pagination.Pagination{Limit: 10})
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAdminUC_HideReview(t *testing.T) {
+	ID, _ := uuid.Parse("123e4567-e89b-12d3-a456-426614174000")
+	testCase := []struct {
+		name        string
+		mock        func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase)
+		expectedErr error
+	}{
+		{
+			name: "success hide review",
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
+				r.On("GetReviewByID", mock.Anything, mock.Anything).Return(&model.Review{ID: ID}, nil)
+				r.On("UpdateReviewHidden", mock.Anything, mock.Anything, mock.MatchedBy(func(review *model.Review) bool {
+					return review.HiddenAt.Valid
+				})).Return(nil)
+				r.On("ResolveReviewReports", mock.Anything, mock.Anything, ID.String()).Return(nil)
+				a.On("Record", mock.Anything, mock.Anything, mock.MatchedBy(func(event *model.AuditEvent) bool {
+					return event.Action == constant.AuditActionReviewHide && event.TargetType == constant.AuditTargetReview
+				})).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "review not found",
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
+				r.On("GetReviewByID", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+			},
+			expectedErr: httperror.New(http.StatusBadRequest, body.ReviewNotFound),
+		},
+		{
+			name: "review already hidden",
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
+				r.On("GetReviewByID", mock.Anything, mock.Anything).Return(&model.Review{
+					ID: ID, HiddenAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+			},
+			expectedErr: httperror.New(http.StatusBadRequest, body.ReviewAlreadyHidden),
+		},
+		{
+			name: "error ResolveReviewReports",
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
+				r.On("GetReviewByID", mock.Anything, mock.Anything).Return(&model.Review{ID: ID}, nil)
+				r.On("UpdateReviewHidden", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("ResolveReviewReports", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, mock, _ := sqlmock.New()
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			r := mocks.NewRepository(t)
+			a := auditMocks.NewUseCase(t)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, a, nil)
+
+			tc.mock(t, r, a)
+			err := u.HideReview(context.Background(), ID.String(), "admin", session.Client{})
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAdminUC_RestoreReview(t *testing.T) {
+	ID, _ := uuid.Parse("123e4567-e89b-12d3-a456-426614174000")
+	testCase := []struct {
+		name        string
+		mock        func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase)
+		expectedErr error
+	}{
+		{
+			name: "success restore review",
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
+				r.On("GetReviewByID", mock.Anything, mock.Anything).Return(&model.Review{
+					ID: ID, HiddenAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+				r.On("UpdateReviewHidden", mock.Anything, mock.Anything, mock.MatchedBy(func(review *model.Review) bool {
+					return !review.HiddenAt.Valid
+				})).Return(nil)
+				r.On("ResolveReviewReports", mock.Anything, mock.Anything, ID.String()).Return(nil)
+				a.On("Record", mock.Anything, mock.Anything, mock.MatchedBy(func(event *model.AuditEvent) bool {
+					return event.Action == constant.AuditActionReviewRestore
+				})).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "review not hidden",
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
+				r.On("GetReviewByID", mock.Anything, mock.Anything).Return(&model.Review{ID: ID}, nil)
+			},
+			expectedErr: httperror.New(http.StatusBadRequest, body.ReviewNotHidden),
+		},
+		{
+			name: "error GetReviewByID",
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
+				r.On("GetReviewByID", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, mock, _ := sqlmock.New()
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			r := mocks.NewRepository(t)
+			a := auditMocks.NewUseCase(t)
+			u := NewAdminUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, a, nil)
+
+			tc.mock(t, r, a)
+			err := u.RestoreReview(context.Background(), ID.String(), "admin", session.Client{})
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package audit
+
+import "github.com/gin-gonic/gin"
+
+type Handlers interface {
+	SearchEvents(c *gin.Context)
+}
@@ -0,0 +1,93 @@
+package body
+
+import (
+	"murakali/pkg/httperror"
+	"murakali/pkg/response"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	InvalidIDMessage    = "Invalid id."
+	InvalidTimeMessage  = "Invalid time, use RFC 3339."
+	InvalidRangeMessage = "Must not be before from."
+)
+
+type UnprocessableEntity struct {
+	Fields map[string]string `json:"fields"`
+}
+
+// SearchEventsQuery filters the audit log. Empty fields match every event,
+// From is inclusive and To exclusive.
+type SearchEventsQuery struct {
+	ActorID    string     `form:"actor_id"`
+	Action     string     `form:"action"`
+	TargetType string     `form:"target_type"`
+	TargetID   string     `form:"target_id"`
+	IP         string     `form:"ip"`
+	FromRaw    string     `form:"from"`
+	ToRaw      string     `form:"to"`
+	From       *time.Time `form:"-"`
+	To         *time.Time `form:"-"`
+}
+
+func (q *SearchEventsQuery) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"actor_id": "",
+			"from":     "",
+			"to":       "",
+		},
+	}
+
+	q.ActorID = strings.TrimSpace(q.ActorID)
+	q.Action = strings.TrimSpace(q.Action)
+	q.TargetType = strings.TrimSpace(q.TargetType)
+	q.TargetID = strings.TrimSpace(q.TargetID)
+	q.IP = strings.TrimSpace(q.IP)
+
+	if q.ActorID != "" {
+		if _, err := uuid.Parse(q.ActorID); err != nil {
+			unprocessableEntity = true
+			entity.Fields["actor_id"] = InvalidIDMessage
+		}
+	}
+
+	for field, value := range map[string]string{"from": q.FromRaw, "to": q.ToRaw} {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			unprocessableEntity = true
+			entity.Fields[field] = InvalidTimeMessage
+			continue
+		}
+
+		if field == "from" {
+			q.From = &parsed
+		} else {
+			q.To = &parsed
+		}
+	}
+
+	if q.From != nil && q.To != nil && q.To.Before(*q.From) {
+		unprocessableEntity = true
+		entity.Fields["to"] = InvalidRangeMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
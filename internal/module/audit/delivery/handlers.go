@@ -0,0 +1,80 @@
+package delivery
+
+import (
+	"errors"
+	"murakali/config"
+	"murakali/internal/module/audit"
+	"murakali/internal/module/audit/delivery/body"
+	"murakali/pkg/httperror"
+	"murakali/pkg/logger"
+	"murakali/pkg/pagination"
+	"murakali/pkg/response"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type auditHandlers struct {
+	cfg     *config.Config
+	auditUC audit.UseCase
+	logger  logger.Logger
+}
+
+func NewAuditHandlers(cfg *config.Config, auditUC audit.UseCase, log logger.Logger) audit.Handlers {
+	return &auditHandlers{cfg: cfg, auditUC: auditUC, logger: log}
+}
+
+func (h *auditHandlers) SearchEvents(c *gin.Context) {
+	pgn := &pagination.Pagination{}
+	h.ValidateQueryPagination(c, pgn)
+
+	var query body.SearchEventsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := query.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	events, err := h.auditUC.SearchEvents(c, &query, pgn)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerAudit, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, events, http.StatusOK)
+}
+
+func (h *auditHandlers) ValidateQueryPagination(c *gin.Context, pgn *pagination.Pagination) {
+	limit := strings.TrimSpace(c.Query("limit"))
+	page := strings.TrimSpace(c.Query("page"))
+
+	var limitFilter int
+	var pageFilter int
+
+	limitFilter, err := strconv.Atoi(limit)
+	if err != nil || limitFilter < 1 || limitFilter > 100 {
+		limitFilter = 20
+	}
+
+	pageFilter, err = strconv.Atoi(page)
+	if err != nil || pageFilter < 1 {
+		pageFilter = 1
+	}
+
+	pgn.Limit = limitFilter
+	pgn.Page = pageFilter
+}
@@ -0,0 +1,107 @@
+package delivery
+
+import (
+	"errors"
+	"murakali/config"
+	"murakali/internal/module/audit/delivery/body"
+	"murakali/internal/module/audit/mocks"
+	"murakali/pkg/httperror"
+	"murakali/pkg/logger"
+	"murakali/pkg/pagination"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestLogger() logger.Logger {
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+			Level:             "info",
+		},
+	}
+
+	appLogger := logger.NewAPILogger(cfg)
+	appLogger.InitLogger()
+
+	return appLogger
+}
+
+func TestAuditHandlers_SearchEvents(t *testing.T) {
+	testCase := []struct {
+		name     string
+		query    string
+		mock     func(s *mocks.UseCase)
+		expected int
+	}{
+		{
+			name:  "success search events",
+			query: "?action=refund.approve&from=2023-01-01T00:00:00Z&to=2023-02-01T00:00:00Z&limit=5",
+			mock: func(s *mocks.UseCase) {
+				s.On("SearchEvents", mock.Anything, mock.MatchedBy(func(query *body.SearchEventsQuery) bool {
+					return query.Action == "refund.approve" && query.From != nil && query.To != nil
+				}), mock.MatchedBy(func(pgn *pagination.Pagination) bool {
+					return pgn.Limit == 5 && pgn.Page == 1
+				})).Return(&pagination.Pagination{}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "error invalid actor id",
+			query:    "?actor_id=123",
+			mock:     func(s *mocks.UseCase) {},
+			expected: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "error invalid time",
+			query:    "?from=yesterday",
+			mock:     func(s *mocks.UseCase) {},
+			expected: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "error to before from",
+			query:    "?from=2023-02-01T00:00:00Z&to=2023-01-01T00:00:00Z",
+			mock:     func(s *mocks.UseCase) {},
+			expected: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "error search events",
+			query: "",
+			mock: func(s *mocks.UseCase) {
+				s.On("SearchEvents", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("test"))
+			},
+			expected: http.StatusInternalServerError,
+		},
+		{
+			name:  "error search events custom error",
+			query: "",
+			mock: func(s *mocks.UseCase) {
+				s.On("SearchEvents", mock.Anything, mock.Anything, mock.Anything).Return(nil, httperror.New(http.StatusBadRequest, "test"))
+			},
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/admin/audit"+tc.query, nil)
+
+			s := mocks.NewUseCase(t)
+			h := NewAuditHandlers(&config.Config{}, s, newTestLogger())
+
+			tc.mock(s)
+			h.SearchEvents(c)
+
+			assert.Equal(t, tc.expected, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package delivery
+
+import (
+	"murakali/internal/constant"
+	"murakali/internal/middleware"
+	"murakali/internal/module/audit"
+
+	"github.com/gin-gonic/gin"
+)
+
+func MapAuditRoutes(auditGroup *gin.RouterGroup, h audit.Handlers, mw *middleware.MWManager) {
+	auditGroup.Use(mw.AuthJWTMiddleware())
+	auditGroup.Use(mw.RequirePermission(constant.PermissionAuditRead))
+	auditGroup.GET("", h.SearchEvents)
+}
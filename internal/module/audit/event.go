@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"murakali/internal/constant"
+	"murakali/internal/model"
+	"murakali/pkg/session"
+	"strings"
+)
+
+// Redacted is the change of a secret, recorded without its values.
+var Redacted = model.AuditChange{From: constant.AuditRedacted, To: constant.AuditRedacted}
+
+// NewEvent describes action taken by actorID from client on a target.
+func NewEvent(actorID string, client session.Client, action, targetType, targetID string, diff model.AuditDiff) *model.AuditEvent {
+	return &model.AuditEvent{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		IP:         client.IP,
+		UserAgent:  client.UserAgent,
+		Diff:       diff,
+	}
+}
+
+// MaskCardNumber keeps the last four digits of a card number, enough to tell
+// the cards of a user apart.
+func MaskCardNumber(cardNumber string) string {
+	if len(cardNumber) <= 4 {
+		return strings.Repeat("*", len(cardNumber))
+	}
+
+	return strings.Repeat("*", len(cardNumber)-4) + cardNumber[len(cardNumber)-4:]
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	body "murakali/internal/module/audit/delivery/body"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "murakali/internal/model"
+
+	pagination "murakali/pkg/pagination"
+
+	postgre "murakali/pkg/postgre"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// CreateEvent provides a mock function with given fields: ctx, tx, event
+func (_m *Repository) CreateEvent(ctx context.Context, tx postgre.Transaction, event *model.AuditEvent) error {
+	ret := _m.Called(ctx, tx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.AuditEvent) error); ok {
+		r0 = rf(ctx, tx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetEvents provides a mock function with given fields: ctx, query, pgn
+func (_m *Repository) GetEvents(ctx context.Context, query *body.SearchEventsQuery, pgn *pagination.Pagination) ([]*model.AuditEvent, error) {
+	ret := _m.Called(ctx, query, pgn)
+
+	var r0 []*model.AuditEvent
+	if rf, ok := ret.Get(0).(func(context.Context, *body.SearchEventsQuery, *pagination.Pagination) []*model.AuditEvent); ok {
+		r0 = rf(ctx, query, pgn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.AuditEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *body.SearchEventsQuery, *pagination.Pagination) error); ok {
+		r1 = rf(ctx, query, pgn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTotalEvents provides a mock function with given fields: ctx, query
+func (_m *Repository) GetTotalEvents(ctx context.Context, query *body.SearchEventsQuery) (int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *body.SearchEventsQuery) int64); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *body.SearchEventsQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
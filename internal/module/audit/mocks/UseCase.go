@@ -0,0 +1,73 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	body "murakali/internal/module/audit/delivery/body"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "murakali/internal/model"
+
+	pagination "murakali/pkg/pagination"
+
+	postgre "murakali/pkg/postgre"
+)
+
+// UseCase is an autogenerated mock type for the UseCase type
+type UseCase struct {
+	mock.Mock
+}
+
+// Record provides a mock function with given fields: ctx, tx, event
+func (_m *UseCase) Record(ctx context.Context, tx postgre.Transaction, event *model.AuditEvent) error {
+	ret := _m.Called(ctx, tx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.AuditEvent) error); ok {
+		r0 = rf(ctx, tx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEvents provides a mock function with given fields: ctx, query, pgn
+func (_m *UseCase) SearchEvents(ctx context.Context, query *body.SearchEventsQuery, pgn *pagination.Pagination) (*pagination.Pagination, error) {
+	ret := _m.Called(ctx, query, pgn)
+
+	var r0 *pagination.Pagination
+	if rf, ok := ret.Get(0).(func(context.Context, *body.SearchEventsQuery, *pagination.Pagination) *pagination.Pagination); ok {
+		r0 = rf(ctx, query, pgn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pagination.Pagination)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *body.SearchEventsQuery, *pagination.Pagination) error); ok {
+		r1 = rf(ctx, query, pgn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewUseCase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUseCase creates a new instance of UseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUseCase(t mockConstructorTestingTNewUseCase) *UseCase {
+	mock := &UseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
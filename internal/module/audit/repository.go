@@ -0,0 +1,15 @@
+package audit
+
+import (
+	"context"
+	"murakali/internal/model"
+	"murakali/internal/module/audit/delivery/body"
+	"murakali/pkg/pagination"
+	"murakali/pkg/postgre"
+)
+
+type Repository interface {
+	CreateEvent(ctx context.Context, tx postgre.Transaction, event *model.AuditEvent) error
+	GetTotalEvents(ctx context.Context, query *body.SearchEventsQuery) (int64, error)
+	GetEvents(ctx context.Context, query *body.SearchEventsQuery, pgn *pagination.Pagination) ([]*model.AuditEvent, error)
+}
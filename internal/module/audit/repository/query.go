@@ -0,0 +1,20 @@
+package repository
+
+const (
+	CreateEventQuery = `INSERT INTO "audit_event" ("actor_id", "action", "target_type", "target_id", "ip", "user_agent", "diff")
+	VALUES (NULLIF($1, '')::uuid, $2, $3, $4, $5, $6, $7) RETURNING "id", "created_at"`
+
+	eventFilter = `WHERE ($1 = '' OR "actor_id" = NULLIF($1, '')::uuid)
+	AND ($2 = '' OR "action" = $2)
+	AND ($3 = '' OR "target_type" = $3)
+	AND ($4 = '' OR "target_id" = $4)
+	AND ($5 = '' OR "ip" = $5)
+	AND ($6::timestamptz IS NULL OR "created_at" >= $6)
+	AND ($7::timestamptz IS NULL OR "created_at" < $7)`
+
+	GetTotalEventsQuery = `SELECT count("id") FROM "audit_event" ` + eventFilter
+
+	GetEventsQuery = `SELECT "id", COALESCE("actor_id"::text, ''), "action", "target_type", "target_id", "ip", "user_agent", "diff", "created_at"
+	FROM "audit_event" ` + eventFilter + `
+	ORDER BY "created_at" DESC LIMIT $8 OFFSET $9`
+)
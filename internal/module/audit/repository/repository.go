@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"murakali/internal/model"
+	"murakali/internal/module/audit"
+	"murakali/internal/module/audit/delivery/body"
+	"murakali/pkg/pagination"
+	"murakali/pkg/postgre"
+)
+
+type auditRepo struct {
+	PSQL *sql.DB
+}
+
+func NewAuditRepository(psql *sql.DB) audit.Repository {
+	return &auditRepo{
+		PSQL: psql,
+	}
+}
+
+func (r *auditRepo) CreateEvent(ctx context.Context, tx postgre.Transaction, event *model.AuditEvent) error {
+	diff, err := json.Marshal(event.Diff)
+	if err != nil {
+		return err
+	}
+
+	return tx.QueryRowContext(ctx, CreateEventQuery,
+		event.ActorID,
+		event.Action,
+		event.TargetType,
+		event.TargetID,
+		event.IP,
+		event.UserAgent,
+		diff).Scan(&event.ID, &event.CreatedAt)
+}
+
+func (r *auditRepo) GetTotalEvents(ctx context.Context, query *body.SearchEventsQuery) (int64, error) {
+	var total int64
+	if err := r.PSQL.QueryRowContext(ctx, GetTotalEventsQuery, filterArgs(query)...).Scan(&total); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *auditRepo) GetEvents(ctx context.Context, query *body.SearchEventsQuery, pgn *pagination.Pagination) ([]*model.AuditEvent, error) {
+	events := make([]*model.AuditEvent, 0)
+	args := append(filterArgs(query), pgn.GetLimit(), pgn.GetOffset())
+	res, err := r.PSQL.QueryContext(ctx, GetEventsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var event model.AuditEvent
+		var diff []byte
+		if errScan := res.Scan(
+			&event.ID,
+			&event.ActorID,
+			&event.Action,
+			&event.TargetType,
+			&event.TargetID,
+			&event.IP,
+			&event.UserAgent,
+			&diff,
+			&event.CreatedAt); errScan != nil {
+			return nil, errScan
+		}
+
+		if errDiff := json.Unmarshal(diff, &event.Diff); errDiff != nil {
+			return nil, errDiff
+		}
+
+		events = append(events, &event)
+	}
+
+	if res.Err() != nil {
+		return nil, res.Err()
+	}
+
+	return events, nil
+}
+
+func filterArgs(query *body.SearchEventsQuery) []interface{} {
+	return []interface{}{query.ActorID, query.Action, query.TargetType, query.TargetID, query.IP, query.From, query.To}
+}
@@ -0,0 +1,14 @@
+package audit
+
+import (
+	"context"
+	"murakali/internal/model"
+	"murakali/internal/module/audit/delivery/body"
+	"murakali/pkg/pagination"
+	"murakali/pkg/postgre"
+)
+
+type UseCase interface {
+	Record(ctx context.Context, tx postgre.Transaction, event *model.AuditEvent) error
+	SearchEvents(ctx context.Context, query *body.SearchEventsQuery, pgn *pagination.Pagination) (*pagination.Pagination, error)
+}
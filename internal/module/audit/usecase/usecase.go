@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"murakali/config"
+	"murakali/internal/model"
+	"murakali/internal/module/audit"
+	"murakali/internal/module/audit/delivery/body"
+	"murakali/pkg/pagination"
+	"murakali/pkg/postgre"
+)
+
+type auditUC struct {
+	cfg       *config.Config
+	auditRepo audit.Repository
+}
+
+func NewAuditUseCase(cfg *config.Config, auditRepo audit.Repository) audit.UseCase {
+	return &auditUC{cfg: cfg, auditRepo: auditRepo}
+}
+
+// Record appends event in tx, the transaction of the change it describes, so
+// the change is not kept without its event or the other way around.
+func (u *auditUC) Record(ctx context.Context, tx postgre.Transaction, event *model.AuditEvent) error {
+	if event.Diff == nil {
+		event.Diff = model.AuditDiff{}
+	}
+
+	return u.auditRepo.CreateEvent(ctx, tx, event)
+}
+
+func (u *auditUC) SearchEvents(ctx context.Context, query *body.SearchEventsQuery, pgn *pagination.Pagination) (*pagination.Pagination, error) {
+	totalRows, err := u.auditRepo.GetTotalEvents(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(math.Ceil(float64(totalRows) / float64(pgn.GetLimit())))
+	pgn.TotalRows = totalRows
+	pgn.TotalPages = totalPages
+
+	events, err := u.auditRepo.GetEvents(ctx, query, pgn)
+	if err != nil {
+		return nil, err
+	}
+
+	pgn.Rows = events
+	return pgn, nil
+}
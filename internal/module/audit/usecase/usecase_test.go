@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"murakali/config"
+	"murakali/internal/model"
+	"murakali/internal/module/audit/delivery/body"
+	"murakali/internal/module/audit/mocks"
+	"murakali/pkg/pagination"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditUC_Record(t *testing.T) {
+	testCase := []struct {
+		name        string
+		event       *model.AuditEvent
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name:  "success with diff",
+			event: &model.AuditEvent{Action: "user.email.change", Diff: model.AuditDiff{"email": {From: "a", To: "b"}}},
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("CreateEvent", mock.Anything, mock.Anything, mock.MatchedBy(func(event *model.AuditEvent) bool {
+					return event.Diff["email"].To == "b"
+				})).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "success without diff",
+			event: &model.AuditEvent{Action: "sealabs_pay.delete"},
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("CreateEvent", mock.Anything, mock.Anything, mock.MatchedBy(func(event *model.AuditEvent) bool {
+					return event.Diff != nil && len(event.Diff) == 0
+				})).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "error CreateEvent",
+			event: &model.AuditEvent{Action: "user.password.change"},
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("CreateEvent", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := NewAuditUseCase(&config.Config{}, r)
+
+			tc.mock(t, r)
+			err := u.Record(context.Background(), nil, tc.event)
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuditUC_SearchEvents(t *testing.T) {
+	testCase := []struct {
+		name        string
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTotalEvents", mock.Anything, mock.Anything).Return(int64(11), nil)
+				r.On("GetEvents", mock.Anything, mock.Anything, mock.Anything).Return([]*model.AuditEvent{}, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error GetTotalEvents",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTotalEvents", mock.Anything, mock.Anything).Return(int64(0), errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
+		{
+			name: "error GetEvents",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTotalEvents", mock.Anything, mock.Anything).Return(int64(1), nil)
+				r.On("GetEvents", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := NewAuditUseCase(&config.Config{}, r)
+
+			tc.mock(t, r)
+			pgn, err := u.SearchEvents(context.Background(), &body.SearchEventsQuery{}, &pagination.Pagination{Limit: 10, Page: 1})
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 2, pgn.TotalPages)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ type Handlers interface {
 	RegisterUser(c *gin.Context)
 	VerifyOTP(c *gin.Context)
 	Login(c *gin.Context)
+	LoginTwoFactor(c *gin.Context)
 	Logout(c *gin.Context)
 	RefreshToken(c *gin.Context)
 	ResetPasswordEmail(c *gin.Context)
@@ -15,4 +16,5 @@ type Handlers interface {
 	CheckUniqueUsername(c *gin.Context)
 	CheckUniquePhoneNo(c *gin.Context)
 	GoogleAuth(c *gin.Context)
+	JWKS(c *gin.Context)
 }
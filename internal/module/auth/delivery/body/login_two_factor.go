@@ -0,0 +1,49 @@
+package body
+
+import (
+	"murakali/pkg/httperror"
+	"murakali/pkg/response"
+	"net/http"
+	"strings"
+)
+
+type LoginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+}
+
+func (r *LoginTwoFactorRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"challenge_token": "",
+			"code":            "",
+		},
+	}
+
+	r.ChallengeToken = strings.TrimSpace(r.ChallengeToken)
+	if r.ChallengeToken == "" {
+		unprocessableEntity = true
+		entity.Fields["challenge_token"] = FieldCannotBeEmptyMessage
+	}
+
+	r.Code = strings.TrimSpace(r.Code)
+	if r.Code == "" {
+		unprocessableEntity = true
+		entity.Fields["code"] = FieldCannotBeEmptyMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
@@ -6,10 +6,12 @@ import (
 	"murakali/internal/constant"
 	"murakali/internal/module/auth"
 	"murakali/internal/module/auth/delivery/body"
+	"murakali/pkg/attempt"
 	"murakali/pkg/httperror"
 	"murakali/pkg/jwt"
 	"murakali/pkg/logger"
 	"murakali/pkg/response"
+	"murakali/pkg/session"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -21,14 +23,31 @@ import (
 type authHandlers struct {
 	cfg    *config.Config
 	authUC auth.UseCase
+	keys   *jwt.Keyring
 	logger logger.Logger
 }
 
-func NewAuthHandlers(cfg *config.Config, authUC auth.UseCase, log logger.Logger) auth.Handlers {
-	return &authHandlers{cfg: cfg, authUC: authUC, logger: log}
+func NewAuthHandlers(cfg *config.Config, authUC auth.UseCase, keys *jwt.Keyring, log logger.Logger) auth.Handlers {
+	return &authHandlers{cfg: cfg, authUC: authUC, keys: keys, logger: log}
+}
+
+func sessionClient(c *gin.Context) session.Client {
+	return session.Client{IP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
 }
 
 func (h *authHandlers) Logout(c *gin.Context) {
+	if refreshToken, err := c.Cookie(constant.RefreshTokenCookie); err == nil {
+		if claims, err := jwt.ExtractJWT(refreshToken, h.keys, jwt.AudienceRefresh); err == nil {
+			id, _ := claims["id"].(string)
+			sessionID, _ := claims["sid"].(string)
+			if err := h.authUC.Logout(c, id, sessionID); err != nil {
+				h.logger.Errorf("HandlerAuth, Error: %s", err)
+				response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+
 	c.SetSameSite(http.SameSiteNoneMode)
 	c.SetCookie(constant.RefreshTokenCookie, "", -1, "/", h.cfg.Server.Domain, true, true)
 	response.SuccessResponse(c.Writer, nil, http.StatusOK)
@@ -47,8 +66,72 @@ func (h *authHandlers) Login(c *gin.Context) {
 		return
 	}
 
-	token, err := h.authUC.Login(c, requestBody)
+	token, err := h.authUC.Login(c, requestBody, sessionClient(c))
+	if err != nil {
+		var locked *attempt.LockedError
+		if errors.As(err, &locked) {
+			response.TooManyRequestsResponse(c.Writer, response.TooManyAttemptsMessage, locked.RetryAfterSeconds())
+			return
+		}
+
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerAuth, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	if token.ChallengeToken != nil {
+		response.SuccessResponse(c.Writer, body.TwoFactorChallengeResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    *token.ChallengeToken}, http.StatusOK)
+		return
+	}
+
+	c.SetSameSite(http.SameSiteNoneMode)
+	c.SetCookie(constant.RefreshTokenCookie, token.Token.RefreshToken.Token, h.cfg.JWT.RefreshExpMin*60, "/", h.cfg.Server.Domain, true, true)
+	response.SuccessResponse(c.Writer, body.LoginResponse{
+		AccessToken: token.Token.AccessToken.Token,
+		ExpiredAt:   token.Token.AccessToken.ExpiredAt}, http.StatusOK)
+}
+
+func (h *authHandlers) LoginTwoFactor(c *gin.Context) {
+	var requestBody body.LoginTwoFactorRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	claims, err := jwt.ExtractJWT(requestBody.ChallengeToken, h.keys, jwt.AudienceTwoFactor)
 	if err != nil {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	userID, _ := claims["id"].(string)
+	if userID == "" {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	token, err := h.authUC.LoginTwoFactor(c, userID, requestBody.Code, sessionClient(c))
+	if err != nil {
+		var locked *attempt.LockedError
+		if errors.As(err, &locked) {
+			response.TooManyRequestsResponse(c.Writer, response.TooManyAttemptsMessage, locked.RetryAfterSeconds())
+			return
+		}
+
 		var e *httperror.Error
 		if !errors.As(err, &e) {
 			h.logger.Errorf("HandlerAuth, Error: %s", err)
@@ -72,14 +155,26 @@ func (h *authHandlers) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	claims, err := jwt.ExtractJWT(refreshToken, h.cfg.JWT.JwtSecretKey)
+	claims, err := jwt.ExtractJWT(refreshToken, h.keys, jwt.AudienceRefresh)
 	if err != nil {
 		response.ErrorResponse(c.Writer, response.ForbiddenMessage, http.StatusForbidden)
 		return
 	}
 
-	accessToken, err := h.authUC.RefreshToken(c, refreshToken, claims["id"].(string))
+	id, _ := claims["id"].(string)
+	sessionID, _ := claims["sid"].(string)
+	tokenID, _ := claims["jti"].(string)
+	token, err := h.authUC.RefreshToken(c, id, sessionID, tokenID)
 	if err != nil {
+		if errors.Is(err, session.ErrRefreshTokenReused) {
+			h.logger.Warnf("SecurityEvent: refresh token reused, session revoked, user %s, session %s, ip %s, user agent %q",
+				id, sessionID, c.ClientIP(), c.Request.UserAgent())
+			c.SetSameSite(http.SameSiteNoneMode)
+			c.SetCookie(constant.RefreshTokenCookie, "", -1, "/", h.cfg.Server.Domain, true, true)
+			response.ErrorResponse(c.Writer, response.ForbiddenMessage, http.StatusForbidden)
+			return
+		}
+
 		var e *httperror.Error
 		if !errors.As(err, &e) {
 			h.logger.Errorf("HandlerAuth, Error: %s", err)
@@ -91,7 +186,9 @@ func (h *authHandlers) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	response.SuccessResponse(c.Writer, body.LoginResponse{AccessToken: accessToken.Token, ExpiredAt: accessToken.ExpiredAt}, http.StatusOK)
+	c.SetSameSite(http.SameSiteNoneMode)
+	c.SetCookie(constant.RefreshTokenCookie, token.RefreshToken.Token, h.cfg.JWT.RefreshExpMin*60, "/", h.cfg.Server.Domain, true, true)
+	response.SuccessResponse(c.Writer, body.LoginResponse{AccessToken: token.AccessToken.Token, ExpiredAt: token.AccessToken.ExpiredAt}, http.StatusOK)
 }
 
 func (h *authHandlers) RegisterEmail(c *gin.Context) {
@@ -130,7 +227,7 @@ func (h *authHandlers) RegisterUser(c *gin.Context) {
 		return
 	}
 
-	claims, err := jwt.ExtractJWT(registerToken, h.cfg.JWT.JwtSecretKey)
+	claims, err := jwt.ExtractJWT(registerToken, h.keys, jwt.AudienceRegister)
 	if err != nil {
 		response.ErrorResponse(c.Writer, response.ForbiddenMessage, http.StatusForbidden)
 		return
@@ -178,8 +275,14 @@ func (h *authHandlers) VerifyOTP(c *gin.Context) {
 		return
 	}
 
-	registerToken, err := h.authUC.VerifyOTP(c, requestBody)
+	registerToken, err := h.authUC.VerifyOTP(c, requestBody, c.ClientIP())
 	if err != nil {
+		var locked *attempt.LockedError
+		if errors.As(err, &locked) {
+			response.TooManyRequestsResponse(c.Writer, response.TooManyAttemptsMessage, locked.RetryAfterSeconds())
+			return
+		}
+
 		var e *httperror.Error
 		if !errors.As(err, &e) {
 			h.logger.Errorf("HandlerAuth, Error: %s", err)
@@ -232,7 +335,7 @@ func (h *authHandlers) ResetPasswordUser(c *gin.Context) {
 		return
 	}
 
-	claims, err := jwt.ExtractJWT(ResetPasswordToken, h.cfg.JWT.JwtSecretKey)
+	claims, err := jwt.ExtractJWT(ResetPasswordToken, h.keys, jwt.AudienceResetPassword)
 	if err != nil {
 		response.ErrorResponse(c.Writer, response.ForbiddenMessage, http.StatusForbidden)
 		return
@@ -281,8 +384,14 @@ func (h *authHandlers) ResetPasswordVerifyOTP(c *gin.Context) {
 		return
 	}
 
-	ResetPasswordToken, err := h.authUC.ResetPasswordVerifyOTP(c, requestBody)
+	ResetPasswordToken, err := h.authUC.ResetPasswordVerifyOTP(c, requestBody, c.ClientIP())
 	if err != nil {
+		var locked *attempt.LockedError
+		if errors.As(err, &locked) {
+			response.TooManyRequestsResponse(c.Writer, response.TooManyAttemptsMessage, locked.RetryAfterSeconds())
+			return
+		}
+
 		var e *httperror.Error
 		if !errors.As(err, &e) {
 			h.logger.Errorf("HandlerAuth, Error: %s", err)
@@ -364,7 +473,7 @@ func (h *authHandlers) GoogleAuth(c *gin.Context) {
 		return
 	}
 
-	token, err := h.authUC.GoogleAuth(c, code, pathURL)
+	token, err := h.authUC.GoogleAuth(c, code, pathURL, sessionClient(c))
 	if err != nil {
 		var e *httperror.Error
 		if !errors.As(err, &e) {
@@ -385,8 +494,23 @@ func (h *authHandlers) GoogleAuth(c *gin.Context) {
 		return
 	}
 
+	if token.ChallengeToken != nil {
+		response.SuccessResponse(c.Writer, body.TwoFactorChallengeResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    *token.ChallengeToken}, http.StatusOK)
+		return
+	}
+
 	c.SetCookie(constant.RefreshTokenCookie, token.Token.RefreshToken.Token, h.cfg.JWT.RefreshExpMin*60, "/", h.cfg.Server.Domain, true, true)
 	response.SuccessResponse(c.Writer, body.LoginResponse{
 		AccessToken: token.Token.AccessToken.Token,
 		ExpiredAt:   token.Token.AccessToken.ExpiredAt}, http.StatusOK)
 }
+
+// JWKS publishes the public keys tokens are verified with. It is a plain
+// JSON Web Key Set rather than the usual response envelope, the format other
+// services and JWT libraries expect.
+func (h *authHandlers) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.keys.JWKS())
+}
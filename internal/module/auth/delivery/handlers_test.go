@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"io"
@@ -14,12 +13,16 @@ import (
 	"murakali/internal/model"
 	"murakali/internal/module/auth/delivery/body"
 	"murakali/internal/module/auth/mocks"
+	"murakali/pkg/attempt"
 	"murakali/pkg/httperror"
 	jwt2 "murakali/pkg/jwt"
+	"murakali/pkg/jwt/jwttest"
 	"murakali/pkg/logger"
+	"murakali/pkg/session"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func MockJsonPost(c *gin.Context, content interface{}) {
@@ -34,12 +37,30 @@ func MockJsonPost(c *gin.Context, content interface{}) {
 	c.Request.Body = io.NopCloser(bytes.NewBuffer(jsonBytes))
 }
 
+// testKeys is the keyring of the handlers under test. Tokens signed by
+// otherKeys carry a key id the handlers do not know.
+var testKeys, otherKeys = jwttest.NewKeyring("test"), jwttest.NewKeyring("other")
+
+// tokenCfg keeps the test tokens valid for the duration of the tests.
+var tokenCfg = &config.Config{JWT: config.JWTConfig{AccessExpMin: 10, RefreshExpMin: 10}}
+
+// signingKeys picks the keyring a test token is signed with, any cookieKey
+// stands for a token signed with a foreign key.
+func signingKeys(cookieKey string) *jwt2.Keyring {
+	if cookieKey == "" {
+		return testKeys
+	}
+
+	return otherKeys
+}
+
 func TestAuthHandlers_Logout(t *testing.T) {
 	testCase := []struct {
 		name     string
 		body     interface{}
 		mock     func(s *mocks.UseCase)
 		expected int
+		isCookie bool
 	}{
 		{
 			name:     "success logout",
@@ -47,6 +68,24 @@ func TestAuthHandlers_Logout(t *testing.T) {
 			mock:     func(s *mocks.UseCase) {},
 			expected: http.StatusOK,
 		},
+		{
+			name: "success logout revoke session",
+			body: nil,
+			mock: func(s *mocks.UseCase) {
+				s.On("Logout", mock.Anything, "user", "session").Return(nil)
+			},
+			expected: http.StatusOK,
+			isCookie: true,
+		},
+		{
+			name: "internal error logout",
+			body: nil,
+			mock: func(s *mocks.UseCase) {
+				s.On("Logout", mock.Anything, "user", "session").Return(errors.New("test"))
+			},
+			expected: http.StatusInternalServerError,
+			isCookie: true,
+		},
 	}
 
 	for _, tc := range testCase {
@@ -56,6 +95,10 @@ func TestAuthHandlers_Logout(t *testing.T) {
 
 			r := httptest.NewRequest(http.MethodGet, "/api/v1/auth/logout", nil)
 			r.Header = make(http.Header)
+			if tc.isCookie {
+				refreshToken, _ := jwt2.GenerateJWTRefreshToken("user", "session", "token", tokenCfg, testKeys)
+				r.AddCookie(&http.Cookie{Name: constant.RefreshTokenCookie, Value: refreshToken.Token})
+			}
 			c.Request = r
 
 			s := mocks.NewUseCase(t)
@@ -73,7 +116,7 @@ func TestAuthHandlers_Logout(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewAuthHandlers(cfg, s, appLogger)
+			h := NewAuthHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.Logout(c)
@@ -101,7 +144,20 @@ func TestAuthHandlers_Login(t *testing.T) {
 				Password: "Tested8*",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("Login", mock.Anything, mock.Anything).Return(&model.Token{AccessToken: &model.AccessToken{}, RefreshToken: &model.RefreshToken{}}, nil)
+				s.On("Login", mock.Anything, mock.Anything, mock.Anything).
+					Return(&model.LoginToken{Token: &model.Token{AccessToken: &model.AccessToken{}, RefreshToken: &model.RefreshToken{}}}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name: "success login two-factor challenge",
+			body: body.LoginRequest{
+				Email:    "emir@gmail.com",
+				Password: "Tested8*",
+			},
+			mock: func(s *mocks.UseCase) {
+				challengeToken := "challenge"
+				s.On("Login", mock.Anything, mock.Anything, mock.Anything).Return(&model.LoginToken{ChallengeToken: &challengeToken}, nil)
 			},
 			expected: http.StatusOK,
 		},
@@ -127,7 +183,7 @@ func TestAuthHandlers_Login(t *testing.T) {
 				Password: "Tested8*",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("Login", mock.Anything, mock.Anything).Return(nil, errors.New("test"))
+				s.On("Login", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("test"))
 			},
 			expected: http.StatusInternalServerError,
 		},
@@ -138,10 +194,21 @@ func TestAuthHandlers_Login(t *testing.T) {
 				Password: "Tested8*",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("Login", mock.Anything, mock.Anything).Return(nil, httperror.New(http.StatusBadRequest, "test"))
+				s.On("Login", mock.Anything, mock.Anything, mock.Anything).Return(nil, httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected: http.StatusBadRequest,
 		},
+		{
+			name: "login locked out",
+			body: body.LoginRequest{
+				Email:    "emir@gmail.com",
+				Password: "Tested8*",
+			},
+			mock: func(s *mocks.UseCase) {
+				s.On("Login", mock.Anything, mock.Anything, mock.Anything).Return(nil, &attempt.LockedError{RetryAfter: time.Minute})
+			},
+			expected: http.StatusTooManyRequests,
+		},
 	}
 
 	for _, tc := range testCase {
@@ -176,7 +243,7 @@ func TestAuthHandlers_Login(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewAuthHandlers(cfg, s, appLogger)
+			h := NewAuthHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.Login(c)
@@ -186,6 +253,105 @@ func TestAuthHandlers_Login(t *testing.T) {
 	}
 }
 
+func TestAuthHandlers_LoginTwoFactor(t *testing.T) {
+	challengeToken, _ := jwt2.GenerateJWTTwoFactorToken("user", tokenCfg, testKeys)
+	registerToken, _ := jwt2.GenerateJWTRegisterToken("emir@gmail.com", tokenCfg, testKeys)
+	testCase := []struct {
+		name     string
+		token    string
+		code     string
+		mock     func(s *mocks.UseCase)
+		expected int
+	}{
+		{
+			name:  "success login two-factor",
+			token: challengeToken,
+			code:  "287082",
+			mock: func(s *mocks.UseCase) {
+				s.On("LoginTwoFactor", mock.Anything, "user", "287082", mock.Anything).
+					Return(&model.Token{AccessToken: &model.AccessToken{}, RefreshToken: &model.RefreshToken{}}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "invalid request entity",
+			token:    challengeToken,
+			code:     "",
+			mock:     func(s *mocks.UseCase) {},
+			expected: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "token of another type",
+			token:    registerToken,
+			code:     "287082",
+			mock:     func(s *mocks.UseCase) {},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:  "invalid code",
+			token: challengeToken,
+			code:  "000000",
+			mock: func(s *mocks.UseCase) {
+				s.On("LoginTwoFactor", mock.Anything, "user", "000000", mock.Anything).
+					Return(nil, httperror.New(http.StatusBadRequest, "test"))
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:  "locked out",
+			token: challengeToken,
+			code:  "000000",
+			mock: func(s *mocks.UseCase) {
+				s.On("LoginTwoFactor", mock.Anything, "user", "000000", mock.Anything).
+					Return(nil, &attempt.LockedError{RetryAfter: time.Minute})
+			},
+			expected: http.StatusTooManyRequests,
+		},
+		{
+			name:  "error internal",
+			token: challengeToken,
+			code:  "287082",
+			mock: func(s *mocks.UseCase) {
+				s.On("LoginTwoFactor", mock.Anything, "user", "287082", mock.Anything).Return(nil, errors.New("test"))
+			},
+			expected: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody := body.LoginTwoFactorRequest{ChallengeToken: tc.token, Code: tc.code}
+
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/auth/login/2fa", http.NoBody)
+			MockJsonPost(c, requestBody)
+
+			s := mocks.NewUseCase(t)
+
+			cfg := &config.Config{
+				Logger: config.LoggerConfig{
+					Development:       true,
+					DisableCaller:     false,
+					DisableStacktrace: false,
+					Encoding:          "json",
+					Level:             "info",
+				},
+			}
+
+			appLogger := logger.NewAPILogger(cfg)
+			appLogger.InitLogger()
+
+			h := NewAuthHandlers(cfg, s, testKeys, appLogger)
+
+			tc.mock(s)
+			h.LoginTwoFactor(c)
+
+			assert.Equal(t, rr.Code, tc.expected)
+		})
+	}
+}
+
 func TestAuthHandlers_RefreshToken(t *testing.T) {
 	testCase := []struct {
 		name      string
@@ -199,7 +365,7 @@ func TestAuthHandlers_RefreshToken(t *testing.T) {
 			name: "success refresh",
 			body: nil,
 			mock: func(s *mocks.UseCase) {
-				s.On("RefreshToken", mock.Anything, mock.Anything, mock.Anything).Return(&model.AccessToken{}, nil)
+				s.On("RefreshToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&model.Token{AccessToken: &model.AccessToken{}, RefreshToken: &model.RefreshToken{}}, nil)
 			},
 			expected:  http.StatusOK,
 			isCookie:  true,
@@ -225,17 +391,27 @@ func TestAuthHandlers_RefreshToken(t *testing.T) {
 			name: "internal error refresh",
 			body: nil,
 			mock: func(s *mocks.UseCase) {
-				s.On("RefreshToken", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("test"))
+				s.On("RefreshToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("test"))
 			},
 			expected:  http.StatusInternalServerError,
 			isCookie:  true,
 			cookieKey: "",
 		},
+		{
+			name: "reused token refresh",
+			body: nil,
+			mock: func(s *mocks.UseCase) {
+				s.On("RefreshToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, session.ErrRefreshTokenReused)
+			},
+			expected:  http.StatusForbidden,
+			isCookie:  true,
+			cookieKey: "",
+		},
 		{
 			name: "custom error refresh",
 			body: nil,
 			mock: func(s *mocks.UseCase) {
-				s.On("RefreshToken", mock.Anything, mock.Anything, mock.Anything).Return(nil, httperror.New(http.StatusBadRequest, "test"))
+				s.On("RefreshToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected:  http.StatusBadRequest,
 			isCookie:  true,
@@ -252,8 +428,8 @@ func TestAuthHandlers_RefreshToken(t *testing.T) {
 
 			r.Header = make(http.Header)
 			if tc.isCookie {
-				tokenString, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt2.RefreshClaims{}).SignedString([]byte(tc.cookieKey))
-				r.AddCookie(&http.Cookie{Name: constant.RefreshTokenCookie, Value: tokenString})
+				refreshToken, _ := jwt2.GenerateJWTRefreshToken("", "", "", tokenCfg, signingKeys(tc.cookieKey))
+				r.AddCookie(&http.Cookie{Name: constant.RefreshTokenCookie, Value: refreshToken.Token})
 			}
 
 			c.Request = r
@@ -273,7 +449,7 @@ func TestAuthHandlers_RefreshToken(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewAuthHandlers(cfg, s, appLogger)
+			h := NewAuthHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.RefreshToken(c)
@@ -372,7 +548,7 @@ func TestAuthHandlers_RegisterEmail(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewAuthHandlers(cfg, s, appLogger)
+			h := NewAuthHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.RegisterEmail(c)
@@ -502,7 +678,7 @@ func TestAuthHandlers_RegisterUser(t *testing.T) {
 			r := httptest.NewRequest(http.MethodPut, "/api/v1/auth/register", bytes.NewBuffer(jsonValue))
 			r.Header = make(http.Header)
 			if tc.isCookie {
-				tokenString, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt2.RegisterClaims{Email: "emir@gmail.com"}).SignedString([]byte(tc.cookieKey))
+				tokenString, _ := jwt2.GenerateJWTRegisterToken("emir@gmail.com", tokenCfg, signingKeys(tc.cookieKey))
 				r.AddCookie(&http.Cookie{Name: constant.RegisterTokenCookie, Value: tokenString})
 			}
 
@@ -525,7 +701,7 @@ func TestAuthHandlers_RegisterUser(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewAuthHandlers(cfg, s, appLogger)
+			h := NewAuthHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.RegisterUser(c)
@@ -553,7 +729,7 @@ func TestAuthHandlers_VerifyOTP(t *testing.T) {
 				OTP:   "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("VerifyOTP", mock.Anything, mock.Anything).Return("", nil)
+				s.On("VerifyOTP", mock.Anything, mock.Anything, mock.Anything).Return("", nil)
 			},
 			expected: http.StatusOK,
 		},
@@ -564,7 +740,7 @@ func TestAuthHandlers_VerifyOTP(t *testing.T) {
 				OTP:   "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("VerifyOTP", mock.Anything, mock.Anything).Return("", errors.New("test"))
+				s.On("VerifyOTP", mock.Anything, mock.Anything, mock.Anything).Return("", errors.New("test"))
 			},
 			expected: http.StatusInternalServerError,
 		},
@@ -575,7 +751,7 @@ func TestAuthHandlers_VerifyOTP(t *testing.T) {
 				OTP:   "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("VerifyOTP", mock.Anything, mock.Anything).Return("", httperror.New(http.StatusBadRequest, "test"))
+				s.On("VerifyOTP", mock.Anything, mock.Anything, mock.Anything).Return("", httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected: http.StatusBadRequest,
 		},
@@ -628,7 +804,7 @@ func TestAuthHandlers_VerifyOTP(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewAuthHandlers(cfg, s, appLogger)
+			h := NewAuthHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.VerifyOTP(c)
@@ -727,7 +903,7 @@ func TestAuthHandlers_ResetPasswordEmail(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewAuthHandlers(cfg, s, appLogger)
+			h := NewAuthHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.ResetPasswordEmail(c)
@@ -827,7 +1003,7 @@ func TestAuthHandlers_ResetPasswordUser(t *testing.T) {
 			r := httptest.NewRequest(http.MethodPatch, "/api/v1/auth/reset-password", bytes.NewBuffer(jsonValue))
 			r.Header = make(http.Header)
 			if tc.isCookie {
-				tokenString, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt2.ResetPasswordClaims{Email: "emir@gmail.com"}).SignedString([]byte(tc.cookieKey))
+				tokenString, _ := jwt2.GenerateJWTResetPasswordToken("emir@gmail.com", "", tokenCfg, signingKeys(tc.cookieKey))
 				r.AddCookie(&http.Cookie{Name: constant.ResetPasswordTokenCookie, Value: tokenString})
 			}
 
@@ -850,7 +1026,7 @@ func TestAuthHandlers_ResetPasswordUser(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewAuthHandlers(cfg, s, appLogger)
+			h := NewAuthHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.ResetPasswordUser(c)
@@ -872,7 +1048,7 @@ func TestAuthHandlers_ResetPasswordVerifyOTP(t *testing.T) {
 			name: "success reset pw verify",
 			body: nil,
 			mock: func(s *mocks.UseCase) {
-				s.On("ResetPasswordVerifyOTP", mock.Anything, mock.Anything).Return("", nil)
+				s.On("ResetPasswordVerifyOTP", mock.Anything, mock.Anything, mock.Anything).Return("", nil)
 			},
 			expected: http.StatusOK,
 			isQuery:  true,
@@ -881,7 +1057,7 @@ func TestAuthHandlers_ResetPasswordVerifyOTP(t *testing.T) {
 			name: "internal error reset pw verify",
 			body: nil,
 			mock: func(s *mocks.UseCase) {
-				s.On("ResetPasswordVerifyOTP", mock.Anything, mock.Anything).Return("", errors.New("test"))
+				s.On("ResetPasswordVerifyOTP", mock.Anything, mock.Anything, mock.Anything).Return("", errors.New("test"))
 			},
 			expected: http.StatusInternalServerError,
 			isQuery:  true,
@@ -890,7 +1066,7 @@ func TestAuthHandlers_ResetPasswordVerifyOTP(t *testing.T) {
 			name: "custom error reset pw verify",
 			body: nil,
 			mock: func(s *mocks.UseCase) {
-				s.On("ResetPasswordVerifyOTP", mock.Anything, mock.Anything).Return("", httperror.New(http.StatusBadRequest, "test"))
+				s.On("ResetPasswordVerifyOTP", mock.Anything, mock.Anything, mock.Anything).Return("", httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected: http.StatusBadRequest,
 			isQuery:  true,
@@ -934,7 +1110,7 @@ func TestAuthHandlers_ResetPasswordVerifyOTP(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewAuthHandlers(cfg, s, appLogger)
+			h := NewAuthHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.ResetPasswordVerifyOTP(c)
@@ -1001,7 +1177,7 @@ func TestAuthHandlers_CheckUniqueUsername(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewAuthHandlers(cfg, s, appLogger)
+			h := NewAuthHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.CheckUniqueUsername(c)
@@ -1093,7 +1269,7 @@ func TestAuthHandlers_CheckUniquePhoneNo(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewAuthHandlers(cfg, s, appLogger)
+			h := NewAuthHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.CheckUniquePhoneNo(c)
@@ -1117,7 +1293,7 @@ func TestAuthHandlers_GoogleAuth(t *testing.T) {
 			name: "success google auth register",
 			body: nil,
 			mock: func(s *mocks.UseCase) {
-				s.On("GoogleAuth", mock.Anything, mock.Anything, mock.Anything).Return(&model.GoogleAuthToken{RegisterToken: &registerToken}, nil)
+				s.On("GoogleAuth", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&model.GoogleAuthToken{RegisterToken: &registerToken}, nil)
 			},
 			expected:   http.StatusOK,
 			queryCode:  "123456",
@@ -1127,7 +1303,7 @@ func TestAuthHandlers_GoogleAuth(t *testing.T) {
 			name: "success google auth login",
 			body: nil,
 			mock: func(s *mocks.UseCase) {
-				s.On("GoogleAuth", mock.Anything, mock.Anything, mock.Anything).Return(&model.GoogleAuthToken{RegisterToken: nil, Token: &model.Token{&model.AccessToken{}, &model.RefreshToken{}}}, nil)
+				s.On("GoogleAuth", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&model.GoogleAuthToken{RegisterToken: nil, Token: &model.Token{&model.AccessToken{}, &model.RefreshToken{}}}, nil)
 			},
 			expected:   http.StatusOK,
 			queryCode:  "123456",
@@ -1137,7 +1313,7 @@ func TestAuthHandlers_GoogleAuth(t *testing.T) {
 			name: "internal error google auth register",
 			body: nil,
 			mock: func(s *mocks.UseCase) {
-				s.On("GoogleAuth", mock.Anything, mock.Anything, mock.Anything).Return(&model.GoogleAuthToken{RegisterToken: &registerToken}, errors.New("test"))
+				s.On("GoogleAuth", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&model.GoogleAuthToken{RegisterToken: &registerToken}, errors.New("test"))
 			},
 			expected:   http.StatusInternalServerError,
 			queryCode:  "123456",
@@ -1147,7 +1323,7 @@ func TestAuthHandlers_GoogleAuth(t *testing.T) {
 			name: "custom error google auth register",
 			body: nil,
 			mock: func(s *mocks.UseCase) {
-				s.On("GoogleAuth", mock.Anything, mock.Anything, mock.Anything).Return(&model.GoogleAuthToken{RegisterToken: &registerToken}, httperror.New(http.StatusBadRequest, "test"))
+				s.On("GoogleAuth", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&model.GoogleAuthToken{RegisterToken: &registerToken}, httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected:   http.StatusBadRequest,
 			queryCode:  "123456",
@@ -1157,7 +1333,7 @@ func TestAuthHandlers_GoogleAuth(t *testing.T) {
 			name: "failed google auth",
 			body: nil,
 			mock: func(s *mocks.UseCase) {
-				s.On("GoogleAuth", mock.Anything, mock.Anything, mock.Anything).Return(&model.GoogleAuthToken{RegisterToken: &registerToken}, nil)
+				s.On("GoogleAuth", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&model.GoogleAuthToken{RegisterToken: &registerToken}, nil)
 			},
 			expected:   http.StatusOK,
 			queryCode:  "123456",
@@ -1168,7 +1344,7 @@ func TestAuthHandlers_GoogleAuth(t *testing.T) {
 			body: nil,
 			mock: func(s *mocks.UseCase) {
 
-				s.On("GoogleAuth", mock.Anything, mock.Anything, mock.Anything).Return(&model.GoogleAuthToken{RegisterToken: &registerToken}, nil)
+				s.On("GoogleAuth", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&model.GoogleAuthToken{RegisterToken: &registerToken}, nil)
 			},
 			expected:   http.StatusOK,
 			queryCode:  "123456",
@@ -1213,7 +1389,7 @@ func TestAuthHandlers_GoogleAuth(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewAuthHandlers(cfg, s, appLogger)
+			h := NewAuthHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.GoogleAuth(c)
@@ -12,6 +12,7 @@ func MapAuthRoutes(authGroup *gin.RouterGroup, h auth.Handlers) {
 	authGroup.POST("/verify", h.VerifyOTP)
 	authGroup.GET("/verify", h.ResetPasswordVerifyOTP)
 	authGroup.POST("/login", h.Login)
+	authGroup.POST("/login/2fa", h.LoginTwoFactor)
 	authGroup.GET("/logout", h.Logout)
 	authGroup.POST("/reset-password", h.ResetPasswordEmail)
 	authGroup.PATCH("/reset-password", h.ResetPasswordUser)
@@ -20,3 +21,7 @@ func MapAuthRoutes(authGroup *gin.RouterGroup, h auth.Handlers) {
 	authGroup.GET("/unique/phone-no/:phone_no", h.CheckUniquePhoneNo)
 	authGroup.GET("/google-oauth", h.GoogleAuth)
 }
+
+func MapWellKnownRoutes(wellKnownGroup *gin.RouterGroup, h auth.Handlers) {
+	wellKnownGroup.GET("/jwks.json", h.JWKS)
+}
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	gin "github.com/gin-gonic/gin"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Handlers is an autogenerated mock type for the Handlers type
+type Handlers struct {
+	mock.Mock
+}
+
+// CheckUniquePhoneNo provides a mock function with given fields: c
+func (_m *Handlers) CheckUniquePhoneNo(c *gin.Context) {
+	_m.Called(c)
+}
+
+// CheckUniqueUsername provides a mock function with given fields: c
+func (_m *Handlers) CheckUniqueUsername(c *gin.Context) {
+	_m.Called(c)
+}
+
+// GoogleAuth provides a mock function with given fields: c
+func (_m *Handlers) GoogleAuth(c *gin.Context) {
+	_m.Called(c)
+}
+
+// Login provides a mock function with given fields: c
+func (_m *Handlers) Login(c *gin.Context) {
+	_m.Called(c)
+}
+
+// LoginTwoFactor provides a mock function with given fields: c
+func (_m *Handlers) LoginTwoFactor(c *gin.Context) {
+	_m.Called(c)
+}
+
+// Logout provides a mock function with given fields: c
+func (_m *Handlers) Logout(c *gin.Context) {
+	_m.Called(c)
+}
+
+// RefreshToken provides a mock function with given fields: c
+func (_m *Handlers) RefreshToken(c *gin.Context) {
+	_m.Called(c)
+}
+
+// RegisterEmail provides a mock function with given fields: c
+func (_m *Handlers) RegisterEmail(c *gin.Context) {
+	_m.Called(c)
+}
+
+// RegisterUser provides a mock function with given fields: c
+func (_m *Handlers) RegisterUser(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ResetPasswordEmail provides a mock function with given fields: c
+func (_m *Handlers) ResetPasswordEmail(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ResetPasswordUser provides a mock function with given fields: c
+func (_m *Handlers) ResetPasswordUser(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ResetPasswordVerifyOTP provides a mock function with given fields: c
+func (_m *Handlers) ResetPasswordVerifyOTP(c *gin.Context) {
+	_m.Called(c)
+}
+
+// VerifyOTP provides a mock function with given fields: c
+func (_m *Handlers) VerifyOTP(c *gin.Context) {
+	_m.Called(c)
+}
+
+type mockConstructorTestingTNewHandlers interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewHandlers creates a new instance of Handlers. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewHandlers(t mockConstructorTestingTNewHandlers) *Handlers {
+	mock := &Handlers{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
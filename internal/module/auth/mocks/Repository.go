@@ -53,13 +53,13 @@ func (_m *Repository) CreateEmailHistory(ctx context.Context, tx postgre.Transac
 	return r0
 }
 
-// CreateUser provides a mock function with given fields: ctx, email
-func (_m *Repository) CreateUser(ctx context.Context, email string) (*model.User, error) {
-	ret := _m.Called(ctx, email)
+// CreateUser provides a mock function with given fields: ctx, tx, email
+func (_m *Repository) CreateUser(ctx context.Context, tx postgre.Transaction, email string) (*model.User, error) {
+	ret := _m.Called(ctx, tx, email)
 
 	var r0 *model.User
-	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
-		r0 = rf(ctx, email)
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) *model.User); ok {
+		r0 = rf(ctx, tx, email)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.User)
@@ -67,8 +67,8 @@ func (_m *Repository) CreateUser(ctx context.Context, email string) (*model.User
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, email)
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string) error); ok {
+		r1 = rf(ctx, tx, email)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -162,13 +162,13 @@ func (_m *Repository) GetOTPValue(ctx context.Context, email string) (string, er
 	return r0, r1
 }
 
-// GetSessionKeyRedis provides a mock function with given fields: ctx, key
-func (_m *Repository) GetSessionKeyRedis(ctx context.Context, key string) ([]string, error) {
-	ret := _m.Called(ctx, key)
+// GetRolePermissions provides a mock function with given fields: ctx, roleID
+func (_m *Repository) GetRolePermissions(ctx context.Context, roleID int) ([]string, error) {
+	ret := _m.Called(ctx, roleID)
 
 	var r0 []string
-	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
-		r0 = rf(ctx, key)
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, roleID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]string)
@@ -176,29 +176,8 @@ func (_m *Repository) GetSessionKeyRedis(ctx context.Context, key string) ([]str
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, key)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// GetSessionRedis provides a mock function with given fields: ctx, key
-func (_m *Repository) GetSessionRedis(ctx context.Context, key string) (string, error) {
-	ret := _m.Called(ctx, key)
-
-	var r0 string
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = rf(ctx, key)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, key)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, roleID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -326,20 +305,6 @@ func (_m *Repository) InsertNewOTPKey(ctx context.Context, email string, otp str
 	return r0
 }
 
-// InsertSessionRedis provides a mock function with given fields: ctx, duration, key, status
-func (_m *Repository) InsertSessionRedis(ctx context.Context, duration int, key string, status string) error {
-	ret := _m.Called(ctx, duration, key, status)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) error); ok {
-		r0 = rf(ctx, duration, key, status)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // UpdatePassword provides a mock function with given fields: ctx, user, password
 func (_m *Repository) UpdatePassword(ctx context.Context, user *model.User, password string) (*model.User, error) {
 	ret := _m.Called(ctx, user, password)
@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	model "murakali/internal/model"
+
+	session "murakali/pkg/session"
 )
 
 // UseCase is an autogenerated mock type for the UseCase type
@@ -58,13 +60,13 @@ func (_m *UseCase) CheckUniqueUsername(ctx context.Context, username string) (bo
 	return r0, r1
 }
 
-// GoogleAuth provides a mock function with given fields: ctx, code, state
-func (_m *UseCase) GoogleAuth(ctx context.Context, code string, state string) (*model.GoogleAuthToken, error) {
-	ret := _m.Called(ctx, code, state)
+// GoogleAuth provides a mock function with given fields: ctx, code, state, client
+func (_m *UseCase) GoogleAuth(ctx context.Context, code string, state string, client session.Client) (*model.GoogleAuthToken, error) {
+	ret := _m.Called(ctx, code, state, client)
 
 	var r0 *model.GoogleAuthToken
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.GoogleAuthToken); ok {
-		r0 = rf(ctx, code, state)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, session.Client) *model.GoogleAuthToken); ok {
+		r0 = rf(ctx, code, state, client)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.GoogleAuthToken)
@@ -72,8 +74,8 @@ func (_m *UseCase) GoogleAuth(ctx context.Context, code string, state string) (*
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, code, state)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, session.Client) error); ok {
+		r1 = rf(ctx, code, state, client)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -81,13 +83,36 @@ func (_m *UseCase) GoogleAuth(ctx context.Context, code string, state string) (*
 	return r0, r1
 }
 
-// Login provides a mock function with given fields: ctx, _a1
-func (_m *UseCase) Login(ctx context.Context, _a1 body.LoginRequest) (*model.Token, error) {
-	ret := _m.Called(ctx, _a1)
+// Login provides a mock function with given fields: ctx, _a1, client
+func (_m *UseCase) Login(ctx context.Context, _a1 body.LoginRequest, client session.Client) (*model.LoginToken, error) {
+	ret := _m.Called(ctx, _a1, client)
+
+	var r0 *model.LoginToken
+	if rf, ok := ret.Get(0).(func(context.Context, body.LoginRequest, session.Client) *model.LoginToken); ok {
+		r0 = rf(ctx, _a1, client)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, body.LoginRequest, session.Client) error); ok {
+		r1 = rf(ctx, _a1, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginTwoFactor provides a mock function with given fields: ctx, userID, code, client
+func (_m *UseCase) LoginTwoFactor(ctx context.Context, userID string, code string, client session.Client) (*model.Token, error) {
+	ret := _m.Called(ctx, userID, code, client)
 
 	var r0 *model.Token
-	if rf, ok := ret.Get(0).(func(context.Context, body.LoginRequest) *model.Token); ok {
-		r0 = rf(ctx, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, session.Client) *model.Token); ok {
+		r0 = rf(ctx, userID, code, client)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Token)
@@ -95,8 +120,8 @@ func (_m *UseCase) Login(ctx context.Context, _a1 body.LoginRequest) (*model.Tok
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, body.LoginRequest) error); ok {
-		r1 = rf(ctx, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, session.Client) error); ok {
+		r1 = rf(ctx, userID, code, client)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -104,22 +129,36 @@ func (_m *UseCase) Login(ctx context.Context, _a1 body.LoginRequest) (*model.Tok
 	return r0, r1
 }
 
-// RefreshToken provides a mock function with given fields: ctx, refreshToken, id
-func (_m *UseCase) RefreshToken(ctx context.Context, refreshToken string, id string) (*model.AccessToken, error) {
-	ret := _m.Called(ctx, refreshToken, id)
+// Logout provides a mock function with given fields: ctx, id, sessionID
+func (_m *UseCase) Logout(ctx context.Context, id string, sessionID string) error {
+	ret := _m.Called(ctx, id, sessionID)
 
-	var r0 *model.AccessToken
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.AccessToken); ok {
-		r0 = rf(ctx, refreshToken, id)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshToken provides a mock function with given fields: ctx, id, sessionID, tokenID
+func (_m *UseCase) RefreshToken(ctx context.Context, id string, sessionID string, tokenID string) (*model.Token, error) {
+	ret := _m.Called(ctx, id, sessionID, tokenID)
+
+	var r0 *model.Token
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *model.Token); ok {
+		r0 = rf(ctx, id, sessionID, tokenID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.AccessToken)
+			r0 = ret.Get(0).(*model.Token)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, refreshToken, id)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, id, sessionID, tokenID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -210,20 +249,20 @@ func (_m *UseCase) ResetPasswordUser(ctx context.Context, email string, _a2 *bod
 	return r0, r1
 }
 
-// ResetPasswordVerifyOTP provides a mock function with given fields: ctx, _a1
-func (_m *UseCase) ResetPasswordVerifyOTP(ctx context.Context, _a1 body.ResetPasswordVerifyOTPRequest) (string, error) {
-	ret := _m.Called(ctx, _a1)
+// ResetPasswordVerifyOTP provides a mock function with given fields: ctx, _a1, clientIP
+func (_m *UseCase) ResetPasswordVerifyOTP(ctx context.Context, _a1 body.ResetPasswordVerifyOTPRequest, clientIP string) (string, error) {
+	ret := _m.Called(ctx, _a1, clientIP)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(context.Context, body.ResetPasswordVerifyOTPRequest) string); ok {
-		r0 = rf(ctx, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, body.ResetPasswordVerifyOTPRequest, string) string); ok {
+		r0 = rf(ctx, _a1, clientIP)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, body.ResetPasswordVerifyOTPRequest) error); ok {
-		r1 = rf(ctx, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, body.ResetPasswordVerifyOTPRequest, string) error); ok {
+		r1 = rf(ctx, _a1, clientIP)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -231,20 +270,20 @@ func (_m *UseCase) ResetPasswordVerifyOTP(ctx context.Context, _a1 body.ResetPas
 	return r0, r1
 }
 
-// VerifyOTP provides a mock function with given fields: ctx, _a1
-func (_m *UseCase) VerifyOTP(ctx context.Context, _a1 body.VerifyOTPRequest) (string, error) {
-	ret := _m.Called(ctx, _a1)
+// VerifyOTP provides a mock function with given fields: ctx, _a1, clientIP
+func (_m *UseCase) VerifyOTP(ctx context.Context, _a1 body.VerifyOTPRequest, clientIP string) (string, error) {
+	ret := _m.Called(ctx, _a1, clientIP)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(context.Context, body.VerifyOTPRequest) string); ok {
-		r0 = rf(ctx, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, body.VerifyOTPRequest, string) string); ok {
+		r0 = rf(ctx, _a1, clientIP)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, body.VerifyOTPRequest) error); ok {
-		r1 = rf(ctx, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, body.VerifyOTPRequest, string) error); ok {
+		r1 = rf(ctx, _a1, clientIP)
 	} else {
 		r1 = ret.Error(1)
 	}
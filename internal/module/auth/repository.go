@@ -9,10 +9,11 @@ import (
 type Repository interface {
 	CheckEmailHistory(ctx context.Context, email string) (*model.EmailHistory, error)
 	GetUserByID(ctx context.Context, id string) (*model.User, error)
+	GetRolePermissions(ctx context.Context, roleID int) ([]string, error)
 	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
 	GetUserByUsername(ctx context.Context, username string) (*model.User, error)
 	GetUserByPhoneNo(ctx context.Context, phoneNo string) (*model.User, error)
-	CreateUser(ctx context.Context, email string) (*model.User, error)
+	CreateUser(ctx context.Context, tx postgre.Transaction, email string) (*model.User, error)
 	UpdatePassword(ctx context.Context, user *model.User, password string) (*model.User, error)
 	InsertNewOTPKey(ctx context.Context, email, otp string) error
 	InsertNewOTPHashedKey(ctx context.Context, hashedOTP, email, otp string) error
@@ -22,7 +23,4 @@ type Repository interface {
 	UpdateUser(ctx context.Context, tx postgre.Transaction, user *model.User) error
 	DeleteOTPValue(ctx context.Context, email string) (int64, error)
 	CreateUserGoogle(ctx context.Context, tx postgre.Transaction, user *model.User) (*model.User, error)
-	InsertSessionRedis(ctx context.Context, duration int, key, status string) error
-	GetSessionKeyRedis(ctx context.Context, key string) ([]string, error)
-	GetSessionRedis(ctx context.Context, key string) (string, error)
 }
@@ -11,4 +11,6 @@ const (
 	UpdatePasswordQuery     = `UPDATE "user" SET "password" = $1, "updated_at" = now() WHERE "email" = $2`
 	VerifyUserQuery         = `UPDATE "user" SET "phone_no" = $1, "fullname" = $2, "username" = $3, "password" = $4, "is_verify" = $5, "updated_at" = $6 WHERE "email" = $7`
 	CreateUserGoogleQuery   = `INSERT INTO "user" (role_id, username, email, fullname, photo_url, is_sso, is_verify) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING "id", "role_id"`
+	GetRolePermissionsQuery = `SELECT "p"."name" FROM "role_permission" "rp" JOIN "permission" "p" ON "p"."id" = "rp"."permission_id"
+		WHERE "rp"."role_id" = $1 ORDER BY "p"."name"`
 )
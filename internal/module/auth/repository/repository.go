@@ -45,6 +45,30 @@ func (r *authRepo) GetUserByID(ctx context.Context, id string) (*model.User, err
 	return &user, nil
 }
 
+func (r *authRepo) GetRolePermissions(ctx context.Context, roleID int) ([]string, error) {
+	permissions := make([]string, 0)
+	res, err := r.PSQL.QueryContext(ctx, GetRolePermissionsQuery, roleID)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var permission string
+		if errScan := res.Scan(&permission); errScan != nil {
+			return nil, errScan
+		}
+
+		permissions = append(permissions, permission)
+	}
+
+	if res.Err() != nil {
+		return nil, res.Err()
+	}
+
+	return permissions, nil
+}
+
 func (r *authRepo) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
 	var user model.User
 	if err := r.PSQL.QueryRowContext(ctx, GetUserByEmailQuery, email).
@@ -75,9 +99,9 @@ func (r *authRepo) GetUserByPhoneNo(ctx context.Context, phoneNo string) (*model
 	return &user, nil
 }
 
-func (r *authRepo) CreateUser(ctx context.Context, email string) (*model.User, error) {
+func (r *authRepo) CreateUser(ctx context.Context, tx postgre.Transaction, email string) (*model.User, error) {
 	var user model.User
-	if err := r.PSQL.QueryRowContext(ctx, CreateUserQuery, constant.RoleUser, email, false, false).
+	if err := tx.QueryRowContext(ctx, CreateUserQuery, constant.RoleUser, email, false, false).
 		Scan(&user.ID, &user.Email); err != nil {
 		return nil, err
 	}
@@ -141,43 +165,6 @@ func (r *authRepo) InsertNewOTPHashedKey(ctx context.Context, hashedOTP, email,
 	return nil
 }
 
-func (r *authRepo) InsertSessionRedis(ctx context.Context, duration int, key, status string) error {
-	if err := r.RedisClient.Set(ctx, key, status, time.Duration(duration)*time.Minute); err != nil {
-		return err.Err()
-	}
-
-	return nil
-}
-
-func (r *authRepo) GetSessionKeyRedis(ctx context.Context, key string) ([]string, error) {
-	keys := make([]string, 0)
-
-	iter := r.RedisClient.Scan(ctx, 0, key, 0).Iterator()
-	for iter.Next(ctx) {
-		keys = append(keys, iter.Val())
-	}
-
-	if err := iter.Err(); err != nil {
-		return keys, err
-	}
-
-	return keys, nil
-}
-
-func (r *authRepo) GetSessionRedis(ctx context.Context, key string) (string, error) {
-	res := r.RedisClient.Get(ctx, key)
-	if res.Err() != nil {
-		return "", res.Err()
-	}
-
-	value, err := res.Result()
-	if err != nil {
-		return "", err
-	}
-
-	return value, nil
-}
-
 func (r *authRepo) GetOTPValue(ctx context.Context, email string) (string, error) {
 	key := fmt.Sprintf("%s:%s", constant.OtpKey, email)
 
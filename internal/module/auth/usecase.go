@@ -4,18 +4,21 @@ import (
 	"context"
 	"murakali/internal/model"
 	body2 "murakali/internal/module/auth/delivery/body"
+	"murakali/pkg/session"
 )
 
 type UseCase interface {
 	RegisterEmail(ctx context.Context, body body2.RegisterEmailRequest) (*model.User, error)
 	RegisterUser(ctx context.Context, email string, body body2.RegisterUserRequest) error
-	VerifyOTP(ctx context.Context, body body2.VerifyOTPRequest) (string, error)
-	ResetPasswordVerifyOTP(ctx context.Context, body body2.ResetPasswordVerifyOTPRequest) (string, error)
-	Login(ctx context.Context, body body2.LoginRequest) (*model.Token, error)
-	RefreshToken(ctx context.Context, refreshToken, id string) (*model.AccessToken, error)
+	VerifyOTP(ctx context.Context, body body2.VerifyOTPRequest, clientIP string) (string, error)
+	ResetPasswordVerifyOTP(ctx context.Context, body body2.ResetPasswordVerifyOTPRequest, clientIP string) (string, error)
+	Login(ctx context.Context, body body2.LoginRequest, client session.Client) (*model.LoginToken, error)
+	LoginTwoFactor(ctx context.Context, userID, code string, client session.Client) (*model.Token, error)
+	RefreshToken(ctx context.Context, id, sessionID, tokenID string) (*model.Token, error)
+	Logout(ctx context.Context, id, sessionID string) error
 	ResetPasswordEmail(ctx context.Context, body body2.ResetPasswordEmailRequest) (*model.User, error)
 	ResetPasswordUser(ctx context.Context, email string, body *body2.ResetPasswordUserRequest) (*model.User, error)
 	CheckUniqueUsername(ctx context.Context, username string) (bool, error)
 	CheckUniquePhoneNo(ctx context.Context, phoneNo string) (bool, error)
-	GoogleAuth(ctx context.Context, code, state string) (*model.GoogleAuthToken, error)
+	GoogleAuth(ctx context.Context, code, state string, client session.Client) (*model.GoogleAuthToken, error)
 }
@@ -4,19 +4,24 @@ import (
 	"context"
 	"crypto/sha256"
 	"database/sql"
+	"errors"
 	"fmt"
 	"murakali/config"
 	"murakali/internal/constant"
 	"murakali/internal/model"
 	"murakali/internal/module/auth"
 	"murakali/internal/module/auth/delivery/body"
+	"murakali/internal/module/outbox"
+	"murakali/internal/module/twofactor"
 	"murakali/internal/util"
-	smtp "murakali/pkg/email"
+	"murakali/pkg/attempt"
+	mail "murakali/pkg/email"
 	"murakali/pkg/httperror"
 	"murakali/pkg/jwt"
 	"murakali/pkg/oauth"
 	"murakali/pkg/postgre"
 	"murakali/pkg/response"
+	"murakali/pkg/session"
 	"net/http"
 	"strings"
 	"time"
@@ -25,63 +30,173 @@ import (
 )
 
 type authUC struct {
-	cfg      *config.Config
-	txRepo   *postgre.TxRepo
-	authRepo auth.Repository
+	cfg       *config.Config
+	txRepo    *postgre.TxRepo
+	authRepo  auth.Repository
+	sessions  session.Store
+	attempts  attempt.Limiter
+	twoFactor twofactor.UseCase
+	outbox    outbox.UseCase
+	keys      *jwt.Keyring
 }
 
-func NewAuthUseCase(cfg *config.Config, txRepo *postgre.TxRepo, authRepo auth.Repository) auth.UseCase {
-	return &authUC{cfg: cfg, txRepo: txRepo, authRepo: authRepo}
+var (
+	loginPolicy = attempt.Policy{
+		Name: "login", MaxAttempts: 5, MaxIPAttempts: 30,
+		Window: 15 * time.Minute, Lockout: time.Minute, MaxLockout: 24 * time.Hour, Decay: 24 * time.Hour,
+	}
+	registerOTPPolicy = attempt.Policy{
+		Name: "register_otp", MaxAttempts: 5, MaxIPAttempts: 20,
+		Window: 10 * time.Minute, Lockout: 5 * time.Minute, MaxLockout: 24 * time.Hour, Decay: 24 * time.Hour,
+	}
+	resetPasswordOTPPolicy = attempt.Policy{
+		Name: "reset_password_otp", MaxAttempts: 5, MaxIPAttempts: 20,
+		Window: 10 * time.Minute, Lockout: 5 * time.Minute, MaxLockout: 24 * time.Hour, Decay: 24 * time.Hour,
+	}
+	loginTwoFactorPolicy = attempt.Policy{
+		Name: "login_2fa", MaxAttempts: 5, MaxIPAttempts: 30,
+		Window: 15 * time.Minute, Lockout: 5 * time.Minute, MaxLockout: 24 * time.Hour, Decay: 24 * time.Hour,
+	}
+)
+
+func NewAuthUseCase(cfg *config.Config, txRepo *postgre.TxRepo, authRepo auth.Repository, sessions session.Store,
+	attempts attempt.Limiter, twoFactor twofactor.UseCase, outboxUC outbox.UseCase, keys *jwt.Keyring) auth.UseCase {
+	return &authUC{cfg: cfg, txRepo: txRepo, authRepo: authRepo, sessions: sessions, attempts: attempts, twoFactor: twoFactor,
+		outbox: outboxUC, keys: keys}
 }
 
-func (u *authUC) Login(ctx context.Context, requestBody body.LoginRequest) (*model.Token, error) {
+// Login checks the password. An account with 2FA gets a challenge token
+// instead of a session, to be exchanged through LoginTwoFactor.
+func (u *authUC) Login(ctx context.Context, requestBody body.LoginRequest, client session.Client) (*model.LoginToken, error) {
+	account := attempt.Account(strings.ToLower(requestBody.Email))
+	if err := u.attempts.Check(ctx, loginPolicy, account, attempt.IP(client.IP)); err != nil {
+		return nil, err
+	}
+
 	user, err := u.authRepo.GetUserByEmail(ctx, requestBody.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, httperror.New(http.StatusUnauthorized, response.UnauthorizedMessage)
+			return nil, u.failAttempt(ctx, loginPolicy, httperror.New(http.StatusUnauthorized, response.UnauthorizedMessage),
+				account, attempt.IP(client.IP))
 		}
 
 		return nil, err
 	}
 
 	if bcrypt.CompareHashAndPassword([]byte(*user.Password), []byte(requestBody.Password)) != nil {
-		return nil, httperror.New(http.StatusUnauthorized, response.UnauthorizedMessage)
+		return nil, u.failAttempt(ctx, loginPolicy, httperror.New(http.StatusUnauthorized, response.UnauthorizedMessage),
+			account, attempt.IP(client.IP))
 	}
 
-	accessToken, err := jwt.GenerateJWTAccessToken(user.ID.String(), user.RoleID, u.cfg)
+	if err := u.attempts.Reset(ctx, loginPolicy, account); err != nil {
+		return nil, err
+	}
+
+	challengeToken, err := u.twoFactorChallenge(ctx, user.ID.String())
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := jwt.GenerateJWTRefreshToken(user.ID.String(), u.cfg)
+	if challengeToken != nil {
+		return &model.LoginToken{ChallengeToken: challengeToken}, nil
+	}
+
+	token, err := u.createSession(ctx, user, client)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := u.authRepo.InsertSessionRedis(ctx, u.cfg.JWT.AccessExpMin, fmt.Sprintf("session:%s:%s",
-		user.ID.String(), accessToken.Token), constant.TRUE); err != nil {
+	return &model.LoginToken{Token: token}, nil
+}
+
+// LoginTwoFactor finishes the sign in of a user who passed the password check
+// with a code from the authenticator app or one of the recovery codes.
+func (u *authUC) LoginTwoFactor(ctx context.Context, userID, code string, client session.Client) (*model.Token, error) {
+	keys := []attempt.Key{attempt.Account(userID), attempt.IP(client.IP)}
+	if err := u.attempts.Check(ctx, loginTwoFactorPolicy, keys...); err != nil {
 		return nil, err
 	}
 
-	if err := u.authRepo.InsertSessionRedis(ctx, u.cfg.JWT.RefreshExpMin, fmt.Sprintf("session:%s:%s",
-		user.ID.String(), refreshToken.Token), constant.TRUE); err != nil {
+	user, err := u.authRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, httperror.New(http.StatusUnauthorized, response.UnauthorizedMessage)
+		}
+
 		return nil, err
 	}
 
-	return &model.Token{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+	if err := u.twoFactor.VerifyLogin(ctx, userID, code); err != nil {
+		var e *httperror.Error
+		if errors.As(err, &e) {
+			return nil, u.failAttempt(ctx, loginTwoFactorPolicy, err, keys...)
+		}
+
+		return nil, err
+	}
+
+	if err := u.attempts.Reset(ctx, loginTwoFactorPolicy, attempt.Account(userID)); err != nil {
+		return nil, err
+	}
+
+	return u.createSession(ctx, user, client)
 }
 
-func (u *authUC) RefreshToken(ctx context.Context, refreshToken, id string) (*model.AccessToken, error) {
-	key := fmt.Sprintf("session:%s:%s", id, refreshToken)
-	session, err := u.authRepo.GetSessionRedis(ctx, key)
+// twoFactorChallenge returns the challenge token for a user with 2FA, or nil
+// when the user can be signed in right away.
+func (u *authUC) twoFactorChallenge(ctx context.Context, userID string) (*string, error) {
+	enabled, err := u.twoFactor.IsEnabled(ctx, userID)
+	if err != nil || !enabled {
+		return nil, err
+	}
+
+	challengeToken, err := jwt.GenerateJWTTwoFactorToken(userID, u.cfg, u.keys)
 	if err != nil {
-		return nil, httperror.New(http.StatusForbidden, response.ForbiddenMessage)
+		return nil, err
 	}
 
-	if session != constant.TRUE {
-		return nil, httperror.New(http.StatusForbidden, response.ForbiddenMessage)
+	return &challengeToken, nil
+}
+
+// failAttempt records a failed attempt and returns err, or the lockout when
+// this attempt was one too many.
+func (u *authUC) failAttempt(ctx context.Context, policy attempt.Policy, err error, keys ...attempt.Key) error {
+	if errFail := u.attempts.Fail(ctx, policy, keys...); errFail != nil {
+		return errFail
+	}
+
+	return err
+}
+
+// createSession signs a user in on a new device session.
+func (u *authUC) createSession(ctx context.Context, user *model.User, client session.Client) (*model.Token, error) {
+	permissions, err := u.authRepo.GetRolePermissions(ctx, user.RoleID)
+	if err != nil {
+		return nil, err
 	}
 
+	userSession, err := u.sessions.Create(ctx, user.ID.String(), client)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := jwt.GenerateJWTAccessToken(user.ID.String(), user.RoleID, permissions, userSession.ID, u.cfg, u.keys)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := jwt.GenerateJWTRefreshToken(user.ID.String(), userSession.ID, userSession.RefreshTokenID, u.cfg, u.keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Token{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// RefreshToken exchanges a refresh token for a new access and refresh token
+// pair. A refresh token that was already exchanged revokes its session and
+// returns session.ErrRefreshTokenReused.
+func (u *authUC) RefreshToken(ctx context.Context, id, sessionID, tokenID string) (*model.Token, error) {
 	user, err := u.authRepo.GetUserByID(ctx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -91,17 +206,39 @@ func (u *authUC) RefreshToken(ctx context.Context, refreshToken, id string) (*mo
 		return nil, err
 	}
 
-	accessToken, err := jwt.GenerateJWTAccessToken(user.ID.String(), user.RoleID, u.cfg)
+	permissions, err := u.authRepo.GetRolePermissions(ctx, user.RoleID)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := u.authRepo.InsertSessionRedis(ctx, u.cfg.JWT.AccessExpMin, fmt.Sprintf("session:%s:%s",
-		user.ID.String(), accessToken.Token), constant.TRUE); err != nil {
+	nextTokenID, err := u.sessions.Rotate(ctx, id, sessionID, tokenID)
+	if err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return nil, httperror.New(http.StatusForbidden, response.ForbiddenMessage)
+		}
+
+		return nil, err
+	}
+
+	accessToken, err := jwt.GenerateJWTAccessToken(user.ID.String(), user.RoleID, permissions, sessionID, u.cfg, u.keys)
+	if err != nil {
 		return nil, err
 	}
 
-	return accessToken, nil
+	refreshToken, err := jwt.GenerateJWTRefreshToken(user.ID.String(), sessionID, nextTokenID, u.cfg, u.keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Token{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+func (u *authUC) Logout(ctx context.Context, id, sessionID string) error {
+	if err := u.sessions.Revoke(ctx, id, sessionID); err != nil && !errors.Is(err, session.ErrNotFound) {
+		return err
+	}
+
+	return nil
 }
 
 func (u *authUC) RegisterEmail(ctx context.Context, requestBody body.RegisterEmailRequest) (*model.User, error) {
@@ -128,19 +265,23 @@ func (u *authUC) RegisterEmail(ctx context.Context, requestBody body.RegisterEma
 			return nil, httperror.New(http.StatusBadRequest, response.UserAlreadyExistMessage)
 		}
 
-		if errEmail := u.SendOTPEmail(ctx, user.Email); errEmail != nil {
+		if errEmail := u.SendOTPEmail(ctx, u.txRepo.PSQL, user.Email); errEmail != nil {
 			return nil, errEmail
 		}
 
 		return user, nil
 	}
 
-	user, err = u.authRepo.CreateUser(ctx, requestBody.Email)
-	if err != nil {
-		return nil, err
-	}
+	err = u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		var errCreate error
+		user, errCreate = u.authRepo.CreateUser(ctx, tx, requestBody.Email)
+		if errCreate != nil {
+			return errCreate
+		}
 
-	if err := u.SendOTPEmail(ctx, user.Email); err != nil {
+		return u.SendOTPEmail(ctx, tx, user.Email)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -290,31 +431,35 @@ func (u *authUC) ResetPasswordUser(ctx context.Context, email string, requestBod
 		return nil, err
 	}
 
-	keys, errKey := u.authRepo.GetSessionKeyRedis(ctx, fmt.Sprintf("session:%s:*", user.ID.String()))
-	if errKey != nil {
-		return nil, errKey
-	}
-
-	for _, key := range keys {
-		if err := u.authRepo.InsertSessionRedis(ctx, u.cfg.JWT.AccessExpMin, key, constant.FALSE); err != nil {
-			return nil, err
-		}
+	if err := u.sessions.RevokeAll(ctx, user.ID.String()); err != nil {
+		return nil, err
 	}
 
 	return user, nil
 }
 
-func (u *authUC) VerifyOTP(ctx context.Context, requestBody body.VerifyOTPRequest) (string, error) {
+func (u *authUC) VerifyOTP(ctx context.Context, requestBody body.VerifyOTPRequest, clientIP string) (string, error) {
+	account := attempt.Account(strings.ToLower(requestBody.Email))
+	if err := u.attempts.Check(ctx, registerOTPPolicy, account, attempt.IP(clientIP)); err != nil {
+		return "", err
+	}
+
 	value, err := u.authRepo.GetOTPValue(ctx, requestBody.Email)
 	if err != nil {
-		return "", httperror.New(http.StatusBadRequest, response.OTPAlreadyExpiredMessage)
+		return "", u.failAttempt(ctx, registerOTPPolicy, httperror.New(http.StatusBadRequest, response.OTPAlreadyExpiredMessage),
+			account, attempt.IP(clientIP))
 	}
 
 	if value != requestBody.OTP {
-		return "", httperror.New(http.StatusBadRequest, response.OTPIsNotValidMessage)
+		return "", u.failAttempt(ctx, registerOTPPolicy, httperror.New(http.StatusBadRequest, response.OTPIsNotValidMessage),
+			account, attempt.IP(clientIP))
+	}
+
+	if err := u.attempts.Reset(ctx, registerOTPPolicy, account); err != nil {
+		return "", err
 	}
 
-	registerToken, err := jwt.GenerateJWTRegisterToken(requestBody.Email, u.cfg)
+	registerToken, err := jwt.GenerateJWTRegisterToken(requestBody.Email, u.cfg, u.keys)
 	if err != nil {
 		return "", err
 	}
@@ -327,10 +472,23 @@ func (u *authUC) VerifyOTP(ctx context.Context, requestBody body.VerifyOTPReques
 	return registerToken, nil
 }
 
-func (u *authUC) ResetPasswordVerifyOTP(ctx context.Context, requestBody body.ResetPasswordVerifyOTPRequest) (string, error) {
+// ResetPasswordVerifyOTP only knows the account once the code matched one, so
+// unknown codes are counted against the client IP alone.
+func (u *authUC) ResetPasswordVerifyOTP(ctx context.Context, requestBody body.ResetPasswordVerifyOTPRequest, clientIP string) (string, error) {
+	if err := u.attempts.Check(ctx, resetPasswordOTPPolicy, attempt.IP(clientIP)); err != nil {
+		return "", err
+	}
+
 	value, err := u.authRepo.GetOTPHashedValue(ctx, requestBody.Code)
 	if err != nil {
-		return "", httperror.New(http.StatusBadRequest, response.OTPAlreadyExpiredMessage)
+		return "", u.failAttempt(ctx, resetPasswordOTPPolicy, httperror.New(http.StatusBadRequest, response.OTPAlreadyExpiredMessage),
+			attempt.IP(clientIP))
+	}
+
+	valueSplit := strings.Split(value, " ")
+	account := attempt.Account(strings.ToLower(valueSplit[0]))
+	if err := u.attempts.Check(ctx, resetPasswordOTPPolicy, account); err != nil {
+		return "", err
 	}
 
 	h := sha256.New()
@@ -338,11 +496,15 @@ func (u *authUC) ResetPasswordVerifyOTP(ctx context.Context, requestBody body.Re
 	hashedOTP := fmt.Sprintf("%x", h.Sum(nil))
 
 	if hashedOTP != requestBody.Code {
-		return "", httperror.New(http.StatusBadRequest, response.OTPIsNotValidMessage)
+		return "", u.failAttempt(ctx, resetPasswordOTPPolicy, httperror.New(http.StatusBadRequest, response.OTPIsNotValidMessage),
+			account, attempt.IP(clientIP))
 	}
 
-	valueSplit := strings.Split(value, " ")
-	resetPasswordToken, err := jwt.GenerateJWTResetPasswordToken(valueSplit[0], hashedOTP, u.cfg)
+	if err := u.attempts.Reset(ctx, resetPasswordOTPPolicy, account); err != nil {
+		return "", err
+	}
+
+	resetPasswordToken, err := jwt.GenerateJWTResetPasswordToken(valueSplit[0], hashedOTP, u.cfg, u.keys)
 	if err != nil {
 		return "", err
 	}
@@ -355,7 +517,7 @@ func (u *authUC) ResetPasswordVerifyOTP(ctx context.Context, requestBody body.Re
 	return resetPasswordToken, nil
 }
 
-func (u *authUC) SendOTPEmail(ctx context.Context, email string) error {
+func (u *authUC) SendOTPEmail(ctx context.Context, tx postgre.Transaction, email string) error {
 	otp, err := util.GenerateOTP(6)
 	if err != nil {
 		return err
@@ -365,11 +527,12 @@ func (u *authUC) SendOTPEmail(ctx context.Context, email string) error {
 		return err
 	}
 
-	subject := "Email Verification!"
-	msg := smtp.VerificationEmailBody(otp)
-	go smtp.SendEmail(u.cfg, email, subject, msg)
+	msg, err := mail.NewMessage(email, "Email Verification!", mail.TemplateVerificationOTP, mail.OTPData{OTP: otp})
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return u.outbox.Enqueue(ctx, tx, msg)
 }
 
 func (u *authUC) SendLinkOTPEmail(ctx context.Context, email string) error {
@@ -388,11 +551,12 @@ func (u *authUC) SendLinkOTPEmail(ctx context.Context, email string) error {
 
 	link := fmt.Sprintf("%s/verify?code=%s", u.cfg.Server.Origin, hashedOTP)
 
-	subject := "Reset Password!"
-	msg := smtp.VerificationEmailLinkOTPBody(link)
-	go smtp.SendEmail(u.cfg, email, subject, msg)
+	msg, err := mail.NewMessage(email, "Reset Password!", mail.TemplateResetPassword, mail.LinkData{Link: link})
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return u.outbox.Enqueue(ctx, u.txRepo.PSQL, msg)
 }
 
 func (u *authUC) CheckUniqueUsername(ctx context.Context, username string) (bool, error) {
@@ -417,7 +581,7 @@ func (u *authUC) CheckUniquePhoneNo(ctx context.Context, phoneNo string) (bool,
 	return false, nil
 }
 
-func (u *authUC) GoogleAuth(ctx context.Context, code, state string) (*model.GoogleAuthToken, error) {
+func (u *authUC) GoogleAuth(ctx context.Context, code, state string, client session.Client) (*model.GoogleAuthToken, error) {
 	tokenRes, err := oauth.GetGoogleOauthToken(u.cfg, code)
 	if err != nil {
 		return nil, httperror.New(http.StatusForbidden, response.ForbiddenMessage)
@@ -465,7 +629,7 @@ func (u *authUC) GoogleAuth(ctx context.Context, code, state string) (*model.Goo
 				return nil, err
 			}
 
-			registerToken, errToken := jwt.GenerateJWTRegisterToken(userAuth.Email, u.cfg)
+			registerToken, errToken := jwt.GenerateJWTRegisterToken(userAuth.Email, u.cfg, u.keys)
 			if errToken != nil {
 				return nil, errToken
 			}
@@ -474,7 +638,7 @@ func (u *authUC) GoogleAuth(ctx context.Context, code, state string) (*model.Goo
 		}
 
 		if !user.IsVerify {
-			registerToken, errToken := jwt.GenerateJWTRegisterToken(userAuth.Email, u.cfg)
+			registerToken, errToken := jwt.GenerateJWTRegisterToken(userAuth.Email, u.cfg, u.keys)
 			if errToken != nil {
 				return nil, errToken
 			}
@@ -485,25 +649,19 @@ func (u *authUC) GoogleAuth(ctx context.Context, code, state string) (*model.Goo
 		return nil, err
 	}
 
-	accessToken, err := jwt.GenerateJWTAccessToken(user.ID.String(), user.RoleID, u.cfg)
+	challengeToken, err := u.twoFactorChallenge(ctx, user.ID.String())
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := jwt.GenerateJWTRefreshToken(user.ID.String(), u.cfg)
-	if err != nil {
-		return nil, err
+	if challengeToken != nil {
+		return &model.GoogleAuthToken{ChallengeToken: challengeToken}, nil
 	}
 
-	if err := u.authRepo.InsertSessionRedis(ctx, u.cfg.JWT.AccessExpMin, fmt.Sprintf("session:%s:%s",
-		user.ID.String(), accessToken.Token), constant.TRUE); err != nil {
-		return nil, err
-	}
-
-	if err := u.authRepo.InsertSessionRedis(ctx, u.cfg.JWT.RefreshExpMin, fmt.Sprintf("session:%s:%s",
-		user.ID.String(), refreshToken.Token), constant.TRUE); err != nil {
+	token, err := u.createSession(ctx, user, client)
+	if err != nil {
 		return nil, err
 	}
 
-	return &model.GoogleAuthToken{Token: &model.Token{AccessToken: accessToken, RefreshToken: refreshToken}}, nil
+	return &model.GoogleAuthToken{Token: token}, nil
 }
@@ -5,51 +5,83 @@ import (
 	"database/sql"
 	"fmt"
 	"murakali/config"
+	"murakali/internal/constant"
 	"murakali/internal/model"
 	"murakali/internal/module/auth/delivery/body"
 	"murakali/internal/module/auth/mocks"
+	outboxMocks "murakali/internal/module/outbox/mocks"
+	twoFactorMocks "murakali/internal/module/twofactor/mocks"
+	"murakali/pkg/attempt"
+	attemptMocks "murakali/pkg/attempt/mocks"
+	mail "murakali/pkg/email"
 	"murakali/pkg/httperror"
+	"murakali/pkg/jwt/jwttest"
 	"murakali/pkg/postgre"
 	"murakali/pkg/response"
+	"murakali/pkg/session"
+	sessionMocks "murakali/pkg/session/mocks"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// testKeys signs the tokens the use cases issue.
+var testKeys = jwttest.NewKeyring("test")
+
 func TestAuthUseCase_Login(t *testing.T) {
 	passwordHash := "$2a$10$WKul/6gjYoYjOXuNVX4XGen1ZkWYb1PKFiI5vlZp5TFerZh6nTujG"
 	testCase := []struct {
-		name        string
-		body        body.LoginRequest
-		mock        func(t *testing.T, r *mocks.Repository)
-		expectedErr error
+		name              string
+		body              body.LoginRequest
+		expectedChallenge bool
+		mock              func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase)
+		expectedErr       error
 	}{
 		{
 			name: "success login",
 			body: body.LoginRequest{Email: "a@test.com", Password: "Tested8*"},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", mock.Anything, loginPolicy, attempt.Account("a@test.com"), attempt.IP("10.0.0.1")).Return(nil)
+				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(&model.User{Email: "a@test.com", Password: &passwordHash}, nil)
+				a.On("Reset", mock.Anything, loginPolicy, attempt.Account("a@test.com")).Return(nil)
+				f.On("IsEnabled", mock.Anything, mock.Anything).Return(false, nil)
+				r.On("GetRolePermissions", mock.Anything, 0).Return([]string{}, nil)
+				s.On("Create", mock.Anything, mock.Anything, session.Client{IP: "10.0.0.1"}).Return(&session.Session{ID: "session"}, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:              "success login with two-factor challenge",
+			body:              body.LoginRequest{Email: "a@test.com", Password: "Tested8*"},
+			expectedChallenge: true,
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", mock.Anything, loginPolicy, attempt.Account("a@test.com"), attempt.IP("10.0.0.1")).Return(nil)
 				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(&model.User{Email: "a@test.com", Password: &passwordHash}, nil)
-				r.On("InsertSessionRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil)
-				r.On("InsertSessionRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil)
+				a.On("Reset", mock.Anything, loginPolicy, attempt.Account("a@test.com")).Return(nil)
+				f.On("IsEnabled", mock.Anything, mock.Anything).Return(true, nil)
 			},
 			expectedErr: nil,
 		},
 		{
 			name: "email not found",
 			body: body.LoginRequest{Email: "a@test.com", Password: "Tested8*"},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", mock.Anything, loginPolicy, attempt.Account("a@test.com"), attempt.IP("10.0.0.1")).Return(nil)
 				r.On("GetUserByEmail", mock.Anything, mock.Anything).
 					Return(nil, sql.ErrNoRows)
+				a.On("Fail", mock.Anything, loginPolicy, attempt.Account("a@test.com"), attempt.IP("10.0.0.1")).Return(nil)
 			},
 			expectedErr: httperror.New(http.StatusUnauthorized, response.UnauthorizedMessage),
 		},
 		{
 			name: "email error",
 			body: body.LoginRequest{Email: "a@test.com", Password: "Tested8*"},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", mock.Anything, loginPolicy, attempt.Account("a@test.com"), attempt.IP("10.0.0.1")).Return(nil)
 				r.On("GetUserByEmail", mock.Anything, mock.Anything).
 					Return(nil, fmt.Errorf("test"))
 			},
@@ -58,27 +90,43 @@ func TestAuthUseCase_Login(t *testing.T) {
 		{
 			name: "wrong password login",
 			body: body.LoginRequest{Email: "a@test.com", Password: "Tested8"},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", mock.Anything, loginPolicy, attempt.Account("a@test.com"), attempt.IP("10.0.0.1")).Return(nil)
 				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(&model.User{Email: "a@test.com", Password: &passwordHash}, nil)
+				a.On("Fail", mock.Anything, loginPolicy, attempt.Account("a@test.com"), attempt.IP("10.0.0.1")).Return(nil)
 			},
 			expectedErr: httperror.New(http.StatusUnauthorized, response.UnauthorizedMessage),
 		},
 		{
-			name: "error access redis",
-			body: body.LoginRequest{Email: "a@test.com", Password: "Tested8*"},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			name: "wrong password locks out",
+			body: body.LoginRequest{Email: "A@test.com", Password: "Tested8"},
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", mock.Anything, loginPolicy, attempt.Account("a@test.com"), attempt.IP("10.0.0.1")).Return(nil)
 				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(&model.User{Email: "a@test.com", Password: &passwordHash}, nil)
-				r.On("InsertSessionRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(fmt.Errorf("test"))
+				a.On("Fail", mock.Anything, loginPolicy, attempt.Account("a@test.com"), attempt.IP("10.0.0.1")).
+					Return(&attempt.LockedError{RetryAfter: time.Minute})
 			},
-			expectedErr: fmt.Errorf("test"),
+			expectedErr: &attempt.LockedError{RetryAfter: time.Minute},
 		},
 		{
-			name: "error refresh redis",
+			name: "locked out login",
 			body: body.LoginRequest{Email: "a@test.com", Password: "Tested8*"},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", mock.Anything, loginPolicy, attempt.Account("a@test.com"), attempt.IP("10.0.0.1")).
+					Return(&attempt.LockedError{RetryAfter: time.Minute})
+			},
+			expectedErr: &attempt.LockedError{RetryAfter: time.Minute},
+		},
+		{
+			name: "error create session",
+			body: body.LoginRequest{Email: "a@test.com", Password: "Tested8*"},
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", mock.Anything, loginPolicy, attempt.Account("a@test.com"), attempt.IP("10.0.0.1")).Return(nil)
 				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(&model.User{Email: "a@test.com", Password: &passwordHash}, nil)
-				r.On("InsertSessionRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(nil)
-				r.On("InsertSessionRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().Return(fmt.Errorf("test"))
+				a.On("Reset", mock.Anything, loginPolicy, attempt.Account("a@test.com")).Return(nil)
+				f.On("IsEnabled", mock.Anything, mock.Anything).Return(false, nil)
+				r.On("GetRolePermissions", mock.Anything, 0).Return([]string{}, nil)
+				s.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
 			},
 			expectedErr: fmt.Errorf("test"),
 		},
@@ -87,13 +135,229 @@ func TestAuthUseCase_Login(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			s := sessionMocks.NewStore(t)
+			a := attemptMocks.NewLimiter(t)
+			f := twoFactorMocks.NewUseCase(t)
+			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{}, r, s, a, f, nil, testKeys)
 
-			tc.mock(t, r)
-			_, err := u.Login(context.Background(), tc.body)
+			tc.mock(t, r, s, a, f)
+			token, err := u.Login(context.Background(), tc.body, session.Client{IP: "10.0.0.1"})
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+				return
+			}
+
+			assert.Equal(t, tc.expectedChallenge, token.ChallengeToken != nil)
+			assert.Equal(t, tc.expectedChallenge, token.Token == nil)
+		})
+	}
+}
+
+func TestAuthUseCase_LoginTwoFactor(t *testing.T) {
+	keys := []interface{}{attempt.Account("user"), attempt.IP("10.0.0.1")}
+	testCase := []struct {
+		name        string
+		code        string
+		mock        func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase)
+		expectedErr error
+	}{
+		{
+			name: "success login two-factor",
+			code: "287082",
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", append([]interface{}{mock.Anything, loginTwoFactorPolicy}, keys...)...).Return(nil)
+				r.On("GetUserByID", mock.Anything, "user").Return(&model.User{}, nil)
+				f.On("VerifyLogin", mock.Anything, "user", "287082").Return(nil)
+				a.On("Reset", mock.Anything, loginTwoFactorPolicy, attempt.Account("user")).Return(nil)
+				r.On("GetRolePermissions", mock.Anything, 0).Return([]string{}, nil)
+				s.On("Create", mock.Anything, mock.Anything, session.Client{IP: "10.0.0.1"}).Return(&session.Session{ID: "session"}, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error invalid code",
+			code: "000000",
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", append([]interface{}{mock.Anything, loginTwoFactorPolicy}, keys...)...).Return(nil)
+				r.On("GetUserByID", mock.Anything, "user").Return(&model.User{}, nil)
+				f.On("VerifyLogin", mock.Anything, "user", "000000").
+					Return(httperror.New(http.StatusBadRequest, response.TwoFactorCodeInvalid))
+				a.On("Fail", append([]interface{}{mock.Anything, loginTwoFactorPolicy}, keys...)...).Return(nil)
+			},
+			expectedErr: httperror.New(http.StatusBadRequest, response.TwoFactorCodeInvalid),
+		},
+		{
+			name: "error invalid code locks out",
+			code: "000000",
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", append([]interface{}{mock.Anything, loginTwoFactorPolicy}, keys...)...).Return(nil)
+				r.On("GetUserByID", mock.Anything, "user").Return(&model.User{}, nil)
+				f.On("VerifyLogin", mock.Anything, "user", "000000").
+					Return(httperror.New(http.StatusBadRequest, response.TwoFactorCodeInvalid))
+				a.On("Fail", append([]interface{}{mock.Anything, loginTwoFactorPolicy}, keys...)...).
+					Return(&attempt.LockedError{RetryAfter: 5 * time.Minute})
+			},
+			expectedErr: &attempt.LockedError{RetryAfter: 5 * time.Minute},
+		},
+		{
+			name: "error locked out",
+			code: "287082",
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", append([]interface{}{mock.Anything, loginTwoFactorPolicy}, keys...)...).
+					Return(&attempt.LockedError{RetryAfter: time.Minute})
+			},
+			expectedErr: &attempt.LockedError{RetryAfter: time.Minute},
+		},
+		{
+			name: "error user not found",
+			code: "287082",
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", append([]interface{}{mock.Anything, loginTwoFactorPolicy}, keys...)...).Return(nil)
+				r.On("GetUserByID", mock.Anything, "user").Return(nil, sql.ErrNoRows)
+			},
+			expectedErr: httperror.New(http.StatusUnauthorized, response.UnauthorizedMessage),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			s := sessionMocks.NewStore(t)
+			a := attemptMocks.NewLimiter(t)
+			f := twoFactorMocks.NewUseCase(t)
+			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{}, r, s, a, f, nil, testKeys)
+
+			tc.mock(t, r, s, a, f)
+			_, err := u.LoginTwoFactor(context.Background(), "user", tc.code, session.Client{IP: "10.0.0.1"})
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Equal(t, err.Error(), tc.expectedErr.Error())
+		})
+	}
+}
+
+func TestAuthUseCase_RefreshToken(t *testing.T) {
+	testCase := []struct {
+		name        string
+		mock        func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store)
+		expectedErr error
+	}{
+		{
+			name: "success refresh",
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store) {
+				r.On("GetUserByID", mock.Anything, "user").Return(&model.User{}, nil)
+				r.On("GetRolePermissions", mock.Anything, 0).Return([]string{}, nil)
+				s.On("Rotate", mock.Anything, "user", "session", "token").Return("next", nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error session revoked",
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store) {
+				r.On("GetUserByID", mock.Anything, "user").Return(&model.User{}, nil)
+				r.On("GetRolePermissions", mock.Anything, 0).Return([]string{}, nil)
+				s.On("Rotate", mock.Anything, "user", "session", "token").Return("", session.ErrNotFound)
+			},
+			expectedErr: httperror.New(http.StatusForbidden, response.ForbiddenMessage),
+		},
+		{
+			name: "error refresh token reused",
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store) {
+				r.On("GetUserByID", mock.Anything, "user").Return(&model.User{}, nil)
+				r.On("GetRolePermissions", mock.Anything, 0).Return([]string{}, nil)
+				s.On("Rotate", mock.Anything, "user", "session", "token").Return("", session.ErrRefreshTokenReused)
+			},
+			expectedErr: session.ErrRefreshTokenReused,
+		},
+		{
+			name: "error rotate session",
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store) {
+				r.On("GetUserByID", mock.Anything, "user").Return(&model.User{}, nil)
+				r.On("GetRolePermissions", mock.Anything, 0).Return([]string{}, nil)
+				s.On("Rotate", mock.Anything, "user", "session", "token").Return("", fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
+		{
+			name: "error get role permissions",
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store) {
+				r.On("GetUserByID", mock.Anything, "user").Return(&model.User{RoleID: constant.RoleSeller}, nil)
+				r.On("GetRolePermissions", mock.Anything, constant.RoleSeller).Return(nil, fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
+		{
+			name: "error user not found",
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store) {
+				r.On("GetUserByID", mock.Anything, "user").Return(nil, sql.ErrNoRows)
+			},
+			expectedErr: httperror.New(http.StatusUnauthorized, response.UnauthorizedMessage),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			s := sessionMocks.NewStore(t)
+			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{}, r, s, nil, nil, nil, testKeys)
+
+			tc.mock(t, r, s)
+			_, err := u.RefreshToken(context.Background(), "user", "session", "token")
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Equal(t, err.Error(), tc.expectedErr.Error())
+		})
+	}
+}
+
+func TestAuthUseCase_Logout(t *testing.T) {
+	testCase := []struct {
+		name        string
+		mock        func(t *testing.T, s *sessionMocks.Store)
+		expectedErr error
+	}{
+		{
+			name: "success logout",
+			mock: func(t *testing.T, s *sessionMocks.Store) {
+				s.On("Revoke", mock.Anything, "user", "session").Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "success session already revoked",
+			mock: func(t *testing.T, s *sessionMocks.Store) {
+				s.On("Revoke", mock.Anything, "user", "session").Return(session.ErrNotFound)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error revoke session",
+			mock: func(t *testing.T, s *sessionMocks.Store) {
+				s.On("Revoke", mock.Anything, "user", "session").Return(fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sessionMocks.NewStore(t)
+			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{}, mocks.NewRepository(t), s, nil, nil, nil, testKeys)
+
+			tc.mock(t, s)
+			err := u.Logout(context.Background(), "user", "session")
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
 			}
+
+			assert.Equal(t, err.Error(), tc.expectedErr.Error())
 		})
 	}
 }
@@ -102,13 +366,13 @@ func TestAuthUseCase_RegisterEmail(t *testing.T) {
 	testCase := []struct {
 		name        string
 		body        interface{}
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase)
 		expectedErr error
 	}{
 		{
 			name: "check email history",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(&model.EmailHistory{}, fmt.Errorf("User already registered."))
 
 			},
@@ -117,7 +381,7 @@ func TestAuthUseCase_RegisterEmail(t *testing.T) {
 		{
 			name: "check email history",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
 
 			},
@@ -126,12 +390,59 @@ func TestAuthUseCase_RegisterEmail(t *testing.T) {
 		{
 			name: "error get user by email",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(nil, nil)
 				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
 			},
 			expectedErr: fmt.Errorf("test"),
 		},
+		{
+			name: "success resend otp to unverified user",
+			body: nil,
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
+				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(nil, nil)
+				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(&model.User{Email: "sammy@gmail.com"}, nil)
+				r.On("InsertNewOTPKey", mock.Anything, "sammy@gmail.com", mock.Anything).Return(nil)
+				o.On("Enqueue", mock.Anything, mock.Anything, mock.MatchedBy(func(msg *mail.Message) bool {
+					return msg.To == "sammy@gmail.com" && msg.Template == mail.TemplateVerificationOTP
+				})).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "success create user",
+			body: nil,
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
+				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(nil, nil)
+				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+				r.On("CreateUser", mock.Anything, mock.Anything, "sammy@gmail.com").Return(&model.User{Email: "sammy@gmail.com"}, nil)
+				r.On("InsertNewOTPKey", mock.Anything, "sammy@gmail.com", mock.Anything).Return(nil)
+				o.On("Enqueue", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error enqueue email",
+			body: nil,
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
+				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(nil, nil)
+				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+				r.On("CreateUser", mock.Anything, mock.Anything, "sammy@gmail.com").Return(&model.User{Email: "sammy@gmail.com"}, nil)
+				r.On("InsertNewOTPKey", mock.Anything, "sammy@gmail.com", mock.Anything).Return(nil)
+				o.On("Enqueue", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
+		{
+			name: "error create user",
+			body: nil,
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
+				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(nil, nil)
+				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+				r.On("CreateUser", mock.Anything, mock.Anything, "sammy@gmail.com").Return(nil, fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
 	}
 
 	for _, tc := range testCase {
@@ -139,12 +450,15 @@ func TestAuthUseCase_RegisterEmail(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			o := outboxMocks.NewUseCase(t)
+			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, o, testKeys)
 
-			tc.mock(t, r)
+			tc.mock(t, r, o)
 			_, err := u.RegisterEmail(context.Background(), body.RegisterEmailRequest{Email: "sammy@gmail.com"})
-			if err != nil {
+			if tc.expectedErr != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
 			}
 		})
 	}
@@ -248,7 +562,7 @@ func TestAuthUseCase_RegisterUser(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			err := u.RegisterUser(context.Background(), "sammy@gmail.com", body.RegisterUserRequest{})
@@ -263,23 +577,26 @@ func TestAuthUseCase_ResetPasswordEmail(t *testing.T) {
 	testCase := []struct {
 		name        string
 		body        interface{}
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase)
 		expectedErr error
 	}{
 		{
 			name: "success check unique username",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(&model.EmailHistory{}, nil)
 				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(&model.User{IsVerify: true}, nil)
 				r.On("InsertNewOTPHashedKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				o.On("Enqueue", mock.Anything, mock.Anything, mock.MatchedBy(func(msg *mail.Message) bool {
+					return msg.Template == mail.TemplateResetPassword
+				})).Return(nil)
 			},
 			expectedErr: httperror.New(http.StatusBadRequest, response.EmailAlreadyExistMessage),
 		},
 		{
 			name: "error check email history",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
 
 			},
@@ -288,7 +605,7 @@ func TestAuthUseCase_ResetPasswordEmail(t *testing.T) {
 		{
 			name: "error check email history",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(nil, nil)
 
 			},
@@ -297,7 +614,7 @@ func TestAuthUseCase_ResetPasswordEmail(t *testing.T) {
 		{
 			name: "error get user by email",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(&model.EmailHistory{}, nil)
 				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
 			},
@@ -306,7 +623,7 @@ func TestAuthUseCase_ResetPasswordEmail(t *testing.T) {
 		{
 			name: "error get user by email user nil",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(&model.EmailHistory{}, nil)
 				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(nil, nil)
 			},
@@ -315,7 +632,7 @@ func TestAuthUseCase_ResetPasswordEmail(t *testing.T) {
 		{
 			name: "error get user by email is not verify",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(&model.EmailHistory{}, nil)
 				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(&model.User{IsVerify: false}, nil)
 			},
@@ -328,9 +645,10 @@ func TestAuthUseCase_ResetPasswordEmail(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			o := outboxMocks.NewUseCase(t)
+			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, o, testKeys)
 
-			tc.mock(t, r)
+			tc.mock(t, r, o)
 			_, err := u.ResetPasswordEmail(context.Background(), body.ResetPasswordEmailRequest{})
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
@@ -343,27 +661,49 @@ func TestAuthUseCase_VerifyOTP(t *testing.T) {
 	testCase := []struct {
 		name        string
 		body        interface{}
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter)
 		expectedErr error
 	}{
 		{
 			name: "success reset password verify otp",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter) {
+				a.On("Check", mock.Anything, registerOTPPolicy, attempt.Account(""), attempt.IP("10.0.0.1")).Return(nil)
 				r.On("GetOTPValue", mock.Anything, mock.Anything).Return("654321", nil)
+				a.On("Reset", mock.Anything, registerOTPPolicy, attempt.Account("")).Return(nil)
 				r.On("DeleteOTPValue", mock.Anything, mock.Anything).Return(int64(1), nil)
 			},
-			expectedErr: fmt.Errorf("OTP already expired."),
+			expectedErr: nil,
 		},
 		{
 			name: "error verify otp",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter) {
+				a.On("Check", mock.Anything, registerOTPPolicy, attempt.Account(""), attempt.IP("10.0.0.1")).Return(nil)
 				r.On("GetOTPValue", mock.Anything, mock.Anything).Return("210832", fmt.Errorf("OTP already expired."))
-
+				a.On("Fail", mock.Anything, registerOTPPolicy, attempt.Account(""), attempt.IP("10.0.0.1")).Return(nil)
 			},
 			expectedErr: fmt.Errorf("OTP already expired."),
 		},
+		{
+			name: "error otp not valid",
+			body: nil,
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter) {
+				a.On("Check", mock.Anything, registerOTPPolicy, attempt.Account(""), attempt.IP("10.0.0.1")).Return(nil)
+				r.On("GetOTPValue", mock.Anything, mock.Anything).Return("210832", nil)
+				a.On("Fail", mock.Anything, registerOTPPolicy, attempt.Account(""), attempt.IP("10.0.0.1")).Return(nil)
+			},
+			expectedErr: httperror.New(http.StatusBadRequest, response.OTPIsNotValidMessage),
+		},
+		{
+			name: "error locked out",
+			body: nil,
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter) {
+				a.On("Check", mock.Anything, registerOTPPolicy, attempt.Account(""), attempt.IP("10.0.0.1")).
+					Return(&attempt.LockedError{RetryAfter: time.Minute})
+			},
+			expectedErr: &attempt.LockedError{RetryAfter: time.Minute},
+		},
 	}
 
 	for _, tc := range testCase {
@@ -371,13 +711,17 @@ func TestAuthUseCase_VerifyOTP(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
-
-			tc.mock(t, r)
-			_, err := u.VerifyOTP(context.Background(), body.VerifyOTPRequest{OTP: "654321"})
-			if err != nil {
-				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			a := attemptMocks.NewLimiter(t)
+			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, a, nil, nil, testKeys)
+
+			tc.mock(t, r, a)
+			_, err := u.VerifyOTP(context.Background(), body.VerifyOTPRequest{OTP: "654321"}, "10.0.0.1")
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
 			}
+
+			assert.Equal(t, err.Error(), tc.expectedErr.Error())
 		})
 	}
 }
@@ -386,16 +730,17 @@ func TestAuthUseCase_ResetPasswordVerifyOTP(t *testing.T) {
 	testCase := []struct {
 		name        string
 		body        interface{}
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter)
 		expectedErr error
 	}{
 
 		{
 			name: "error reset password verify otp",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter) {
+				a.On("Check", mock.Anything, resetPasswordOTPPolicy, attempt.IP("10.0.0.1")).Return(nil)
 				r.On("GetOTPHashedValue", mock.Anything, mock.Anything).Return("", fmt.Errorf("test"))
-
+				a.On("Fail", mock.Anything, resetPasswordOTPPolicy, attempt.IP("10.0.0.1")).Return(nil)
 			},
 			expectedErr: fmt.Errorf("OTP already expired."),
 		},
@@ -406,10 +751,11 @@ func TestAuthUseCase_ResetPasswordVerifyOTP(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			a := attemptMocks.NewLimiter(t)
+			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, a, nil, nil, testKeys)
 
-			tc.mock(t, r)
-			_, err := u.ResetPasswordVerifyOTP(context.Background(), body.ResetPasswordVerifyOTPRequest{Code: "123456"})
+			tc.mock(t, r, a)
+			_, err := u.ResetPasswordVerifyOTP(context.Background(), body.ResetPasswordVerifyOTPRequest{Code: "123456"}, "10.0.0.1")
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
 			}
@@ -422,25 +768,24 @@ func TestAuthUseCase_ResetPasswordUser(t *testing.T) {
 	testCase := []struct {
 		name        string
 		body        interface{}
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store)
 		expectedErr error
 	}{
 		{
 			name: "error  get user by email user nil",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store) {
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(&model.EmailHistory{}, nil)
 				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(&model.User{IsVerify: true, Password: &pass, Username: &username}, nil)
 				r.On("UpdatePassword", mock.Anything, mock.Anything, mock.Anything).Return(&model.User{IsVerify: true, Password: &pass, Username: &username}, nil)
-				r.On("GetSessionKeyRedis", mock.Anything, mock.Anything, mock.Anything).Return([]string{""}, nil)
-				r.On("InsertSessionRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				s.On("RevokeAll", mock.Anything, mock.Anything).Return(nil)
 			},
 			expectedErr: httperror.New(http.StatusBadRequest, response.UserNotVerifyMessage),
 		},
 		{
 			name: "error check email history",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store) {
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
 
 			},
@@ -449,7 +794,7 @@ func TestAuthUseCase_ResetPasswordUser(t *testing.T) {
 		{
 			name: "error  get user by email",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store) {
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(&model.EmailHistory{}, nil)
 				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
 			},
@@ -458,7 +803,7 @@ func TestAuthUseCase_ResetPasswordUser(t *testing.T) {
 		{
 			name: "error  get user by email ",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store) {
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(&model.EmailHistory{}, nil)
 				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(nil, nil)
 			},
@@ -467,7 +812,7 @@ func TestAuthUseCase_ResetPasswordUser(t *testing.T) {
 		{
 			name: "error  get user by email user nil",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store) {
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(&model.EmailHistory{}, nil)
 				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(nil, nil)
 			},
@@ -476,7 +821,7 @@ func TestAuthUseCase_ResetPasswordUser(t *testing.T) {
 		{
 			name: "error  get user by email user nil",
 			body: nil,
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store) {
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(&model.EmailHistory{}, nil)
 				r.On("GetUserByEmail", mock.Anything, mock.Anything).Return(&model.User{IsVerify: false}, nil)
 			},
@@ -489,9 +834,10 @@ func TestAuthUseCase_ResetPasswordUser(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			s := sessionMocks.NewStore(t)
+			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, s, nil, nil, nil, testKeys)
 
-			tc.mock(t, r)
+			tc.mock(t, r, s)
 			_, err := u.ResetPasswordUser(context.Background(), "sammy@gmail.com", &body.ResetPasswordUserRequest{Password: pass})
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
@@ -550,7 +896,7 @@ func TestAuthUseCase_CheckUniqueUsername(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.CheckUniqueUsername(context.Background(), "87738171235")
@@ -602,7 +948,7 @@ func TestAuthUseCase_CheckUniquePhoneNo(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewAuthUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.CheckUniquePhoneNo(context.Background(), "87738171235")
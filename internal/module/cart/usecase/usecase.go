@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"math"
 	"murakali/config"
+	"murakali/internal/model"
 	"murakali/internal/module/cart"
 	"murakali/internal/module/cart/delivery/body"
+	"murakali/internal/util"
 	"murakali/pkg/httperror"
 	"murakali/pkg/pagination"
 	"murakali/pkg/postgre"
@@ -35,39 +37,19 @@ func (u *cartUC) GetCartHoverHome(ctx context.Context, userID string, limit int)
 	}
 
 	for _, cart := range cartHomes {
-		var maxDiscountPrice float64
-		var minProductPrice float64
-		var discountPercentage float64
-		var discountFixPrice float64
-		var resultDiscount float64
 		if cart.MaxDiscountPrice == nil || cart.Quantity > *cart.Quota {
 			continue
 		}
 
-		if cart.MinProductPrice != nil {
-			minProductPrice = *cart.MinProductPrice
-		}
-
-		maxDiscountPrice = *cart.MaxDiscountPrice
-		if cart.DiscountPercentage != nil {
-			discountPercentage = *cart.DiscountPercentage
-			if cart.Price >= minProductPrice && discountPercentage > 0 {
-				resultDiscount = math.Min(maxDiscountPrice,
-					cart.Price*(discountPercentage/100.00))
-			}
-		}
-
-		if cart.DiscountFixPrice != nil {
-			discountFixPrice = *cart.DiscountFixPrice
-			if cart.Price >= minProductPrice && discountFixPrice > 0 {
-				resultDiscount = math.Max(resultDiscount, discountFixPrice)
-				resultDiscount = math.Min(resultDiscount, maxDiscountPrice)
-			}
-		}
-
+		resultDiscount, subPrice := util.CalculateDiscount(cart.Price, &model.Discount{
+			DiscountPercentage: cart.DiscountPercentage,
+			DiscountFixPrice:   cart.DiscountFixPrice,
+			MinProductPrice:    cart.MinProductPrice,
+			MaxDiscountPrice:   cart.MaxDiscountPrice,
+		})
 		if resultDiscount > 0 {
 			cart.ResultDiscount = resultDiscount
-			cart.SubPrice = cart.Price - resultDiscount
+			cart.SubPrice = subPrice
 		}
 	}
 
@@ -250,35 +232,15 @@ func (u *cartUC) CalculateDiscountProduct(p *body.ProductDetailResponse) *body.P
 		return p
 	}
 
-	var maxDiscountPrice float64
-	var minProductPrice float64
-	var discountPercentage float64
-	var discountFixPrice float64
-	var resultDiscount float64
-	if p.Promo.MinProductPrice != nil {
-		minProductPrice = *p.Promo.MinProductPrice
-	}
-
-	maxDiscountPrice = *p.Promo.MaxDiscountPrice
-	if p.Promo.DiscountPercentage != nil {
-		discountPercentage = *p.Promo.DiscountPercentage
-		if p.ProductPrice >= minProductPrice && *p.Promo.DiscountPercentage > 0 {
-			resultDiscount = math.Min(maxDiscountPrice,
-				p.ProductPrice*(discountPercentage/100.00))
-		}
-	}
-
-	if p.Promo.DiscountFixPrice != nil {
-		discountFixPrice = *p.Promo.DiscountFixPrice
-		if p.ProductPrice >= minProductPrice && discountFixPrice > 0 {
-			resultDiscount = math.Max(resultDiscount, discountFixPrice)
-			resultDiscount = math.Min(resultDiscount, maxDiscountPrice)
-		}
-	}
-
+	resultDiscount, subPrice := util.CalculateDiscount(p.ProductPrice, &model.Discount{
+		DiscountPercentage: p.Promo.DiscountPercentage,
+		DiscountFixPrice:   p.Promo.DiscountFixPrice,
+		MinProductPrice:    p.Promo.MinProductPrice,
+		MaxDiscountPrice:   p.Promo.MaxDiscountPrice,
+	})
 	if resultDiscount > 0 {
 		p.Promo.ResultDiscount = resultDiscount
-		p.Promo.SubPrice = p.ProductPrice - resultDiscount
+		p.Promo.SubPrice = subPrice
 	}
 
 	return p
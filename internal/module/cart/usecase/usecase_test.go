@@ -34,15 +34,16 @@ func TestCartUseCase_GetCartHoverHome(t *testing.T) {
 			body: nil,
 			mock: func(t *testing.T, r *mocks.Repository) {
 				var temp float64 = 10
+				tempMoney := model.NewMoney(10)
 				var tempInt int = 10
 				r.On("GetCartHoverHome", mock.Anything, mock.Anything, mock.Anything).Return([]*body.CartHome{
 					{
-						MaxDiscountPrice:   &temp,
-						MinProductPrice:    &temp,
+						MaxDiscountPrice:   &tempMoney,
+						MinProductPrice:    &tempMoney,
 						Quota:              &tempInt,
-						DiscountFixPrice:   &temp,
-						ResultDiscount:     temp,
-						Price:              temp,
+						DiscountFixPrice:   &tempMoney,
+						ResultDiscount:     model.NewMoneyFromFloat(temp),
+						Price:              model.NewMoneyFromFloat(temp),
 						DiscountPercentage: &temp,
 					},
 				}, nil)
@@ -86,6 +87,7 @@ func TestCartUseCase_GetCartHoverHome(t *testing.T) {
 
 func TestCartUseCase_GetCartItems(t *testing.T) {
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	var tempInt int = 10
 	id, _ := uuid.Parse("989d94b7-58fc-4a76-ae01-1c1b47a0755c")
 	testCase := []struct {
@@ -110,17 +112,17 @@ func TestCartUseCase_GetCartItems(t *testing.T) {
 						{
 							Title:        "test",
 							ThumbnailURL: "asdassd",
-							ProductPrice: temp,
+							ProductPrice: model.NewMoneyFromFloat(temp),
 							ProductStock: temp,
 							Quantity:     10,
 							Weight:       float64(tempInt),
 							Promo: &body.PromoResponse{
 								DiscountPercentage: &temp,
-								DiscountFixPrice:   &temp,
-								MinProductPrice:    &temp,
-								MaxDiscountPrice:   &temp,
-								ResultDiscount:     temp,
-								SubPrice:           temp,
+								DiscountFixPrice:   &tempMoney,
+								MinProductPrice:    &tempMoney,
+								MaxDiscountPrice:   &tempMoney,
+								ResultDiscount:     model.NewMoneyFromFloat(temp),
+								SubPrice:           model.NewMoneyFromFloat(temp),
 								Quota:              &tempInt,
 							},
 							Variant: map[string]string{
@@ -132,7 +134,7 @@ func TestCartUseCase_GetCartItems(t *testing.T) {
 					ID:           "989d94b7-58fc-4a76-ae01-1c1b47a0755c",
 					Title:        "Test",
 					ThumbnailURL: "test",
-					ProductPrice: temp,
+					ProductPrice: model.NewMoneyFromFloat(temp),
 					ProductStock: temp,
 					Quantity:     temp,
 					Weight:       temp,
@@ -141,21 +143,21 @@ func TestCartUseCase_GetCartItems(t *testing.T) {
 					},
 					Promo: &body.PromoResponse{
 						DiscountPercentage: &temp,
-						DiscountFixPrice:   &temp,
-						MinProductPrice:    &temp,
-						MaxDiscountPrice:   &temp,
-						ResultDiscount:     temp,
-						SubPrice:           temp,
+						DiscountFixPrice:   &tempMoney,
+						MinProductPrice:    &tempMoney,
+						MaxDiscountPrice:   &tempMoney,
+						ResultDiscount:     model.NewMoneyFromFloat(temp),
+						SubPrice:           model.NewMoneyFromFloat(temp),
 						Quota:              &tempInt,
 					},
 				}}, []*body.PromoResponse{{
 					DiscountPercentage: &temp,
-					DiscountFixPrice:   &temp,
-					MinProductPrice:    &temp,
-					MaxDiscountPrice:   &temp,
-					ResultDiscount:     temp,
+					DiscountFixPrice:   &tempMoney,
+					MinProductPrice:    &tempMoney,
+					MaxDiscountPrice:   &tempMoney,
+					ResultDiscount:     model.NewMoneyFromFloat(temp),
 					Quota:              &tempInt,
-					SubPrice:           temp}}, nil)
+					SubPrice:           model.NewMoneyFromFloat(temp)}}, nil)
 
 			},
 			expectedErr: nil,
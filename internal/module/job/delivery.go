@@ -0,0 +1,9 @@
+package job
+
+import "github.com/gin-gonic/gin"
+
+type Handlers interface {
+	GetJobs(c *gin.Context)
+	GetJobRuns(c *gin.Context)
+	RunJob(c *gin.Context)
+}
@@ -0,0 +1,96 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"murakali/config"
+	"murakali/internal/module/job"
+	"murakali/pkg/httperror"
+	"murakali/pkg/logger"
+	"murakali/pkg/pagination"
+	"murakali/pkg/response"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type jobHandlers struct {
+	cfg    *config.Config
+	jobUC  job.UseCase
+	logger logger.Logger
+}
+
+func NewJobHandlers(cfg *config.Config, jobUC job.UseCase, log logger.Logger) job.Handlers {
+	return &jobHandlers{cfg: cfg, jobUC: jobUC, logger: log}
+}
+
+func (h *jobHandlers) GetJobs(c *gin.Context) {
+	response.SuccessResponse(c.Writer, h.jobUC.GetJobs(), http.StatusOK)
+}
+
+func (h *jobHandlers) GetJobRuns(c *gin.Context) {
+	pgn := &pagination.Pagination{}
+	h.ValidateQueryPagination(c, pgn)
+
+	name := strings.TrimSpace(c.Query("job"))
+	runs, err := h.jobUC.GetJobRuns(c, name, pgn)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerJob, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, runs, http.StatusOK)
+}
+
+func (h *jobHandlers) RunJob(c *gin.Context) {
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	run, err := h.jobUC.StartJob(c, c.Param("name"), fmt.Sprintf("admin:%s", userID.(string)))
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerJob, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, run, http.StatusAccepted)
+}
+
+func (h *jobHandlers) ValidateQueryPagination(c *gin.Context, pgn *pagination.Pagination) {
+	limit := strings.TrimSpace(c.Query("limit"))
+	page := strings.TrimSpace(c.Query("page"))
+
+	var limitFilter int
+	var pageFilter int
+
+	limitFilter, err := strconv.Atoi(limit)
+	if err != nil || limitFilter < 1 {
+		limitFilter = 10
+	}
+
+	pageFilter, err = strconv.Atoi(page)
+	if err != nil || pageFilter < 1 {
+		pageFilter = 1
+	}
+
+	pgn.Limit = limitFilter
+	pgn.Page = pageFilter
+}
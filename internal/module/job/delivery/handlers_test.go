@@ -0,0 +1,156 @@
+package delivery
+
+import (
+	"errors"
+	"murakali/config"
+	"murakali/internal/model"
+	"murakali/internal/module/job/mocks"
+	"murakali/pkg/httperror"
+	"murakali/pkg/logger"
+	"murakali/pkg/pagination"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestLogger() logger.Logger {
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+			Level:             "info",
+		},
+	}
+
+	appLogger := logger.NewAPILogger(cfg)
+	appLogger.InitLogger()
+
+	return appLogger
+}
+
+func TestJobHandlers_GetJobs(t *testing.T) {
+	rr := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rr)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/admin/job/", nil)
+
+	s := mocks.NewUseCase(t)
+	s.On("GetJobs").Return([]*model.JobDefinition{{Name: "order-delivered", Schedule: "@every 1m"}})
+
+	h := NewJobHandlers(&config.Config{}, s, newTestLogger())
+	h.GetJobs(c)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestJobHandlers_GetJobRuns(t *testing.T) {
+	testCase := []struct {
+		name     string
+		mock     func(s *mocks.UseCase)
+		expected int
+	}{
+		{
+			name: "success get job runs",
+			mock: func(s *mocks.UseCase) {
+				s.On("GetJobRuns", mock.Anything, "order-delivered", mock.Anything).Return(&pagination.Pagination{}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name: "error get job runs",
+			mock: func(s *mocks.UseCase) {
+				s.On("GetJobRuns", mock.Anything, "order-delivered", mock.Anything).Return(nil, errors.New("test"))
+			},
+			expected: http.StatusInternalServerError,
+		},
+		{
+			name: "error get job runs custom error",
+			mock: func(s *mocks.UseCase) {
+				s.On("GetJobRuns", mock.Anything, "order-delivered", mock.Anything).Return(nil, httperror.New(http.StatusNotFound, "test"))
+			},
+			expected: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/admin/job/run?job=order-delivered&page=2&limit=5", nil)
+
+			s := mocks.NewUseCase(t)
+			h := NewJobHandlers(&config.Config{}, s, newTestLogger())
+
+			tc.mock(s)
+			h.GetJobRuns(c)
+
+			assert.Equal(t, tc.expected, rr.Code)
+		})
+	}
+}
+
+func TestJobHandlers_RunJob(t *testing.T) {
+	testCase := []struct {
+		name      string
+		userIDCtx string
+		mock      func(s *mocks.UseCase)
+		expected  int
+	}{
+		{
+			name:      "success run job",
+			userIDCtx: "123456",
+			mock: func(s *mocks.UseCase) {
+				s.On("StartJob", mock.Anything, "order-delivered", "admin:123456").Return(&model.JobRun{}, nil)
+			},
+			expected: http.StatusAccepted,
+		},
+		{
+			name:      "error unauthorized",
+			userIDCtx: "",
+			mock:      func(s *mocks.UseCase) {},
+			expected:  http.StatusUnauthorized,
+		},
+		{
+			name:      "error already running",
+			userIDCtx: "123456",
+			mock: func(s *mocks.UseCase) {
+				s.On("StartJob", mock.Anything, "order-delivered", "admin:123456").
+					Return(nil, httperror.New(http.StatusConflict, "test"))
+			},
+			expected: http.StatusConflict,
+		},
+		{
+			name:      "error run job",
+			userIDCtx: "123456",
+			mock: func(s *mocks.UseCase) {
+				s.On("StartJob", mock.Anything, "order-delivered", "admin:123456").Return(nil, errors.New("test"))
+			},
+			expected: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/admin/job/order-delivered/run", nil)
+			c.Params = gin.Params{{Key: "name", Value: "order-delivered"}}
+			if tc.userIDCtx != "" {
+				c.Set("userID", tc.userIDCtx)
+			}
+
+			s := mocks.NewUseCase(t)
+			h := NewJobHandlers(&config.Config{}, s, newTestLogger())
+
+			tc.mock(s)
+			h.RunJob(c)
+
+			assert.Equal(t, tc.expected, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package delivery
+
+import (
+	"murakali/internal/constant"
+	"murakali/internal/middleware"
+	"murakali/internal/module/job"
+
+	"github.com/gin-gonic/gin"
+)
+
+func MapJobRoutes(jobGroup *gin.RouterGroup, h job.Handlers, mw *middleware.MWManager) {
+	jobGroup.Use(mw.AuthJWTMiddleware())
+	jobGroup.Use(mw.RequirePermission(constant.PermissionJobManage))
+	jobGroup.GET("/", h.GetJobs)
+	jobGroup.GET("/run", h.GetJobRuns)
+	jobGroup.POST("/:name/run", h.RunJob)
+}
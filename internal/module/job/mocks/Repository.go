@@ -0,0 +1,163 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "murakali/internal/model"
+
+	pagination "murakali/pkg/pagination"
+
+	time "time"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// AcquireJobLock provides a mock function with given fields: ctx, name, token, ttl
+func (_m *Repository) AcquireJobLock(ctx context.Context, name string, token string, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, name, token, ttl)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) bool); ok {
+		r0 = rf(ctx, name, token, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, name, token, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimJobSlot provides a mock function with given fields: ctx, name, slot, ttl
+func (_m *Repository) ClaimJobSlot(ctx context.Context, name string, slot time.Time, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, name, slot, ttl)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Duration) bool); ok {
+		r0 = rf(ctx, name, slot, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Duration) error); ok {
+		r1 = rf(ctx, name, slot, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateJobRun provides a mock function with given fields: ctx, run
+func (_m *Repository) CreateJobRun(ctx context.Context, run *model.JobRun) error {
+	ret := _m.Called(ctx, run)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.JobRun) error); ok {
+		r0 = rf(ctx, run)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetJobRuns provides a mock function with given fields: ctx, name, pgn
+func (_m *Repository) GetJobRuns(ctx context.Context, name string, pgn *pagination.Pagination) ([]*model.JobRun, error) {
+	ret := _m.Called(ctx, name, pgn)
+
+	var r0 []*model.JobRun
+	if rf, ok := ret.Get(0).(func(context.Context, string, *pagination.Pagination) []*model.JobRun); ok {
+		r0 = rf(ctx, name, pgn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.JobRun)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *pagination.Pagination) error); ok {
+		r1 = rf(ctx, name, pgn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTotalJobRuns provides a mock function with given fields: ctx, name
+func (_m *Repository) GetTotalJobRuns(ctx context.Context, name string) (int64, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseJobLock provides a mock function with given fields: ctx, name, token
+func (_m *Repository) ReleaseJobLock(ctx context.Context, name string, token string) error {
+	ret := _m.Called(ctx, name, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, name, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateJobRun provides a mock function with given fields: ctx, run
+func (_m *Repository) UpdateJobRun(ctx context.Context, run *model.JobRun) error {
+	ret := _m.Called(ctx, run)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.JobRun) error); ok {
+		r0 = rf(ctx, run)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,120 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "murakali/internal/model"
+
+	pagination "murakali/pkg/pagination"
+
+	time "time"
+)
+
+// UseCase is an autogenerated mock type for the UseCase type
+type UseCase struct {
+	mock.Mock
+}
+
+// GetJobRuns provides a mock function with given fields: ctx, name, pgn
+func (_m *UseCase) GetJobRuns(ctx context.Context, name string, pgn *pagination.Pagination) (*pagination.Pagination, error) {
+	ret := _m.Called(ctx, name, pgn)
+
+	var r0 *pagination.Pagination
+	if rf, ok := ret.Get(0).(func(context.Context, string, *pagination.Pagination) *pagination.Pagination); ok {
+		r0 = rf(ctx, name, pgn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pagination.Pagination)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *pagination.Pagination) error); ok {
+		r1 = rf(ctx, name, pgn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJobs provides a mock function with given fields:
+func (_m *UseCase) GetJobs() []*model.JobDefinition {
+	ret := _m.Called()
+
+	var r0 []*model.JobDefinition
+	if rf, ok := ret.Get(0).(func() []*model.JobDefinition); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.JobDefinition)
+		}
+	}
+
+	return r0
+}
+
+// StartJob provides a mock function with given fields: ctx, name, triggeredBy
+func (_m *UseCase) StartJob(ctx context.Context, name string, triggeredBy string) (*model.JobRun, error) {
+	ret := _m.Called(ctx, name, triggeredBy)
+
+	var r0 *model.JobRun
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.JobRun); ok {
+		r0 = rf(ctx, name, triggeredBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.JobRun)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, triggeredBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartScheduledJob provides a mock function with given fields: ctx, name, tick
+func (_m *UseCase) StartScheduledJob(ctx context.Context, name string, tick time.Time) (*model.JobRun, error) {
+	ret := _m.Called(ctx, name, tick)
+
+	var r0 *model.JobRun
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *model.JobRun); ok {
+		r0 = rf(ctx, name, tick)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.JobRun)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, name, tick)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewUseCase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUseCase creates a new instance of UseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUseCase(t mockConstructorTestingTNewUseCase) *UseCase {
+	mock := &UseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
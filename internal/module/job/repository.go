@@ -0,0 +1,18 @@
+package job
+
+import (
+	"context"
+	"murakali/internal/model"
+	"murakali/pkg/pagination"
+	"time"
+)
+
+type Repository interface {
+	AcquireJobLock(ctx context.Context, name, token string, ttl time.Duration) (bool, error)
+	ReleaseJobLock(ctx context.Context, name, token string) error
+	ClaimJobSlot(ctx context.Context, name string, slot time.Time, ttl time.Duration) (bool, error)
+	CreateJobRun(ctx context.Context, run *model.JobRun) error
+	UpdateJobRun(ctx context.Context, run *model.JobRun) error
+	GetTotalJobRuns(ctx context.Context, name string) (int64, error)
+	GetJobRuns(ctx context.Context, name string, pgn *pagination.Pagination) ([]*model.JobRun, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+const (
+	CreateJobRunQuery = `INSERT INTO "job_run" ("job", "status", "triggered_by", "attempt")
+	VALUES ($1, $2, $3, $4) RETURNING "id", "started_at"`
+
+	UpdateJobRunQuery = `UPDATE "job_run" SET "status" = $1, "attempt" = $2, "rows_affected" = $3, "error" = $4,
+	"finished_at" = $5, "duration_ms" = $6 WHERE "id" = $7`
+
+	GetTotalJobRunsQuery = `SELECT count("id") FROM "job_run" WHERE ($1 = '' OR "job" = $1)`
+
+	GetJobRunsQuery = `SELECT "id", "job", "status", "triggered_by", "attempt", "rows_affected", "error",
+	"started_at", "finished_at", "duration_ms"
+	FROM "job_run"
+	WHERE ($1 = '' OR "job" = $1)
+	ORDER BY "started_at" DESC LIMIT $2 OFFSET $3`
+
+	// ReleaseJobLockScript only deletes the lock while it still holds the token
+	// of the run that took it, so an expired lock taken over by another replica
+	// is left alone.
+	ReleaseJobLockScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`
+)
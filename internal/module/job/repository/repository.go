@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"murakali/internal/constant"
+	"murakali/internal/model"
+	"murakali/internal/module/job"
+	"murakali/pkg/pagination"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type jobRepo struct {
+	PSQL        *sql.DB
+	RedisClient *redis.Client
+}
+
+func NewJobRepository(psql *sql.DB, client *redis.Client) job.Repository {
+	return &jobRepo{
+		PSQL:        psql,
+		RedisClient: client,
+	}
+}
+
+func (r *jobRepo) AcquireJobLock(ctx context.Context, name, token string, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf("%s:%s", constant.JobLockKey, name)
+
+	res := r.RedisClient.SetNX(ctx, key, token, ttl)
+	if res.Err() != nil {
+		return false, res.Err()
+	}
+
+	return res.Val(), nil
+}
+
+func (r *jobRepo) ReleaseJobLock(ctx context.Context, name, token string) error {
+	key := fmt.Sprintf("%s:%s", constant.JobLockKey, name)
+
+	return r.RedisClient.Eval(ctx, ReleaseJobLockScript, []string{key}, token).Err()
+}
+
+// ClaimJobSlot marks the schedule slot of a job as taken. The key outlives the
+// run lock, so a replica whose ticker fires later in the same slot skips it.
+func (r *jobRepo) ClaimJobSlot(ctx context.Context, name string, slot time.Time, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf("%s:%s:%d", constant.JobSlotKey, name, slot.Unix())
+
+	res := r.RedisClient.SetNX(ctx, key, slot.Format(time.RFC3339), ttl)
+	if res.Err() != nil {
+		return false, res.Err()
+	}
+
+	return res.Val(), nil
+}
+
+func (r *jobRepo) CreateJobRun(ctx context.Context, run *model.JobRun) error {
+	if err := r.PSQL.QueryRowContext(ctx, CreateJobRunQuery, run.Job, run.Status, run.TriggeredBy, run.Attempt).
+		Scan(&run.ID, &run.StartedAt); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *jobRepo) UpdateJobRun(ctx context.Context, run *model.JobRun) error {
+	_, err := r.PSQL.ExecContext(ctx, UpdateJobRunQuery,
+		run.Status,
+		run.Attempt,
+		run.RowsAffected,
+		run.Error,
+		run.FinishedAt,
+		run.DurationMs,
+		run.ID)
+
+	return err
+}
+
+func (r *jobRepo) GetTotalJobRuns(ctx context.Context, name string) (int64, error) {
+	var total int64
+	if err := r.PSQL.QueryRowContext(ctx, GetTotalJobRunsQuery, name).Scan(&total); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *jobRepo) GetJobRuns(ctx context.Context, name string, pgn *pagination.Pagination) ([]*model.JobRun, error) {
+	runs := make([]*model.JobRun, 0)
+	res, err := r.PSQL.QueryContext(ctx, GetJobRunsQuery, name, pgn.GetLimit(), pgn.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var run model.JobRun
+		if errScan := res.Scan(
+			&run.ID,
+			&run.Job,
+			&run.Status,
+			&run.TriggeredBy,
+			&run.Attempt,
+			&run.RowsAffected,
+			&run.Error,
+			&run.StartedAt,
+			&run.FinishedAt,
+			&run.DurationMs); errScan != nil {
+			return nil, errScan
+		}
+
+		runs = append(runs, &run)
+	}
+
+	if res.Err() != nil {
+		return nil, res.Err()
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,15 @@
+package job
+
+import (
+	"context"
+	"murakali/internal/model"
+	"murakali/pkg/pagination"
+	"time"
+)
+
+type UseCase interface {
+	GetJobs() []*model.JobDefinition
+	StartJob(ctx context.Context, name, triggeredBy string) (*model.JobRun, error)
+	StartScheduledJob(ctx context.Context, name string, tick time.Time) (*model.JobRun, error)
+	GetJobRuns(ctx context.Context, name string, pgn *pagination.Pagination) (*pagination.Pagination, error)
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"murakali/config"
+	"murakali/internal/constant"
+	"murakali/internal/model"
+	"murakali/internal/module/job"
+	"murakali/internal/module/outbox"
+	"murakali/internal/module/product"
+	"murakali/internal/module/seller"
+	"murakali/internal/module/user"
+	"murakali/pkg/httperror"
+	"murakali/pkg/pagination"
+	"murakali/pkg/response"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type jobFunc func(ctx context.Context) (*model.JobReport, error)
+
+type jobEntry struct {
+	definition *model.JobDefinition
+	interval   time.Duration
+	run        jobFunc
+}
+
+type jobUC struct {
+	cfg     *config.Config
+	jobRepo job.Repository
+	jobs    map[string]*jobEntry
+	order   []string
+	backoff time.Duration
+}
+
+func NewJobUseCase(cfg *config.Config, jobRepo job.Repository, sellerUC seller.UseCase, userUC user.UseCase,
+	productUC product.UseCase, outboxUC outbox.UseCase) job.UseCase {
+	u := &jobUC{
+		cfg:     cfg,
+		jobRepo: jobRepo,
+		jobs:    make(map[string]*jobEntry),
+		backoff: time.Duration(constant.JobRetryBackoffSec) * time.Second,
+	}
+
+	u.register(constant.JobOrderDelivered, time.Minute, sellerUC.UpdateOnDeliveryOrder)
+	u.register(constant.JobTransactionExpired, time.Minute, sellerUC.UpdateExpiredAtOrder)
+	u.register(constant.JobRejectedRefund, time.Minute, userUC.CompletedRejectedRefund)
+	u.register(constant.JobProductMetadata, time.Hour, productUC.UpdateProductMetadata)
+	u.register(constant.JobEmailOutbox, 30*time.Second, outboxUC.DispatchEmails)
+
+	return u
+}
+
+func (u *jobUC) register(name string, interval time.Duration, run jobFunc) {
+	definition := &model.JobDefinition{Name: name, Schedule: fmt.Sprintf("@every %s", interval)}
+	u.jobs[name] = &jobEntry{definition: definition, interval: interval, run: run}
+	u.order = append(u.order, name)
+}
+
+func (u *jobUC) GetJobs() []*model.JobDefinition {
+	definitions := make([]*model.JobDefinition, 0, len(u.order))
+	for _, name := range u.order {
+		definitions = append(definitions, u.jobs[name].definition)
+	}
+
+	return definitions
+}
+
+// StartJob takes the job lock, records a running job_run and executes the job
+// in the background. It fails with a conflict when another replica or an
+// earlier trigger still holds the lock.
+func (u *jobUC) StartJob(ctx context.Context, name, triggeredBy string) (*model.JobRun, error) {
+	entry, ok := u.jobs[name]
+	if !ok {
+		return nil, httperror.New(http.StatusNotFound, response.JobNotFound)
+	}
+
+	token := uuid.NewString()
+	locked, err := u.jobRepo.AcquireJobLock(ctx, name, token, time.Duration(constant.JobLockTTLMin)*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	if !locked {
+		return nil, httperror.New(http.StatusConflict, response.JobAlreadyRunning)
+	}
+
+	run := &model.JobRun{Job: name, Status: constant.JobStatusRunning, TriggeredBy: triggeredBy}
+	if err := u.jobRepo.CreateJobRun(ctx, run); err != nil {
+		_ = u.jobRepo.ReleaseJobLock(ctx, name, token)
+		return nil, err
+	}
+
+	started := *run
+	go func() {
+		_ = u.executeJob(context.Background(), entry, run, token)
+	}()
+
+	return &started, nil
+}
+
+// StartScheduledJob starts a job for the schedule slot the tick falls in, the
+// tick truncated to the job interval. Replicas tick at different offsets, so
+// only the first one to claim the slot runs the job; the run lock alone would
+// let every replica run once the previous run released it.
+func (u *jobUC) StartScheduledJob(ctx context.Context, name string, tick time.Time) (*model.JobRun, error) {
+	entry, ok := u.jobs[name]
+	if !ok {
+		return nil, httperror.New(http.StatusNotFound, response.JobNotFound)
+	}
+
+	slot := tick.Truncate(entry.interval)
+	claimed, err := u.jobRepo.ClaimJobSlot(ctx, name, slot, 2*entry.interval)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claimed {
+		return nil, httperror.New(http.StatusConflict, response.JobSlotTaken)
+	}
+
+	return u.StartJob(ctx, name, constant.JobTriggerSchedule)
+}
+
+// executeJob runs the job until it succeeds or runs out of attempts, waiting
+// twice as long after every failure, then stores the outcome and releases the
+// lock. Rows changed by a failed attempt are already committed, so they count.
+func (u *jobUC) executeJob(ctx context.Context, entry *jobEntry, run *model.JobRun, token string) error {
+	defer func() {
+		_ = u.jobRepo.ReleaseJobLock(ctx, run.Job, token)
+	}()
+
+	var err error
+	for attempt := 1; attempt <= constant.JobMaxAttempts; attempt++ {
+		var report *model.JobReport
+		run.Attempt = attempt
+		report, err = runAttempt(ctx, entry.run)
+		if report != nil {
+			run.RowsAffected += report.RowsAffected
+		}
+
+		if err == nil {
+			break
+		}
+
+		if attempt < constant.JobMaxAttempts {
+			time.Sleep(u.backoff * time.Duration(math.Pow(2, float64(attempt-1))))
+		}
+	}
+
+	run.Status = constant.JobStatusSuccess
+	run.Error = ""
+	if err != nil {
+		run.Status = constant.JobStatusFailed
+		run.Error = err.Error()
+	}
+
+	run.FinishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	run.DurationMs = run.FinishedAt.Time.Sub(run.StartedAt).Milliseconds()
+
+	return u.jobRepo.UpdateJobRun(ctx, run)
+}
+
+func runAttempt(ctx context.Context, run jobFunc) (report *model.JobReport, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panic: %v", r)
+		}
+	}()
+
+	return run(ctx)
+}
+
+func (u *jobUC) GetJobRuns(ctx context.Context, name string, pgn *pagination.Pagination) (*pagination.Pagination, error) {
+	if _, ok := u.jobs[name]; name != "" && !ok {
+		return nil, httperror.New(http.StatusNotFound, response.JobNotFound)
+	}
+
+	totalRows, err := u.jobRepo.GetTotalJobRuns(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(math.Ceil(float64(totalRows) / float64(pgn.GetLimit())))
+	pgn.TotalRows = totalRows
+	pgn.TotalPages = totalPages
+
+	runs, err := u.jobRepo.GetJobRuns(ctx, name, pgn)
+	if err != nil {
+		return nil, err
+	}
+
+	pgn.Rows = runs
+	return pgn, nil
+}
@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"murakali/config"
+	"murakali/internal/constant"
+	"murakali/internal/model"
+	"murakali/internal/module/job/mocks"
+	outboxMocks "murakali/internal/module/outbox/mocks"
+	productMocks "murakali/internal/module/product/mocks"
+	sellerMocks "murakali/internal/module/seller/mocks"
+	userMocks "murakali/internal/module/user/mocks"
+	"murakali/pkg/httperror"
+	"murakali/pkg/pagination"
+	"murakali/pkg/response"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestJobUC_StartJob(t *testing.T) {
+	testCase := []struct {
+		name        string
+		job         string
+		mock        func(t *testing.T, r *mocks.Repository, s *sellerMocks.UseCase, done chan struct{})
+		expectedErr error
+	}{
+		{
+			name: "success",
+			job:  constant.JobOrderDelivered,
+			mock: func(t *testing.T, r *mocks.Repository, s *sellerMocks.UseCase, done chan struct{}) {
+				r.On("AcquireJobLock", mock.Anything, constant.JobOrderDelivered, mock.Anything, mock.Anything).Return(true, nil)
+				r.On("CreateJobRun", mock.Anything, mock.Anything).Return(nil)
+				s.On("UpdateOnDeliveryOrder", mock.Anything).Return(&model.JobReport{RowsAffected: 3}, nil)
+				r.On("UpdateJobRun", mock.Anything, mock.MatchedBy(func(run *model.JobRun) bool {
+					return run.Status == constant.JobStatusSuccess && run.RowsAffected == 3 && run.Attempt == 1
+				})).Return(nil)
+				r.On("ReleaseJobLock", mock.Anything, constant.JobOrderDelivered, mock.Anything).
+					Return(nil).Run(func(args mock.Arguments) { close(done) })
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "error job not found",
+			job:         "unknown",
+			mock:        func(t *testing.T, r *mocks.Repository, s *sellerMocks.UseCase, done chan struct{}) { close(done) },
+			expectedErr: httperror.New(http.StatusNotFound, response.JobNotFound),
+		},
+		{
+			name: "error already running",
+			job:  constant.JobOrderDelivered,
+			mock: func(t *testing.T, r *mocks.Repository, s *sellerMocks.UseCase, done chan struct{}) {
+				r.On("AcquireJobLock", mock.Anything, constant.JobOrderDelivered, mock.Anything, mock.Anything).Return(false, nil)
+				close(done)
+			},
+			expectedErr: httperror.New(http.StatusConflict, response.JobAlreadyRunning),
+		},
+		{
+			name: "error create job run releases the lock",
+			job:  constant.JobOrderDelivered,
+			mock: func(t *testing.T, r *mocks.Repository, s *sellerMocks.UseCase, done chan struct{}) {
+				r.On("AcquireJobLock", mock.Anything, constant.JobOrderDelivered, mock.Anything, mock.Anything).Return(true, nil)
+				r.On("CreateJobRun", mock.Anything, mock.Anything).Return(errors.New("test"))
+				r.On("ReleaseJobLock", mock.Anything, constant.JobOrderDelivered, mock.Anything).Return(nil)
+				close(done)
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			s := sellerMocks.NewUseCase(t)
+			u := NewJobUseCase(&config.Config{}, r, s, userMocks.NewUseCase(t), productMocks.NewUseCase(t), outboxMocks.NewUseCase(t))
+
+			done := make(chan struct{})
+			tc.mock(t, r, s, done)
+			run, err := u.StartJob(context.Background(), tc.job, constant.JobTriggerSchedule)
+			<-done
+
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, constant.JobStatusRunning, run.Status)
+			}
+		})
+	}
+}
+
+func TestJobUC_StartScheduledJob(t *testing.T) {
+	slot := time.Date(2022, 12, 1, 10, 5, 0, 0, time.UTC)
+	testCase := []struct {
+		name        string
+		tick        time.Time
+		mock        func(t *testing.T, r *mocks.Repository, s *sellerMocks.UseCase, done chan struct{})
+		expectedErr error
+	}{
+		{
+			name: "success claim the slot",
+			tick: slot.Add(10 * time.Second),
+			mock: func(t *testing.T, r *mocks.Repository, s *sellerMocks.UseCase, done chan struct{}) {
+				r.On("ClaimJobSlot", mock.Anything, constant.JobOrderDelivered, slot, 2*time.Minute).Return(true, nil)
+				r.On("AcquireJobLock", mock.Anything, constant.JobOrderDelivered, mock.Anything, mock.Anything).Return(true, nil)
+				r.On("CreateJobRun", mock.Anything, mock.MatchedBy(func(run *model.JobRun) bool {
+					return run.TriggeredBy == constant.JobTriggerSchedule
+				})).Return(nil)
+				s.On("UpdateOnDeliveryOrder", mock.Anything).Return(&model.JobReport{}, nil)
+				r.On("UpdateJobRun", mock.Anything, mock.Anything).Return(nil)
+				r.On("ReleaseJobLock", mock.Anything, constant.JobOrderDelivered, mock.Anything).
+					Return(nil).Run(func(args mock.Arguments) { close(done) })
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error slot already taken by another replica",
+			tick: slot.Add(50 * time.Second),
+			mock: func(t *testing.T, r *mocks.Repository, s *sellerMocks.UseCase, done chan struct{}) {
+				r.On("ClaimJobSlot", mock.Anything, constant.JobOrderDelivered, slot, 2*time.Minute).Return(false, nil)
+				close(done)
+			},
+			expectedErr: httperror.New(http.StatusConflict, response.JobSlotTaken),
+		},
+		{
+			name: "error ClaimJobSlot",
+			tick: slot,
+			mock: func(t *testing.T, r *mocks.Repository, s *sellerMocks.UseCase, done chan struct{}) {
+				r.On("ClaimJobSlot", mock.Anything, constant.JobOrderDelivered, slot, 2*time.Minute).Return(false, errors.New("test"))
+				close(done)
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			s := sellerMocks.NewUseCase(t)
+			u := NewJobUseCase(&config.Config{}, r, s, userMocks.NewUseCase(t), productMocks.NewUseCase(t), outboxMocks.NewUseCase(t))
+
+			done := make(chan struct{})
+			tc.mock(t, r, s, done)
+			run, err := u.StartScheduledJob(context.Background(), constant.JobOrderDelivered, tc.tick)
+			<-done
+
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, constant.JobStatusRunning, run.Status)
+			}
+		})
+	}
+}
+
+func TestJobUC_executeJob(t *testing.T) {
+	testCase := []struct {
+		name           string
+		results        []error
+		expectedStatus string
+		expectedRows   int64
+		expectedError  string
+	}{
+		{
+			name:           "success after retry",
+			results:        []error{errors.New("test"), nil},
+			expectedStatus: constant.JobStatusSuccess,
+			expectedRows:   2,
+			expectedError:  "",
+		},
+		{
+			name:           "failed after every attempt",
+			results:        []error{errors.New("first"), errors.New("second"), errors.New("third")},
+			expectedStatus: constant.JobStatusFailed,
+			expectedRows:   3,
+			expectedError:  "third",
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := &jobUC{cfg: &config.Config{}, jobRepo: r}
+
+			calls := 0
+			entry := &jobEntry{
+				definition: &model.JobDefinition{Name: constant.JobRejectedRefund},
+				run: func(ctx context.Context) (*model.JobReport, error) {
+					err := tc.results[calls]
+					calls++
+					return &model.JobReport{RowsAffected: 1}, err
+				},
+			}
+
+			run := &model.JobRun{Job: constant.JobRejectedRefund}
+			r.On("UpdateJobRun", mock.Anything, run).Return(nil)
+			r.On("ReleaseJobLock", mock.Anything, constant.JobRejectedRefund, "token").Return(nil)
+
+			err := u.executeJob(context.Background(), entry, run, "token")
+			assert.NoError(t, err)
+			assert.Equal(t, len(tc.results), run.Attempt)
+			assert.Equal(t, tc.expectedStatus, run.Status)
+			assert.Equal(t, tc.expectedRows, run.RowsAffected)
+			assert.Equal(t, tc.expectedError, run.Error)
+			assert.True(t, run.FinishedAt.Valid)
+		})
+	}
+}
+
+func TestJobUC_executeJobPanic(t *testing.T) {
+	r := mocks.NewRepository(t)
+	u := &jobUC{cfg: &config.Config{}, jobRepo: r}
+	entry := &jobEntry{
+		definition: &model.JobDefinition{Name: constant.JobProductMetadata},
+		run: func(ctx context.Context) (*model.JobReport, error) {
+			panic("boom")
+		},
+	}
+
+	run := &model.JobRun{Job: constant.JobProductMetadata}
+	r.On("UpdateJobRun", mock.Anything, run).Return(nil)
+	r.On("ReleaseJobLock", mock.Anything, constant.JobProductMetadata, "token").Return(nil)
+
+	err := u.executeJob(context.Background(), entry, run, "token")
+	assert.NoError(t, err)
+	assert.Equal(t, constant.JobStatusFailed, run.Status)
+	assert.Equal(t, "job panic: boom", run.Error)
+}
+
+func TestJobUC_GetJobRuns(t *testing.T) {
+	testCase := []struct {
+		name        string
+		job         string
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name: "success all jobs",
+			job:  "",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTotalJobRuns", mock.Anything, "").Return(int64(11), nil)
+				r.On("GetJobRuns", mock.Anything, "", mock.Anything).Return([]*model.JobRun{}, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "error job not found",
+			job:         "unknown",
+			mock:        func(t *testing.T, r *mocks.Repository) {},
+			expectedErr: httperror.New(http.StatusNotFound, response.JobNotFound),
+		},
+		{
+			name: "error GetTotalJobRuns",
+			job:  constant.JobTransactionExpired,
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTotalJobRuns", mock.Anything, constant.JobTransactionExpired).Return(int64(0), errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
+		{
+			name: "error GetJobRuns",
+			job:  constant.JobTransactionExpired,
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTotalJobRuns", mock.Anything, constant.JobTransactionExpired).Return(int64(1), nil)
+				r.On("GetJobRuns", mock.Anything, constant.JobTransactionExpired, mock.Anything).Return(nil, errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := NewJobUseCase(&config.Config{}, r, sellerMocks.NewUseCase(t), userMocks.NewUseCase(t), productMocks.NewUseCase(t), outboxMocks.NewUseCase(t))
+
+			tc.mock(t, r)
+			pgn, err := u.GetJobRuns(context.Background(), tc.job, &pagination.Pagination{Limit: 10, Page: 1})
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 2, pgn.TotalPages)
+			}
+		})
+	}
+}
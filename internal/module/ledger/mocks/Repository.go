@@ -0,0 +1,165 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "murakali/internal/model"
+
+	postgre "murakali/pkg/postgre"
+
+	uuid "github.com/google/uuid"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// ApplyWalletBalance provides a mock function with given fields: ctx, tx, walletID, amount
+func (_m *Repository) ApplyWalletBalance(ctx context.Context, tx postgre.Transaction, walletID uuid.UUID, amount model.Money) (int64, error) {
+	ret := _m.Called(ctx, tx, walletID, amount)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, uuid.UUID, model.Money) int64); ok {
+		r0 = rf(ctx, tx, walletID, amount)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, uuid.UUID, model.Money) error); ok {
+		r1 = rf(ctx, tx, walletID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateJournalEntry provides a mock function with given fields: ctx, tx, entry
+func (_m *Repository) CreateJournalEntry(ctx context.Context, tx postgre.Transaction, entry *model.JournalEntry) error {
+	ret := _m.Called(ctx, tx, entry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.JournalEntry) error); ok {
+		r0 = rf(ctx, tx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateJournalLine provides a mock function with given fields: ctx, tx, line
+func (_m *Repository) CreateJournalLine(ctx context.Context, tx postgre.Transaction, line *model.JournalLine) error {
+	ret := _m.Called(ctx, tx, line)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.JournalLine) error); ok {
+		r0 = rf(ctx, tx, line)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetOrCreateAccount provides a mock function with given fields: ctx, tx, account
+func (_m *Repository) GetOrCreateAccount(ctx context.Context, tx postgre.Transaction, account *model.LedgerAccount) error {
+	ret := _m.Called(ctx, tx, account)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.LedgerAccount) error); ok {
+		r0 = rf(ctx, tx, account)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetWalletIDByUserID provides a mock function with given fields: ctx, tx, userID
+func (_m *Repository) GetWalletIDByUserID(ctx context.Context, tx postgre.Transaction, userID string) (uuid.UUID, error) {
+	ret := _m.Called(ctx, tx, userID)
+
+	var r0 uuid.UUID
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) uuid.UUID); ok {
+		r0 = rf(ctx, tx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string) error); ok {
+		r1 = rf(ctx, tx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWalletLedgerBalance provides a mock function with given fields: ctx, walletID
+func (_m *Repository) GetWalletLedgerBalance(ctx context.Context, walletID string) (model.Money, error) {
+	ret := _m.Called(ctx, walletID)
+
+	var r0 model.Money
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.Money); ok {
+		r0 = rf(ctx, walletID)
+	} else {
+		r0 = ret.Get(0).(model.Money)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, walletID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWalletReconciliations provides a mock function with given fields: ctx
+func (_m *Repository) GetWalletReconciliations(ctx context.Context) ([]*model.WalletReconciliation, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.WalletReconciliation
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.WalletReconciliation); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.WalletReconciliation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "murakali/internal/model"
+
+	postgre "murakali/pkg/postgre"
+)
+
+// UseCase is an autogenerated mock type for the UseCase type
+type UseCase struct {
+	mock.Mock
+}
+
+// GetWalletBalance provides a mock function with given fields: ctx, walletID
+func (_m *UseCase) GetWalletBalance(ctx context.Context, walletID string) (model.Money, error) {
+	ret := _m.Called(ctx, walletID)
+
+	var r0 model.Money
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.Money); ok {
+		r0 = rf(ctx, walletID)
+	} else {
+		r0 = ret.Get(0).(model.Money)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, walletID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostEntry provides a mock function with given fields: ctx, tx, entry
+func (_m *UseCase) PostEntry(ctx context.Context, tx postgre.Transaction, entry *model.JournalEntry) error {
+	ret := _m.Called(ctx, tx, entry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.JournalEntry) error); ok {
+		r0 = rf(ctx, tx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReconcileWallets provides a mock function with given fields: ctx
+func (_m *UseCase) ReconcileWallets(ctx context.Context) ([]*model.WalletReconciliation, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.WalletReconciliation
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.WalletReconciliation); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.WalletReconciliation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewUseCase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUseCase creates a new instance of UseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUseCase(t mockConstructorTestingTNewUseCase) *UseCase {
+	mock := &UseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,19 @@
+package ledger
+
+import (
+	"context"
+	"murakali/internal/model"
+	"murakali/pkg/postgre"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	GetWalletIDByUserID(ctx context.Context, tx postgre.Transaction, userID string) (uuid.UUID, error)
+	GetOrCreateAccount(ctx context.Context, tx postgre.Transaction, account *model.LedgerAccount) error
+	CreateJournalEntry(ctx context.Context, tx postgre.Transaction, entry *model.JournalEntry) error
+	CreateJournalLine(ctx context.Context, tx postgre.Transaction, line *model.JournalLine) error
+	ApplyWalletBalance(ctx context.Context, tx postgre.Transaction, walletID uuid.UUID, amount model.Money) (int64, error)
+	GetWalletLedgerBalance(ctx context.Context, walletID string) (model.Money, error)
+	GetWalletReconciliations(ctx context.Context) ([]*model.WalletReconciliation, error)
+}
@@ -0,0 +1,33 @@
+package repository
+
+const (
+	GetWalletIDByUserIDQuery = `SELECT "id" FROM "wallet" WHERE "user_id" = $1`
+
+	GetOrCreateAccountQuery = `INSERT INTO "ledger_account" ("code", "account_type", "wallet_id")
+	VALUES ($1, $2, $3)
+	ON CONFLICT ("code") DO UPDATE SET "code" = EXCLUDED."code"
+	RETURNING "id", "created_at"`
+
+	CreateJournalEntryQuery = `INSERT INTO "journal_entry" ("transaction_id", "description")
+	VALUES ($1, $2) RETURNING "id", "created_at"`
+
+	CreateJournalLineQuery = `INSERT INTO "journal_line" ("journal_entry_id", "account_id", "debit", "credit")
+	VALUES ($1, $2, $3, $4) RETURNING "id"`
+
+	ApplyWalletBalanceQuery = `UPDATE "wallet" SET "balance" = "balance" + $1, "updated_at" = NOW()
+	WHERE "id" = $2 AND "balance" + $1 >= 0`
+
+	GetWalletLedgerBalanceQuery = `SELECT COALESCE(SUM("jl"."credit" - "jl"."debit"), 0)
+	FROM "journal_line" "jl"
+	JOIN "ledger_account" "la" ON "la"."id" = "jl"."account_id"
+	WHERE "la"."wallet_id" = $1`
+
+	GetWalletReconciliationsQuery = `SELECT "w"."id", "w"."user_id", "w"."balance",
+		COALESCE(SUM("jl"."credit" - "jl"."debit"), 0) AS "ledger_balance"
+	FROM "wallet" "w"
+	LEFT JOIN "ledger_account" "la" ON "la"."wallet_id" = "w"."id"
+	LEFT JOIN "journal_line" "jl" ON "jl"."account_id" = "la"."id"
+	GROUP BY "w"."id", "w"."user_id", "w"."balance"
+	HAVING "w"."balance" <> COALESCE(SUM("jl"."credit" - "jl"."debit"), 0)
+	ORDER BY "w"."id"`
+)
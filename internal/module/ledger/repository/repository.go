@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"murakali/internal/model"
+	"murakali/internal/module/ledger"
+	"murakali/pkg/postgre"
+
+	"github.com/google/uuid"
+)
+
+type ledgerRepo struct {
+	PSQL *sql.DB
+}
+
+func NewLedgerRepository(psql *sql.DB) ledger.Repository {
+	return &ledgerRepo{
+		PSQL: psql,
+	}
+}
+
+func (r *ledgerRepo) GetWalletIDByUserID(ctx context.Context, tx postgre.Transaction, userID string) (uuid.UUID, error) {
+	var walletID uuid.UUID
+	if err := tx.QueryRowContext(ctx, GetWalletIDByUserIDQuery, userID).Scan(&walletID); err != nil {
+		return uuid.Nil, err
+	}
+
+	return walletID, nil
+}
+
+func (r *ledgerRepo) GetOrCreateAccount(ctx context.Context, tx postgre.Transaction, account *model.LedgerAccount) error {
+	if err := tx.QueryRowContext(ctx, GetOrCreateAccountQuery, account.Code, account.AccountType, account.WalletID).
+		Scan(&account.ID, &account.CreatedAt); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ledgerRepo) CreateJournalEntry(ctx context.Context, tx postgre.Transaction, entry *model.JournalEntry) error {
+	if err := tx.QueryRowContext(ctx, CreateJournalEntryQuery, entry.TransactionID, entry.Description).
+		Scan(&entry.ID, &entry.CreatedAt); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ledgerRepo) CreateJournalLine(ctx context.Context, tx postgre.Transaction, line *model.JournalLine) error {
+	if err := tx.QueryRowContext(ctx, CreateJournalLineQuery, line.JournalEntryID, line.Account.ID, line.Debit, line.Credit).
+		Scan(&line.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ledgerRepo) ApplyWalletBalance(ctx context.Context, tx postgre.Transaction, walletID uuid.UUID, amount model.Money) (int64, error) {
+	res, err := tx.ExecContext(ctx, ApplyWalletBalanceQuery, amount, walletID)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *ledgerRepo) GetWalletLedgerBalance(ctx context.Context, walletID string) (model.Money, error) {
+	var balance model.Money
+	if err := r.PSQL.QueryRowContext(ctx, GetWalletLedgerBalanceQuery, walletID).Scan(&balance); err != nil {
+		return 0, err
+	}
+
+	return balance, nil
+}
+
+func (r *ledgerRepo) GetWalletReconciliations(ctx context.Context) ([]*model.WalletReconciliation, error) {
+	reconciliations := make([]*model.WalletReconciliation, 0)
+	res, err := r.PSQL.QueryContext(ctx, GetWalletReconciliationsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var reconciliation model.WalletReconciliation
+		if errScan := res.Scan(
+			&reconciliation.WalletID,
+			&reconciliation.UserID,
+			&reconciliation.StoredBalance,
+			&reconciliation.LedgerBalance,
+		); errScan != nil {
+			return nil, errScan
+		}
+
+		reconciliations = append(reconciliations, &reconciliation)
+	}
+
+	if res.Err() != nil {
+		return nil, res.Err()
+	}
+
+	return reconciliations, nil
+}
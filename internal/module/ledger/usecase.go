@@ -0,0 +1,13 @@
+package ledger
+
+import (
+	"context"
+	"murakali/internal/model"
+	"murakali/pkg/postgre"
+)
+
+type UseCase interface {
+	PostEntry(ctx context.Context, tx postgre.Transaction, entry *model.JournalEntry) error
+	GetWalletBalance(ctx context.Context, walletID string) (model.Money, error)
+	ReconcileWallets(ctx context.Context) ([]*model.WalletReconciliation, error)
+}
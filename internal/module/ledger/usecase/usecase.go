@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"murakali/config"
+	"murakali/internal/constant"
+	"murakali/internal/model"
+	"murakali/internal/module/ledger"
+	"murakali/pkg/httperror"
+	"murakali/pkg/postgre"
+	"murakali/pkg/response"
+	"net/http"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+type ledgerUC struct {
+	cfg        *config.Config
+	txRepo     *postgre.TxRepo
+	ledgerRepo ledger.Repository
+}
+
+func NewLedgerUseCase(cfg *config.Config, txRepo *postgre.TxRepo, ledgerRepo ledger.Repository) ledger.UseCase {
+	return &ledgerUC{cfg: cfg, txRepo: txRepo, ledgerRepo: ledgerRepo}
+}
+
+// PostEntry writes a balanced journal entry inside tx and applies its net
+// effect to every wallet it touches. A wallet that would go negative fails the
+// whole entry.
+func (u *ledgerUC) PostEntry(ctx context.Context, tx postgre.Transaction, entry *model.JournalEntry) error {
+	if err := validateEntry(entry); err != nil {
+		return err
+	}
+
+	if err := u.ledgerRepo.CreateJournalEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	walletAmounts := make(map[uuid.UUID]model.Money)
+	for _, line := range entry.Lines {
+		if err := u.resolveAccount(ctx, tx, &line.Account); err != nil {
+			return err
+		}
+
+		line.JournalEntryID = entry.ID
+		if err := u.ledgerRepo.CreateJournalLine(ctx, tx, line); err != nil {
+			return err
+		}
+
+		if line.Account.WalletID != nil {
+			walletAmounts[*line.Account.WalletID] += line.Credit - line.Debit
+		}
+	}
+
+	// wallets are locked in a fixed order so concurrent entries over the same
+	// wallets wait for each other instead of deadlocking
+	walletIDs := make([]uuid.UUID, 0, len(walletAmounts))
+	for walletID := range walletAmounts {
+		walletIDs = append(walletIDs, walletID)
+	}
+	sort.Slice(walletIDs, func(i, j int) bool {
+		return walletIDs[i].String() < walletIDs[j].String()
+	})
+
+	for _, walletID := range walletIDs {
+		if walletAmounts[walletID] == 0 {
+			continue
+		}
+
+		rows, err := u.ledgerRepo.ApplyWalletBalance(ctx, tx, walletID, walletAmounts[walletID])
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return httperror.New(http.StatusBadRequest, response.WalletBalanceNotEnough)
+		}
+	}
+
+	return nil
+}
+
+func (u *ledgerUC) resolveAccount(ctx context.Context, tx postgre.Transaction, account *model.LedgerAccount) error {
+	switch account.AccountType {
+	case constant.LedgerAccountEscrow:
+		walletID, err := u.ledgerRepo.GetWalletIDByUserID(ctx, tx, constant.AdminMarketplaceID)
+		if err != nil {
+			return err
+		}
+
+		account.WalletID = &walletID
+		account.Code = constant.LedgerAccountEscrow
+	case constant.LedgerAccountBuyerWallet, constant.LedgerAccountSellerWallet:
+		if account.WalletID == nil {
+			return errors.New(response.LedgerAccountWithoutWallet)
+		}
+
+		account.Code = fmt.Sprintf("%s:%s", account.AccountType, account.WalletID.String())
+	default:
+		account.WalletID = nil
+		account.Code = account.AccountType
+	}
+
+	return u.ledgerRepo.GetOrCreateAccount(ctx, tx, account)
+}
+
+func validateEntry(entry *model.JournalEntry) error {
+	if len(entry.Lines) < 2 {
+		return errors.New(response.JournalEntryUnbalanced)
+	}
+
+	var debit, credit model.Money
+	for _, line := range entry.Lines {
+		if line.Debit < 0 || line.Credit < 0 || (line.Debit == 0) == (line.Credit == 0) {
+			return errors.New(response.JournalEntryUnbalanced)
+		}
+
+		debit += line.Debit
+		credit += line.Credit
+	}
+
+	if debit != credit {
+		return errors.New(response.JournalEntryUnbalanced)
+	}
+
+	return nil
+}
+
+func (u *ledgerUC) GetWalletBalance(ctx context.Context, walletID string) (model.Money, error) {
+	return u.ledgerRepo.GetWalletLedgerBalance(ctx, walletID)
+}
+
+func (u *ledgerUC) ReconcileWallets(ctx context.Context) ([]*model.WalletReconciliation, error) {
+	return u.ledgerRepo.GetWalletReconciliations(ctx)
+}
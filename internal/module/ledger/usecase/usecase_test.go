@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"murakali/config"
+	"murakali/internal/constant"
+	"murakali/internal/model"
+	"murakali/internal/module/ledger/mocks"
+	"murakali/pkg/postgre"
+	"murakali/pkg/response"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLedgerUC_PostEntry(t *testing.T) {
+	buyerWalletID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	escrowWalletID := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	buyerWallet := model.LedgerAccount{AccountType: constant.LedgerAccountBuyerWallet, WalletID: &buyerWalletID}
+	escrow := model.LedgerAccount{AccountType: constant.LedgerAccountEscrow}
+	seaLabsPay := model.LedgerAccount{AccountType: constant.LedgerAccountSeaLabsPay}
+
+	testCase := []struct {
+		name        string
+		entry       *model.JournalEntry
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name:  "success wallet payment to escrow",
+			entry: model.NewTransferEntry(uuid.Nil, "Payment", buyerWallet, escrow, model.NewMoney(15000)),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("CreateJournalEntry", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("GetWalletIDByUserID", mock.Anything, mock.Anything, constant.AdminMarketplaceID).Return(escrowWalletID, nil)
+				r.On("GetOrCreateAccount", mock.Anything, mock.Anything, mock.MatchedBy(func(account *model.LedgerAccount) bool {
+					return account.Code == "buyer_wallet:"+buyerWalletID.String()
+				})).Return(nil)
+				r.On("GetOrCreateAccount", mock.Anything, mock.Anything, mock.MatchedBy(func(account *model.LedgerAccount) bool {
+					return account.Code == constant.LedgerAccountEscrow && *account.WalletID == escrowWalletID
+				})).Return(nil)
+				r.On("CreateJournalLine", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("ApplyWalletBalance", mock.Anything, mock.Anything, buyerWalletID, -model.NewMoney(15000)).Return(int64(1), nil)
+				r.On("ApplyWalletBalance", mock.Anything, mock.Anything, escrowWalletID, model.NewMoney(15000)).Return(int64(1), nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "success top up only touches the buyer wallet",
+			entry: model.NewTransferEntry(uuid.Nil, "Top up", seaLabsPay, buyerWallet, model.NewMoney(10000)),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("CreateJournalEntry", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("GetOrCreateAccount", mock.Anything, mock.Anything, mock.MatchedBy(func(account *model.LedgerAccount) bool {
+					return account.Code == constant.LedgerAccountSeaLabsPay && account.WalletID == nil
+				})).Return(nil)
+				r.On("GetOrCreateAccount", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("CreateJournalLine", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("ApplyWalletBalance", mock.Anything, mock.Anything, buyerWalletID, model.NewMoney(10000)).Return(int64(1), nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "error wallet balance not enough",
+			entry: model.NewTransferEntry(uuid.Nil, "Payment", buyerWallet, seaLabsPay, model.NewMoney(15000)),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("CreateJournalEntry", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("GetOrCreateAccount", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("CreateJournalLine", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("ApplyWalletBalance", mock.Anything, mock.Anything, buyerWalletID, -model.NewMoney(15000)).Return(int64(0), nil)
+			},
+			expectedErr: errors.New(response.WalletBalanceNotEnough),
+		},
+		{
+			name: "error unbalanced entry",
+			entry: &model.JournalEntry{Lines: []*model.JournalLine{
+				{Account: seaLabsPay, Debit: model.NewMoney(100)},
+				{Account: buyerWallet, Credit: model.NewMoney(99)},
+			}},
+			mock:        func(t *testing.T, r *mocks.Repository) {},
+			expectedErr: errors.New(response.JournalEntryUnbalanced),
+		},
+		{
+			name: "error line with debit and credit",
+			entry: &model.JournalEntry{Lines: []*model.JournalLine{
+				{Account: seaLabsPay, Debit: model.NewMoney(100), Credit: model.NewMoney(100)},
+				{Account: buyerWallet, Debit: model.NewMoney(100), Credit: model.NewMoney(100)},
+			}},
+			mock:        func(t *testing.T, r *mocks.Repository) {},
+			expectedErr: errors.New(response.JournalEntryUnbalanced),
+		},
+		{
+			name:        "error single line",
+			entry:       &model.JournalEntry{Lines: []*model.JournalLine{{Account: seaLabsPay}}},
+			mock:        func(t *testing.T, r *mocks.Repository) {},
+			expectedErr: errors.New(response.JournalEntryUnbalanced),
+		},
+		{
+			name: "error wallet account without wallet",
+			entry: model.NewTransferEntry(uuid.Nil, "Withdrawal", escrow,
+				model.LedgerAccount{AccountType: constant.LedgerAccountSellerWallet}, model.NewMoney(100)),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("CreateJournalEntry", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("GetWalletIDByUserID", mock.Anything, mock.Anything, constant.AdminMarketplaceID).Return(escrowWalletID, nil)
+				r.On("GetOrCreateAccount", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("CreateJournalLine", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedErr: errors.New(response.LedgerAccountWithoutWallet),
+		},
+		{
+			name:  "error create journal entry",
+			entry: model.NewTransferEntry(uuid.Nil, "Top up", seaLabsPay, buyerWallet, model.NewMoney(100)),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("CreateJournalEntry", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, _, _ := sqlmock.New()
+			r := mocks.NewRepository(t)
+			u := NewLedgerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+
+			tc.mock(t, r)
+			err := u.PostEntry(context.Background(), sql, tc.entry)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLedgerUC_ReconcileWallets(t *testing.T) {
+	mismatch := &model.WalletReconciliation{
+		WalletID:      uuid.New(),
+		StoredBalance: model.NewMoney(500),
+		LedgerBalance: model.NewMoney(200),
+	}
+
+	testCase := []struct {
+		name        string
+		mock        func(t *testing.T, r *mocks.Repository)
+		expected    []*model.WalletReconciliation
+		expectedErr error
+	}{
+		{
+			name: "success found mismatch",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetWalletReconciliations", mock.Anything).Return([]*model.WalletReconciliation{mismatch}, nil)
+			},
+			expected:    []*model.WalletReconciliation{mismatch},
+			expectedErr: nil,
+		},
+		{
+			name: "error GetWalletReconciliations",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetWalletReconciliations", mock.Anything).Return(nil, errors.New("test"))
+			},
+			expected:    nil,
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := NewLedgerUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+
+			tc.mock(t, r)
+			res, err := u.ReconcileWallets(context.Background())
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			}
+			assert.Equal(t, tc.expected, res)
+		})
+	}
+
+	assert.Equal(t, model.NewMoney(300), mismatch.Difference())
+}
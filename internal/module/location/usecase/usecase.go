@@ -10,8 +10,10 @@ import (
 	"murakali/internal/module/location"
 	"murakali/internal/module/location/delivery/body"
 	"murakali/pkg/httperror"
+	"murakali/pkg/kodepos"
 	"murakali/pkg/postgre"
 	"murakali/pkg/response"
+	"murakali/pkg/shipping"
 	"net/http"
 	"strings"
 )
@@ -20,22 +22,28 @@ type locationUC struct {
 	cfg          *config.Config
 	txRepo       *postgre.TxRepo
 	locationRepo location.Repository
+	shipping     shipping.Provider
+	kodepos      kodepos.Client
 }
 
-func NewLocationUseCase(cfg *config.Config, txRepo *postgre.TxRepo, locationRepo location.Repository) location.UseCase {
-	return &locationUC{cfg: cfg, txRepo: txRepo, locationRepo: locationRepo}
+func NewLocationUseCase(cfg *config.Config, txRepo *postgre.TxRepo, locationRepo location.Repository, shippingProvider shipping.Provider,
+	kodeposClient kodepos.Client) location.UseCase {
+	return &locationUC{cfg: cfg, txRepo: txRepo, locationRepo: locationRepo, shipping: shippingProvider, kodepos: kodeposClient}
 }
 
 func (u *locationUC) GetProvince(ctx context.Context) (*body.ProvinceResponse, error) {
 	province := body.ProvinceResponse{}
 	provinceRedis, err := u.locationRepo.GetProvinceRedis(ctx)
 	if err != nil {
-		res, err := u.GetProvinceRajaOngkir()
+		res, err := u.shipping.GetProvinces(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		province.Rows = res.RajaOngkir.Results
+		province.Rows = make([]model.Province, 0, len(res))
+		for _, result := range res {
+			province.Rows = append(province.Rows, model.Province{ProvinceID: result.ProvinceID, Province: result.Province})
+		}
 		redisValue, err := json.Marshal(province)
 		if err != nil {
 			return nil, err
@@ -59,12 +67,12 @@ func (u *locationUC) GetCity(ctx context.Context, provinceID int) (*body.CityRes
 	city := body.CityResponse{Rows: make([]model.City, 0)}
 	cityRedis, err := u.locationRepo.GetCityRedis(ctx, provinceID)
 	if err != nil {
-		res, err := u.GetCityRajaOngkir(provinceID)
+		res, err := u.shipping.GetCities(ctx, provinceID)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, result := range res.Rajaongkir.Results {
+		for _, result := range res {
 			city.Rows = append(city.Rows, model.City{
 				CityID: result.CityID,
 				City:   fmt.Sprintf("%s %s", result.Type, result.CityName),
@@ -94,7 +102,7 @@ func (u *locationUC) GetSubDistrict(ctx context.Context, province, city string)
 	subDistrict := body.SubDistrictResponse{Rows: make([]model.SubDistrict, 0)}
 	subDistrictRedis, err := u.locationRepo.GetSubDistrictRedis(ctx, province, city)
 	if err != nil {
-		res, err := u.GetDataFromKodePos(province, city, "")
+		res, err := u.kodepos.Search(ctx, province, city, "")
 		if err != nil {
 			return nil, err
 		}
@@ -131,7 +139,7 @@ func (u *locationUC) GetUrban(ctx context.Context, province, city, subDistrict s
 	urban := body.UrbanResponse{Rows: make([]model.Urban, 0)}
 	urbanRedis, err := u.locationRepo.GetUrbanRedis(ctx, province, city, subDistrict)
 	if err != nil {
-		res, err := u.GetDataFromKodePos(province, city, subDistrict)
+		res, err := u.kodepos.Search(ctx, province, city, subDistrict)
 		if err != nil {
 			return nil, err
 		}
@@ -220,10 +228,10 @@ func (u *locationUC) GetShippingCost(ctx context.Context, requestBody body.GetSh
 		}
 
 		var costRedis *string
-		key := fmt.Sprintf("%d:%d:%d:%s", shopAddress.CityID, requestBody.Destination, requestBody.Weight, courier.Code)
+		key := shipping.CostKey(u.shipping, shopAddress.CityID, requestBody.Destination, requestBody.Weight, courier.Code)
 		costRedis, err = u.locationRepo.GetCostRedis(ctx, key)
 		if err != nil {
-			res, err := u.GetCostRajaOngkir(shopAddress.CityID, requestBody.Destination, requestBody.Weight, courier.Code)
+			res, err := u.shipping.GetCost(ctx, shopAddress.CityID, requestBody.Destination, requestBody.Weight, courier.Code)
 			if err != nil {
 				return nil, err
 			}
@@ -241,119 +249,15 @@ func (u *locationUC) GetShippingCost(ctx context.Context, requestBody body.GetSh
 			costRedis = &value
 		}
 
-		var costResp body.RajaOngkirCostResponse
-		if err := json.Unmarshal([]byte(*costRedis), &costResp); err != nil {
+		var quote shipping.Quote
+		if err := json.Unmarshal([]byte(*costRedis), &quote); err != nil {
 			return nil, err
 		}
 
-		if len(costResp.Rajaongkir.Results) > 0 {
-			for _, cost := range costResp.Rajaongkir.Results[0].Costs {
-				costCourier := &model.Cost{}
-				if cost.Service == courier.Service {
-					costCourier.Courier = *courier
-					costCourier.Fee = cost.Cost[0].Value
-					costCourier.ETD = cost.Cost[0].Etd
-					resp.ShippingOption = append(resp.ShippingOption, costCourier)
-				}
-			}
+		if fee, etd, ok := quote.ServiceCost(courier.Service); ok {
+			resp.ShippingOption = append(resp.ShippingOption, &model.Cost{Courier: *courier, Fee: fee, ETD: etd})
 		}
 	}
 
 	return resp, nil
 }
-
-func (u *locationUC) GetProvinceRajaOngkir() (*body.RajaOngkirProvinceResponse, error) {
-	var responseOngkir body.RajaOngkirProvinceResponse
-	url := fmt.Sprintf("%s/province", u.cfg.External.OngkirAPIURL)
-	req, err := http.NewRequest("GET", url, http.NoBody)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Add("key", u.cfg.External.OngkirAPIKey)
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	defer res.Body.Close()
-	readErr := json.NewDecoder(res.Body).Decode(&responseOngkir)
-	if readErr != nil {
-		return nil, err
-	}
-
-	return &responseOngkir, nil
-}
-
-func (u *locationUC) GetCityRajaOngkir(provinceID int) (*body.RajaOngkirCityResponse, error) {
-	var responseOngkir body.RajaOngkirCityResponse
-	url := fmt.Sprintf("%s/city?province=%d", u.cfg.External.OngkirAPIURL, provinceID)
-
-	req, err := http.NewRequest("GET", url, http.NoBody)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Add("key", u.cfg.External.OngkirAPIKey)
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	defer res.Body.Close()
-	readErr := json.NewDecoder(res.Body).Decode(&responseOngkir)
-	if readErr != nil {
-		return nil, err
-	}
-
-	return &responseOngkir, nil
-}
-
-func (u *locationUC) GetCostRajaOngkir(origin, destination, weight int, code string) (*body.RajaOngkirCostResponse, error) {
-	var responseCost body.RajaOngkirCostResponse
-	url := fmt.Sprintf("%s/cost", u.cfg.External.OngkirAPIURL)
-	payload := fmt.Sprintf(
-		"origin=%d&destination=%d&weight=%d&courier=%s", origin, destination, weight, code)
-
-	req, err := http.NewRequest("POST", url, strings.NewReader(payload))
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Add("key", u.cfg.External.OngkirAPIKey)
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	readErr := json.NewDecoder(res.Body).Decode(&responseCost)
-	if readErr != nil {
-		return nil, err
-	}
-
-	return &responseCost, nil
-}
-
-func (u *locationUC) GetDataFromKodePos(province, city, subdistrict string) (*body.KodePosResponse, error) {
-	var responseKodePos body.KodePosResponse
-	url := fmt.Sprintf("%s/search/?q=%s %s %s", u.cfg.External.KodePosURL, province, city, subdistrict)
-	req, err := http.NewRequest("GET", url, http.NoBody)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	defer res.Body.Close()
-	readErr := json.NewDecoder(res.Body).Decode(&responseKodePos)
-	if readErr != nil {
-		return nil, err
-	}
-
-	return &responseKodePos, nil
-}
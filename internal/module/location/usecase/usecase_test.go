@@ -8,79 +8,72 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"murakali/config"
-	"murakali/internal/constant"
 	"murakali/internal/model"
 	"murakali/internal/module/location/delivery/body"
 	"murakali/internal/module/location/mocks"
+	"murakali/pkg/kodepos"
 	"murakali/pkg/postgre"
+	"murakali/pkg/shipping"
 	"net/url"
 	"testing"
 )
 
-func TestLocationUC_GetShippingCost(t *testing.T) {
-	response := `
-		{
-   "rajaongkir":{
-      "query":{
-         "origin":"501",
-         "destination":"114",
-         "weight":1700,
-         "courier":"jne"
-      },
-      "status":{
-         "code":200,
-         "description":"OK"
-      },
-      "origin_details":{
-         "city_id":"501",
-         "province_id":"5",
-         "province":"DI Yogyakarta",
-         "type":"Kota",
-         "city_name":"Yogyakarta",
-         "postal_code":"55000"
-      },
-      "destination_details":{
-         "city_id":"114",
-         "province_id":"1",
-         "province":"Bali",
-         "type":"Kota",
-         "city_name":"Denpasar",
-         "postal_code":"80000"
-      },
-      "results":[
-         {
-            "code":"jne",
-            "name":"Jalur Nugraha Ekakurir (JNE)",
-            "costs":[
-               {
-                  "service":"",
-                  "description":"Layanan Reguler",
-                  "cost":[
-                     {
-                        "value":44000,
-                        "etd":"2-3",
-                        "note":""
-                     }
-                  ]
-               }
-            ]
-         }
-      ]
-   }
+type stubShipping struct {
+	err error
+}
+
+func (s *stubShipping) Name() string {
+	return "stub"
+}
+
+func (s *stubShipping) GetProvinces(ctx context.Context) ([]shipping.Province, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return []shipping.Province{{ProvinceID: "5", Province: "DI Yogyakarta"}}, nil
 }
-	`
+
+func (s *stubShipping) GetCities(ctx context.Context, provinceID int) ([]shipping.City, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return []shipping.City{{CityID: "501", ProvinceID: "5", Type: "Kota", CityName: "Yogyakarta"}}, nil
+}
+
+func (s *stubShipping) GetCost(ctx context.Context, origin, destination, weight int, courier string) (*shipping.Quote, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &shipping.Quote{Courier: courier, Services: []shipping.Service{{Service: "REG", Fee: 44000, ETD: "2-3"}}}, nil
+}
+
+type stubKodePos struct {
+	err error
+}
+
+func (s *stubKodePos) Search(ctx context.Context, province, city, subDistrict string) (*kodepos.SearchResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &kodepos.SearchResponse{Code: 200, Status: true}, nil
+}
+
+func TestLocationUC_GetShippingCost(t *testing.T) {
+	response := `{"courier":"jne","services":[{"service":"","description":"Layanan Reguler","fee":44000,"etd":"2-3"}]}`
 	errResponse := ""
 	testCase := []struct {
 		name        string
-		url         string
-		posUrl      string
+		shippingErr error
+		kodeposErr  error
 		mock        func(t *testing.T, r *mocks.Repository)
 		expectedErr error
 	}{
 		{
-			name:   "success get shipping cost redis",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "success get shipping cost redis",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetShopByID", mock.Anything, mock.Anything).Return(&model.Shop{}, nil)
 				r.On("GetShopAddress", mock.Anything, mock.Anything).Return(&model.Address{}, nil)
@@ -92,9 +85,7 @@ func TestLocationUC_GetShippingCost(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:   "success get shipping cost api",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "success get shipping cost api",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetShopByID", mock.Anything, mock.Anything).Return(&model.Shop{}, nil)
 				r.On("GetShopAddress", mock.Anything, mock.Anything).Return(&model.Address{}, nil)
@@ -107,9 +98,7 @@ func TestLocationUC_GetShippingCost(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:   "err insert get shipping cost api",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "err insert get shipping cost api",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetShopByID", mock.Anything, mock.Anything).Return(&model.Shop{}, nil)
 				r.On("GetShopAddress", mock.Anything, mock.Anything).Return(&model.Address{}, nil)
@@ -122,9 +111,8 @@ func TestLocationUC_GetShippingCost(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:   "error get shipping cost api",
-			url:    "",
-			posUrl: constant.KODE_POS_URL,
+			name:        "error get shipping cost api",
+			shippingErr: errors.New("test"),
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetShopByID", mock.Anything, mock.Anything).Return(&model.Shop{}, nil)
 				r.On("GetShopAddress", mock.Anything, mock.Anything).Return(&model.Address{}, nil)
@@ -136,9 +124,7 @@ func TestLocationUC_GetShippingCost(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:   "error get shipping cost redis",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "error get shipping cost redis",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetShopByID", mock.Anything, mock.Anything).Return(&model.Shop{}, nil)
 				r.On("GetShopAddress", mock.Anything, mock.Anything).Return(&model.Address{}, nil)
@@ -150,9 +136,7 @@ func TestLocationUC_GetShippingCost(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:   "err courier get shipping cost redis",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "err courier get shipping cost redis",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetShopByID", mock.Anything, mock.Anything).Return(&model.Shop{}, nil)
 				r.On("GetShopAddress", mock.Anything, mock.Anything).Return(&model.Address{}, nil)
@@ -163,9 +147,7 @@ func TestLocationUC_GetShippingCost(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:   "err whitelist shipping cost redis",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "err whitelist shipping cost redis",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetShopByID", mock.Anything, mock.Anything).Return(&model.Shop{}, nil)
 				r.On("GetShopAddress", mock.Anything, mock.Anything).Return(&model.Address{}, nil)
@@ -175,9 +157,7 @@ func TestLocationUC_GetShippingCost(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:   "error no courier get shipping cost redis",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "error no courier get shipping cost redis",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetShopByID", mock.Anything, mock.Anything).Return(&model.Shop{}, nil)
 				r.On("GetShopAddress", mock.Anything, mock.Anything).Return(&model.Address{}, nil)
@@ -186,9 +166,7 @@ func TestLocationUC_GetShippingCost(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:   "error no courier get shipping cost redis",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "error no courier get shipping cost redis",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetShopByID", mock.Anything, mock.Anything).Return(&model.Shop{}, nil)
 				r.On("GetShopAddress", mock.Anything, mock.Anything).Return(&model.Address{}, nil)
@@ -197,9 +175,7 @@ func TestLocationUC_GetShippingCost(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:   "err no address get shipping cost redis",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "err no address get shipping cost redis",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetShopByID", mock.Anything, mock.Anything).Return(&model.Shop{}, nil)
 				r.On("GetShopAddress", mock.Anything, mock.Anything).Return(&model.Address{}, sql.ErrNoRows)
@@ -207,9 +183,7 @@ func TestLocationUC_GetShippingCost(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:   "err address get shipping cost redis",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "err address get shipping cost redis",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetShopByID", mock.Anything, mock.Anything).Return(&model.Shop{}, nil)
 				r.On("GetShopAddress", mock.Anything, mock.Anything).Return(&model.Address{}, errors.New("test"))
@@ -217,18 +191,14 @@ func TestLocationUC_GetShippingCost(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:   "err no shop shipping cost redis",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "err no shop shipping cost redis",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetShopByID", mock.Anything, mock.Anything).Return(&model.Shop{}, sql.ErrNoRows)
 			},
 			expectedErr: nil,
 		},
 		{
-			name:   "err shop shipping cost redis",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "err shop shipping cost redis",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetShopByID", mock.Anything, mock.Anything).Return(&model.Shop{}, errors.New("test"))
 			},
@@ -242,7 +212,7 @@ func TestLocationUC_GetShippingCost(t *testing.T) {
 			mock.ExpectBegin()
 
 			r := mocks.NewRepository(t)
-			u := NewLocationUseCase(&config.Config{External: config.ExternalConfig{OngkirAPIURL: tc.url, KodePosURL: tc.posUrl, OngkirAPIKey: constant.ONGKIR_API_KEY}}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewLocationUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, &stubShipping{err: tc.shippingErr}, &stubKodePos{err: tc.kodeposErr})
 
 			tc.mock(t, r)
 			_, err := u.GetShippingCost(context.Background(), body.GetShippingCostRequest{Destination: 1, Weight: 1000, ProductIDS: []string{"1"}, ShopID: "1"})
@@ -258,24 +228,20 @@ func TestLocationUC_GetShippingCost(t *testing.T) {
 func TestLocationUC_GetUrban(t *testing.T) {
 	testCase := []struct {
 		name        string
-		url         string
-		posUrl      string
+		shippingErr error
+		kodeposErr  error
 		mock        func(t *testing.T, r *mocks.Repository)
 		expectedErr error
 	}{
 		{
-			name:   "success get urban redis",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "success get urban redis",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetUrbanRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("{}", nil)
 			},
 			expectedErr: nil,
 		},
 		{
-			name:   "success get urban api",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "success get urban api",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetUrbanRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("{}", errors.New("test"))
 				r.On("InsertUrbanRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -283,9 +249,7 @@ func TestLocationUC_GetUrban(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:   "error insert urban api",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "error insert urban api",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetUrbanRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("{}", errors.New("test"))
 				r.On("InsertUrbanRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
@@ -293,18 +257,15 @@ func TestLocationUC_GetUrban(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:   "error get urban api",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: "",
+			name:       "error get urban api",
+			kodeposErr: errors.New("test"),
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetUrbanRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("{}", errors.New("test"))
 			},
 			expectedErr: nil,
 		},
 		{
-			name:   "error get urban redis",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "error get urban redis",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetUrbanRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", nil)
 			},
@@ -318,7 +279,7 @@ func TestLocationUC_GetUrban(t *testing.T) {
 			mock.ExpectBegin()
 
 			r := mocks.NewRepository(t)
-			u := NewLocationUseCase(&config.Config{External: config.ExternalConfig{OngkirAPIURL: tc.url, KodePosURL: tc.posUrl, OngkirAPIKey: constant.ONGKIR_API_KEY}}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewLocationUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, &stubShipping{err: tc.shippingErr}, &stubKodePos{err: tc.kodeposErr})
 
 			tc.mock(t, r)
 			_, err := u.GetUrban(context.Background(), "DKI Jakarta", "Jakarta Selatan", "Mampang Prapatan")
@@ -334,23 +295,20 @@ func TestLocationUC_GetUrban(t *testing.T) {
 func TestLocationUC_GetSubDistrict(t *testing.T) {
 	testCase := []struct {
 		name        string
-		url         string
-		posUrl      string
+		shippingErr error
+		kodeposErr  error
 		mock        func(t *testing.T, r *mocks.Repository)
 		expectedErr error
 	}{
 		{
 			name: "success get sub district redis",
-			url:  constant.ONGKIR_API_URL,
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetSubDistrictRedis", mock.Anything, mock.Anything, mock.Anything).Return("{}", nil)
 			},
 			expectedErr: nil,
 		},
 		{
-			name:   "success get sub district api",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "success get sub district api",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetSubDistrictRedis", mock.Anything, mock.Anything, mock.Anything).Return("", errors.New("test"))
 				r.On("InsertSubDistrictRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -358,18 +316,15 @@ func TestLocationUC_GetSubDistrict(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:   "error get sub district api",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: "",
+			name:       "error get sub district api",
+			kodeposErr: errors.New("test"),
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetSubDistrictRedis", mock.Anything, mock.Anything, mock.Anything).Return("", errors.New("test"))
 			},
 			expectedErr: nil,
 		},
 		{
-			name:   "error insert sub district api",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "error insert sub district api",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetSubDistrictRedis", mock.Anything, mock.Anything, mock.Anything).Return("", errors.New("test"))
 				r.On("InsertSubDistrictRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
@@ -377,9 +332,7 @@ func TestLocationUC_GetSubDistrict(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:   "error get sub district redis",
-			url:    constant.ONGKIR_API_URL,
-			posUrl: constant.KODE_POS_URL,
+			name: "error get sub district redis",
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetSubDistrictRedis", mock.Anything, mock.Anything, mock.Anything).Return("", nil)
 			},
@@ -393,7 +346,7 @@ func TestLocationUC_GetSubDistrict(t *testing.T) {
 			mock.ExpectBegin()
 
 			r := mocks.NewRepository(t)
-			u := NewLocationUseCase(&config.Config{External: config.ExternalConfig{OngkirAPIURL: tc.url, KodePosURL: tc.posUrl, OngkirAPIKey: constant.ONGKIR_API_KEY}}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewLocationUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, &stubShipping{err: tc.shippingErr}, &stubKodePos{err: tc.kodeposErr})
 
 			tc.mock(t, r)
 			_, err := u.GetSubDistrict(context.Background(), "Jakarta", "Selatan")
@@ -409,13 +362,12 @@ func TestLocationUC_GetSubDistrict(t *testing.T) {
 func TestLocationUC_GetCity(t *testing.T) {
 	testCase := []struct {
 		name        string
-		url         string
+		shippingErr error
 		mock        func(t *testing.T, r *mocks.Repository)
 		expectedErr error
 	}{
 		{
 			name: "success get city redis",
-			url:  constant.ONGKIR_API_URL,
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetCityRedis", mock.Anything, mock.Anything).Return("{}", nil)
 			},
@@ -423,7 +375,6 @@ func TestLocationUC_GetCity(t *testing.T) {
 		},
 		{
 			name: "success get city api",
-			url:  constant.ONGKIR_API_URL,
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetCityRedis", mock.Anything, mock.Anything).Return("{}", errors.New("test"))
 				r.On("InsertCityRedis", mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -432,7 +383,6 @@ func TestLocationUC_GetCity(t *testing.T) {
 		},
 		{
 			name: "error insert city api",
-			url:  constant.ONGKIR_API_URL,
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetCityRedis", mock.Anything, mock.Anything).Return("{}", errors.New("test"))
 				r.On("InsertCityRedis", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
@@ -440,16 +390,15 @@ func TestLocationUC_GetCity(t *testing.T) {
 			expectedErr: errors.New("test"),
 		},
 		{
-			name: "error get city api",
-			url:  "",
+			name:        "error get city api",
+			shippingErr: errors.New("test"),
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetCityRedis", mock.Anything, mock.Anything).Return("{}", errors.New("test"))
 			},
-			expectedErr: &url.Error{},
+			expectedErr: errors.New("test"),
 		},
 		{
 			name: "err get city redis",
-			url:  constant.ONGKIR_API_URL,
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetCityRedis", mock.Anything, mock.Anything).Return("", nil)
 			},
@@ -463,7 +412,7 @@ func TestLocationUC_GetCity(t *testing.T) {
 			mock.ExpectBegin()
 
 			r := mocks.NewRepository(t)
-			u := NewLocationUseCase(&config.Config{External: config.ExternalConfig{OngkirAPIURL: tc.url, OngkirAPIKey: constant.ONGKIR_API_KEY}}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewLocationUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, &stubShipping{err: tc.shippingErr}, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetCity(context.Background(), 1)
@@ -479,13 +428,12 @@ func TestLocationUC_GetCity(t *testing.T) {
 func TestLocationUC_GetProvince(t *testing.T) {
 	testCase := []struct {
 		name        string
-		url         string
+		shippingErr error
 		mock        func(t *testing.T, r *mocks.Repository)
 		expectedErr error
 	}{
 		{
 			name: "success get province redis",
-			url:  constant.ONGKIR_API_URL,
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetProvinceRedis", mock.Anything).Return("{}", nil)
 			},
@@ -493,7 +441,6 @@ func TestLocationUC_GetProvince(t *testing.T) {
 		},
 		{
 			name: "err get province redis",
-			url:  constant.ONGKIR_API_URL,
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetProvinceRedis", mock.Anything).Return("", nil)
 			},
@@ -501,7 +448,6 @@ func TestLocationUC_GetProvince(t *testing.T) {
 		},
 		{
 			name: "success get province api",
-			url:  constant.ONGKIR_API_URL,
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetProvinceRedis", mock.Anything).Return("", errors.New("test"))
 				r.On("InsertProvinceRedis", mock.Anything, mock.Anything).Return(nil)
@@ -510,7 +456,6 @@ func TestLocationUC_GetProvince(t *testing.T) {
 		},
 		{
 			name: "insert redis error province api",
-			url:  constant.ONGKIR_API_URL,
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetProvinceRedis", mock.Anything).Return("", errors.New("test"))
 				r.On("InsertProvinceRedis", mock.Anything, mock.Anything).Return(errors.New("test"))
@@ -518,12 +463,12 @@ func TestLocationUC_GetProvince(t *testing.T) {
 			expectedErr: errors.New("test"),
 		},
 		{
-			name: "error get province api",
-			url:  "",
+			name:        "error get province api",
+			shippingErr: errors.New("test"),
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetProvinceRedis", mock.Anything).Return("", errors.New("test"))
 			},
-			expectedErr: &url.Error{},
+			expectedErr: errors.New("test"),
 		},
 	}
 
@@ -533,7 +478,7 @@ func TestLocationUC_GetProvince(t *testing.T) {
 			mock.ExpectBegin()
 
 			r := mocks.NewRepository(t)
-			u := NewLocationUseCase(&config.Config{External: config.ExternalConfig{OngkirAPIURL: tc.url, OngkirAPIKey: constant.ONGKIR_API_KEY}}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewLocationUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, &stubShipping{err: tc.shippingErr}, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetProvince(context.Background())
@@ -0,0 +1,8 @@
+package notification
+
+import "github.com/gin-gonic/gin"
+
+type Handlers interface {
+	GetPreference(c *gin.Context)
+	UpdatePreference(c *gin.Context)
+}
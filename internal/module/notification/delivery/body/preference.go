@@ -0,0 +1,47 @@
+package body
+
+import (
+	"murakali/pkg/httperror"
+	"murakali/pkg/response"
+	"net/http"
+)
+
+const FieldCannotBeEmptyMessage = "Field cannot be empty."
+
+type UnprocessableEntity struct {
+	Fields map[string]string `json:"fields"`
+}
+
+type UpdatePreferenceRequest struct {
+	OrderEmail  *bool `json:"order_email"`
+	RefundEmail *bool `json:"refund_email"`
+}
+
+func (r *UpdatePreferenceRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"order_email":  "",
+			"refund_email": "",
+		},
+	}
+
+	if r.OrderEmail == nil {
+		unprocessableEntity = true
+		entity.Fields["order_email"] = FieldCannotBeEmptyMessage
+	}
+
+	if r.RefundEmail == nil {
+		unprocessableEntity = true
+		entity.Fields["refund_email"] = FieldCannotBeEmptyMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,79 @@
+package delivery
+
+import (
+	"errors"
+	"murakali/config"
+	"murakali/internal/module/notification"
+	"murakali/internal/module/notification/delivery/body"
+	"murakali/pkg/httperror"
+	"murakali/pkg/logger"
+	"murakali/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type notificationHandlers struct {
+	cfg            *config.Config
+	notificationUC notification.UseCase
+	logger         logger.Logger
+}
+
+func NewNotificationHandlers(cfg *config.Config, notificationUC notification.UseCase, log logger.Logger) notification.Handlers {
+	return &notificationHandlers{cfg: cfg, notificationUC: notificationUC, logger: log}
+}
+
+func (h *notificationHandlers) GetPreference(c *gin.Context) {
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	preference, err := h.notificationUC.GetPreference(c, userID.(string))
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, preference, http.StatusOK)
+}
+
+func (h *notificationHandlers) UpdatePreference(c *gin.Context) {
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	var requestBody body.UpdatePreferenceRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	preference, err := h.notificationUC.UpdatePreference(c, userID.(string), requestBody)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, preference, http.StatusOK)
+}
+
+func (h *notificationHandlers) errorResponse(c *gin.Context, err error) {
+	var e *httperror.Error
+	if !errors.As(err, &e) {
+		h.logger.Errorf("HandlerNotification, Error: %s", err)
+		response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+		return
+	}
+
+	response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+}
@@ -0,0 +1,169 @@
+package delivery
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"murakali/config"
+	"murakali/internal/model"
+	"murakali/internal/module/notification/delivery/body"
+	"murakali/internal/module/notification/mocks"
+	"murakali/pkg/httperror"
+	"murakali/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestLogger() logger.Logger {
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+			Level:             "info",
+		},
+	}
+
+	appLogger := logger.NewAPILogger(cfg)
+	appLogger.InitLogger()
+
+	return appLogger
+}
+
+func newTestContext(t *testing.T, method string, requestBody interface{}, userID string) (*gin.Context, *httptest.ResponseRecorder) {
+	jsonValue, err := json.Marshal(requestBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rr)
+	c.Request = httptest.NewRequest(method, "/api/v1/user/notification", bytes.NewBuffer(jsonValue))
+	c.Request.Header.Set("Content-Type", "application/json")
+	if userID != "" {
+		c.Set("userID", userID)
+	}
+
+	return c, rr
+}
+
+func TestNotificationHandlers_GetPreference(t *testing.T) {
+	testCase := []struct {
+		name     string
+		userID   string
+		mock     func(s *mocks.UseCase)
+		expected int
+	}{
+		{
+			name:   "success get preference",
+			userID: "user",
+			mock: func(s *mocks.UseCase) {
+				s.On("GetPreference", mock.Anything, "user").Return(&model.NotificationPreference{OrderEmail: true}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "error unauthorized",
+			mock:     func(s *mocks.UseCase) {},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:   "error get preference",
+			userID: "user",
+			mock: func(s *mocks.UseCase) {
+				s.On("GetPreference", mock.Anything, "user").Return(nil, errors.New("test"))
+			},
+			expected: http.StatusInternalServerError,
+		},
+		{
+			name:   "error get preference custom error",
+			userID: "user",
+			mock: func(s *mocks.UseCase) {
+				s.On("GetPreference", mock.Anything, "user").Return(nil, httperror.New(http.StatusBadRequest, "test"))
+			},
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			c, rr := newTestContext(t, http.MethodGet, nil, tc.userID)
+			s := mocks.NewUseCase(t)
+			h := NewNotificationHandlers(&config.Config{}, s, newTestLogger())
+
+			tc.mock(s)
+			h.GetPreference(c)
+
+			assert.Equal(t, tc.expected, rr.Code)
+		})
+	}
+}
+
+func TestNotificationHandlers_UpdatePreference(t *testing.T) {
+	on := true
+	testCase := []struct {
+		name     string
+		userID   string
+		body     interface{}
+		mock     func(s *mocks.UseCase)
+		expected int
+	}{
+		{
+			name:   "success update preference",
+			userID: "user",
+			body:   body.UpdatePreferenceRequest{OrderEmail: &on, RefundEmail: &on},
+			mock: func(s *mocks.UseCase) {
+				s.On("UpdatePreference", mock.Anything, "user", mock.Anything).Return(&model.NotificationPreference{}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "error unauthorized",
+			body:     body.UpdatePreferenceRequest{OrderEmail: &on, RefundEmail: &on},
+			mock:     func(s *mocks.UseCase) {},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "error bad request",
+			userID:   "user",
+			body:     "order_email",
+			mock:     func(s *mocks.UseCase) {},
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "error missing field",
+			userID:   "user",
+			body:     body.UpdatePreferenceRequest{OrderEmail: &on},
+			mock:     func(s *mocks.UseCase) {},
+			expected: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "error update preference",
+			userID: "user",
+			body:   body.UpdatePreferenceRequest{OrderEmail: &on, RefundEmail: &on},
+			mock: func(s *mocks.UseCase) {
+				s.On("UpdatePreference", mock.Anything, "user", mock.Anything).Return(nil, errors.New("test"))
+			},
+			expected: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			c, rr := newTestContext(t, http.MethodPut, tc.body, tc.userID)
+			s := mocks.NewUseCase(t)
+			h := NewNotificationHandlers(&config.Config{}, s, newTestLogger())
+
+			tc.mock(s)
+			h.UpdatePreference(c)
+
+			assert.Equal(t, tc.expected, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package delivery
+
+import (
+	"murakali/internal/middleware"
+	"murakali/internal/module/notification"
+
+	"github.com/gin-gonic/gin"
+)
+
+func MapNotificationRoutes(notificationGroup *gin.RouterGroup, h notification.Handlers, mw *middleware.MWManager) {
+	notificationGroup.Use(mw.AuthJWTMiddleware())
+	notificationGroup.GET("", h.GetPreference)
+	notificationGroup.PUT("", h.UpdatePreference)
+}
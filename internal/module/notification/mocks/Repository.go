@@ -0,0 +1,115 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "murakali/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	postgre "murakali/pkg/postgre"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// GetOrderRecipients provides a mock function with given fields: ctx, tx, orderID
+func (_m *Repository) GetOrderRecipients(ctx context.Context, tx postgre.Transaction, orderID string) (*model.OrderRecipients, error) {
+	ret := _m.Called(ctx, tx, orderID)
+
+	var r0 *model.OrderRecipients
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) *model.OrderRecipients); ok {
+		r0 = rf(ctx, tx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OrderRecipients)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string) error); ok {
+		r1 = rf(ctx, tx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPreference provides a mock function with given fields: ctx, userID
+func (_m *Repository) GetPreference(ctx context.Context, userID string) (*model.NotificationPreference, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *model.NotificationPreference
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.NotificationPreference); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.NotificationPreference)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRefundRecipients provides a mock function with given fields: ctx, tx, refundID
+func (_m *Repository) GetRefundRecipients(ctx context.Context, tx postgre.Transaction, refundID string) (*model.OrderRecipients, error) {
+	ret := _m.Called(ctx, tx, refundID)
+
+	var r0 *model.OrderRecipients
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) *model.OrderRecipients); ok {
+		r0 = rf(ctx, tx, refundID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OrderRecipients)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string) error); ok {
+		r1 = rf(ctx, tx, refundID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertPreference provides a mock function with given fields: ctx, preference
+func (_m *Repository) UpsertPreference(ctx context.Context, preference *model.NotificationPreference) error {
+	ret := _m.Called(ctx, preference)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.NotificationPreference) error); ok {
+		r0 = rf(ctx, preference)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
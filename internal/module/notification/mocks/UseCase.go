@@ -0,0 +1,108 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	body "murakali/internal/module/notification/delivery/body"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "murakali/internal/model"
+
+	postgre "murakali/pkg/postgre"
+)
+
+// UseCase is an autogenerated mock type for the UseCase type
+type UseCase struct {
+	mock.Mock
+}
+
+// GetPreference provides a mock function with given fields: ctx, userID
+func (_m *UseCase) GetPreference(ctx context.Context, userID string) (*model.NotificationPreference, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *model.NotificationPreference
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.NotificationPreference); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.NotificationPreference)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyOrderStatus provides a mock function with given fields: ctx, tx, orderID, orderStatusID
+func (_m *UseCase) NotifyOrderStatus(ctx context.Context, tx postgre.Transaction, orderID string, orderStatusID int) error {
+	ret := _m.Called(ctx, tx, orderID, orderStatusID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string, int) error); ok {
+		r0 = rf(ctx, tx, orderID, orderStatusID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyRefundReply provides a mock function with given fields: ctx, tx, refundID, text, fromSeller
+func (_m *UseCase) NotifyRefundReply(ctx context.Context, tx postgre.Transaction, refundID string, text string, fromSeller bool) error {
+	ret := _m.Called(ctx, tx, refundID, text, fromSeller)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string, string, bool) error); ok {
+		r0 = rf(ctx, tx, refundID, text, fromSeller)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePreference provides a mock function with given fields: ctx, userID, requestBody
+func (_m *UseCase) UpdatePreference(ctx context.Context, userID string, requestBody body.UpdatePreferenceRequest) (*model.NotificationPreference, error) {
+	ret := _m.Called(ctx, userID, requestBody)
+
+	var r0 *model.NotificationPreference
+	if rf, ok := ret.Get(0).(func(context.Context, string, body.UpdatePreferenceRequest) *model.NotificationPreference); ok {
+		r0 = rf(ctx, userID, requestBody)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.NotificationPreference)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, body.UpdatePreferenceRequest) error); ok {
+		r1 = rf(ctx, userID, requestBody)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewUseCase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUseCase creates a new instance of UseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUseCase(t mockConstructorTestingTNewUseCase) *UseCase {
+	mock := &UseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
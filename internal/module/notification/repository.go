@@ -0,0 +1,14 @@
+package notification
+
+import (
+	"context"
+	"murakali/internal/model"
+	"murakali/pkg/postgre"
+)
+
+type Repository interface {
+	GetOrderRecipients(ctx context.Context, tx postgre.Transaction, orderID string) (*model.OrderRecipients, error)
+	GetRefundRecipients(ctx context.Context, tx postgre.Transaction, refundID string) (*model.OrderRecipients, error)
+	GetPreference(ctx context.Context, userID string) (*model.NotificationPreference, error)
+	UpsertPreference(ctx context.Context, preference *model.NotificationPreference) error
+}
@@ -0,0 +1,36 @@
+package repository
+
+const (
+	// recipientsSelect reads both parties of an order "o", a missing
+	// preference row meaning every notification is on.
+	recipientsSelect = `SELECT o."id", s."name",
+		b."email", COALESCE(b."username", b."email"), COALESCE(bp."order_email", TRUE), COALESCE(bp."refund_email", TRUE),
+		su."email", COALESCE(su."username", su."email"), COALESCE(sp."order_email", TRUE), COALESCE(sp."refund_email", TRUE)`
+
+	recipientsJoin = `JOIN "user" b ON b."id" = o."user_id"
+	JOIN "shop" s ON s."id" = o."shop_id"
+	JOIN "user" su ON su."id" = s."user_id"
+	LEFT JOIN "notification_preference" bp ON bp."user_id" = b."id"
+	LEFT JOIN "notification_preference" sp ON sp."user_id" = su."id"`
+
+	GetOrderRecipientsQuery = recipientsSelect + `
+	FROM "order" o ` + recipientsJoin + `
+	WHERE o."id" = $1`
+
+	GetRefundRecipientsQuery = recipientsSelect + `
+	FROM "refund" r
+	JOIN "order" o ON o."id" = r."order_id" ` + recipientsJoin + `
+	WHERE r."id" = $1`
+
+	GetPreferenceQuery = `SELECT u."id", COALESCE(p."order_email", TRUE), COALESCE(p."refund_email", TRUE),
+		COALESCE(p."updated_at", u."created_at")
+	FROM "user" u
+	LEFT JOIN "notification_preference" p ON p."user_id" = u."id"
+	WHERE u."id" = $1 AND u."deleted_at" IS NULL`
+
+	UpsertPreferenceQuery = `INSERT INTO "notification_preference" ("user_id", "order_email", "refund_email")
+	VALUES ($1, $2, $3)
+	ON CONFLICT ("user_id") DO UPDATE SET "order_email" = EXCLUDED."order_email", "refund_email" = EXCLUDED."refund_email",
+		"updated_at" = NOW()
+	RETURNING "updated_at"`
+)
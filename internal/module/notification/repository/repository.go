@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"murakali/internal/model"
+	"murakali/internal/module/notification"
+	"murakali/pkg/postgre"
+)
+
+type notificationRepo struct {
+	PSQL *sql.DB
+}
+
+func NewNotificationRepository(psql *sql.DB) notification.Repository {
+	return &notificationRepo{
+		PSQL: psql,
+	}
+}
+
+func (r *notificationRepo) GetOrderRecipients(ctx context.Context, tx postgre.Transaction, orderID string) (*model.OrderRecipients, error) {
+	return scanRecipients(tx.QueryRowContext(ctx, GetOrderRecipientsQuery, orderID))
+}
+
+func (r *notificationRepo) GetRefundRecipients(ctx context.Context, tx postgre.Transaction, refundID string) (*model.OrderRecipients, error) {
+	return scanRecipients(tx.QueryRowContext(ctx, GetRefundRecipientsQuery, refundID))
+}
+
+func (r *notificationRepo) GetPreference(ctx context.Context, userID string) (*model.NotificationPreference, error) {
+	var preference model.NotificationPreference
+	if err := r.PSQL.QueryRowContext(ctx, GetPreferenceQuery, userID).Scan(
+		&preference.UserID,
+		&preference.OrderEmail,
+		&preference.RefundEmail,
+		&preference.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	return &preference, nil
+}
+
+func (r *notificationRepo) UpsertPreference(ctx context.Context, preference *model.NotificationPreference) error {
+	return r.PSQL.QueryRowContext(ctx, UpsertPreferenceQuery,
+		preference.UserID,
+		preference.OrderEmail,
+		preference.RefundEmail).Scan(&preference.UpdatedAt)
+}
+
+func scanRecipients(row *sql.Row) (*model.OrderRecipients, error) {
+	var recipients model.OrderRecipients
+	if err := row.Scan(
+		&recipients.OrderID,
+		&recipients.ShopName,
+		&recipients.Buyer.Email,
+		&recipients.Buyer.Name,
+		&recipients.Buyer.OrderEmail,
+		&recipients.Buyer.RefundEmail,
+		&recipients.Seller.Email,
+		&recipients.Seller.Name,
+		&recipients.Seller.OrderEmail,
+		&recipients.Seller.RefundEmail); err != nil {
+		return nil, err
+	}
+
+	return &recipients, nil
+}
@@ -0,0 +1,15 @@
+package notification
+
+import (
+	"context"
+	"murakali/internal/model"
+	"murakali/internal/module/notification/delivery/body"
+	"murakali/pkg/postgre"
+)
+
+type UseCase interface {
+	NotifyOrderStatus(ctx context.Context, tx postgre.Transaction, orderID string, orderStatusID int) error
+	NotifyRefundReply(ctx context.Context, tx postgre.Transaction, refundID, text string, fromSeller bool) error
+	GetPreference(ctx context.Context, userID string) (*model.NotificationPreference, error)
+	UpdatePreference(ctx context.Context, userID string, requestBody body.UpdatePreferenceRequest) (*model.NotificationPreference, error)
+}
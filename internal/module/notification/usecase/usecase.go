@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"murakali/config"
+	"murakali/internal/constant"
+	"murakali/internal/model"
+	"murakali/internal/module/notification"
+	"murakali/internal/module/notification/delivery/body"
+	"murakali/internal/module/outbox"
+	mail "murakali/pkg/email"
+	"murakali/pkg/httperror"
+	"murakali/pkg/postgre"
+	"murakali/pkg/response"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type notificationUC struct {
+	cfg              *config.Config
+	notificationRepo notification.Repository
+	outbox           outbox.UseCase
+}
+
+func NewNotificationUseCase(cfg *config.Config, notificationRepo notification.Repository, outboxUC outbox.UseCase) notification.UseCase {
+	return &notificationUC{cfg: cfg, notificationRepo: notificationRepo, outbox: outboxUC}
+}
+
+// orderStatusNotice is what the buyer and the seller are told when an order
+// reaches a status. A party without a title is not told.
+type orderStatusNotice struct {
+	buyerTitle    string
+	buyerMessage  string
+	sellerTitle   string
+	sellerMessage string
+}
+
+var orderStatusNotices = map[int]orderStatusNotice{
+	constant.OrderStatusWaitingForSeller: {
+		buyerTitle:    "Payment received",
+		buyerMessage:  "we received your payment and the shop will process your order soon.",
+		sellerTitle:   "New order",
+		sellerMessage: "you have a new paid order waiting to be processed.",
+	},
+	constant.OrderStatusProcessed: {
+		buyerTitle:   "Order processed",
+		buyerMessage: "the shop is preparing your order.",
+	},
+	constant.OrderStatusOnDelivery: {
+		buyerTitle:   "Order shipped",
+		buyerMessage: "your order is on its way.",
+	},
+	constant.OrderStatusDelivered: {
+		buyerTitle:   "Order delivered",
+		buyerMessage: "your order has arrived. Let the shop know once you received it.",
+	},
+	constant.OrderStatusCompleted: {
+		buyerTitle:    "Order completed",
+		buyerMessage:  "thank you for shopping, you can now review the products you bought.",
+		sellerTitle:   "Order completed",
+		sellerMessage: "the buyer completed the order.",
+	},
+	constant.OrderStatusCanceled: {
+		buyerTitle:    "Order canceled",
+		buyerMessage:  "your order was canceled.",
+		sellerTitle:   "Order canceled",
+		sellerMessage: "the order was canceled.",
+	},
+	constant.OrderStatusRefunded: {
+		buyerTitle:    "Order refunded",
+		buyerMessage:  "the payment of your order was refunded to your wallet.",
+		sellerTitle:   "Order refunded",
+		sellerMessage: "the payment of the order was refunded to the buyer.",
+	},
+}
+
+// NotifyOrderStatus queues the emails telling the buyer and the seller that
+// the order reached orderStatusID, in tx, the transaction that changed the
+// status. Statuses nobody is told about and users who turned order emails off
+// are skipped.
+func (u *notificationUC) NotifyOrderStatus(ctx context.Context, tx postgre.Transaction, orderID string, orderStatusID int) error {
+	notice, ok := orderStatusNotices[orderStatusID]
+	if !ok {
+		return nil
+	}
+
+	recipients, err := u.notificationRepo.GetOrderRecipients(ctx, tx, orderID)
+	if err != nil {
+		return err
+	}
+
+	for _, party := range []struct {
+		recipient model.NotificationRecipient
+		title     string
+		message   string
+		link      string
+	}{
+		{recipient: recipients.Buyer, title: notice.buyerTitle, message: notice.buyerMessage, link: u.buyerOrderLink(recipients)},
+		{recipient: recipients.Seller, title: notice.sellerTitle, message: notice.sellerMessage, link: u.sellerOrderLink(recipients)},
+	} {
+		if party.title == "" || !party.recipient.OrderEmail {
+			continue
+		}
+
+		msg, err := mail.NewMessage(party.recipient.Email, party.title, mail.TemplateOrderStatus, mail.OrderStatusData{
+			Name:     party.recipient.Name,
+			OrderID:  recipients.OrderID.String(),
+			ShopName: recipients.ShopName,
+			Title:    party.title,
+			Message:  party.message,
+			Link:     party.link,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := u.outbox.Enqueue(ctx, tx, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NotifyRefundReply queues the email telling the other party of a refund
+// that text was added to its thread, unless they turned refund emails off.
+func (u *notificationUC) NotifyRefundReply(ctx context.Context, tx postgre.Transaction, refundID, text string, fromSeller bool) error {
+	recipients, err := u.notificationRepo.GetRefundRecipients(ctx, tx, refundID)
+	if err != nil {
+		return err
+	}
+
+	recipient, from, link := recipients.Seller, recipients.Buyer.Name, u.sellerOrderLink(recipients)
+	if fromSeller {
+		recipient, from, link = recipients.Buyer, recipients.ShopName, u.buyerOrderLink(recipients)
+	}
+
+	if !recipient.RefundEmail {
+		return nil
+	}
+
+	msg, err := mail.NewMessage(recipient.Email, "New reply on your refund", mail.TemplateRefundReply, mail.RefundReplyData{
+		Name:    recipient.Name,
+		OrderID: recipients.OrderID.String(),
+		From:    from,
+		Text:    text,
+		Link:    link,
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.outbox.Enqueue(ctx, tx, msg)
+}
+
+func (u *notificationUC) buyerOrderLink(recipients *model.OrderRecipients) string {
+	return fmt.Sprintf("%s/order/%s", u.cfg.Server.Origin, recipients.OrderID)
+}
+
+func (u *notificationUC) sellerOrderLink(recipients *model.OrderRecipients) string {
+	return fmt.Sprintf("%s/seller-panel/order/%s", u.cfg.Server.Origin, recipients.OrderID)
+}
+
+func (u *notificationUC) GetPreference(ctx context.Context, userID string) (*model.NotificationPreference, error) {
+	preference, err := u.notificationRepo.GetPreference(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, httperror.New(http.StatusBadRequest, response.UserNotExistMessage)
+		}
+
+		return nil, err
+	}
+
+	return preference, nil
+}
+
+func (u *notificationUC) UpdatePreference(ctx context.Context, userID string,
+	requestBody body.UpdatePreferenceRequest) (*model.NotificationPreference, error) {
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, httperror.New(http.StatusBadRequest, response.BadRequestMessage)
+	}
+
+	preference := &model.NotificationPreference{
+		UserID:      parsedUserID,
+		OrderEmail:  *requestBody.OrderEmail,
+		RefundEmail: *requestBody.RefundEmail,
+	}
+	if err := u.notificationRepo.UpsertPreference(ctx, preference); err != nil {
+		return nil, err
+	}
+
+	return preference, nil
+}
@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"murakali/config"
+	"murakali/internal/constant"
+	"murakali/internal/model"
+	"murakali/internal/module/notification/delivery/body"
+	"murakali/internal/module/notification/mocks"
+	outboxMocks "murakali/internal/module/outbox/mocks"
+	"murakali/pkg/email"
+	"murakali/pkg/httperror"
+	"murakali/pkg/response"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	orderID = uuid.MustParse("008dc24d-1f30-4e13-823f-d62972f416df")
+	userID  = uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+)
+
+func newRecipients(buyerOn, sellerOn bool) *model.OrderRecipients {
+	return &model.OrderRecipients{
+		OrderID:  orderID,
+		ShopName: "Toko",
+		Buyer:    model.NotificationRecipient{Email: "buyer@test.com", Name: "buyer", OrderEmail: buyerOn, RefundEmail: buyerOn},
+		Seller:   model.NotificationRecipient{Email: "seller@test.com", Name: "seller", OrderEmail: sellerOn, RefundEmail: sellerOn},
+	}
+}
+
+func sentTo(recipient string) interface{} {
+	return mock.MatchedBy(func(msg *email.Message) bool {
+		return msg.To == recipient
+	})
+}
+
+func TestNotificationUC_NotifyOrderStatus(t *testing.T) {
+	testCase := []struct {
+		name          string
+		orderStatusID int
+		mock          func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase)
+		expectedErr   error
+	}{
+		{
+			name:          "success paid order tells buyer and seller",
+			orderStatusID: constant.OrderStatusWaitingForSeller,
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
+				r.On("GetOrderRecipients", mock.Anything, mock.Anything, orderID.String()).Return(newRecipients(true, true), nil)
+				o.On("Enqueue", mock.Anything, mock.Anything, mock.MatchedBy(func(msg *email.Message) bool {
+					return msg.To == "buyer@test.com" && msg.Subject == "Payment received" && msg.Template == email.TemplateOrderStatus &&
+						strings.Contains(msg.Text, "/order/"+orderID.String())
+				})).Return(nil).Once()
+				o.On("Enqueue", mock.Anything, mock.Anything, mock.MatchedBy(func(msg *email.Message) bool {
+					return msg.To == "seller@test.com" && msg.Subject == "New order" &&
+						strings.Contains(msg.Text, "/seller-panel/order/"+orderID.String())
+				})).Return(nil).Once()
+			},
+			expectedErr: nil,
+		},
+		{
+			name:          "success shipped order only tells buyer",
+			orderStatusID: constant.OrderStatusOnDelivery,
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
+				r.On("GetOrderRecipients", mock.Anything, mock.Anything, mock.Anything).Return(newRecipients(true, true), nil)
+				o.On("Enqueue", mock.Anything, mock.Anything, sentTo("buyer@test.com")).Return(nil).Once()
+			},
+			expectedErr: nil,
+		},
+		{
+			name:          "success skips users who turned order emails off",
+			orderStatusID: constant.OrderStatusCanceled,
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
+				r.On("GetOrderRecipients", mock.Anything, mock.Anything, mock.Anything).Return(newRecipients(false, true), nil)
+				o.On("Enqueue", mock.Anything, mock.Anything, sentTo("seller@test.com")).Return(nil).Once()
+			},
+			expectedErr: nil,
+		},
+		{
+			name:          "success status nobody is told about",
+			orderStatusID: constant.OrderStatusWaitingToPay,
+			mock:          func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {},
+			expectedErr:   nil,
+		},
+		{
+			name:          "error GetOrderRecipients",
+			orderStatusID: constant.OrderStatusDelivered,
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
+				r.On("GetOrderRecipients", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
+		{
+			name:          "error Enqueue",
+			orderStatusID: constant.OrderStatusRefunded,
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
+				r.On("GetOrderRecipients", mock.Anything, mock.Anything, mock.Anything).Return(newRecipients(true, true), nil)
+				o.On("Enqueue", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test")).Once()
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			o := outboxMocks.NewUseCase(t)
+			u := NewNotificationUseCase(&config.Config{}, r, o)
+
+			tc.mock(t, r, o)
+			err := u.NotifyOrderStatus(context.Background(), nil, orderID.String(), tc.orderStatusID)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNotificationUC_NotifyRefundReply(t *testing.T) {
+	testCase := []struct {
+		name        string
+		fromSeller  bool
+		mock        func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase)
+		expectedErr error
+	}{
+		{
+			name:       "success seller reply tells buyer",
+			fromSeller: true,
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
+				r.On("GetRefundRecipients", mock.Anything, mock.Anything, "refund").Return(newRecipients(true, true), nil)
+				o.On("Enqueue", mock.Anything, mock.Anything, mock.MatchedBy(func(msg *email.Message) bool {
+					return msg.To == "buyer@test.com" && msg.Template == email.TemplateRefundReply &&
+						strings.Contains(msg.Text, "Toko replied") && strings.Contains(msg.Text, `"barang rusak"`)
+				})).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "success buyer reply tells seller",
+			fromSeller: false,
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
+				r.On("GetRefundRecipients", mock.Anything, mock.Anything, "refund").Return(newRecipients(true, true), nil)
+				o.On("Enqueue", mock.Anything, mock.Anything, sentTo("seller@test.com")).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "success skips seller who turned refund emails off",
+			fromSeller: false,
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
+				r.On("GetRefundRecipients", mock.Anything, mock.Anything, "refund").Return(newRecipients(true, false), nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "error GetRefundRecipients",
+			fromSeller: true,
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
+				r.On("GetRefundRecipients", mock.Anything, mock.Anything, "refund").Return(nil, errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			o := outboxMocks.NewUseCase(t)
+			u := NewNotificationUseCase(&config.Config{}, r, o)
+
+			tc.mock(t, r, o)
+			err := u.NotifyRefundReply(context.Background(), nil, "refund", "barang rusak", tc.fromSeller)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNotificationUC_GetPreference(t *testing.T) {
+	testCase := []struct {
+		name        string
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetPreference", mock.Anything, userID.String()).Return(&model.NotificationPreference{OrderEmail: true}, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error user not exist",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetPreference", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+			},
+			expectedErr: httperror.New(http.StatusBadRequest, response.UserNotExistMessage),
+		},
+		{
+			name: "error GetPreference",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetPreference", mock.Anything, mock.Anything).Return(nil, errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := NewNotificationUseCase(&config.Config{}, r, nil)
+
+			tc.mock(t, r)
+			_, err := u.GetPreference(context.Background(), userID.String())
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNotificationUC_UpdatePreference(t *testing.T) {
+	on, off := true, false
+	testCase := []struct {
+		name        string
+		userID      string
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name:   "success",
+			userID: userID.String(),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("UpsertPreference", mock.Anything, mock.MatchedBy(func(preference *model.NotificationPreference) bool {
+					return preference.UserID == userID && preference.OrderEmail && !preference.RefundEmail
+				})).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "error invalid user id",
+			userID:      "123",
+			mock:        func(t *testing.T, r *mocks.Repository) {},
+			expectedErr: httperror.New(http.StatusBadRequest, response.BadRequestMessage),
+		},
+		{
+			name:   "error UpsertPreference",
+			userID: userID.String(),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("UpsertPreference", mock.Anything, mock.Anything).Return(errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := NewNotificationUseCase(&config.Config{}, r, nil)
+
+			tc.mock(t, r)
+			_, err := u.UpdatePreference(context.Background(), tc.userID, body.UpdatePreferenceRequest{OrderEmail: &on, RefundEmail: &off})
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
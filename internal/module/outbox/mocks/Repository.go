@@ -0,0 +1,99 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "murakali/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	postgre "murakali/pkg/postgre"
+
+	time "time"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// ClaimDueEmails provides a mock function with given fields: ctx, limit, lease
+func (_m *Repository) ClaimDueEmails(ctx context.Context, limit int, lease time.Duration) ([]*model.EmailOutbox, error) {
+	ret := _m.Called(ctx, limit, lease)
+
+	var r0 []*model.EmailOutbox
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []*model.EmailOutbox); ok {
+		r0 = rf(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.EmailOutbox)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateEmail provides a mock function with given fields: ctx, tx, outboxEmail
+func (_m *Repository) CreateEmail(ctx context.Context, tx postgre.Transaction, outboxEmail *model.EmailOutbox) error {
+	ret := _m.Called(ctx, tx, outboxEmail)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.EmailOutbox) error); ok {
+		r0 = rf(ctx, tx, outboxEmail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkEmailFailed provides a mock function with given fields: ctx, outboxEmail
+func (_m *Repository) MarkEmailFailed(ctx context.Context, outboxEmail *model.EmailOutbox) error {
+	ret := _m.Called(ctx, outboxEmail)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.EmailOutbox) error); ok {
+		r0 = rf(ctx, outboxEmail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkEmailSent provides a mock function with given fields: ctx, outboxEmail
+func (_m *Repository) MarkEmailSent(ctx context.Context, outboxEmail *model.EmailOutbox) error {
+	ret := _m.Called(ctx, outboxEmail)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.EmailOutbox) error); ok {
+		r0 = rf(ctx, outboxEmail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,71 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	email "murakali/pkg/email"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "murakali/internal/model"
+
+	postgre "murakali/pkg/postgre"
+)
+
+// UseCase is an autogenerated mock type for the UseCase type
+type UseCase struct {
+	mock.Mock
+}
+
+// DispatchEmails provides a mock function with given fields: ctx
+func (_m *UseCase) DispatchEmails(ctx context.Context) (*model.JobReport, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *model.JobReport
+	if rf, ok := ret.Get(0).(func(context.Context) *model.JobReport); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.JobReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Enqueue provides a mock function with given fields: ctx, tx, msg
+func (_m *UseCase) Enqueue(ctx context.Context, tx postgre.Transaction, msg *email.Message) error {
+	ret := _m.Called(ctx, tx, msg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *email.Message) error); ok {
+		r0 = rf(ctx, tx, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewUseCase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUseCase creates a new instance of UseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUseCase(t mockConstructorTestingTNewUseCase) *UseCase {
+	mock := &UseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,15 @@
+package outbox
+
+import (
+	"context"
+	"murakali/internal/model"
+	"murakali/pkg/postgre"
+	"time"
+)
+
+type Repository interface {
+	CreateEmail(ctx context.Context, tx postgre.Transaction, outboxEmail *model.EmailOutbox) error
+	ClaimDueEmails(ctx context.Context, limit int, lease time.Duration) ([]*model.EmailOutbox, error)
+	MarkEmailSent(ctx context.Context, outboxEmail *model.EmailOutbox) error
+	MarkEmailFailed(ctx context.Context, outboxEmail *model.EmailOutbox) error
+}
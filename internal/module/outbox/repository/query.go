@@ -0,0 +1,25 @@
+package repository
+
+const (
+	CreateEmailQuery = `INSERT INTO "email_outbox" ("recipient", "subject", "template", "html_body", "text_body", "status")
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING "id", "next_attempt_at", "created_at"`
+
+	// ClaimDueEmailsQuery pushes next_attempt_at of the due emails past the
+	// lease, so a worker that dies mid-batch only delays them, and skips rows
+	// another worker is claiming.
+	ClaimDueEmailsQuery = `UPDATE "email_outbox" SET "next_attempt_at" = now() + $2 * interval '1 second', "updated_at" = now()
+	WHERE "id" IN (
+		SELECT "id" FROM "email_outbox"
+		WHERE "status" = $3 AND "next_attempt_at" <= now()
+		ORDER BY "next_attempt_at" LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING "id", "recipient", "subject", "template", "html_body", "text_body", "status", "attempts",
+	"next_attempt_at", "created_at"`
+
+	MarkEmailSentQuery = `UPDATE "email_outbox" SET "status" = $1, "attempts" = $2, "last_error" = '', "sent_at" = $3,
+	"updated_at" = now() WHERE "id" = $4`
+
+	MarkEmailFailedQuery = `UPDATE "email_outbox" SET "status" = $1, "attempts" = $2, "last_error" = $3,
+	"next_attempt_at" = $4, "updated_at" = now() WHERE "id" = $5`
+)
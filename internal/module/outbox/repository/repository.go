@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"murakali/internal/constant"
+	"murakali/internal/model"
+	"murakali/internal/module/outbox"
+	"murakali/pkg/postgre"
+	"time"
+)
+
+type outboxRepo struct {
+	PSQL *sql.DB
+}
+
+func NewOutboxRepository(psql *sql.DB) outbox.Repository {
+	return &outboxRepo{
+		PSQL: psql,
+	}
+}
+
+func (r *outboxRepo) CreateEmail(ctx context.Context, tx postgre.Transaction, outboxEmail *model.EmailOutbox) error {
+	return tx.QueryRowContext(ctx, CreateEmailQuery,
+		outboxEmail.Recipient,
+		outboxEmail.Subject,
+		outboxEmail.Template,
+		outboxEmail.HTMLBody,
+		outboxEmail.TextBody,
+		outboxEmail.Status).Scan(&outboxEmail.ID, &outboxEmail.NextAttemptAt, &outboxEmail.CreatedAt)
+}
+
+func (r *outboxRepo) ClaimDueEmails(ctx context.Context, limit int, lease time.Duration) ([]*model.EmailOutbox, error) {
+	emails := make([]*model.EmailOutbox, 0)
+	res, err := r.PSQL.QueryContext(ctx, ClaimDueEmailsQuery, limit, int(lease.Seconds()), constant.EmailStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var outboxEmail model.EmailOutbox
+		if errScan := res.Scan(
+			&outboxEmail.ID,
+			&outboxEmail.Recipient,
+			&outboxEmail.Subject,
+			&outboxEmail.Template,
+			&outboxEmail.HTMLBody,
+			&outboxEmail.TextBody,
+			&outboxEmail.Status,
+			&outboxEmail.Attempts,
+			&outboxEmail.NextAttemptAt,
+			&outboxEmail.CreatedAt); errScan != nil {
+			return nil, errScan
+		}
+
+		emails = append(emails, &outboxEmail)
+	}
+
+	if res.Err() != nil {
+		return nil, res.Err()
+	}
+
+	return emails, nil
+}
+
+func (r *outboxRepo) MarkEmailSent(ctx context.Context, outboxEmail *model.EmailOutbox) error {
+	_, err := r.PSQL.ExecContext(ctx, MarkEmailSentQuery,
+		outboxEmail.Status,
+		outboxEmail.Attempts,
+		outboxEmail.SentAt,
+		outboxEmail.ID)
+
+	return err
+}
+
+func (r *outboxRepo) MarkEmailFailed(ctx context.Context, outboxEmail *model.EmailOutbox) error {
+	_, err := r.PSQL.ExecContext(ctx, MarkEmailFailedQuery,
+		outboxEmail.Status,
+		outboxEmail.Attempts,
+		outboxEmail.LastError,
+		outboxEmail.NextAttemptAt,
+		outboxEmail.ID)
+
+	return err
+}
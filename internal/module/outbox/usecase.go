@@ -0,0 +1,13 @@
+package outbox
+
+import (
+	"context"
+	"murakali/internal/model"
+	"murakali/pkg/email"
+	"murakali/pkg/postgre"
+)
+
+type UseCase interface {
+	Enqueue(ctx context.Context, tx postgre.Transaction, msg *email.Message) error
+	DispatchEmails(ctx context.Context) (*model.JobReport, error)
+}
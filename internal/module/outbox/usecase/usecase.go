@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"murakali/config"
+	"murakali/internal/constant"
+	"murakali/internal/model"
+	"murakali/internal/module/outbox"
+	"murakali/pkg/email"
+	"murakali/pkg/postgre"
+	"time"
+)
+
+type outboxUC struct {
+	cfg        *config.Config
+	outboxRepo outbox.Repository
+	mailer     email.Mailer
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+func NewOutboxUseCase(cfg *config.Config, outboxRepo outbox.Repository, mailer email.Mailer) outbox.UseCase {
+	return &outboxUC{
+		cfg:        cfg,
+		outboxRepo: outboxRepo,
+		mailer:     mailer,
+		backoff:    time.Duration(constant.EmailOutboxBackoffSec) * time.Second,
+		maxBackoff: time.Duration(constant.EmailOutboxMaxBackoffMin) * time.Minute,
+	}
+}
+
+// Enqueue stores msg in tx, the transaction of the change it tells the
+// recipient about, so the email goes out if and only if the change commits.
+func (u *outboxUC) Enqueue(ctx context.Context, tx postgre.Transaction, msg *email.Message) error {
+	return u.outboxRepo.CreateEmail(ctx, tx, &model.EmailOutbox{
+		Recipient: msg.To,
+		Subject:   msg.Subject,
+		Template:  msg.Template,
+		HTMLBody:  msg.HTML,
+		TextBody:  msg.Text,
+		Status:    constant.EmailStatusPending,
+	})
+}
+
+// DispatchEmails sends a batch of due emails. A failed email is retried later,
+// waiting twice as long after every attempt, until it runs out of attempts and
+// is left dead for an operator to look at. Only storing the outcome can fail
+// the job; the sent count is the rows affected.
+func (u *outboxUC) DispatchEmails(ctx context.Context) (*model.JobReport, error) {
+	report := model.NewJobReport(constant.JobEmailOutbox)
+	defer report.Finish()
+
+	emails, err := u.outboxRepo.ClaimDueEmails(ctx, constant.EmailOutboxBatchSize,
+		time.Duration(constant.EmailOutboxLeaseMin)*time.Minute)
+	if err != nil {
+		return report, err
+	}
+
+	for _, outboxEmail := range emails {
+		outboxEmail.Attempts++
+		errSend := u.mailer.Send(ctx, &email.Message{
+			To:       outboxEmail.Recipient,
+			Subject:  outboxEmail.Subject,
+			Template: outboxEmail.Template,
+			HTML:     outboxEmail.HTMLBody,
+			Text:     outboxEmail.TextBody,
+		})
+		if errSend != nil {
+			outboxEmail.LastError = errSend.Error()
+			outboxEmail.NextAttemptAt = time.Now().Add(u.retryDelay(outboxEmail.Attempts))
+			if outboxEmail.Attempts >= constant.EmailOutboxMaxAttempts {
+				outboxEmail.Status = constant.EmailStatusDead
+			}
+
+			if err := u.outboxRepo.MarkEmailFailed(ctx, outboxEmail); err != nil {
+				return report, err
+			}
+			continue
+		}
+
+		outboxEmail.Status = constant.EmailStatusSent
+		outboxEmail.SentAt = sql.NullTime{Time: time.Now(), Valid: true}
+		if err := u.outboxRepo.MarkEmailSent(ctx, outboxEmail); err != nil {
+			return report, err
+		}
+		report.RowsAffected++
+	}
+
+	return report, nil
+}
+
+func (u *outboxUC) retryDelay(attempts int) time.Duration {
+	delay := time.Duration(float64(u.backoff) * math.Pow(2, float64(attempts-1)))
+	if delay > u.maxBackoff {
+		return u.maxBackoff
+	}
+
+	return delay
+}
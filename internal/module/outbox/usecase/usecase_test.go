@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"murakali/config"
+	"murakali/internal/constant"
+	"murakali/internal/model"
+	"murakali/internal/module/outbox/mocks"
+	"murakali/pkg/email"
+	mailerMocks "murakali/pkg/email/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOutboxUC_Enqueue(t *testing.T) {
+	r := mocks.NewRepository(t)
+	u := NewOutboxUseCase(&config.Config{}, r, mailerMocks.NewMailer(t))
+
+	r.On("CreateEmail", mock.Anything, mock.Anything, mock.MatchedBy(func(outboxEmail *model.EmailOutbox) bool {
+		return outboxEmail.Recipient == "a@test.com" && outboxEmail.Template == email.TemplateVerificationOTP &&
+			outboxEmail.TextBody == "text" && outboxEmail.Status == constant.EmailStatusPending
+	})).Return(nil)
+
+	err := u.Enqueue(context.Background(), nil, &email.Message{
+		To: "a@test.com", Subject: "subject", Template: email.TemplateVerificationOTP, HTML: "html", Text: "text",
+	})
+	assert.NoError(t, err)
+}
+
+func TestOutboxUC_DispatchEmails(t *testing.T) {
+	testCase := []struct {
+		name         string
+		mock         func(t *testing.T, r *mocks.Repository, m *mailerMocks.Mailer)
+		rowsAffected int64
+		expectedErr  error
+	}{
+		{
+			name: "success",
+			mock: func(t *testing.T, r *mocks.Repository, m *mailerMocks.Mailer) {
+				r.On("ClaimDueEmails", mock.Anything, constant.EmailOutboxBatchSize, mock.Anything).
+					Return([]*model.EmailOutbox{{Recipient: "a@test.com"}, {Recipient: "b@test.com"}}, nil)
+				m.On("Send", mock.Anything, mock.Anything).Return(nil)
+				r.On("MarkEmailSent", mock.Anything, mock.MatchedBy(func(outboxEmail *model.EmailOutbox) bool {
+					return outboxEmail.Status == constant.EmailStatusSent && outboxEmail.Attempts == 1 && outboxEmail.SentAt.Valid
+				})).Return(nil)
+			},
+			rowsAffected: 2,
+			expectedErr:  nil,
+		},
+		{
+			name: "send failure is retried with backoff",
+			mock: func(t *testing.T, r *mocks.Repository, m *mailerMocks.Mailer) {
+				r.On("ClaimDueEmails", mock.Anything, mock.Anything, mock.Anything).
+					Return([]*model.EmailOutbox{{Recipient: "a@test.com", Status: constant.EmailStatusPending, Attempts: 2}}, nil)
+				m.On("Send", mock.Anything, mock.Anything).Return(errors.New("smtp down"))
+				r.On("MarkEmailFailed", mock.Anything, mock.MatchedBy(func(outboxEmail *model.EmailOutbox) bool {
+					delay := time.Until(outboxEmail.NextAttemptAt)
+					return outboxEmail.Status == constant.EmailStatusPending && outboxEmail.Attempts == 3 && outboxEmail.LastError == "smtp down" &&
+						delay > 110*time.Second && delay <= 120*time.Second
+				})).Return(nil)
+			},
+			rowsAffected: 0,
+			expectedErr:  nil,
+		},
+		{
+			name: "send failure on the last attempt is dead",
+			mock: func(t *testing.T, r *mocks.Repository, m *mailerMocks.Mailer) {
+				r.On("ClaimDueEmails", mock.Anything, mock.Anything, mock.Anything).
+					Return([]*model.EmailOutbox{{Recipient: "a@test.com", Attempts: constant.EmailOutboxMaxAttempts - 1}}, nil)
+				m.On("Send", mock.Anything, mock.Anything).Return(errors.New("mailbox unavailable"))
+				r.On("MarkEmailFailed", mock.Anything, mock.MatchedBy(func(outboxEmail *model.EmailOutbox) bool {
+					return outboxEmail.Status == constant.EmailStatusDead && outboxEmail.Attempts == constant.EmailOutboxMaxAttempts
+				})).Return(nil)
+			},
+			rowsAffected: 0,
+			expectedErr:  nil,
+		},
+		{
+			name: "error ClaimDueEmails",
+			mock: func(t *testing.T, r *mocks.Repository, m *mailerMocks.Mailer) {
+				r.On("ClaimDueEmails", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
+		{
+			name: "error MarkEmailSent",
+			mock: func(t *testing.T, r *mocks.Repository, m *mailerMocks.Mailer) {
+				r.On("ClaimDueEmails", mock.Anything, mock.Anything, mock.Anything).
+					Return([]*model.EmailOutbox{{Recipient: "a@test.com"}}, nil)
+				m.On("Send", mock.Anything, mock.Anything).Return(nil)
+				r.On("MarkEmailSent", mock.Anything, mock.Anything).Return(errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			m := mailerMocks.NewMailer(t)
+			u := NewOutboxUseCase(&config.Config{}, r, m)
+
+			tc.mock(t, r, m)
+			report, err := u.DispatchEmails(context.Background())
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.rowsAffected, report.RowsAffected)
+			}
+		})
+	}
+}
+
+func TestOutboxUC_retryDelay(t *testing.T) {
+	u := &outboxUC{backoff: 30 * time.Second, maxBackoff: time.Hour}
+
+	assert.Equal(t, 30*time.Second, u.retryDelay(1))
+	assert.Equal(t, 4*time.Minute, u.retryDelay(4))
+	assert.Equal(t, time.Hour, u.retryDelay(10))
+}
@@ -4,6 +4,8 @@ import "github.com/gin-gonic/gin"
 
 type Handlers interface {
 	GetProducts(c *gin.Context)
+	GetSearchSuggestions(c *gin.Context)
+	GetTrendingSearches(c *gin.Context)
 	GetCategories(c *gin.Context)
 	GetBanners(c *gin.Context)
 	GetCategoriesByNameLevelOne(c *gin.Context)
@@ -21,6 +23,10 @@ type Handlers interface {
 	GetTotalReviewRatingByProductID(c *gin.Context)
 	CreateProductReview(c *gin.Context)
 	DeleteProductReview(c *gin.Context)
+	ReplyProductReview(c *gin.Context)
+	VoteProductReview(c *gin.Context)
+	DeleteProductReviewVote(c *gin.Context)
+	ReportProductReview(c *gin.Context)
 	CreateProduct(c *gin.Context)
 	UpdateListedStatus(c *gin.Context)
 	UpdateListedStatusBulk(c *gin.Context)
@@ -2,6 +2,7 @@ package body
 
 import (
 	"mime/multipart"
+	"murakali/internal/model"
 	"murakali/pkg/httperror"
 	"murakali/pkg/response"
 	"net/http"
@@ -32,15 +33,15 @@ type CreateProductInfoForQuery struct {
 	Description  string
 	Thumbnail    string
 	CategoryID   string
-	MinPrice     float64
-	MaxPrice     float64
+	MinPrice     model.Money
+	MaxPrice     model.Money
 	ShopID       string
 	SKU          string
 	ListedStatus bool
 }
 
 type CreateProductDetailRequest struct {
-	Price         float64                `json:"price"`
+	Price         model.Money            `json:"price"`
 	Stock         float64                `json:"stock"`
 	Weight        float64                `json:"weight"`
 	Size          float64                `json:"size"`
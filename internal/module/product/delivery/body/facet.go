@@ -0,0 +1,42 @@
+package body
+
+import "murakali/internal/model"
+
+const (
+	FacetCategory = "category"
+	FacetProvince = "province"
+	FacetPrice    = "price"
+	FacetRating   = "rating"
+)
+
+// ProductFacets counts the products of a listing per facet value. Each facet
+// ignores its own filter, so the counts show what choosing another value
+// would return.
+type ProductFacets struct {
+	Categories []*FacetCount     `json:"categories"`
+	Provinces  []*FacetCount     `json:"provinces"`
+	PriceBands []*PriceBandFacet `json:"price_bands"`
+	Ratings    []*RatingFacet    `json:"ratings"`
+}
+
+// FacetCount is a value of the category or province facet, Value being what
+// the category or province_ids query expects.
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+// PriceBandFacet counts the products whose min_price is in
+// [MinPrice, MaxPrice). The last band has no MaxPrice.
+type PriceBandFacet struct {
+	MinPrice model.Money  `json:"min_price"`
+	MaxPrice *model.Money `json:"max_price"`
+	Count    int64        `json:"count"`
+}
+
+// RatingFacet counts the products rated MinRating or more.
+type RatingFacet struct {
+	MinRating float64 `json:"min_rating"`
+	Count     int64   `json:"count"`
+}
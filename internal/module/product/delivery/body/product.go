@@ -1,6 +1,7 @@
 package body
 
 import (
+	"murakali/internal/model"
 	"murakali/pkg/httperror"
 	"murakali/pkg/response"
 	"net/http"
@@ -20,12 +21,13 @@ type UnprocessableEntity struct {
 
 type GetProductQueryRequest struct {
 	Search       string
+	Keyword      string
 	Sort         string
 	SortBy       string
 	Shop         string
 	Category     string
-	MinPrice     float64
-	MaxPrice     float64
+	MinPrice     model.Money
+	MaxPrice     model.Money
 	MinRating    float64
 	MaxRating    float64
 	ListedStatus int
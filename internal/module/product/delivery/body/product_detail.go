@@ -1,6 +1,9 @@
 package body
 
-import "time"
+import (
+	"murakali/internal/model"
+	"time"
+)
 
 type ProductDetailRequest struct {
 }
@@ -12,39 +15,39 @@ type ProductDetailResponse struct {
 }
 
 type ProductInfo struct {
-	ProductID     string   `json:"id"`
-	SKU           string   `json:"sku"`
-	Title         string   `json:"title"`
-	Description   string   `json:"description"`
-	ViewCount     int64    `json:"view_count"`
-	FavoriteCount int64    `json:"favorite_count"`
-	UnitSold      float64  `json:"unit_sold"`
-	ListedStatus  bool     `json:"listed_status"`
-	ThumbnailURL  string   `json:"thumbnail_url"`
-	RatingAVG     *float64 `json:"rating_avg"`
-	MinPrice      *float64 `json:"min_price"`
-	MaxPrice      *float64 `json:"max_price"`
-	ShopID        string   `json:"shop_id"`
-	CategoryName  string   `json:"category_name"`
-	CategoryURL   string   `json:"category_url"`
+	ProductID     string       `json:"id"`
+	SKU           string       `json:"sku"`
+	Title         string       `json:"title"`
+	Description   string       `json:"description"`
+	ViewCount     int64        `json:"view_count"`
+	FavoriteCount int64        `json:"favorite_count"`
+	UnitSold      float64      `json:"unit_sold"`
+	ListedStatus  bool         `json:"listed_status"`
+	ThumbnailURL  string       `json:"thumbnail_url"`
+	RatingAVG     *float64     `json:"rating_avg"`
+	MinPrice      *model.Money `json:"min_price"`
+	MaxPrice      *model.Money `json:"max_price"`
+	ShopID        string       `json:"shop_id"`
+	CategoryName  string       `json:"category_name"`
+	CategoryURL   string       `json:"category_url"`
 }
 
 type PromotionInfo struct {
-	PromotionName               string     `json:"promotion_name"`
-	PromotionDiscountPercentage *float64   `json:"promotion_discount_percentage"`
-	PromotionDiscountFixPrice   *float64   `json:"promotion_discount_fix_price"`
-	PromotionMinProductPrice    *float64   `json:"promotion_min_product_price"`
-	PromotionMaxDiscountPrice   *float64   `json:"promotion_max_discount_price"`
-	PromotionQuota              *int       `json:"promotion_quota"`
-	PromotionMaxQuantity        *int64     `json:"promotion_max_quantity"`
-	PromotionActiveDate         *time.Time `json:"promotion_active_date"`
-	PromotionExpiryDate         *time.Time `json:"promotion_expiry_date"`
+	PromotionName               string       `json:"promotion_name"`
+	PromotionDiscountPercentage *float64     `json:"promotion_discount_percentage"`
+	PromotionDiscountFixPrice   *model.Money `json:"promotion_discount_fix_price"`
+	PromotionMinProductPrice    *model.Money `json:"promotion_min_product_price"`
+	PromotionMaxDiscountPrice   *model.Money `json:"promotion_max_discount_price"`
+	PromotionQuota              *int         `json:"promotion_quota"`
+	PromotionMaxQuantity        *int64       `json:"promotion_max_quantity"`
+	PromotionActiveDate         *time.Time   `json:"promotion_active_date"`
+	PromotionExpiryDate         *time.Time   `json:"promotion_expiry_date"`
 }
 
 type ProductDetail struct {
 	ProductDetailID string            `json:"id"`
-	NormalPrice     *float64          `json:"normal_price"`
-	DiscountPrice   *float64          `json:"discount_price"`
+	NormalPrice     *model.Money      `json:"normal_price"`
+	DiscountPrice   *model.Money      `json:"discount_price"`
 	Stock           *float64          `json:"stock"`
 	Weight          *float64          `json:"weight"`
 	Size            *float64          `json:"size"`
@@ -11,20 +11,55 @@ import (
 )
 
 const (
-	ReviewAlreadyExist = "Product Review Already Exist"
-	ReviewNotExist     = "Review Not Exist"
+	ReviewAlreadyExist       = "Product Review Already Exist"
+	ReviewNotExist           = "Review Not Exist"
+	ReviewOrderItemNotExist  = "Order Item Not Exist"
+	ReviewOrderNotCompleted  = "Order Is Not Completed"
+	ReviewProductNotInOrder  = "Product Is Not In Order Item"
+	ReviewInvalidOrderItemID = "Invalid order item id."
+	ReviewVoteOwn            = "Cannot Vote Own Review"
+	ReviewVoteNotExist       = "Review Vote Not Exist"
+	ReviewReportOwn          = "Cannot Report Own Review"
+	ReviewAlreadyReported    = "Review Already Reported"
+
+	ReviewSortMostHelpful = "most_helpful"
 )
 
+// ReviewProduct is a review with its author. Reviews of an order item are
+// verified purchases and carry the variant that was bought.
 type ReviewProduct struct {
+	ID               uuid.UUID         `json:"id"`
+	UserID           uuid.UUID         `json:"user_id"`
+	ProductID        uuid.UUID         `json:"product_id"`
+	OrderItemID      *uuid.UUID        `json:"order_item_id"`
+	Comment          *string           `json:"comment"`
+	Rating           int               `json:"rating"`
+	ImageURL         *string           `json:"image_url"`
+	CreatedAt        time.Time         `json:"created_at"`
+	PhotoURL         *string           `json:"photo_url"`
+	Username         string            `json:"username"`
+	VerifiedPurchase bool              `json:"verified_purchase"`
+	Variant          map[string]string `json:"variant"`
+	HelpfulCount     int               `json:"helpful_count"`
+	UnhelpfulCount   int               `json:"unhelpful_count"`
+	Reply            *ReviewReply      `json:"reply"`
+}
+
+// ReviewReply is the answer of the shop to a review.
+type ReviewReply struct {
 	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	ProductID uuid.UUID `json:"product_id"`
-	Comment   *string   `json:"comment"`
-	Rating    int       `json:"rating"`
-	ImageURL  *string   `json:"image_url"`
+	Comment   string    `json:"comment"`
 	CreatedAt time.Time `json:"created_at"`
-	PhotoURL  *string   `json:"photo_url"`
-	Username  string    `json:"username"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReviewOrderItem is an order item as far as reviewing it is concerned.
+type ReviewOrderItem struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	OrderStatusID int
+	ProductID     uuid.UUID
+	IsReview      bool
 }
 
 type RatingProduct struct {
@@ -68,18 +103,20 @@ func (p *GetReviewQueryRequest) GetValidate() string {
 }
 
 type ReviewProductRequest struct {
-	ProductID string  `json:"product_id"`
-	Comment   *string `json:"comment,omitempty"`
-	Rating    int     `json:"rating"`
-	PhotoURL  *string `json:"photo_url,omitempty"`
+	ProductID   string  `json:"product_id"`
+	OrderItemID string  `json:"order_item_id"`
+	Comment     *string `json:"comment,omitempty"`
+	Rating      int     `json:"rating"`
+	PhotoURL    *string `json:"photo_url,omitempty"`
 }
 
 func (r *ReviewProductRequest) Validate() (UnprocessableEntity, error) {
 	unprocessableEntity := false
 	entity := UnprocessableEntity{
 		Fields: map[string]string{
-			"product_id": "",
-			"rating":     "",
+			"product_id":    "",
+			"order_item_id": "",
+			"rating":        "",
 		},
 	}
 
@@ -89,6 +126,15 @@ func (r *ReviewProductRequest) Validate() (UnprocessableEntity, error) {
 		entity.Fields["product_id"] = FieldCannotBeEmptyMessage
 	}
 
+	r.OrderItemID = strings.TrimSpace(r.OrderItemID)
+	if r.OrderItemID == "" {
+		unprocessableEntity = true
+		entity.Fields["order_item_id"] = FieldCannotBeEmptyMessage
+	} else if _, err := uuid.Parse(r.OrderItemID); err != nil {
+		unprocessableEntity = true
+		entity.Fields["order_item_id"] = ReviewInvalidOrderItemID
+	}
+
 	if r.Rating == 0 {
 		unprocessableEntity = true
 		entity.Fields["rating"] = FieldCannotBeEmptyMessage
@@ -131,3 +177,86 @@ func (r *DeleteReviewProductRequest) Validate() (UnprocessableEntity, error) {
 
 	return entity, nil
 }
+
+type ReviewReplyRequest struct {
+	Comment string `json:"comment"`
+}
+
+func (r *ReviewReplyRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"comment": "",
+		},
+	}
+
+	r.Comment = strings.TrimSpace(r.Comment)
+	if r.Comment == "" {
+		unprocessableEntity = true
+		entity.Fields["comment"] = FieldCannotBeEmptyMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
+
+type ReviewVoteRequest struct {
+	IsHelpful *bool `json:"is_helpful"`
+}
+
+func (r *ReviewVoteRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"is_helpful": "",
+		},
+	}
+
+	if r.IsHelpful == nil {
+		unprocessableEntity = true
+		entity.Fields["is_helpful"] = FieldCannotBeEmptyMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
+
+type ReportReviewRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (r *ReportReviewRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"reason": "",
+		},
+	}
+
+	r.Reason = strings.TrimSpace(r.Reason)
+	if r.Reason == "" {
+		unprocessableEntity = true
+		entity.Fields["reason"] = FieldCannotBeEmptyMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,61 @@
+package body
+
+import (
+	"html"
+	"murakali/pkg/pagination"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SortByRelevance = "relevance"
+
+	SuggestProduct  = "product"
+	SuggestCategory = "category"
+	SuggestShop     = "shop"
+
+	highlightStart = "\x02"
+	highlightStop  = "\x03"
+)
+
+var highlightReplacer = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// NormalizeSearch lowercases keyword and collapses its whitespace, so the
+// same search is logged, counted and cached once.
+func NormalizeSearch(keyword string) string {
+	return strings.ToLower(strings.Join(strings.Fields(keyword), " "))
+}
+
+// HighlightSearch escapes text from ts_headline and turns the words it
+// marked into <mark> elements, so a description cannot inject markup.
+func HighlightSearch(text string) string {
+	return highlightReplacer.Replace(html.EscapeString(text))
+}
+
+// GetProductsResponse is a page of products with the facets of the whole
+// listing. DidYouMean is set when a search has a word that is closer to
+// another word of the catalogue.
+type GetProductsResponse struct {
+	*pagination.Pagination
+	DidYouMean string         `json:"did_you_mean,omitempty"`
+	Facets     *ProductFacets `json:"facets"`
+}
+
+// SearchSuggestion completes what a user is typing in the search box.
+type SearchSuggestion struct {
+	Products   []*SuggestItem `json:"products"`
+	Categories []*SuggestItem `json:"categories"`
+	Shops      []*SuggestItem `json:"shops"`
+}
+
+type SuggestItem struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	ImageURL *string   `json:"image_url"`
+}
+
+type TrendingSearch struct {
+	Query string `json:"query"`
+	Count int64  `json:"count"`
+}
@@ -1,6 +1,7 @@
 package body
 
 import (
+	"murakali/internal/model"
 	"murakali/pkg/httperror"
 	"murakali/pkg/response"
 	"net/http"
@@ -27,14 +28,14 @@ type UpdateProductInfoForQuery struct {
 	Description  string
 	Thumbnail    string
 	CategoryID   string
-	MinPrice     float64
-	MaxPrice     float64
+	MinPrice     model.Money
+	MaxPrice     model.Money
 	ListedStatus bool
 }
 
 type UpdateProductDetailRequest struct {
 	ProductDetailID string          `json:"product_detail_id"`
-	Price           float64         `json:"price"`
+	Price           model.Money     `json:"price"`
 	Stock           float64         `json:"stock"`
 	Weight          float64         `json:"weight"`
 	Size            float64         `json:"size"`
@@ -52,8 +53,8 @@ type UpdateVariant struct {
 }
 
 type RangePrice struct {
-	MinPrice float64
-	MaxPrice float64
+	MinPrice model.Money
+	MaxPrice model.Money
 }
 
 type UpdateProductListedStatusBulkRequest struct {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"murakali/config"
 	"murakali/internal/constant"
+	"murakali/internal/model"
 	"murakali/internal/module/product"
 	"murakali/internal/module/product/delivery/body"
 	"murakali/internal/util"
@@ -157,9 +158,58 @@ func (h *productHandlers) GetProducts(c *gin.Context) {
 		return
 	}
 
+	// the search is logged once, not for every page, and a failed log
+	// must not fail the search
+	if query.Keyword != "" && pgn.Page <= 1 {
+		if err := h.productUC.LogSearchQuery(c, query.Keyword, SearchProducts.TotalRows); err != nil {
+			h.logger.Errorf("HandlerProduct, Error: %s", err)
+		}
+	}
+
 	response.SuccessResponse(c.Writer, SearchProducts, http.StatusOK)
 }
 
+func (h *productHandlers) GetSearchSuggestions(c *gin.Context) {
+	suggestion, err := h.productUC.GetSearchSuggestions(c, c.Query("q"))
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerProduct, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, suggestion, http.StatusOK)
+}
+
+func (h *productHandlers) GetTrendingSearches(c *gin.Context) {
+	limit, err := strconv.Atoi(strings.TrimSpace(c.Query("limit")))
+	if err != nil || limit < 1 {
+		limit = constant.SearchTrendingLimitDefault
+	} else if limit > constant.SearchTrendingLimitMax {
+		limit = constant.SearchTrendingLimitMax
+	}
+
+	trending, err := h.productUC.GetTrendingSearches(c, limit)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerProduct, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, trending, http.StatusOK)
+}
+
 func (h *productHandlers) GetFavoriteProducts(c *gin.Context) {
 	pgn, query := h.ValidateQueryProduct(c)
 
@@ -430,8 +480,18 @@ func (h *productHandlers) ValidateQueryProduct(c *gin.Context) (*pagination.Pagi
 		pageFilter = 1
 	}
 
+	if sortBy == "" && search != "" {
+		sortBy = body.SortByRelevance
+	}
+
 	var pgn *pagination.Pagination
 	switch sortBy {
+	case body.SortByRelevance:
+		pgn = &pagination.Pagination{
+			Limit: limitFilter,
+			Page:  pageFilter,
+			Sort:  "relevance " + sortFilter + ", unit_sold DESC",
+		}
 	case "created_at":
 		pgn = &pagination.Pagination{
 			Limit: limitFilter,
@@ -512,10 +572,11 @@ func (h *productHandlers) ValidateQueryProduct(c *gin.Context) (*pagination.Pagi
 	}
 	query := &body.GetProductQueryRequest{
 		Search:       searchFilter,
+		Keyword:      search,
 		Shop:         shop,
 		Category:     categoryFilter,
-		MinPrice:     minPriceFilter,
-		MaxPrice:     maxPriceFilter,
+		MinPrice:     model.NewMoneyFromFloat(minPriceFilter),
+		MaxPrice:     model.NewMoneyFromFloat(maxPriceFilter),
 		MinRating:    minRatingFilter,
 		MaxRating:    maxRatingFilter,
 		Province:     provinceFilter,
@@ -525,18 +586,21 @@ func (h *productHandlers) ValidateQueryProduct(c *gin.Context) (*pagination.Pagi
 }
 
 func (h *productHandlers) UpdateProductMetadata(c *gin.Context) {
-	if err := h.productUC.UpdateProductMetadata(c); err != nil {
+	report, err := h.productUC.UpdateProductMetadata(c)
+	if err != nil {
 		var e *httperror.Error
 		if !errors.As(err, &e) {
 			h.logger.Errorf("HandlerProduct, Error: %s", err)
-			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			response.ErrorResponseData(c.Writer, report, response.InternalServerErrorMessage, http.StatusInternalServerError)
 			return
 		}
-		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+
+		response.ErrorResponseData(c.Writer, report, e.Err.Error(), e.Status)
 		return
 	}
 
-	response.SuccessResponse(c.Writer, nil, http.StatusOK)
+	h.logger.Infof("job %s affected %d rows in %dms", report.Job, report.RowsAffected, report.DurationMs)
+	response.SuccessResponse(c.Writer, report, http.StatusOK)
 }
 
 func (h *productHandlers) CreateProduct(c *gin.Context) {
@@ -774,10 +838,163 @@ func (h *productHandlers) DeleteProductReview(c *gin.Context) {
 	response.SuccessResponse(c.Writer, nil, http.StatusOK)
 }
 
+func (h *productHandlers) ReplyProductReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("review_id"))
+	if err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	var requestBody body.ReviewReplyRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	err = h.productUC.ReplyProductReview(c, reviewID.String(), userID.(string), requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerProduct, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, nil, http.StatusOK)
+}
+
+func (h *productHandlers) VoteProductReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("review_id"))
+	if err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	var requestBody body.ReviewVoteRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	err = h.productUC.VoteProductReview(c, reviewID.String(), userID.(string), requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerProduct, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, nil, http.StatusOK)
+}
+
+func (h *productHandlers) DeleteProductReviewVote(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("review_id"))
+	if err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	err = h.productUC.DeleteProductReviewVote(c, reviewID.String(), userID.(string))
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerProduct, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, nil, http.StatusOK)
+}
+
+func (h *productHandlers) ReportProductReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("review_id"))
+	if err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	var requestBody body.ReportReviewRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	err = h.productUC.ReportProductReview(c, reviewID.String(), userID.(string), requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerProduct, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, nil, http.StatusCreated)
+}
+
 func (h *productHandlers) ValidateQueryReview(c *gin.Context) (*pagination.Pagination, *body.GetReviewQueryRequest) {
 	limit := strings.TrimSpace(c.Query("limit"))
 	page := strings.TrimSpace(c.Query("page"))
 	sort := strings.TrimSpace(c.Query("sort"))
+	sortBy := strings.TrimSpace(c.Query("sort_by"))
 
 	var limitFilter int
 	var pageFilter int
@@ -800,10 +1017,15 @@ func (h *productHandlers) ValidateQueryReview(c *gin.Context) (*pagination.Pagin
 		sortFilter = "desc"
 	}
 
+	sortColumn := "r.created_at " + sortFilter
+	if sortBy == body.ReviewSortMostHelpful {
+		sortColumn = "r.helpful_count DESC, r.unhelpful_count ASC, " + sortColumn
+	}
+
 	pgn := &pagination.Pagination{
 		Limit: limitFilter,
 		Page:  pageFilter,
-		Sort:  "r.created_at " + sortFilter,
+		Sort:  sortColumn,
 	}
 
 	rating := strings.TrimSpace(c.Query("rating"))
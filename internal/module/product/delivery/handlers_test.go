@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"murakali/config"
+	"murakali/internal/constant"
 	"murakali/internal/model"
 	"murakali/internal/module/product/delivery/body"
 	"murakali/internal/module/product/mocks"
@@ -520,7 +521,7 @@ func TestProductHandlers_GetProducts(t *testing.T) {
 			body: nil,
 
 			mock: func(s *mocks.UseCase) {
-				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).Return(&pagination.Pagination{}, nil)
+				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).Return(&body.GetProductsResponse{}, nil)
 			},
 			expected:   http.StatusOK,
 			authorized: true,
@@ -540,7 +541,7 @@ func TestProductHandlers_GetProducts(t *testing.T) {
 				"province_ids":  "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).Return(&pagination.Pagination{}, nil)
+				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).Return(&body.GetProductsResponse{}, nil)
 			},
 			expected:   http.StatusOK,
 			authorized: true,
@@ -560,7 +561,7 @@ func TestProductHandlers_GetProducts(t *testing.T) {
 				"province_ids":  "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).Return(&pagination.Pagination{}, nil)
+				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).Return(&body.GetProductsResponse{}, nil)
 			},
 			expected:   http.StatusOK,
 			authorized: true,
@@ -580,7 +581,7 @@ func TestProductHandlers_GetProducts(t *testing.T) {
 				"province_ids":  "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).Return(&pagination.Pagination{}, nil)
+				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).Return(&body.GetProductsResponse{}, nil)
 			},
 			expected:   http.StatusOK,
 			authorized: true,
@@ -600,7 +601,7 @@ func TestProductHandlers_GetProducts(t *testing.T) {
 				"province_ids":  "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).Return(&pagination.Pagination{}, nil)
+				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).Return(&body.GetProductsResponse{}, nil)
 			},
 			expected:   http.StatusOK,
 			authorized: true,
@@ -620,7 +621,7 @@ func TestProductHandlers_GetProducts(t *testing.T) {
 				"province_ids":  "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).Return(&pagination.Pagination{}, nil)
+				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).Return(&body.GetProductsResponse{}, nil)
 			},
 			expected:   http.StatusOK,
 			authorized: true,
@@ -640,7 +641,7 @@ func TestProductHandlers_GetProducts(t *testing.T) {
 				"province_ids":  "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).Return(&pagination.Pagination{}, nil)
+				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).Return(&body.GetProductsResponse{}, nil)
 			},
 			expected:   http.StatusOK,
 			authorized: true,
@@ -663,6 +664,41 @@ func TestProductHandlers_GetProducts(t *testing.T) {
 			expected:   http.StatusBadRequest,
 			authorized: true,
 		},
+		{
+			name:    "success search is logged",
+			body:    nil,
+			queries: map[string]string{"search": "sepatu"},
+			mock: func(s *mocks.UseCase) {
+				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).
+					Return(&body.GetProductsResponse{Pagination: &pagination.Pagination{TotalRows: 3}}, nil)
+				s.On("LogSearchQuery", mock.Anything, "sepatu", int64(3)).Return(nil)
+			},
+			expected:   http.StatusOK,
+			authorized: true,
+		},
+		{
+			name:    "success search when logging fails",
+			body:    nil,
+			queries: map[string]string{"search": "sepatu"},
+			mock: func(s *mocks.UseCase) {
+				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).
+					Return(&body.GetProductsResponse{Pagination: &pagination.Pagination{TotalRows: 3}}, nil)
+				s.On("LogSearchQuery", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+			},
+			expected:   http.StatusOK,
+			authorized: true,
+		},
+		{
+			name:    "success search next page is not logged",
+			body:    nil,
+			queries: map[string]string{"search": "sepatu", "page": "2"},
+			mock: func(s *mocks.UseCase) {
+				s.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).
+					Return(&body.GetProductsResponse{Pagination: &pagination.Pagination{TotalRows: 30}}, nil)
+			},
+			expected:   http.StatusOK,
+			authorized: true,
+		},
 	}
 
 	for _, tc := range testCase {
@@ -706,6 +742,131 @@ func TestProductHandlers_GetProducts(t *testing.T) {
 	}
 }
 
+func TestProductHandlers_GetSearchSuggestions(t *testing.T) {
+	testCase := []struct {
+		name     string
+		queries  map[string]string
+		mock     func(s *mocks.UseCase)
+		expected int
+	}{
+		{
+			name:    "success",
+			queries: map[string]string{"q": "sep"},
+			mock: func(s *mocks.UseCase) {
+				s.On("GetSearchSuggestions", mock.Anything, "sep").Return(&body.SearchSuggestion{}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name:    "error",
+			queries: map[string]string{"q": "sep"},
+			mock: func(s *mocks.UseCase) {
+				s.On("GetSearchSuggestions", mock.Anything, mock.Anything).Return(nil, errors.New("test"))
+			},
+			expected: http.StatusInternalServerError,
+		},
+		{
+			name:    "error custom",
+			queries: map[string]string{"q": "sep"},
+			mock: func(s *mocks.UseCase) {
+				s.On("GetSearchSuggestions", mock.Anything, mock.Anything).Return(nil, httperror.New(http.StatusBadRequest, "test"))
+			},
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/product/suggest", nil)
+			r.Header = make(http.Header)
+			c.Request = r
+
+			u := url.Values{}
+			for key, value := range tc.queries {
+				u.Set(key, value)
+			}
+			c.Request.URL.RawQuery = u.Encode()
+
+			cfg := &config.Config{Logger: config.LoggerConfig{Development: true, Encoding: "json", Level: "info"}}
+			appLogger := logger.NewAPILogger(cfg)
+			appLogger.InitLogger()
+
+			s := mocks.NewUseCase(t)
+			h := NewProductHandlers(cfg, s, appLogger)
+
+			tc.mock(s)
+			h.GetSearchSuggestions(c)
+
+			assert.Equal(t, rr.Code, tc.expected)
+		})
+	}
+}
+
+func TestProductHandlers_GetTrendingSearches(t *testing.T) {
+	testCase := []struct {
+		name     string
+		queries  map[string]string
+		mock     func(s *mocks.UseCase)
+		expected int
+	}{
+		{
+			name: "success default limit",
+			mock: func(s *mocks.UseCase) {
+				s.On("GetTrendingSearches", mock.Anything, constant.SearchTrendingLimitDefault).Return([]*body.TrendingSearch{}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name:    "success limit capped",
+			queries: map[string]string{"limit": "1000"},
+			mock: func(s *mocks.UseCase) {
+				s.On("GetTrendingSearches", mock.Anything, constant.SearchTrendingLimitMax).Return([]*body.TrendingSearch{}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name:    "error",
+			queries: map[string]string{"limit": "5"},
+			mock: func(s *mocks.UseCase) {
+				s.On("GetTrendingSearches", mock.Anything, 5).Return(nil, errors.New("test"))
+			},
+			expected: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/product/search/trending", nil)
+			r.Header = make(http.Header)
+			c.Request = r
+
+			u := url.Values{}
+			for key, value := range tc.queries {
+				u.Set(key, value)
+			}
+			c.Request.URL.RawQuery = u.Encode()
+
+			cfg := &config.Config{Logger: config.LoggerConfig{Development: true, Encoding: "json", Level: "info"}}
+			appLogger := logger.NewAPILogger(cfg)
+			appLogger.InitLogger()
+
+			s := mocks.NewUseCase(t)
+			h := NewProductHandlers(cfg, s, appLogger)
+
+			tc.mock(s)
+			h.GetTrendingSearches(c)
+
+			assert.Equal(t, rr.Code, tc.expected)
+		})
+	}
+}
+
 func TestProductHandlers_GetFavoriteProducts(t *testing.T) {
 	testCase := []struct {
 		name       string
@@ -1357,7 +1518,7 @@ func TestCartHandlers_UpdateProductMetadata(t *testing.T) {
 				ProductID: "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("UpdateProductMetadata", mock.Anything).Return(nil)
+				s.On("UpdateProductMetadata", mock.Anything).Return(&model.JobReport{}, nil)
 			},
 			expected:   http.StatusOK,
 			authorized: true,
@@ -1368,7 +1529,7 @@ func TestCartHandlers_UpdateProductMetadata(t *testing.T) {
 				ProductID: "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("UpdateProductMetadata", mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
+				s.On("UpdateProductMetadata", mock.Anything).Return(&model.JobReport{}, httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected:   http.StatusBadRequest,
 			authorized: true,
@@ -1379,7 +1540,7 @@ func TestCartHandlers_UpdateProductMetadata(t *testing.T) {
 				ProductID: "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("UpdateProductMetadata", mock.Anything).Return(errors.New("test"))
+				s.On("UpdateProductMetadata", mock.Anything).Return(&model.JobReport{}, errors.New("test"))
 			},
 			expected:   http.StatusInternalServerError,
 			authorized: true,
@@ -1452,7 +1613,7 @@ func TestCartHandlers_CreateProduct(t *testing.T) {
 					ListedStatus: true,
 				},
 				ProductDetail: []body.CreateProductDetailRequest{{
-					Price:     temp,
+					Price:     model.NewMoneyFromFloat(temp),
 					Stock:     temp,
 					Weight:    temp,
 					Size:      temp,
@@ -1483,7 +1644,7 @@ func TestCartHandlers_CreateProduct(t *testing.T) {
 					ListedStatus: true,
 				},
 				ProductDetail: []body.CreateProductDetailRequest{{
-					Price:     temp,
+					Price:     model.NewMoneyFromFloat(temp),
 					Stock:     temp,
 					Weight:    temp,
 					Size:      temp,
@@ -1530,7 +1691,7 @@ func TestCartHandlers_CreateProduct(t *testing.T) {
 					ListedStatus: true,
 				},
 				ProductDetail: []body.CreateProductDetailRequest{{
-					Price:     temp,
+					Price:     model.NewMoneyFromFloat(temp),
 					Stock:     temp,
 					Weight:    temp,
 					Size:      temp,
@@ -1807,7 +1968,7 @@ func TestCartHandlers_UpdateProduct(t *testing.T) {
 				},
 				ProductDetail: []body.UpdateProductDetailRequest{{
 					ProductDetailID: "989d94b7-58fc-4a76-ae01-1c1b47a0755c",
-					Price:           temp,
+					Price:           model.NewMoneyFromFloat(temp),
 					Stock:           temp,
 					Weight:          temp,
 					Size:            temp,
@@ -1840,7 +2001,7 @@ func TestCartHandlers_UpdateProduct(t *testing.T) {
 				},
 				ProductDetail: []body.UpdateProductDetailRequest{{
 					ProductDetailID: "989d94b7-58fc-4a76-ae01-1c1b47a0755c",
-					Price:           temp,
+					Price:           model.NewMoneyFromFloat(temp),
 					Stock:           temp,
 					Weight:          temp,
 					Size:            temp,
@@ -1891,7 +2052,7 @@ func TestCartHandlers_UpdateProduct(t *testing.T) {
 				},
 				ProductDetail: []body.UpdateProductDetailRequest{{
 					ProductDetailID: "989d94b7-58fc-4a76-ae01-1c1b47a0755c",
-					Price:           temp,
+					Price:           model.NewMoneyFromFloat(temp),
 					Stock:           temp,
 					Weight:          temp,
 					Size:            temp,
@@ -2085,10 +2246,11 @@ func TestCartHandlers_CreateProductReview(t *testing.T) {
 		{
 			name: "success create review product",
 			body: body.ReviewProductRequest{
-				ProductID: "123456",
-				Comment:   &temp,
-				Rating:    1,
-				PhotoURL:  &temp,
+				ProductID:   "123456",
+				OrderItemID: "989d94b7-58fc-4a76-ae01-1c1b47a0755c",
+				Comment:     &temp,
+				Rating:      1,
+				PhotoURL:    &temp,
 			},
 			mock: func(s *mocks.UseCase) {
 				s.On("CreateProductReview", mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -2100,10 +2262,11 @@ func TestCartHandlers_CreateProductReview(t *testing.T) {
 		{
 			name: "create review  error custom",
 			body: body.ReviewProductRequest{
-				ProductID: "123456",
-				Comment:   &temp,
-				Rating:    1,
-				PhotoURL:  &temp,
+				ProductID:   "123456",
+				OrderItemID: "989d94b7-58fc-4a76-ae01-1c1b47a0755c",
+				Comment:     &temp,
+				Rating:      1,
+				PhotoURL:    &temp,
 			},
 			mock: func(s *mocks.UseCase) {
 				s.On("CreateProductReview", mock.Anything, mock.Anything, mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
@@ -2114,10 +2277,11 @@ func TestCartHandlers_CreateProductReview(t *testing.T) {
 		{
 			name: "success create unauthorized",
 			body: body.ReviewProductRequest{
-				ProductID: "123456",
-				Comment:   &temp,
-				Rating:    1,
-				PhotoURL:  &temp,
+				ProductID:   "123456",
+				OrderItemID: "989d94b7-58fc-4a76-ae01-1c1b47a0755c",
+				Comment:     &temp,
+				Rating:      1,
+				PhotoURL:    &temp,
 			},
 			mock:       func(s *mocks.UseCase) {},
 			expected:   http.StatusUnauthorized,
@@ -2140,10 +2304,11 @@ func TestCartHandlers_CreateProductReview(t *testing.T) {
 		{
 			name: "add create review product error internal",
 			body: body.ReviewProductRequest{
-				ProductID: "123456",
-				Comment:   &temp,
-				Rating:    1,
-				PhotoURL:  &temp,
+				ProductID:   "123456",
+				OrderItemID: "989d94b7-58fc-4a76-ae01-1c1b47a0755c",
+				Comment:     &temp,
+				Rating:      1,
+				PhotoURL:    &temp,
 			},
 			mock: func(s *mocks.UseCase) {
 				s.On("CreateProductReview", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
@@ -2381,3 +2546,295 @@ func TestProductHandlers_GetTotalReviewRatingByProductID(t *testing.T) {
 		})
 	}
 }
+
+func TestProductHandlers_ReplyProductReview(t *testing.T) {
+	testCase := []struct {
+		name       string
+		body       interface{}
+		mock       func(s *mocks.UseCase)
+		expected   int
+		authorized bool
+	}{
+		{
+			name: "success reply review",
+			body: body.ReviewReplyRequest{Comment: "thanks"},
+			mock: func(s *mocks.UseCase) {
+				s.On("ReplyProductReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			},
+			expected:   http.StatusOK,
+			authorized: true,
+		},
+		{
+			name: "reply review error custom",
+			body: body.ReviewReplyRequest{Comment: "thanks"},
+			mock: func(s *mocks.UseCase) {
+				s.On("ReplyProductReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
+			},
+			expected:   http.StatusBadRequest,
+			authorized: true,
+		},
+		{
+			name:       "reply review unauthorized",
+			body:       body.ReviewReplyRequest{Comment: "thanks"},
+			mock:       func(s *mocks.UseCase) {},
+			expected:   http.StatusUnauthorized,
+			authorized: false,
+		},
+		{
+			name:       "invalid request",
+			body:       body.ReviewReplyRequest{Comment: "   "},
+			mock:       func(s *mocks.UseCase) {},
+			expected:   http.StatusUnprocessableEntity,
+			authorized: true,
+		},
+		{
+			name: "reply review error internal",
+			body: body.ReviewReplyRequest{Comment: "thanks"},
+			mock: func(s *mocks.UseCase) {
+				s.On("ReplyProductReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+			},
+			expected:   http.StatusInternalServerError,
+			authorized: true,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+
+			r := httptest.NewRequest(http.MethodPut, "/api/v1/product/review/:review_id/reply", nil)
+			r.Header = make(http.Header)
+
+			c.Params = []gin.Param{
+				{
+					Key:   "review_id",
+					Value: "989d94b7-58fc-4a76-ae01-1c1b47a0755c",
+				},
+			}
+			c.Request = r
+			if tc.authorized {
+				c.Set("userID", "123456")
+			}
+
+			MockJsonPUT(c, tc.body)
+
+			s := mocks.NewUseCase(t)
+
+			cfg := &config.Config{
+				Logger: config.LoggerConfig{
+					Development:       true,
+					DisableCaller:     false,
+					DisableStacktrace: false,
+					Encoding:          "json",
+					Level:             "info",
+				},
+			}
+
+			appLogger := logger.NewAPILogger(cfg)
+			appLogger.InitLogger()
+
+			h := NewProductHandlers(cfg, s, appLogger)
+
+			tc.mock(s)
+			h.ReplyProductReview(c)
+
+			assert.Equal(t, rr.Code, tc.expected)
+		})
+	}
+}
+
+func TestProductHandlers_VoteProductReview(t *testing.T) {
+	helpful := true
+	testCase := []struct {
+		name       string
+		body       interface{}
+		mock       func(s *mocks.UseCase)
+		expected   int
+		authorized bool
+	}{
+		{
+			name: "success vote review",
+			body: body.ReviewVoteRequest{IsHelpful: &helpful},
+			mock: func(s *mocks.UseCase) {
+				s.On("VoteProductReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			},
+			expected:   http.StatusOK,
+			authorized: true,
+		},
+		{
+			name: "vote review error custom",
+			body: body.ReviewVoteRequest{IsHelpful: &helpful},
+			mock: func(s *mocks.UseCase) {
+				s.On("VoteProductReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
+			},
+			expected:   http.StatusBadRequest,
+			authorized: true,
+		},
+		{
+			name:       "vote review unauthorized",
+			body:       body.ReviewVoteRequest{IsHelpful: &helpful},
+			mock:       func(s *mocks.UseCase) {},
+			expected:   http.StatusUnauthorized,
+			authorized: false,
+		},
+		{
+			name:       "invalid request",
+			body:       body.ReviewVoteRequest{},
+			mock:       func(s *mocks.UseCase) {},
+			expected:   http.StatusUnprocessableEntity,
+			authorized: true,
+		},
+		{
+			name: "vote review error internal",
+			body: body.ReviewVoteRequest{IsHelpful: &helpful},
+			mock: func(s *mocks.UseCase) {
+				s.On("VoteProductReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+			},
+			expected:   http.StatusInternalServerError,
+			authorized: true,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+
+			r := httptest.NewRequest(http.MethodPut, "/api/v1/product/review/:review_id/vote", nil)
+			r.Header = make(http.Header)
+
+			c.Params = []gin.Param{
+				{
+					Key:   "review_id",
+					Value: "989d94b7-58fc-4a76-ae01-1c1b47a0755c",
+				},
+			}
+			c.Request = r
+			if tc.authorized {
+				c.Set("userID", "123456")
+			}
+
+			MockJsonPUT(c, tc.body)
+
+			s := mocks.NewUseCase(t)
+
+			cfg := &config.Config{
+				Logger: config.LoggerConfig{
+					Development:       true,
+					DisableCaller:     false,
+					DisableStacktrace: false,
+					Encoding:          "json",
+					Level:             "info",
+				},
+			}
+
+			appLogger := logger.NewAPILogger(cfg)
+			appLogger.InitLogger()
+
+			h := NewProductHandlers(cfg, s, appLogger)
+
+			tc.mock(s)
+			h.VoteProductReview(c)
+
+			assert.Equal(t, rr.Code, tc.expected)
+		})
+	}
+}
+
+func TestProductHandlers_ReportProductReview(t *testing.T) {
+	testCase := []struct {
+		name       string
+		body       interface{}
+		mock       func(s *mocks.UseCase)
+		expected   int
+		authorized bool
+	}{
+		{
+			name: "success report review",
+			body: body.ReportReviewRequest{Reason: "spam"},
+			mock: func(s *mocks.UseCase) {
+				s.On("ReportProductReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			},
+			expected:   http.StatusCreated,
+			authorized: true,
+		},
+		{
+			name: "report review error custom",
+			body: body.ReportReviewRequest{Reason: "spam"},
+			mock: func(s *mocks.UseCase) {
+				s.On("ReportProductReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
+			},
+			expected:   http.StatusBadRequest,
+			authorized: true,
+		},
+		{
+			name:       "report review unauthorized",
+			body:       body.ReportReviewRequest{Reason: "spam"},
+			mock:       func(s *mocks.UseCase) {},
+			expected:   http.StatusUnauthorized,
+			authorized: false,
+		},
+		{
+			name:       "invalid request",
+			body:       body.ReportReviewRequest{},
+			mock:       func(s *mocks.UseCase) {},
+			expected:   http.StatusUnprocessableEntity,
+			authorized: true,
+		},
+		{
+			name: "report review error internal",
+			body: body.ReportReviewRequest{Reason: "spam"},
+			mock: func(s *mocks.UseCase) {
+				s.On("ReportProductReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+			},
+			expected:   http.StatusInternalServerError,
+			authorized: true,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/product/review/:review_id/report", nil)
+			r.Header = make(http.Header)
+
+			c.Params = []gin.Param{
+				{
+					Key:   "review_id",
+					Value: "989d94b7-58fc-4a76-ae01-1c1b47a0755c",
+				},
+			}
+			c.Request = r
+			if tc.authorized {
+				c.Set("userID", "123456")
+			}
+
+			MockJsonPost(c, tc.body)
+
+			s := mocks.NewUseCase(t)
+
+			cfg := &config.Config{
+				Logger: config.LoggerConfig{
+					Development:       true,
+					DisableCaller:     false,
+					DisableStacktrace: false,
+					Encoding:          "json",
+					Level:             "info",
+				},
+			}
+
+			appLogger := logger.NewAPILogger(cfg)
+			appLogger.InitLogger()
+
+			h := NewProductHandlers(cfg, s, appLogger)
+
+			tc.mock(s)
+			h.ReportProductReview(c)
+
+			assert.Equal(t, rr.Code, tc.expected)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package delivery
 
 import (
+	"murakali/internal/constant"
 	"murakali/internal/middleware"
 	"murakali/internal/module/product"
 
@@ -14,13 +15,15 @@ func MapProductRoutes(productGroup *gin.RouterGroup, h product.Handlers, mw *mid
 	productGroup.GET("/category/:name_lvl_one/:name_lvl_two", h.GetCategoriesByNameLevelTwo)
 	productGroup.GET("/category/:name_lvl_one/:name_lvl_two/:name_lvl_three", h.GetCategoriesByNameLevelThree)
 	productGroup.GET("/recommended", h.GetRecommendedProducts)
+	productGroup.GET("/suggest", h.GetSearchSuggestions)
+	productGroup.GET("/search/trending", h.GetTrendingSearches)
 	productGroup.GET("/:product_id", h.GetProductDetail)
 	productGroup.GET("/:product_id/picture", h.GetAllProductImage)
 	productGroup.GET("/:product_id/review", h.GetProductReviews)
 	productGroup.GET("/:product_id/review/rating", h.GetTotalReviewRatingByProductID)
 	productGroup.GET("/", h.GetProducts)
 	productGroup.POST("/favorite/count", h.CountSpecificFavoriteProduct)
-	productGroup.POST("/metadata", h.UpdateProductMetadata)
+	productGroup.POST("/metadata", mw.JobAuthMiddleware(), h.UpdateProductMetadata)
 
 	productGroup.Use(mw.AuthJWTMiddleware())
 	productGroup.GET("/favorite", h.GetFavoriteProducts)
@@ -29,10 +32,14 @@ func MapProductRoutes(productGroup *gin.RouterGroup, h product.Handlers, mw *mid
 	productGroup.POST("/favorite", h.CreateFavoriteProduct)
 	productGroup.DELETE("/favorite", h.DeleteFavoriteProduct)
 	productGroup.DELETE("/review/:review_id", h.DeleteProductReview)
+	productGroup.PUT("/review/:review_id/vote", h.VoteProductReview)
+	productGroup.DELETE("/review/:review_id/vote", h.DeleteProductReviewVote)
+	productGroup.POST("/review/:review_id/report", h.ReportProductReview)
 	productGroup.POST("/:product_id/review", h.CreateProductReview)
-	productGroup.Use(mw.SellerJWTMiddleware())
+	productGroup.Use(mw.RequirePermission(constant.PermissionProductManage))
 	productGroup.POST("/", h.CreateProduct)
 	productGroup.PUT("/status/:id", h.UpdateListedStatus)
 	productGroup.PATCH("/bulk-status", h.UpdateListedStatusBulk)
 	productGroup.PUT("/:id", h.UpdateProduct)
+	productGroup.PUT("/review/:review_id/reply", h.ReplyProductReview)
 }
@@ -148,6 +148,41 @@ func (_m *Repository) CreateProductReview(ctx context.Context, tx postgre.Transa
 	return r0
 }
 
+// CreateReviewReport provides a mock function with given fields: ctx, reviewID, userID, reason
+func (_m *Repository) CreateReviewReport(ctx context.Context, reviewID string, userID string, reason string) (int64, error) {
+	ret := _m.Called(ctx, reviewID, userID, reason)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) int64); ok {
+		r0 = rf(ctx, reviewID, userID, reason)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, reviewID, userID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateSearchQuery provides a mock function with given fields: ctx, keyword, resultCount
+func (_m *Repository) CreateSearchQuery(ctx context.Context, keyword string, resultCount int64) error {
+	ret := _m.Called(ctx, keyword, resultCount)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, keyword, resultCount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateVariant provides a mock function with given fields: ctx, tx, productDetailID, variantDetailID
 func (_m *Repository) CreateVariant(ctx context.Context, tx postgre.Transaction, productDetailID string, variantDetailID string) error {
 	ret := _m.Called(ctx, tx, productDetailID, variantDetailID)
@@ -239,6 +274,27 @@ func (_m *Repository) DeleteReview(ctx context.Context, tx postgre.Transaction,
 	return r0
 }
 
+// DeleteReviewVote provides a mock function with given fields: ctx, tx, reviewID, userID
+func (_m *Repository) DeleteReviewVote(ctx context.Context, tx postgre.Transaction, reviewID string, userID string) (int64, error) {
+	ret := _m.Called(ctx, tx, reviewID, userID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string, string) int64); ok {
+		r0 = rf(ctx, tx, reviewID, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string, string) error); ok {
+		r1 = rf(ctx, tx, reviewID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteVariant provides a mock function with given fields: ctx, tx, productID
 func (_m *Repository) DeleteVariant(ctx context.Context, tx postgre.Transaction, productID string) error {
 	ret := _m.Called(ctx, tx, productID)
@@ -562,6 +618,29 @@ func (_m *Repository) GetMaxMinPriceByID(ctx context.Context, productID string)
 	return r0, r1
 }
 
+// GetOrderItemForReview provides a mock function with given fields: ctx, tx, orderItemID
+func (_m *Repository) GetOrderItemForReview(ctx context.Context, tx postgre.Transaction, orderItemID string) (*body.ReviewOrderItem, error) {
+	ret := _m.Called(ctx, tx, orderItemID)
+
+	var r0 *body.ReviewOrderItem
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) *body.ReviewOrderItem); ok {
+		r0 = rf(ctx, tx, orderItemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*body.ReviewOrderItem)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string) error); ok {
+		r1 = rf(ctx, tx, orderItemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProductDetail provides a mock function with given fields: ctx, productID, promo
 func (_m *Repository) GetProductDetail(ctx context.Context, productID string, promo *body.PromotionInfo) ([]*body.ProductDetail, error) {
 	ret := _m.Called(ctx, productID, promo)
@@ -585,6 +664,52 @@ func (_m *Repository) GetProductDetail(ctx context.Context, productID string, pr
 	return r0, r1
 }
 
+// GetProductFacets provides a mock function with given fields: ctx, query
+func (_m *Repository) GetProductFacets(ctx context.Context, query *body.GetProductQueryRequest) (*body.ProductFacets, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 *body.ProductFacets
+	if rf, ok := ret.Get(0).(func(context.Context, *body.GetProductQueryRequest) *body.ProductFacets); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*body.ProductFacets)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *body.GetProductQueryRequest) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProductFacetsRedis provides a mock function with given fields: ctx, key
+func (_m *Repository) GetProductFacetsRedis(ctx context.Context, key string) (*body.ProductFacets, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *body.ProductFacets
+	if rf, ok := ret.Get(0).(func(context.Context, string) *body.ProductFacets); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*body.ProductFacets)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProductInfo provides a mock function with given fields: ctx, productID
 func (_m *Repository) GetProductInfo(ctx context.Context, productID string) (*body.ProductInfo, error) {
 	ret := _m.Called(ctx, productID)
@@ -759,6 +884,94 @@ func (_m *Repository) GetRecommendedProducts(ctx context.Context, pgn *paginatio
 	return r0, r1, r2, r3
 }
 
+// GetReviewShopID provides a mock function with given fields: ctx, reviewID
+func (_m *Repository) GetReviewShopID(ctx context.Context, reviewID string) (string, error) {
+	ret := _m.Called(ctx, reviewID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, reviewID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reviewID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSearchAutocomplete provides a mock function with given fields: ctx, keyword, limit
+func (_m *Repository) GetSearchAutocomplete(ctx context.Context, keyword string, limit int) (*body.SearchSuggestion, error) {
+	ret := _m.Called(ctx, keyword, limit)
+
+	var r0 *body.SearchSuggestion
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *body.SearchSuggestion); ok {
+		r0 = rf(ctx, keyword, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*body.SearchSuggestion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, keyword, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSearchSuggestion provides a mock function with given fields: ctx, keyword
+func (_m *Repository) GetSearchSuggestion(ctx context.Context, keyword string) (string, error) {
+	ret := _m.Called(ctx, keyword)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, keyword)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, keyword)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSearchTrendingRedis provides a mock function with given fields: ctx, key, limit
+func (_m *Repository) GetSearchTrendingRedis(ctx context.Context, key string, limit int) ([]*body.TrendingSearch, error) {
+	ret := _m.Called(ctx, key, limit)
+
+	var r0 []*body.TrendingSearch
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*body.TrendingSearch); ok {
+		r0 = rf(ctx, key, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*body.TrendingSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, key, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetShopIDByUserID provides a mock function with given fields: ctx, userID
 func (_m *Repository) GetShopIDByUserID(ctx context.Context, userID string) (string, error) {
 	ret := _m.Called(ctx, userID)
@@ -868,6 +1081,48 @@ func (_m *Repository) GetTotalReviewRatingByProductID(ctx context.Context, produ
 	return r0, r1
 }
 
+// IncrSearchTrendingRedis provides a mock function with given fields: ctx, key, keyword
+func (_m *Repository) IncrSearchTrendingRedis(ctx context.Context, key string, keyword string) error {
+	ret := _m.Called(ctx, key, keyword)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, key, keyword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertProductFacetsRedis provides a mock function with given fields: ctx, key, value
+func (_m *Repository) InsertProductFacetsRedis(ctx context.Context, key string, value *body.ProductFacets) error {
+	ret := _m.Called(ctx, key, value)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *body.ProductFacets) error); ok {
+		r0 = rf(ctx, key, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshSearchWords provides a mock function with given fields: ctx
+func (_m *Repository) RefreshSearchWords(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateListedStatus provides a mock function with given fields: ctx, tx, listedStatus, productID
 func (_m *Repository) UpdateListedStatus(ctx context.Context, tx postgre.Transaction, listedStatus bool, productID string) error {
 	ret := _m.Called(ctx, tx, listedStatus, productID)
@@ -882,6 +1137,20 @@ func (_m *Repository) UpdateListedStatus(ctx context.Context, tx postgre.Transac
 	return r0
 }
 
+// UpdateOrderItemIsReview provides a mock function with given fields: ctx, tx, orderItemID, isReview
+func (_m *Repository) UpdateOrderItemIsReview(ctx context.Context, tx postgre.Transaction, orderItemID string, isReview bool) error {
+	ret := _m.Called(ctx, tx, orderItemID, isReview)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string, bool) error); ok {
+		r0 = rf(ctx, tx, orderItemID, isReview)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateProduct provides a mock function with given fields: ctx, tx, requestBody, productID
 func (_m *Repository) UpdateProduct(ctx context.Context, tx postgre.Transaction, requestBody body.UpdateProductInfoForQuery, productID string) error {
 	ret := _m.Called(ctx, tx, requestBody, productID)
@@ -938,6 +1207,20 @@ func (_m *Repository) UpdateProductRating(ctx context.Context, productID string,
 	return r0
 }
 
+// UpdateReviewVoteCount provides a mock function with given fields: ctx, tx, reviewID
+func (_m *Repository) UpdateReviewVoteCount(ctx context.Context, tx postgre.Transaction, reviewID string) error {
+	ret := _m.Called(ctx, tx, reviewID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) error); ok {
+		r0 = rf(ctx, tx, reviewID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateShopProductRating provides a mock function with given fields: ctx, shop
 func (_m *Repository) UpdateShopProductRating(ctx context.Context, shop *model.ShopProductRating) error {
 	ret := _m.Called(ctx, shop)
@@ -966,6 +1249,34 @@ func (_m *Repository) UpdateVariant(ctx context.Context, tx postgre.Transaction,
 	return r0
 }
 
+// UpsertReviewReply provides a mock function with given fields: ctx, reviewID, shopID, comment
+func (_m *Repository) UpsertReviewReply(ctx context.Context, reviewID string, shopID string, comment string) error {
+	ret := _m.Called(ctx, reviewID, shopID, comment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, reviewID, shopID, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertReviewVote provides a mock function with given fields: ctx, tx, reviewID, userID, isHelpful
+func (_m *Repository) UpsertReviewVote(ctx context.Context, tx postgre.Transaction, reviewID string, userID string, isHelpful bool) error {
+	ret := _m.Called(ctx, tx, reviewID, userID, isHelpful)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string, string, bool) error); ok {
+		r0 = rf(ctx, tx, reviewID, userID, isHelpful)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewRepository interface {
 	mock.TestingT
 	Cleanup(func())
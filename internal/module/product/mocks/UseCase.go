@@ -123,6 +123,20 @@ func (_m *UseCase) DeleteProductReview(ctx context.Context, reviewID string, use
 	return r0
 }
 
+// DeleteProductReviewVote provides a mock function with given fields: ctx, reviewID, userID
+func (_m *UseCase) DeleteProductReviewVote(ctx context.Context, reviewID string, userID string) error {
+	ret := _m.Called(ctx, reviewID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, reviewID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetAllProductImage provides a mock function with given fields: ctx, productID
 func (_m *UseCase) GetAllProductImage(ctx context.Context, productID string) ([]*body.GetImageResponse, error) {
 	ret := _m.Called(ctx, productID)
@@ -285,15 +299,15 @@ func (_m *UseCase) GetProductReviews(ctx context.Context, pgn *pagination.Pagina
 }
 
 // GetProducts provides a mock function with given fields: ctx, pgn, query
-func (_m *UseCase) GetProducts(ctx context.Context, pgn *pagination.Pagination, query *body.GetProductQueryRequest) (*pagination.Pagination, error) {
+func (_m *UseCase) GetProducts(ctx context.Context, pgn *pagination.Pagination, query *body.GetProductQueryRequest) (*body.GetProductsResponse, error) {
 	ret := _m.Called(ctx, pgn, query)
 
-	var r0 *pagination.Pagination
-	if rf, ok := ret.Get(0).(func(context.Context, *pagination.Pagination, *body.GetProductQueryRequest) *pagination.Pagination); ok {
+	var r0 *body.GetProductsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *pagination.Pagination, *body.GetProductQueryRequest) *body.GetProductsResponse); ok {
 		r0 = rf(ctx, pgn, query)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*pagination.Pagination)
+			r0 = ret.Get(0).(*body.GetProductsResponse)
 		}
 	}
 
@@ -330,6 +344,29 @@ func (_m *UseCase) GetRecommendedProducts(ctx context.Context, pgn *pagination.P
 	return r0, r1
 }
 
+// GetSearchSuggestions provides a mock function with given fields: ctx, keyword
+func (_m *UseCase) GetSearchSuggestions(ctx context.Context, keyword string) (*body.SearchSuggestion, error) {
+	ret := _m.Called(ctx, keyword)
+
+	var r0 *body.SearchSuggestion
+	if rf, ok := ret.Get(0).(func(context.Context, string) *body.SearchSuggestion); ok {
+		r0 = rf(ctx, keyword)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*body.SearchSuggestion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, keyword)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTotalReviewRatingByProductID provides a mock function with given fields: ctx, productID
 func (_m *UseCase) GetTotalReviewRatingByProductID(ctx context.Context, productID string) (*body.AllRatingProduct, error) {
 	ret := _m.Called(ctx, productID)
@@ -353,6 +390,71 @@ func (_m *UseCase) GetTotalReviewRatingByProductID(ctx context.Context, productI
 	return r0, r1
 }
 
+// GetTrendingSearches provides a mock function with given fields: ctx, limit
+func (_m *UseCase) GetTrendingSearches(ctx context.Context, limit int) ([]*body.TrendingSearch, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []*body.TrendingSearch
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*body.TrendingSearch); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*body.TrendingSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LogSearchQuery provides a mock function with given fields: ctx, keyword, resultCount
+func (_m *UseCase) LogSearchQuery(ctx context.Context, keyword string, resultCount int64) error {
+	ret := _m.Called(ctx, keyword, resultCount)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, keyword, resultCount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplyProductReview provides a mock function with given fields: ctx, reviewID, userID, reqBody
+func (_m *UseCase) ReplyProductReview(ctx context.Context, reviewID string, userID string, reqBody body.ReviewReplyRequest) error {
+	ret := _m.Called(ctx, reviewID, userID, reqBody)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, body.ReviewReplyRequest) error); ok {
+		r0 = rf(ctx, reviewID, userID, reqBody)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReportProductReview provides a mock function with given fields: ctx, reviewID, userID, reqBody
+func (_m *UseCase) ReportProductReview(ctx context.Context, reviewID string, userID string, reqBody body.ReportReviewRequest) error {
+	ret := _m.Called(ctx, reviewID, userID, reqBody)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, body.ReportReviewRequest) error); ok {
+		r0 = rf(ctx, reviewID, userID, reqBody)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateListedStatus provides a mock function with given fields: ctx, productID
 func (_m *UseCase) UpdateListedStatus(ctx context.Context, productID string) error {
 	ret := _m.Called(ctx, productID)
@@ -396,12 +498,35 @@ func (_m *UseCase) UpdateProductListedStatusBulk(ctx context.Context, _a1 body.U
 }
 
 // UpdateProductMetadata provides a mock function with given fields: ctx
-func (_m *UseCase) UpdateProductMetadata(ctx context.Context) error {
+func (_m *UseCase) UpdateProductMetadata(ctx context.Context) (*model.JobReport, error) {
 	ret := _m.Called(ctx)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+	var r0 *model.JobReport
+	if rf, ok := ret.Get(0).(func(context.Context) *model.JobReport); ok {
 		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.JobReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VoteProductReview provides a mock function with given fields: ctx, reviewID, userID, reqBody
+func (_m *UseCase) VoteProductReview(ctx context.Context, reviewID string, userID string, reqBody body.ReviewVoteRequest) error {
+	ret := _m.Called(ctx, reviewID, userID, reqBody)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, body.ReviewVoteRequest) error); ok {
+		r0 = rf(ctx, reviewID, userID, reqBody)
 	} else {
 		r0 = ret.Error(0)
 	}
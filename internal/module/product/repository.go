@@ -24,6 +24,15 @@ type Repository interface {
 	GetProducts(ctx context.Context, pgn *pagination.Pagination, query *body.GetProductQueryRequest) ([]*body.Products,
 		[]*model.Promotion, []*model.Voucher, error)
 	GetAllTotalProduct(ctx context.Context, query *body.GetProductQueryRequest) (int64, error)
+	GetSearchSuggestion(ctx context.Context, keyword string) (string, error)
+	RefreshSearchWords(ctx context.Context) error
+	GetProductFacets(ctx context.Context, query *body.GetProductQueryRequest) (*body.ProductFacets, error)
+	GetProductFacetsRedis(ctx context.Context, key string) (*body.ProductFacets, error)
+	InsertProductFacetsRedis(ctx context.Context, key string, value *body.ProductFacets) error
+	GetSearchAutocomplete(ctx context.Context, keyword string, limit int) (*body.SearchSuggestion, error)
+	CreateSearchQuery(ctx context.Context, keyword string, resultCount int64) error
+	IncrSearchTrendingRedis(ctx context.Context, key, keyword string) error
+	GetSearchTrendingRedis(ctx context.Context, key string, limit int) ([]*body.TrendingSearch, error)
 	GetFavoriteProducts(ctx context.Context, pgn *pagination.Pagination, query *body.GetProductQueryRequest, userID string) ([]*body.Products,
 		[]*model.Promotion, []*model.Voucher, error)
 	GetAllFavoriteTotalProduct(ctx context.Context, query *body.GetProductQueryRequest, userID string) (int64, error)
@@ -38,8 +47,16 @@ type Repository interface {
 	GetTotalReviewRatingByProductID(ctx context.Context, productID string) ([]*body.RatingProduct, error)
 	FindReview(ctx context.Context, reviewID string) (*body.ReviewProduct, error)
 	CreateProductReview(ctx context.Context, tx postgre.Transaction, userID string, reqBody body.ReviewProductRequest) error
+	GetOrderItemForReview(ctx context.Context, tx postgre.Transaction, orderItemID string) (*body.ReviewOrderItem, error)
+	UpdateOrderItemIsReview(ctx context.Context, tx postgre.Transaction, orderItemID string, isReview bool) error
 	DeleteReview(ctx context.Context, tx postgre.Transaction, reviewID string) error
 	GetShopIDByUserID(ctx context.Context, userID string) (string, error)
+	GetReviewShopID(ctx context.Context, reviewID string) (string, error)
+	UpsertReviewReply(ctx context.Context, reviewID, shopID, comment string) error
+	UpsertReviewVote(ctx context.Context, tx postgre.Transaction, reviewID, userID string, isHelpful bool) error
+	DeleteReviewVote(ctx context.Context, tx postgre.Transaction, reviewID, userID string) (int64, error)
+	UpdateReviewVoteCount(ctx context.Context, tx postgre.Transaction, reviewID string) error
+	CreateReviewReport(ctx context.Context, reviewID, userID, reason string) (int64, error)
 
 	CreateProduct(ctx context.Context, tx postgre.Transaction, requestBody body.CreateProductInfoForQuery) (string, error)
 	CreateProductDetail(ctx context.Context, tx postgre.Transaction, requestBody body.CreateProductDetailRequest, ProductID string) (string, error)
@@ -60,6 +60,112 @@ const (
 	FROM "promotion" as "promo"
 	WHERE "promo"."product_id" = $1 AND (now() BETWEEN "promo"."actived_date" AND "promo"."expired_date")`
 
+	// likeKeyword is $1 with the LIKE wildcards escaped, so a search for "%"
+	// or "_" matches those characters instead of every name.
+	likeKeyword = `replace(replace(replace($1, '\', '\\'), '%', '\%'), '_', '\_')`
+
+	// WhereProductSearch matches $1 against the search vector, and against
+	// the title by substring or trigram similarity so typos still match.
+	WhereProductSearch = `($1 = ''
+		OR "p"."search_vector" @@ websearch_to_tsquery('simple', $1)
+		OR "p"."title" ILIKE ('%' || ` + likeKeyword + ` || '%')
+		OR "p"."title" % $1)`
+
+	// SelectProductSearch wraps the matched words of the title and the
+	// description between chr(2) and chr(3), see body.HighlightSearch.
+	SelectProductSearch = `CASE WHEN $1 = '' THEN 0
+			ELSE ts_rank_cd("p"."search_vector", websearch_to_tsquery('simple', $1)) + similarity("p"."title", $1)
+		END as "relevance",
+		CASE WHEN $1 = '' THEN ''
+			ELSE ts_headline('simple', "p"."title", websearch_to_tsquery('simple', $1),
+				'HighlightAll=true, StartSel=' || chr(2) || ', StopSel=' || chr(3))
+		END as "title_highlight",
+		CASE WHEN $1 = '' THEN ''
+			ELSE ts_headline('simple', COALESCE("p"."description", ''), websearch_to_tsquery('simple', $1),
+				'MaxFragments=2, MaxWords=20, MinWords=5, StartSel=' || chr(2) || ', StopSel=' || chr(3))
+		END as "snippet"`
+
+	GetSearchSuggestionQuery = `
+	SELECT COALESCE(string_agg(COALESCE("s"."word", "q"."word"), ' ' ORDER BY "q"."n"), '')
+	FROM regexp_split_to_table(lower($1), '\s+') WITH ORDINALITY as "q"("word", "n")
+	LEFT JOIN LATERAL (
+		SELECT "w"."word" FROM "product_search_word" as "w"
+		WHERE "w"."word" % "q"."word"
+		ORDER BY similarity("w"."word", "q"."word") DESC, "w"."ndoc" DESC
+		LIMIT 1
+	) as "s" ON true`
+
+	RefreshSearchWordsQuery = `REFRESH MATERIALIZED VIEW CONCURRENTLY "product_search_word"`
+
+	// GetSearchAutocompleteQuery finds the listed products, categories and
+	// shops whose name starts with, contains or is similar to $1, prefix
+	// matches first.
+	GetSearchAutocompleteQuery = `
+	(SELECT 'product' as "type", "p"."id", "p"."title" as "name", "p"."thumbnail_url" as "image_url"
+	FROM "product" as "p"
+	WHERE "p"."deleted_at" IS NULL AND "p"."listed_status" = true
+	AND ("p"."title" ILIKE ('%' || ` + likeKeyword + ` || '%') OR "p"."title" % $1)
+	ORDER BY "p"."title" ILIKE (` + likeKeyword + ` || '%') DESC, similarity("p"."title", $1) DESC, "p"."unit_sold" DESC
+	LIMIT $2)
+	UNION ALL
+	(SELECT 'category', "c"."id", "c"."name", "c"."photo_url"
+	FROM "category" as "c"
+	WHERE "c"."name" ILIKE ('%' || ` + likeKeyword + ` || '%') OR "c"."name" % $1
+	ORDER BY "c"."name" ILIKE (` + likeKeyword + ` || '%') DESC, similarity("c"."name", $1) DESC
+	LIMIT $2)
+	UNION ALL
+	(SELECT 'shop', "s"."id", "s"."name", "u"."photo_url"
+	FROM "shop" as "s"
+	INNER JOIN "user" as "u" ON "u"."id" = "s"."user_id"
+	WHERE "s"."deleted_at" IS NULL
+	AND ("s"."name" ILIKE ('%' || ` + likeKeyword + ` || '%') OR "s"."name" % $1)
+	ORDER BY "s"."name" ILIKE (` + likeKeyword + ` || '%') DESC, similarity("s"."name", $1) DESC
+	LIMIT $2)`
+
+	CreateSearchQueryQuery = `INSERT INTO "search_query" ("query", "result_count") VALUES ($1, $2)`
+
+	// GetProductFacetsQuery counts the products of a listing per category,
+	// province, price band and minimum rating. Each facet applies every
+	// filter but its own, so picking a value does not hide the others.
+	// Price bands are in minor units (sen), like "min_price".
+	GetProductFacetsQuery = `
+	WITH "f" AS (
+		SELECT "c"."name" as "category_name", "a"."province_id", "a"."province", "p"."rating_avg", "p"."min_price",
+			"c".name ILIKE $2 as "in_category",
+			("p".rating_avg BETWEEN $3 AND $4) as "in_rating",
+			("p".min_price BETWEEN $5 AND $6) as "in_price",
+			(COALESCE(cardinality($7::text[]), 0) = 0 OR "a"."province_id"::text = ANY($7::text[])) as "in_province"
+		FROM "product" as "p"
+		INNER JOIN "shop" as "s" ON "s"."id" = "p"."shop_id"
+		INNER JOIN "category" as "c" ON "c"."id" = "p"."category_id"
+		INNER JOIN "user" as "u" ON "u"."id" = "s"."user_id"
+		INNER JOIN "address" as "a" ON "u"."id" = "a"."user_id"
+		WHERE ` + WhereProductSearch + `
+		AND "a"."is_shop_default" = true
+		AND ($8 = '' OR "s"."id"::text = $8)
+		AND ($9 = 0 OR "p"."listed_status" = ($9 = 1))
+	)
+	SELECT 'category' as "facet", "category_name" as "value", "category_name" as "label",
+		NULL::numeric as "min", NULL::numeric as "max", count(*) as "count", -count(*) as "sort"
+	FROM "f" WHERE "in_rating" AND "in_price" AND "in_province"
+	GROUP BY "category_name"
+	UNION ALL
+	SELECT 'province', "province_id"::text, "province", NULL, NULL, count(*), -count(*)
+	FROM "f" WHERE "in_category" AND "in_rating" AND "in_price" AND "province_id" IS NOT NULL
+	GROUP BY "province_id", "province"
+	UNION ALL
+	SELECT 'price', '', '', "b"."min", "b"."max", count("f"."min_price"), "b"."min"
+	FROM (VALUES (0, 10000000), (10000000, 50000000), (50000000, 100000000), (100000000, 500000000), (500000000, NULL)) as "b"("min", "max")
+	LEFT JOIN "f" ON "f"."in_category" AND "f"."in_rating" AND "f"."in_province"
+		AND "f"."min_price" >= "b"."min" AND ("b"."max" IS NULL OR "f"."min_price" < "b"."max")
+	GROUP BY "b"."min", "b"."max"
+	UNION ALL
+	SELECT 'rating', '', '', "r"."min", NULL, count("f"."rating_avg"), -"r"."min"
+	FROM (VALUES (4), (3), (2), (1)) as "r"("min")
+	LEFT JOIN "f" ON "f"."in_category" AND "f"."in_price" AND "f"."in_province" AND "f"."rating_avg" >= "r"."min"
+	GROUP BY "r"."min"
+	ORDER BY "facet", "sort", "label"`
+
 	GetProductsQuery = `
 	SELECT "p"."id" as "product_id","p"."title" as "title", "p"."unit_sold" as "unit_sold", "p"."rating_avg" as "rating_avg", "p"."thumbnail_url" as "thumbnail_url",
 		"p"."min_price" as "min_price", "p"."max_price" as "max_price", "p"."view_count" as "view_count", 
@@ -71,7 +177,8 @@ const (
 		"p".listed_status,
 		"p"."created_at",
 		"p"."updated_at",
-		"p"."sku"
+		"p"."sku",
+		` + SelectProductSearch + `
 	FROM "product" as "p"
 	LEFT JOIN (
 		SELECT * FROM "promotion"
@@ -81,7 +188,7 @@ const (
 	INNER JOIN "category" as "c" ON "c"."id" = "p"."category_id"
 	INNER JOIN "user" as "u" ON "u"."id" = "s"."user_id"
 	INNER JOIN "address" as "a" ON "u"."id" = "a"."user_id"
-	WHERE "p".title ILIKE $1 
+	WHERE ` + WhereProductSearch + `
 	AND "a"."is_shop_default" = true
 	AND  "c".name ILIKE $2
 	AND ("p".rating_avg BETWEEN $3 AND $4)
@@ -100,7 +207,8 @@ const (
 		"p".listed_status,
 		"p"."created_at",
 		"p"."updated_at",
-		"p"."sku"
+		"p"."sku",
+		` + SelectProductSearch + `
 	FROM "product" as "p"
 	LEFT JOIN (
 		SELECT * FROM "promotion"
@@ -110,7 +218,7 @@ const (
 	INNER JOIN "category" as "c" ON "c"."id" = "p"."category_id"
 	INNER JOIN "user" as "u" ON "u"."id" = "s"."user_id"
 	INNER JOIN "address" as "a" ON "u"."id" = "a"."user_id"
-	WHERE "p".title ILIKE $1 
+	WHERE ` + WhereProductSearch + `
 	AND "a"."is_shop_default" = true
 	AND  "c".name ILIKE $2
 	AND ("p".rating_avg BETWEEN $3 AND $4)
@@ -138,7 +246,7 @@ const (
 	INNER JOIN "shop" as "s" ON "s"."id" = "p"."shop_id"
 	INNER JOIN "user" as "u" ON "u"."id" = "s"."user_id"
 	INNER JOIN "address" as "a" ON "u"."id" = "a"."user_id"
-	WHERE "p".title ILIKE $1 
+	WHERE ` + WhereProductSearch + `
 	AND "a"."is_shop_default" = true
 	AND  "c".name ILIKE $2
 	AND ("p".rating_avg BETWEEN $3 AND $4)
@@ -151,7 +259,7 @@ const (
 	INNER JOIN "shop" as "s" ON "s"."id" = "p"."shop_id"
 	INNER JOIN "user" as "u" ON "u"."id" = "s"."user_id"
 	INNER JOIN "address" as "a" ON "u"."id" = "a"."user_id"
-	WHERE "p".title ILIKE $1 
+	WHERE ` + WhereProductSearch + `
 	AND "a"."is_shop_default" = true
 	AND  "c".name ILIKE $2
 	AND ("p".rating_avg BETWEEN $3 AND $4)
@@ -237,38 +345,87 @@ const (
 	FROM review r
 	WHERE r.product_id = $1
 	%s
-	and r.deleted_at IS NULL;`
+	and r.deleted_at IS NULL
+	and r.hidden_at IS NULL;`
 
 	GetReviewProductQuery = `
-	SELECT r.id, r.user_id, r.product_id, r.comment, r.rating, r.image_url, r.created_at, u.photo_url, u.username
+	SELECT r.id, r.user_id, r.product_id, r.order_item_id, r.comment, r.rating, r.image_url, r.created_at,
+		u.photo_url, u.username,
+		COALESCE(array_agg(vd.name) FILTER (WHERE vd.id IS NOT NULL), '{}') as variant_name,
+		COALESCE(array_agg(vd.type) FILTER (WHERE vd.id IS NOT NULL), '{}') as variant_type,
+		r.helpful_count, r.unhelpful_count, rr.id, rr.comment, rr.created_at, rr.updated_at
 	FROM review r
 	INNER JOIN "user" u
 	ON r.user_id = u.id
+	LEFT JOIN order_item oi ON oi.id = r.order_item_id
+	LEFT JOIN variant v ON v.product_detail_id = oi.product_detail_id
+	LEFT JOIN variant_detail vd ON vd.id = v.variant_detail_id
+	LEFT JOIN review_reply rr ON rr.review_id = r.id
 	WHERE r.product_id = $1
 	%s
 	AND r.deleted_at IS NULL
+	AND r.hidden_at IS NULL
+	GROUP BY r.id, u.id, rr.id
 	ORDER BY %s LIMIT $2 OFFSET $3;`
 
 	GetReviewProductByIDQuery = `
-	SELECT r.id, r.user_id, r.product_id, r.comment, r.rating, r.image_url, r.created_at, u.photo_url, u.username
+	SELECT r.id, r.user_id, r.product_id, r.order_item_id, r.comment, r.rating, r.image_url, r.created_at,
+		u.photo_url, u.username
 	FROM review r
 	INNER JOIN "user" u
 	ON r.user_id = u.id
 	WHERE r.id = $1
 	AND r.deleted_at IS NULL;`
 
+	GetOrderItemForReviewQuery = `
+	SELECT oi.id, o.user_id, o.order_status_id, pd.product_id, oi.is_review
+	FROM order_item oi
+	INNER JOIN "order" o ON o.id = oi.order_id
+	INNER JOIN product_detail pd ON pd.id = oi.product_detail_id
+	WHERE oi.id = $1
+	FOR UPDATE OF oi;`
+
+	UpdateOrderItemIsReviewQuery = `UPDATE "order_item" SET is_review = $2 WHERE id = $1;`
+
 	GetTotalReviewRatingByProductIDQuery = `
 	SELECT r.rating, count(r.id) as count 
 	FROM review r
 	WHERE r.product_id = $1
 	and r.deleted_at IS NULL
+	and r.hidden_at IS NULL
 	group by r.rating;`
 
-	CreateReviewQuery = `INSERT INTO "review" (user_id, product_id, comment, rating, image_url)
-	VALUES ($1, $2, $3, $4, $5);`
+	CreateReviewQuery = `INSERT INTO "review" (user_id, product_id, order_item_id, comment, rating, image_url)
+	VALUES ($1, $2, $3, $4, $5, $6);`
 
 	DeleteReviewByIDQuery = `UPDATE "review" set deleted_at = now() WHERE id = $1;`
 
+	GetReviewShopIDQuery = `
+	SELECT p.shop_id
+	FROM review r
+	INNER JOIN product p ON p.id = r.product_id
+	WHERE r.id = $1
+	AND r.deleted_at IS NULL;`
+
+	UpsertReviewReplyQuery = `INSERT INTO "review_reply" (review_id, shop_id, comment)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (review_id) DO UPDATE SET comment = EXCLUDED.comment, updated_at = now();`
+
+	UpsertReviewVoteQuery = `INSERT INTO "review_vote" (review_id, user_id, is_helpful)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (review_id, user_id) DO UPDATE SET is_helpful = EXCLUDED.is_helpful, updated_at = now();`
+
+	DeleteReviewVoteQuery = `DELETE FROM "review_vote" WHERE review_id = $1 AND user_id = $2;`
+
+	UpdateReviewVoteCountQuery = `UPDATE "review" SET
+	helpful_count = (SELECT count(*) FROM review_vote WHERE review_id = $1 AND is_helpful),
+	unhelpful_count = (SELECT count(*) FROM review_vote WHERE review_id = $1 AND NOT is_helpful)
+	WHERE id = $1;`
+
+	CreateReviewReportQuery = `INSERT INTO "review_report" (review_id, user_id, reason)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (review_id, user_id) DO NOTHING;`
+
 	GetShopIDByUserIDQuery = `SELECT id from "shop" WHERE user_id = $1 AND deleted_at IS NULL `
 
 	CreateProductQuery = `INSERT INTO "product" 
@@ -311,9 +468,15 @@ const (
 		GROUP BY s.id`
 
 	GetRatingProductQuery = `SELECT 
-    	"p"."id", "p"."title", "p"."shop_id", count("r"."id"), avg("r"."rating")  
-	FROM "product" as "p" INNER JOIN "review" as "r" on "p"."id" = "r"."product_id" 
-	WHERE "r"."deleted_at" IS NULL AND "r"."created_at" >= (now() - interval '1 hour') GROUP BY "p"."id"`
+    	"p"."id", "p"."title", "p"."shop_id", count("r"."id"), COALESCE(avg("r"."rating"), 0)
+	FROM "product" as "p" LEFT JOIN "review" as "r" on "p"."id" = "r"."product_id"
+		AND "r"."deleted_at" IS NULL AND "r"."hidden_at" IS NULL
+	WHERE "p"."id" IN (
+		SELECT "product_id" FROM "review"
+		WHERE "created_at" >= (now() - interval '1 hour')
+		OR "deleted_at" >= (now() - interval '1 hour')
+		OR "moderated_at" >= (now() - interval '1 hour')
+	) GROUP BY "p"."id"`
 
 	GetFavoriteProductQuery = `SELECT 
     "p"."id", "p"."title", count("p"."id") 
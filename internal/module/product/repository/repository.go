@@ -3,18 +3,23 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"murakali/internal/constant"
 	"murakali/internal/model"
 	"murakali/internal/module/product"
 	"murakali/internal/module/product/delivery/body"
+	"murakali/internal/util"
 	"murakali/pkg/httperror"
 	"murakali/pkg/pagination"
 	"murakali/pkg/postgre"
 	"murakali/pkg/response"
 	"net/http"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type productRepo struct {
@@ -342,20 +347,13 @@ func (r *productRepo) GetProductDetail(ctx context.Context, productID string, pr
 		detail.ProductURL = productURLs
 
 		if promo != nil && (*promo.PromotionQuota) > 0 {
-			discountedPrice := 0.0
-			if promo.PromotionDiscountPercentage != nil {
-				discountedPrice = *detail.NormalPrice - (*detail.NormalPrice * (*promo.PromotionDiscountPercentage / float64(100)))
-			}
-			if promo.PromotionDiscountFixPrice != nil {
-				discountedPrice = *detail.NormalPrice - *promo.PromotionDiscountFixPrice
-			}
-			if *detail.NormalPrice >= *promo.PromotionMinProductPrice {
-				if promo.PromotionDiscountFixPrice != nil && *promo.PromotionDiscountFixPrice > *promo.PromotionMaxDiscountPrice {
-					discountedPrice = *detail.NormalPrice - *promo.PromotionDiscountFixPrice
-				}
-				if discountedPrice > *promo.PromotionMaxDiscountPrice {
-					discountedPrice = *detail.NormalPrice - *promo.PromotionMaxDiscountPrice
-				}
+			discount, discountedPrice := util.CalculateDiscount(*detail.NormalPrice, &model.Discount{
+				DiscountPercentage: promo.PromotionDiscountPercentage,
+				DiscountFixPrice:   promo.PromotionDiscountFixPrice,
+				MinProductPrice:    promo.PromotionMinProductPrice,
+				MaxDiscountPrice:   promo.PromotionMaxDiscountPrice,
+			})
+			if discount > 0 {
 				detail.DiscountPrice = &discountedPrice
 			}
 		}
@@ -473,7 +471,7 @@ func (r *productRepo) GetProducts(ctx context.Context, pgn *pagination.Paginatio
 	if len(query.Province) > 0 {
 		res, err = r.PSQL.QueryContext(
 			ctx, GetProductsWithProvinceQuery+queryWhereShopIds+queryWhereProvinceIds+queryListedStatus+queryOrderBySomething,
-			query.Search,
+			query.Keyword,
 			query.Category,
 			query.MinRating,
 			query.MaxRating,
@@ -484,7 +482,7 @@ func (r *productRepo) GetProducts(ctx context.Context, pgn *pagination.Paginatio
 	} else {
 		res, err = r.PSQL.QueryContext(
 			ctx, GetProductsQuery+queryWhereShopIds+queryWhereProvinceIds+queryListedStatus+queryOrderBySomething,
-			query.Search,
+			query.Keyword,
 			query.Category,
 			query.MinRating,
 			query.MaxRating,
@@ -505,6 +503,7 @@ func (r *productRepo) GetProducts(ctx context.Context, pgn *pagination.Paginatio
 		var productData body.Products
 		var promo model.Promotion
 		var voucher model.Voucher
+		var relevance float64
 
 		if errScan := res.Scan(
 			&productData.ID,
@@ -526,10 +525,15 @@ func (r *productRepo) GetProducts(ctx context.Context, pgn *pagination.Paginatio
 			&productData.CreatedAt,
 			&productData.UpdatedAt,
 			&productData.SKU,
+			&relevance,
+			&productData.TitleHighlight,
+			&productData.Snippet,
 		); errScan != nil {
 			return nil, nil, nil, err
 		}
 
+		productData.TitleHighlight = body.HighlightSearch(productData.TitleHighlight)
+		productData.Snippet = body.HighlightSearch(productData.Snippet)
 		products = append(products, &productData)
 		promotions = append(promotions, &promo)
 		vouchers = append(vouchers, &voucher)
@@ -564,7 +568,7 @@ func (r *productRepo) GetAllTotalProduct(ctx context.Context, query *body.GetPro
 	if len(query.Province) > 0 {
 		if err := r.PSQL.QueryRowContext(ctx,
 			GetAllTotalProductWithProvinceQuery+queryWhereShopIds+queryWhereProvinceIds+queryListedStatus,
-			query.Search,
+			query.Keyword,
 			query.Category,
 			query.MinRating,
 			query.MaxRating,
@@ -577,7 +581,7 @@ func (r *productRepo) GetAllTotalProduct(ctx context.Context, query *body.GetPro
 	} else {
 		if err := r.PSQL.QueryRowContext(ctx,
 			GetAllTotalProductQuery+queryWhereShopIds+queryWhereProvinceIds+queryListedStatus,
-			query.Search,
+			query.Keyword,
 			query.Category,
 			query.MinRating,
 			query.MaxRating,
@@ -591,6 +595,174 @@ func (r *productRepo) GetAllTotalProduct(ctx context.Context, query *body.GetPro
 	return total, nil
 }
 
+func (r *productRepo) GetSearchSuggestion(ctx context.Context, keyword string) (string, error) {
+	var suggestion string
+	if err := r.PSQL.QueryRowContext(ctx, GetSearchSuggestionQuery, keyword).Scan(&suggestion); err != nil {
+		return "", err
+	}
+
+	return suggestion, nil
+}
+
+func (r *productRepo) RefreshSearchWords(ctx context.Context) error {
+	_, err := r.PSQL.ExecContext(ctx, RefreshSearchWordsQuery)
+	return err
+}
+
+func (r *productRepo) GetSearchAutocomplete(ctx context.Context, keyword string, limit int) (*body.SearchSuggestion, error) {
+	suggestion := &body.SearchSuggestion{
+		Products:   make([]*body.SuggestItem, 0),
+		Categories: make([]*body.SuggestItem, 0),
+		Shops:      make([]*body.SuggestItem, 0),
+	}
+
+	res, err := r.PSQL.QueryContext(ctx, GetSearchAutocompleteQuery, keyword, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var suggestType string
+		var item body.SuggestItem
+		if errScan := res.Scan(&suggestType, &item.ID, &item.Name, &item.ImageURL); errScan != nil {
+			return nil, errScan
+		}
+
+		switch suggestType {
+		case body.SuggestProduct:
+			suggestion.Products = append(suggestion.Products, &item)
+		case body.SuggestCategory:
+			suggestion.Categories = append(suggestion.Categories, &item)
+		case body.SuggestShop:
+			suggestion.Shops = append(suggestion.Shops, &item)
+		}
+	}
+
+	if res.Err() != nil {
+		return nil, res.Err()
+	}
+
+	return suggestion, nil
+}
+
+func (r *productRepo) CreateSearchQuery(ctx context.Context, keyword string, resultCount int64) error {
+	_, err := r.PSQL.ExecContext(ctx, CreateSearchQueryQuery, keyword, resultCount)
+	return err
+}
+
+func (r *productRepo) IncrSearchTrendingRedis(ctx context.Context, key, keyword string) error {
+	_, err := r.RedisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZIncrBy(ctx, key, 1, keyword)
+		pipe.Expire(ctx, key, time.Duration(constant.SearchTrendingTTLDay)*24*time.Hour)
+		return nil
+	})
+
+	return err
+}
+
+func (r *productRepo) GetSearchTrendingRedis(ctx context.Context, key string, limit int) ([]*body.TrendingSearch, error) {
+	res, err := r.RedisClient.ZRevRangeWithScores(ctx, key, 0, int64(limit)-1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	trending := make([]*body.TrendingSearch, 0, len(res))
+	for _, z := range res {
+		keyword, _ := z.Member.(string)
+		trending = append(trending, &body.TrendingSearch{Query: keyword, Count: int64(z.Score)})
+	}
+
+	return trending, nil
+}
+
+func (r *productRepo) GetProductFacets(ctx context.Context, query *body.GetProductQueryRequest) (*body.ProductFacets, error) {
+	facets := &body.ProductFacets{
+		Categories: make([]*body.FacetCount, 0),
+		Provinces:  make([]*body.FacetCount, 0),
+		PriceBands: make([]*body.PriceBandFacet, 0),
+		Ratings:    make([]*body.RatingFacet, 0),
+	}
+
+	res, err := r.PSQL.QueryContext(ctx, GetProductFacetsQuery,
+		query.Keyword,
+		query.Category,
+		query.MinRating,
+		query.MaxRating,
+		query.MinPrice,
+		query.MaxPrice,
+		pq.Array(query.Province),
+		query.Shop,
+		query.ListedStatus)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var facet, value, label string
+		var minValue, maxValue sql.NullFloat64
+		var count, sort int64
+		if errScan := res.Scan(&facet, &value, &label, &minValue, &maxValue, &count, &sort); errScan != nil {
+			return nil, errScan
+		}
+
+		switch facet {
+		case body.FacetCategory:
+			facets.Categories = append(facets.Categories, &body.FacetCount{Value: value, Label: label, Count: count})
+		case body.FacetProvince:
+			facets.Provinces = append(facets.Provinces, &body.FacetCount{Value: value, Label: label, Count: count})
+		case body.FacetPrice:
+			band := &body.PriceBandFacet{MinPrice: model.Money(minValue.Float64), Count: count}
+			if maxValue.Valid {
+				maxPrice := model.Money(maxValue.Float64)
+				band.MaxPrice = &maxPrice
+			}
+			facets.PriceBands = append(facets.PriceBands, band)
+		case body.FacetRating:
+			facets.Ratings = append(facets.Ratings, &body.RatingFacet{MinRating: minValue.Float64, Count: count})
+		}
+	}
+
+	if res.Err() != nil {
+		return nil, res.Err()
+	}
+
+	return facets, nil
+}
+
+func (r *productRepo) GetProductFacetsRedis(ctx context.Context, key string) (*body.ProductFacets, error) {
+	res := r.RedisClient.Get(ctx, key)
+	if res.Err() != nil {
+		return nil, res.Err()
+	}
+
+	value, err := res.Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var facets body.ProductFacets
+	if err := json.Unmarshal([]byte(value), &facets); err != nil {
+		return nil, err
+	}
+
+	return &facets, nil
+}
+
+func (r *productRepo) InsertProductFacetsRedis(ctx context.Context, key string, value *body.ProductFacets) error {
+	valueStr, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	if err := r.RedisClient.Set(ctx, key, valueStr, time.Duration(constant.ProductFacetsTTLSec)*time.Second); err.Err() != nil {
+		return err.Err()
+	}
+
+	return nil
+}
+
 func (r *productRepo) GetFavoriteProducts(
 	ctx context.Context, pgn *pagination.Pagination, query *body.GetProductQueryRequest, userID string) ([]*body.Products,
 	[]*model.Promotion, []*model.Voucher, error) {
@@ -735,19 +907,48 @@ func (r *productRepo) GetProductReviews(ctx context.Context,
 
 	for res.Next() {
 		var reviewData body.ReviewProduct
+		var variantName []string
+		var variantType []string
+		var replyID *uuid.UUID
+		var replyComment *string
+		var replyCreatedAt, replyUpdatedAt *time.Time
 
 		if errScan := res.Scan(
 			&reviewData.ID,
 			&reviewData.UserID,
 			&reviewData.ProductID,
+			&reviewData.OrderItemID,
 			&reviewData.Comment,
 			&reviewData.Rating,
 			&reviewData.ImageURL,
 			&reviewData.CreatedAt,
 			&reviewData.PhotoURL,
 			&reviewData.Username,
+			(*pq.StringArray)(&variantName),
+			(*pq.StringArray)(&variantType),
+			&reviewData.HelpfulCount,
+			&reviewData.UnhelpfulCount,
+			&replyID,
+			&replyComment,
+			&replyCreatedAt,
+			&replyUpdatedAt,
 		); errScan != nil {
-			return nil, err
+			return nil, errScan
+		}
+
+		if replyID != nil {
+			reviewData.Reply = &body.ReviewReply{
+				ID:        *replyID,
+				Comment:   *replyComment,
+				CreatedAt: *replyCreatedAt,
+				UpdatedAt: *replyUpdatedAt,
+			}
+		}
+
+		reviewData.VerifiedPurchase = reviewData.OrderItemID != nil
+		reviewData.Variant = make(map[string]string, len(variantName))
+		for i := range variantName {
+			reviewData.Variant[variantName[i]] = variantType[i]
 		}
 
 		reviews = append(reviews, &reviewData)
@@ -826,10 +1027,11 @@ func (r *productRepo) FindReview(ctx context.Context, reviewID string) (*body.Re
 		&review.ID,
 		&review.UserID,
 		&review.ProductID,
+		&review.OrderItemID,
 		&review.Comment,
 		&review.Rating,
 		&review.ImageURL,
-		&review.Username,
+		&review.CreatedAt,
 		&review.PhotoURL,
 		&review.Username,
 	); err != nil {
@@ -844,6 +1046,7 @@ func (r *productRepo) CreateProductReview(ctx context.Context, tx postgre.Transa
 		CreateReviewQuery,
 		userID,
 		reqBody.ProductID,
+		reqBody.OrderItemID,
 		reqBody.Comment,
 		reqBody.Rating,
 		reqBody.PhotoURL,
@@ -854,6 +1057,28 @@ func (r *productRepo) CreateProductReview(ctx context.Context, tx postgre.Transa
 	return nil
 }
 
+// GetOrderItemForReview locks the order item until tx ends, so the same item
+// cannot be reviewed twice at once.
+func (r *productRepo) GetOrderItemForReview(ctx context.Context, tx postgre.Transaction, orderItemID string) (*body.ReviewOrderItem, error) {
+	var orderItem body.ReviewOrderItem
+	if err := tx.QueryRowContext(ctx, GetOrderItemForReviewQuery, orderItemID).Scan(
+		&orderItem.ID,
+		&orderItem.UserID,
+		&orderItem.OrderStatusID,
+		&orderItem.ProductID,
+		&orderItem.IsReview,
+	); err != nil {
+		return nil, err
+	}
+
+	return &orderItem, nil
+}
+
+func (r *productRepo) UpdateOrderItemIsReview(ctx context.Context, tx postgre.Transaction, orderItemID string, isReview bool) error {
+	_, err := tx.ExecContext(ctx, UpdateOrderItemIsReviewQuery, orderItemID, isReview)
+	return err
+}
+
 func (r *productRepo) DeleteReview(ctx context.Context, tx postgre.Transaction, reviewID string) error {
 	_, err := tx.ExecContext(ctx, DeleteReviewByIDQuery, reviewID)
 	if err != nil {
@@ -862,6 +1087,48 @@ func (r *productRepo) DeleteReview(ctx context.Context, tx postgre.Transaction,
 	return nil
 }
 
+func (r *productRepo) GetReviewShopID(ctx context.Context, reviewID string) (string, error) {
+	var shopID string
+	if err := r.PSQL.QueryRowContext(ctx, GetReviewShopIDQuery, reviewID).Scan(&shopID); err != nil {
+		return "", err
+	}
+
+	return shopID, nil
+}
+
+func (r *productRepo) UpsertReviewReply(ctx context.Context, reviewID, shopID, comment string) error {
+	_, err := r.PSQL.ExecContext(ctx, UpsertReviewReplyQuery, reviewID, shopID, comment)
+	return err
+}
+
+func (r *productRepo) UpsertReviewVote(ctx context.Context, tx postgre.Transaction, reviewID, userID string, isHelpful bool) error {
+	_, err := tx.ExecContext(ctx, UpsertReviewVoteQuery, reviewID, userID, isHelpful)
+	return err
+}
+
+func (r *productRepo) DeleteReviewVote(ctx context.Context, tx postgre.Transaction, reviewID, userID string) (int64, error) {
+	res, err := tx.ExecContext(ctx, DeleteReviewVoteQuery, reviewID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *productRepo) UpdateReviewVoteCount(ctx context.Context, tx postgre.Transaction, reviewID string) error {
+	_, err := tx.ExecContext(ctx, UpdateReviewVoteCountQuery, reviewID)
+	return err
+}
+
+func (r *productRepo) CreateReviewReport(ctx context.Context, reviewID, userID, reason string) (int64, error) {
+	res, err := r.PSQL.ExecContext(ctx, CreateReviewReportQuery, reviewID, userID, reason)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
 func (r *productRepo) GetShopIDByUserID(ctx context.Context, userID string) (string, error) {
 	var shopID string
 	if err := r.PSQL.QueryRowContext(ctx, GetShopIDByUserIDQuery, userID).Scan(&shopID); err != nil {
@@ -14,7 +14,10 @@ type UseCase interface {
 	GetRecommendedProducts(ctx context.Context, pgn *pagination.Pagination) (*pagination.Pagination, error)
 	GetProductDetail(ctx context.Context, productID string) (*body.ProductDetailResponse, error)
 	GetAllProductImage(ctx context.Context, productID string) ([]*body.GetImageResponse, error)
-	GetProducts(ctx context.Context, pgn *pagination.Pagination, query *body.GetProductQueryRequest) (*pagination.Pagination, error)
+	GetProducts(ctx context.Context, pgn *pagination.Pagination, query *body.GetProductQueryRequest) (*body.GetProductsResponse, error)
+	LogSearchQuery(ctx context.Context, keyword string, resultCount int64) error
+	GetSearchSuggestions(ctx context.Context, keyword string) (*body.SearchSuggestion, error)
+	GetTrendingSearches(ctx context.Context, limit int) ([]*body.TrendingSearch, error)
 	GetFavoriteProducts(ctx context.Context, pgn *pagination.Pagination, query *body.GetProductQueryRequest,
 		userID string) (*pagination.Pagination, error)
 	CheckProductIsFavorite(ctx context.Context, userID, productID string) bool
@@ -27,9 +30,13 @@ type UseCase interface {
 	GetTotalReviewRatingByProductID(ctx context.Context, productID string) (*body.AllRatingProduct, error)
 	CreateProductReview(ctx context.Context, reqBody body.ReviewProductRequest, userID string) error
 	DeleteProductReview(ctx context.Context, reviewID, userID string) error
+	ReplyProductReview(ctx context.Context, reviewID, userID string, reqBody body.ReviewReplyRequest) error
+	VoteProductReview(ctx context.Context, reviewID, userID string, reqBody body.ReviewVoteRequest) error
+	DeleteProductReviewVote(ctx context.Context, reviewID, userID string) error
+	ReportProductReview(ctx context.Context, reviewID, userID string, reqBody body.ReportReviewRequest) error
 	CreateProduct(ctx context.Context, requestBody body.CreateProductRequest, userID string) error
 	UpdateListedStatus(ctx context.Context, productID string) error
 	UpdateProductListedStatusBulk(ctx context.Context, product body.UpdateProductListedStatusBulkRequest) error
 	UpdateProduct(ctx context.Context, requestBody body.UpdateProductRequest, userID, productID string) error
-	UpdateProductMetadata(ctx context.Context) error
+	UpdateProductMetadata(ctx context.Context) (*model.JobReport, error)
 }
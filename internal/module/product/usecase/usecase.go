@@ -2,10 +2,14 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"fmt"
 
 	"math"
 	"murakali/config"
+	"murakali/internal/constant"
 	"murakali/internal/model"
 	"murakali/internal/module/product"
 	"murakali/internal/module/product/delivery/body"
@@ -15,6 +19,10 @@ import (
 	"murakali/pkg/postgre"
 	"murakali/pkg/response"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -29,22 +37,27 @@ func NewProductUseCase(cfg *config.Config, txRepo *postgre.TxRepo, productRepo p
 	return &productUC{cfg: cfg, txRepo: txRepo, productRepo: productRepo}
 }
 
-func (u *productUC) UpdateProductMetadata(ctx context.Context) error {
+func (u *productUC) UpdateProductMetadata(ctx context.Context) (*model.JobReport, error) {
+	report := model.NewJobReport(constant.JobProductMetadata)
+	defer report.Finish()
+
 	productFav, err := u.productRepo.GetFavoriteProduct(ctx)
 	if err != nil {
-		return err
+		return report, err
 	}
 
 	productRating, err := u.productRepo.GetRatingProduct(ctx)
 	if err != nil {
-		return err
+		return report, err
 	}
 
 	var errFav error
 	for _, favorite := range productFav {
 		if err := u.productRepo.UpdateProductFavorite(ctx, favorite.Product.ID.String(), *favorite.Count); err != nil {
 			errFav = err
+			continue
 		}
+		report.RowsAffected++
 	}
 
 	var errRating error
@@ -53,29 +66,43 @@ func (u *productUC) UpdateProductMetadata(ctx context.Context) error {
 		shopID[rating.Product.ShopID.String()] = rating.Product.ShopID.String()
 		if err := u.productRepo.UpdateProductRating(ctx, rating.Product.ID.String(), *rating.Avg); err != nil {
 			errRating = err
+			continue
 		}
+		report.RowsAffected++
 	}
 
 	var errShop error
 	for _, id := range shopID {
-		shopProductRating, errShop := u.productRepo.GetShopProductRating(ctx, id)
-		if errShop == nil {
-			errShop = u.productRepo.UpdateShopProductRating(ctx, shopProductRating)
+		shopProductRating, err := u.productRepo.GetShopProductRating(ctx, id)
+		if err == nil {
+			err = u.productRepo.UpdateShopProductRating(ctx, shopProductRating)
 		}
+
+		if err != nil {
+			errShop = err
+			continue
+		}
+		report.RowsAffected++
 	}
+	errSearch := u.productRepo.RefreshSearchWords(ctx)
+
 	if errShop != nil {
-		return errShop
+		return report, errShop
 	}
 
 	if errFav != nil {
-		return errFav
+		return report, errFav
 	}
 
 	if errRating != nil {
-		return errRating
+		return report, errRating
 	}
 
-	return nil
+	if errSearch != nil {
+		return report, errSearch
+	}
+
+	return report, nil
 }
 
 func (u *productUC) GetCategories(ctx context.Context) ([]*body.CategoryResponse, error) {
@@ -218,38 +245,15 @@ func (u *productUC) GetRecommendedProducts(ctx context.Context, pgn *pagination.
 }
 
 func (u *productUC) CalculateDiscountProduct(p *body.Products) *body.Products {
-	if p.PromoMaxDiscountPrice == nil {
-		return p
-	}
-	var maxDiscountPrice float64
-	var minProductPrice float64
-	var discountPercentage float64
-	var discountFixPrice float64
-	var resultDiscount float64
-	if p.PromoMinProductPrice != nil {
-		minProductPrice = *p.PromoMinProductPrice
-	}
-
-	maxDiscountPrice = *p.PromoMaxDiscountPrice
-	if p.PromoDiscountPercentage != nil {
-		discountPercentage = *p.PromoDiscountPercentage
-		if p.MinPrice >= minProductPrice && *p.PromoDiscountPercentage > 0 {
-			resultDiscount = math.Min(maxDiscountPrice,
-				p.MinPrice*(discountPercentage/100.00))
-		}
-	}
-
-	if p.PromoDiscountFixPrice != nil {
-		discountFixPrice = *p.PromoDiscountFixPrice
-		if p.MinPrice >= minProductPrice && discountFixPrice > 0 {
-			resultDiscount = math.Max(resultDiscount, discountFixPrice)
-			resultDiscount = math.Min(resultDiscount, maxDiscountPrice)
-		}
-	}
-
+	resultDiscount, subPrice := util.CalculateDiscount(p.MinPrice, &model.Discount{
+		DiscountPercentage: p.PromoDiscountPercentage,
+		DiscountFixPrice:   p.PromoDiscountFixPrice,
+		MinProductPrice:    p.PromoMinProductPrice,
+		MaxDiscountPrice:   p.PromoMaxDiscountPrice,
+	})
 	if resultDiscount > 0 {
 		p.ResultDiscount = &resultDiscount
-		p.SubPrice = p.MinPrice - resultDiscount
+		p.SubPrice = subPrice
 	}
 
 	return p
@@ -285,7 +289,10 @@ func (u *productUC) GetProductDetail(ctx context.Context, productID string) (*bo
 	return &result, nil
 }
 
-func (u *productUC) GetProducts(ctx context.Context, pgn *pagination.Pagination, query *body.GetProductQueryRequest) (*pagination.Pagination, error) {
+// GetProducts lists the products matching query. A search also suggests a
+// spelling when one of its words is closer to another word of the catalogue.
+func (u *productUC) GetProducts(ctx context.Context, pgn *pagination.Pagination,
+	query *body.GetProductQueryRequest) (*body.GetProductsResponse, error) {
 	totalRows, err := u.productRepo.GetAllTotalProduct(ctx, query)
 	if err != nil {
 		return nil, err
@@ -326,13 +333,105 @@ func (u *productUC) GetProducts(ctx context.Context, pgn *pagination.Pagination,
 			CreatedAt:                 products[i].CreatedAt,
 			UpdatedAt:                 products[i].UpdatedAt,
 			SKU:                       products[i].SKU,
+			TitleHighlight:            products[i].TitleHighlight,
+			Snippet:                   products[i].Snippet,
 		}
 		p = u.CalculateDiscountProduct(p)
 		resultProduct = append(resultProduct, p)
 	}
 	pgn.Rows = resultProduct
 
-	return pgn, nil
+	facets, err := u.getProductFacets(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &body.GetProductsResponse{Pagination: pgn, Facets: facets}
+	keyword := body.NormalizeSearch(query.Keyword)
+	if keyword == "" {
+		return result, nil
+	}
+
+	suggestion, err := u.productRepo.GetSearchSuggestion(ctx, keyword)
+	if err != nil {
+		return nil, err
+	}
+
+	if suggestion != keyword {
+		result.DidYouMean = suggestion
+	}
+
+	return result, nil
+}
+
+// LogSearchQuery records a search for analytics. Searches with results
+// count towards the trending searches of the day.
+func (u *productUC) LogSearchQuery(ctx context.Context, keyword string, resultCount int64) error {
+	keyword = body.NormalizeSearch(keyword)
+	if keyword == "" {
+		return nil
+	}
+
+	if err := u.productRepo.CreateSearchQuery(ctx, keyword, resultCount); err != nil {
+		return err
+	}
+
+	if resultCount == 0 {
+		return nil
+	}
+
+	return u.productRepo.IncrSearchTrendingRedis(ctx, searchTrendingKey(time.Now()), keyword)
+}
+
+func searchTrendingKey(day time.Time) string {
+	return fmt.Sprintf("%s:%s", constant.SearchTrendingKey, day.Format("2006-01-02"))
+}
+
+func (u *productUC) GetSearchSuggestions(ctx context.Context, keyword string) (*body.SearchSuggestion, error) {
+	keyword = body.NormalizeSearch(keyword)
+	if utf8.RuneCountInString(keyword) < constant.SearchSuggestMinLength {
+		return &body.SearchSuggestion{
+			Products:   make([]*body.SuggestItem, 0),
+			Categories: make([]*body.SuggestItem, 0),
+			Shops:      make([]*body.SuggestItem, 0),
+		}, nil
+	}
+
+	return u.productRepo.GetSearchAutocomplete(ctx, keyword, constant.SearchSuggestLimit)
+}
+
+func (u *productUC) GetTrendingSearches(ctx context.Context, limit int) ([]*body.TrendingSearch, error) {
+	return u.productRepo.GetSearchTrendingRedis(ctx, searchTrendingKey(time.Now()), limit)
+}
+
+// getProductFacets caches the facets of a listing for a short while, so the
+// common ones, like a category page without a search, are not counted on
+// every page.
+func (u *productUC) getProductFacets(ctx context.Context, query *body.GetProductQueryRequest) (*body.ProductFacets, error) {
+	province := append([]string(nil), query.Province...)
+	sort.Strings(province)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%q|%q|%q|%v|%v|%v|%v|%q|%d", body.NormalizeSearch(query.Keyword),
+		query.Category, query.Shop, query.MinRating, query.MaxRating, query.MinPrice, query.MaxPrice,
+		strings.Join(province, ","), query.ListedStatus)
+	key := fmt.Sprintf("%s:%s", constant.ProductFacetsKey, hex.EncodeToString(h.Sum(nil)))
+
+	gotFacetsRedis, _ := u.productRepo.GetProductFacetsRedis(ctx, key)
+	if gotFacetsRedis != nil {
+		return gotFacetsRedis, nil
+	}
+
+	facets, err := u.productRepo.GetProductFacets(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.productRepo.InsertProductFacetsRedis(ctx, key, facets); err != nil {
+		return nil, err
+	}
+
+	return facets, nil
 }
 
 func (u *productUC) GetAllProductImage(ctx context.Context, productID string) ([]*body.GetImageResponse, error) {
@@ -749,46 +848,159 @@ func (u *productUC) UpdateProduct(ctx context.Context, requestBody body.UpdatePr
 	return nil
 }
 
+// CreateProductReview reviews one order item the user bought in a completed
+// order. Every order item can be reviewed once, so buying a product again
+// allows another review.
 func (u *productUC) CreateProductReview(ctx context.Context, reqBody body.ReviewProductRequest, userID string) error {
-	gotReview, err := u.productRepo.GetProductReviews(ctx, &pagination.Pagination{}, reqBody.ProductID, &body.GetReviewQueryRequest{
-		UserID: userID,
+	return u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		orderItem, err := u.productRepo.GetOrderItemForReview(ctx, tx, reqBody.OrderItemID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return httperror.New(http.StatusBadRequest, body.ReviewOrderItemNotExist)
+			}
+			return err
+		}
+
+		if orderItem.UserID.String() != userID {
+			return httperror.New(http.StatusForbidden, response.ForbiddenMessage)
+		}
+
+		if orderItem.OrderStatusID != constant.OrderStatusCompleted {
+			return httperror.New(http.StatusBadRequest, body.ReviewOrderNotCompleted)
+		}
+
+		if orderItem.ProductID.String() != reqBody.ProductID {
+			return httperror.New(http.StatusBadRequest, body.ReviewProductNotInOrder)
+		}
+
+		if orderItem.IsReview {
+			return httperror.New(http.StatusBadRequest, body.ReviewAlreadyExist)
+		}
+
+		if err := u.productRepo.CreateProductReview(ctx, tx, userID, reqBody); err != nil {
+			return err
+		}
+
+		return u.productRepo.UpdateOrderItemIsReview(ctx, tx, reqBody.OrderItemID, true)
 	})
+}
 
-	if len(gotReview) > 0 {
-		return httperror.New(http.StatusBadRequest, body.ReviewAlreadyExist)
+// DeleteProductReview lets the author delete a review. The order item it
+// reviewed can then be reviewed again.
+func (u *productUC) DeleteProductReview(ctx context.Context, reviewID, userID string) error {
+	gotReview, err := u.productRepo.FindReview(ctx, reviewID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return httperror.New(http.StatusBadRequest, body.ReviewNotExist)
+		}
+		return err
 	}
 
-	err = u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
-		err = u.productRepo.CreateProductReview(ctx, tx, userID, reqBody)
-		if err != nil {
+	if gotReview.UserID.String() != userID {
+		return httperror.New(http.StatusForbidden, response.ForbiddenMessage)
+	}
+
+	return u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		if err := u.productRepo.DeleteReview(ctx, tx, reviewID); err != nil {
 			return err
 		}
+
+		if gotReview.OrderItemID != nil {
+			return u.productRepo.UpdateOrderItemIsReview(ctx, tx, gotReview.OrderItemID.String(), false)
+		}
+
 		return nil
 	})
+}
+
+// ReplyProductReview sets the reply of the shop that sells the reviewed
+// product, replacing its previous reply.
+func (u *productUC) ReplyProductReview(ctx context.Context, reviewID, userID string, reqBody body.ReviewReplyRequest) error {
+	shopID, err := u.productRepo.GetShopIDByUserID(ctx, userID)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return httperror.New(http.StatusForbidden, response.ForbiddenMessage)
+		}
 		return err
 	}
-	return nil
+
+	reviewShopID, err := u.productRepo.GetReviewShopID(ctx, reviewID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return httperror.New(http.StatusBadRequest, body.ReviewNotExist)
+		}
+		return err
+	}
+
+	if reviewShopID != shopID {
+		return httperror.New(http.StatusForbidden, response.ForbiddenMessage)
+	}
+
+	return u.productRepo.UpsertReviewReply(ctx, reviewID, shopID, reqBody.Comment)
 }
 
-func (u *productUC) DeleteProductReview(ctx context.Context, reviewID, userID string) error {
-	gotReview, err := u.productRepo.FindReview(ctx, reviewID)
+// VoteProductReview records whether the user found a review helpful. Voting
+// again changes the vote.
+func (u *productUC) VoteProductReview(ctx context.Context, reviewID, userID string, reqBody body.ReviewVoteRequest) error {
+	review, err := u.productRepo.FindReview(ctx, reviewID)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return httperror.New(http.StatusBadRequest, body.ReviewNotExist)
+		}
 		return err
 	}
-	if gotReview == nil {
-		return httperror.New(http.StatusBadRequest, body.ReviewNotExist)
+
+	if review.UserID.String() == userID {
+		return httperror.New(http.StatusBadRequest, body.ReviewVoteOwn)
 	}
-	err = u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
-		err = u.productRepo.DeleteReview(ctx, tx, reviewID)
+
+	return u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		if err := u.productRepo.UpsertReviewVote(ctx, tx, reviewID, userID, *reqBody.IsHelpful); err != nil {
+			return err
+		}
+
+		return u.productRepo.UpdateReviewVoteCount(ctx, tx, reviewID)
+	})
+}
+
+func (u *productUC) DeleteProductReviewVote(ctx context.Context, reviewID, userID string) error {
+	return u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		deleted, err := u.productRepo.DeleteReviewVote(ctx, tx, reviewID, userID)
 		if err != nil {
 			return err
 		}
-		return nil
+
+		if deleted == 0 {
+			return httperror.New(http.StatusBadRequest, body.ReviewVoteNotExist)
+		}
+
+		return u.productRepo.UpdateReviewVoteCount(ctx, tx, reviewID)
 	})
+}
+
+// ReportProductReview puts a review in the moderation queue of the admins.
+// A user reports a review at most once.
+func (u *productUC) ReportProductReview(ctx context.Context, reviewID, userID string, reqBody body.ReportReviewRequest) error {
+	review, err := u.productRepo.FindReview(ctx, reviewID)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return httperror.New(http.StatusBadRequest, body.ReviewNotExist)
+		}
 		return err
 	}
 
+	if review.UserID.String() == userID {
+		return httperror.New(http.StatusBadRequest, body.ReviewReportOwn)
+	}
+
+	created, err := u.productRepo.CreateReviewReport(ctx, reviewID, userID, reqBody.Reason)
+	if err != nil {
+		return err
+	}
+
+	if created == 0 {
+		return httperror.New(http.StatusBadRequest, body.ReviewAlreadyReported)
+	}
+
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"murakali/config"
+	"murakali/internal/constant"
 	"murakali/internal/model"
 	"murakali/internal/module/product/delivery/body"
 	"murakali/internal/module/product/mocks"
@@ -13,6 +14,7 @@ import (
 	"murakali/pkg/postgre"
 	"murakali/pkg/response"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,11 +27,40 @@ import (
 func TestCartUseCase_UpdateProductMetadata(t *testing.T) {
 
 	testCase := []struct {
-		name        string
-		body        interface{}
-		mock        func(t *testing.T, r *mocks.Repository)
-		expectedErr error
+		name         string
+		body         interface{}
+		mock         func(t *testing.T, r *mocks.Repository)
+		expectedRows int64
+		expectedErr  error
 	}{
+		{
+			name: "success update product meta data",
+			body: nil,
+			mock: func(t *testing.T, r *mocks.Repository) {
+				count := 2
+				avg := 4.5
+				product := &model.Product{ID: uuid.Nil, ShopID: uuid.Nil}
+				r.On("GetFavoriteProduct", mock.Anything).Return([]*model.ProductFavorite{{Product: product, Count: &count}}, nil)
+				r.On("GetRatingProduct", mock.Anything).Return([]*model.ProductRating{{Product: product, Count: &count, Avg: &avg}}, nil)
+				r.On("UpdateProductFavorite", mock.Anything, mock.Anything, count).Return(nil)
+				r.On("UpdateProductRating", mock.Anything, mock.Anything, avg).Return(nil)
+				r.On("GetShopProductRating", mock.Anything, mock.Anything).Return(&model.ShopProductRating{}, nil)
+				r.On("UpdateShopProductRating", mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
+				r.On("RefreshSearchWords", mock.Anything).Return(nil)
+			},
+			expectedRows: 2,
+			expectedErr:  fmt.Errorf("test"),
+		},
+		{
+			name: "error refresh search words",
+			body: nil,
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetFavoriteProduct", mock.Anything).Return([]*model.ProductFavorite{}, nil)
+				r.On("GetRatingProduct", mock.Anything).Return([]*model.ProductRating{}, nil)
+				r.On("RefreshSearchWords", mock.Anything).Return(fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
 		{
 			name: "error update product meta data",
 			body: nil,
@@ -58,10 +89,11 @@ func TestCartUseCase_UpdateProductMetadata(t *testing.T) {
 			u := NewProductUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
 
 			tc.mock(t, r)
-			err := u.UpdateProductMetadata(context.Background())
+			report, err := u.UpdateProductMetadata(context.Background())
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
 			}
+			assert.Equal(t, tc.expectedRows, report.RowsAffected)
 		})
 	}
 }
@@ -275,6 +307,7 @@ func TestProductUseCase_GetCategoriesByName(t *testing.T) {
 
 func TestProductUseCase_GetRecommendedProducts(t *testing.T) {
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	dateString := "2021-11-23"
 	date, _ := time.Parse("2006-01-02", dateString)
 	id, _ := uuid.Parse("989d94b7-58fc-4a76-ae01-1c1b47a0755c")
@@ -299,25 +332,25 @@ func TestProductUseCase_GetRecommendedProducts(t *testing.T) {
 							UnitSold:                  10,
 							RatingAVG:                 temp,
 							ThumbnailURL:              "test",
-							MinPrice:                  temp,
-							MaxPrice:                  temp,
+							MinPrice:                  tempMoney,
+							MaxPrice:                  tempMoney,
 							ViewCount:                 10,
-							SubPrice:                  temp,
+							SubPrice:                  tempMoney,
 							PromoDiscountPercentage:   &temp,
-							PromoDiscountFixPrice:     &temp,
-							PromoMaxDiscountPrice:     &temp,
-							ResultDiscount:            &temp,
+							PromoDiscountFixPrice:     &tempMoney,
+							PromoMaxDiscountPrice:     &tempMoney,
+							ResultDiscount:            &tempMoney,
 							VoucherDiscountPercentage: &temp,
-							VoucherDiscountFixPrice:   &temp,
+							VoucherDiscountFixPrice:   &tempMoney,
 						}},
 						[]*model.Promotion{{
 							ID:                 id,
 							Name:               "test",
 							ProductID:          id,
 							DiscountPercentage: &temp,
-							DiscountFixPrice:   &temp,
-							MinProductPrice:    &temp,
-							MaxDiscountPrice:   &temp,
+							DiscountFixPrice:   &tempMoney,
+							MinProductPrice:    &tempMoney,
+							MaxDiscountPrice:   &tempMoney,
 						}}, []*model.Voucher{{ID: id, ShopID: id, Code: "test",
 							Quota: 10, ActivedDate: date, ExpiredDate: date}}, nil)
 
@@ -435,6 +468,7 @@ func TestProductUseCase_GetProductDetail(t *testing.T) {
 
 func TestProductUseCase_GetProducts(t *testing.T) {
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	dateString := "2021-11-23"
 	date, _ := time.Parse("2006-01-02", dateString)
 	id, _ := uuid.Parse("989d94b7-58fc-4a76-ae01-1c1b47a0755c")
@@ -459,31 +493,58 @@ func TestProductUseCase_GetProducts(t *testing.T) {
 							UnitSold:                  10,
 							RatingAVG:                 temp,
 							ThumbnailURL:              "test",
-							MinPrice:                  temp,
-							MaxPrice:                  temp,
+							MinPrice:                  tempMoney,
+							MaxPrice:                  tempMoney,
 							ViewCount:                 10,
-							SubPrice:                  temp,
+							SubPrice:                  tempMoney,
 							PromoDiscountPercentage:   &temp,
-							PromoDiscountFixPrice:     &temp,
-							PromoMaxDiscountPrice:     &temp,
-							ResultDiscount:            &temp,
+							PromoDiscountFixPrice:     &tempMoney,
+							PromoMaxDiscountPrice:     &tempMoney,
+							ResultDiscount:            &tempMoney,
 							VoucherDiscountPercentage: &temp,
-							VoucherDiscountFixPrice:   &temp,
+							VoucherDiscountFixPrice:   &tempMoney,
 						}},
 						[]*model.Promotion{{
 							ID:                 id,
 							Name:               "test",
 							ProductID:          id,
 							DiscountPercentage: &temp,
-							DiscountFixPrice:   &temp,
-							MinProductPrice:    &temp,
-							MaxDiscountPrice:   &temp,
+							DiscountFixPrice:   &tempMoney,
+							MinProductPrice:    &tempMoney,
+							MaxDiscountPrice:   &tempMoney,
 						}}, []*model.Voucher{{ID: id, ShopID: id, Code: "test",
 							Quota: 10, ActivedDate: date, ExpiredDate: date}}, nil)
-
+				r.On("GetProductFacetsRedis", mock.Anything, mock.Anything).Return(&body.ProductFacets{}, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "facets counted when not cached",
+			body: nil,
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetAllTotalProduct", mock.Anything, mock.Anything).Return(int64(0), nil)
+				r.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).
+					Return([]*body.Products{}, []*model.Promotion{}, []*model.Voucher{}, nil)
+				r.On("GetProductFacetsRedis", mock.Anything, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, constant.ProductFacetsKey+":")
+				})).Return(nil, fmt.Errorf("redis: nil"))
+				r.On("GetProductFacets", mock.Anything, mock.Anything).Return(&body.ProductFacets{}, nil)
+				r.On("InsertProductFacetsRedis", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "error GetProductFacets",
+			body: nil,
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetAllTotalProduct", mock.Anything, mock.Anything).Return(int64(0), nil)
+				r.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).
+					Return([]*body.Products{}, []*model.Promotion{}, []*model.Voucher{}, nil)
+				r.On("GetProductFacetsRedis", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("redis: nil"))
+				r.On("GetProductFacets", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
 		{
 			name: "get  get  product error",
 			body: nil,
@@ -521,6 +582,211 @@ func TestProductUseCase_GetProducts(t *testing.T) {
 	}
 }
 
+func TestProductUseCase_GetProductsDidYouMean(t *testing.T) {
+	testCase := []struct {
+		name               string
+		keyword            string
+		mock               func(t *testing.T, r *mocks.Repository)
+		expectedDidYouMean string
+		expectedErr        error
+	}{
+		{
+			name:    "suggest a closer spelling",
+			keyword: "  Sepatu   Lri ",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetSearchSuggestion", mock.Anything, "sepatu lri").Return("sepatu lari", nil)
+			},
+			expectedDidYouMean: "sepatu lari",
+		},
+		{
+			name:    "no suggestion when every word is known",
+			keyword: "sepatu",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetSearchSuggestion", mock.Anything, "sepatu").Return("sepatu", nil)
+			},
+			expectedDidYouMean: "",
+		},
+		{
+			name:    "error GetSearchSuggestion",
+			keyword: "sepatu",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetSearchSuggestion", mock.Anything, mock.Anything).Return("", fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := NewProductUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+
+			r.On("GetAllTotalProduct", mock.Anything, mock.Anything).Return(int64(0), nil)
+			r.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).
+				Return([]*body.Products{}, []*model.Promotion{}, []*model.Voucher{}, nil)
+			r.On("GetProductFacetsRedis", mock.Anything, mock.Anything).Return(&body.ProductFacets{}, nil)
+			tc.mock(t, r)
+
+			result, err := u.GetProducts(context.Background(), &pagination.Pagination{Limit: 12},
+				&body.GetProductQueryRequest{Keyword: tc.keyword})
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedDidYouMean, result.DidYouMean)
+			}
+		})
+	}
+}
+
+func TestProductUseCase_GetProductsHighlight(t *testing.T) {
+	r := mocks.NewRepository(t)
+	u := NewProductUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+
+	r.On("GetAllTotalProduct", mock.Anything, mock.Anything).Return(int64(1), nil)
+	r.On("GetProducts", mock.Anything, mock.Anything, mock.Anything).
+		Return([]*body.Products{{Title: "Sepatu Lari", TitleHighlight: "<mark>Sepatu</mark> Lari",
+			Snippet: "<mark>sepatu</mark> ringan"}}, []*model.Promotion{{}}, []*model.Voucher{{}}, nil)
+	r.On("GetProductFacetsRedis", mock.Anything, mock.Anything).Return(&body.ProductFacets{}, nil)
+	r.On("GetSearchSuggestion", mock.Anything, "sepatu").Return("sepatu", nil)
+
+	result, err := u.GetProducts(context.Background(), &pagination.Pagination{Limit: 12},
+		&body.GetProductQueryRequest{Keyword: "sepatu"})
+	assert.NoError(t, err)
+
+	products := result.Rows.([]*body.Products)
+	assert.Equal(t, "<mark>Sepatu</mark> Lari", products[0].TitleHighlight)
+	assert.Equal(t, "<mark>sepatu</mark> ringan", products[0].Snippet)
+}
+
+func TestProductUseCase_LogSearchQuery(t *testing.T) {
+	trendingKey := fmt.Sprintf("%s:%s", constant.SearchTrendingKey, time.Now().Format("2006-01-02"))
+	testCase := []struct {
+		name        string
+		keyword     string
+		resultCount int64
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name:        "search with results is trending",
+			keyword:     " Sepatu  Lari",
+			resultCount: 3,
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("CreateSearchQuery", mock.Anything, "sepatu lari", int64(3)).Return(nil)
+				r.On("IncrSearchTrendingRedis", mock.Anything, trendingKey, "sepatu lari").Return(nil)
+			},
+		},
+		{
+			name:        "search without results is only logged",
+			keyword:     "sepatu lari",
+			resultCount: 0,
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("CreateSearchQuery", mock.Anything, "sepatu lari", int64(0)).Return(nil)
+			},
+		},
+		{
+			name:    "empty search is not logged",
+			keyword: "  ",
+			mock:    func(t *testing.T, r *mocks.Repository) {},
+		},
+		{
+			name:        "error CreateSearchQuery",
+			keyword:     "sepatu lari",
+			resultCount: 3,
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("CreateSearchQuery", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
+		{
+			name:        "error IncrSearchTrendingRedis",
+			keyword:     "sepatu lari",
+			resultCount: 3,
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("CreateSearchQuery", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("IncrSearchTrendingRedis", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := NewProductUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+
+			tc.mock(t, r)
+			err := u.LogSearchQuery(context.Background(), tc.keyword, tc.resultCount)
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProductUseCase_GetSearchSuggestions(t *testing.T) {
+	testCase := []struct {
+		name        string
+		keyword     string
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name:    "success",
+			keyword: " Sep ",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetSearchAutocomplete", mock.Anything, "sep", constant.SearchSuggestLimit).
+					Return(&body.SearchSuggestion{}, nil)
+			},
+		},
+		{
+			name:    "keyword too short",
+			keyword: "s",
+			mock:    func(t *testing.T, r *mocks.Repository) {},
+		},
+		{
+			name:    "error GetSearchAutocomplete",
+			keyword: "sep",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetSearchAutocomplete", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := NewProductUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+
+			tc.mock(t, r)
+			result, err := u.GetSearchSuggestions(context.Background(), tc.keyword)
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			}
+		})
+	}
+}
+
+func TestProductUseCase_GetTrendingSearches(t *testing.T) {
+	r := mocks.NewRepository(t)
+	u := NewProductUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+
+	trendingKey := fmt.Sprintf("%s:%s", constant.SearchTrendingKey, time.Now().Format("2006-01-02"))
+	r.On("GetSearchTrendingRedis", mock.Anything, trendingKey, 10).
+		Return([]*body.TrendingSearch{{Query: "sepatu", Count: 4}}, nil)
+
+	result, err := u.GetTrendingSearches(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []*body.TrendingSearch{{Query: "sepatu", Count: 4}}, result)
+}
+
 func TestProductUseCase_GetAllProductImage(t *testing.T) {
 	testCase := []struct {
 		name        string
@@ -608,6 +874,7 @@ func TestProductUseCase_GetAllProductImage(t *testing.T) {
 
 func TestProductUseCase_GetFavoriteProducts(t *testing.T) {
 	var temp float64 = 10
+	tempMoney := model.NewMoney(10)
 	dateString := "2021-11-23"
 	date, _ := time.Parse("2006-01-02", dateString)
 	id, _ := uuid.Parse("989d94b7-58fc-4a76-ae01-1c1b47a0755c")
@@ -632,25 +899,25 @@ func TestProductUseCase_GetFavoriteProducts(t *testing.T) {
 							UnitSold:                  10,
 							RatingAVG:                 temp,
 							ThumbnailURL:              "test",
-							MinPrice:                  temp,
-							MaxPrice:                  temp,
+							MinPrice:                  tempMoney,
+							MaxPrice:                  tempMoney,
 							ViewCount:                 10,
-							SubPrice:                  temp,
+							SubPrice:                  tempMoney,
 							PromoDiscountPercentage:   &temp,
-							PromoDiscountFixPrice:     &temp,
-							PromoMaxDiscountPrice:     &temp,
-							ResultDiscount:            &temp,
+							PromoDiscountFixPrice:     &tempMoney,
+							PromoMaxDiscountPrice:     &tempMoney,
+							ResultDiscount:            &tempMoney,
 							VoucherDiscountPercentage: &temp,
-							VoucherDiscountFixPrice:   &temp,
+							VoucherDiscountFixPrice:   &tempMoney,
 						}},
 						[]*model.Promotion{{
 							ID:                 id,
 							Name:               "test",
 							ProductID:          id,
 							DiscountPercentage: &temp,
-							DiscountFixPrice:   &temp,
-							MinProductPrice:    &temp,
-							MaxDiscountPrice:   &temp,
+							DiscountFixPrice:   &tempMoney,
+							MinProductPrice:    &tempMoney,
+							MaxDiscountPrice:   &tempMoney,
 						}}, []*model.Voucher{{ID: id, ShopID: id, Code: "test",
 							Quota: 10, ActivedDate: date, ExpiredDate: date}}, nil)
 
@@ -995,7 +1262,7 @@ func TestCartUseCase_CreateProduct(t *testing.T) {
 					ListedStatus: true,
 				},
 				ProductDetail: []body.CreateProductDetailRequest{{
-					Price:     temp,
+					Price:     model.NewMoneyFromFloat(temp),
 					Stock:     temp,
 					Weight:    temp,
 					Size:      temp,
@@ -1031,7 +1298,7 @@ func TestCartUseCase_CreateProduct(t *testing.T) {
 					ListedStatus: true,
 				},
 				ProductDetail: []body.CreateProductDetailRequest{{
-					Price:     temp,
+					Price:     model.NewMoneyFromFloat(temp),
 					Stock:     temp,
 					Weight:    temp,
 					Size:      temp,
@@ -1062,7 +1329,7 @@ func TestCartUseCase_CreateProduct(t *testing.T) {
 					ListedStatus: true,
 				},
 				ProductDetail: []body.CreateProductDetailRequest{{
-					Price:     temp,
+					Price:     model.NewMoneyFromFloat(temp),
 					Stock:     temp,
 					Weight:    temp,
 					Size:      temp,
@@ -1103,82 +1370,53 @@ func TestCartUseCase_CreateProduct(t *testing.T) {
 }
 
 func TestAdminUC_EditBanner(t *testing.T) {
-	var temp float64 = 10
-	datestring := "02-01-2006 15:04:05"
-	date, _ := time.Parse("02-01-2006 15:04:05", datestring)
+	userID := uuid.MustParse("989d94b7-58fc-4a76-ae01-1c1b47a0755c")
+	orderItemID := uuid.MustParse("0b5e7a64-6a55-4f3a-9a2f-4f8a7a1f6c11")
 	testCase := []struct {
 		name        string
-		body        model.Voucher
 		userID      string
 		mock        func(t *testing.T, r *mocks.Repository)
 		expectedErr error
 	}{
 		{
-			name: "Delete Product successfully",
-			body: model.Voucher{
-				Code:               "123",
-				Quota:              123,
-				ActivedDate:        date,
-				ExpiredDate:        date,
-				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
-			},
+			name:   "Delete Product successfully",
+			userID: userID.String(),
 			mock: func(t *testing.T, r *mocks.Repository) {
-				r.On("FindReview", mock.Anything, mock.Anything).Return(&body.ReviewProduct{}, nil)
+				r.On("FindReview", mock.Anything, mock.Anything).Return(&body.ReviewProduct{UserID: userID, OrderItemID: &orderItemID}, nil)
 				r.On("DeleteReview", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("UpdateOrderItemIsReview", mock.Anything, mock.Anything, orderItemID.String(), false).Return(nil)
 			},
 			expectedErr: nil,
 		},
 		{
-			name: "Delete Product successfully",
-			body: model.Voucher{
-				Code:               "123",
-				Quota:              123,
-				ActivedDate:        date,
-				ExpiredDate:        date,
-				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
-			},
+			name:   "error FindReview",
+			userID: userID.String(),
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("FindReview", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
 			},
 			expectedErr: fmt.Errorf("test"),
 		},
 		{
-			name: "Delete Product successfully",
-			body: model.Voucher{
-				Code:               "123",
-				Quota:              123,
-				ActivedDate:        date,
-				ExpiredDate:        date,
-				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
-			},
+			name:   "review not exist",
+			userID: userID.String(),
 			mock: func(t *testing.T, r *mocks.Repository) {
-				r.On("FindReview", mock.Anything, mock.Anything).Return(nil, nil)
+				r.On("FindReview", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
 			},
 			expectedErr: httperror.New(http.StatusBadRequest, body.ReviewNotExist),
 		},
 		{
-			name: "Delete Product successfully",
-			body: model.Voucher{
-				Code:               "123",
-				Quota:              123,
-				ActivedDate:        date,
-				ExpiredDate:        date,
-				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+			name:   "review of another user",
+			userID: "123",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("FindReview", mock.Anything, mock.Anything).Return(&body.ReviewProduct{UserID: userID}, nil)
 			},
+			expectedErr: httperror.New(http.StatusForbidden, response.ForbiddenMessage),
+		},
+		{
+			name:   "error DeleteReview",
+			userID: userID.String(),
 			mock: func(t *testing.T, r *mocks.Repository) {
-				r.On("FindReview", mock.Anything, mock.Anything).Return(&body.ReviewProduct{}, nil)
+				r.On("FindReview", mock.Anything, mock.Anything).Return(&body.ReviewProduct{UserID: userID}, nil)
 				r.On("DeleteReview", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
 			},
 			expectedErr: fmt.Errorf("test"),
@@ -1193,75 +1431,89 @@ func TestAdminUC_EditBanner(t *testing.T) {
 			u := NewProductUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
 
 			tc.mock(t, r)
-			err := u.DeleteProductReview(context.Background(), "123", "123")
-			if err != nil {
+			err := u.DeleteProductReview(context.Background(), "123", tc.userID)
+			if tc.expectedErr != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
 			}
 		})
 	}
 }
 
 func TestAdminUC_CreateProductReview(t *testing.T) {
-	var temp float64 = 10
-	datestring := "02-01-2006 15:04:05"
-	date, _ := time.Parse("02-01-2006 15:04:05", datestring)
+	userID := uuid.MustParse("989d94b7-58fc-4a76-ae01-1c1b47a0755c")
+	productID := uuid.MustParse("0b5e7a64-6a55-4f3a-9a2f-4f8a7a1f6c11")
+	orderItem := func() *body.ReviewOrderItem {
+		return &body.ReviewOrderItem{UserID: userID, ProductID: productID, OrderStatusID: constant.OrderStatusCompleted}
+	}
 	testCase := []struct {
 		name        string
-		body        model.Voucher
 		userID      string
 		mock        func(t *testing.T, r *mocks.Repository)
 		expectedErr error
 	}{
 		{
-			name: "Delete Product successfully",
-			body: model.Voucher{
-				Code:               "123",
-				Quota:              123,
-				ActivedDate:        date,
-				ExpiredDate:        date,
-				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
-			},
+			name:   "success create review",
+			userID: userID.String(),
 			mock: func(t *testing.T, r *mocks.Repository) {
-				r.On("GetProductReviews", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*body.ReviewProduct{}, nil)
+				r.On("GetOrderItemForReview", mock.Anything, mock.Anything, mock.Anything).Return(orderItem(), nil)
 				r.On("CreateProductReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("UpdateOrderItemIsReview", mock.Anything, mock.Anything, mock.Anything, true).Return(nil)
 			},
 			expectedErr: nil,
 		},
 		{
-			name: "Delete Product successfully",
-			body: model.Voucher{
-				Code:               "123",
-				Quota:              123,
-				ActivedDate:        date,
-				ExpiredDate:        date,
-				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+			name:   "order item not exist",
+			userID: userID.String(),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetOrderItemForReview", mock.Anything, mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
 			},
+			expectedErr: httperror.New(http.StatusBadRequest, body.ReviewOrderItemNotExist),
+		},
+		{
+			name:   "order item of another user",
+			userID: "123",
 			mock: func(t *testing.T, r *mocks.Repository) {
-				r.On("GetProductReviews", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*body.ReviewProduct{{}, {}}, nil)
+				r.On("GetOrderItemForReview", mock.Anything, mock.Anything, mock.Anything).Return(orderItem(), nil)
 			},
-			expectedErr: httperror.New(http.StatusBadRequest, body.ReviewAlreadyExist),
+			expectedErr: httperror.New(http.StatusForbidden, response.ForbiddenMessage),
 		},
 		{
-			name: "Delete Product successfully",
-			body: model.Voucher{
-				Code:               "123",
-				Quota:              123,
-				ActivedDate:        date,
-				ExpiredDate:        date,
-				DiscountPercentage: &temp,
-				DiscountFixPrice:   &temp,
-				MinProductPrice:    &temp,
-				MaxDiscountPrice:   &temp,
+			name:   "order not completed",
+			userID: userID.String(),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				item := orderItem()
+				item.OrderStatusID = constant.OrderStatusOnDelivery
+				r.On("GetOrderItemForReview", mock.Anything, mock.Anything, mock.Anything).Return(item, nil)
 			},
-
+			expectedErr: httperror.New(http.StatusBadRequest, body.ReviewOrderNotCompleted),
+		},
+		{
+			name:   "product not in order item",
+			userID: userID.String(),
 			mock: func(t *testing.T, r *mocks.Repository) {
-				r.On("GetProductReviews", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*body.ReviewProduct{}, nil)
+				item := orderItem()
+				item.ProductID = uuid.Nil
+				r.On("GetOrderItemForReview", mock.Anything, mock.Anything, mock.Anything).Return(item, nil)
+			},
+			expectedErr: httperror.New(http.StatusBadRequest, body.ReviewProductNotInOrder),
+		},
+		{
+			name:   "order item already reviewed",
+			userID: userID.String(),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				item := orderItem()
+				item.IsReview = true
+				r.On("GetOrderItemForReview", mock.Anything, mock.Anything, mock.Anything).Return(item, nil)
+			},
+			expectedErr: httperror.New(http.StatusBadRequest, body.ReviewAlreadyExist),
+		},
+		{
+			name:   "error CreateProductReview",
+			userID: userID.String(),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetOrderItemForReview", mock.Anything, mock.Anything, mock.Anything).Return(orderItem(), nil)
 				r.On("CreateProductReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
 			},
 			expectedErr: fmt.Errorf("test"),
@@ -1276,9 +1528,11 @@ func TestAdminUC_CreateProductReview(t *testing.T) {
 			u := NewProductUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
 
 			tc.mock(t, r)
-			err := u.CreateProductReview(context.Background(), body.ReviewProductRequest{}, "123")
-			if err != nil {
+			err := u.CreateProductReview(context.Background(), body.ReviewProductRequest{ProductID: productID.String()}, tc.userID)
+			if tc.expectedErr != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
 			}
 		})
 	}
@@ -1458,3 +1712,223 @@ func TestAdminUC_UpdateListedStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestProductUC_ReplyProductReview(t *testing.T) {
+	testCase := []struct {
+		name        string
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name: "success reply review",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetShopIDByUserID", mock.Anything, mock.Anything).Return("shop", nil)
+				r.On("GetReviewShopID", mock.Anything, mock.Anything).Return("shop", nil)
+				r.On("UpsertReviewReply", mock.Anything, "review", "shop", "thanks").Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "user without shop",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetShopIDByUserID", mock.Anything, mock.Anything).Return("", sql.ErrNoRows)
+			},
+			expectedErr: httperror.New(http.StatusForbidden, response.ForbiddenMessage),
+		},
+		{
+			name: "review not exist",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetShopIDByUserID", mock.Anything, mock.Anything).Return("shop", nil)
+				r.On("GetReviewShopID", mock.Anything, mock.Anything).Return("", sql.ErrNoRows)
+			},
+			expectedErr: httperror.New(http.StatusBadRequest, body.ReviewNotExist),
+		},
+		{
+			name: "review of another shop",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetShopIDByUserID", mock.Anything, mock.Anything).Return("shop", nil)
+				r.On("GetReviewShopID", mock.Anything, mock.Anything).Return("other", nil)
+			},
+			expectedErr: httperror.New(http.StatusForbidden, response.ForbiddenMessage),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := NewProductUseCase(&config.Config{}, nil, r)
+
+			tc.mock(t, r)
+			err := u.ReplyProductReview(context.Background(), "review", "user", body.ReviewReplyRequest{Comment: "thanks"})
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProductUC_VoteProductReview(t *testing.T) {
+	userID := uuid.MustParse("989d94b7-58fc-4a76-ae01-1c1b47a0755c")
+	helpful := true
+	testCase := []struct {
+		name        string
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name: "success vote review",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("FindReview", mock.Anything, mock.Anything).Return(&body.ReviewProduct{UserID: uuid.Nil}, nil)
+				r.On("UpsertReviewVote", mock.Anything, mock.Anything, "review", userID.String(), true).Return(nil)
+				r.On("UpdateReviewVoteCount", mock.Anything, mock.Anything, "review").Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "review not exist",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("FindReview", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+			},
+			expectedErr: httperror.New(http.StatusBadRequest, body.ReviewNotExist),
+		},
+		{
+			name: "vote own review",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("FindReview", mock.Anything, mock.Anything).Return(&body.ReviewProduct{UserID: userID}, nil)
+			},
+			expectedErr: httperror.New(http.StatusBadRequest, body.ReviewVoteOwn),
+		},
+		{
+			name: "error UpsertReviewVote",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("FindReview", mock.Anything, mock.Anything).Return(&body.ReviewProduct{UserID: uuid.Nil}, nil)
+				r.On("UpsertReviewVote", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, mock, _ := sqlmock.New()
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			r := mocks.NewRepository(t)
+			u := NewProductUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+
+			tc.mock(t, r)
+			err := u.VoteProductReview(context.Background(), "review", userID.String(), body.ReviewVoteRequest{IsHelpful: &helpful})
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProductUC_DeleteProductReviewVote(t *testing.T) {
+	testCase := []struct {
+		name        string
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name: "success delete vote",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("DeleteReviewVote", mock.Anything, mock.Anything, "review", "user").Return(int64(1), nil)
+				r.On("UpdateReviewVoteCount", mock.Anything, mock.Anything, "review").Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "vote not exist",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("DeleteReviewVote", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(0), nil)
+			},
+			expectedErr: httperror.New(http.StatusBadRequest, body.ReviewVoteNotExist),
+		},
+		{
+			name: "error DeleteReviewVote",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("DeleteReviewVote", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(0), fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, mock, _ := sqlmock.New()
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			r := mocks.NewRepository(t)
+			u := NewProductUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+
+			tc.mock(t, r)
+			err := u.DeleteProductReviewVote(context.Background(), "review", "user")
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProductUC_ReportProductReview(t *testing.T) {
+	userID := uuid.MustParse("989d94b7-58fc-4a76-ae01-1c1b47a0755c")
+	testCase := []struct {
+		name        string
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name: "success report review",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("FindReview", mock.Anything, mock.Anything).Return(&body.ReviewProduct{UserID: uuid.Nil}, nil)
+				r.On("CreateReviewReport", mock.Anything, "review", userID.String(), "spam").Return(int64(1), nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "report own review",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("FindReview", mock.Anything, mock.Anything).Return(&body.ReviewProduct{UserID: userID}, nil)
+			},
+			expectedErr: httperror.New(http.StatusBadRequest, body.ReviewReportOwn),
+		},
+		{
+			name: "review already reported",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("FindReview", mock.Anything, mock.Anything).Return(&body.ReviewProduct{UserID: uuid.Nil}, nil)
+				r.On("CreateReviewReport", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(0), nil)
+			},
+			expectedErr: httperror.New(http.StatusBadRequest, body.ReviewAlreadyReported),
+		},
+		{
+			name: "error FindReview",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("FindReview", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("test"))
+			},
+			expectedErr: fmt.Errorf("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := NewProductUseCase(&config.Config{}, nil, r)
+
+			tc.mock(t, r)
+			err := u.ReportProductReview(context.Background(), "review", userID.String(), body.ReportReviewRequest{Reason: "spam"})
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
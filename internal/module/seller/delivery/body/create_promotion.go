@@ -1,6 +1,7 @@
 package body
 
 import (
+	"murakali/internal/model"
 	"murakali/pkg/httperror"
 	"murakali/pkg/response"
 	"net/http"
@@ -18,13 +19,13 @@ type CreatePromotionRequest struct {
 }
 
 type ProductPromotionData struct {
-	ProductID          string  `json:"product_id"`
-	Quota              int     `json:"quota"`
-	MaxQuantity        int     `json:"max_quantity"`
-	DiscountPercentage float64 `json:"discount_percentage"`
-	DiscountFixPrice   float64 `json:"discount_fix_price"`
-	MinProductPrice    float64 `json:"min_product_price"`
-	MaxDiscountPrice   float64 `json:"max_discount_price"`
+	ProductID          string      `json:"product_id"`
+	Quota              int         `json:"quota"`
+	MaxQuantity        int         `json:"max_quantity"`
+	DiscountPercentage float64     `json:"discount_percentage"`
+	DiscountFixPrice   model.Money `json:"discount_fix_price"`
+	MinProductPrice    model.Money `json:"min_product_price"`
+	MaxDiscountPrice   model.Money `json:"max_discount_price"`
 }
 
 type ProductPromotion struct {
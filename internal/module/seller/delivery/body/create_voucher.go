@@ -1,6 +1,8 @@
 package body
 
 import (
+	"murakali/internal/constant"
+	"murakali/internal/model"
 	"murakali/pkg/httperror"
 	"murakali/pkg/response"
 	"net/http"
@@ -9,14 +11,15 @@ import (
 )
 
 type CreateVoucherRequest struct {
-	Code               string  `json:"code"`
-	Quota              int     `json:"quota"`
-	ActivedDate        string  `json:"actived_date"`
-	ExpiredDate        string  `json:"expired_date"`
-	DiscountPercentage float64 `json:"discount_percentage"`
-	DiscountFixPrice   float64 `json:"discount_fix_price"`
-	MinProductPrice    float64 `json:"min_product_price"`
-	MaxDiscountPrice   float64 `json:"max_discount_price"`
+	Code               string      `json:"code"`
+	Quota              int         `json:"quota"`
+	ActivedDate        string      `json:"actived_date"`
+	ExpiredDate        string      `json:"expired_date"`
+	DiscountPercentage float64     `json:"discount_percentage"`
+	DiscountFixPrice   model.Money `json:"discount_fix_price"`
+	MinProductPrice    model.Money `json:"min_product_price"`
+	MaxDiscountPrice   model.Money `json:"max_discount_price"`
+	LimitPerUser       int         `json:"limit_per_user"`
 
 	ActiveDateTime  time.Time
 	ExpiredDateTime time.Time
@@ -33,6 +36,7 @@ func (r *CreateVoucherRequest) Validate() (UnprocessableEntity, error) {
 			"discount_fix_price":  "",
 			"min_product_price":   "",
 			"max_discount_price":  "",
+			"limit_per_user":      "",
 		},
 	}
 
@@ -47,6 +51,15 @@ func (r *CreateVoucherRequest) Validate() (UnprocessableEntity, error) {
 		entity.Fields["quota"] = FieldCannotBeEmptyMessage
 	}
 
+	if r.LimitPerUser < 0 {
+		unprocessableEntity = true
+		entity.Fields["limit_per_user"] = InvalidLimitPerUserMessage
+	}
+
+	if r.LimitPerUser == 0 {
+		r.LimitPerUser = constant.VoucherLimitPerUserDefault
+	}
+
 	activeTime, err := time.Parse("02-01-2006 15:04:05", r.ActivedDate)
 	if err != nil {
 		unprocessableEntity = true
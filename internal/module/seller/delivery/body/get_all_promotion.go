@@ -2,6 +2,7 @@ package body
 
 import (
 	"database/sql"
+	"murakali/internal/model"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,9 +15,9 @@ type PromotionSellerResponse struct {
 	ProductName         string       `json:"product_name"`
 	ProductThumbnailURL *string      `json:"product_thumbnail_url"`
 	DiscountPercentage  *float64     `json:"discount_percentage"`
-	DiscountFixPrice    *float64     `json:"discount_fix_price"`
-	MinProductPrice     *float64     `json:"min_product_price"`
-	MaxDiscountPrice    *float64     `json:"max_discount_price"`
+	DiscountFixPrice    *model.Money `json:"discount_fix_price"`
+	MinProductPrice     *model.Money `json:"min_product_price"`
+	MaxDiscountPrice    *model.Money `json:"max_discount_price"`
 	Quota               int          `json:"quota"`
 	MaxQuantity         int          `json:"max_quantity"`
 	ActivedDate         time.Time    `json:"actived_date"`
@@ -2,6 +2,7 @@ package body
 
 import (
 	"database/sql"
+	"murakali/internal/model"
 	"time"
 )
 
@@ -10,19 +11,19 @@ type PromotionDetailSeller struct {
 	PromotionName           string       `json:"promotion_name"`
 	ProductID               string       `json:"product_id"`
 	ProductName             string       `json:"product_name"`
-	MinPrice                float64      `json:"min_price"`
-	MaxPrice                float64      `json:"max_price"`
+	MinPrice                model.Money  `json:"min_price"`
+	MaxPrice                model.Money  `json:"max_price"`
 	ProductThumbnailURL     string       `json:"product_thumbnail_url"`
 	DiscountPercentage      *float64     `json:"discount_percentage"`
-	DiscountFixPrice        *float64     `json:"discount_fix_price"`
-	MinProductPrice         *float64     `json:"min_product_price"`
-	MaxDiscountPrice        *float64     `json:"max_discount_price"`
+	DiscountFixPrice        *model.Money `json:"discount_fix_price"`
+	MinProductPrice         *model.Money `json:"min_product_price"`
+	MaxDiscountPrice        *model.Money `json:"max_discount_price"`
 	Quota                   int          `json:"quota"`
 	MaxQuantity             int          `json:"max_quantity"`
-	ProductSubMinPrice      float64      `json:"product_sub_min_price"`
-	ProductSubMaxPrice      float64      `json:"product_sub_max_price"`
-	ProductMinDiscountPrice float64      `json:"product_min_discount_price"`
-	ProductMaxDiscountPrice float64      `json:"product_max_discount_price"`
+	ProductSubMinPrice      model.Money  `json:"product_sub_min_price"`
+	ProductSubMaxPrice      model.Money  `json:"product_sub_max_price"`
+	ProductMinDiscountPrice model.Money  `json:"product_min_discount_price"`
+	ProductMaxDiscountPrice model.Money  `json:"product_max_discount_price"`
 	ActivedDate             time.Time    `json:"actived_date"`
 	ExpiredDate             time.Time    `json:"expired_date"`
 	CreatedAt               time.Time    `json:"created_at"`
@@ -8,6 +8,7 @@ const (
 	InvalidDateFormatMessage          = "Invalid date format."
 	InvalidBirthDateAfterTodayMassage = "Birth Date should be in past."
 	InvalidOTPFormatMessage           = "Invalid OTP."
+	InvalidLimitPerUserMessage        = "Limit per user cannot be negative."
 	InvalidPasswordFormatMessage      = "Password must contain at least 8-40 characters," +
 		"at least 1 number, 1 Upper case, 1 special character, and not contains username"
 	InvalidPasswordSameOldPasswordMessage      = "Your new password cannot be the same as your old password."
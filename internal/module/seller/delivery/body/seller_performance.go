@@ -1,5 +1,7 @@
 package body
 
+import "murakali/internal/model"
+
 type SellerPerformance struct {
 	ShopID             string                `json:"shop_id" db:"shop_id"`
 	ShopName           string                `json:"shop_name" db:"shop_name"`
@@ -15,8 +17,8 @@ type SellerPerformance struct {
 }
 
 type DailySales struct {
-	Date       string  `json:"date" db:"date"`
-	TotalSales float64 `json:"total_sales" db:"total_sales"`
+	Date       string      `json:"date" db:"date"`
+	TotalSales model.Money `json:"total_sales" db:"total_sales"`
 }
 
 type DailyOrder struct {
@@ -55,7 +57,7 @@ type NumOrderByProvince struct {
 }
 
 type TotalSales struct {
-	TotalSales      float64 `json:"total_sales" db:"total_sales"`
-	WithdrawableSum float64 `json:"withdrawable_sum" db:"withdrawable_sum"`
-	WithdrawnSum    float64 `json:"withdrawn_sum" db:"withdrawn_sum"`
+	TotalSales      model.Money `json:"total_sales" db:"total_sales"`
+	WithdrawableSum model.Money `json:"withdrawable_sum" db:"withdrawable_sum"`
+	WithdrawnSum    model.Money `json:"withdrawn_sum" db:"withdrawn_sum"`
 }
@@ -1,6 +1,7 @@
 package body
 
 import (
+	"murakali/internal/model"
 	"murakali/pkg/httperror"
 	"murakali/pkg/response"
 	"net/http"
@@ -9,16 +10,16 @@ import (
 )
 
 type UpdatePromotionRequest struct {
-	PromotionID        string  `json:"promotion_id"`
-	ProductID          string  `json:"product_id"`
-	PromotionName      string  `json:"promotion_name"`
-	MaxQuantity        int     `json:"max_quantity"`
-	ActivedDate        string  `json:"actived_date"`
-	ExpiredDate        string  `json:"expired_date"`
-	DiscountPercentage float64 `json:"discount_percentage"`
-	DiscountFixPrice   float64 `json:"discount_fix_price"`
-	MinProductPrice    float64 `json:"min_product_price"`
-	MaxDiscountPrice   float64 `json:"max_discount_price"`
+	PromotionID        string      `json:"promotion_id"`
+	ProductID          string      `json:"product_id"`
+	PromotionName      string      `json:"promotion_name"`
+	MaxQuantity        int         `json:"max_quantity"`
+	ActivedDate        string      `json:"actived_date"`
+	ExpiredDate        string      `json:"expired_date"`
+	DiscountPercentage float64     `json:"discount_percentage"`
+	DiscountFixPrice   model.Money `json:"discount_fix_price"`
+	MinProductPrice    model.Money `json:"min_product_price"`
+	MaxDiscountPrice   model.Money `json:"max_discount_price"`
 
 	ActiveDateTime  time.Time
 	ExpiredDateTime time.Time
@@ -700,35 +700,39 @@ func (h *sellerHandlers) UpdateVoucherSeller(c *gin.Context) {
 }
 
 func (h *sellerHandlers) UpdateOnDeliveryOrder(c *gin.Context) {
-	if err := h.sellerUC.UpdateOnDeliveryOrder(c); err != nil {
+	report, err := h.sellerUC.UpdateOnDeliveryOrder(c)
+	if err != nil {
 		var e *httperror.Error
 		if !errors.As(err, &e) {
 			h.logger.Errorf("HandlerSeller, Error: %s", err)
-			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			response.ErrorResponseData(c.Writer, report, response.InternalServerErrorMessage, http.StatusInternalServerError)
 			return
 		}
 
-		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		response.ErrorResponseData(c.Writer, report, e.Err.Error(), e.Status)
 		return
 	}
 
-	response.SuccessResponse(c.Writer, nil, http.StatusOK)
+	h.logger.Infof("job %s affected %d rows in %dms", report.Job, report.RowsAffected, report.DurationMs)
+	response.SuccessResponse(c.Writer, report, http.StatusOK)
 }
 
 func (h *sellerHandlers) UpdateExpiredAtOrder(c *gin.Context) {
-	if err := h.sellerUC.UpdateExpiredAtOrder(c); err != nil {
+	report, err := h.sellerUC.UpdateExpiredAtOrder(c)
+	if err != nil {
 		var e *httperror.Error
 		if !errors.As(err, &e) {
 			h.logger.Errorf("HandlerSeller, Error: %s", err)
-			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			response.ErrorResponseData(c.Writer, report, response.InternalServerErrorMessage, http.StatusInternalServerError)
 			return
 		}
 
-		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		response.ErrorResponseData(c.Writer, report, e.Err.Error(), e.Status)
 		return
 	}
 
-	response.SuccessResponse(c.Writer, nil, http.StatusOK)
+	h.logger.Infof("job %s affected %d rows in %dms", report.Job, report.RowsAffected, report.DurationMs)
+	response.SuccessResponse(c.Writer, report, http.StatusOK)
 }
 
 func (h *sellerHandlers) DetailVoucherSeller(c *gin.Context) {
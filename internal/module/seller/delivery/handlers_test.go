@@ -2073,21 +2073,21 @@ func Test_sellerHandlers_UpdateOnDeliveryOrder(t *testing.T) {
 		{
 			name: "Success Update On Delivery Order",
 			mock: func(s *mocks.UseCase) {
-				s.On("UpdateOnDeliveryOrder", mock.Anything).Return(nil)
+				s.On("UpdateOnDeliveryOrder", mock.Anything).Return(&model.JobReport{}, nil)
 			},
 			expected: http.StatusOK,
 		},
 		{
 			name: "Failed Update On Delivery Order",
 			mock: func(s *mocks.UseCase) {
-				s.On("UpdateOnDeliveryOrder", mock.Anything).Return(errors.New("error"))
+				s.On("UpdateOnDeliveryOrder", mock.Anything).Return(&model.JobReport{}, errors.New("error"))
 			},
 			expected: http.StatusInternalServerError,
 		},
 		{
 			name: "Failed Update On Delivery Order HTTP ERROR",
 			mock: func(s *mocks.UseCase) {
-				s.On("UpdateOnDeliveryOrder", mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
+				s.On("UpdateOnDeliveryOrder", mock.Anything).Return(&model.JobReport{}, httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected: http.StatusBadRequest,
 		},
@@ -2138,21 +2138,21 @@ func Test_sellerHandlers_UpdateExpiredAtOrder(t *testing.T) {
 		{
 			name: "Success Update On Delivery Order",
 			mock: func(s *mocks.UseCase) {
-				s.On("UpdateExpiredAtOrder", mock.Anything).Return(nil)
+				s.On("UpdateExpiredAtOrder", mock.Anything).Return(&model.JobReport{}, nil)
 			},
 			expected: http.StatusOK,
 		},
 		{
 			name: "Failed Update On Delivery Order",
 			mock: func(s *mocks.UseCase) {
-				s.On("UpdateExpiredAtOrder", mock.Anything).Return(errors.New("error"))
+				s.On("UpdateExpiredAtOrder", mock.Anything).Return(&model.JobReport{}, errors.New("error"))
 			},
 			expected: http.StatusInternalServerError,
 		},
 		{
 			name: "Failed Update On Delivery Order HTTP ERROR",
 			mock: func(s *mocks.UseCase) {
-				s.On("UpdateExpiredAtOrder", mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
+				s.On("UpdateExpiredAtOrder", mock.Anything).Return(&model.JobReport{}, httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected: http.StatusBadRequest,
 		},
@@ -1,6 +1,7 @@
 package delivery
 
 import (
+	"murakali/internal/constant"
 	"murakali/internal/middleware"
 	"murakali/internal/module/seller"
 
@@ -11,36 +12,43 @@ func MapSellerRoutes(sellerGroup *gin.RouterGroup, h seller.Handlers, mw *middle
 	sellerGroup.GET("/", h.GetAllSeller)
 	sellerGroup.GET("/:seller_id", h.GetSellerBySellerID)
 	sellerGroup.GET("/:seller_id/category", h.GetCategoryBySellerID)
-	sellerGroup.POST("/delivery", h.UpdateOnDeliveryOrder)
-	sellerGroup.POST("/expired", h.UpdateExpiredAtOrder)
+	sellerGroup.POST("/delivery", mw.JobAuthMiddleware(), h.UpdateOnDeliveryOrder)
+	sellerGroup.POST("/expired", mw.JobAuthMiddleware(), h.UpdateExpiredAtOrder)
 
 	sellerGroup.Use(mw.AuthJWTMiddleware())
-	sellerGroup.Use(mw.SellerJWTMiddleware())
-	sellerGroup.GET("/performance", h.GetPerformance)
-	sellerGroup.GET("/information", h.GetSellerDetailInformation)
-	sellerGroup.PATCH("/information", h.UpdateSellerInformation)
-	sellerGroup.GET("/user/:user_id", h.GetSellerByUserID)
-	sellerGroup.GET("/order", h.GetOrder)
-	sellerGroup.GET("/order/:order_id", h.GetOrderByOrderID)
-	sellerGroup.PATCH("/order-status", h.ChangeOrderStatus)
-	sellerGroup.PATCH("/order-cancel", h.CancelOrderStatus)
-	sellerGroup.GET("/courier", h.GetCourierSeller)
-	sellerGroup.POST("/courier", h.CreateCourierSeller)
-	sellerGroup.DELETE("/courier/:id", h.DeleteCourierSellerByID)
-	sellerGroup.PATCH("/order-resi/:id", h.UpdateResiNumberInOrderSeller)
-	sellerGroup.POST("/withdrawal/:id", h.WithdrawalOrderBalance)
-	sellerGroup.GET("/voucher", h.GetAllVoucherSeller)
-	sellerGroup.POST("/voucher", h.CreateVoucherSeller)
-	sellerGroup.PUT("/voucher", h.UpdateVoucherSeller)
-	sellerGroup.GET("/voucher/:id", h.DetailVoucherSeller)
-	sellerGroup.DELETE("/voucher/:id", h.DeleteVoucherSeller)
-	sellerGroup.GET("/product/without-promotion", h.GetProductWithoutPromotionSeller)
-	sellerGroup.GET("/promotion", h.GetAllPromotionSeller)
-	sellerGroup.POST("/promotion", h.CreatePromotionSeller)
-	sellerGroup.PUT("/promotion", h.UpdatePromotionSeller)
-	sellerGroup.GET("/promotion/:id", h.GetDetailPromotionSellerByID)
-	sellerGroup.GET("/refund/:refund_id", h.GetRefundOrderSeller)
-	sellerGroup.POST("/refund-thread", h.CreateRefundThreadSeller)
-	sellerGroup.PATCH("/refund-accept", h.UpdateRefundAccept)
-	sellerGroup.PATCH("/refund-reject", h.UpdateRefundReject)
+
+	shop := mw.RequirePermission(constant.PermissionShopManage)
+	sellerGroup.GET("/performance", shop, h.GetPerformance)
+	sellerGroup.GET("/information", shop, h.GetSellerDetailInformation)
+	sellerGroup.PATCH("/information", shop, h.UpdateSellerInformation)
+	sellerGroup.GET("/user/:user_id", shop, h.GetSellerByUserID)
+	sellerGroup.GET("/courier", shop, h.GetCourierSeller)
+	sellerGroup.POST("/courier", shop, h.CreateCourierSeller)
+	sellerGroup.DELETE("/courier/:id", shop, h.DeleteCourierSellerByID)
+	sellerGroup.POST("/withdrawal/:id", shop, h.WithdrawalOrderBalance)
+
+	order := mw.RequirePermission(constant.PermissionOrderManage)
+	sellerGroup.GET("/order", order, h.GetOrder)
+	sellerGroup.GET("/order/:order_id", order, h.GetOrderByOrderID)
+	sellerGroup.PATCH("/order-status", order, h.ChangeOrderStatus)
+	sellerGroup.PATCH("/order-cancel", order, h.CancelOrderStatus)
+	sellerGroup.PATCH("/order-resi/:id", order, h.UpdateResiNumberInOrderSeller)
+
+	promotion := mw.RequirePermission(constant.PermissionPromotionManage)
+	sellerGroup.GET("/voucher", promotion, h.GetAllVoucherSeller)
+	sellerGroup.POST("/voucher", promotion, h.CreateVoucherSeller)
+	sellerGroup.PUT("/voucher", promotion, h.UpdateVoucherSeller)
+	sellerGroup.GET("/voucher/:id", promotion, h.DetailVoucherSeller)
+	sellerGroup.DELETE("/voucher/:id", promotion, h.DeleteVoucherSeller)
+	sellerGroup.GET("/product/without-promotion", promotion, h.GetProductWithoutPromotionSeller)
+	sellerGroup.GET("/promotion", promotion, h.GetAllPromotionSeller)
+	sellerGroup.POST("/promotion", promotion, h.CreatePromotionSeller)
+	sellerGroup.PUT("/promotion", promotion, h.UpdatePromotionSeller)
+	sellerGroup.GET("/promotion/:id", promotion, h.GetDetailPromotionSellerByID)
+
+	refund := mw.RequirePermission(constant.PermissionRefundRespond)
+	sellerGroup.GET("/refund/:refund_id", refund, h.GetRefundOrderSeller)
+	sellerGroup.POST("/refund-thread", refund, h.CreateRefundThreadSeller)
+	sellerGroup.PATCH("/refund-accept", refund, h.UpdateRefundAccept)
+	sellerGroup.PATCH("/refund-reject", refund, h.UpdateRefundReject)
 }
@@ -22,6 +22,27 @@ type Repository struct {
 	mock.Mock
 }
 
+// CancelExpiredTransaction provides a mock function with given fields: ctx, tx, transactionID
+func (_m *Repository) CancelExpiredTransaction(ctx context.Context, tx postgre.Transaction, transactionID string) (int64, error) {
+	ret := _m.Called(ctx, tx, transactionID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) int64); ok {
+		r0 = rf(ctx, tx, transactionID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string) error); ok {
+		r1 = rf(ctx, tx, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CancelOrderStatus provides a mock function with given fields: ctx, tx, requestBody
 func (_m *Repository) CancelOrderStatus(ctx context.Context, tx postgre.Transaction, requestBody body.CancelOrderStatus) error {
 	ret := _m.Called(ctx, tx, requestBody)
@@ -36,13 +57,13 @@ func (_m *Repository) CancelOrderStatus(ctx context.Context, tx postgre.Transact
 	return r0
 }
 
-// ChangeOrderStatus provides a mock function with given fields: ctx, requestBody
-func (_m *Repository) ChangeOrderStatus(ctx context.Context, requestBody body.ChangeOrderStatusRequest) error {
-	ret := _m.Called(ctx, requestBody)
+// ChangeOrderStatus provides a mock function with given fields: ctx, tx, requestBody
+func (_m *Repository) ChangeOrderStatus(ctx context.Context, tx postgre.Transaction, requestBody body.ChangeOrderStatusRequest) error {
+	ret := _m.Called(ctx, tx, requestBody)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, body.ChangeOrderStatusRequest) error); ok {
-		r0 = rf(ctx, requestBody)
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, body.ChangeOrderStatusRequest) error); ok {
+		r0 = rf(ctx, tx, requestBody)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -113,13 +134,13 @@ func (_m *Repository) CreateRefundSeller(ctx context.Context, tx postgre.Transac
 	return r0
 }
 
-// CreateRefundThreadSeller provides a mock function with given fields: ctx, refundThreadData
-func (_m *Repository) CreateRefundThreadSeller(ctx context.Context, refundThreadData *model.RefundThread) error {
-	ret := _m.Called(ctx, refundThreadData)
+// CreateRefundThreadSeller provides a mock function with given fields: ctx, tx, refundThreadData
+func (_m *Repository) CreateRefundThreadSeller(ctx context.Context, tx postgre.Transaction, refundThreadData *model.RefundThread) error {
+	ret := _m.Called(ctx, tx, refundThreadData)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *model.RefundThread) error); ok {
-		r0 = rf(ctx, refundThreadData)
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.RefundThread) error); ok {
+		r0 = rf(ctx, tx, refundThreadData)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -552,29 +573,6 @@ func (_m *Repository) GetOrderByTransactionID(ctx context.Context, tx postgre.Tr
 	return r0, r1
 }
 
-// GetOrderItemsByOrderID provides a mock function with given fields: ctx, tx, orderID
-func (_m *Repository) GetOrderItemsByOrderID(ctx context.Context, tx postgre.Transaction, orderID string) ([]*model.OrderItem, error) {
-	ret := _m.Called(ctx, tx, orderID)
-
-	var r0 []*model.OrderItem
-	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) []*model.OrderItem); ok {
-		r0 = rf(ctx, tx, orderID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.OrderItem)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string) error); ok {
-		r1 = rf(ctx, tx, orderID)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // GetOrderModelByID provides a mock function with given fields: ctx, OrderID
 func (_m *Repository) GetOrderModelByID(ctx context.Context, OrderID string) (*model.OrderModel, error) {
 	ret := _m.Called(ctx, OrderID)
@@ -690,29 +688,6 @@ func (_m *Repository) GetPerformance(ctx context.Context, shopID string) (*body.
 	return r0, r1
 }
 
-// GetProductDetailByID provides a mock function with given fields: ctx, tx, productDetailID
-func (_m *Repository) GetProductDetailByID(ctx context.Context, tx postgre.Transaction, productDetailID string) (*model.ProductDetail, error) {
-	ret := _m.Called(ctx, tx, productDetailID)
-
-	var r0 *model.ProductDetail
-	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) *model.ProductDetail); ok {
-		r0 = rf(ctx, tx, productDetailID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.ProductDetail)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string) error); ok {
-		r1 = rf(ctx, tx, productDetailID)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // GetProductPromotion provides a mock function with given fields: ctx, shopProduct
 func (_m *Repository) GetProductPromotion(ctx context.Context, shopProduct *body.ShopProduct) (*body.ProductPromotion, error) {
 	ret := _m.Called(ctx, shopProduct)
@@ -1220,6 +1195,111 @@ func (_m *Repository) InsertWalletHistory(ctx context.Context, tx postgre.Transa
 	return r0
 }
 
+// ReleaseOrderPromotionClaims provides a mock function with given fields: ctx, tx, orderID
+func (_m *Repository) ReleaseOrderPromotionClaims(ctx context.Context, tx postgre.Transaction, orderID string) (int64, error) {
+	ret := _m.Called(ctx, tx, orderID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) int64); ok {
+		r0 = rf(ctx, tx, orderID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string) error); ok {
+		r1 = rf(ctx, tx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseOrderVoucherRedemptions provides a mock function with given fields: ctx, tx, orderID
+func (_m *Repository) ReleaseOrderVoucherRedemptions(ctx context.Context, tx postgre.Transaction, orderID string) (int64, error) {
+	ret := _m.Called(ctx, tx, orderID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) int64); ok {
+		r0 = rf(ctx, tx, orderID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string) error); ok {
+		r1 = rf(ctx, tx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleasePromotionClaims provides a mock function with given fields: ctx, tx, transactionID
+func (_m *Repository) ReleasePromotionClaims(ctx context.Context, tx postgre.Transaction, transactionID string) (int64, error) {
+	ret := _m.Called(ctx, tx, transactionID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) int64); ok {
+		r0 = rf(ctx, tx, transactionID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string) error); ok {
+		r1 = rf(ctx, tx, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseStockReservations provides a mock function with given fields: ctx, tx, orderID
+func (_m *Repository) ReleaseStockReservations(ctx context.Context, tx postgre.Transaction, orderID string) (int64, error) {
+	ret := _m.Called(ctx, tx, orderID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) int64); ok {
+		r0 = rf(ctx, tx, orderID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string) error); ok {
+		r1 = rf(ctx, tx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseVoucherRedemptions provides a mock function with given fields: ctx, tx, transactionID
+func (_m *Repository) ReleaseVoucherRedemptions(ctx context.Context, tx postgre.Transaction, transactionID string) (int64, error) {
+	ret := _m.Called(ctx, tx, transactionID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) int64); ok {
+		r0 = rf(ctx, tx, transactionID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string) error); ok {
+		r1 = rf(ctx, tx, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateCourierSellerByID provides a mock function with given fields: ctx, shopID, courierID
 func (_m *Repository) UpdateCourierSellerByID(ctx context.Context, shopID string, courierID string) error {
 	ret := _m.Called(ctx, shopID, courierID)
@@ -1262,20 +1342,6 @@ func (_m *Repository) UpdateOrderRefundRejected(ctx context.Context, tx postgre.
 	return r0
 }
 
-// UpdateProductDetailStock provides a mock function with given fields: ctx, tx, productDetailData
-func (_m *Repository) UpdateProductDetailStock(ctx context.Context, tx postgre.Transaction, productDetailData *model.ProductDetail) error {
-	ret := _m.Called(ctx, tx, productDetailData)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.ProductDetail) error); ok {
-		r0 = rf(ctx, tx, productDetailData)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // UpdatePromotionSeller provides a mock function with given fields: ctx, promotion
 func (_m *Repository) UpdatePromotionSeller(ctx context.Context, promotion *model.Promotion) error {
 	ret := _m.Called(ctx, promotion)
@@ -1346,20 +1412,6 @@ func (_m *Repository) UpdateSellerInformationByUserID(ctx context.Context, shopN
 	return r0
 }
 
-// UpdateTransaction provides a mock function with given fields: ctx, tx, transactionData
-func (_m *Repository) UpdateTransaction(ctx context.Context, tx postgre.Transaction, transactionData *model.Transaction) error {
-	ret := _m.Called(ctx, tx, transactionData)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.Transaction) error); ok {
-		r0 = rf(ctx, tx, transactionData)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // UpdateVoucherSeller provides a mock function with given fields: ctx, voucherShop
 func (_m *Repository) UpdateVoucherSeller(ctx context.Context, voucherShop *model.Voucher) error {
 	ret := _m.Called(ctx, voucherShop)
@@ -1374,20 +1426,6 @@ func (_m *Repository) UpdateVoucherSeller(ctx context.Context, voucherShop *mode
 	return r0
 }
 
-// UpdateWalletBalance provides a mock function with given fields: ctx, tx, wallet
-func (_m *Repository) UpdateWalletBalance(ctx context.Context, tx postgre.Transaction, wallet *model.Wallet) error {
-	ret := _m.Called(ctx, tx, wallet)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.Wallet) error); ok {
-		r0 = rf(ctx, tx, wallet)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 type mockConstructorTestingTNewRepository interface {
 	mock.TestingT
 	Cleanup(func())
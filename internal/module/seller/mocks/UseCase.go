@@ -460,31 +460,49 @@ func (_m *UseCase) GetSellerByUserID(ctx context.Context, userID string) (*body.
 }
 
 // UpdateExpiredAtOrder provides a mock function with given fields: ctx
-func (_m *UseCase) UpdateExpiredAtOrder(ctx context.Context) error {
+func (_m *UseCase) UpdateExpiredAtOrder(ctx context.Context) (*model.JobReport, error) {
 	ret := _m.Called(ctx)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+	var r0 *model.JobReport
+	if rf, ok := ret.Get(0).(func(context.Context) *model.JobReport); ok {
 		r0 = rf(ctx)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.JobReport)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // UpdateOnDeliveryOrder provides a mock function with given fields: ctx
-func (_m *UseCase) UpdateOnDeliveryOrder(ctx context.Context) error {
+func (_m *UseCase) UpdateOnDeliveryOrder(ctx context.Context) (*model.JobReport, error) {
 	ret := _m.Called(ctx)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+	var r0 *model.JobReport
+	if rf, ok := ret.Get(0).(func(context.Context) *model.JobReport); ok {
 		r0 = rf(ctx)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.JobReport)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // UpdatePromotionSeller provides a mock function with given fields: ctx, userID, requestBody
@@ -19,7 +19,7 @@ type Repository interface {
 	GetOrders(ctx context.Context, userID, orderStatusID, voucherShopID, sortQuery string, pgn *pagination.Pagination) ([]*model.Order, error)
 	GetShopIDByUser(ctx context.Context, userID string) (string, error)
 	GetShopIDByOrder(ctx context.Context, OrderID string) (string, error)
-	ChangeOrderStatus(ctx context.Context, requestBody body.ChangeOrderStatusRequest) error
+	ChangeOrderStatus(ctx context.Context, tx postgre.Transaction, requestBody body.ChangeOrderStatusRequest) error
 	CancelOrderStatus(ctx context.Context, tx postgre.Transaction, requestBody body.CancelOrderStatus) error
 	CreateRefundSeller(ctx context.Context, tx postgre.Transaction, requestBody body.CancelOrderStatus) error
 	GetOrderByOrderID(ctx context.Context, OrderID string) (*model.Order, error)
@@ -52,12 +52,14 @@ type Repository interface {
 	DeleteVoucherSeller(ctx context.Context, voucherIDShopID *body.VoucherIDShopID) error
 	GetAllVoucherSellerByIDAndShopID(ctx context.Context, voucherIDShopID *body.VoucherIDShopID) (*model.Voucher, error)
 	UpdateOrder(ctx context.Context, tx postgre.Transaction, orderData *model.OrderModel) error
-	UpdateTransaction(ctx context.Context, tx postgre.Transaction, transactionData *model.Transaction) error
+	CancelExpiredTransaction(ctx context.Context, tx postgre.Transaction, transactionID string) (int64, error)
 	GetOrderByTransactionID(ctx context.Context, tx postgre.Transaction, transactionID string) ([]*model.OrderModel, error)
 	GetTransactionsExpired(ctx context.Context) ([]*model.Transaction, error)
-	GetOrderItemsByOrderID(ctx context.Context, tx postgre.Transaction, orderID string) ([]*model.OrderItem, error)
-	GetProductDetailByID(ctx context.Context, tx postgre.Transaction, productDetailID string) (*model.ProductDetail, error)
-	UpdateProductDetailStock(ctx context.Context, tx postgre.Transaction, productDetailData *model.ProductDetail) error
+	ReleaseStockReservations(ctx context.Context, tx postgre.Transaction, orderID string) (int64, error)
+	ReleaseVoucherRedemptions(ctx context.Context, tx postgre.Transaction, transactionID string) (int64, error)
+	ReleaseOrderVoucherRedemptions(ctx context.Context, tx postgre.Transaction, orderID string) (int64, error)
+	ReleasePromotionClaims(ctx context.Context, tx postgre.Transaction, transactionID string) (int64, error)
+	ReleaseOrderPromotionClaims(ctx context.Context, tx postgre.Transaction, orderID string) (int64, error)
 	GetAllPromotionSeller(ctx context.Context, shopID string, promoStatusID string) ([]*body.PromotionSellerResponse, error)
 	GetTotalPromotionSeller(ctx context.Context, shopID string, promoStatusID string) (int64, error)
 	GetProductPromotion(ctx context.Context, shopProduct *body.ShopProduct) (*body.ProductPromotion, error)
@@ -69,7 +71,6 @@ type Repository interface {
 	GetProductWithoutPromotionSeller(ctx context.Context, shopID,
 		productName string, pgn *pagination.Pagination) ([]*body.GetProductWithoutPromotion, error)
 	GetWalletByUserID(ctx context.Context, tx postgre.Transaction, userID string) (*model.Wallet, error)
-	UpdateWalletBalance(ctx context.Context, tx postgre.Transaction, wallet *model.Wallet) error
 	InsertWalletHistory(ctx context.Context, tx postgre.Transaction, walletHistory *model.WalletHistory) error
 	GetOrderModelByID(ctx context.Context, OrderID string) (*model.OrderModel, error)
 	GetRefundOrderByOrderID(ctx context.Context, orderID string) (*model.Refund, error)
@@ -77,7 +78,7 @@ type Repository interface {
 	GetUserByID(ctx context.Context, id string) (*model.User, error)
 	GetShopByID(ctx context.Context, shopID string) (*model.Shop, error)
 	GetRefundThreadByRefundID(ctx context.Context, refundID string) ([]*body.RThread, error)
-	CreateRefundThreadSeller(ctx context.Context, refundThreadData *model.RefundThread) error
+	CreateRefundThreadSeller(ctx context.Context, tx postgre.Transaction, refundThreadData *model.RefundThread) error
 	UpdateRefundAccept(ctx context.Context, refundDataID string) error
 	UpdateRefundReject(ctx context.Context, tx postgre.Transaction, refundDataID string) error
 	UpdateOrderRefundRejected(ctx context.Context, tx postgre.Transaction, orderData *model.OrderModel) error
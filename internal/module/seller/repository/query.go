@@ -202,7 +202,7 @@ const (
 	GetSellerIDByOrderIDQuery = `SELECT s.user_id from "order" o join shop s on o.shop_id = s.id where o.id = $1`
 
 	GetOrderDetailQuery = `SELECT pd.id,pd.product_id,p.title, pd.weight,
-        p.thumbnail_url,oi.quantity,oi.item_price,oi.total_price
+        p.thumbnail_url,oi.quantity,oi.item_price,oi.total_price,oi.id,oi.is_review
     from  "product_detail" pd 
     join "order_item" oi on pd.id = oi.product_detail_id 
     join "product" p on p.id = pd.product_id WHERE oi.order_id = $1 `
@@ -286,7 +286,7 @@ const (
 	GetAllVoucherSellerQuery = `
 	SELECT "v"."id", "v"."shop_id", "v"."code", "v"."quota", "v"."actived_date", "v"."expired_date",
 		"v"."discount_percentage", "v"."discount_fix_price", "v"."min_product_price", "v"."max_discount_price",
-		"v"."created_at", "v"."updated_at",  "v"."deleted_at"
+		"v"."limit_per_user", "v"."created_at", "v"."updated_at",  "v"."deleted_at"
 	FROM "voucher" as "v"
 	INNER JOIN "shop" as "s" ON "s"."id" = "v"."shop_id"
 	WHERE "v"."shop_id" = $1
@@ -305,8 +305,9 @@ const (
 	 AND (now() > "v"."actived_date" AND  now() > "v"."expired_date")  `
 
 	CreateVoucherSellerQuery = `INSERT INTO "voucher" 
-    	(shop_id, code, quota, actived_date, expired_date, discount_percentage, discount_fix_price, min_product_price, max_discount_price)
-    	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    	(shop_id, code, quota, actived_date, expired_date, discount_percentage, discount_fix_price, min_product_price, max_discount_price,
+    	limit_per_user)
+    	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 	DeleteVoucherSellerQuery = `UPDATE "voucher" set deleted_at = now() WHERE "id" = $1 AND "shop_id" = $2 AND "deleted_at" IS NULL`
 
@@ -315,7 +316,7 @@ const (
 	GetAllVoucherSellerByIDandShopIDQuery = `
 	SELECT "v"."id", "v"."shop_id", "v"."code", "v"."quota", "v"."actived_date", "v"."expired_date",
 		"v"."discount_percentage", "v"."discount_fix_price", "v"."min_product_price", "v"."max_discount_price",
-		"v"."created_at", "v"."updated_at",  "v"."deleted_at"
+		"v"."limit_per_user", "v"."created_at", "v"."updated_at",  "v"."deleted_at"
 	FROM "voucher" as "v"
 	INNER JOIN "shop" as "s" ON "s"."id" = "v"."shop_id"
 	WHERE "v"."id"  = $1 AND "v"."shop_id" = $2 AND "v"."deleted_at" IS NULL
@@ -323,8 +324,8 @@ const (
 
 	UpdateVoucherSellerQuery = `
 		UPDATE "voucher" SET "quota" = $1, "actived_date" = $2, "expired_date" = $3, "discount_percentage" = $4,
-			"discount_fix_price" = $5, "min_product_price" = $6, "max_discount_price" = $7, "updated_at" = now()
-		WHERE "id" = $8
+			"discount_fix_price" = $5, "min_product_price" = $6, "max_discount_price" = $7, "limit_per_user" = $8, "updated_at" = now()
+		WHERE "id" = $9
 	`
 	GetAllPromotionSellerQuery = `
 	SELECT "promo"."id", "promo"."name", "p"."id", "p"."title", "p"."thumbnail_url", "promo"."discount_percentage",
@@ -392,15 +393,52 @@ const (
 	`
 
 	UpdateOrderByID               = `UPDATE "order" SET "order_status_id" = $1, "is_withdraw" = $2 WHERE "id" = $3`
-	UpdateTransactionByID         = `UPDATE "transaction" SET "paid_at" = $1, "canceled_at" = $2 WHERE "id" = $3`
-	GetProductDetailByIDQuery     = `SELECT "id", "price", "stock", "weight", "size", "hazardous", "condition", "bulk_price" FROM "product_detail" WHERE "id" = $1 AND "deleted_at" IS NULL;`
+	CancelExpiredTransactionQuery = `UPDATE "transaction" SET "canceled_at" = now()
+		WHERE "id" = $1 AND "paid_at" IS NULL AND "canceled_at" IS NULL`
 	GetTransactionsExpiredQuery   = `SELECT "id", "voucher_marketplace_id", "wallet_id", "card_number", "invoice", "total_price", "paid_at", "canceled_at", "expired_at" FROM "transaction" WHERE "paid_at" IS NULL AND "canceled_at" IS NULL AND "expired_at" < current_timestamp`
-	GetOrderItemsByOrderIDQuery   = `SELECT "id", "order_id", "product_detail_id", "quantity", "item_price", "total_price" FROM "order_item" WHERE "order_id" = $1`
-	UpdateProductDetailStockQuery = `UPDATE "product_detail" SET "stock" = $1, "updated_at" = now() WHERE "id" = $2;`
-	GetOrderByTransactionID       = `SELECT 
+	ReleaseStockReservationsQuery = `WITH "released" AS (
+		UPDATE "stock_reservation" SET "released_at" = now()
+		WHERE "order_id" = $1 AND "released_at" IS NULL
+		RETURNING "product_detail_id", "quantity"),
+	"total" AS (
+		SELECT "product_detail_id", SUM("quantity") AS "quantity" FROM "released" GROUP BY "product_detail_id")
+	UPDATE "product_detail" SET "stock" = "product_detail"."stock" + "total"."quantity", "updated_at" = now()
+	FROM "total" WHERE "product_detail"."id" = "total"."product_detail_id"`
+	ReleaseVoucherRedemptionsQuery = `WITH "released" AS (
+		UPDATE "voucher_redemption" SET "released_at" = now()
+		WHERE "transaction_id" = $1 AND "released_at" IS NULL
+		RETURNING "voucher_id"),
+	"total" AS (
+		SELECT "voucher_id", count("voucher_id") AS "quota" FROM "released" GROUP BY "voucher_id")
+	UPDATE "voucher" SET "quota" = "voucher"."quota" + "total"."quota", "updated_at" = now()
+	FROM "total" WHERE "voucher"."id" = "total"."voucher_id"`
+	ReleaseOrderVoucherRedemptionsQuery = `WITH "released" AS (
+		UPDATE "voucher_redemption" SET "released_at" = now()
+		WHERE "order_id" = $1 AND "released_at" IS NULL
+		RETURNING "voucher_id"),
+	"total" AS (
+		SELECT "voucher_id", count("voucher_id") AS "quota" FROM "released" GROUP BY "voucher_id")
+	UPDATE "voucher" SET "quota" = "voucher"."quota" + "total"."quota", "updated_at" = now()
+	FROM "total" WHERE "voucher"."id" = "total"."voucher_id"`
+	ReleasePromotionClaimsQuery = `WITH "released" AS (
+		UPDATE "promotion_claim" SET "released_at" = now()
+		WHERE "transaction_id" = $1 AND "released_at" IS NULL
+		RETURNING "promotion_id", "quantity"),
+	"total" AS (
+		SELECT "promotion_id", SUM("quantity") AS "quota" FROM "released" GROUP BY "promotion_id")
+	UPDATE "promotion" SET "quota" = "promotion"."quota" + "total"."quota", "updated_at" = now()
+	FROM "total" WHERE "promotion"."id" = "total"."promotion_id"`
+	ReleaseOrderPromotionClaimsQuery = `WITH "released" AS (
+		UPDATE "promotion_claim" SET "released_at" = now()
+		WHERE "order_id" = $1 AND "released_at" IS NULL
+		RETURNING "promotion_id", "quantity"),
+	"total" AS (
+		SELECT "promotion_id", SUM("quantity") AS "quota" FROM "released" GROUP BY "promotion_id")
+	UPDATE "promotion" SET "quota" = "promotion"."quota" + "total"."quota", "updated_at" = now()
+	FROM "total" WHERE "promotion"."id" = "total"."promotion_id"`
+	GetOrderByTransactionID = `SELECT 
 		"id", "transaction_id", "shop_id", "user_id", "courier_id", "voucher_shop_id", "order_status_id", "total_price", "delivery_fee", "resi_no", "created_at", "arrived_at" 
 	FROM "order" WHERE "transaction_id" = $1`
-	UpdateWalletBalanceQuery = `UPDATE "wallet" SET "balance" = $1, "updated_at" = $2 WHERE "id" = $3`
 
 	GetTotalProductWithoutPromotionQuery = `SELECT count("p"."id") FROM "product" as "p"
 		INNER JOIN "category" as "c" ON "c"."id" = "p"."category_id"
@@ -419,7 +457,7 @@ const (
 		LIMIT $3 OFFSET $4;
 	`
 
-	GetWalletByUserIDQuery = `SELECT "id", "user_id", "balance", "pin", "attempt_count", "attempt_at", "unlocked_at", "active_date" FROM "wallet" WHERE "user_id" = $1 AND "deleted_at" IS NULL`
+	GetWalletByUserIDQuery = `SELECT "id", "user_id", "balance", "pin", "active_date" FROM "wallet" WHERE "user_id" = $1 AND "deleted_at" IS NULL`
 
 	GetOrderModelByIDQuery = `SELECT "id", "transaction_id", "shop_id", "user_id", "courier_id", "voucher_shop_id", "order_status_id", "total_price",
 	"delivery_fee", "resi_no", "buyer_address", "shop_address", "cancel_notes", "is_withdraw", "is_refund", "created_at", "arrived_at"
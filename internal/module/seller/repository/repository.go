@@ -442,6 +442,8 @@ func (r *sellerRepo) GetOrderByOrderID(ctx context.Context, orderID string) (*mo
 			&detail.OrderQuantity,
 			&detail.ItemPrice,
 			&detail.TotalPrice,
+			&detail.OrderItemID,
+			&detail.IsReview,
 		); errScan != nil {
 			return nil, errScan
 		}
@@ -546,6 +548,8 @@ func (r *sellerRepo) GetOrders(ctx context.Context, shopID, orderStatusID, vouch
 				&detail.OrderQuantity,
 				&detail.ItemPrice,
 				&detail.TotalPrice,
+				&detail.OrderItemID,
+				&detail.IsReview,
 			); errScan != nil {
 				return nil, err
 			}
@@ -561,8 +565,8 @@ func (r *sellerRepo) GetOrders(ctx context.Context, shopID, orderStatusID, vouch
 	return orders, nil
 }
 
-func (r *sellerRepo) ChangeOrderStatus(ctx context.Context, requestBody body.ChangeOrderStatusRequest) error {
-	_, err := r.PSQL.ExecContext(
+func (r *sellerRepo) ChangeOrderStatus(ctx context.Context, tx postgre.Transaction, requestBody body.ChangeOrderStatusRequest) error {
+	_, err := tx.ExecContext(
 		ctx, ChangeOrderStatusQuery, requestBody.OrderStatusID, requestBody.OrderID)
 	if err != nil {
 		return err
@@ -919,6 +923,7 @@ func (r *sellerRepo) GetAllVoucherSeller(ctx context.Context, shopID, voucherSta
 			&voucher.DiscountFixPrice,
 			&voucher.MinProductPrice,
 			&voucher.MaxDiscountPrice,
+			&voucher.LimitPerUser,
 			&voucher.CreatedAt,
 			&voucher.UpdatedAt,
 			&voucher.DeletedAt,
@@ -946,7 +951,8 @@ func (r *sellerRepo) CreateVoucherSeller(ctx context.Context, voucherShop *model
 		voucherShop.DiscountPercentage,
 		voucherShop.DiscountFixPrice,
 		voucherShop.MinProductPrice,
-		voucherShop.MaxDiscountPrice); err != nil {
+		voucherShop.MaxDiscountPrice,
+		voucherShop.LimitPerUser); err != nil {
 		return err
 	}
 	return nil
@@ -961,6 +967,7 @@ func (r *sellerRepo) UpdateVoucherSeller(ctx context.Context, voucherShop *model
 		voucherShop.DiscountFixPrice,
 		voucherShop.MinProductPrice,
 		voucherShop.MaxDiscountPrice,
+		voucherShop.LimitPerUser,
 		voucherShop.ID); err != nil {
 		return err
 	}
@@ -988,6 +995,7 @@ func (r *sellerRepo) GetAllVoucherSellerByIDAndShopID(ctx context.Context, vouch
 		&voucher.DiscountFixPrice,
 		&voucher.MinProductPrice,
 		&voucher.MaxDiscountPrice,
+		&voucher.LimitPerUser,
 		&voucher.CreatedAt,
 		&voucher.UpdatedAt,
 		&voucher.DeletedAt,
@@ -1181,33 +1189,25 @@ func (r *sellerRepo) UpdateOrder(ctx context.Context, tx postgre.Transaction, or
 	return nil
 }
 
-func (r *sellerRepo) UpdateWalletBalance(ctx context.Context, tx postgre.Transaction, wallet *model.Wallet) error {
-	_, err := tx.ExecContext(ctx, UpdateWalletBalanceQuery, wallet.Balance, wallet.UpdatedAt, wallet.ID)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func (r *sellerRepo) GetWalletByUserID(ctx context.Context, tx postgre.Transaction, userID string) (*model.Wallet, error) {
 	var walletModel model.Wallet
 	if err := tx.QueryRowContext(ctx, GetWalletByUserIDQuery, userID).Scan(&walletModel.ID, &walletModel.UserID,
-		&walletModel.Balance, &walletModel.PIN, &walletModel.AttemptCount,
-		&walletModel.AttemptAt, &walletModel.UnlockedAt, &walletModel.ActiveDate); err != nil {
+		&walletModel.Balance, &walletModel.PIN, &walletModel.ActiveDate); err != nil {
 		return nil, err
 	}
 
 	return &walletModel, nil
 }
 
-func (r *sellerRepo) UpdateTransaction(ctx context.Context, tx postgre.Transaction, transactionData *model.Transaction) error {
-	_, err := tx.ExecContext(ctx, UpdateTransactionByID, transactionData.PaidAt, transactionData.CanceledAt, transactionData.ID)
+// CancelExpiredTransaction cancels a transaction only while it is still unpaid.
+// It affects no row when a payment callback settled it after it was read.
+func (r *sellerRepo) CancelExpiredTransaction(ctx context.Context, tx postgre.Transaction, transactionID string) (int64, error) {
+	res, err := tx.ExecContext(ctx, CancelExpiredTransactionQuery, transactionID)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	return res.RowsAffected()
 }
 
 func (r *sellerRepo) GetOrderByTransactionID(ctx context.Context, tx postgre.Transaction, transactionID string) ([]*model.OrderModel, error) {
@@ -1279,61 +1279,59 @@ func (r *sellerRepo) GetTransactionsExpired(ctx context.Context) ([]*model.Trans
 	return transactions, nil
 }
 
-func (r *sellerRepo) GetOrderItemsByOrderID(ctx context.Context, tx postgre.Transaction, orderID string) ([]*model.OrderItem, error) {
-	orderItems := make([]*model.OrderItem, 0)
-	res, err := tx.QueryContext(ctx, GetOrderItemsByOrderIDQuery, orderID)
+// ReleaseStockReservations gives the stock held by an order back. A
+// reservation is only released once, so running it again is harmless.
+func (r *sellerRepo) ReleaseStockReservations(ctx context.Context, tx postgre.Transaction, orderID string) (int64, error) {
+	res, err := tx.ExecContext(ctx, ReleaseStockReservationsQuery, orderID)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer res.Close()
 
-	for res.Next() {
-		var orderItem model.OrderItem
-		if errScan := res.Scan(
-			&orderItem.ID,
-			&orderItem.OrderID,
-			&orderItem.ProductDetailID,
-			&orderItem.Quantity,
-			&orderItem.ItemPrice,
-			&orderItem.TotalPrice); errScan != nil {
-			return nil, err
-		}
+	return res.RowsAffected()
+}
 
-		orderItems = append(orderItems, &orderItem)
+// ReleaseVoucherRedemptions gives back every voucher use of a transaction,
+// both the marketplace voucher and the shop vouchers of its orders.
+func (r *sellerRepo) ReleaseVoucherRedemptions(ctx context.Context, tx postgre.Transaction, transactionID string) (int64, error) {
+	res, err := tx.ExecContext(ctx, ReleaseVoucherRedemptionsQuery, transactionID)
+	if err != nil {
+		return 0, err
 	}
 
-	if res.Err() != nil {
-		return nil, err
+	return res.RowsAffected()
+}
+
+// ReleaseOrderVoucherRedemptions gives back the shop voucher use of a single
+// order. The marketplace voucher stays used by the rest of the transaction.
+func (r *sellerRepo) ReleaseOrderVoucherRedemptions(ctx context.Context, tx postgre.Transaction, orderID string) (int64, error) {
+	res, err := tx.ExecContext(ctx, ReleaseOrderVoucherRedemptionsQuery, orderID)
+	if err != nil {
+		return 0, err
 	}
 
-	return orderItems, nil
+	return res.RowsAffected()
 }
 
-func (r *sellerRepo) GetProductDetailByID(ctx context.Context, tx postgre.Transaction, productDetailID string) (*model.ProductDetail, error) {
-	var pd model.ProductDetail
-	if err := tx.QueryRowContext(ctx, GetProductDetailByIDQuery, productDetailID).Scan(
-		&pd.ID,
-		&pd.Price,
-		&pd.Stock,
-		&pd.Size,
-		&pd.Weight,
-		&pd.Hazardous,
-		&pd.Condition,
-		&pd.BulkPrice); err != nil {
-		return nil, err
+// ReleasePromotionClaims gives back the promotion quota taken by every order
+// of a transaction.
+func (r *sellerRepo) ReleasePromotionClaims(ctx context.Context, tx postgre.Transaction, transactionID string) (int64, error) {
+	res, err := tx.ExecContext(ctx, ReleasePromotionClaimsQuery, transactionID)
+	if err != nil {
+		return 0, err
 	}
 
-	return &pd, nil
+	return res.RowsAffected()
 }
 
-func (r *sellerRepo) UpdateProductDetailStock(ctx context.Context, tx postgre.Transaction,
-	productDetailData *model.ProductDetail) error {
-	_, err := tx.ExecContext(ctx, UpdateProductDetailStockQuery, productDetailData.Stock, productDetailData.ID)
+// ReleaseOrderPromotionClaims gives back the promotion quota taken by a
+// single order.
+func (r *sellerRepo) ReleaseOrderPromotionClaims(ctx context.Context, tx postgre.Transaction, orderID string) (int64, error) {
+	res, err := tx.ExecContext(ctx, ReleaseOrderPromotionClaimsQuery, orderID)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	return res.RowsAffected()
 }
 
 func (r *sellerRepo) GetTotalProductWithoutPromotionSeller(ctx context.Context, shopID, productName string) (int64, error) {
@@ -1482,8 +1480,8 @@ func (r *sellerRepo) GetRefundThreadByRefundID(ctx context.Context, refundID str
 	}
 	return refundThreadList, nil
 }
-func (r *sellerRepo) CreateRefundThreadSeller(ctx context.Context, refundThreadData *model.RefundThread) error {
-	if _, err := r.PSQL.ExecContext(ctx, CreateRefundThreadSellerQuery,
+func (r *sellerRepo) CreateRefundThreadSeller(ctx context.Context, tx postgre.Transaction, refundThreadData *model.RefundThread) error {
+	if _, err := tx.ExecContext(ctx, CreateRefundThreadSellerQuery,
 		refundThreadData.RefundID,
 		refundThreadData.UserID,
 		refundThreadData.IsSeller,
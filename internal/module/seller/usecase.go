@@ -23,8 +23,8 @@ type UseCase interface {
 	DeleteCourierSellerByID(ctx context.Context, shopCourierID string) error
 	GetCategoryBySellerID(ctx context.Context, shopID string) ([]*body.CategoryResponse, error)
 	UpdateResiNumberInOrderSeller(ctx context.Context, userID, orderID string, requestBody body.UpdateNoResiOrderSellerRequest) error
-	UpdateOnDeliveryOrder(ctx context.Context) error
-	UpdateExpiredAtOrder(ctx context.Context) error
+	UpdateOnDeliveryOrder(ctx context.Context) (*model.JobReport, error)
+	UpdateExpiredAtOrder(ctx context.Context) (*model.JobReport, error)
 	WithdrawalOrderBalance(ctx context.Context, orderID string) error
 	GetAllVoucherSeller(ctx context.Context, userID, voucherStatusID, sortFilter string, pgn *pagination.Pagination) (*pagination.Pagination, error)
 	CreateVoucherSeller(ctx context.Context, userID string, requestBody body.CreateVoucherRequest) error
@@ -9,16 +9,18 @@ import (
 	"murakali/config"
 	"murakali/internal/constant"
 	"murakali/internal/model"
-	body2 "murakali/internal/module/location/delivery/body"
+	"murakali/internal/module/ledger"
+	"murakali/internal/module/notification"
 	"murakali/internal/module/seller"
 	"murakali/internal/module/seller/delivery/body"
+	"murakali/internal/util"
 	"murakali/pkg/httperror"
 	"murakali/pkg/pagination"
 	"murakali/pkg/postgre"
 	"murakali/pkg/response"
+	"murakali/pkg/shipping"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -28,10 +30,14 @@ type sellerUC struct {
 	cfg        *config.Config
 	txRepo     *postgre.TxRepo
 	sellerRepo seller.Repository
+	shipping   shipping.Provider
+	ledger     ledger.UseCase
+	notify     notification.UseCase
 }
 
-func NewSellerUseCase(cfg *config.Config, txRepo *postgre.TxRepo, sellerRepo seller.Repository) seller.UseCase {
-	return &sellerUC{cfg: cfg, txRepo: txRepo, sellerRepo: sellerRepo}
+func NewSellerUseCase(cfg *config.Config, txRepo *postgre.TxRepo, sellerRepo seller.Repository, shippingProvider shipping.Provider,
+	ledgerUC ledger.UseCase, notificationUC notification.UseCase) seller.UseCase {
+	return &sellerUC{cfg: cfg, txRepo: txRepo, sellerRepo: sellerRepo, shipping: shippingProvider, ledger: ledgerUC, notify: notificationUC}
 }
 
 func (u *sellerUC) GetPerformance(ctx context.Context, userID string, update bool) (*body.SellerPerformance, error) {
@@ -95,13 +101,6 @@ func (u *sellerUC) WithdrawalOrderBalance(ctx context.Context, orderID string) e
 			return err
 		}
 
-		walletMarketplace.Balance -= *order.TotalPrice
-		walletMarketplace.UpdatedAt.Valid = true
-		walletMarketplace.UpdatedAt.Time = time.Now()
-		if errWallet := u.sellerRepo.UpdateWalletBalance(ctx, tx, walletMarketplace); errWallet != nil {
-			return errWallet
-		}
-
 		sellerID, err := u.sellerRepo.GetSellerIDByOrderID(ctx, orderID)
 		if err != nil {
 			return err
@@ -112,13 +111,6 @@ func (u *sellerUC) WithdrawalOrderBalance(ctx context.Context, orderID string) e
 			return err
 		}
 
-		walletSeller.Balance += *order.TotalPrice
-		walletSeller.UpdatedAt.Time = time.Now()
-		walletSeller.UpdatedAt.Valid = true
-		if errWallet := u.sellerRepo.UpdateWalletBalance(ctx, tx, walletSeller); errWallet != nil {
-			return errWallet
-		}
-
 		transactionID, err := uuid.Parse(order.TransactionID)
 		if err != nil {
 			return err
@@ -150,7 +142,12 @@ func (u *sellerUC) WithdrawalOrderBalance(ctx context.Context, orderID string) e
 			return err
 		}
 
-		return nil
+		entry := model.NewTransferEntry(transactionID, walletUserHistory.Description,
+			model.LedgerAccount{AccountType: constant.LedgerAccountEscrow},
+			model.LedgerAccount{AccountType: constant.LedgerAccountSellerWallet, WalletID: &walletSeller.ID},
+			*order.TotalPrice)
+
+		return u.ledger.PostEntry(ctx, tx, entry)
 	})
 
 	if errTx != nil {
@@ -219,11 +216,18 @@ func (u *sellerUC) ChangeOrderStatus(ctx context.Context, userID string, request
 		return httperror.New(http.StatusUnauthorized, response.UnauthorizedMessage)
 	}
 
-	err = u.sellerRepo.ChangeOrderStatus(ctx, requestBody)
+	orderStatusID, err := strconv.Atoi(requestBody.OrderStatusID)
 	if err != nil {
-		return err
+		return httperror.New(http.StatusBadRequest, response.BadRequestMessage)
 	}
-	return nil
+
+	return u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		if err := u.sellerRepo.ChangeOrderStatus(ctx, tx, requestBody); err != nil {
+			return err
+		}
+
+		return u.notify.NotifyOrderStatus(ctx, tx, requestBody.OrderID, orderStatusID)
+	})
 }
 
 func (u *sellerUC) GetOrderByOrderID(ctx context.Context, orderID string) (*model.Order, error) {
@@ -261,10 +265,10 @@ func (u *sellerUC) GetOrderByOrderID(ctx context.Context, orderID string) (*mode
 	}
 
 	var costRedis *string
-	key := fmt.Sprintf("%d:%d:%d:%s", sellerAddress.CityID, buyerAddress.CityID, totalWeight, order.CourierCode)
+	key := shipping.CostKey(u.shipping, sellerAddress.CityID, buyerAddress.CityID, totalWeight, order.CourierCode)
 	costRedis, err = u.sellerRepo.GetCostRedis(ctx, key)
 	if err != nil {
-		res, err := u.GetCostRajaOngkir(sellerAddress.CityID, buyerAddress.CityID, totalWeight, order.CourierCode)
+		res, err := u.shipping.GetCost(ctx, sellerAddress.CityID, buyerAddress.CityID, totalWeight, order.CourierCode)
 		if err != nil {
 			return nil, err
 		}
@@ -282,17 +286,13 @@ func (u *sellerUC) GetOrderByOrderID(ctx context.Context, orderID string) (*mode
 		costRedis = &value
 	}
 
-	var costResp body2.RajaOngkirCostResponse
-	if err := json.Unmarshal([]byte(*costRedis), &costResp); err != nil {
+	var quote shipping.Quote
+	if err := json.Unmarshal([]byte(*costRedis), &quote); err != nil {
 		return nil, err
 	}
 
-	if len(costResp.Rajaongkir.Results) > 0 {
-		for _, cost := range costResp.Rajaongkir.Results[0].Costs {
-			if cost.Service == order.CourierService {
-				order.CourierETD = cost.Cost[0].Etd
-			}
-		}
+	if _, etd, ok := quote.ServiceCost(order.CourierService); ok {
+		order.CourierETD = etd
 	}
 
 	return order, nil
@@ -470,38 +470,59 @@ func (u *sellerUC) UpdateResiNumberInOrderSeller(ctx context.Context, userID, or
 	return nil
 }
 
-func (u *sellerUC) UpdateOnDeliveryOrder(ctx context.Context) error {
+func (u *sellerUC) UpdateOnDeliveryOrder(ctx context.Context) (*model.JobReport, error) {
+	report := model.NewJobReport(constant.JobOrderDelivered)
+	defer report.Finish()
+
 	orders, err := u.sellerRepo.GetOrdersOnDelivery(ctx)
 	if err != nil {
-		return err
+		return report, err
 	}
 
 	for _, order := range orders {
 		if order.OrderStatusID == constant.OrderStatusOnDelivery && order.ArrivedAt.Valid && time.Until(order.ArrivedAt.Time) <= 0 {
-			if err := u.sellerRepo.ChangeOrderStatus(ctx, body.ChangeOrderStatusRequest{OrderID: order.ID.String(),
-				OrderStatusID: strconv.Itoa(constant.OrderStatusDelivered)}); err != nil {
-				return err
+			err := u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+				if err := u.sellerRepo.ChangeOrderStatus(ctx, tx, body.ChangeOrderStatusRequest{OrderID: order.ID.String(),
+					OrderStatusID: strconv.Itoa(constant.OrderStatusDelivered)}); err != nil {
+					return err
+				}
+
+				return u.notify.NotifyOrderStatus(ctx, tx, order.ID.String(), constant.OrderStatusDelivered)
+			})
+			if err != nil {
+				return report, err
 			}
+			report.RowsAffected++
 		}
 	}
 
-	return nil
+	return report, nil
 }
 
-func (u *sellerUC) UpdateExpiredAtOrder(ctx context.Context) error {
+func (u *sellerUC) UpdateExpiredAtOrder(ctx context.Context) (*model.JobReport, error) {
+	report := model.NewJobReport(constant.JobTransactionExpired)
+	defer report.Finish()
+
 	transactions, err := u.sellerRepo.GetTransactionsExpired(ctx)
 	if err != nil {
-		return err
+		return report, err
 	}
 
 	for _, transaction := range transactions {
+		canceled := false
 		err := u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
-			transaction.CanceledAt.Valid = true
-			transaction.CanceledAt.Time = time.Now()
-			if err := u.sellerRepo.UpdateTransaction(ctx, tx, transaction); err != nil {
+			rows, err := u.sellerRepo.CancelExpiredTransaction(ctx, tx, transaction.ID.String())
+			if err != nil {
 				return err
 			}
 
+			// paid or canceled since it was read, its stock, vouchers and
+			// promotions are no longer ours to give back
+			if rows == 0 {
+				return nil
+			}
+			canceled = true
+
 			orders, err := u.sellerRepo.GetOrderByTransactionID(ctx, tx, transaction.ID.String())
 			if err != nil {
 				return err
@@ -514,61 +535,36 @@ func (u *sellerUC) UpdateExpiredAtOrder(ctx context.Context) error {
 					return err
 				}
 
-				orderItems, err := u.sellerRepo.GetOrderItemsByOrderID(ctx, tx, order.ID.String())
-				if err != nil {
+				if _, err := u.sellerRepo.ReleaseStockReservations(ctx, tx, order.ID.String()); err != nil {
 					return err
 				}
 
-				for _, item := range orderItems {
-					productDetailData, err := u.sellerRepo.GetProductDetailByID(ctx, tx, item.ProductDetailID.String())
-					if err != nil {
-						return err
-					}
-
-					productDetailData.Stock += float64(item.Quantity)
-					errProduct := u.sellerRepo.UpdateProductDetailStock(ctx, tx, productDetailData)
-					if errProduct != nil {
-						return errProduct
-					}
+				if err := u.notify.NotifyOrderStatus(ctx, tx, order.ID.String(), order.OrderStatusID); err != nil {
+					return err
 				}
 			}
 
+			if _, err := u.sellerRepo.ReleaseVoucherRedemptions(ctx, tx, transaction.ID.String()); err != nil {
+				return err
+			}
+
+			if _, err := u.sellerRepo.ReleasePromotionClaims(ctx, tx, transaction.ID.String()); err != nil {
+				return err
+			}
+
 			return nil
 		})
 
 		if err != nil {
-			return err
+			return report, err
 		}
-	}
 
-	return nil
-}
-
-func (u *sellerUC) GetCostRajaOngkir(origin, destination, weight int, code string) (*body2.RajaOngkirCostResponse, error) {
-	var responseCost body2.RajaOngkirCostResponse
-	url := fmt.Sprintf("%s/cost", u.cfg.External.OngkirAPIURL)
-	payload := fmt.Sprintf(
-		"origin=%d&destination=%d&weight=%d&courier=%s", origin, destination, weight, code)
-
-	req, err := http.NewRequest("POST", url, strings.NewReader(payload))
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Add("key", u.cfg.External.OngkirAPIKey)
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	readErr := json.NewDecoder(res.Body).Decode(&responseCost)
-	if readErr != nil {
-		return nil, err
+		if canceled {
+			report.RowsAffected++
+		}
 	}
 
-	return &responseCost, nil
+	return report, nil
 }
 
 func (u *sellerUC) GetAllVoucherSeller(ctx context.Context, userID, voucherStatusID, sortFilter string,
@@ -629,6 +625,7 @@ func (u *sellerUC) CreateVoucherSeller(ctx context.Context, userID string, reque
 		DiscountFixPrice:   &requestBody.DiscountFixPrice,
 		MinProductPrice:    &requestBody.MinProductPrice,
 		MaxDiscountPrice:   &requestBody.MaxDiscountPrice,
+		LimitPerUser:       requestBody.LimitPerUser,
 	}
 
 	err = u.sellerRepo.CreateVoucherSeller(ctx, voucherShop)
@@ -669,6 +666,7 @@ func (u *sellerUC) UpdateVoucherSeller(ctx context.Context, userID string, reque
 	voucherShop.DiscountFixPrice = &requestBody.DiscountFixPrice
 	voucherShop.MinProductPrice = &requestBody.MinProductPrice
 	voucherShop.MaxDiscountPrice = &requestBody.MaxDiscountPrice
+	voucherShop.LimitPerUser = requestBody.LimitPerUser
 
 	err = u.sellerRepo.UpdateVoucherSeller(ctx, voucherShop)
 	if err != nil {
@@ -754,7 +752,15 @@ func (u *sellerUC) CancelOrderStatus(ctx context.Context, userID string, request
 			return err
 		}
 
-		return nil
+		if _, err := u.sellerRepo.ReleaseOrderVoucherRedemptions(ctx, tx, requestBody.OrderID); err != nil {
+			return err
+		}
+
+		if _, err := u.sellerRepo.ReleaseOrderPromotionClaims(ctx, tx, requestBody.OrderID); err != nil {
+			return err
+		}
+
+		return u.notify.NotifyOrderStatus(ctx, tx, requestBody.OrderID, constant.OrderStatusCanceled)
 	})
 	if errTx != nil {
 		return errTx
@@ -1093,12 +1099,13 @@ func (u *sellerUC) CreateRefundThreadSeller(ctx context.Context, userID string,
 		Text:     requestBody.Text,
 	}
 
-	err = u.sellerRepo.CreateRefundThreadSeller(ctx, refundThreadData)
-	if err != nil {
-		return err
-	}
+	return u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		if err := u.sellerRepo.CreateRefundThreadSeller(ctx, tx, refundThreadData); err != nil {
+			return err
+		}
 
-	return nil
+		return u.notify.NotifyRefundReply(ctx, tx, requestBody.RefundID, requestBody.Text, true)
+	})
 }
 
 func (u *sellerUC) UpdateRefundAccept(ctx context.Context, userID string, requestBody *body.UpdateRefundRequest) error {
@@ -1182,50 +1189,21 @@ func (u *sellerUC) UpdateRefundReject(ctx context.Context, userID string, reques
 }
 
 func (u *sellerUC) CalculateDiscountPromotionProduct(ctx context.Context, p *body.PromotionDetailSeller) *body.PromotionDetailSeller {
-	var maxDiscountPrice float64
-	var minProductPrice float64
-	var discountPercentage float64
-	var discountFixPrice float64
-	var resultMinPriceDiscount float64
-	var resultMaxPriceDiscount float64
-
-	if p.MinProductPrice != nil {
-		minProductPrice = *p.MinProductPrice
-	}
-
-	maxDiscountPrice = *p.MaxDiscountPrice
-	if p.DiscountPercentage != nil {
-		discountPercentage = *p.DiscountPercentage
-		if p.MinPrice >= minProductPrice && discountPercentage > 0 {
-			resultMinPriceDiscount = math.Min(maxDiscountPrice,
-				p.MinPrice*(discountPercentage/100.00))
-		}
-		if p.MaxPrice >= minProductPrice && discountPercentage > 0 {
-			resultMaxPriceDiscount = math.Min(maxDiscountPrice,
-				p.MaxPrice*(discountPercentage/100.00))
-		}
-	}
-
-	if p.DiscountFixPrice != nil {
-		discountFixPrice = *p.DiscountFixPrice
-		if p.MinPrice >= minProductPrice && discountFixPrice > 0 {
-			resultMinPriceDiscount = math.Max(resultMinPriceDiscount, discountFixPrice)
-			resultMinPriceDiscount = math.Min(resultMinPriceDiscount, maxDiscountPrice)
-		}
-		if p.MaxPrice >= minProductPrice && discountFixPrice > 0 {
-			resultMaxPriceDiscount = math.Max(resultMaxPriceDiscount, discountFixPrice)
-			resultMaxPriceDiscount = math.Min(resultMaxPriceDiscount, maxDiscountPrice)
-		}
+	disc := &model.Discount{
+		DiscountPercentage: p.DiscountPercentage,
+		DiscountFixPrice:   p.DiscountFixPrice,
+		MinProductPrice:    p.MinProductPrice,
+		MaxDiscountPrice:   p.MaxDiscountPrice,
 	}
 
-	if resultMinPriceDiscount > 0 {
-		p.ProductMinDiscountPrice = resultMinPriceDiscount
-		p.ProductSubMinPrice = p.MinPrice - p.ProductMinDiscountPrice
+	if resultDiscount, subPrice := util.CalculateDiscount(p.MinPrice, disc); resultDiscount > 0 {
+		p.ProductMinDiscountPrice = resultDiscount
+		p.ProductSubMinPrice = subPrice
 	}
 
-	if resultMaxPriceDiscount > 0 {
-		p.ProductMaxDiscountPrice = resultMaxPriceDiscount
-		p.ProductSubMaxPrice = p.MaxPrice - p.ProductMaxDiscountPrice
+	if resultDiscount, subPrice := util.CalculateDiscount(p.MaxPrice, disc); resultDiscount > 0 {
+		p.ProductMaxDiscountPrice = resultDiscount
+		p.ProductSubMaxPrice = subPrice
 	}
 
 	return p
@@ -5,14 +5,18 @@ import (
 	"database/sql"
 	"errors"
 	"murakali/config"
+	"murakali/internal/constant"
 	"murakali/internal/model"
+	notificationMocks "murakali/internal/module/notification/mocks"
 	"murakali/internal/module/seller/delivery/body"
 	"murakali/internal/module/seller/mocks"
 	"murakali/pkg/httperror"
 	"murakali/pkg/pagination"
 	"murakali/pkg/postgre"
+	"murakali/pkg/response"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
@@ -46,7 +50,7 @@ func Test_sellerUC_GetPerformance(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetPerformance(context.Background(), tc.userID, tc.update)
@@ -85,7 +89,7 @@ func Test_sellerUC_GetAllSeller(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetAllSeller(context.Background(), tc.shopName, tc.pgn)
@@ -130,7 +134,7 @@ func Test_sellerUC_GetOrder(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetOrder(context.Background(), tc.userID, tc.orderStatusID, tc.voucherShopID, tc.sortQuery, tc.pgn)
@@ -146,20 +150,43 @@ func Test_sellerUC_ChangeOrderStatus(t *testing.T) {
 		name        string
 		userID      string
 		requestBody body.ChangeOrderStatusRequest
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase)
 		expectedErr error
 	}{
 		{
 			name:        "success get performance",
 			userID:      "123456",
-			requestBody: body.ChangeOrderStatusRequest{},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			requestBody: body.ChangeOrderStatusRequest{OrderID: "1", OrderStatusID: "3"},
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
 				r.On("GetShopIDByUser", mock.Anything, mock.Anything).Return("123", nil)
 				r.On("GetShopIDByOrder", mock.Anything, mock.Anything).Return("123", nil)
-				r.On("ChangeOrderStatus", mock.Anything, mock.Anything).Return(nil)
+				r.On("ChangeOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, "1", constant.OrderStatusProcessed).Return(nil)
 			},
 			expectedErr: nil,
 		},
+		{
+			name:        "error invalid order status",
+			userID:      "123456",
+			requestBody: body.ChangeOrderStatusRequest{OrderID: "1", OrderStatusID: "processed"},
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
+				r.On("GetShopIDByUser", mock.Anything, mock.Anything).Return("123", nil)
+				r.On("GetShopIDByOrder", mock.Anything, mock.Anything).Return("123", nil)
+			},
+			expectedErr: httperror.New(http.StatusBadRequest, response.BadRequestMessage),
+		},
+		{
+			name:        "error NotifyOrderStatus",
+			userID:      "123456",
+			requestBody: body.ChangeOrderStatusRequest{OrderID: "1", OrderStatusID: "4"},
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
+				r.On("GetShopIDByUser", mock.Anything, mock.Anything).Return("123", nil)
+				r.On("GetShopIDByOrder", mock.Anything, mock.Anything).Return("123", nil)
+				r.On("ChangeOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, "1", constant.OrderStatusOnDelivery).Return(errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
 	}
 
 	for _, tc := range testCase {
@@ -167,9 +194,10 @@ func Test_sellerUC_ChangeOrderStatus(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			n := notificationMocks.NewUseCase(t)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, n)
 
-			tc.mock(t, r)
+			tc.mock(t, r, n)
 			err := u.ChangeOrderStatus(context.Background(), tc.userID, tc.requestBody)
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
@@ -200,7 +228,7 @@ func Test_sellerUC_GetSellerBySellerID(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetSellerBySellerID(context.Background(), tc.sellerID)
@@ -249,7 +277,7 @@ func Test_sellerUC_GetSellerByUserID(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetSellerByUserID(context.Background(), tc.userID)
@@ -304,7 +332,7 @@ func Test_sellerUC_UpdateSellerInformationByUserID(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			err := u.UpdateSellerInformationByUserID(context.Background(), tc.shopName, tc.userID)
@@ -355,7 +383,7 @@ func Test_sellerUC_DeleteCourierSellerByID(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			err := u.DeleteCourierSellerByID(context.Background(), tc.shopCourierID)
@@ -396,7 +424,7 @@ func Test_sellerUC_GetCategoryBySellerID(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetCategoryBySellerID(context.Background(), tc.shopID)
@@ -433,7 +461,7 @@ func Test_sellerUC_UpdateResiNumberInOrderSeller(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			err := u.UpdateResiNumberInOrderSeller(context.Background(), tc.userID, tc.orderID, tc.requestBody)
@@ -477,7 +505,7 @@ func Test_sellerUC_GetAllVoucherSeller(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetAllVoucherSeller(context.Background(), tc.userID, tc.voucherStatusID, tc.sortFilter, tc.pgn)
@@ -525,7 +553,7 @@ func Test_sellerUC_GetAllVoucherSeller(t *testing.T) {
 // 			sql, mock, _ := sqlmock.New()
 // 			mock.ExpectBegin()
 // 			r := mocks.NewRepository(t)
-// 			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+// 			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 // 			tc.mock(t, r)
 // 			err := u.CreateVoucherSeller(context.Background(), tc.userID, tc.requestBody)
@@ -562,7 +590,7 @@ func Test_sellerUC_UpdateVoucherSeller(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			err := u.UpdateVoucherSeller(context.Background(), tc.userID, tc.requestBody)
@@ -596,7 +624,7 @@ func Test_sellerUC_GetDetailVoucherSeller(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetDetailVoucherSeller(context.Background(), tc.voucherIDShopID)
@@ -651,7 +679,7 @@ func Test_sellerUC_DeleteVoucherSeller(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			err := u.DeleteVoucherSeller(context.Background(), tc.voucherIDShopID)
@@ -662,43 +690,74 @@ func Test_sellerUC_DeleteVoucherSeller(t *testing.T) {
 	}
 }
 
-// func Test_sellerUC_CancelOrderStatus(t *testing.T) {
-// 	testCase := []struct {
-// 		name        string
-// 		userID      string
-// 		requestBody body.CancelOrderStatus
-// 		mock        func(t *testing.T, r *mocks.Repository)
-// 		expectedErr error
-// 	}{
-// 		{
-// 			name:   "success DeleteVoucherSeller",
-// 			userID: "",
-// 			mock: func(t *testing.T, r *mocks.Repository) {
-// 				r.On("GetShopIDByUser", mock.Anything, mock.Anything).Return("", nil)
-// 				r.On("GetOrderByOrderID", mock.Anything, mock.Anything).Return(&model.Order{}, nil)
-// 				// r.On("CancelOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-// 				// r.On("CreateRefundSeller", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-
-// 			},
-// 			expectedErr: nil,
-// 		},
-// 	}
-
-// 	for _, tc := range testCase {
-// 		t.Run(tc.name, func(t *testing.T) {
-// 			sql, mock, _ := sqlmock.New()
-// 			mock.ExpectBegin()
-// 			r := mocks.NewRepository(t)
-// 			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+func Test_sellerUC_CancelOrderStatus(t *testing.T) {
+	requestBody := body.CancelOrderStatus{OrderID: "008dc24d-1f30-4e13-823f-d62972f416df", CancelNotes: "out of stock"}
+	testCase := []struct {
+		name        string
+		mock        func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase)
+		expectedErr error
+	}{
+		{
+			name: "success release vouchers and promotions of the order",
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
+				r.On("GetShopIDByUser", mock.Anything, mock.Anything).Return("shop", nil)
+				r.On("GetOrderByOrderID", mock.Anything, requestBody.OrderID).
+					Return(&model.Order{ShopID: "shop", OrderStatus: constant.OrderStatusWaitingForSeller}, nil)
+				r.On("CancelOrderStatus", mock.Anything, mock.Anything, requestBody).Return(nil)
+				r.On("CreateRefundSeller", mock.Anything, mock.Anything, requestBody).Return(nil)
+				r.On("ReleaseOrderVoucherRedemptions", mock.Anything, mock.Anything, requestBody.OrderID).Return(int64(1), nil)
+				r.On("ReleaseOrderPromotionClaims", mock.Anything, mock.Anything, requestBody.OrderID).Return(int64(1), nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, requestBody.OrderID, constant.OrderStatusCanceled).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error ReleaseOrderPromotionClaims",
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
+				r.On("GetShopIDByUser", mock.Anything, mock.Anything).Return("shop", nil)
+				r.On("GetOrderByOrderID", mock.Anything, requestBody.OrderID).
+					Return(&model.Order{ShopID: "shop", OrderStatus: constant.OrderStatusWaitingForSeller}, nil)
+				r.On("CancelOrderStatus", mock.Anything, mock.Anything, requestBody).Return(nil)
+				r.On("CreateRefundSeller", mock.Anything, mock.Anything, requestBody).Return(nil)
+				r.On("ReleaseOrderVoucherRedemptions", mock.Anything, mock.Anything, requestBody.OrderID).Return(int64(0), nil)
+				r.On("ReleaseOrderPromotionClaims", mock.Anything, mock.Anything, requestBody.OrderID).Return(int64(0), errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
+		{
+			name: "error order of another shop",
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
+				r.On("GetShopIDByUser", mock.Anything, mock.Anything).Return("shop", nil)
+				r.On("GetOrderByOrderID", mock.Anything, requestBody.OrderID).
+					Return(&model.Order{ShopID: "other", OrderStatus: constant.OrderStatusWaitingForSeller}, nil)
+			},
+			expectedErr: httperror.New(http.StatusUnauthorized, response.UnauthorizedMessage),
+		},
+	}
 
-// 			tc.mock(t, r)
-// 			err := u.CancelOrderStatus(context.Background(), tc.userID, tc.requestBody)
-// 			if err != nil {
-// 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
-// 			}
-// 		})
-// 	}
-// }
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, mock, _ := sqlmock.New()
+			mock.ExpectBegin()
+			if tc.expectedErr == nil {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+			r := mocks.NewRepository(t)
+			n := notificationMocks.NewUseCase(t)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, n)
+
+			tc.mock(t, r, n)
+			err := u.CancelOrderStatus(context.Background(), "user", requestBody)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
 
 func Test_sellerUC_GetAllPromotionSeller(t *testing.T) {
 	value := int64(1)
@@ -730,7 +789,7 @@ func Test_sellerUC_GetAllPromotionSeller(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetAllPromotionSeller(context.Background(), tc.userID, tc.promoStatusID, tc.pgn)
@@ -782,7 +841,7 @@ func Test_sellerUC_GetAllPromotionSeller(t *testing.T) {
 // 			sql, mock, _ := sqlmock.New()
 // 			mock.ExpectBegin()
 // 			r := mocks.NewRepository(t)
-// 			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+// 			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 // 			tc.mock(t, r)
 // 			_, err := u.CreatePromotionSeller(context.Background(), tc.userID, tc.requestBody)
@@ -822,7 +881,7 @@ func Test_sellerUC_GetAllPromotionSeller(t *testing.T) {
 // 			sql, mock, _ := sqlmock.New()
 // 			mock.ExpectBegin()
 // 			r := mocks.NewRepository(t)
-// 			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+// 			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 // 			tc.mock(t, r)
 // 			err := u.UpdatePromotionSeller(context.Background(), tc.userID, tc.requestBody)
@@ -835,6 +894,7 @@ func Test_sellerUC_GetAllPromotionSeller(t *testing.T) {
 
 func Test_sellerUC_GetDetailPromotionSellerByID(t *testing.T) {
 	float64Value := float64(1)
+	moneyValue := model.NewMoney(1)
 	testCase := []struct {
 		name             string
 		userID           string
@@ -852,12 +912,12 @@ func Test_sellerUC_GetDetailPromotionSellerByID(t *testing.T) {
 			mock: func(t *testing.T, r *mocks.Repository) {
 				r.On("GetShopIDByUserID", mock.Anything, mock.Anything).Return("008dc24d-1f30-4e13-823f-d62972f416df", nil)
 				r.On("GetDetailPromotionSellerByID", mock.Anything, mock.Anything).Return(&body.PromotionDetailSeller{
-					MinProductPrice:         &float64Value,
-					MaxDiscountPrice:        &float64Value,
+					MinProductPrice:         &moneyValue,
+					MaxDiscountPrice:        &moneyValue,
 					DiscountPercentage:      &float64Value,
 					MinPrice:                1000,
 					MaxPrice:                10000,
-					DiscountFixPrice:        &float64Value,
+					DiscountFixPrice:        &moneyValue,
 					ProductMinDiscountPrice: 1000,
 					ProductSubMinPrice:      1000,
 					ProductMaxDiscountPrice: 1000,
@@ -873,7 +933,7 @@ func Test_sellerUC_GetDetailPromotionSellerByID(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetDetailPromotionSellerByID(context.Background(), tc.shopProductPromo)
@@ -917,7 +977,7 @@ func Test_sellerUC_GetProductWithoutPromotionSeller(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetProductWithoutPromotionSeller(context.Background(), tc.userID, tc.productName, tc.pgn)
@@ -982,7 +1042,7 @@ func Test_sellerUC_GetRefundOrderSeller(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			_, err := u.GetRefundOrderSeller(context.Background(), tc.userID, tc.orderID)
@@ -1006,7 +1066,7 @@ func Test_sellerUC_CreateRefundThreadSeller(t *testing.T) {
 		productName      string
 		orderID          string
 		requestBody      *body.CreateRefundThreadRequest
-		mock             func(t *testing.T, r *mocks.Repository)
+		mock             func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase)
 		expectedErr      error
 	}{
 		{
@@ -1015,11 +1075,12 @@ func Test_sellerUC_CreateRefundThreadSeller(t *testing.T) {
 			requestBody: &body.CreateRefundThreadRequest{
 				RefundID: "008dc24d-1f30-4e13-823f-d62972f416df",
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
 				r.On("GetShopIDByUserID", mock.Anything, mock.Anything).Return("008dc24d-1f30-4e13-823f-d62972f416df", nil)
 				r.On("GetRefundOrderByID", mock.Anything, mock.Anything).Return(&model.Refund{OrderID: uuidString1}, nil)
 				r.On("GetOrderModelByID", mock.Anything, mock.Anything).Return(&model.OrderModel{ShopID: uuidString1, OrderStatusID: 6}, nil)
-				r.On("CreateRefundThreadSeller", mock.Anything, mock.Anything).Return(nil)
+				r.On("CreateRefundThreadSeller", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyRefundReply", mock.Anything, mock.Anything, "008dc24d-1f30-4e13-823f-d62972f416df", "", true).Return(nil)
 			},
 			expectedErr: nil,
 		},
@@ -1030,9 +1091,10 @@ func Test_sellerUC_CreateRefundThreadSeller(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			n := notificationMocks.NewUseCase(t)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, n)
 
-			tc.mock(t, r)
+			tc.mock(t, r, n)
 			err := u.CreateRefundThreadSeller(context.Background(), tc.userID, tc.requestBody)
 			if err != nil {
 				assert.Equal(t, err, tc.expectedErr)
@@ -1078,7 +1140,7 @@ func Test_sellerUC_UpdateRefundAccept(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			err := u.UpdateRefundAccept(context.Background(), tc.userID, tc.requestBody)
@@ -1127,7 +1189,7 @@ func Test_sellerUC_UpdateRefundReject(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil)
 
 			tc.mock(t, r)
 			err := u.UpdateRefundReject(context.Background(), tc.userID, tc.requestBody)
@@ -1137,3 +1199,196 @@ func Test_sellerUC_UpdateRefundReject(t *testing.T) {
 		})
 	}
 }
+
+func Test_sellerUC_UpdateExpiredAtOrder(t *testing.T) {
+	orderID, _ := uuid.Parse("008dc24d-1f30-4e13-823f-d62972f416df")
+	testCase := []struct {
+		name         string
+		mock         func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase)
+		expectedRows int64
+		expectedErr  error
+	}{
+		{
+			name: "success release stock of expired transaction",
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
+				r.On("GetTransactionsExpired", mock.Anything).Return([]*model.Transaction{{}}, nil)
+				r.On("CancelExpiredTransaction", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+				r.On("GetOrderByTransactionID", mock.Anything, mock.Anything, mock.Anything).Return([]*model.OrderModel{{ID: orderID}}, nil)
+				r.On("UpdateOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("ReleaseStockReservations", mock.Anything, mock.Anything, orderID.String()).Return(int64(2), nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, orderID.String(), constant.OrderStatusCanceled).Return(nil)
+				r.On("ReleaseVoucherRedemptions", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+				r.On("ReleasePromotionClaims", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+			},
+			expectedRows: 1,
+			expectedErr:  nil,
+		},
+		{
+			name: "error release promotion claims",
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
+				r.On("GetTransactionsExpired", mock.Anything).Return([]*model.Transaction{{}}, nil)
+				r.On("CancelExpiredTransaction", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+				r.On("GetOrderByTransactionID", mock.Anything, mock.Anything, mock.Anything).Return([]*model.OrderModel{{ID: orderID}}, nil)
+				r.On("UpdateOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("ReleaseStockReservations", mock.Anything, mock.Anything, orderID.String()).Return(int64(2), nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, orderID.String(), constant.OrderStatusCanceled).Return(nil)
+				r.On("ReleaseVoucherRedemptions", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+				r.On("ReleasePromotionClaims", mock.Anything, mock.Anything, mock.Anything).Return(int64(0), errors.New("test"))
+			},
+			expectedRows: 0,
+			expectedErr:  errors.New("test"),
+		},
+		{
+			name: "error release stock",
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
+				r.On("GetTransactionsExpired", mock.Anything).Return([]*model.Transaction{{}}, nil)
+				r.On("CancelExpiredTransaction", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+				r.On("GetOrderByTransactionID", mock.Anything, mock.Anything, mock.Anything).Return([]*model.OrderModel{{ID: orderID}}, nil)
+				r.On("UpdateOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("ReleaseStockReservations", mock.Anything, mock.Anything, orderID.String()).Return(int64(0), errors.New("test"))
+			},
+			expectedRows: 0,
+			expectedErr:  errors.New("test"),
+		},
+		{
+			name: "skip transaction paid since it was read",
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
+				r.On("GetTransactionsExpired", mock.Anything).Return([]*model.Transaction{{}}, nil)
+				r.On("CancelExpiredTransaction", mock.Anything, mock.Anything, mock.Anything).Return(int64(0), nil)
+			},
+			expectedRows: 0,
+			expectedErr:  nil,
+		},
+		{
+			name: "error CancelExpiredTransaction",
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
+				r.On("GetTransactionsExpired", mock.Anything).Return([]*model.Transaction{{}}, nil)
+				r.On("CancelExpiredTransaction", mock.Anything, mock.Anything, mock.Anything).Return(int64(0), errors.New("test"))
+			},
+			expectedRows: 0,
+			expectedErr:  errors.New("test"),
+		},
+		{
+			name: "error GetTransactionsExpired",
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
+				r.On("GetTransactionsExpired", mock.Anything).Return(nil, errors.New("test"))
+			},
+			expectedRows: 0,
+			expectedErr:  errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, mock, _ := sqlmock.New()
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			r := mocks.NewRepository(t)
+			n := notificationMocks.NewUseCase(t)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, n)
+
+			tc.mock(t, r, n)
+			report, err := u.UpdateExpiredAtOrder(context.Background())
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedRows, report.RowsAffected)
+		})
+	}
+}
+
+func Test_sellerUC_UpdateExpiredAtOrderPaidConcurrently(t *testing.T) {
+	paidID, expiredID, orderID := uuid.New(), uuid.New(), uuid.New()
+	sql, sqlMock, _ := sqlmock.New()
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+	r := mocks.NewRepository(t)
+	n := notificationMocks.NewUseCase(t)
+	u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, n)
+
+	r.On("GetTransactionsExpired", mock.Anything).Return([]*model.Transaction{{ID: paidID}, {ID: expiredID}}, nil)
+	r.On("CancelExpiredTransaction", mock.Anything, mock.Anything, paidID.String()).Return(int64(0), nil)
+	r.On("CancelExpiredTransaction", mock.Anything, mock.Anything, expiredID.String()).Return(int64(1), nil)
+	r.On("GetOrderByTransactionID", mock.Anything, mock.Anything, expiredID.String()).Return([]*model.OrderModel{{ID: orderID}}, nil)
+	r.On("UpdateOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	r.On("ReleaseStockReservations", mock.Anything, mock.Anything, orderID.String()).Return(int64(1), nil)
+	n.On("NotifyOrderStatus", mock.Anything, mock.Anything, orderID.String(), constant.OrderStatusCanceled).Return(nil)
+	r.On("ReleaseVoucherRedemptions", mock.Anything, mock.Anything, expiredID.String()).Return(int64(0), nil)
+	r.On("ReleasePromotionClaims", mock.Anything, mock.Anything, expiredID.String()).Return(int64(1), nil)
+
+	report, err := u.UpdateExpiredAtOrder(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), report.RowsAffected)
+	r.AssertNotCalled(t, "ReleasePromotionClaims", mock.Anything, mock.Anything, paidID.String())
+	r.AssertNotCalled(t, "ReleaseVoucherRedemptions", mock.Anything, mock.Anything, paidID.String())
+}
+
+func Test_sellerUC_UpdateOnDeliveryOrder(t *testing.T) {
+	orderID, _ := uuid.Parse("008dc24d-1f30-4e13-823f-d62972f416df")
+	arrived := sql.NullTime{Valid: true, Time: time.Now().Add(-time.Hour)}
+	testCase := []struct {
+		name         string
+		mock         func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase)
+		expectedRows int64
+		expectedErr  error
+	}{
+		{
+			name: "success deliver arrived order",
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
+				r.On("GetOrdersOnDelivery", mock.Anything).Return([]*model.OrderModel{
+					{ID: orderID, OrderStatusID: constant.OrderStatusOnDelivery, ArrivedAt: arrived},
+					{ID: uuid.New(), OrderStatusID: constant.OrderStatusOnDelivery},
+				}, nil)
+				r.On("ChangeOrderStatus", mock.Anything, mock.Anything, body.ChangeOrderStatusRequest{
+					OrderID: orderID.String(), OrderStatusID: "5"}).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, orderID.String(), constant.OrderStatusDelivered).Return(nil)
+			},
+			expectedRows: 1,
+			expectedErr:  nil,
+		},
+		{
+			name: "error NotifyOrderStatus",
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
+				r.On("GetOrdersOnDelivery", mock.Anything).Return([]*model.OrderModel{
+					{ID: orderID, OrderStatusID: constant.OrderStatusOnDelivery, ArrivedAt: arrived},
+				}, nil)
+				r.On("ChangeOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+			},
+			expectedRows: 0,
+			expectedErr:  errors.New("test"),
+		},
+		{
+			name: "error GetOrdersOnDelivery",
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
+				r.On("GetOrdersOnDelivery", mock.Anything).Return(nil, errors.New("test"))
+			},
+			expectedRows: 0,
+			expectedErr:  errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, mock, _ := sqlmock.New()
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			r := mocks.NewRepository(t)
+			n := notificationMocks.NewUseCase(t)
+			u := NewSellerUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, n)
+
+			tc.mock(t, r, n)
+			report, err := u.UpdateOnDeliveryOrder(context.Background())
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedRows, report.RowsAffected)
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package twofactor
+
+import "github.com/gin-gonic/gin"
+
+type Handlers interface {
+	Enroll(c *gin.Context)
+	Confirm(c *gin.Context)
+	Disable(c *gin.Context)
+}
@@ -0,0 +1,51 @@
+package body
+
+import (
+	"murakali/pkg/httperror"
+	"murakali/pkg/response"
+	"net/http"
+	"strings"
+)
+
+const FieldCannotBeEmptyMessage = "Field cannot be empty."
+
+type UnprocessableEntity struct {
+	Fields map[string]string `json:"fields"`
+}
+
+type CodeRequest struct {
+	Code string `json:"code"`
+}
+
+type EnrollResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type ConfirmResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (r *CodeRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"code": "",
+		},
+	}
+
+	r.Code = strings.TrimSpace(r.Code)
+	if r.Code == "" {
+		unprocessableEntity = true
+		entity.Fields["code"] = FieldCannotBeEmptyMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
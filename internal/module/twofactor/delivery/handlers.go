@@ -0,0 +1,113 @@
+package delivery
+
+import (
+	"errors"
+	"murakali/config"
+	"murakali/internal/module/twofactor"
+	"murakali/internal/module/twofactor/delivery/body"
+	"murakali/pkg/attempt"
+	"murakali/pkg/httperror"
+	"murakali/pkg/logger"
+	"murakali/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type twoFactorHandlers struct {
+	cfg         *config.Config
+	twoFactorUC twofactor.UseCase
+	logger      logger.Logger
+}
+
+func NewTwoFactorHandlers(cfg *config.Config, twoFactorUC twofactor.UseCase, log logger.Logger) twofactor.Handlers {
+	return &twoFactorHandlers{cfg: cfg, twoFactorUC: twoFactorUC, logger: log}
+}
+
+func (h *twoFactorHandlers) Enroll(c *gin.Context) {
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	enrollment, err := h.twoFactorUC.Enroll(c, userID.(string))
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, body.EnrollResponse{Secret: enrollment.Secret, URI: enrollment.URI}, http.StatusOK)
+}
+
+func (h *twoFactorHandlers) Confirm(c *gin.Context) {
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	var requestBody body.CodeRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	codes, err := h.twoFactorUC.Confirm(c, userID.(string), requestBody.Code)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, body.ConfirmResponse{RecoveryCodes: codes}, http.StatusOK)
+}
+
+func (h *twoFactorHandlers) Disable(c *gin.Context) {
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	var requestBody body.CodeRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	if err := h.twoFactorUC.Disable(c, userID.(string), requestBody.Code); err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, nil, http.StatusOK)
+}
+
+func (h *twoFactorHandlers) errorResponse(c *gin.Context, err error) {
+	var locked *attempt.LockedError
+	if errors.As(err, &locked) {
+		response.TooManyRequestsResponse(c.Writer, response.TooManyAttemptsMessage, locked.RetryAfterSeconds())
+		return
+	}
+
+	var e *httperror.Error
+	if !errors.As(err, &e) {
+		h.logger.Errorf("HandlerTwoFactor, Error: %s", err)
+		response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+		return
+	}
+
+	response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+}
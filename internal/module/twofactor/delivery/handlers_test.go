@@ -0,0 +1,195 @@
+package delivery
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"murakali/config"
+	"murakali/internal/model"
+	"murakali/internal/module/twofactor/delivery/body"
+	"murakali/internal/module/twofactor/mocks"
+	"murakali/pkg/attempt"
+	"murakali/pkg/httperror"
+	"murakali/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestLogger() logger.Logger {
+	cfg := &config.Config{
+		Logger: config.LoggerConfig{
+			Development:       true,
+			DisableCaller:     false,
+			DisableStacktrace: false,
+			Encoding:          "json",
+			Level:             "info",
+		},
+	}
+
+	appLogger := logger.NewAPILogger(cfg)
+	appLogger.InitLogger()
+
+	return appLogger
+}
+
+func newTestContext(t *testing.T, method string, requestBody interface{}, userID string) (*gin.Context, *httptest.ResponseRecorder) {
+	jsonValue, err := json.Marshal(requestBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rr)
+	c.Request = httptest.NewRequest(method, "/api/v1/user/2fa", bytes.NewBuffer(jsonValue))
+	c.Request.Header.Set("Content-Type", "application/json")
+	if userID != "" {
+		c.Set("userID", userID)
+	}
+
+	return c, rr
+}
+
+func TestTwoFactorHandlers_Enroll(t *testing.T) {
+	testCase := []struct {
+		name     string
+		userID   string
+		mock     func(s *mocks.UseCase)
+		expected int
+	}{
+		{
+			name:   "success enroll",
+			userID: "user",
+			mock: func(s *mocks.UseCase) {
+				s.On("Enroll", mock.Anything, "user").Return(&model.TOTPEnrollment{Secret: "secret", URI: "otpauth://totp/x"}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "error unauthorized",
+			mock:     func(s *mocks.UseCase) {},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:   "error already enabled",
+			userID: "user",
+			mock: func(s *mocks.UseCase) {
+				s.On("Enroll", mock.Anything, "user").Return(nil, httperror.New(http.StatusBadRequest, "test"))
+			},
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			c, rr := newTestContext(t, http.MethodPost, nil, tc.userID)
+
+			s := mocks.NewUseCase(t)
+			tc.mock(s)
+
+			h := NewTwoFactorHandlers(&config.Config{}, s, newTestLogger())
+			h.Enroll(c)
+
+			assert.Equal(t, tc.expected, rr.Code)
+		})
+	}
+}
+
+func TestTwoFactorHandlers_Confirm(t *testing.T) {
+	testCase := []struct {
+		name     string
+		body     body.CodeRequest
+		mock     func(s *mocks.UseCase)
+		expected int
+	}{
+		{
+			name: "success confirm",
+			body: body.CodeRequest{Code: "081804"},
+			mock: func(s *mocks.UseCase) {
+				s.On("Confirm", mock.Anything, "user", "081804").Return([]string{"ABCDE-12345"}, nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "error empty code",
+			body:     body.CodeRequest{Code: " "},
+			mock:     func(s *mocks.UseCase) {},
+			expected: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "error internal",
+			body: body.CodeRequest{Code: "081804"},
+			mock: func(s *mocks.UseCase) {
+				s.On("Confirm", mock.Anything, "user", "081804").Return(nil, errors.New("test"))
+			},
+			expected: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			c, rr := newTestContext(t, http.MethodPost, tc.body, "user")
+
+			s := mocks.NewUseCase(t)
+			tc.mock(s)
+
+			h := NewTwoFactorHandlers(&config.Config{}, s, newTestLogger())
+			h.Confirm(c)
+
+			assert.Equal(t, tc.expected, rr.Code)
+		})
+	}
+}
+
+func TestTwoFactorHandlers_Disable(t *testing.T) {
+	testCase := []struct {
+		name     string
+		body     body.CodeRequest
+		mock     func(s *mocks.UseCase)
+		expected int
+	}{
+		{
+			name: "success disable",
+			body: body.CodeRequest{Code: "081804"},
+			mock: func(s *mocks.UseCase) {
+				s.On("Disable", mock.Anything, "user", "081804").Return(nil)
+			},
+			expected: http.StatusOK,
+		},
+		{
+			name: "error invalid code",
+			body: body.CodeRequest{Code: "000000"},
+			mock: func(s *mocks.UseCase) {
+				s.On("Disable", mock.Anything, "user", "000000").Return(httperror.New(http.StatusBadRequest, "test"))
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			name: "error locked out",
+			body: body.CodeRequest{Code: "000000"},
+			mock: func(s *mocks.UseCase) {
+				s.On("Disable", mock.Anything, "user", "000000").Return(&attempt.LockedError{RetryAfter: time.Minute})
+			},
+			expected: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			c, rr := newTestContext(t, http.MethodDelete, tc.body, "user")
+
+			s := mocks.NewUseCase(t)
+			tc.mock(s)
+
+			h := NewTwoFactorHandlers(&config.Config{}, s, newTestLogger())
+			h.Disable(c)
+
+			assert.Equal(t, tc.expected, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package delivery
+
+import (
+	"murakali/internal/middleware"
+	"murakali/internal/module/twofactor"
+
+	"github.com/gin-gonic/gin"
+)
+
+func MapTwoFactorRoutes(twoFactorGroup *gin.RouterGroup, h twofactor.Handlers, mw *middleware.MWManager) {
+	twoFactorGroup.Use(mw.AuthJWTMiddleware())
+	twoFactorGroup.POST("/enroll", h.Enroll)
+	twoFactorGroup.POST("/confirm", h.Confirm)
+	twoFactorGroup.DELETE("", h.Disable)
+}
@@ -0,0 +1,188 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "murakali/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	postgre "murakali/pkg/postgre"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// ConfirmTOTP provides a mock function with given fields: ctx, tx, userID, step
+func (_m *Repository) ConfirmTOTP(ctx context.Context, tx postgre.Transaction, userID string, step int64) error {
+	ret := _m.Called(ctx, tx, userID, step)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string, int64) error); ok {
+		r0 = rf(ctx, tx, userID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRecoveryCodes provides a mock function with given fields: ctx, tx, userID, codeHashes
+func (_m *Repository) CreateRecoveryCodes(ctx context.Context, tx postgre.Transaction, userID string, codeHashes []string) error {
+	ret := _m.Called(ctx, tx, userID, codeHashes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string, []string) error); ok {
+		r0 = rf(ctx, tx, userID, codeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRecoveryCodes provides a mock function with given fields: ctx, tx, userID
+func (_m *Repository) DeleteRecoveryCodes(ctx context.Context, tx postgre.Transaction, userID string) error {
+	ret := _m.Called(ctx, tx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) error); ok {
+		r0 = rf(ctx, tx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTOTP provides a mock function with given fields: ctx, tx, userID
+func (_m *Repository) DeleteTOTP(ctx context.Context, tx postgre.Transaction, userID string) error {
+	ret := _m.Called(ctx, tx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) error); ok {
+		r0 = rf(ctx, tx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetTOTP provides a mock function with given fields: ctx, userID
+func (_m *Repository) GetTOTP(ctx context.Context, userID string) (*model.UserTOTP, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *model.UserTOTP
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.UserTOTP); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserTOTP)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserEmailByID provides a mock function with given fields: ctx, userID
+func (_m *Repository) GetUserEmailByID(ctx context.Context, userID string) (string, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertTOTP provides a mock function with given fields: ctx, userID, secret
+func (_m *Repository) UpsertTOTP(ctx context.Context, userID string, secret string) error {
+	ret := _m.Called(ctx, userID, secret)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, secret)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseRecoveryCode provides a mock function with given fields: ctx, userID, codeHash
+func (_m *Repository) UseRecoveryCode(ctx context.Context, userID string, codeHash string) (bool, error) {
+	ret := _m.Called(ctx, userID, codeHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userID, codeHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UseTOTPStep provides a mock function with given fields: ctx, userID, step
+func (_m *Repository) UseTOTPStep(ctx context.Context, userID string, step int64) (bool, error) {
+	ret := _m.Called(ctx, userID, step)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) bool); ok {
+		r0 = rf(ctx, userID, step)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, userID, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,139 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "murakali/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UseCase is an autogenerated mock type for the UseCase type
+type UseCase struct {
+	mock.Mock
+}
+
+// Confirm provides a mock function with given fields: ctx, userID, code
+func (_m *UseCase) Confirm(ctx context.Context, userID string, code string) ([]string, error) {
+	ret := _m.Called(ctx, userID, code)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Disable provides a mock function with given fields: ctx, userID, code
+func (_m *UseCase) Disable(ctx context.Context, userID string, code string) error {
+	ret := _m.Called(ctx, userID, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Enroll provides a mock function with given fields: ctx, userID
+func (_m *UseCase) Enroll(ctx context.Context, userID string) (*model.TOTPEnrollment, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *model.TOTPEnrollment
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.TOTPEnrollment); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TOTPEnrollment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsEnabled provides a mock function with given fields: ctx, userID
+func (_m *UseCase) IsEnabled(ctx context.Context, userID string) (bool, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyLogin provides a mock function with given fields: ctx, userID, code
+func (_m *UseCase) VerifyLogin(ctx context.Context, userID string, code string) error {
+	ret := _m.Called(ctx, userID, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyStepUp provides a mock function with given fields: ctx, userID, code
+func (_m *UseCase) VerifyStepUp(ctx context.Context, userID string, code string) error {
+	ret := _m.Called(ctx, userID, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewUseCase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUseCase creates a new instance of UseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUseCase(t mockConstructorTestingTNewUseCase) *UseCase {
+	mock := &UseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
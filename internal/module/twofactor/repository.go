@@ -0,0 +1,19 @@
+package twofactor
+
+import (
+	"context"
+	"murakali/internal/model"
+	"murakali/pkg/postgre"
+)
+
+type Repository interface {
+	GetUserEmailByID(ctx context.Context, userID string) (string, error)
+	GetTOTP(ctx context.Context, userID string) (*model.UserTOTP, error)
+	UpsertTOTP(ctx context.Context, userID, secret string) error
+	ConfirmTOTP(ctx context.Context, tx postgre.Transaction, userID string, step int64) error
+	UseTOTPStep(ctx context.Context, userID string, step int64) (bool, error)
+	DeleteTOTP(ctx context.Context, tx postgre.Transaction, userID string) error
+	CreateRecoveryCodes(ctx context.Context, tx postgre.Transaction, userID string, codeHashes []string) error
+	DeleteRecoveryCodes(ctx context.Context, tx postgre.Transaction, userID string) error
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+const (
+	GetUserEmailByIDQuery = `SELECT "email" FROM "user" WHERE "id" = $1`
+
+	GetTOTPQuery = `SELECT "user_id", "secret", "last_used_step", "confirmed_at" FROM "user_totp" WHERE "user_id" = $1`
+
+	UpsertTOTPQuery = `INSERT INTO "user_totp" ("user_id", "secret") VALUES ($1, $2)
+	ON CONFLICT ("user_id") DO UPDATE SET "secret" = EXCLUDED."secret", "last_used_step" = 0, "confirmed_at" = NULL,
+		"updated_at" = NOW()`
+
+	ConfirmTOTPQuery = `UPDATE "user_totp" SET "confirmed_at" = NOW(), "last_used_step" = $2, "updated_at" = NOW()
+	WHERE "user_id" = $1`
+
+	UseTOTPStepQuery = `UPDATE "user_totp" SET "last_used_step" = $2, "updated_at" = NOW()
+	WHERE "user_id" = $1 AND "last_used_step" < $2`
+
+	DeleteTOTPQuery = `DELETE FROM "user_totp" WHERE "user_id" = $1`
+
+	CreateRecoveryCodeQuery = `INSERT INTO "user_recovery_code" ("user_id", "code_hash") VALUES ($1, $2)`
+
+	DeleteRecoveryCodesQuery = `DELETE FROM "user_recovery_code" WHERE "user_id" = $1`
+
+	UseRecoveryCodeQuery = `UPDATE "user_recovery_code" SET "used_at" = NOW()
+	WHERE "user_id" = $1 AND "code_hash" = $2 AND "used_at" IS NULL`
+)
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"murakali/internal/model"
+	"murakali/internal/module/twofactor"
+	"murakali/pkg/postgre"
+)
+
+type twoFactorRepo struct {
+	PSQL *sql.DB
+}
+
+func NewTwoFactorRepository(psql *sql.DB) twofactor.Repository {
+	return &twoFactorRepo{
+		PSQL: psql,
+	}
+}
+
+func (r *twoFactorRepo) GetUserEmailByID(ctx context.Context, userID string) (string, error) {
+	var email string
+	if err := r.PSQL.QueryRowContext(ctx, GetUserEmailByIDQuery, userID).Scan(&email); err != nil {
+		return "", err
+	}
+
+	return email, nil
+}
+
+func (r *twoFactorRepo) GetTOTP(ctx context.Context, userID string) (*model.UserTOTP, error) {
+	var userTOTP model.UserTOTP
+	if err := r.PSQL.QueryRowContext(ctx, GetTOTPQuery, userID).
+		Scan(&userTOTP.UserID, &userTOTP.Secret, &userTOTP.LastUsedStep, &userTOTP.ConfirmedAt); err != nil {
+		return nil, err
+	}
+
+	return &userTOTP, nil
+}
+
+func (r *twoFactorRepo) UpsertTOTP(ctx context.Context, userID, secret string) error {
+	_, err := r.PSQL.ExecContext(ctx, UpsertTOTPQuery, userID, secret)
+	return err
+}
+
+func (r *twoFactorRepo) ConfirmTOTP(ctx context.Context, tx postgre.Transaction, userID string, step int64) error {
+	_, err := tx.ExecContext(ctx, ConfirmTOTPQuery, userID, step)
+	return err
+}
+
+// UseTOTPStep records step as the last accepted one. It returns false when an
+// equal or later step was already used, so a code can only be used once.
+func (r *twoFactorRepo) UseTOTPStep(ctx context.Context, userID string, step int64) (bool, error) {
+	res, err := r.PSQL.ExecContext(ctx, UseTOTPStepQuery, userID, step)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *twoFactorRepo) DeleteTOTP(ctx context.Context, tx postgre.Transaction, userID string) error {
+	_, err := tx.ExecContext(ctx, DeleteTOTPQuery, userID)
+	return err
+}
+
+func (r *twoFactorRepo) CreateRecoveryCodes(ctx context.Context, tx postgre.Transaction, userID string, codeHashes []string) error {
+	for _, codeHash := range codeHashes {
+		if _, err := tx.ExecContext(ctx, CreateRecoveryCodeQuery, userID, codeHash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *twoFactorRepo) DeleteRecoveryCodes(ctx context.Context, tx postgre.Transaction, userID string) error {
+	_, err := tx.ExecContext(ctx, DeleteRecoveryCodesQuery, userID)
+	return err
+}
+
+func (r *twoFactorRepo) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	res, err := r.PSQL.ExecContext(ctx, UseRecoveryCodeQuery, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
@@ -0,0 +1,15 @@
+package twofactor
+
+import (
+	"context"
+	"murakali/internal/model"
+)
+
+type UseCase interface {
+	Enroll(ctx context.Context, userID string) (*model.TOTPEnrollment, error)
+	Confirm(ctx context.Context, userID, code string) ([]string, error)
+	Disable(ctx context.Context, userID, code string) error
+	IsEnabled(ctx context.Context, userID string) (bool, error)
+	VerifyLogin(ctx context.Context, userID, code string) error
+	VerifyStepUp(ctx context.Context, userID, code string) error
+}
@@ -0,0 +1,267 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"murakali/config"
+	"murakali/internal/constant"
+	"murakali/internal/model"
+	"murakali/internal/module/twofactor"
+	"murakali/internal/util"
+	"murakali/pkg/attempt"
+	"murakali/pkg/httperror"
+	"murakali/pkg/postgre"
+	"murakali/pkg/response"
+	"murakali/pkg/totp"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type twoFactorUC struct {
+	cfg           *config.Config
+	txRepo        *postgre.TxRepo
+	twoFactorRepo twofactor.Repository
+	attempts      attempt.Limiter
+	now           func() time.Time
+}
+
+var (
+	confirmPolicy = attempt.Policy{
+		Name: "2fa_confirm", MaxAttempts: 5,
+		Window: 15 * time.Minute, Lockout: 15 * time.Minute, MaxLockout: 24 * time.Hour, Decay: 24 * time.Hour,
+	}
+	disablePolicy = attempt.Policy{
+		Name: "2fa_disable", MaxAttempts: 5,
+		Window: 15 * time.Minute, Lockout: 15 * time.Minute, MaxLockout: 24 * time.Hour, Decay: 24 * time.Hour,
+	}
+)
+
+func NewTwoFactorUseCase(cfg *config.Config, txRepo *postgre.TxRepo, twoFactorRepo twofactor.Repository,
+	attempts attempt.Limiter) twofactor.UseCase {
+	return &twoFactorUC{cfg: cfg, txRepo: txRepo, twoFactorRepo: twoFactorRepo, attempts: attempts, now: time.Now}
+}
+
+// Enroll starts over with a new secret, which only takes effect once Confirm
+// sees a code from it. An account that already has 2FA must disable it first.
+func (u *twoFactorUC) Enroll(ctx context.Context, userID string) (*model.TOTPEnrollment, error) {
+	userTOTP, err := u.getTOTP(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userTOTP != nil && userTOTP.ConfirmedAt.Valid {
+		return nil, httperror.New(http.StatusBadRequest, response.TwoFactorAlreadyEnabled)
+	}
+
+	email, err := u.twoFactorRepo.GetUserEmailByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.twoFactorRepo.UpsertTOTP(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+
+	return &model.TOTPEnrollment{Secret: secret, URI: totp.URI(secret, constant.TOTPIssuer, email)}, nil
+}
+
+// Confirm turns on 2FA with a code from the enrolled secret and returns the
+// recovery codes. They are only stored hashed, so this is the one time they
+// can be shown. Wrong codes are limited like Disable, the secret would
+// otherwise be open to guessing until it is confirmed.
+func (u *twoFactorUC) Confirm(ctx context.Context, userID, code string) ([]string, error) {
+	if err := u.attempts.Check(ctx, confirmPolicy, attempt.Account(userID)); err != nil {
+		return nil, err
+	}
+
+	userTOTP, err := u.getTOTP(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userTOTP == nil {
+		return nil, httperror.New(http.StatusBadRequest, response.TwoFactorNotEnrolled)
+	}
+
+	if userTOTP.ConfirmedAt.Valid {
+		return nil, httperror.New(http.StatusBadRequest, response.TwoFactorAlreadyEnabled)
+	}
+
+	step, ok := totp.Validate(userTOTP.Secret, code, u.now())
+	if !ok {
+		if errFail := u.attempts.Fail(ctx, confirmPolicy, attempt.Account(userID)); errFail != nil {
+			return nil, errFail
+		}
+
+		return nil, httperror.New(http.StatusBadRequest, response.TwoFactorCodeInvalid)
+	}
+
+	if err := u.attempts.Reset(ctx, confirmPolicy, attempt.Account(userID)); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, constant.TOTPRecoveryCodeCount)
+	codeHashes := make([]string, constant.TOTPRecoveryCodeCount)
+	for i := range codes {
+		raw, errGenerate := util.GenerateRandomAlpaNumeric(10)
+		if errGenerate != nil {
+			return nil, errGenerate
+		}
+
+		codes[i] = raw[:5] + "-" + raw[5:]
+		codeHashes[i] = hashRecoveryCode(codes[i])
+	}
+
+	err = u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		if err := u.twoFactorRepo.ConfirmTOTP(ctx, tx, userID, step); err != nil {
+			return err
+		}
+
+		if err := u.twoFactorRepo.DeleteRecoveryCodes(ctx, tx, userID); err != nil {
+			return err
+		}
+
+		return u.twoFactorRepo.CreateRecoveryCodes(ctx, tx, userID, codeHashes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Disable turns off 2FA after checking a current code or a recovery code.
+func (u *twoFactorUC) Disable(ctx context.Context, userID, code string) error {
+	if err := u.attempts.Check(ctx, disablePolicy, attempt.Account(userID)); err != nil {
+		return err
+	}
+
+	if err := u.VerifyLogin(ctx, userID, code); err != nil {
+		var e *httperror.Error
+		if errors.As(err, &e) {
+			if errFail := u.attempts.Fail(ctx, disablePolicy, attempt.Account(userID)); errFail != nil {
+				return errFail
+			}
+		}
+
+		return err
+	}
+
+	if err := u.attempts.Reset(ctx, disablePolicy, attempt.Account(userID)); err != nil {
+		return err
+	}
+
+	return u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		if err := u.twoFactorRepo.DeleteRecoveryCodes(ctx, tx, userID); err != nil {
+			return err
+		}
+
+		return u.twoFactorRepo.DeleteTOTP(ctx, tx, userID)
+	})
+}
+
+func (u *twoFactorUC) IsEnabled(ctx context.Context, userID string) (bool, error) {
+	userTOTP, err := u.getTOTP(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return userTOTP != nil && userTOTP.ConfirmedAt.Valid, nil
+}
+
+// VerifyLogin checks the second factor of a sign in, which may also be one of
+// the recovery codes for a user who lost the device.
+func (u *twoFactorUC) VerifyLogin(ctx context.Context, userID, code string) error {
+	userTOTP, err := u.getTOTP(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if userTOTP == nil || !userTOTP.ConfirmedAt.Valid {
+		return httperror.New(http.StatusBadRequest, response.TwoFactorNotEnabled)
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		return u.verifyCode(ctx, userTOTP, code)
+	}
+
+	used, err := u.twoFactorRepo.UseRecoveryCode(ctx, userID, hashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+
+	if !used {
+		return httperror.New(http.StatusBadRequest, response.TwoFactorCodeInvalid)
+	}
+
+	return nil
+}
+
+// VerifyStepUp asks users with 2FA for a current code before a sensitive
+// operation. Users without 2FA pass through.
+func (u *twoFactorUC) VerifyStepUp(ctx context.Context, userID, code string) error {
+	userTOTP, err := u.getTOTP(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if userTOTP == nil || !userTOTP.ConfirmedAt.Valid {
+		return nil
+	}
+
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return httperror.New(http.StatusBadRequest, response.TwoFactorCodeRequired)
+	}
+
+	return u.verifyCode(ctx, userTOTP, code)
+}
+
+// verifyCode accepts a code once. Its step has to be later than the last one
+// used, so a code seen by someone else is worthless after the user used it.
+func (u *twoFactorUC) verifyCode(ctx context.Context, userTOTP *model.UserTOTP, code string) error {
+	step, ok := totp.Validate(userTOTP.Secret, code, u.now())
+	if !ok || step <= userTOTP.LastUsedStep {
+		return httperror.New(http.StatusBadRequest, response.TwoFactorCodeInvalid)
+	}
+
+	used, err := u.twoFactorRepo.UseTOTPStep(ctx, userTOTP.UserID.String(), step)
+	if err != nil {
+		return err
+	}
+
+	if !used {
+		return httperror.New(http.StatusBadRequest, response.TwoFactorCodeInvalid)
+	}
+
+	return nil
+}
+
+func (u *twoFactorUC) getTOTP(ctx context.Context, userID string) (*model.UserTOTP, error) {
+	userTOTP, err := u.twoFactorRepo.GetTOTP(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return userTOTP, nil
+}
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,426 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"murakali/config"
+	"murakali/internal/model"
+	"murakali/internal/module/twofactor/mocks"
+	"murakali/pkg/attempt"
+	attemptMocks "murakali/pkg/attempt/mocks"
+	"murakali/pkg/postgre"
+	"murakali/pkg/response"
+	"murakali/pkg/totp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// rfcSecret is the RFC 6238 test key, whose code at fixedNow is 081804.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+var (
+	fixedNow  = time.Unix(1111111109, 0)
+	fixedStep = totp.Step(fixedNow)
+	userID    = uuid.MustParse("7f6b3ab0-5c1e-4d5a-9a51-0f1a3e7c2b11")
+)
+
+func newTestUseCase(r *mocks.Repository, a *attemptMocks.Limiter, db *sql.DB) *twoFactorUC {
+	return &twoFactorUC{
+		cfg:           &config.Config{},
+		txRepo:        &postgre.TxRepo{PSQL: db},
+		twoFactorRepo: r,
+		attempts:      a,
+		now:           func() time.Time { return fixedNow },
+	}
+}
+
+func enabledTOTP(lastUsedStep int64) *model.UserTOTP {
+	return &model.UserTOTP{
+		UserID:       userID,
+		Secret:       rfcSecret,
+		LastUsedStep: lastUsedStep,
+		ConfirmedAt:  sql.NullTime{Time: fixedNow, Valid: true},
+	}
+}
+
+func TestTwoFactorUC_Enroll(t *testing.T) {
+	testCase := []struct {
+		name        string
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name: "success enroll",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(nil, sql.ErrNoRows)
+				r.On("GetUserEmailByID", mock.Anything, userID.String()).Return("a@test.com", nil)
+				r.On("UpsertTOTP", mock.Anything, userID.String(), mock.Anything).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "success enroll again before confirming",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(&model.UserTOTP{UserID: userID, Secret: rfcSecret}, nil)
+				r.On("GetUserEmailByID", mock.Anything, userID.String()).Return("a@test.com", nil)
+				r.On("UpsertTOTP", mock.Anything, userID.String(), mock.Anything).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error already enabled",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(enabledTOTP(0), nil)
+			},
+			expectedErr: errors.New(response.TwoFactorAlreadyEnabled),
+		},
+		{
+			name: "error repo UpsertTOTP",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(nil, sql.ErrNoRows)
+				r.On("GetUserEmailByID", mock.Anything, userID.String()).Return("a@test.com", nil)
+				r.On("UpsertTOTP", mock.Anything, userID.String(), mock.Anything).Return(errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := newTestUseCase(r, nil, nil)
+
+			tc.mock(t, r)
+			enrollment, err := u.Enroll(context.Background(), userID.String())
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Contains(t, enrollment.URI, "secret="+enrollment.Secret)
+		})
+	}
+}
+
+func TestTwoFactorUC_Confirm(t *testing.T) {
+	testCase := []struct {
+		name        string
+		code        string
+		mock        func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, s sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "success confirm",
+			code: "081804",
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, s sqlmock.Sqlmock) {
+				a.On("Check", mock.Anything, confirmPolicy, attempt.Account(userID.String())).Return(nil)
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(&model.UserTOTP{UserID: userID, Secret: rfcSecret}, nil)
+				a.On("Reset", mock.Anything, confirmPolicy, attempt.Account(userID.String())).Return(nil)
+				s.ExpectBegin()
+				r.On("ConfirmTOTP", mock.Anything, mock.Anything, userID.String(), fixedStep).Return(nil)
+				r.On("DeleteRecoveryCodes", mock.Anything, mock.Anything, userID.String()).Return(nil)
+				r.On("CreateRecoveryCodes", mock.Anything, mock.Anything, userID.String(), mock.MatchedBy(func(hashes []string) bool {
+					return len(hashes) == 10
+				})).Return(nil)
+				s.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error not enrolled",
+			code: "081804",
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, s sqlmock.Sqlmock) {
+				a.On("Check", mock.Anything, confirmPolicy, attempt.Account(userID.String())).Return(nil)
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(nil, sql.ErrNoRows)
+			},
+			expectedErr: errors.New(response.TwoFactorNotEnrolled),
+		},
+		{
+			name: "error already enabled",
+			code: "081804",
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, s sqlmock.Sqlmock) {
+				a.On("Check", mock.Anything, confirmPolicy, attempt.Account(userID.String())).Return(nil)
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(enabledTOTP(0), nil)
+			},
+			expectedErr: errors.New(response.TwoFactorAlreadyEnabled),
+		},
+		{
+			name: "error invalid code",
+			code: "000000",
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, s sqlmock.Sqlmock) {
+				a.On("Check", mock.Anything, confirmPolicy, attempt.Account(userID.String())).Return(nil)
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(&model.UserTOTP{UserID: userID, Secret: rfcSecret}, nil)
+				a.On("Fail", mock.Anything, confirmPolicy, attempt.Account(userID.String())).Return(nil)
+			},
+			expectedErr: errors.New(response.TwoFactorCodeInvalid),
+		},
+		{
+			name: "error invalid code locks out",
+			code: "000000",
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, s sqlmock.Sqlmock) {
+				a.On("Check", mock.Anything, confirmPolicy, attempt.Account(userID.String())).Return(nil)
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(&model.UserTOTP{UserID: userID, Secret: rfcSecret}, nil)
+				a.On("Fail", mock.Anything, confirmPolicy, attempt.Account(userID.String())).
+					Return(&attempt.LockedError{RetryAfter: 15 * time.Minute})
+			},
+			expectedErr: &attempt.LockedError{RetryAfter: 15 * time.Minute},
+		},
+		{
+			name: "error locked out",
+			code: "081804",
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, s sqlmock.Sqlmock) {
+				a.On("Check", mock.Anything, confirmPolicy, attempt.Account(userID.String())).
+					Return(&attempt.LockedError{RetryAfter: time.Minute})
+			},
+			expectedErr: &attempt.LockedError{RetryAfter: time.Minute},
+		},
+		{
+			name: "error repo ConfirmTOTP",
+			code: "081804",
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, s sqlmock.Sqlmock) {
+				a.On("Check", mock.Anything, confirmPolicy, attempt.Account(userID.String())).Return(nil)
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(&model.UserTOTP{UserID: userID, Secret: rfcSecret}, nil)
+				a.On("Reset", mock.Anything, confirmPolicy, attempt.Account(userID.String())).Return(nil)
+				s.ExpectBegin()
+				r.On("ConfirmTOTP", mock.Anything, mock.Anything, userID.String(), fixedStep).Return(errors.New("test"))
+				s.ExpectRollback()
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			db, s, _ := sqlmock.New()
+			r := mocks.NewRepository(t)
+			a := attemptMocks.NewLimiter(t)
+			u := newTestUseCase(r, a, db)
+
+			tc.mock(t, r, a, s)
+			codes, err := u.Confirm(context.Background(), userID.String(), tc.code)
+			if tc.expectedErr != nil {
+				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, codes, 10)
+			assert.NoError(t, s.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTwoFactorUC_VerifyLogin(t *testing.T) {
+	testCase := []struct {
+		name        string
+		code        string
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name: "success totp code",
+			code: "081804",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(enabledTOTP(fixedStep-1), nil)
+				r.On("UseTOTPStep", mock.Anything, userID.String(), fixedStep).Return(true, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error totp code already used",
+			code: "081804",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(enabledTOTP(fixedStep), nil)
+			},
+			expectedErr: errors.New(response.TwoFactorCodeInvalid),
+		},
+		{
+			name: "error totp code used concurrently",
+			code: "081804",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(enabledTOTP(0), nil)
+				r.On("UseTOTPStep", mock.Anything, userID.String(), fixedStep).Return(false, nil)
+			},
+			expectedErr: errors.New(response.TwoFactorCodeInvalid),
+		},
+		{
+			name: "success recovery code",
+			code: "abcde-12345",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(enabledTOTP(0), nil)
+				r.On("UseRecoveryCode", mock.Anything, userID.String(), hashRecoveryCode("ABCDE12345")).Return(true, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error recovery code unknown or used",
+			code: "ABCDE-12345",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(enabledTOTP(0), nil)
+				r.On("UseRecoveryCode", mock.Anything, userID.String(), mock.Anything).Return(false, nil)
+			},
+			expectedErr: errors.New(response.TwoFactorCodeInvalid),
+		},
+		{
+			name: "error not enabled",
+			code: "081804",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(&model.UserTOTP{UserID: userID, Secret: rfcSecret}, nil)
+			},
+			expectedErr: errors.New(response.TwoFactorNotEnabled),
+		},
+		{
+			name: "error repo GetTOTP",
+			code: "081804",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(nil, errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := newTestUseCase(r, nil, nil)
+
+			tc.mock(t, r)
+			err := u.VerifyLogin(context.Background(), userID.String(), tc.code)
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Equal(t, err.Error(), tc.expectedErr.Error())
+		})
+	}
+}
+
+func TestTwoFactorUC_VerifyStepUp(t *testing.T) {
+	testCase := []struct {
+		name        string
+		code        string
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name: "success without two-factor",
+			code: "",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(nil, sql.ErrNoRows)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "success totp code",
+			code: "081804",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(enabledTOTP(0), nil)
+				r.On("UseTOTPStep", mock.Anything, userID.String(), fixedStep).Return(true, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error code required",
+			code: "",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(enabledTOTP(0), nil)
+			},
+			expectedErr: errors.New(response.TwoFactorCodeRequired),
+		},
+		{
+			name: "error recovery code is not accepted",
+			code: "ABCDE-12345",
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(enabledTOTP(0), nil)
+			},
+			expectedErr: errors.New(response.TwoFactorCodeInvalid),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := newTestUseCase(r, nil, nil)
+
+			tc.mock(t, r)
+			err := u.VerifyStepUp(context.Background(), userID.String(), tc.code)
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Equal(t, err.Error(), tc.expectedErr.Error())
+		})
+	}
+}
+
+func TestTwoFactorUC_Disable(t *testing.T) {
+	testCase := []struct {
+		name        string
+		code        string
+		mock        func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, s sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "success disable",
+			code: "081804",
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, s sqlmock.Sqlmock) {
+				a.On("Check", mock.Anything, disablePolicy, attempt.Account(userID.String())).Return(nil)
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(enabledTOTP(0), nil)
+				r.On("UseTOTPStep", mock.Anything, userID.String(), fixedStep).Return(true, nil)
+				a.On("Reset", mock.Anything, disablePolicy, attempt.Account(userID.String())).Return(nil)
+				s.ExpectBegin()
+				r.On("DeleteRecoveryCodes", mock.Anything, mock.Anything, userID.String()).Return(nil)
+				r.On("DeleteTOTP", mock.Anything, mock.Anything, userID.String()).Return(nil)
+				s.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error invalid code",
+			code: "000000",
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, s sqlmock.Sqlmock) {
+				a.On("Check", mock.Anything, disablePolicy, attempt.Account(userID.String())).Return(nil)
+				r.On("GetTOTP", mock.Anything, userID.String()).Return(enabledTOTP(0), nil)
+				a.On("Fail", mock.Anything, disablePolicy, attempt.Account(userID.String())).Return(nil)
+			},
+			expectedErr: errors.New(response.TwoFactorCodeInvalid),
+		},
+		{
+			name: "error locked out",
+			code: "081804",
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, s sqlmock.Sqlmock) {
+				a.On("Check", mock.Anything, disablePolicy, attempt.Account(userID.String())).
+					Return(&attempt.LockedError{RetryAfter: time.Minute})
+			},
+			expectedErr: &attempt.LockedError{RetryAfter: time.Minute},
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			db, s, _ := sqlmock.New()
+			r := mocks.NewRepository(t)
+			a := attemptMocks.NewLimiter(t)
+			u := newTestUseCase(r, a, db)
+
+			tc.mock(t, r, a, s)
+			err := u.Disable(context.Background(), userID.String(), tc.code)
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.NoError(t, s.ExpectationsWereMet())
+				return
+			}
+
+			assert.Equal(t, err.Error(), tc.expectedErr.Error())
+		})
+	}
+}
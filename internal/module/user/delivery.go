@@ -47,4 +47,7 @@ type Handlers interface {
 	GetRefundOrder(c *gin.Context)
 	CreateRefundThreadUser(c *gin.Context)
 	CompletedRejectedRefund(c *gin.Context)
+	GetSessions(c *gin.Context)
+	RevokeSession(c *gin.Context)
+	RevokeAllSessions(c *gin.Context)
 }
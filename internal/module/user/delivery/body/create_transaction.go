@@ -23,15 +23,15 @@ type CartItem struct {
 	ShopID         string          `json:"shop_id"`
 	VoucherShopID  string          `json:"voucher_shop_id"`
 	CourierID      string          `json:"courier_id"`
-	CourierFee     float64         `json:"courier_fee"`
+	CourierFee     model.Money     `json:"courier_fee"`
 	ProductDetails []ProductDetail `json:"product_details"`
 }
 
 type ProductDetail struct {
-	ID       string  `json:"id"`
-	Quantity int     `json:"quantity"`
-	SubPrice float64 `json:"sub_price"`
-	Note     string  `json:"note"`
+	ID       string      `json:"id"`
+	Quantity int         `json:"quantity"`
+	SubPrice model.Money `json:"sub_price"`
+	Note     string      `json:"note"`
 }
 type TransactionResponse struct {
 	TransactionData *model.Transaction
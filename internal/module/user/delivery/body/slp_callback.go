@@ -5,10 +5,6 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
-	"murakali/config"
-	"murakali/pkg/httperror"
-	"murakali/pkg/response"
-	"net/http"
 )
 
 type SLPCallbackRequest struct {
@@ -17,43 +13,26 @@ type SLPCallbackRequest struct {
 	MerchantCode string `json:"merchant_code"`
 	Status       string `json:"status"`
 	Message      string `json:"message"`
+	Timestamp    int64  `json:"timestamp"`
+	Nonce        string `json:"nonce"`
 	Signature    string `json:"signature"`
 }
 
-func (r *SLPCallbackRequest) Validate(cfg *config.Config) (UnprocessableEntity, error) {
-	unprocessableEntity := false
-	entity := UnprocessableEntity{
-		Fields: map[string]string{
-			"txn_id":        "",
-			"amount":        "",
-			"merchant_code": "",
-			"status":        "",
-			"message":       "",
-			"signature":     "",
-		},
-	}
-
-	signFormat := fmt.Sprintf("%s:%s:%s:%s:%s", r.TxnID, r.Amount, cfg.External.SlpMerchantCode, r.Status, r.Message)
-	h := hmac.New(sha256.New, []byte(cfg.External.SlpAPIKey))
+// Sign returns the signature SeaLabs Pay is expected to attach to this callback.
+// The timestamp and nonce are part of the signed payload so a captured callback
+// cannot be replayed with a fresh timestamp.
+func (r *SLPCallbackRequest) Sign(merchantCode, apiKey string) string {
+	signFormat := fmt.Sprintf("%s:%s:%s:%s:%s:%d:%s", r.TxnID, r.Amount, merchantCode, r.Status, r.Message, r.Timestamp, r.Nonce)
+	h := hmac.New(sha256.New, []byte(apiKey))
 	h.Write([]byte(signFormat))
-	sign := hex.EncodeToString(h.Sum(nil))
 
-	if sign != r.Signature {
-		unprocessableEntity = true
-		entity.Fields["txn_id"] = r.TxnID
-		entity.Fields["amount"] = r.Amount
-		entity.Fields["merchant_code"] = r.MerchantCode
-		entity.Fields["status"] = r.Status
-		entity.Fields["message"] = r.Message
-		entity.Fields["signature"] = InvalidSignatureMessage
-	}
+	return hex.EncodeToString(h.Sum(nil))
+}
 
-	if unprocessableEntity {
-		return entity, httperror.New(
-			http.StatusUnprocessableEntity,
-			response.UnprocessableEntityMessage,
-		)
+func (r *SLPCallbackRequest) VerifySignature(merchantCode, apiKey string) bool {
+	if r.Signature == "" {
+		return false
 	}
 
-	return entity, nil
+	return hmac.Equal([]byte(r.Sign(merchantCode, apiKey)), []byte(r.Signature))
 }
@@ -1,6 +1,7 @@
 package body
 
 import (
+	"murakali/internal/model"
 	"murakali/pkg/httperror"
 	"murakali/pkg/response"
 	"net/http"
@@ -9,8 +10,9 @@ import (
 )
 
 type WalletStepUpRequest struct {
-	Pin    string `json:"pin"`
-	Amount int    `json:"amount"`
+	Pin    string      `json:"pin"`
+	TOTP   string      `json:"totp"`
+	Amount model.Money `json:"amount"`
 }
 
 func (r *WalletStepUpRequest) Validate() (UnprocessableEntity, error) {
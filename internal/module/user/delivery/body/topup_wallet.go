@@ -1,6 +1,7 @@
 package body
 
 import (
+	"murakali/internal/model"
 	"murakali/pkg/httperror"
 	"murakali/pkg/response"
 	"net/http"
@@ -8,8 +9,8 @@ import (
 )
 
 type TopUpWalletRequest struct {
-	CardNumber string `json:"card_number"`
-	Amount     int    `json:"amount"`
+	CardNumber string      `json:"card_number"`
+	Amount     model.Money `json:"amount"`
 }
 
 type TopUpWalletResponse struct {
@@ -31,7 +32,7 @@ func (r *TopUpWalletRequest) Validate() (UnprocessableEntity, error) {
 		entity.Fields["card_number"] = FieldCannotBeEmptyMessage
 	}
 
-	if r.Amount < 10000 {
+	if r.Amount < model.NewMoney(10000) {
 		unprocessableEntity = true
 		entity.Fields["amount"] = TopUpAmountNotValidMessage
 	}
@@ -8,7 +8,8 @@ import (
 )
 
 type VerifyOTPRequest struct {
-	OTP string `json:"otp" form:"otp"`
+	OTP  string `json:"otp" form:"otp"`
+	TOTP string `json:"totp" form:"totp"`
 }
 
 type VerifyOTPResponse struct {
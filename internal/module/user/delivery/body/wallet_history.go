@@ -1,14 +1,17 @@
 package body
 
-import "time"
+import (
+	"murakali/internal/model"
+	"time"
+)
 
 type HistoryWalletResponse struct {
-	ID          string  `json:"id"`
-	From        string  `json:"from"`
-	To          string  `json:"to"`
-	Amount      float64 `json:"amount"`
-	Description string  `json:"description"`
-	CreatedAt   string  `json:"created_at"`
+	ID          string      `json:"id"`
+	From        string      `json:"from"`
+	To          string      `json:"to"`
+	Amount      model.Money `json:"amount"`
+	Description string      `json:"description"`
+	CreatedAt   string      `json:"created_at"`
 }
 
 type GetWalletHistoryRequest struct {
@@ -20,7 +23,7 @@ type DetailHistoryWalletResponse struct {
 	Transaction *TransactionDetailResponse `json:"transaction"`
 	From        string                     `json:"from"`
 	To          string                     `json:"to"`
-	Amount      float64                    `json:"amount"`
+	Amount      model.Money                `json:"amount"`
 	Description string                     `json:"description"`
 	CreatedAt   time.Time                  `json:"created_at"`
 }
@@ -8,11 +8,13 @@ import (
 	"murakali/internal/module/user"
 	"murakali/internal/module/user/delivery/body"
 	"murakali/internal/util"
+	"murakali/pkg/attempt"
 	"murakali/pkg/httperror"
 	"murakali/pkg/jwt"
 	"murakali/pkg/logger"
 	"murakali/pkg/pagination"
 	"murakali/pkg/response"
+	"murakali/pkg/session"
 	"net/http"
 	"strconv"
 	"strings"
@@ -24,11 +26,16 @@ import (
 type userHandlers struct {
 	cfg    *config.Config
 	userUC user.UseCase
+	keys   *jwt.Keyring
 	logger logger.Logger
 }
 
-func NewUserHandlers(cfg *config.Config, userUC user.UseCase, log logger.Logger) user.Handlers {
-	return &userHandlers{cfg: cfg, userUC: userUC, logger: log}
+func NewUserHandlers(cfg *config.Config, userUC user.UseCase, keys *jwt.Keyring, log logger.Logger) user.Handlers {
+	return &userHandlers{cfg: cfg, userUC: userUC, keys: keys, logger: log}
+}
+
+func sessionClient(c *gin.Context) session.Client {
+	return session.Client{IP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
 }
 
 func (h *userHandlers) RegisterMerchant(c *gin.Context) {
@@ -797,7 +804,7 @@ func (h *userHandlers) EditEmailUser(c *gin.Context) {
 		return
 	}
 
-	_, err = h.userUC.EditEmailUser(c, fmt.Sprintf("%v", userID), requestParam)
+	_, err = h.userUC.EditEmailUser(c, fmt.Sprintf("%v", userID), requestParam, sessionClient(c))
 	if err != nil {
 		var e *httperror.Error
 		if !errors.As(err, &e) {
@@ -856,7 +863,7 @@ func (h *userHandlers) AddSealabsPay(c *gin.Context) {
 		return
 	}
 
-	if err := h.userUC.AddSealabsPay(c, requestBody, userid.(string)); err != nil {
+	if err := h.userUC.AddSealabsPay(c, requestBody, userid.(string), sessionClient(c)); err != nil {
 		var e *httperror.Error
 		if !errors.As(err, &e) {
 			h.logger.Errorf("HandlerUser, Error: %s", err)
@@ -920,7 +927,7 @@ func (h *userHandlers) DeleteSealabsPay(c *gin.Context) {
 		return
 	}
 
-	if err := h.userUC.DeleteSealabsPay(c, userID.(string), requestBody.CardNumber); err != nil {
+	if err := h.userUC.DeleteSealabsPay(c, userID.(string), requestBody.CardNumber, sessionClient(c)); err != nil {
 		var e *httperror.Error
 		if !errors.As(err, &e) {
 			h.logger.Errorf("HandlerUser, Error: %s", err)
@@ -1049,8 +1056,14 @@ func (h *userHandlers) VerifyOTP(c *gin.Context) {
 		return
 	}
 
-	changePasswordToken, err := h.userUC.VerifyOTP(c, requestBody, userID.(string))
+	changePasswordToken, err := h.userUC.VerifyOTP(c, requestBody, userID.(string), c.ClientIP())
 	if err != nil {
+		var locked *attempt.LockedError
+		if errors.As(err, &locked) {
+			response.TooManyRequestsResponse(c.Writer, response.TooManyAttemptsMessage, locked.RetryAfterSeconds())
+			return
+		}
+
 		var e *httperror.Error
 		if !errors.As(err, &e) {
 			h.logger.Errorf("HandlerUser, Error: %s", err)
@@ -1068,19 +1081,21 @@ func (h *userHandlers) VerifyOTP(c *gin.Context) {
 }
 
 func (h *userHandlers) CompletedRejectedRefund(c *gin.Context) {
-	if err := h.userUC.CompletedRejectedRefund(c); err != nil {
+	report, err := h.userUC.CompletedRejectedRefund(c)
+	if err != nil {
 		var e *httperror.Error
 		if !errors.As(err, &e) {
 			h.logger.Errorf("HandlerUser, Error: %s", err)
-			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			response.ErrorResponseData(c.Writer, report, response.InternalServerErrorMessage, http.StatusInternalServerError)
 			return
 		}
 
-		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		response.ErrorResponseData(c.Writer, report, e.Err.Error(), e.Status)
 		return
 	}
 
-	response.SuccessResponse(c.Writer, nil, http.StatusOK)
+	h.logger.Infof("job %s affected %d rows in %dms", report.Job, report.RowsAffected, report.DurationMs)
+	response.SuccessResponse(c.Writer, report, http.StatusOK)
 }
 
 func (h *userHandlers) ChangePassword(c *gin.Context) {
@@ -1090,7 +1105,7 @@ func (h *userHandlers) ChangePassword(c *gin.Context) {
 		return
 	}
 
-	claims, err := jwt.ExtractJWT(changePasswordToken, h.cfg.JWT.JwtSecretKey)
+	claims, err := jwt.ExtractJWT(changePasswordToken, h.keys, jwt.AudienceChangePassword)
 	if err != nil {
 		response.ErrorResponse(c.Writer, response.ForbiddenMessage, http.StatusForbidden)
 		return
@@ -1108,7 +1123,7 @@ func (h *userHandlers) ChangePassword(c *gin.Context) {
 		return
 	}
 
-	if err := h.userUC.ChangePassword(c, claims["id"].(string), requestBody.NewPassword); err != nil {
+	if err := h.userUC.ChangePassword(c, claims["id"].(string), requestBody.NewPassword, sessionClient(c)); err != nil {
 		var e *httperror.Error
 		if !errors.As(err, &e) {
 			h.logger.Errorf("HandlerUser, Error: %s", err)
@@ -1145,8 +1160,14 @@ func (h *userHandlers) WalletStepUp(c *gin.Context) {
 		return
 	}
 
-	token, err := h.userUC.WalletStepUp(c, userID.(string), requestBody)
+	token, err := h.userUC.WalletStepUp(c, userID.(string), requestBody, c.ClientIP())
 	if err != nil {
+		var locked *attempt.LockedError
+		if errors.As(err, &locked) {
+			response.TooManyRequestsResponse(c.Writer, response.TooManyAttemptsMessage, locked.RetryAfterSeconds())
+			return
+		}
+
 		var e *httperror.Error
 		if !errors.As(err, &e) {
 			h.logger.Errorf("HandlerUser, Error: %s", err)
@@ -1213,7 +1234,7 @@ func (h *userHandlers) ChangeWalletPin(c *gin.Context) {
 		return
 	}
 
-	claims, err := jwt.ExtractJWT(walletToken, h.cfg.JWT.JwtSecretKey)
+	claims, err := jwt.ExtractJWT(walletToken, h.keys, jwt.AudienceWallet)
 	if err != nil {
 		response.ErrorResponse(c.Writer, response.ForbiddenMessage, http.StatusForbidden)
 		return
@@ -1238,7 +1259,7 @@ func (h *userHandlers) ChangeWalletPin(c *gin.Context) {
 		return
 	}
 
-	if err := h.userUC.ChangeWalletPin(c, userID.(string), requestBody.Pin); err != nil {
+	if err := h.userUC.ChangeWalletPin(c, userID.(string), requestBody.Pin, sessionClient(c)); err != nil {
 		var e *httperror.Error
 		if !errors.As(err, &e) {
 			h.logger.Errorf("HandlerUser, Error: %s", err)
@@ -1291,7 +1312,7 @@ func (h *userHandlers) CreateWalletPayment(c *gin.Context) {
 		return
 	}
 
-	claims, err := jwt.ExtractJWT(walletToken, h.cfg.JWT.JwtSecretKey)
+	claims, err := jwt.ExtractJWT(walletToken, h.keys, jwt.AudienceWallet)
 	if err != nil {
 		response.ErrorResponse(c.Writer, response.ForbiddenMessage, http.StatusForbidden)
 		return
@@ -1338,12 +1359,6 @@ func (h *userHandlers) SLPPaymentCallback(c *gin.Context) {
 		return
 	}
 
-	invalidFields, err := requestBody.Validate(h.cfg)
-	if err != nil {
-		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
-		return
-	}
-
 	id := c.Param("id")
 	transactionID, err := uuid.Parse(id)
 	if err != nil {
@@ -1373,12 +1388,6 @@ func (h *userHandlers) WalletPaymentCallback(c *gin.Context) {
 		return
 	}
 
-	invalidFields, err := requestBody.Validate(h.cfg)
-	if err != nil {
-		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
-		return
-	}
-
 	id := c.Param("id")
 	transactionID, err := uuid.Parse(id)
 	if err != nil {
@@ -1639,8 +1648,14 @@ func (h *userHandlers) ChangeWalletPinStepUpVerify(c *gin.Context) {
 		return
 	}
 
-	changeWalletPinToken, err := h.userUC.ChangeWalletPinStepUpVerify(c, requestBody, userID.(string))
+	changeWalletPinToken, err := h.userUC.ChangeWalletPinStepUpVerify(c, requestBody, userID.(string), c.ClientIP())
 	if err != nil {
+		var locked *attempt.LockedError
+		if errors.As(err, &locked) {
+			response.TooManyRequestsResponse(c.Writer, response.TooManyAttemptsMessage, locked.RetryAfterSeconds())
+			return
+		}
+
 		var e *httperror.Error
 		if !errors.As(err, &e) {
 			h.logger.Errorf("HandlerUser, Error: %s", err)
@@ -1656,3 +1671,72 @@ func (h *userHandlers) ChangeWalletPinStepUpVerify(c *gin.Context) {
 	c.SetCookie(constant.ChangeWalletPinTokenCookie, changeWalletPinToken, h.cfg.JWT.RefreshExpMin*60, "/", h.cfg.Server.Domain, true, true)
 	response.SuccessResponse(c.Writer, nil, http.StatusOK)
 }
+
+func (h *userHandlers) GetSessions(c *gin.Context) {
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	sessions, err := h.userUC.GetSessions(c, userID.(string), c.GetString("sessionID"))
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerUser, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, sessions, http.StatusOK)
+}
+
+func (h *userHandlers) RevokeSession(c *gin.Context) {
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.userUC.RevokeSession(c, userID.(string), sessionID.String()); err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerUser, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, nil, http.StatusOK)
+}
+
+func (h *userHandlers) RevokeAllSessions(c *gin.Context) {
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.userUC.RevokeAllSessions(c, userID.(string)); err != nil {
+		h.logger.Errorf("HandlerUser, Error: %s", err)
+		response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+		return
+	}
+
+	c.SetSameSite(http.SameSiteNoneMode)
+	c.SetCookie(constant.RefreshTokenCookie, "", -1, "/", h.cfg.Server.Domain, true, true)
+	response.SuccessResponse(c.Writer, nil, http.StatusOK)
+}
@@ -12,10 +12,13 @@ import (
 	"murakali/internal/model"
 	"murakali/internal/module/user/delivery/body"
 	"murakali/internal/module/user/mocks"
+	"murakali/pkg/attempt"
 	"murakali/pkg/httperror"
 	jwt2 "murakali/pkg/jwt"
+	"murakali/pkg/jwt/jwttest"
 	"murakali/pkg/logger"
 	"murakali/pkg/pagination"
+	"murakali/pkg/response"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -23,12 +26,28 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// testKeys is the keyring of the handlers under test. Tokens signed by
+// otherKeys carry a key id the handlers do not know.
+var testKeys, otherKeys = jwttest.NewKeyring("test"), jwttest.NewKeyring("other")
+
+// tokenCfg keeps the test tokens valid for the duration of the tests.
+var tokenCfg = &config.Config{JWT: config.JWTConfig{RefreshExpMin: 10}}
+
+// signingKeys picks the keyring a test token is signed with, any cookieKey
+// stands for a token signed with a foreign key.
+func signingKeys(cookieKey string) *jwt2.Keyring {
+	if cookieKey == "" {
+		return testKeys
+	}
+
+	return otherKeys
+}
+
 func MockJsonPost(c *gin.Context, content interface{}) {
 	c.Request.Method = "POST"
 	c.Request.Header.Set("Content-Type", "application/json")
@@ -170,7 +189,7 @@ func TestUserHandlers_RegisterMerchant(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.RegisterMerchant(c)
@@ -191,15 +210,12 @@ func TestUserHandlers_GetWallet(t *testing.T) {
 			name: "Success Get Wallet",
 			mock: func(s *mocks.UseCase) {
 				s.On("GetWallet", mock.Anything, mock.Anything).Return(&model.Wallet{
-					ID:           uuid.Nil,
-					UserID:       uuid.Nil,
-					Balance:      0,
-					PIN:          "",
-					AttemptCount: 0,
-					AttemptAt:    sql.NullTime{},
-					UnlockedAt:   sql.NullTime{},
-					ActiveDate:   sql.NullTime{},
-					UpdatedAt:    sql.NullTime{},
+					ID:         uuid.Nil,
+					UserID:     uuid.Nil,
+					Balance:    0,
+					PIN:        "",
+					ActiveDate: sql.NullTime{},
+					UpdatedAt:  sql.NullTime{},
 				}, nil)
 			},
 			expected:   http.StatusOK,
@@ -256,7 +272,7 @@ func TestUserHandlers_GetWallet(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.GetWallet(c)
@@ -356,7 +372,7 @@ func TestUserHandlers_GetWalletHistory(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.GetWalletHistory(c)
@@ -441,7 +457,7 @@ func TestUserHandlers_GetWalletHistoryByID(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.GetWalletHistoryByID(c)
@@ -468,7 +484,7 @@ func TestUserHandlers_TopUpWallet(t *testing.T) {
 			name: "Success TopUp Wallet",
 			body: body.TopUpWalletRequest{
 				CardNumber: "123456",
-				Amount:     10000,
+				Amount:     model.NewMoney(10000),
 			},
 			mock: func(s *mocks.UseCase) {
 				s.On("TopUpWallet", mock.Anything, mock.Anything, mock.Anything).Return("test", nil)
@@ -504,7 +520,7 @@ func TestUserHandlers_TopUpWallet(t *testing.T) {
 			name: "TopUp Wallet Internal Error",
 			body: body.TopUpWalletRequest{
 				CardNumber: "123456",
-				Amount:     10000,
+				Amount:     model.NewMoney(10000),
 			},
 			mock: func(s *mocks.UseCase) {
 				s.On("TopUpWallet", mock.Anything, mock.Anything, mock.Anything).Return("", errors.New("test"))
@@ -516,7 +532,7 @@ func TestUserHandlers_TopUpWallet(t *testing.T) {
 			name: "TopUp Wallet Custom  error",
 			body: body.TopUpWalletRequest{
 				CardNumber: "123456",
-				Amount:     10000,
+				Amount:     model.NewMoney(10000),
 			},
 			mock: func(s *mocks.UseCase) {
 				s.On("TopUpWallet", mock.Anything, mock.Anything, mock.Anything).Return("", httperror.New(http.StatusBadRequest, "test"))
@@ -561,7 +577,7 @@ func TestUserHandlers_TopUpWallet(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.TopUpWallet(c)
@@ -676,7 +692,7 @@ func TestUserHandlers_ActivateWallet(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.ActivateWallet(c)
@@ -774,7 +790,7 @@ func TestUserHandlers_DeleteAddressByID(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.DeleteAddressByID(c)
@@ -872,7 +888,7 @@ func TestUserHandlers_GetAddressByID(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.GetAddressByID(c)
@@ -1015,7 +1031,7 @@ func TestUserHandlers_CreateAddress(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.CreateAddress(c)
@@ -1182,7 +1198,7 @@ func TestUserHandlers_UpdateAddressByID(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.UpdateAddressByID(c)
@@ -1279,7 +1295,7 @@ func TestUserHandlers_GetAddress(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.GetAddress(c)
@@ -1390,7 +1406,7 @@ func TestUserHandlers_GetOrder(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.GetOrder(c)
@@ -1483,7 +1499,7 @@ func TestUserHandlers_GetOrderByOrderID(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.GetOrderByOrderID(c)
@@ -1620,7 +1636,7 @@ func TestUserHandlers_ChangeOrderStatus(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.ChangeOrderStatus(c)
@@ -1715,7 +1731,7 @@ func TestUserHandlers_GetTransactionDetailByID(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.GetTransactionDetailByID(c)
@@ -1829,7 +1845,7 @@ func TestUserHandlers_ChangeTransactionPaymentMethod(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.ChangeTransactionPaymentMethod(c)
@@ -1961,7 +1977,7 @@ func TestUserHandlers_EditUser(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.EditUser(c)
@@ -2077,7 +2093,7 @@ func TestUserHandlers_EditEmail(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.EditEmail(c)
@@ -2108,7 +2124,7 @@ func TestUserHandlers_EditEmailUser(t *testing.T) {
 			},
 			body: nil,
 			mock: func(s *mocks.UseCase) {
-				s.On("EditEmailUser", mock.Anything, mock.Anything, mock.Anything).Return(&model.User{}, nil)
+				s.On("EditEmailUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&model.User{}, nil)
 			},
 			expected:   http.StatusOK,
 			authorized: true,
@@ -2148,7 +2164,7 @@ func TestUserHandlers_EditEmailUser(t *testing.T) {
 			},
 			body: nil,
 			mock: func(s *mocks.UseCase) {
-				s.On("EditEmailUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("test"))
+				s.On("EditEmailUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("test"))
 			},
 			expected:   http.StatusInternalServerError,
 			authorized: true,
@@ -2161,7 +2177,7 @@ func TestUserHandlers_EditEmailUser(t *testing.T) {
 			},
 			body: nil,
 			mock: func(s *mocks.UseCase) {
-				s.On("EditEmailUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, httperror.New(http.StatusBadRequest, "test"))
+				s.On("EditEmailUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected:   http.StatusBadRequest,
 			authorized: true,
@@ -2212,7 +2228,7 @@ func TestUserHandlers_EditEmailUser(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.EditEmailUser(c)
@@ -2293,7 +2309,7 @@ func TestUserHandlers_GetSealabsPay(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.GetSealabsPay(c)
@@ -2324,7 +2340,7 @@ func TestUserHandlers_AddSealabsPay(t *testing.T) {
 				ActiveDate: "02-01-2006 15:04:05",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("AddSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				s.On("AddSealabsPay", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			},
 			expected:   http.StatusOK,
 			authorized: true,
@@ -2359,7 +2375,7 @@ func TestUserHandlers_AddSealabsPay(t *testing.T) {
 				ActiveDate: "02-01-2006 15:04:05",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("AddSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+				s.On("AddSealabsPay", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
 			},
 			expected:   http.StatusInternalServerError,
 			authorized: true,
@@ -2373,7 +2389,7 @@ func TestUserHandlers_AddSealabsPay(t *testing.T) {
 				ActiveDate: "02-01-2006 15:04:05",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("AddSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
+				s.On("AddSealabsPay", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected:   http.StatusBadRequest,
 			authorized: true,
@@ -2417,7 +2433,7 @@ func TestUserHandlers_AddSealabsPay(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.AddSealabsPay(c)
@@ -2522,7 +2538,7 @@ func TestUserHandlers_PatchSealabsPay(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.PatchSealabsPay(c)
@@ -2544,7 +2560,7 @@ func TestUserHandlers_DeleteSealabsPay(t *testing.T) {
 			name:  "Success Delete Sealabs Pay",
 			param: "1234567890123456",
 			mock: func(s *mocks.UseCase) {
-				s.On("DeleteSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				s.On("DeleteSealabsPay", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			},
 			expected:   http.StatusOK,
 			authorized: true,
@@ -2567,7 +2583,7 @@ func TestUserHandlers_DeleteSealabsPay(t *testing.T) {
 			name:  "Delete Sealabs Pay Internal Error",
 			param: "1234567890123456",
 			mock: func(s *mocks.UseCase) {
-				s.On("DeleteSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+				s.On("DeleteSealabsPay", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
 			},
 			expected:   http.StatusInternalServerError,
 			authorized: true,
@@ -2576,7 +2592,7 @@ func TestUserHandlers_DeleteSealabsPay(t *testing.T) {
 			name:  "Delete Sealabs Pay Error Custom",
 			param: "1234567890123456",
 			mock: func(s *mocks.UseCase) {
-				s.On("DeleteSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
+				s.On("DeleteSealabsPay", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected:   http.StatusBadRequest,
 			authorized: true,
@@ -2623,7 +2639,7 @@ func TestUserHandlers_DeleteSealabsPay(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.DeleteSealabsPay(c)
@@ -2703,7 +2719,7 @@ func TestUserHandlers_GetUserProfile(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.GetUserProfile(c)
@@ -2767,7 +2783,7 @@ func TestUserHandlers_GetUserProfile(t *testing.T) {
 // 			appLogger := logger.NewAPILogger(cfg)
 // 			appLogger.InitLogger()
 
-// 			h := NewUserHandlers(cfg, s, appLogger)
+// 			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 // 			tc.mock(s)
 // 			h.UploadProfilePicture(c)
@@ -2849,7 +2865,7 @@ func TestUserHandlers_VerifyPasswordChange(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.VerifyPasswordChange(c)
@@ -2877,7 +2893,7 @@ func TestUserHandlers_VerifyOTP(t *testing.T) {
 				OTP: "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("VerifyOTP", mock.Anything, mock.Anything, mock.Anything).Return("test", nil)
+				s.On("VerifyOTP", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("test", nil)
 			},
 			expected:   http.StatusOK,
 			authorized: true,
@@ -2911,7 +2927,7 @@ func TestUserHandlers_VerifyOTP(t *testing.T) {
 				OTP: "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("VerifyOTP", mock.Anything, mock.Anything, mock.Anything).Return("test", errors.New("test"))
+				s.On("VerifyOTP", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("test", errors.New("test"))
 			},
 			expected:   http.StatusInternalServerError,
 			authorized: true,
@@ -2922,7 +2938,7 @@ func TestUserHandlers_VerifyOTP(t *testing.T) {
 				OTP: "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("VerifyOTP", mock.Anything, mock.Anything, mock.Anything).Return("test", httperror.New(http.StatusBadRequest, "test"))
+				s.On("VerifyOTP", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("test", httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected:   http.StatusBadRequest,
 			authorized: true,
@@ -2967,7 +2983,7 @@ func TestUserHandlers_VerifyOTP(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.VerifyOTP(c)
@@ -2986,21 +3002,21 @@ func TestUserHandlers_CompletedRejectedRefund(t *testing.T) {
 		{
 			name: "Success Completed Rejected Refund",
 			mock: func(s *mocks.UseCase) {
-				s.On("CompletedRejectedRefund", mock.Anything).Return(nil)
+				s.On("CompletedRejectedRefund", mock.Anything).Return(&model.JobReport{}, nil)
 			},
 			expected: http.StatusOK,
 		},
 		{
 			name: "Completed Rejected Refund Internal Error",
 			mock: func(s *mocks.UseCase) {
-				s.On("CompletedRejectedRefund", mock.Anything).Return(errors.New("test"))
+				s.On("CompletedRejectedRefund", mock.Anything).Return(&model.JobReport{}, errors.New("test"))
 			},
 			expected: http.StatusInternalServerError,
 		},
 		{
 			name: "Completed Rejected Refund Error Custom",
 			mock: func(s *mocks.UseCase) {
-				s.On("CompletedRejectedRefund", mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
+				s.On("CompletedRejectedRefund", mock.Anything).Return(&model.JobReport{}, httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected: http.StatusBadRequest,
 		},
@@ -3033,7 +3049,7 @@ func TestUserHandlers_CompletedRejectedRefund(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.CompletedRejectedRefund(c)
@@ -3063,7 +3079,7 @@ func TestUserHandlers_ChangePassword(t *testing.T) {
 				NewPassword: "Tested9*",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("ChangePassword", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				s.On("ChangePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			},
 			expected:  http.StatusOK,
 			isCookie:  true,
@@ -3107,7 +3123,7 @@ func TestUserHandlers_ChangePassword(t *testing.T) {
 				NewPassword: "Tested9*",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("ChangePassword", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+				s.On("ChangePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
 			},
 			expected:  http.StatusInternalServerError,
 			isCookie:  true,
@@ -3119,7 +3135,7 @@ func TestUserHandlers_ChangePassword(t *testing.T) {
 				NewPassword: "Tested9*",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("ChangePassword", mock.Anything, mock.Anything, mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
+				s.On("ChangePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected:  http.StatusBadRequest,
 			isCookie:  true,
@@ -3141,7 +3157,7 @@ func TestUserHandlers_ChangePassword(t *testing.T) {
 			r.Header = make(http.Header)
 
 			if tc.isCookie {
-				tokenString, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt2.ChangePasswordClaims{}).SignedString([]byte(tc.cookieKey))
+				tokenString, _ := jwt2.GenerateJWTChangePasswordToken("", tokenCfg, signingKeys(tc.cookieKey))
 				r.AddCookie(&http.Cookie{Name: constant.ChangePasswordTokenCookie, Value: tokenString})
 
 			}
@@ -3164,7 +3180,7 @@ func TestUserHandlers_ChangePassword(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.ChangePassword(c)
@@ -3193,7 +3209,7 @@ func TestUserHandlers_WalletStepUp(t *testing.T) {
 				Amount: 123456,
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("WalletStepUp", mock.Anything, mock.Anything, mock.Anything).Return("test", nil)
+				s.On("WalletStepUp", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("test", nil)
 			},
 			expected:   http.StatusOK,
 			authorized: true,
@@ -3226,7 +3242,7 @@ func TestUserHandlers_WalletStepUp(t *testing.T) {
 				Amount: 123456,
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("WalletStepUp", mock.Anything, mock.Anything, mock.Anything).Return("", errors.New("test"))
+				s.On("WalletStepUp", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", errors.New("test"))
 			},
 			expected:   http.StatusInternalServerError,
 			authorized: true,
@@ -3238,11 +3254,23 @@ func TestUserHandlers_WalletStepUp(t *testing.T) {
 				Amount: 123456,
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("WalletStepUp", mock.Anything, mock.Anything, mock.Anything).Return("", httperror.New(http.StatusBadRequest, "test"))
+				s.On("WalletStepUp", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected:   http.StatusBadRequest,
 			authorized: true,
 		},
+		{
+			name: "Wallet StepUp Locked Out",
+			body: body.WalletStepUpRequest{
+				Pin:    "123456",
+				Amount: 123456,
+			},
+			mock: func(s *mocks.UseCase) {
+				s.On("WalletStepUp", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", &attempt.LockedError{RetryAfter: time.Minute})
+			},
+			expected:   http.StatusTooManyRequests,
+			authorized: true,
+		},
 	}
 
 	for _, tc := range testCase {
@@ -3281,7 +3309,7 @@ func TestUserHandlers_WalletStepUp(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.WalletStepUp(c)
@@ -3395,7 +3423,7 @@ func TestUserHandlers_ChangeWalletPinStepUp(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.ChangeWalletPinStepUp(c)
@@ -3426,7 +3454,7 @@ func TestUserHandlers_ChangeWalletPin(t *testing.T) {
 				Pin: "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("ChangeWalletPin", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				s.On("ChangeWalletPin", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			},
 			expected:   http.StatusOK,
 			authorized: true,
@@ -3500,7 +3528,7 @@ func TestUserHandlers_ChangeWalletPin(t *testing.T) {
 				Pin: "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("ChangeWalletPin", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+				s.On("ChangeWalletPin", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
 			},
 			expected:   http.StatusInternalServerError,
 			authorized: true,
@@ -3514,7 +3542,7 @@ func TestUserHandlers_ChangeWalletPin(t *testing.T) {
 				Pin: "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("ChangeWalletPin", mock.Anything, mock.Anything, mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
+				s.On("ChangeWalletPin", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected:   http.StatusBadRequest,
 			authorized: true,
@@ -3538,7 +3566,7 @@ func TestUserHandlers_ChangeWalletPin(t *testing.T) {
 			r.Header = make(http.Header)
 
 			if tc.isCookie {
-				tokenString, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt2.WalletClaims{Scope: tc.scope}).SignedString([]byte(tc.cookieKey))
+				tokenString, _ := jwt2.GenerateJWTWalletToken("", tc.scope, tokenCfg, signingKeys(tc.cookieKey))
 				r.AddCookie(&http.Cookie{Name: constant.ChangeWalletPinTokenCookie, Value: tokenString})
 
 			}
@@ -3565,7 +3593,7 @@ func TestUserHandlers_ChangeWalletPin(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.ChangeWalletPin(c)
@@ -3662,7 +3690,7 @@ func TestUserHandlers_CreateSLPPayment(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.CreateSLPPayment(c)
@@ -3786,7 +3814,7 @@ func TestUserHandlers_CreateWalletPayment(t *testing.T) {
 			r.Header = make(http.Header)
 
 			if tc.isCookie {
-				tokenString, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt2.WalletClaims{Scope: tc.scope}).SignedString([]byte(tc.cookieKey))
+				tokenString, _ := jwt2.GenerateJWTWalletToken("", tc.scope, tokenCfg, signingKeys(tc.cookieKey))
 				r.AddCookie(&http.Cookie{Name: constant.WalletTokenCookie, Value: tokenString})
 
 			}
@@ -3809,7 +3837,7 @@ func TestUserHandlers_CreateWalletPayment(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.CreateWalletPayment(c)
@@ -3850,11 +3878,15 @@ func TestUserHandlers_SLPPaymentCallback(t *testing.T) {
 			expected: http.StatusBadRequest,
 		},
 		{
-			name:     "Invalid Request Body Validate",
-			param:    "",
-			body:     body.CreatePaymentRequest{},
-			mock:     func(s *mocks.UseCase) {},
-			expected: http.StatusUnprocessableEntity,
+			name:  "Invalid Callback Signature",
+			param: "8302755e-25c5-4523-8498-7dc8b9e3a098",
+			body: body.SLPCallbackRequest{
+				Signature: "invalid",
+			},
+			mock: func(s *mocks.UseCase) {
+				s.On("UpdateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(httperror.New(http.StatusUnauthorized, response.PaymentCallbackInvalid))
+			},
+			expected: http.StatusUnauthorized,
 		},
 		{
 			name:  "Invalid Request Body Validate",
@@ -3930,7 +3962,7 @@ func TestUserHandlers_SLPPaymentCallback(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.SLPPaymentCallback(c)
@@ -3971,11 +4003,15 @@ func TestUserHandlers_WalletPaymentCallback(t *testing.T) {
 			expected: http.StatusBadRequest,
 		},
 		{
-			name:     "Invalid Request Body Validate",
-			param:    "",
-			body:     body.CreatePaymentRequest{},
-			mock:     func(s *mocks.UseCase) {},
-			expected: http.StatusUnprocessableEntity,
+			name:  "Invalid Callback Signature",
+			param: "8302755e-25c5-4523-8498-7dc8b9e3a098",
+			body: body.SLPCallbackRequest{
+				Signature: "invalid",
+			},
+			mock: func(s *mocks.UseCase) {
+				s.On("UpdateWalletTransaction", mock.Anything, mock.Anything, mock.Anything).Return(httperror.New(http.StatusUnauthorized, response.PaymentCallbackInvalid))
+			},
+			expected: http.StatusUnauthorized,
 		},
 		{
 			name:  "Invalid Request Body Validate",
@@ -4051,7 +4087,7 @@ func TestUserHandlers_WalletPaymentCallback(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.WalletPaymentCallback(c)
@@ -4143,7 +4179,7 @@ func TestUserHandlers_GetTransactions(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.GetTransactions(c)
@@ -4235,7 +4271,7 @@ func TestUserHandlers_GetTransaction(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.GetTransaction(c)
@@ -4362,7 +4398,7 @@ func TestUserHandlers_CreateRefundUser(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.CreateRefundUser(c)
@@ -4462,7 +4498,7 @@ func TestUserHandlers_GetRefundOrder(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.GetRefundOrder(c)
@@ -4585,7 +4621,7 @@ func TestUserHandlers_CreateRefundThreadUser(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.CreateRefundThreadUser(c)
@@ -4713,7 +4749,7 @@ func TestUserHandlers_CreateTransaction(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.CreateTransaction(c)
@@ -4793,7 +4829,7 @@ func TestUserHandlers_ChangeWalletPinStepUpEmail(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.ChangeWalletPinStepUpEmail(c)
@@ -4821,7 +4857,7 @@ func TestUserHandlers_ChangeWalletPinStepUpVerify(t *testing.T) {
 				OTP: "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("ChangeWalletPinStepUpVerify", mock.Anything, mock.Anything, mock.Anything).Return("test", nil)
+				s.On("ChangeWalletPinStepUpVerify", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("test", nil)
 			},
 			expected:   http.StatusOK,
 			authorized: true,
@@ -4853,7 +4889,7 @@ func TestUserHandlers_ChangeWalletPinStepUpVerify(t *testing.T) {
 				OTP: "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("ChangeWalletPinStepUpVerify", mock.Anything, mock.Anything, mock.Anything).Return("", errors.New("test"))
+				s.On("ChangeWalletPinStepUpVerify", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", errors.New("test"))
 			},
 			expected:   http.StatusInternalServerError,
 			authorized: true,
@@ -4864,7 +4900,7 @@ func TestUserHandlers_ChangeWalletPinStepUpVerify(t *testing.T) {
 				OTP: "123456",
 			},
 			mock: func(s *mocks.UseCase) {
-				s.On("ChangeWalletPinStepUpVerify", mock.Anything, mock.Anything, mock.Anything).Return("", httperror.New(http.StatusBadRequest, "test"))
+				s.On("ChangeWalletPinStepUpVerify", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", httperror.New(http.StatusBadRequest, "test"))
 			},
 			expected:   http.StatusBadRequest,
 			authorized: true,
@@ -4907,7 +4943,7 @@ func TestUserHandlers_ChangeWalletPinStepUpVerify(t *testing.T) {
 			appLogger := logger.NewAPILogger(cfg)
 			appLogger.InitLogger()
 
-			h := NewUserHandlers(cfg, s, appLogger)
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
 
 			tc.mock(s)
 			h.ChangeWalletPinStepUpVerify(c)
@@ -4916,3 +4952,101 @@ func TestUserHandlers_ChangeWalletPinStepUpVerify(t *testing.T) {
 		})
 	}
 }
+
+func TestUserHandlers_RevokeSession(t *testing.T) {
+	testCase := []struct {
+		name       string
+		param      string
+		mock       func(s *mocks.UseCase)
+		expected   int
+		authorized bool
+	}{
+		{
+			name:  "Success Revoke Session",
+			param: "8302755e-25c5-4523-8498-7dc8b9e3a098",
+			mock: func(s *mocks.UseCase) {
+				s.On("RevokeSession", mock.Anything, "123456", "8302755e-25c5-4523-8498-7dc8b9e3a098").Return(nil)
+			},
+			expected:   http.StatusOK,
+			authorized: true,
+		},
+		{
+			name:       "Unauthorized User",
+			param:      "",
+			mock:       func(s *mocks.UseCase) {},
+			expected:   http.StatusUnauthorized,
+			authorized: false,
+		},
+		{
+			name:       "param id Parse Error",
+			param:      "test",
+			mock:       func(s *mocks.UseCase) {},
+			expected:   http.StatusBadRequest,
+			authorized: true,
+		},
+		{
+			name:  "Revoke Session Internal Error",
+			param: uuid.Nil.String(),
+			mock: func(s *mocks.UseCase) {
+				s.On("RevokeSession", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+			},
+			expected:   http.StatusInternalServerError,
+			authorized: true,
+		},
+		{
+			name:  "Revoke Session Not Found",
+			param: uuid.Nil.String(),
+			mock: func(s *mocks.UseCase) {
+				s.On("RevokeSession", mock.Anything, mock.Anything, mock.Anything).Return(httperror.New(http.StatusNotFound, "test"))
+			},
+			expected:   http.StatusNotFound,
+			authorized: true,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rr)
+
+			r := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/user/session/%s", tc.param), nil)
+
+			r.Header = make(http.Header)
+
+			if tc.authorized {
+				c.Set("userID", "123456")
+			}
+
+			if tc.param != "" {
+				c.Params = []gin.Param{
+					{
+						Key:   "id",
+						Value: tc.param,
+					},
+				}
+			}
+
+			s := mocks.NewUseCase(t)
+
+			cfg := &config.Config{
+				Logger: config.LoggerConfig{
+					Development:       true,
+					DisableCaller:     false,
+					DisableStacktrace: false,
+					Encoding:          "json",
+					Level:             "info",
+				},
+			}
+
+			appLogger := logger.NewAPILogger(cfg)
+			appLogger.InitLogger()
+
+			h := NewUserHandlers(cfg, s, testKeys, appLogger)
+
+			tc.mock(s)
+			h.RevokeSession(c)
+
+			assert.Equal(t, rr.Code, tc.expected)
+		})
+	}
+}
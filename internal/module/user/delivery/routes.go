@@ -10,7 +10,7 @@ import (
 func MapUserRoutes(userGroup *gin.RouterGroup, h user.Handlers, mw *middleware.MWManager) {
 	userGroup.POST("/transaction/slp-payment/:id", h.SLPPaymentCallback)
 	userGroup.POST("/transaction/wallet-payment/:id", h.WalletPaymentCallback)
-	userGroup.POST("/rejected-refund", h.CompletedRejectedRefund)
+	userGroup.POST("/rejected-refund", mw.JobAuthMiddleware(), h.CompletedRejectedRefund)
 
 	userGroup.Use(mw.AuthJWTMiddleware())
 	userGroup.GET("/address", h.GetAddress)
@@ -34,9 +34,9 @@ func MapUserRoutes(userGroup *gin.RouterGroup, h user.Handlers, mw *middleware.M
 	userGroup.GET("/transaction/detail/:transaction_id", h.GetTransactionDetailByID)
 	userGroup.GET("/transaction", h.GetTransactions)
 	userGroup.GET("/transaction/:id", h.GetTransaction)
-	userGroup.POST("/transaction", h.CreateTransaction)
-	userGroup.POST("/transaction/slp-payment", h.CreateSLPPayment)
-	userGroup.POST("/transaction/wallet-payment", h.CreateWalletPayment)
+	userGroup.POST("/transaction", mw.IdempotencyMiddleware(), h.CreateTransaction)
+	userGroup.POST("/transaction/slp-payment", mw.IdempotencyMiddleware(), h.CreateSLPPayment)
+	userGroup.POST("/transaction/wallet-payment", mw.IdempotencyMiddleware(), h.CreateWalletPayment)
 	userGroup.PUT("/transaction", h.ChangeTransactionPaymentMethod)
 	userGroup.GET("/order", h.GetOrder)
 	userGroup.GET("/order/:order_id", h.GetOrderByOrderID)
@@ -54,4 +54,7 @@ func MapUserRoutes(userGroup *gin.RouterGroup, h user.Handlers, mw *middleware.M
 	userGroup.POST("/refund", h.CreateRefundUser)
 	userGroup.GET("/refund/:refund_id", h.GetRefundOrder)
 	userGroup.POST("/refund-thread", h.CreateRefundThreadUser)
+	userGroup.GET("/session", h.GetSessions)
+	userGroup.DELETE("/session", h.RevokeAllSessions)
+	userGroup.DELETE("/session/:id", h.RevokeSession)
 }
@@ -22,20 +22,6 @@ type Repository struct {
 	mock.Mock
 }
 
-// AddSealabsPay provides a mock function with given fields: ctx, request, userid
-func (_m *Repository) AddSealabsPay(ctx context.Context, request body.AddSealabsPayRequest, userid string) error {
-	ret := _m.Called(ctx, request, userid)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, body.AddSealabsPayRequest, string) error); ok {
-		r0 = rf(ctx, request, userid)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // AddSealabsPayTrans provides a mock function with given fields: ctx, tx, request, userid
 func (_m *Repository) AddSealabsPayTrans(ctx context.Context, tx postgre.Transaction, request body.AddSealabsPayRequest, userid string) error {
 	ret := _m.Called(ctx, tx, request, userid)
@@ -64,13 +50,13 @@ func (_m *Repository) AddShop(ctx context.Context, userID string, shopName strin
 	return r0
 }
 
-// ChangeOrderStatus provides a mock function with given fields: ctx, requestBody
-func (_m *Repository) ChangeOrderStatus(ctx context.Context, requestBody body.ChangeOrderStatusRequest) error {
-	ret := _m.Called(ctx, requestBody)
+// ChangeOrderStatus provides a mock function with given fields: ctx, tx, requestBody
+func (_m *Repository) ChangeOrderStatus(ctx context.Context, tx postgre.Transaction, requestBody body.ChangeOrderStatusRequest) error {
+	ret := _m.Called(ctx, tx, requestBody)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, body.ChangeOrderStatusRequest) error); ok {
-		r0 = rf(ctx, requestBody)
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, body.ChangeOrderStatusRequest) error); ok {
+		r0 = rf(ctx, tx, requestBody)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -208,6 +194,69 @@ func (_m *Repository) CheckUserSealabsPay(ctx context.Context, userid string) (i
 	return r0, r1
 }
 
+// ClaimPromotionQuota provides a mock function with given fields: ctx, tx, promotionID, quantity
+func (_m *Repository) ClaimPromotionQuota(ctx context.Context, tx postgre.Transaction, promotionID string, quantity int) (bool, error) {
+	ret := _m.Called(ctx, tx, promotionID, quantity)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string, int) bool); ok {
+		r0 = rf(ctx, tx, promotionID, quantity)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string, int) error); ok {
+		r1 = rf(ctx, tx, promotionID, quantity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimVoucherQuota provides a mock function with given fields: ctx, tx, voucherID
+func (_m *Repository) ClaimVoucherQuota(ctx context.Context, tx postgre.Transaction, voucherID string) (bool, error) {
+	ret := _m.Called(ctx, tx, voucherID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) bool); ok {
+		r0 = rf(ctx, tx, voucherID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string) error); ok {
+		r1 = rf(ctx, tx, voucherID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountVoucherRedemptionByUser provides a mock function with given fields: ctx, tx, voucherID, userID
+func (_m *Repository) CountVoucherRedemptionByUser(ctx context.Context, tx postgre.Transaction, voucherID string, userID string) (int, error) {
+	ret := _m.Called(ctx, tx, voucherID, userID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string, string) int); ok {
+		r0 = rf(ctx, tx, voucherID, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string, string) error); ok {
+		r1 = rf(ctx, tx, voucherID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateAddress provides a mock function with given fields: ctx, tx, userID, requestBody
 func (_m *Repository) CreateAddress(ctx context.Context, tx postgre.Transaction, userID string, requestBody body.CreateAddressRequest) error {
 	ret := _m.Called(ctx, tx, userID, requestBody)
@@ -282,13 +331,41 @@ func (_m *Repository) CreateOrderItem(ctx context.Context, tx postgre.Transactio
 	return r0, r1
 }
 
-// CreateRefundThreadUser provides a mock function with given fields: ctx, refundThreadData
-func (_m *Repository) CreateRefundThreadUser(ctx context.Context, refundThreadData *model.RefundThread) error {
-	ret := _m.Called(ctx, refundThreadData)
+// CreatePaymentCallback provides a mock function with given fields: ctx, callback
+func (_m *Repository) CreatePaymentCallback(ctx context.Context, callback *model.PaymentCallback) error {
+	ret := _m.Called(ctx, callback)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *model.RefundThread) error); ok {
-		r0 = rf(ctx, refundThreadData)
+	if rf, ok := ret.Get(0).(func(context.Context, *model.PaymentCallback) error); ok {
+		r0 = rf(ctx, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreatePromotionClaim provides a mock function with given fields: ctx, tx, claim
+func (_m *Repository) CreatePromotionClaim(ctx context.Context, tx postgre.Transaction, claim *model.PromotionClaim) error {
+	ret := _m.Called(ctx, tx, claim)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.PromotionClaim) error); ok {
+		r0 = rf(ctx, tx, claim)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRefundThreadUser provides a mock function with given fields: ctx, tx, refundThreadData
+func (_m *Repository) CreateRefundThreadUser(ctx context.Context, tx postgre.Transaction, refundThreadData *model.RefundThread) error {
+	ret := _m.Called(ctx, tx, refundThreadData)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.RefundThread) error); ok {
+		r0 = rf(ctx, tx, refundThreadData)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -333,6 +410,20 @@ func (_m *Repository) CreateTransaction(ctx context.Context, tx postgre.Transact
 	return r0, r1
 }
 
+// CreateVoucherRedemption provides a mock function with given fields: ctx, tx, redemption
+func (_m *Repository) CreateVoucherRedemption(ctx context.Context, tx postgre.Transaction, redemption *model.VoucherRedemption) error {
+	ret := _m.Called(ctx, tx, redemption)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.VoucherRedemption) error); ok {
+		r0 = rf(ctx, tx, redemption)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateWallet provides a mock function with given fields: ctx, walletData
 func (_m *Repository) CreateWallet(ctx context.Context, walletData *model.Wallet) error {
 	ret := _m.Called(ctx, walletData)
@@ -417,13 +508,13 @@ func (_m *Repository) DeleteOTPValueChangeWalletPin(ctx context.Context, email s
 	return r0, r1
 }
 
-// DeleteSealabsPay provides a mock function with given fields: ctx, cardNmber
-func (_m *Repository) DeleteSealabsPay(ctx context.Context, cardNmber string) error {
-	ret := _m.Called(ctx, cardNmber)
+// DeleteSealabsPay provides a mock function with given fields: ctx, tx, cardNumber
+func (_m *Repository) DeleteSealabsPay(ctx context.Context, tx postgre.Transaction, cardNumber string) error {
+	ret := _m.Called(ctx, tx, cardNumber)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, cardNmber)
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) error); ok {
+		r0 = rf(ctx, tx, cardNumber)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1111,29 +1202,6 @@ func (_m *Repository) GetSellerIDByOrderID(ctx context.Context, orderID string)
 	return r0, r1
 }
 
-// GetSessionKeyRedis provides a mock function with given fields: ctx, key
-func (_m *Repository) GetSessionKeyRedis(ctx context.Context, key string) ([]string, error) {
-	ret := _m.Called(ctx, key)
-
-	var r0 []string
-	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
-		r0 = rf(ctx, key)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, key)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // GetShopByID provides a mock function with given fields: ctx, shopID
 func (_m *Repository) GetShopByID(ctx context.Context, shopID string) (*model.Shop, error) {
 	ret := _m.Called(ctx, shopID)
@@ -1400,6 +1468,29 @@ func (_m *Repository) GetUserPasswordByID(ctx context.Context, id string) (*mode
 	return r0, r1
 }
 
+// GetVoucherForUpdate provides a mock function with given fields: ctx, tx, voucherID
+func (_m *Repository) GetVoucherForUpdate(ctx context.Context, tx postgre.Transaction, voucherID string) (*model.Voucher, error) {
+	ret := _m.Called(ctx, tx, voucherID)
+
+	var r0 *model.Voucher
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string) *model.Voucher); ok {
+		r0 = rf(ctx, tx, voucherID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Voucher)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string) error); ok {
+		r1 = rf(ctx, tx, voucherID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetVoucherMarketplaceByID provides a mock function with given fields: ctx, voucherMarketplaceID
 func (_m *Repository) GetVoucherMarketplaceByID(ctx context.Context, voucherMarketplaceID string) (*model.Voucher, error) {
 	ret := _m.Called(ctx, voucherMarketplaceID)
@@ -1580,18 +1671,25 @@ func (_m *Repository) InsertNewOTPKeyChangeWalletPin(ctx context.Context, email
 	return r0
 }
 
-// InsertSessionRedis provides a mock function with given fields: ctx, duration, key, status
-func (_m *Repository) InsertSessionRedis(ctx context.Context, duration int, key string, status string) error {
-	ret := _m.Called(ctx, duration, key, status)
+// InsertPaymentCallbackNonceRedis provides a mock function with given fields: ctx, nonce, duration
+func (_m *Repository) InsertPaymentCallbackNonceRedis(ctx context.Context, nonce string, duration int) (bool, error) {
+	ret := _m.Called(ctx, nonce, duration)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) error); ok {
-		r0 = rf(ctx, duration, key, status)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) bool); ok {
+		r0 = rf(ctx, nonce, duration)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, nonce, duration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // InsertWalletHistory provides a mock function with given fields: ctx, tx, walletHistory
@@ -1622,6 +1720,27 @@ func (_m *Repository) PatchSealabsPay(ctx context.Context, cardNumber string) er
 	return r0
 }
 
+// ReserveProductDetailStock provides a mock function with given fields: ctx, tx, orderID, productDetailID, quantity
+func (_m *Repository) ReserveProductDetailStock(ctx context.Context, tx postgre.Transaction, orderID string, productDetailID string, quantity int) (bool, error) {
+	ret := _m.Called(ctx, tx, orderID, productDetailID, quantity)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string, string, int) bool); ok {
+		r0 = rf(ctx, tx, orderID, productDetailID, quantity)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, postgre.Transaction, string, string, int) error); ok {
+		r1 = rf(ctx, tx, orderID, productDetailID, quantity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetDefaultSealabsPay provides a mock function with given fields: ctx, cardNumber, userid
 func (_m *Repository) SetDefaultSealabsPay(ctx context.Context, cardNumber string, userid string) error {
 	ret := _m.Called(ctx, cardNumber, userid)
@@ -1720,27 +1839,13 @@ func (_m *Repository) UpdateOrderRefund(ctx context.Context, tx postgre.Transact
 	return r0
 }
 
-// UpdatePasswordByID provides a mock function with given fields: ctx, userID, newPassword
-func (_m *Repository) UpdatePasswordByID(ctx context.Context, userID string, newPassword string) error {
-	ret := _m.Called(ctx, userID, newPassword)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, userID, newPassword)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// UpdateProductDetailStock provides a mock function with given fields: ctx, tx, productDetailData
-func (_m *Repository) UpdateProductDetailStock(ctx context.Context, tx postgre.Transaction, productDetailData *model.ProductDetail) error {
-	ret := _m.Called(ctx, tx, productDetailData)
+// UpdatePasswordByID provides a mock function with given fields: ctx, tx, userID, newPassword
+func (_m *Repository) UpdatePasswordByID(ctx context.Context, tx postgre.Transaction, userID string, newPassword string) error {
+	ret := _m.Called(ctx, tx, userID, newPassword)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.ProductDetail) error); ok {
-		r0 = rf(ctx, tx, productDetailData)
+	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, string, string) error); ok {
+		r0 = rf(ctx, tx, userID, newPassword)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1776,20 +1881,6 @@ func (_m *Repository) UpdateProfileImage(ctx context.Context, imgURL string, use
 	return r0
 }
 
-// UpdatePromotionQuota provides a mock function with given fields: ctx, tx, promo
-func (_m *Repository) UpdatePromotionQuota(ctx context.Context, tx postgre.Transaction, promo *model.Promotion) error {
-	ret := _m.Called(ctx, tx, promo)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.Promotion) error); ok {
-		r0 = rf(ctx, tx, promo)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // UpdateRole provides a mock function with given fields: ctx, userID
 func (_m *Repository) UpdateRole(ctx context.Context, userID string) error {
 	ret := _m.Called(ctx, userID)
@@ -1846,20 +1937,6 @@ func (_m *Repository) UpdateUserField(ctx context.Context, _a1 *model.User) erro
 	return r0
 }
 
-// UpdateUserSealabsPay provides a mock function with given fields: ctx, request, userid
-func (_m *Repository) UpdateUserSealabsPay(ctx context.Context, request body.AddSealabsPayRequest, userid string) error {
-	ret := _m.Called(ctx, request, userid)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, body.AddSealabsPayRequest, string) error); ok {
-		r0 = rf(ctx, request, userid)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // UpdateUserSealabsPayTrans provides a mock function with given fields: ctx, tx, request, userid
 func (_m *Repository) UpdateUserSealabsPayTrans(ctx context.Context, tx postgre.Transaction, request body.AddSealabsPayRequest, userid string) error {
 	ret := _m.Called(ctx, tx, request, userid)
@@ -1874,36 +1951,8 @@ func (_m *Repository) UpdateUserSealabsPayTrans(ctx context.Context, tx postgre.
 	return r0
 }
 
-// UpdateVoucherQuota provides a mock function with given fields: ctx, tx, upVoucher
-func (_m *Repository) UpdateVoucherQuota(ctx context.Context, tx postgre.Transaction, upVoucher *model.Voucher) error {
-	ret := _m.Called(ctx, tx, upVoucher)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, postgre.Transaction, *model.Voucher) error); ok {
-		r0 = rf(ctx, tx, upVoucher)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// UpdateWallet provides a mock function with given fields: ctx, wallet
-func (_m *Repository) UpdateWallet(ctx context.Context, wallet *model.Wallet) error {
-	ret := _m.Called(ctx, wallet)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *model.Wallet) error); ok {
-		r0 = rf(ctx, wallet)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// UpdateWalletBalance provides a mock function with given fields: ctx, tx, wallet
-func (_m *Repository) UpdateWalletBalance(ctx context.Context, tx postgre.Transaction, wallet *model.Wallet) error {
+// UpdateWalletPin provides a mock function with given fields: ctx, tx, wallet
+func (_m *Repository) UpdateWalletPin(ctx context.Context, tx postgre.Transaction, wallet *model.Wallet) error {
 	ret := _m.Called(ctx, tx, wallet)
 
 	var r0 error
@@ -1916,20 +1965,6 @@ func (_m *Repository) UpdateWalletBalance(ctx context.Context, tx postgre.Transa
 	return r0
 }
 
-// UpdateWalletPin provides a mock function with given fields: ctx, wallet
-func (_m *Repository) UpdateWalletPin(ctx context.Context, wallet *model.Wallet) error {
-	ret := _m.Called(ctx, wallet)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *model.Wallet) error); ok {
-		r0 = rf(ctx, wallet)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 type mockConstructorTestingTNewRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -11,6 +11,8 @@ import (
 	model "murakali/internal/model"
 
 	pagination "murakali/pkg/pagination"
+
+	session "murakali/pkg/session"
 )
 
 // UseCase is an autogenerated mock type for the UseCase type
@@ -32,13 +34,13 @@ func (_m *UseCase) ActivateWallet(ctx context.Context, userID string, pin string
 	return r0
 }
 
-// AddSealabsPay provides a mock function with given fields: ctx, request, userid
-func (_m *UseCase) AddSealabsPay(ctx context.Context, request body.AddSealabsPayRequest, userid string) error {
-	ret := _m.Called(ctx, request, userid)
+// AddSealabsPay provides a mock function with given fields: ctx, request, userid, client
+func (_m *UseCase) AddSealabsPay(ctx context.Context, request body.AddSealabsPayRequest, userid string, client session.Client) error {
+	ret := _m.Called(ctx, request, userid, client)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, body.AddSealabsPayRequest, string) error); ok {
-		r0 = rf(ctx, request, userid)
+	if rf, ok := ret.Get(0).(func(context.Context, body.AddSealabsPayRequest, string, session.Client) error); ok {
+		r0 = rf(ctx, request, userid, client)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -60,13 +62,13 @@ func (_m *UseCase) ChangeOrderStatus(ctx context.Context, userID string, request
 	return r0
 }
 
-// ChangePassword provides a mock function with given fields: ctx, userID, newPassword
-func (_m *UseCase) ChangePassword(ctx context.Context, userID string, newPassword string) error {
-	ret := _m.Called(ctx, userID, newPassword)
+// ChangePassword provides a mock function with given fields: ctx, userID, newPassword, client
+func (_m *UseCase) ChangePassword(ctx context.Context, userID string, newPassword string, client session.Client) error {
+	ret := _m.Called(ctx, userID, newPassword, client)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, userID, newPassword)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, session.Client) error); ok {
+		r0 = rf(ctx, userID, newPassword, client)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -74,13 +76,13 @@ func (_m *UseCase) ChangePassword(ctx context.Context, userID string, newPasswor
 	return r0
 }
 
-// ChangeWalletPin provides a mock function with given fields: ctx, userID, pin
-func (_m *UseCase) ChangeWalletPin(ctx context.Context, userID string, pin string) error {
-	ret := _m.Called(ctx, userID, pin)
+// ChangeWalletPin provides a mock function with given fields: ctx, userID, pin, client
+func (_m *UseCase) ChangeWalletPin(ctx context.Context, userID string, pin string, client session.Client) error {
+	ret := _m.Called(ctx, userID, pin, client)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, userID, pin)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, session.Client) error); ok {
+		r0 = rf(ctx, userID, pin, client)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -123,20 +125,20 @@ func (_m *UseCase) ChangeWalletPinStepUpEmail(ctx context.Context, userID string
 	return r0
 }
 
-// ChangeWalletPinStepUpVerify provides a mock function with given fields: ctx, requestBody, userID
-func (_m *UseCase) ChangeWalletPinStepUpVerify(ctx context.Context, requestBody body.VerifyOTPRequest, userID string) (string, error) {
-	ret := _m.Called(ctx, requestBody, userID)
+// ChangeWalletPinStepUpVerify provides a mock function with given fields: ctx, requestBody, userID, clientIP
+func (_m *UseCase) ChangeWalletPinStepUpVerify(ctx context.Context, requestBody body.VerifyOTPRequest, userID string, clientIP string) (string, error) {
+	ret := _m.Called(ctx, requestBody, userID, clientIP)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(context.Context, body.VerifyOTPRequest, string) string); ok {
-		r0 = rf(ctx, requestBody, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, body.VerifyOTPRequest, string, string) string); ok {
+		r0 = rf(ctx, requestBody, userID, clientIP)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, body.VerifyOTPRequest, string) error); ok {
-		r1 = rf(ctx, requestBody, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, body.VerifyOTPRequest, string, string) error); ok {
+		r1 = rf(ctx, requestBody, userID, clientIP)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -145,17 +147,26 @@ func (_m *UseCase) ChangeWalletPinStepUpVerify(ctx context.Context, requestBody
 }
 
 // CompletedRejectedRefund provides a mock function with given fields: ctx
-func (_m *UseCase) CompletedRejectedRefund(ctx context.Context) error {
+func (_m *UseCase) CompletedRejectedRefund(ctx context.Context) (*model.JobReport, error) {
 	ret := _m.Called(ctx)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+	var r0 *model.JobReport
+	if rf, ok := ret.Get(0).(func(context.Context) *model.JobReport); ok {
 		r0 = rf(ctx)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.JobReport)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // CreateAddress provides a mock function with given fields: ctx, userID, requestBody
@@ -270,13 +281,13 @@ func (_m *UseCase) DeleteAddressByID(ctx context.Context, userID string, address
 	return r0
 }
 
-// DeleteSealabsPay provides a mock function with given fields: ctx, userID, cardNumber
-func (_m *UseCase) DeleteSealabsPay(ctx context.Context, userID string, cardNumber string) error {
-	ret := _m.Called(ctx, userID, cardNumber)
+// DeleteSealabsPay provides a mock function with given fields: ctx, userID, cardNumber, client
+func (_m *UseCase) DeleteSealabsPay(ctx context.Context, userID string, cardNumber string, client session.Client) error {
+	ret := _m.Called(ctx, userID, cardNumber, client)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, userID, cardNumber)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, session.Client) error); ok {
+		r0 = rf(ctx, userID, cardNumber, client)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -307,13 +318,13 @@ func (_m *UseCase) EditEmail(ctx context.Context, userID string, requestBody bod
 	return r0, r1
 }
 
-// EditEmailUser provides a mock function with given fields: ctx, userID, requestBody
-func (_m *UseCase) EditEmailUser(ctx context.Context, userID string, requestBody body.EditEmailUserRequest) (*model.User, error) {
-	ret := _m.Called(ctx, userID, requestBody)
+// EditEmailUser provides a mock function with given fields: ctx, userID, requestBody, client
+func (_m *UseCase) EditEmailUser(ctx context.Context, userID string, requestBody body.EditEmailUserRequest, client session.Client) (*model.User, error) {
+	ret := _m.Called(ctx, userID, requestBody, client)
 
 	var r0 *model.User
-	if rf, ok := ret.Get(0).(func(context.Context, string, body.EditEmailUserRequest) *model.User); ok {
-		r0 = rf(ctx, userID, requestBody)
+	if rf, ok := ret.Get(0).(func(context.Context, string, body.EditEmailUserRequest, session.Client) *model.User); ok {
+		r0 = rf(ctx, userID, requestBody, client)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.User)
@@ -321,8 +332,8 @@ func (_m *UseCase) EditEmailUser(ctx context.Context, userID string, requestBody
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, body.EditEmailUserRequest) error); ok {
-		r1 = rf(ctx, userID, requestBody)
+	if rf, ok := ret.Get(1).(func(context.Context, string, body.EditEmailUserRequest, session.Client) error); ok {
+		r1 = rf(ctx, userID, requestBody, client)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -514,6 +525,29 @@ func (_m *UseCase) GetSealabsPay(ctx context.Context, userid string) ([]*model.S
 	return r0, r1
 }
 
+// GetSessions provides a mock function with given fields: ctx, userID, currentSessionID
+func (_m *UseCase) GetSessions(ctx context.Context, userID string, currentSessionID string) ([]*body.SessionResponse, error) {
+	ret := _m.Called(ctx, userID, currentSessionID)
+
+	var r0 []*body.SessionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*body.SessionResponse); ok {
+		r0 = rf(ctx, userID, currentSessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*body.SessionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, currentSessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransactionByID provides a mock function with given fields: ctx, transactionID
 func (_m *UseCase) GetTransactionByID(ctx context.Context, transactionID string) (*body.GetTransactionByIDResponse, error) {
 	ret := _m.Called(ctx, transactionID)
@@ -680,6 +714,34 @@ func (_m *UseCase) RegisterMerchant(ctx context.Context, userID string, shopName
 	return r0
 }
 
+// RevokeAllSessions provides a mock function with given fields: ctx, userID
+func (_m *UseCase) RevokeAllSessions(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSession provides a mock function with given fields: ctx, userID, sessionID
+func (_m *UseCase) RevokeSession(ctx context.Context, userID string, sessionID string) error {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendOTPEmail provides a mock function with given fields: ctx, email
 func (_m *UseCase) SendOTPEmail(ctx context.Context, email string) error {
 	ret := _m.Called(ctx, email)
@@ -785,20 +847,20 @@ func (_m *UseCase) UploadProfilePicture(ctx context.Context, imgURL string, user
 	return r0
 }
 
-// VerifyOTP provides a mock function with given fields: ctx, requestBody, userID
-func (_m *UseCase) VerifyOTP(ctx context.Context, requestBody body.VerifyOTPRequest, userID string) (string, error) {
-	ret := _m.Called(ctx, requestBody, userID)
+// VerifyOTP provides a mock function with given fields: ctx, requestBody, userID, clientIP
+func (_m *UseCase) VerifyOTP(ctx context.Context, requestBody body.VerifyOTPRequest, userID string, clientIP string) (string, error) {
+	ret := _m.Called(ctx, requestBody, userID, clientIP)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(context.Context, body.VerifyOTPRequest, string) string); ok {
-		r0 = rf(ctx, requestBody, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, body.VerifyOTPRequest, string, string) string); ok {
+		r0 = rf(ctx, requestBody, userID, clientIP)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, body.VerifyOTPRequest, string) error); ok {
-		r1 = rf(ctx, requestBody, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, body.VerifyOTPRequest, string, string) error); ok {
+		r1 = rf(ctx, requestBody, userID, clientIP)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -820,20 +882,20 @@ func (_m *UseCase) VerifyPasswordChange(ctx context.Context, userID string) erro
 	return r0
 }
 
-// WalletStepUp provides a mock function with given fields: ctx, userID, requestBody
-func (_m *UseCase) WalletStepUp(ctx context.Context, userID string, requestBody body.WalletStepUpRequest) (string, error) {
-	ret := _m.Called(ctx, userID, requestBody)
+// WalletStepUp provides a mock function with given fields: ctx, userID, requestBody, clientIP
+func (_m *UseCase) WalletStepUp(ctx context.Context, userID string, requestBody body.WalletStepUpRequest, clientIP string) (string, error) {
+	ret := _m.Called(ctx, userID, requestBody, clientIP)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(context.Context, string, body.WalletStepUpRequest) string); ok {
-		r0 = rf(ctx, userID, requestBody)
+	if rf, ok := ret.Get(0).(func(context.Context, string, body.WalletStepUpRequest, string) string); ok {
+		r0 = rf(ctx, userID, requestBody, clientIP)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, body.WalletStepUpRequest) error); ok {
-		r1 = rf(ctx, userID, requestBody)
+	if rf, ok := ret.Get(1).(func(context.Context, string, body.WalletStepUpRequest, string) error); ok {
+		r1 = rf(ctx, userID, requestBody, clientIP)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -34,7 +34,7 @@ type Repository interface {
 	CheckDefaultSealabsPay(ctx context.Context, userid string) (*string, error)
 	SetDefaultSealabsPayTrans(ctx context.Context, tx postgre.Transaction, cardNumber *string) error
 	SetDefaultSealabsPay(ctx context.Context, cardNumber string, userid string) error
-	DeleteSealabsPay(ctx context.Context, cardNmber string) error
+	DeleteSealabsPay(ctx context.Context, tx postgre.Transaction, cardNumber string) error
 	CheckEmailHistory(ctx context.Context, email string) (*model.EmailHistory, error)
 	InsertNewOTPKey(ctx context.Context, email, otp string) error
 	GetOTPValue(ctx context.Context, email string) (string, error)
@@ -49,9 +49,9 @@ type Repository interface {
 	AddShop(ctx context.Context, userID string, shopName string) error
 	UpdateRole(ctx context.Context, userID string) error
 	UpdateProfileImage(ctx context.Context, imgURL, userID string) error
-	UpdatePasswordByID(ctx context.Context, userID, newPassword string) error
+	UpdatePasswordByID(ctx context.Context, tx postgre.Transaction, userID, newPassword string) error
 	GetPasswordByID(ctx context.Context, id string) (string, error)
-	ChangeOrderStatus(ctx context.Context, requestBody body.ChangeOrderStatusRequest) error
+	ChangeOrderStatus(ctx context.Context, tx postgre.Transaction, requestBody body.ChangeOrderStatusRequest) error
 	GetOrdersByTransactionID(ctx context.Context, transactionID, userID string) ([]*model.Order, error)
 	GetTotalOrder(ctx context.Context, userID, orderStatusID string) (int64, error)
 	GetProductUnitSoldByOrderID(ctx context.Context, tx postgre.Transaction, orderID string) ([]*body.ProductUnitSoldOrderQty, error)
@@ -79,7 +79,7 @@ type Repository interface {
 	UpdateTransaction(ctx context.Context, tx postgre.Transaction, transactionData *model.Transaction) error
 	CreateOrder(ctx context.Context, tx postgre.Transaction, orderData *model.OrderModel) (*uuid.UUID, error)
 	CreateOrderItem(ctx context.Context, tx postgre.Transaction, item *model.OrderItem) (*uuid.UUID, error)
-	UpdateProductDetailStock(ctx context.Context, tx postgre.Transaction, productDetailData *model.ProductDetail) error
+	ReserveProductDetailStock(ctx context.Context, tx postgre.Transaction, orderID, productDetailID string, quantity int) (bool, error)
 	DeleteCartItemByID(ctx context.Context, tx postgre.Transaction, cartItemData *model.CartItem) error
 	GetOrderByTransactionID(ctx context.Context, transactionID string) ([]*model.OrderModel, error)
 	GetOrderDetailByTransactionID(ctx context.Context, TransactionID string) ([]*model.Order, error)
@@ -87,28 +87,28 @@ type Repository interface {
 	CreateWallet(ctx context.Context, walletData *model.Wallet) error
 	GetWalletByUserID(ctx context.Context, userID string) (*model.Wallet, error)
 	InsertWalletHistory(ctx context.Context, tx postgre.Transaction, walletHistory *model.WalletHistory) error
-	UpdateWalletBalance(ctx context.Context, tx postgre.Transaction, wallet *model.Wallet) error
-	UpdateWallet(ctx context.Context, wallet *model.Wallet) error
 	CheckUserSealabsPay(ctx context.Context, userid string) (int, error)
 	CheckDeletedSealabsPay(ctx context.Context, cardNumber string, userid string) (int, error)
-	AddSealabsPay(ctx context.Context, request body.AddSealabsPayRequest, userid string) error
-	UpdateUserSealabsPay(ctx context.Context, request body.AddSealabsPayRequest, userid string) error
 	UpdateUserSealabsPayTrans(ctx context.Context, tx postgre.Transaction, request body.AddSealabsPayRequest, userid string) error
-	UpdateWalletPin(ctx context.Context, wallet *model.Wallet) error
+	UpdateWalletPin(ctx context.Context, tx postgre.Transaction, wallet *model.Wallet) error
 	GetProductPromotionByProductID(ctx context.Context, productID string) (*model.Promotion, error)
-	UpdateVoucherQuota(ctx context.Context, tx postgre.Transaction, upVoucher *model.Voucher) error
-	UpdatePromotionQuota(ctx context.Context, tx postgre.Transaction, promo *model.Promotion) error
+	GetVoucherForUpdate(ctx context.Context, tx postgre.Transaction, voucherID string) (*model.Voucher, error)
+	CountVoucherRedemptionByUser(ctx context.Context, tx postgre.Transaction, voucherID, userID string) (int, error)
+	ClaimVoucherQuota(ctx context.Context, tx postgre.Transaction, voucherID string) (bool, error)
+	CreateVoucherRedemption(ctx context.Context, tx postgre.Transaction, redemption *model.VoucherRedemption) error
+	ClaimPromotionQuota(ctx context.Context, tx postgre.Transaction, promotionID string, quantity int) (bool, error)
+	CreatePromotionClaim(ctx context.Context, tx postgre.Transaction, claim *model.PromotionClaim) error
 	GetOrderModelByID(ctx context.Context, OrderID string) (*model.OrderModel, error)
 	GetRefundOrderByOrderID(ctx context.Context, orderID string) (*model.Refund, error)
 	CreateRefundUser(ctx context.Context, tx postgre.Transaction, refundData *model.Refund) error
 	UpdateOrderRefund(ctx context.Context, tx postgre.Transaction, orderID string, isRefund bool) error
 	GetRefundOrderByID(ctx context.Context, refundID string) (*model.Refund, error)
 	GetRefundThreadByRefundID(ctx context.Context, refundID string) ([]*body.RThread, error)
-	CreateRefundThreadUser(ctx context.Context, refundThreadData *model.RefundThread) error
-	InsertSessionRedis(ctx context.Context, duration int, key, status string) error
-	GetSessionKeyRedis(ctx context.Context, key string) ([]string, error)
+	CreateRefundThreadUser(ctx context.Context, tx postgre.Transaction, refundThreadData *model.RefundThread) error
 	GetRejectedRefund(ctx context.Context) ([]*model.RefundOrder, error)
 	InsertNewOTPKeyChangeWalletPin(ctx context.Context, email, otp string) error
 	GetOTPValueChangeWalletPin(ctx context.Context, email string) (string, error)
 	DeleteOTPValueChangeWalletPin(ctx context.Context, email string) (int64, error)
+	InsertPaymentCallbackNonceRedis(ctx context.Context, nonce string, duration int) (bool, error)
+	CreatePaymentCallback(ctx context.Context, callback *model.PaymentCallback) error
 }
@@ -82,7 +82,7 @@ const (
 	left join "voucher" v on v.id = o.voucher_shop_id WHERE o.transaction_id = $1
 	`
 
-	GetOrderDetailQuery = `SELECT pd.id,pd.product_id,p.title,ph.url,oi.quantity,oi.item_price,oi.total_price
+	GetOrderDetailQuery = `SELECT pd.id,pd.product_id,p.title,ph.url,oi.quantity,oi.item_price,oi.total_price,oi.id,oi.is_review
 	from  "product_detail" pd 
 	join "photo" ph on pd.id = ph.product_detail_id join "order_item" oi on pd.id = oi.product_detail_id 
 	join "product" p on p.id = pd.product_id WHERE oi.order_id = $1`
@@ -121,7 +121,7 @@ const (
 	UpdatePasswordQuery            = `UPDATE "user" SET "password" = $1 WHERE "id" = $2`
 	UpdateWalletPinQuery           = `UPDATE "wallet" SET "pin" = $1 WHERE "id" = $2`
 
-	GetWalletUserQuery        = `SELECT "id", "user_id", "balance", "attempt_count", "attempt_at", "unlocked_at", "active_date" FROM "wallet" WHERE "id" = $1 AND "deleted_at" IS NULL;`
+	GetWalletUserQuery        = `SELECT "id", "user_id", "balance", "active_date" FROM "wallet" WHERE "id" = $1 AND "deleted_at" IS NULL;`
 	GetWalletHistoryUserQuery = `SELECT "id", "from", "to", "amount", "description", "created_at" 
 	FROM "wallet_history" 
 	WHERE "wallet_id" = $1`
@@ -137,23 +137,26 @@ const (
 	GetCourierShopByIDQuery = `SELECT "c"."id", "c"."name", "c"."code", "c"."service", "c"."description" FROM "courier" as "c"
 		INNER JOIN "shop_courier" as sc ON "sc"."courier_id" = "c"."id"
 		WHERE "c"."id" = $1 AND "sc"."shop_id" = $2 AND "c"."deleted_at" IS NULL;`
-	GetProductDetailByIDQuery     = `SELECT "id", "product_id", "price", "stock", "weight", "size", "hazardous", "condition", "bulk_price" FROM "product_detail" WHERE "id" = $1 AND "deleted_at" IS NULL;`
-	GetShopByIDQuery              = `SELECT "id", "name", "user_id" FROM "shop" WHERE "id" = $1 AND "deleted_at" IS NULL;`
-	CreateTransactionQuery        = `INSERT INTO "transaction" (voucher_marketplace_id, wallet_id, card_number, invoice, total_price, expired_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING "id";`
-	CreateOrderQuery              = `INSERT INTO "order" (transaction_id, shop_id, user_id, courier_id, voucher_shop_id, order_status_id, total_price, delivery_fee, buyer_address, shop_address) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING "id";`
-	CreateOrderItemQuery          = `INSERT INTO "order_item" (order_id, product_detail_id, quantity, item_price, total_price, note) VALUES ($1, $2, $3, $4, $5, $6) RETURNING "id";`
-	CreateWalletQuery             = `INSERT INTO "wallet" (user_id, balance, pin, attempt_count, active_date) VALUES ($1, $2, $3, $4, $5)`
-	CreateWalletHistoryQuery      = `INSERT INTO "wallet_history" (transaction_id, wallet_id, "from", "to", description, amount, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`
-	UpdateWalletBalanceQuery      = `UPDATE "wallet" SET "balance" = $1, "updated_at" = $2 WHERE "id" = $3`
-	UpdateWalletQuery             = `UPDATE "wallet" SET "attempt_count" = $1, "attempt_at" = $2, "unlocked_at" = $3, "updated_at" = CURRENT_TIMESTAMP WHERE "id" = $4`
-	GetWalletByUserIDQuery        = `SELECT "id", "user_id", "balance", "pin", "attempt_count", "attempt_at", "unlocked_at", "active_date" FROM "wallet" WHERE "user_id" = $1 AND "deleted_at" IS NULL`
-	GetCartItemUserQuery          = `SELECT "id", "user_id", "product_detail_id", "quantity" FROM "cart_item" WHERE "user_id" = $1 AND "product_detail_id" = $2 AND "deleted_at" IS NULL;`
-	UpdateProductDetailStockQuery = `UPDATE "product_detail" SET "stock" = $1, "updated_at" = now() WHERE "id" = $2;`
-	DeleteCartItemByIDQuery       = `DELETE FROM "cart_item" WHERE "id" = $1`
-	GetTransactionByIDQuery       = `SELECT "id", "voucher_marketplace_id", "wallet_id", "card_number", "invoice", "total_price", "paid_at", "canceled_at", "expired_at" FROM "transaction" WHERE "id" = $1;`
-	UpdateTransactionByID         = `UPDATE "transaction" SET "paid_at" = $1, "canceled_at" = $2, "card_number" = $3 WHERE "id" = $4`
-	UpdateOrderByID               = `UPDATE "order" SET "order_status_id" = $1 WHERE "id" = $2`
-	GetOrderByTransactionID       = `SELECT 
+	GetProductDetailByIDQuery      = `SELECT "id", "product_id", "price", "stock", "weight", "size", "hazardous", "condition", "bulk_price" FROM "product_detail" WHERE "id" = $1 AND "deleted_at" IS NULL;`
+	GetShopByIDQuery               = `SELECT "id", "name", "user_id" FROM "shop" WHERE "id" = $1 AND "deleted_at" IS NULL;`
+	CreateTransactionQuery         = `INSERT INTO "transaction" (voucher_marketplace_id, wallet_id, card_number, invoice, total_price, expired_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING "id";`
+	CreateOrderQuery               = `INSERT INTO "order" (transaction_id, shop_id, user_id, courier_id, voucher_shop_id, order_status_id, total_price, delivery_fee, buyer_address, shop_address) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING "id";`
+	CreateOrderItemQuery           = `INSERT INTO "order_item" (order_id, product_detail_id, quantity, item_price, total_price, note) VALUES ($1, $2, $3, $4, $5, $6) RETURNING "id";`
+	CreateWalletQuery              = `INSERT INTO "wallet" (user_id, balance, pin, active_date) VALUES ($1, $2, $3, $4)`
+	CreateWalletHistoryQuery       = `INSERT INTO "wallet_history" (transaction_id, wallet_id, "from", "to", description, amount, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	GetWalletByUserIDQuery         = `SELECT "id", "user_id", "balance", "pin", "active_date" FROM "wallet" WHERE "user_id" = $1 AND "deleted_at" IS NULL`
+	GetCartItemUserQuery           = `SELECT "id", "user_id", "product_detail_id", "quantity" FROM "cart_item" WHERE "user_id" = $1 AND "product_detail_id" = $2 AND "deleted_at" IS NULL;`
+	ReserveProductDetailStockQuery = `WITH "reserved" AS (
+		UPDATE "product_detail" SET "stock" = "stock" - $3, "updated_at" = now()
+		WHERE "id" = $2 AND "deleted_at" IS NULL AND "stock" >= $3
+		RETURNING "id")
+	INSERT INTO "stock_reservation" ("order_id", "product_detail_id", "quantity")
+	SELECT $1, "id", $3 FROM "reserved"`
+	DeleteCartItemByIDQuery = `DELETE FROM "cart_item" WHERE "id" = $1`
+	GetTransactionByIDQuery = `SELECT "id", "voucher_marketplace_id", "wallet_id", "card_number", "invoice", "total_price", "paid_at", "canceled_at", "expired_at" FROM "transaction" WHERE "id" = $1;`
+	UpdateTransactionByID   = `UPDATE "transaction" SET "paid_at" = $1, "canceled_at" = $2, "card_number" = $3 WHERE "id" = $4`
+	UpdateOrderByID         = `UPDATE "order" SET "order_status_id" = $1 WHERE "id" = $2`
+	GetOrderByTransactionID = `SELECT 
 		"id", "transaction_id", "shop_id", "user_id", "courier_id", "voucher_shop_id", "order_status_id", "total_price", "delivery_fee", "resi_no", "created_at", "arrived_at" 
 	FROM "order" WHERE "transaction_id" = $1`
 	GetOrderByTransactionIDUserID = `SELECT 
@@ -175,7 +178,7 @@ const (
 	`
 
 	GetOrderDetailQuery2 = `SELECT pd.id,pd.product_id,p.title, pd.weight,
-	p.thumbnail_url,oi.quantity,oi.item_price,oi.total_price
+	p.thumbnail_url,oi.quantity,oi.item_price,oi.total_price,oi.id,oi.is_review
 	from  "product_detail" pd 
 	join "order_item" oi on pd.id = oi.product_detail_id 
 	join "product" p on p.id = pd.product_id WHERE oi.order_id = $1 `
@@ -224,8 +227,17 @@ const (
 	WHERE "p"."id" = $1 AND ("promo"."actived_date" < now() AND "promo"."expired_date" >= now())
 	`
 
-	UpdateVoucherQuotaQuery   = `UPDATE "voucher" SET "quota" = $1, "updated_at" = now() WHERE "id" = $2;`
-	UpdatePromotionQuotaQuery = `UPDATE "promotion" SET "quota" = $1, "updated_at" = now() WHERE "id" = $2;`
+	GetVoucherForUpdateQuery = `SELECT "id", "shop_id", "code", "quota", "actived_date", "expired_date", "discount_percentage", "discount_fix_price",
+		"min_product_price", "max_discount_price", "limit_per_user"
+	FROM "voucher" WHERE "id" = $1 AND "deleted_at" IS NULL FOR UPDATE`
+	CountVoucherRedemptionByUserQuery = `SELECT count("id") FROM "voucher_redemption"
+		WHERE "voucher_id" = $1 AND "user_id" = $2 AND "released_at" IS NULL`
+	ClaimVoucherQuotaQuery       = `UPDATE "voucher" SET "quota" = "quota" - 1, "updated_at" = now() WHERE "id" = $1 AND "quota" > 0`
+	CreateVoucherRedemptionQuery = `INSERT INTO "voucher_redemption" ("voucher_id", "user_id", "transaction_id", "order_id")
+		VALUES ($1, $2, $3, $4) RETURNING "id"`
+	ClaimPromotionQuotaQuery  = `UPDATE "promotion" SET "quota" = "quota" - $1, "updated_at" = now() WHERE "id" = $2 AND "quota" >= $1`
+	CreatePromotionClaimQuery = `INSERT INTO "promotion_claim" ("promotion_id", "transaction_id", "order_id", "quantity")
+		VALUES ($1, $2, $3, $4) RETURNING "id"`
 
 	GetOrderModelByIDQuery = `SELECT "id", "transaction_id", "shop_id", "user_id", "courier_id", "voucher_shop_id", "order_status_id", "total_price",
 	"delivery_fee", "resi_no", "buyer_address", "shop_address", "cancel_notes", "is_withdraw", "is_refund", "created_at", "arrived_at"
@@ -253,4 +265,8 @@ const (
 	(refund_id, user_id, is_seller, is_buyer, text)
 	VALUES ($1, $2, $3, $4, $5)`
 	UpdateProductUnitSoldQuery = `UPDATE "product" SET "unit_sold" = $1, "updated_at" = now() WHERE "id" = $2;`
+
+	CreatePaymentCallbackQuery = `INSERT INTO "payment_callback"
+		(transaction_id, callback_type, payload, signature, nonce, signed_at, is_accepted, reject_reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
 )
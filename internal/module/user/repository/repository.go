@@ -423,6 +423,8 @@ func (r *userRepo) GetOrderByOrderID(ctx context.Context, orderID string) (*mode
 			&detail.OrderQuantity,
 			&detail.ItemPrice,
 			&detail.TotalPrice,
+			&detail.OrderItemID,
+			&detail.IsReview,
 		); errScan != nil {
 			return nil, errScan
 		}
@@ -595,6 +597,8 @@ func (r *userRepo) GetOrders(ctx context.Context, userID, orderStatusID string,
 				&detail.OrderQuantity,
 				&detail.ItemPrice,
 				&detail.TotalPrice,
+				&detail.OrderItemID,
+				&detail.IsReview,
 			); errScan != nil {
 				return nil, err
 			}
@@ -655,6 +659,8 @@ func (r *userRepo) GetOrderDetailByTransactionID(ctx context.Context, transactio
 				&detail.OrderQuantity,
 				&detail.ItemPrice,
 				&detail.TotalPrice,
+				&detail.OrderItemID,
+				&detail.IsReview,
 			); errScan != nil {
 				return nil, err
 			}
@@ -740,6 +746,8 @@ func (r *userRepo) GetOrdersByTransactionID(ctx context.Context, transactionID,
 				&detail.OrderQuantity,
 				&detail.ItemPrice,
 				&detail.TotalPrice,
+				&detail.OrderItemID,
+				&detail.IsReview,
 			); errScan != nil {
 				return nil, err
 			}
@@ -882,8 +890,7 @@ func (r *userRepo) GetUserByUsername(ctx context.Context, username string) (*mod
 func (r *userRepo) GetWalletByUserID(ctx context.Context, userID string) (*model.Wallet, error) {
 	var walletModel model.Wallet
 	if err := r.PSQL.QueryRowContext(ctx, GetWalletByUserIDQuery, userID).Scan(&walletModel.ID, &walletModel.UserID,
-		&walletModel.Balance, &walletModel.PIN, &walletModel.AttemptCount,
-		&walletModel.AttemptAt, &walletModel.UnlockedAt, &walletModel.ActiveDate); err != nil {
+		&walletModel.Balance, &walletModel.PIN, &walletModel.ActiveDate); err != nil {
 		return nil, err
 	}
 
@@ -978,8 +985,8 @@ func (r *userRepo) UpdateUserEmail(ctx context.Context, tx postgre.Transaction,
 	return nil
 }
 
-func (r *userRepo) UpdateWalletPin(ctx context.Context, wallet *model.Wallet) error {
-	_, err := r.PSQL.ExecContext(ctx, UpdateWalletPinQuery, wallet.PIN, wallet.ID)
+func (r *userRepo) UpdateWalletPin(ctx context.Context, tx postgre.Transaction, wallet *model.Wallet) error {
+	_, err := tx.ExecContext(ctx, UpdateWalletPinQuery, wallet.PIN, wallet.ID)
 	if err != nil {
 		return err
 	}
@@ -988,7 +995,7 @@ func (r *userRepo) UpdateWalletPin(ctx context.Context, wallet *model.Wallet) er
 }
 
 func (r *userRepo) CreateWallet(ctx context.Context, wallet *model.Wallet) error {
-	_, err := r.PSQL.ExecContext(ctx, CreateWalletQuery, wallet.UserID, wallet.Balance, wallet.PIN, wallet.AttemptCount, wallet.ActiveDate)
+	_, err := r.PSQL.ExecContext(ctx, CreateWalletQuery, wallet.UserID, wallet.Balance, wallet.PIN, wallet.ActiveDate)
 	if err != nil {
 		return err
 	}
@@ -1110,8 +1117,8 @@ func (r *userRepo) CheckDeletedSealabsPay(ctx context.Context, cardNumber, useri
 	return temp, nil
 }
 
-func (r *userRepo) DeleteSealabsPay(ctx context.Context, cardNumber string) error {
-	if _, err := r.PSQL.ExecContext(ctx, DeleteSealabsPayQuery, cardNumber); err != nil {
+func (r *userRepo) DeleteSealabsPay(ctx context.Context, tx postgre.Transaction, cardNumber string) error {
+	if _, err := tx.ExecContext(ctx, DeleteSealabsPayQuery, cardNumber); err != nil {
 		return err
 	}
 	return nil
@@ -1150,23 +1157,6 @@ func (r *userRepo) UpdateUserSealabsPayTrans(ctx context.Context, tx postgre.Tra
 	return nil
 }
 
-func (r *userRepo) AddSealabsPay(ctx context.Context, request body.AddSealabsPayRequest, userid string) error {
-	if _, err := r.PSQL.ExecContext(ctx, CreateSealabsPayQuery, request.CardNumber, userid,
-		request.Name, request.IsDefault, request.ActiveDateTime); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (r *userRepo) UpdateUserSealabsPay(ctx context.Context, request body.AddSealabsPayRequest, userid string) error {
-	if _, err := r.PSQL.ExecContext(ctx, UpdateUserSealabsPayQuery, request.Name, request.CardNumber); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func (r *userRepo) CheckShopByID(ctx context.Context, userID string) (int64, error) {
 	var result int64
 	err := r.PSQL.QueryRowContext(ctx, CheckShopByIdQuery, userID).Scan(&result)
@@ -1211,8 +1201,8 @@ func (r *userRepo) UpdateProfileImage(ctx context.Context, imgURL, userID string
 	return nil
 }
 
-func (r *userRepo) UpdatePasswordByID(ctx context.Context, userID, newPassword string) error {
-	_, err := r.PSQL.ExecContext(ctx, UpdatePasswordQuery, newPassword, userID)
+func (r *userRepo) UpdatePasswordByID(ctx context.Context, tx postgre.Transaction, userID, newPassword string) error {
+	_, err := tx.ExecContext(ctx, UpdatePasswordQuery, newPassword, userID)
 	if err != nil {
 		return err
 	}
@@ -1225,9 +1215,6 @@ func (r *userRepo) GetWalletUser(ctx context.Context, walletID string) (*model.W
 		&walletUser.ID,
 		&walletUser.UserID,
 		&walletUser.Balance,
-		&walletUser.AttemptCount,
-		&walletUser.AttemptAt,
-		&walletUser.UnlockedAt,
 		&walletUser.ActiveDate); err != nil {
 		return nil, err
 	}
@@ -1395,32 +1382,21 @@ func (r *userRepo) GetCartItemUser(ctx context.Context, userID, productDetailID
 	return &CartItemResult, nil
 }
 
-func (r *userRepo) UpdateProductDetailStock(ctx context.Context, tx postgre.Transaction,
-	productDetailData *model.ProductDetail) error {
-	_, err := tx.ExecContext(ctx, UpdateProductDetailStockQuery, productDetailData.Stock, productDetailData.ID)
+// ReserveProductDetailStock takes quantity out of the stock for an order in a
+// single statement and reports false when there is not enough left.
+func (r *userRepo) ReserveProductDetailStock(ctx context.Context, tx postgre.Transaction,
+	orderID, productDetailID string, quantity int) (bool, error) {
+	res, err := tx.ExecContext(ctx, ReserveProductDetailStockQuery, orderID, productDetailID, quantity)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
-}
-
-func (r *userRepo) UpdateWalletBalance(ctx context.Context, tx postgre.Transaction, wallet *model.Wallet) error {
-	_, err := tx.ExecContext(ctx, UpdateWalletBalanceQuery, wallet.Balance, wallet.UpdatedAt, wallet.ID)
+	rows, err := res.RowsAffected()
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
-}
-
-func (r *userRepo) UpdateWallet(ctx context.Context, wallet *model.Wallet) error {
-	_, err := r.PSQL.ExecContext(ctx, UpdateWalletQuery, wallet.AttemptCount, wallet.AttemptAt, wallet.UnlockedAt, wallet.ID)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return rows == 1, nil
 }
 
 func (r *userRepo) InsertWalletHistory(ctx context.Context, tx postgre.Transaction, walletHistory *model.WalletHistory) error {
@@ -1432,8 +1408,8 @@ func (r *userRepo) InsertWalletHistory(ctx context.Context, tx postgre.Transacti
 	return nil
 }
 
-func (r *userRepo) ChangeOrderStatus(ctx context.Context, requestBody body.ChangeOrderStatusRequest) error {
-	_, err := r.PSQL.ExecContext(
+func (r *userRepo) ChangeOrderStatus(ctx context.Context, tx postgre.Transaction, requestBody body.ChangeOrderStatusRequest) error {
+	_, err := tx.ExecContext(
 		ctx, UpdateOrderByID, requestBody.OrderStatusID, requestBody.OrderID)
 	if err != nil {
 		return err
@@ -1554,20 +1530,81 @@ func (r *userRepo) GetProductPromotionByProductID(ctx context.Context, productID
 	return &promo, nil
 }
 
-func (r *userRepo) UpdateVoucherQuota(ctx context.Context, tx postgre.Transaction, upVoucher *model.Voucher) error {
-	_, err := tx.ExecContext(ctx, UpdateVoucherQuotaQuery, upVoucher.Quota, upVoucher.ID)
+// GetVoucherForUpdate locks the voucher row until the transaction ends, so
+// the redemption count read after it cannot change under the caller.
+func (r *userRepo) GetVoucherForUpdate(ctx context.Context, tx postgre.Transaction, voucherID string) (*model.Voucher, error) {
+	var voucher model.Voucher
+	if err := tx.QueryRowContext(ctx, GetVoucherForUpdateQuery, voucherID).Scan(
+		&voucher.ID,
+		&voucher.ShopID,
+		&voucher.Code,
+		&voucher.Quota,
+		&voucher.ActivedDate,
+		&voucher.ExpiredDate,
+		&voucher.DiscountPercentage,
+		&voucher.DiscountFixPrice,
+		&voucher.MinProductPrice,
+		&voucher.MaxDiscountPrice,
+		&voucher.LimitPerUser); err != nil {
+		return nil, err
+	}
+
+	return &voucher, nil
+}
+
+func (r *userRepo) CountVoucherRedemptionByUser(ctx context.Context, tx postgre.Transaction, voucherID, userID string) (int, error) {
+	var total int
+	if err := tx.QueryRowContext(ctx, CountVoucherRedemptionByUserQuery, voucherID, userID).Scan(&total); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *userRepo) ClaimVoucherQuota(ctx context.Context, tx postgre.Transaction, voucherID string) (bool, error) {
+	res, err := tx.ExecContext(ctx, ClaimVoucherQuotaQuery, voucherID)
 	if err != nil {
-		return err
+		return false, err
 	}
-	return nil
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+func (r *userRepo) CreateVoucherRedemption(ctx context.Context, tx postgre.Transaction, redemption *model.VoucherRedemption) error {
+	return tx.QueryRowContext(ctx, CreateVoucherRedemptionQuery,
+		redemption.VoucherID,
+		redemption.UserID,
+		redemption.TransactionID,
+		redemption.OrderID).Scan(&redemption.ID)
+}
+
+func (r *userRepo) CreatePromotionClaim(ctx context.Context, tx postgre.Transaction, claim *model.PromotionClaim) error {
+	return tx.QueryRowContext(ctx, CreatePromotionClaimQuery,
+		claim.PromotionID,
+		claim.TransactionID,
+		claim.OrderID,
+		claim.Quantity).Scan(&claim.ID)
 }
 
-func (r *userRepo) UpdatePromotionQuota(ctx context.Context, tx postgre.Transaction, promo *model.Promotion) error {
-	_, err := tx.ExecContext(ctx, UpdatePromotionQuotaQuery, promo.Quota, promo.ID)
+// ClaimPromotionQuota takes quantity out of the promotion quota in a single
+// statement and reports false when there is not enough left.
+func (r *userRepo) ClaimPromotionQuota(ctx context.Context, tx postgre.Transaction, promotionID string, quantity int) (bool, error) {
+	res, err := tx.ExecContext(ctx, ClaimPromotionQuotaQuery, quantity, promotionID)
 	if err != nil {
-		return err
+		return false, err
 	}
-	return nil
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
 }
 
 func (r *userRepo) GetOrderModelByID(ctx context.Context, orderID string) (*model.OrderModel, error) {
@@ -1697,8 +1734,8 @@ func (r *userRepo) GetRefundThreadByRefundID(ctx context.Context, refundID strin
 	return refundThreadList, nil
 }
 
-func (r *userRepo) CreateRefundThreadUser(ctx context.Context, refundThreadData *model.RefundThread) error {
-	if _, err := r.PSQL.ExecContext(ctx, CreateRefundThreadUserQuery,
+func (r *userRepo) CreateRefundThreadUser(ctx context.Context, tx postgre.Transaction, refundThreadData *model.RefundThread) error {
+	if _, err := tx.ExecContext(ctx, CreateRefundThreadUserQuery,
 		refundThreadData.RefundID,
 		refundThreadData.UserID,
 		refundThreadData.IsSeller,
@@ -1709,29 +1746,6 @@ func (r *userRepo) CreateRefundThreadUser(ctx context.Context, refundThreadData
 	return nil
 }
 
-func (r *userRepo) InsertSessionRedis(ctx context.Context, duration int, key, status string) error {
-	if err := r.RedisClient.Set(ctx, key, status, time.Duration(duration)*time.Minute); err != nil {
-		return err.Err()
-	}
-
-	return nil
-}
-
-func (r *userRepo) GetSessionKeyRedis(ctx context.Context, key string) ([]string, error) {
-	keys := make([]string, 0)
-
-	iter := r.RedisClient.Scan(ctx, 0, key, 0).Iterator()
-	for iter.Next(ctx) {
-		keys = append(keys, iter.Val())
-	}
-
-	if err := iter.Err(); err != nil {
-		return keys, err
-	}
-
-	return keys, nil
-}
-
 func (r *userRepo) InsertNewOTPKeyChangeWalletPin(ctx context.Context, email, otp string) error {
 	key := fmt.Sprintf("wallet:%s:%s", constant.OtpKey, email)
 
@@ -1778,3 +1792,31 @@ func (r *userRepo) DeleteOTPValueChangeWalletPin(ctx context.Context, email stri
 
 	return value, nil
 }
+
+func (r *userRepo) InsertPaymentCallbackNonceRedis(ctx context.Context, nonce string, duration int) (bool, error) {
+	key := fmt.Sprintf("%s:%s", constant.SLPCallbackNonceKey, nonce)
+
+	res := r.RedisClient.SetNX(ctx, key, constant.TRUE, time.Duration(duration)*time.Minute)
+	if res.Err() != nil {
+		return false, res.Err()
+	}
+
+	return res.Val(), nil
+}
+
+func (r *userRepo) CreatePaymentCallback(ctx context.Context, callback *model.PaymentCallback) error {
+	_, err := r.PSQL.ExecContext(ctx, CreatePaymentCallbackQuery,
+		callback.TransactionID,
+		callback.CallbackType,
+		callback.Payload,
+		callback.Signature,
+		callback.Nonce,
+		callback.SignedAt,
+		callback.IsAccepted,
+		callback.RejectReason)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
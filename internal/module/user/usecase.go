@@ -5,6 +5,7 @@ import (
 	"murakali/internal/model"
 	"murakali/internal/module/user/delivery/body"
 	"murakali/pkg/pagination"
+	"murakali/pkg/session"
 )
 
 type UseCase interface {
@@ -16,18 +17,18 @@ type UseCase interface {
 	DeleteAddressByID(ctx context.Context, userID, addressID string) error
 	EditUser(ctx context.Context, userID string, requestBody body.EditUserRequest) (*model.User, error)
 	EditEmail(ctx context.Context, userID string, requestBody body.EditEmailRequest) (*model.User, error)
-	EditEmailUser(ctx context.Context, userID string, requestBody body.EditEmailUserRequest) (*model.User, error)
+	EditEmailUser(ctx context.Context, userID string, requestBody body.EditEmailUserRequest, client session.Client) (*model.User, error)
 	GetSealabsPay(ctx context.Context, userid string) ([]*model.SealabsPay, error)
-	AddSealabsPay(ctx context.Context, request body.AddSealabsPayRequest, userid string) error
+	AddSealabsPay(ctx context.Context, request body.AddSealabsPayRequest, userid string, client session.Client) error
 	PatchSealabsPay(ctx context.Context, cardNumber string, userid string) error
-	DeleteSealabsPay(ctx context.Context, userID, cardNumber string) error
+	DeleteSealabsPay(ctx context.Context, userID, cardNumber string, client session.Client) error
 	RegisterMerchant(ctx context.Context, userID string, shopName string) error
 	GetUserProfile(ctx context.Context, userID string) (*body.ProfileResponse, error)
 	UploadProfilePicture(ctx context.Context, imgURL, userID string) error
 	VerifyPasswordChange(ctx context.Context, userID string) error
 	SendOTPEmail(ctx context.Context, email string) error
-	VerifyOTP(ctx context.Context, requestBody body.VerifyOTPRequest, userID string) (string, error)
-	ChangePassword(ctx context.Context, userID string, newPassword string) error
+	VerifyOTP(ctx context.Context, requestBody body.VerifyOTPRequest, userID, clientIP string) (string, error)
+	ChangePassword(ctx context.Context, userID string, newPassword string, client session.Client) error
 	GetTransactionByID(ctx context.Context, transactionID string) (*body.GetTransactionByIDResponse, error)
 	GetTransactionByUserID(ctx context.Context, userID string, status int, pgn *pagination.Pagination) (*pagination.Pagination, error)
 	CreateTransaction(ctx context.Context, userID string, requestBody body.CreateTransactionRequest) (string, error)
@@ -44,14 +45,17 @@ type UseCase interface {
 	GetDetailWalletHistory(ctx context.Context, walletHistoryID, userID string) (*body.DetailHistoryWalletResponse, error)
 	GetWalletHistory(ctx context.Context, userID string, pgn *pagination.Pagination) (*pagination.Pagination, error)
 	TopUpWallet(ctx context.Context, userID string, requestBody body.TopUpWalletRequest) (string, error)
-	WalletStepUp(ctx context.Context, userID string, requestBody body.WalletStepUpRequest) (string, error)
+	WalletStepUp(ctx context.Context, userID string, requestBody body.WalletStepUpRequest, clientIP string) (string, error)
 	CreateWalletPayment(ctx context.Context, transactionID string) error
 	ChangeWalletPinStepUp(ctx context.Context, userID string, requestBody body.ChangeWalletPinStepUpRequest) (string, error)
 	ChangeWalletPinStepUpEmail(ctx context.Context, userID string) error
-	ChangeWalletPinStepUpVerify(ctx context.Context, requestBody body.VerifyOTPRequest, userID string) (string, error)
-	ChangeWalletPin(ctx context.Context, userID, pin string) error
+	ChangeWalletPinStepUpVerify(ctx context.Context, requestBody body.VerifyOTPRequest, userID, clientIP string) (string, error)
+	ChangeWalletPin(ctx context.Context, userID, pin string, client session.Client) error
 	CreateRefundUser(ctx context.Context, userID string, requestBody body.CreateRefundUserRequest) error
 	GetRefundOrder(ctx context.Context, userID string, refundID string) (*body.GetRefundThreadResponse, error)
 	CreateRefundThreadUser(ctx context.Context, userID string, requestBody *body.CreateRefundThreadRequest) error
-	CompletedRejectedRefund(ctx context.Context) error
+	CompletedRejectedRefund(ctx context.Context) (*model.JobReport, error)
+	GetSessions(ctx context.Context, userID, currentSessionID string) ([]*body.SessionResponse, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+	RevokeAllSessions(ctx context.Context, userID string) error
 }
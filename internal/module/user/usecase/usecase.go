@@ -7,22 +7,32 @@ import (
 	"database/sql"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"murakali/config"
 	"murakali/internal/constant"
 	"murakali/internal/model"
-	body2 "murakali/internal/module/location/delivery/body"
+	"murakali/internal/module/audit"
+	"murakali/internal/module/ledger"
+	"murakali/internal/module/notification"
+	"murakali/internal/module/outbox"
+	"murakali/internal/module/twofactor"
 	"murakali/internal/module/user"
 	"murakali/internal/module/user/delivery/body"
 	"murakali/internal/util"
-	smtp "murakali/pkg/email"
+	"murakali/pkg/attempt"
+	mail "murakali/pkg/email"
 	"murakali/pkg/httperror"
 	"murakali/pkg/jwt"
 	"murakali/pkg/pagination"
 	"murakali/pkg/postgre"
 	"murakali/pkg/response"
+	"murakali/pkg/session"
+	"murakali/pkg/shipping"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,13 +42,65 @@ import (
 )
 
 type userUC struct {
-	cfg      *config.Config
-	txRepo   *postgre.TxRepo
-	userRepo user.Repository
+	cfg       *config.Config
+	txRepo    *postgre.TxRepo
+	userRepo  user.Repository
+	shipping  shipping.Provider
+	sessions  session.Store
+	attempts  attempt.Limiter
+	twoFactor twofactor.UseCase
+	ledger    ledger.UseCase
+	audit     audit.UseCase
+	outbox    outbox.UseCase
+	notify    notification.UseCase
+	keys      *jwt.Keyring
 }
 
-func NewUserUseCase(cfg *config.Config, txRepo *postgre.TxRepo, userRepo user.Repository) user.UseCase {
-	return &userUC{cfg: cfg, txRepo: txRepo, userRepo: userRepo}
+var (
+	changePasswordOTPPolicy = attempt.Policy{
+		Name: "change_password_otp", MaxAttempts: 5, MaxIPAttempts: 20,
+		Window: 10 * time.Minute, Lockout: 5 * time.Minute, MaxLockout: 24 * time.Hour, Decay: 24 * time.Hour,
+	}
+	changeWalletPinOTPPolicy = attempt.Policy{
+		Name: "change_wallet_pin_otp", MaxAttempts: 5, MaxIPAttempts: 20,
+		Window: 10 * time.Minute, Lockout: 5 * time.Minute, MaxLockout: 24 * time.Hour, Decay: 24 * time.Hour,
+	}
+	walletPinPolicy = attempt.Policy{
+		Name: "wallet_pin", MaxAttempts: 3, MaxIPAttempts: 20,
+		Window: 15 * time.Minute, Lockout: 15 * time.Minute, MaxLockout: 24 * time.Hour, Decay: 24 * time.Hour,
+	}
+)
+
+func NewUserUseCase(cfg *config.Config, txRepo *postgre.TxRepo, userRepo user.Repository, shippingProvider shipping.Provider,
+	sessions session.Store, attempts attempt.Limiter, twoFactor twofactor.UseCase, ledgerUC ledger.UseCase, auditUC audit.UseCase,
+	outboxUC outbox.UseCase, notificationUC notification.UseCase, keys *jwt.Keyring) user.UseCase {
+	return &userUC{cfg: cfg, txRepo: txRepo, userRepo: userRepo, shipping: shippingProvider, sessions: sessions, attempts: attempts,
+		twoFactor: twoFactor, ledger: ledgerUC, audit: auditUC, outbox: outboxUC, notify: notificationUC, keys: keys}
+}
+
+// failAttempt records a failed attempt and returns err, or the lockout when
+// this attempt was one too many.
+func (u *userUC) failAttempt(ctx context.Context, policy attempt.Policy, err error, keys ...attempt.Key) error {
+	if errFail := u.attempts.Fail(ctx, policy, keys...); errFail != nil {
+		return errFail
+	}
+
+	return err
+}
+
+// verifyStepUp asks users with 2FA for a code on top of the PIN or OTP. A
+// wrong code counts as a failed attempt of the same policy.
+func (u *userUC) verifyStepUp(ctx context.Context, policy attempt.Policy, userID, clientIP, code string) error {
+	if err := u.twoFactor.VerifyStepUp(ctx, userID, code); err != nil {
+		var e *httperror.Error
+		if errors.As(err, &e) {
+			return u.failAttempt(ctx, policy, err, attempt.Account(userID), attempt.IP(clientIP))
+		}
+
+		return err
+	}
+
+	return nil
 }
 
 func (u *userUC) CreateAddress(ctx context.Context, userID string, requestBody body.CreateAddressRequest) error {
@@ -267,39 +329,13 @@ func (u *userUC) GetOrderByOrderID(ctx context.Context, orderID string) (*model.
 		totalWeight += int(detail.ProductWeight) * detail.OrderQuantity
 	}
 
-	var costRedis *string
-	key := fmt.Sprintf("%d:%d:%d:%s", order.SellerAddress.CityID, order.BuyerAddress.CityID, totalWeight, order.CourierCode)
-	costRedis, err = u.userRepo.GetCostRedis(ctx, key)
+	costResp, err := u.getShippingCost(ctx, order.SellerAddress.CityID, order.BuyerAddress.CityID, totalWeight, order.CourierCode)
 	if err != nil {
-		res, err := u.GetCostRajaOngkir(order.SellerAddress.CityID, order.BuyerAddress.CityID, totalWeight, order.CourierCode)
-		if err != nil {
-			return nil, err
-		}
-
-		redisValue, err := json.Marshal(res)
-		if err != nil {
-			return nil, err
-		}
-
-		if errInsert := u.userRepo.InsertCostRedis(ctx, key, string(redisValue)); errInsert != nil {
-			return nil, errInsert
-		}
-
-		value := string(redisValue)
-		costRedis = &value
-	}
-
-	var costResp body2.RajaOngkirCostResponse
-	if err := json.Unmarshal([]byte(*costRedis), &costResp); err != nil {
 		return nil, err
 	}
 
-	if len(costResp.Rajaongkir.Results) > 0 {
-		for _, cost := range costResp.Rajaongkir.Results[0].Costs {
-			if cost.Service == order.CourierService {
-				order.CourierETD = cost.Cost[0].Etd
-			}
-		}
+	if _, etd, ok := costResp.ServiceCost(order.CourierService); ok {
+		order.CourierETD = etd
 	}
 
 	return order, nil
@@ -319,13 +355,12 @@ func (u *userUC) ChangeOrderStatus(ctx context.Context, userID string, requestBo
 		return httperror.New(http.StatusBadRequest, response.BadRequestMessage)
 	}
 
-	err = u.userRepo.ChangeOrderStatus(ctx, requestBody)
-	if err != nil {
-		return err
-	}
+	return u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		if err := u.userRepo.ChangeOrderStatus(ctx, tx, requestBody); err != nil {
+			return err
+		}
 
-	if requestBody.OrderStatusID == constant.OrderStatusCompleted {
-		if err := u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		if requestBody.OrderStatusID == constant.OrderStatusCompleted {
 			productUnitSolds, errGet := u.userRepo.GetProductUnitSoldByOrderID(ctx, tx, requestBody.OrderID)
 			if errGet != nil {
 				return errGet
@@ -336,41 +371,41 @@ func (u *userUC) ChangeOrderStatus(ctx context.Context, userID string, requestBo
 					return err
 				}
 			}
-			return nil
-		},
-		); err != nil {
-			return err
 		}
-	}
 
-	return nil
+		return u.notify.NotifyOrderStatus(ctx, tx, requestBody.OrderID, requestBody.OrderStatusID)
+	})
 }
 
-func (u *userUC) GetCostRajaOngkir(origin, destination, weight int, code string) (*body2.RajaOngkirCostResponse, error) {
-	var responseCost body2.RajaOngkirCostResponse
-	url := fmt.Sprintf("%s/cost", u.cfg.External.OngkirAPIURL)
-	payload := fmt.Sprintf(
-		"origin=%d&destination=%d&weight=%d&courier=%s", origin, destination, weight, code)
-
-	req, err := http.NewRequest("POST", url, strings.NewReader(payload))
+// getShippingCost quotes a route through the redis cost cache, the same cache
+// the location module fills when it lists shipping options.
+func (u *userUC) getShippingCost(ctx context.Context, origin, destination, weight int, code string) (*shipping.Quote, error) {
+	key := shipping.CostKey(u.shipping, origin, destination, weight, code)
+	costRedis, err := u.userRepo.GetCostRedis(ctx, key)
 	if err != nil {
-		return nil, err
-	}
+		res, err := u.shipping.GetCost(ctx, origin, destination, weight, code)
+		if err != nil {
+			return nil, err
+		}
 
-	req.Header.Add("key", u.cfg.External.OngkirAPIKey)
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
+		redisValue, err := json.Marshal(res)
+		if err != nil {
+			return nil, err
+		}
+
+		if errInsert := u.userRepo.InsertCostRedis(ctx, key, string(redisValue)); errInsert != nil {
+			return nil, errInsert
+		}
+
+		return res, nil
 	}
-	defer res.Body.Close()
 
-	readErr := json.NewDecoder(res.Body).Decode(&responseCost)
-	if readErr != nil {
+	var quote shipping.Quote
+	if err := json.Unmarshal([]byte(*costRedis), &quote); err != nil {
 		return nil, err
 	}
 
-	return &responseCost, nil
+	return &quote, nil
 }
 
 func (u *userUC) GetTransactionDetailByID(ctx context.Context, transactionID, userID string) (*body.TransactionDetailResponse, error) {
@@ -458,20 +493,24 @@ func (u *userUC) DeleteAddressByID(ctx context.Context, userID, addressID string
 	return nil
 }
 
-func (u *userUC) CompletedRejectedRefund(ctx context.Context) error {
+func (u *userUC) CompletedRejectedRefund(ctx context.Context) (*model.JobReport, error) {
+	report := model.NewJobReport(constant.JobRejectedRefund)
+	defer report.Finish()
+
 	orderRefund, err := u.userRepo.GetRejectedRefund(ctx)
 	if err != nil {
-		return err
+		return report, err
 	}
 
 	for _, refund := range orderRefund {
 		if errUpdate := u.ChangeOrderStatus(ctx, refund.Order.UserID.String(),
 			body.ChangeOrderStatusRequest{OrderID: refund.Order.ID.String(), OrderStatusID: constant.OrderStatusCompleted}); errUpdate != nil {
-			return errUpdate
+			return report, errUpdate
 		}
+		report.RowsAffected++
 	}
 
-	return nil
+	return report, nil
 }
 
 func (u *userUC) EditUser(ctx context.Context, userID string, requestBody body.EditUserRequest) (*model.User, error) {
@@ -562,7 +601,8 @@ func (u *userUC) EditEmail(ctx context.Context, userID string, requestBody body.
 	return userModel, nil
 }
 
-func (u *userUC) EditEmailUser(ctx context.Context, userID string, requestBody body.EditEmailUserRequest) (*model.User, error) {
+func (u *userUC) EditEmailUser(ctx context.Context, userID string, requestBody body.EditEmailUserRequest,
+	client session.Client) (*model.User, error) {
 	value, err := u.userRepo.GetOTPValue(ctx, requestBody.Email)
 	if err != nil {
 		return nil, httperror.New(http.StatusBadRequest, response.OTPAlreadyExpiredMessage)
@@ -583,6 +623,7 @@ func (u *userUC) EditEmailUser(ctx context.Context, userID string, requestBody b
 		}
 	}
 
+	oldEmail := userModel.Email
 	userModel.Email = requestBody.Email
 	userModel.UpdatedAt.Time = time.Now()
 	userModel.UpdatedAt.Valid = true
@@ -594,7 +635,9 @@ func (u *userUC) EditEmailUser(ctx context.Context, userID string, requestBody b
 		if errEmailHistory := u.userRepo.CreateEmailHistory(ctx, tx, userModel.Email); errEmailHistory != nil {
 			return errEmailHistory
 		}
-		return nil
+
+		return u.audit.Record(ctx, tx, audit.NewEvent(userID, client, constant.AuditActionEmailChange,
+			constant.AuditTargetUser, userID, model.AuditDiff{"email": {From: oldEmail, To: userModel.Email}}))
 	})
 	if err != nil {
 		return nil, err
@@ -624,11 +667,12 @@ func (u *userUC) SendLinkOTPEmail(ctx context.Context, email string) error {
 
 	link := fmt.Sprintf("%s/verify/email?code=%s&email=%s", u.cfg.Server.Origin, hashedOTP, email)
 
-	subject := "Change email!"
-	msg := smtp.VerificationEmailLinkOTPBody(link)
-	go smtp.SendEmail(u.cfg, email, subject, msg)
+	msg, err := mail.NewMessage(email, "Change email!", mail.TemplateChangeEmail, mail.LinkData{Link: link})
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return u.outbox.Enqueue(ctx, u.txRepo.PSQL, msg)
 }
 
 func (u *userUC) GetSealabsPay(ctx context.Context, userid string) ([]*model.SealabsPay, error) {
@@ -640,7 +684,7 @@ func (u *userUC) GetSealabsPay(ctx context.Context, userid string) ([]*model.Sea
 	return slp, nil
 }
 
-func (u *userUC) AddSealabsPay(ctx context.Context, request body.AddSealabsPayRequest, userid string) error {
+func (u *userUC) AddSealabsPay(ctx context.Context, request body.AddSealabsPayRequest, userid string, client session.Client) error {
 	slpCount, err := u.userRepo.CheckUserSealabsPay(ctx, userid)
 	if err != nil {
 		return err
@@ -650,49 +694,42 @@ func (u *userUC) AddSealabsPay(ctx context.Context, request body.AddSealabsPayRe
 		return err
 	}
 
-	if slpCount == 0 {
-		if deletedSLPCount != 0 {
-			err = u.userRepo.UpdateUserSealabsPay(ctx, request, userid)
-			if err != nil {
-				return httperror.New(http.StatusBadRequest, response.SealabsCardAlreadyExist)
-			}
-		} else {
-			err = u.userRepo.AddSealabsPay(ctx, request, userid)
-			if err != nil {
-				return httperror.New(http.StatusBadRequest, response.SealabsCardAlreadyExist)
-			}
-		}
-	} else {
-		cardNumber, err := u.userRepo.CheckDefaultSealabsPay(ctx, userid)
+	var defaultCardNumber *string
+	if slpCount != 0 {
+		defaultCardNumber, err = u.userRepo.CheckDefaultSealabsPay(ctx, userid)
 		if err != nil && err != sql.ErrNoRows {
 			return err
 		}
-		if *cardNumber == request.CardNumber {
+		if defaultCardNumber != nil && *defaultCardNumber == request.CardNumber {
 			return httperror.New(http.StatusBadRequest, response.SealabsCardAlreadyExist)
 		}
-		err = u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
-			errTx := u.userRepo.SetDefaultSealabsPayTrans(ctx, tx, cardNumber)
-			if errTx != nil {
-				return errTx
-			}
-			if deletedSLPCount != 0 {
-				errTx = u.userRepo.UpdateUserSealabsPayTrans(ctx, tx, request, userid)
-				if errTx != nil {
-					return errTx
-				}
-			} else {
-				errTx = u.userRepo.AddSealabsPayTrans(ctx, tx, request, userid)
-				if errTx != nil {
-					return errTx
-				}
+	}
+
+	var errCard error
+	err = u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		if defaultCardNumber != nil {
+			if errCard = u.userRepo.SetDefaultSealabsPayTrans(ctx, tx, defaultCardNumber); errCard != nil {
+				return errCard
 			}
-			return nil
-		})
-		if err != nil {
-			return httperror.New(http.StatusBadRequest, response.SealabsCardAlreadyExist)
 		}
+		if deletedSLPCount != 0 {
+			errCard = u.userRepo.UpdateUserSealabsPayTrans(ctx, tx, request, userid)
+		} else {
+			errCard = u.userRepo.AddSealabsPayTrans(ctx, tx, request, userid)
+		}
+		if errCard != nil {
+			return errCard
+		}
+
+		return u.audit.Record(ctx, tx, audit.NewEvent(userid, client, constant.AuditActionSealabsPayAdd,
+			constant.AuditTargetSealabsPay, audit.MaskCardNumber(request.CardNumber),
+			model.AuditDiff{"name": {From: nil, To: request.Name}}))
+	})
+	if errCard != nil {
+		return httperror.New(http.StatusBadRequest, response.SealabsCardAlreadyExist)
 	}
-	return nil
+
+	return err
 }
 
 func (u *userUC) PatchSealabsPay(ctx context.Context, cardNumber, userid string) error {
@@ -707,7 +744,7 @@ func (u *userUC) PatchSealabsPay(ctx context.Context, cardNumber, userid string)
 	return nil
 }
 
-func (u *userUC) DeleteSealabsPay(ctx context.Context, userID, cardNumber string) error {
+func (u *userUC) DeleteSealabsPay(ctx context.Context, userID, cardNumber string, client session.Client) error {
 	slp, err := u.userRepo.GetSealabsPayUser(ctx, userID, cardNumber)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -721,12 +758,14 @@ func (u *userUC) DeleteSealabsPay(ctx context.Context, userID, cardNumber string
 		return httperror.New(http.StatusBadRequest, response.SealabsCardIsDefault)
 	}
 
-	errDelete := u.userRepo.DeleteSealabsPay(ctx, cardNumber)
-	if errDelete != nil {
-		return errDelete
-	}
+	return u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		if errDelete := u.userRepo.DeleteSealabsPay(ctx, tx, cardNumber); errDelete != nil {
+			return errDelete
+		}
 
-	return nil
+		return u.audit.Record(ctx, tx, audit.NewEvent(userID, client, constant.AuditActionSealabsPayDelete,
+			constant.AuditTargetSealabsPay, audit.MaskCardNumber(cardNumber), nil))
+	})
 }
 
 func (u *userUC) ActivateWallet(ctx context.Context, userID, pin string) error {
@@ -755,7 +794,6 @@ func (u *userUC) ActivateWallet(ctx context.Context, userID, pin string) error {
 	walletData.UserID = userModel.ID
 	walletData.Balance = 0
 	walletData.PIN = string(hashedPin)
-	walletData.AttemptCount = 0
 	walletData.ActiveDate.Valid = true
 	walletData.ActiveDate.Time = time.Now()
 
@@ -858,28 +896,43 @@ func (u *userUC) SendOTPEmail(ctx context.Context, email string) error {
 		return err
 	}
 
-	subject := "Change Password Verification!"
-	msg := smtp.VerificationEmailBody(otp)
-	go smtp.SendEmail(u.cfg, email, subject, msg)
+	msg, err := mail.NewMessage(email, "Change Password Verification!", mail.TemplateVerificationOTP, mail.OTPData{OTP: otp})
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return u.outbox.Enqueue(ctx, u.txRepo.PSQL, msg)
 }
 
-func (u *userUC) VerifyOTP(ctx context.Context, requestBody body.VerifyOTPRequest, userID string) (string, error) {
+func (u *userUC) VerifyOTP(ctx context.Context, requestBody body.VerifyOTPRequest, userID, clientIP string) (string, error) {
+	if err := u.attempts.Check(ctx, changePasswordOTPPolicy, attempt.Account(userID), attempt.IP(clientIP)); err != nil {
+		return "", err
+	}
+
 	userInfo, err := u.userRepo.GetUserByID(ctx, userID)
 	if err != nil {
 		return "", err
 	}
 	value, err := u.userRepo.GetOTPValue(ctx, userInfo.Email)
 	if err != nil {
-		return "", httperror.New(http.StatusBadRequest, response.OTPAlreadyExpiredMessage)
+		return "", u.failAttempt(ctx, changePasswordOTPPolicy, httperror.New(http.StatusBadRequest, response.OTPAlreadyExpiredMessage),
+			attempt.Account(userID), attempt.IP(clientIP))
 	}
 
 	if value != requestBody.OTP {
-		return "", httperror.New(http.StatusBadRequest, response.OTPIsNotValidMessage)
+		return "", u.failAttempt(ctx, changePasswordOTPPolicy, httperror.New(http.StatusBadRequest, response.OTPIsNotValidMessage),
+			attempt.Account(userID), attempt.IP(clientIP))
 	}
 
-	changePasswordToken, err := jwt.GenerateJWTChangePasswordToken(userInfo.ID.String(), u.cfg)
+	if err := u.verifyStepUp(ctx, changePasswordOTPPolicy, userID, clientIP, requestBody.TOTP); err != nil {
+		return "", err
+	}
+
+	if err := u.attempts.Reset(ctx, changePasswordOTPPolicy, attempt.Account(userID)); err != nil {
+		return "", err
+	}
+
+	changePasswordToken, err := jwt.GenerateJWTChangePasswordToken(userInfo.ID.String(), u.cfg, u.keys)
 	if err != nil {
 		return "", err
 	}
@@ -892,7 +945,7 @@ func (u *userUC) VerifyOTP(ctx context.Context, requestBody body.VerifyOTPReques
 	return changePasswordToken, nil
 }
 
-func (u *userUC) ChangePassword(ctx context.Context, userID, newPassword string) error {
+func (u *userUC) ChangePassword(ctx context.Context, userID, newPassword string, client session.Client) error {
 	userInfo, err := u.userRepo.GetUserByID(ctx, userID)
 	if err != nil {
 		return err
@@ -917,25 +970,63 @@ func (u *userUC) ChangePassword(ctx context.Context, userID, newPassword string)
 
 	password := string(hashedPassword)
 
-	err = u.userRepo.UpdatePasswordByID(ctx, userID, password)
+	err = u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		if errUpdate := u.userRepo.UpdatePasswordByID(ctx, tx, userID, password); errUpdate != nil {
+			return errUpdate
+		}
+
+		return u.audit.Record(ctx, tx, audit.NewEvent(userID, client, constant.AuditActionPasswordChange,
+			constant.AuditTargetUser, userID, model.AuditDiff{"password": audit.Redacted}))
+	})
 	if err != nil {
 		return err
 	}
 
-	keys, errKey := u.userRepo.GetSessionKeyRedis(ctx, fmt.Sprintf("session:%s:*", userID))
-	if errKey != nil {
-		return errKey
+	return u.sessions.RevokeAll(ctx, userID)
+}
+
+func (u *userUC) GetSessions(ctx context.Context, userID, currentSessionID string) ([]*body.SessionResponse, error) {
+	sessions, err := u.sessions.List(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, key := range keys {
-		if err := u.userRepo.InsertSessionRedis(ctx, u.cfg.JWT.AccessExpMin, key, constant.FALSE); err != nil {
-			return err
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	resp := make([]*body.SessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		resp = append(resp, &body.SessionResponse{
+			ID:         s.ID,
+			Device:     s.Device,
+			IP:         s.IP,
+			UserAgent:  s.UserAgent,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			IsCurrent:  s.ID == currentSessionID,
+		})
+	}
+
+	return resp, nil
+}
+
+func (u *userUC) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	if err := u.sessions.Revoke(ctx, userID, sessionID); err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return httperror.New(http.StatusNotFound, response.SessionNotFound)
 		}
+
+		return err
 	}
 
 	return nil
 }
 
+func (u *userUC) RevokeAllSessions(ctx context.Context, userID string) error {
+	return u.sessions.RevokeAll(ctx, userID)
+}
+
 func (u *userUC) TopUpWallet(ctx context.Context, userID string, requestBody body.TopUpWalletRequest) (string, error) {
 	wallet, err := u.userRepo.GetWalletByUserID(ctx, userID)
 	if err != nil {
@@ -957,7 +1048,7 @@ func (u *userUC) TopUpWallet(ctx context.Context, userID string, requestBody bod
 		transaction := &model.Transaction{}
 		transaction.WalletID = &wallet.ID
 		transaction.CardNumber = &card.CardNumber
-		transaction.TotalPrice = float64(requestBody.Amount)
+		transaction.TotalPrice = requestBody.Amount
 		transaction.ExpiredAt.Valid = true
 		transaction.ExpiredAt.Time = time.Now().Add(time.Hour * 24)
 
@@ -998,7 +1089,7 @@ func (u *userUC) CreateSLPPayment(ctx context.Context, transactionID string) (st
 		return "", httperror.New(http.StatusBadRequest, response.InvalidPaymentMethod)
 	}
 
-	signFormat := fmt.Sprintf("%s:%d:%s", *transaction.CardNumber, int(transaction.TotalPrice), u.cfg.External.SlpMerchantCode)
+	signFormat := fmt.Sprintf("%s:%d:%s", *transaction.CardNumber, transaction.TotalPrice.Rupiah(), u.cfg.External.SlpMerchantCode)
 	h := hmac.New(sha256.New, []byte(u.cfg.External.SlpAPIKey))
 	h.Write([]byte(signFormat))
 	sign := hex.EncodeToString(h.Sum(nil))
@@ -1059,6 +1150,10 @@ func (u *userUC) CreateWalletPayment(ctx context.Context, transactionID string)
 			if errOrder := u.userRepo.UpdateOrder(ctx, tx, order); errOrder != nil {
 				return errOrder
 			}
+
+			if errNotify := u.notify.NotifyOrderStatus(ctx, tx, order.ID.String(), order.OrderStatusID); errNotify != nil {
+				return errNotify
+			}
 		}
 
 		walletHistory := &model.WalletHistory{}
@@ -1073,22 +1168,15 @@ func (u *userUC) CreateWalletPayment(ctx context.Context, transactionID string)
 			return errWallet
 		}
 
-		wallet.Balance -= transaction.TotalPrice
-		wallet.UpdatedAt.Valid = true
-		wallet.UpdatedAt.Time = time.Now()
-
-		if errBalance := u.userRepo.UpdateWalletBalance(ctx, tx, wallet); errBalance != nil {
-			return errBalance
-		}
-
-		if errCredit := u.CreditToMarketplaceAccount(ctx, tx, transaction); errCredit != nil {
+		from := model.LedgerAccount{AccountType: constant.LedgerAccountBuyerWallet, WalletID: &wallet.ID}
+		if errCredit := u.CreditToMarketplaceAccount(ctx, tx, transaction, from); errCredit != nil {
 			return errCredit
 		}
 
 		return nil
 	})
 	if errTx != nil {
-		return err
+		return errTx
 	}
 
 	return nil
@@ -1106,7 +1194,7 @@ func (u *userUC) GetRedirectURL(transaction *model.Transaction, sign string) (st
 	payload := fmt.Sprintf(
 		"card_number=%s&amount=%d&merchant_code=%s&redirect_url=%s&callback_url=%s&signature=%s",
 		*transaction.CardNumber,
-		int(transaction.TotalPrice),
+		transaction.TotalPrice.Rupiah(),
 		u.cfg.External.SlpMerchantCode,
 		"https://www.google.com",
 		callbackURL,
@@ -1216,12 +1304,8 @@ func (u *userUC) GetTransactionByID(ctx context.Context, transactionID string) (
 }
 
 func (u *userUC) UpdateTransaction(ctx context.Context, transactionID string, requestBody body.SLPCallbackRequest) error {
-	transaction, err := u.userRepo.GetTransactionByID(ctx, transactionID)
+	transaction, err := u.verifyPaymentCallback(ctx, constant.SLPCallbackTypePayment, transactionID, requestBody)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return httperror.New(http.StatusBadRequest, response.TransactionIDNotExist)
-		}
-
 		return err
 	}
 
@@ -1247,9 +1331,14 @@ func (u *userUC) UpdateTransaction(ctx context.Context, transactionID string, re
 				if err := u.userRepo.UpdateOrder(ctx, tx, order); err != nil {
 					return err
 				}
+
+				if err := u.notify.NotifyOrderStatus(ctx, tx, order.ID.String(), order.OrderStatusID); err != nil {
+					return err
+				}
 			}
 
-			if err := u.CreditToMarketplaceAccount(ctx, tx, transaction); err != nil {
+			from := model.LedgerAccount{AccountType: constant.LedgerAccountSeaLabsPay}
+			if err := u.CreditToMarketplaceAccount(ctx, tx, transaction, from); err != nil {
 				return err
 			}
 
@@ -1296,12 +1385,8 @@ func (u *userUC) UpdateTransactionPaymentMethod(ctx context.Context, transaction
 }
 
 func (u *userUC) UpdateWalletTransaction(ctx context.Context, transactionID string, requestBody body.SLPCallbackRequest) error {
-	transaction, err := u.userRepo.GetTransactionByID(ctx, transactionID)
+	transaction, err := u.verifyPaymentCallback(ctx, constant.SLPCallbackTypeWallet, transactionID, requestBody)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return httperror.New(http.StatusBadRequest, response.TransactionIDNotExist)
-		}
-
 		return err
 	}
 
@@ -1354,14 +1439,12 @@ func (u *userUC) UpdateWalletTransaction(ctx context.Context, transactionID stri
 				return err
 			}
 
-			wallet.Balance += transaction.TotalPrice
-			wallet.UpdatedAt.Valid = true
-			wallet.UpdatedAt.Time = time.Now()
+			entry := model.NewTransferEntry(transaction.ID, walletHistory.Description,
+				model.LedgerAccount{AccountType: constant.LedgerAccountSeaLabsPay},
+				model.LedgerAccount{AccountType: constant.LedgerAccountBuyerWallet, WalletID: &wallet.ID},
+				transaction.TotalPrice)
 
-			if err := u.userRepo.UpdateWalletBalance(ctx, tx, wallet); err != nil {
-				return err
-			}
-			return nil
+			return u.ledger.PostEntry(ctx, tx, entry)
 		})
 
 		if err != nil {
@@ -1372,7 +1455,93 @@ func (u *userUC) UpdateWalletTransaction(ctx context.Context, transactionID stri
 	return nil
 }
 
-func (u *userUC) CreditToMarketplaceAccount(ctx context.Context, tx postgre.Transaction, transaction *model.Transaction) error {
+// verifyPaymentCallback authenticates a SeaLabs Pay callback and stores it in the
+// payment_callback audit table whether it is accepted or rejected.
+func (u *userUC) verifyPaymentCallback(ctx context.Context, callbackType, transactionID string,
+	requestBody body.SLPCallbackRequest) (*model.Transaction, error) {
+	payload, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	callback := &model.PaymentCallback{
+		CallbackType: callbackType,
+		Payload:      string(payload),
+		Signature:    requestBody.Signature,
+		Nonce:        requestBody.Nonce,
+	}
+	callback.TransactionID, _ = uuid.Parse(transactionID)
+	if requestBody.Timestamp > 0 {
+		callback.SignedAt.Valid = true
+		callback.SignedAt.Time = time.Unix(requestBody.Timestamp, 0)
+	}
+
+	transaction, errCheck := u.checkPaymentCallback(ctx, transactionID, requestBody, callback.SignedAt)
+	callback.IsAccepted = errCheck == nil
+	if errCheck != nil {
+		callback.RejectReason = errCheck.Error()
+	}
+
+	if err := u.userRepo.CreatePaymentCallback(ctx, callback); err != nil {
+		return nil, err
+	}
+
+	if errCheck != nil {
+		return nil, errCheck
+	}
+
+	return transaction, nil
+}
+
+func (u *userUC) checkPaymentCallback(ctx context.Context, transactionID string,
+	requestBody body.SLPCallbackRequest, signedAt sql.NullTime) (*model.Transaction, error) {
+	if requestBody.Nonce == "" || requestBody.MerchantCode != u.cfg.External.SlpMerchantCode ||
+		!requestBody.VerifySignature(u.cfg.External.SlpMerchantCode, u.cfg.External.SlpAPIKey) {
+		return nil, httperror.New(http.StatusUnauthorized, response.PaymentCallbackInvalid)
+	}
+
+	// the signature covers txn_id, not the URL, so a genuine callback of one
+	// transaction must not settle another one it is posted to
+	if requestBody.TxnID != transactionID {
+		return nil, httperror.New(http.StatusUnauthorized, response.PaymentCallbackInvalid)
+	}
+
+	window := time.Duration(constant.SLPCallbackWindowMin) * time.Minute
+	if !signedAt.Valid || time.Since(signedAt.Time) > window || time.Until(signedAt.Time) > window {
+		return nil, httperror.New(http.StatusUnauthorized, response.PaymentCallbackExpired)
+	}
+
+	transaction, err := u.userRepo.GetTransactionByID(ctx, transactionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, httperror.New(http.StatusBadRequest, response.TransactionIDNotExist)
+		}
+
+		return nil, err
+	}
+
+	if requestBody.Amount != strconv.FormatInt(transaction.TotalPrice.Rupiah(), 10) {
+		return nil, httperror.New(http.StatusBadRequest, response.PaymentCallbackAmountMismatch)
+	}
+
+	// a nonce is remembered for twice the window so it outlives every timestamp
+	// that would still be accepted with it
+	isNew, err := u.userRepo.InsertPaymentCallbackNonceRedis(ctx, requestBody.Nonce, constant.SLPCallbackWindowMin*2)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isNew {
+		return nil, httperror.New(http.StatusConflict, response.PaymentCallbackReplayed)
+	}
+
+	return transaction, nil
+}
+
+// CreditToMarketplaceAccount moves a paid transaction from the account that paid
+// it into the marketplace escrow.
+func (u *userUC) CreditToMarketplaceAccount(ctx context.Context, tx postgre.Transaction, transaction *model.Transaction,
+	from model.LedgerAccount) error {
 	walletMarketplace, err := u.userRepo.GetWalletByUserID(ctx, constant.AdminMarketplaceID)
 	if err != nil {
 		return err
@@ -1398,15 +1567,10 @@ func (u *userUC) CreditToMarketplaceAccount(ctx context.Context, tx postgre.Tran
 		return err
 	}
 
-	walletMarketplace.Balance += transaction.TotalPrice
-	walletMarketplace.UpdatedAt.Valid = true
-	walletMarketplace.UpdatedAt.Time = time.Now()
+	entry := model.NewTransferEntry(transaction.ID, walletHistory.Description, from,
+		model.LedgerAccount{AccountType: constant.LedgerAccountEscrow}, transaction.TotalPrice)
 
-	if err := u.userRepo.UpdateWalletBalance(ctx, tx, walletMarketplace); err != nil {
-		return err
-	}
-
-	return nil
+	return u.ledger.PostEntry(ctx, tx, entry)
 }
 
 func (u *userUC) GetWallet(ctx context.Context, userID string) (*model.Wallet, error) {
@@ -1419,6 +1583,11 @@ func (u *userUC) GetWallet(ctx context.Context, userID string) (*model.Wallet, e
 		return nil, err
 	}
 
+	wallet.Balance, err = u.ledger.GetWalletBalance(ctx, wallet.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
 	return wallet, nil
 }
 
@@ -1505,7 +1674,7 @@ func (u *userUC) GetDetailWalletHistory(ctx context.Context, walletHistoryID, us
 	return responseWallet, nil
 }
 
-func (u *userUC) WalletStepUp(ctx context.Context, userID string, requestBody body.WalletStepUpRequest) (string, error) {
+func (u *userUC) WalletStepUp(ctx context.Context, userID string, requestBody body.WalletStepUpRequest, clientIP string) (string, error) {
 	wallet, err := u.userRepo.GetWalletByUserID(ctx, userID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -1514,49 +1683,28 @@ func (u *userUC) WalletStepUp(ctx context.Context, userID string, requestBody bo
 		return "", err
 	}
 
-	if wallet.Balance-float64(requestBody.Amount) < 0 {
+	if wallet.Balance-requestBody.Amount < 0 {
 		return "", httperror.New(http.StatusBadRequest, response.WalletBalanceNotEnough)
 	}
 
-	if wallet.UnlockedAt.Valid && time.Until(wallet.UnlockedAt.Time) >= 0 {
-		return "", httperror.New(http.StatusBadRequest, response.WalletIsBlocked)
+	if err := u.attempts.Check(ctx, walletPinPolicy, attempt.Account(userID), attempt.IP(clientIP)); err != nil {
+		return "", err
 	}
 
-	blocked := false
-	invalidPin := false
 	if bcrypt.CompareHashAndPassword([]byte(wallet.PIN), []byte(requestBody.Pin)) != nil {
-		invalidPin = true
-		wallet.AttemptCount++
-		wallet.AttemptAt.Valid = true
-		wallet.AttemptAt.Time = time.Now()
-
-		if wallet.AttemptCount >= 3 {
-			blocked = true
-			wallet.AttemptCount = 0
-			wallet.UnlockedAt.Valid = true
-			wallet.UnlockedAt.Time = time.Now().Add(time.Minute * 15)
-		}
-	}
-
-	if !invalidPin {
-		wallet.AttemptCount = 0
-		wallet.AttemptAt.Valid = true
-		wallet.AttemptAt.Time = time.Now()
+		return "", u.failAttempt(ctx, walletPinPolicy, httperror.New(http.StatusBadRequest, response.WalletPinIsInvalid),
+			attempt.Account(userID), attempt.IP(clientIP))
 	}
 
-	if errWallet := u.userRepo.UpdateWallet(ctx, wallet); errWallet != nil {
-		return "", errWallet
-	}
-
-	if blocked {
-		return "", httperror.New(http.StatusBadRequest, response.WalletIsBlocked)
+	if err := u.verifyStepUp(ctx, walletPinPolicy, userID, clientIP, requestBody.TOTP); err != nil {
+		return "", err
 	}
 
-	if invalidPin {
-		return "", httperror.New(http.StatusBadRequest, response.WalletPinIsInvalid)
+	if err := u.attempts.Reset(ctx, walletPinPolicy, attempt.Account(userID)); err != nil {
+		return "", err
 	}
 
-	walletToken, err := jwt.GenerateJWTWalletToken(userID, "level1", u.cfg)
+	walletToken, err := jwt.GenerateJWTWalletToken(userID, "level1", u.cfg, u.keys)
 	if err != nil {
 		return "", err
 	}
@@ -1582,7 +1730,7 @@ func (u *userUC) ChangeWalletPinStepUp(ctx context.Context, userID string, reque
 		return "", httperror.New(http.StatusBadRequest, response.InvalidPasswordMessage)
 	}
 
-	walletToken, err := jwt.GenerateJWTWalletToken(userID, "level2", u.cfg)
+	walletToken, err := jwt.GenerateJWTWalletToken(userID, "level2", u.cfg, u.keys)
 	if err != nil {
 		return "", err
 	}
@@ -1590,7 +1738,7 @@ func (u *userUC) ChangeWalletPinStepUp(ctx context.Context, userID string, reque
 	return walletToken, nil
 }
 
-func (u *userUC) ChangeWalletPin(ctx context.Context, userID, pin string) error {
+func (u *userUC) ChangeWalletPin(ctx context.Context, userID, pin string, client session.Client) error {
 	wallet, err := u.userRepo.GetWalletByUserID(ctx, userID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -1605,11 +1753,14 @@ func (u *userUC) ChangeWalletPin(ctx context.Context, userID, pin string) error
 	}
 
 	wallet.PIN = string(hashedPin)
-	if err := u.userRepo.UpdateWalletPin(ctx, wallet); err != nil {
-		return err
-	}
+	return u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		if err := u.userRepo.UpdateWalletPin(ctx, tx, wallet); err != nil {
+			return err
+		}
 
-	return nil
+		return u.audit.Record(ctx, tx, audit.NewEvent(userID, client, constant.AuditActionWalletPinChange,
+			constant.AuditTargetWallet, wallet.ID.String(), model.AuditDiff{"pin": audit.Redacted}))
+	})
 }
 
 func (u *userUC) CreateTransaction(ctx context.Context, userID string, requestBody body.CreateTransactionRequest) (string, error) {
@@ -1661,13 +1812,17 @@ func (u *userUC) CreateTransaction(ctx context.Context, userID string, requestBo
 		transactionData.VoucherMarketplaceID = &voucherMarketplace.ID
 	}
 
-	voucherShopList := make([]*model.Voucher, 0)
 	promotionMap := make(map[string]int, 0)
 	qtyTotalProduct := make(map[string]int, 0)
-	promotionList := make([]*model.Promotion, 0)
+	// promotionsByShop holds the promotions applied to each order, claimed
+	// once the order exists so the claim can be given back with it
+	promotionsByShop := make(map[uuid.UUID][]*model.Promotion, 0)
+	// soldOutCartItems are removed from the cart after the checkout is rolled
+	// back, a delete inside the transaction would be rolled back with it
+	soldOutCartItems := make([]*model.CartItem, 0)
 
 	data, err := u.txRepo.WithTransactionReturnData(func(tx postgre.Transaction) (interface{}, error) {
-		var totalDeliveryFee float64
+		var totalDeliveryFee model.Money
 		if len(requestBody.CartItems) == 0 {
 			return nil, httperror.New(http.StatusBadRequest, response.CartIsEmpty)
 		}
@@ -1709,6 +1864,7 @@ func (u *userUC) CreateTransaction(ctx context.Context, userID string, requestBo
 				Items: make([]*body.OrderItemResponse, 0),
 			}
 			isAvail := true
+			totalWeight := 0
 
 			if len(cart.ProductDetails) == 0 {
 				return nil, httperror.New(http.StatusBadRequest, response.CartIsEmpty)
@@ -1760,17 +1916,15 @@ func (u *userUC) CreateTransaction(ctx context.Context, userID string, requestBo
 					}
 					_, subPrice = util.CalculateDiscount(productDetailData.Price, DiscountPromotion)
 					if promotionMap[promo.ID.String()] == 0 {
-						promotionList = append(promotionList, promo)
+						promotionsByShop[cartShop.ID] = append(promotionsByShop[cartShop.ID], promo)
 					}
 					promotionMap[promo.ID.String()] = 1
 				}
-				totalPrice := subPrice * float64(bodyProductDetail.Quantity)
+				totalPrice := subPrice.Mul(bodyProductDetail.Quantity)
+				totalWeight += int(productDetailData.Weight) * bodyProductDetail.Quantity
 				if int(productDetailData.Stock)-bodyProductDetail.Quantity < 0 {
 					isAvail = false
-					errCart := u.userRepo.DeleteCartItemByID(ctx, tx, cartData)
-					if errCart != nil {
-						return nil, errCart
-					}
+					soldOutCartItems = append(soldOutCartItems, cartData)
 				}
 
 				orderItem := &model.OrderItem{
@@ -1802,26 +1956,37 @@ func (u *userUC) CreateTransaction(ctx context.Context, userID string, requestBo
 					MaxDiscountPrice:   voucherShop.MaxDiscountPrice,
 				}
 				_, subOrderPrice = util.CalculateDiscount(subOrderPrice, discountVoucher)
-				voucherShopList = append(voucherShopList, voucherShop)
 			}
 			orderData.TotalPrice = subOrderPrice
 
-			buyerAddressString, errBAS := u.getAddressString(ctx, userModel.ID.String(), false)
+			buyerAddress, buyerAddressString, errBAS := u.getAddress(ctx, userModel.ID.String(), false)
 			if errBAS != nil {
 				return nil, errBAS
 			}
-			shopAddressString, errSAS := u.getAddressString(ctx, cartShop.UserID.String(), true)
+			shopAddress, shopAddressString, errSAS := u.getAddress(ctx, cartShop.UserID.String(), true)
 			if errSAS != nil {
 				return nil, errSAS
 			}
 
+			costResp, errCost := u.getShippingCost(ctx, shopAddress.CityID, buyerAddress.CityID, totalWeight, courierShop.Code)
+			if errCost != nil {
+				return nil, errCost
+			}
+			deliveryFee, _, ok := costResp.ServiceCost(courierShop.Service)
+			if !ok {
+				return nil, httperror.New(http.StatusBadRequest, response.ShippingServiceUnavailable)
+			}
+			if model.NewMoney(int64(deliveryFee)) != cart.CourierFee {
+				return nil, httperror.New(http.StatusBadRequest, response.ShippingFeeChanged)
+			}
+
 			orderData.ShopID = cartShop.ID
 			orderData.UserID = userModel.ID
 			orderData.BuyerAddress = buyerAddressString
 			orderData.ShopAddress = shopAddressString
 			orderData.VoucherShopID = voucherShopID
 			orderData.CourierID = courierShop.ID
-			orderData.DeliveryFee = cart.CourierFee
+			orderData.DeliveryFee = model.NewMoney(int64(deliveryFee))
 			orderData.OrderStatusID = 1
 
 			orderResponse.OrderData = orderData
@@ -1831,6 +1996,7 @@ func (u *userUC) CreateTransaction(ctx context.Context, userID string, requestBo
 			orderResponses = append(orderResponses, orderResponse)
 		}
 
+		marketplacePrice := transactionData.TotalPrice
 		if voucherMarketplace.ID != uuid.Nil {
 			discountVoucherMarketplace := &model.Discount{
 				DiscountPercentage: voucherMarketplace.DiscountPercentage,
@@ -1862,24 +2028,13 @@ func (u *userUC) CreateTransaction(ctx context.Context, userID string, requestBo
 		}
 
 		if voucherMarketplace.ID != uuid.Nil {
-			voucherMarketplace.Quota--
-			if errVoucherMarketplace := u.userRepo.UpdateVoucherQuota(ctx, tx, voucherMarketplace); errVoucherMarketplace != nil {
-				return nil, errVoucherMarketplace
-			}
-		}
-
-		for _, vs := range voucherShopList {
-			vs.Quota--
-			if errVoucherShop := u.userRepo.UpdateVoucherQuota(ctx, tx, vs); errVoucherShop != nil {
-				return nil, errVoucherShop
+			redemption := &model.VoucherRedemption{
+				VoucherID:     voucherMarketplace.ID,
+				UserID:        userModel.ID,
+				TransactionID: *transactionID,
 			}
-		}
-
-		for _, promo := range promotionList {
-			reduceQty := qtyTotalProduct[promo.ProductID.String()]
-			promo.Quota -= reduceQty
-			if errPromo := u.userRepo.UpdatePromotionQuota(ctx, tx, promo); errPromo != nil {
-				return nil, errPromo
+			if errVoucherMarketplace := u.claimVoucher(ctx, tx, redemption, marketplacePrice); errVoucherMarketplace != nil {
+				return nil, errVoucherMarketplace
 			}
 		}
 
@@ -1890,17 +2045,48 @@ func (u *userUC) CreateTransaction(ctx context.Context, userID string, requestBo
 				return nil, errOrder
 			}
 
+			for _, promo := range promotionsByShop[o.OrderData.ShopID] {
+				claim := &model.PromotionClaim{
+					PromotionID:   promo.ID,
+					TransactionID: *transactionID,
+					OrderID:       *orderID,
+					Quantity:      qtyTotalProduct[promo.ProductID.String()],
+				}
+				if errPromo := u.claimPromotion(ctx, tx, claim); errPromo != nil {
+					return nil, errPromo
+				}
+			}
+
+			if o.OrderData.VoucherShopID != nil {
+				var orderPrice model.Money
+				for _, i := range o.Items {
+					orderPrice += i.Item.TotalPrice
+				}
+				redemption := &model.VoucherRedemption{
+					VoucherID:     *o.OrderData.VoucherShopID,
+					UserID:        userModel.ID,
+					TransactionID: *transactionID,
+					OrderID:       orderID,
+				}
+				if errVoucherShop := u.claimVoucher(ctx, tx, redemption, orderPrice); errVoucherShop != nil {
+					return nil, errVoucherShop
+				}
+			}
+
 			for _, i := range o.Items {
 				i.Item.OrderID = *orderID
 				_, errItem := u.userRepo.CreateOrderItem(ctx, tx, i.Item)
 				if errItem != nil {
 					return nil, errItem
 				}
-				i.ProductDetailData.Stock -= i.CartItemData.Quantity
-				errProduct := u.userRepo.UpdateProductDetailStock(ctx, tx, i.ProductDetailData)
+				reserved, errProduct := u.userRepo.ReserveProductDetailStock(ctx, tx, orderID.String(),
+					i.ProductDetailData.ID.String(), i.Item.Quantity)
 				if errProduct != nil {
 					return nil, errProduct
 				}
+				if !reserved {
+					return nil, httperror.New(http.StatusBadRequest, response.ProductQuantityNotAvailable)
+				}
 				errCart := u.userRepo.DeleteCartItemByID(ctx, tx, i.CartItemData)
 				if errCart != nil {
 					return nil, errCart
@@ -1910,32 +2096,93 @@ func (u *userUC) CreateTransaction(ctx context.Context, userID string, requestBo
 		return transactionID.String(), nil
 	})
 	if err != nil {
+		for _, cartData := range soldOutCartItems {
+			if errCart := u.userRepo.DeleteCartItemByID(ctx, u.txRepo.PSQL, cartData); errCart != nil {
+				return "", errCart
+			}
+		}
 		return "", err
 	}
 	return data.(string), nil
 }
 
-func (u *userUC) getAddressString(ctx context.Context, userID string, isShop bool) (string, error) {
+// claimPromotion takes the quantity of the order out of the promotion quota
+// and records it, so it is given back when the order is canceled.
+func (u *userUC) claimPromotion(ctx context.Context, tx postgre.Transaction, claim *model.PromotionClaim) error {
+	claimed, err := u.userRepo.ClaimPromotionQuota(ctx, tx, claim.PromotionID.String(), claim.Quantity)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return httperror.New(http.StatusBadRequest, response.PromotionQuotaExhausted)
+	}
+
+	return u.userRepo.CreatePromotionClaim(ctx, tx, claim)
+}
+
+// claimVoucher checks the voucher again under a row lock, now that the order
+// is about to be placed, and takes one use of it for the user. price is what
+// the voucher is applied to, before its own discount.
+func (u *userUC) claimVoucher(ctx context.Context, tx postgre.Transaction, redemption *model.VoucherRedemption, price model.Money) error {
+	voucher, err := u.userRepo.GetVoucherForUpdate(ctx, tx, redemption.VoucherID.String())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return httperror.New(http.StatusBadRequest, response.VoucherNotActive)
+		}
+		return err
+	}
+
+	now := time.Now()
+	if now.Before(voucher.ActivedDate) || now.After(voucher.ExpiredDate) {
+		return httperror.New(http.StatusBadRequest, response.VoucherNotActive)
+	}
+
+	if voucher.MinProductPrice != nil && price < *voucher.MinProductPrice {
+		return httperror.New(http.StatusBadRequest, response.VoucherMinPriceNotMet)
+	}
+
+	used, err := u.userRepo.CountVoucherRedemptionByUser(ctx, tx, redemption.VoucherID.String(), redemption.UserID.String())
+	if err != nil {
+		return err
+	}
+
+	if used >= voucher.LimitPerUser {
+		return httperror.New(http.StatusBadRequest, response.VoucherUsageLimitReached)
+	}
+
+	claimed, err := u.userRepo.ClaimVoucherQuota(ctx, tx, redemption.VoucherID.String())
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		return httperror.New(http.StatusBadRequest, response.VoucherQuotaExhausted)
+	}
+
+	return u.userRepo.CreateVoucherRedemption(ctx, tx, redemption)
+}
+
+func (u *userUC) getAddress(ctx context.Context, userID string, isShop bool) (*model.Address, string, error) {
 	AddressModel := &model.Address{}
 	var err error
 	if isShop {
 		AddressModel, err = u.userRepo.GetAddressBySellerID(ctx, userID)
 		if err != nil {
-			return "", err
+			return nil, "", err
 		}
 	}
 	if !isShop {
 		AddressModel, err = u.userRepo.GetAddressByBuyerID(ctx, userID)
 		if err != nil {
-			return "", err
+			return nil, "", err
 		}
 	}
 	resultAddress, errMarshal := json.Marshal(AddressModel)
 	if errMarshal != nil {
-		return "", errMarshal
+		return nil, "", errMarshal
 	}
 
-	return string(resultAddress), nil
+	return AddressModel, string(resultAddress), nil
 }
 
 func (u *userUC) CreateRefundUser(ctx context.Context, userID string, requestBody body.CreateRefundUserRequest) error {
@@ -2119,12 +2366,13 @@ func (u *userUC) CreateRefundThreadUser(ctx context.Context, userID string, requ
 		Text:     requestBody.Text,
 	}
 
-	err = u.userRepo.CreateRefundThreadUser(ctx, refundThreadData)
-	if err != nil {
-		return err
-	}
+	return u.txRepo.WithTransaction(func(tx postgre.Transaction) error {
+		if err := u.userRepo.CreateRefundThreadUser(ctx, tx, refundThreadData); err != nil {
+			return err
+		}
 
-	return nil
+		return u.notify.NotifyRefundReply(ctx, tx, requestBody.RefundID, requestBody.Text, false)
+	})
 }
 
 func (u *userUC) ChangeWalletPinStepUpEmail(ctx context.Context, userID string) error {
@@ -2152,21 +2400,31 @@ func (u *userUC) ChangeWalletPinStepUpEmail(ctx context.Context, userID string)
 	return nil
 }
 
-func (u *userUC) ChangeWalletPinStepUpVerify(ctx context.Context, requestBody body.VerifyOTPRequest, userID string) (string, error) {
+func (u *userUC) ChangeWalletPinStepUpVerify(ctx context.Context, requestBody body.VerifyOTPRequest, userID, clientIP string) (string, error) {
+	if err := u.attempts.Check(ctx, changeWalletPinOTPPolicy, attempt.Account(userID), attempt.IP(clientIP)); err != nil {
+		return "", err
+	}
+
 	userInfo, err := u.userRepo.GetUserByID(ctx, userID)
 	if err != nil {
 		return "", err
 	}
 	value, err := u.userRepo.GetOTPValueChangeWalletPin(ctx, userInfo.Email)
 	if err != nil {
-		return "", httperror.New(http.StatusBadRequest, response.OTPAlreadyExpiredMessage)
+		return "", u.failAttempt(ctx, changeWalletPinOTPPolicy, httperror.New(http.StatusBadRequest, response.OTPAlreadyExpiredMessage),
+			attempt.Account(userID), attempt.IP(clientIP))
 	}
 
 	if value != requestBody.OTP {
-		return "", httperror.New(http.StatusBadRequest, response.OTPIsNotValidMessage)
+		return "", u.failAttempt(ctx, changeWalletPinOTPPolicy, httperror.New(http.StatusBadRequest, response.OTPIsNotValidMessage),
+			attempt.Account(userID), attempt.IP(clientIP))
+	}
+
+	if err := u.attempts.Reset(ctx, changeWalletPinOTPPolicy, attempt.Account(userID)); err != nil {
+		return "", err
 	}
 
-	changeWalletPinToken, err := jwt.GenerateJWTWalletToken(userID, "level2", u.cfg)
+	changeWalletPinToken, err := jwt.GenerateJWTWalletToken(userID, "level2", u.cfg, u.keys)
 	if err != nil {
 		return "", err
 	}
@@ -2189,9 +2447,10 @@ func (u *userUC) SendOTPChangeWalletPinEmail(ctx context.Context, email string)
 		return err
 	}
 
-	subject := "Change Wallet Pin Verification!"
-	msg := smtp.VerificationEmailBody(otp)
-	go smtp.SendEmail(u.cfg, email, subject, msg)
+	msg, err := mail.NewMessage(email, "Change Wallet Pin Verification!", mail.TemplateVerificationOTP, mail.OTPData{OTP: otp})
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return u.outbox.Enqueue(ctx, u.txRepo.PSQL, msg)
 }
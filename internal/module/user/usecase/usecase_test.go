@@ -2,18 +2,37 @@ package usecase
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"murakali/config"
 	"murakali/internal/constant"
 	"murakali/internal/model"
-	body2 "murakali/internal/module/location/delivery/body"
+	auditMocks "murakali/internal/module/audit/mocks"
+	ledgerMocks "murakali/internal/module/ledger/mocks"
+	notificationMocks "murakali/internal/module/notification/mocks"
+	outboxMocks "murakali/internal/module/outbox/mocks"
+	twoFactorMocks "murakali/internal/module/twofactor/mocks"
 	"murakali/internal/module/user/delivery/body"
 	"murakali/internal/module/user/mocks"
+	"murakali/pkg/attempt"
+	attemptMocks "murakali/pkg/attempt/mocks"
+	mail "murakali/pkg/email"
+	"murakali/pkg/httperror"
+	"murakali/pkg/jwt/jwttest"
 	"murakali/pkg/pagination"
 	"murakali/pkg/postgre"
 	"murakali/pkg/response"
+	"murakali/pkg/session"
+	sessionMocks "murakali/pkg/session/mocks"
+	"murakali/pkg/shipping"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,6 +42,9 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+// testKeys signs the tokens the use cases issue.
+var testKeys = jwttest.NewKeyring("test")
+
 func TestUserUC_CreateAddress(t *testing.T) {
 	testCase := []struct {
 		name        string
@@ -131,7 +153,7 @@ func TestUserUC_CreateAddress(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			err := u.CreateAddress(context.Background(), tc.userID, tc.body)
@@ -286,7 +308,7 @@ func Test_userUC_UpdateAddressByID(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			err := u.UpdateAddressByID(context.Background(), tc.userID, tc.addressID, tc.body)
@@ -434,7 +456,7 @@ func Test_userUC_GetAddress(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.GetAddress(context.Background(), tc.userID, tc.pgn, tc.queryRequest)
@@ -493,7 +515,7 @@ func Test_userUC_GetOrder(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.GetOrder(context.Background(), tc.userID, tc.orderStatusID, tc.pgn)
@@ -515,146 +537,8 @@ func Test_userUC_GetOrderByOrderID(t *testing.T) {
 			name:    "Success Get Order By Order ID",
 			orderID: "123456",
 			mock: func(t *testing.T, r *mocks.Repository) {
-				tempString := "{\"rajaongkir\":{\"query\":{\"origin\":\"501\",\"destination\":\"114\",\"weight\":1700,\"courier\":\"jne\"},\"status\":{\"code\":200,\"description\":\"OK\"},\"origin_details\":{\"city_id\":\"501\",\"province_id\":\"5\",\"province\":\"DI Yogyakarta\",\"type\":\"Kota\",\"city_name\":\"Yogyakarta\",\"postal_code\":\"55000\"},\"destination_details\":{\"city_id\":\"114\",\"province_id\":\"1\",\"province\":\"Bali\",\"type\":\"Kota\",\"city_name\":\"Denpasar\",\"postal_code\":\"80000\"},\"results\":[{\"code\":\"jne\",\"name\":\"Jalur Nugraha Ekakurir (JNE)\",\"costs\":[{\"service\":\"OKE\",\"description\":\"Ongkos Kirim Ekonomis\",\"cost\":[{\"value\":38000,\"etd\":\"4-5\",\"note\":\"\"}]},{\"service\":\"REG\",\"description\":\"Layanan Reguler\",\"cost\":[{\"value\":44000,\"etd\":\"2-3\",\"note\":\"\"}]},{\"service\":\"SPS\",\"description\":\"Super Speed\",\"cost\":[{\"value\":349000,\"etd\":\"\",\"note\":\"\"}]},{\"service\":\"YES\",\"description\":\"Yakin Esok Sampai\",\"cost\":[{\"value\":98000,\"etd\":\"1-1\",\"note\":\"\"}]}]}]}}"
-				tempRajaOngkir := body2.RajaOngkirCostResponse{
-					Rajaongkir: struct {
-						Query struct {
-							Origin      string "json:\"origin,omitempty\""
-							Destination string "json:\"destination,omitempty\""
-							Weight      int    "json:\"weight,omitempty\""
-							Courier     string "json:\"courier,omitempty\""
-						} "json:\"query,omitempty\""
-						Status struct {
-							Code        int    "json:\"code,omitempty\""
-							Description string "json:\"description,omitempty\""
-						} "json:\"status,omitempty\""
-						OriginDetails struct {
-							CityID     string "json:\"city_id,omitempty\""
-							ProvinceID string "json:\"province_id,omitempty\""
-							Province   string "json:\"province,omitempty\""
-							Type       string "json:\"type,omitempty\""
-							CityName   string "json:\"city_name,omitempty\""
-							PostalCode string "json:\"postal_code,omitempty\""
-						} "json:\"origin_details,omitempty\""
-						DestinationDetails struct {
-							CityID     string "json:\"city_id,omitempty\""
-							ProvinceID string "json:\"province_id,omitempty\""
-							Province   string "json:\"province,omitempty\""
-							Type       string "json:\"type,omitempty\""
-							CityName   string "json:\"city_name,omitempty\""
-							PostalCode string "json:\"postal_code,omitempty\""
-						} "json:\"destination_details,omitempty\""
-						Results []struct {
-							Code  string "json:\"code,omitempty\""
-							Name  string "json:\"name,omitempty\""
-							Costs []struct {
-								Service     string "json:\"service,omitempty\""
-								Description string "json:\"description,omitempty\""
-								Cost        []struct {
-									Value int    "json:\"value,omitempty\""
-									Etd   string "json:\"etd,omitempty\""
-									Note  string "json:\"note,omitempty\""
-								} "json:\"cost,omitempty\""
-							} "json:\"costs,omitempty\""
-						} "json:\"results,omitempty\""
-					}{
-						Query: struct {
-							Origin      string "json:\"origin,omitempty\""
-							Destination string "json:\"destination,omitempty\""
-							Weight      int    "json:\"weight,omitempty\""
-							Courier     string "json:\"courier,omitempty\""
-						}{
-							Origin:      "origin",
-							Destination: "des",
-							Weight:      11,
-							Courier:     "jne",
-						},
-						Status: struct {
-							Code        int    "json:\"code,omitempty\""
-							Description string "json:\"description,omitempty\""
-						}{
-							Code:        1,
-							Description: "descrpition",
-						},
-						OriginDetails: struct {
-							CityID     string "json:\"city_id,omitempty\""
-							ProvinceID string "json:\"province_id,omitempty\""
-							Province   string "json:\"province,omitempty\""
-							Type       string "json:\"type,omitempty\""
-							CityName   string "json:\"city_name,omitempty\""
-							PostalCode string "json:\"postal_code,omitempty\""
-						}{
-							CityID:     "1",
-							ProvinceID: "1",
-							Province:   "palembang",
-							Type:       "type",
-							CityName:   "palembang",
-							PostalCode: "12212",
-						},
-						DestinationDetails: struct {
-							CityID     string "json:\"city_id,omitempty\""
-							ProvinceID string "json:\"province_id,omitempty\""
-							Province   string "json:\"province,omitempty\""
-							Type       string "json:\"type,omitempty\""
-							CityName   string "json:\"city_name,omitempty\""
-							PostalCode string "json:\"postal_code,omitempty\""
-						}{
-							CityID:     "test",
-							ProvinceID: "test",
-							Province:   "test",
-							Type:       "test",
-							CityName:   "test",
-							PostalCode: "test",
-						},
-						Results: make([]struct {
-							Code  string "json:\"code,omitempty\""
-							Name  string "json:\"name,omitempty\""
-							Costs []struct {
-								Service     string "json:\"service,omitempty\""
-								Description string "json:\"description,omitempty\""
-								Cost        []struct {
-									Value int    "json:\"value,omitempty\""
-									Etd   string "json:\"etd,omitempty\""
-									Note  string "json:\"note,omitempty\""
-								} "json:\"cost,omitempty\""
-							} "json:\"costs,omitempty\""
-						}, 1),
-					}}
-				for _, res := range tempRajaOngkir.Rajaongkir.Results {
-					fmt.Println("result index")
-					res.Code = "code"
-					res.Name = "name"
-					res.Costs = make([]struct {
-						Service     string "json:\"service,omitempty\""
-						Description string "json:\"description,omitempty\""
-						Cost        []struct {
-							Value int    "json:\"value,omitempty\""
-							Etd   string "json:\"etd,omitempty\""
-							Note  string "json:\"note,omitempty\""
-						} "json:\"cost,omitempty\""
-					}, 1)
-					fmt.Println("tempRajaOngkir.Rajaongkir.Results", res)
-					for _, c := range res.Costs {
-						fmt.Println("cost masuk")
-						c.Service = "service"
-						c.Description = "description"
-						c.Cost = make([]struct {
-							Value int    "json:\"value,omitempty\""
-							Etd   string "json:\"etd,omitempty\""
-							Note  string "json:\"note,omitempty\""
-						}, 1)
-						fmt.Println("res.Costs", c)
-						for _, cc := range c.Cost {
-							fmt.Println("ccccc")
-							cc.Value = 111
-							cc.Etd = "etd"
-							cc.Note = "note"
-							fmt.Println("cc", cc)
-						}
-						fmt.Println("after res.Costs", c)
-					}
-				}
-				fmt.Println("test - rajaongkir", tempRajaOngkir)
+				cached, _ := json.Marshal(newStubQuote("OKE", 38000))
+				tempString := string(cached)
 				r.On("GetOrderByOrderID", mock.Anything, mock.Anything).Return(&model.Order{
 					SellerAddress: &model.Address{CityID: 501},
 					BuyerAddress:  &model.Address{CityID: 114},
@@ -669,9 +553,7 @@ func Test_userUC_GetOrderByOrderID(t *testing.T) {
 				}, nil)
 				r.On("GetBuyerIDByOrderID", mock.Anything, mock.Anything).Return("buyer", nil)
 				r.On("GetSellerIDByOrderID", mock.Anything, mock.Anything).Return("seller", nil)
-				r.On("GetCostRedis", mock.Anything, mock.Anything).Return(&tempString, nil)
-				// r.On("GetCostRajaOngkir", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&tempRajaOngkir, nil)
-				// r.On("InsertCostRedis", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("GetCostRedis", mock.Anything, "stub:501:114:100:jne").Return(&tempString, nil)
 			},
 			expectedErr: nil,
 		},
@@ -679,7 +561,7 @@ func Test_userUC_GetOrderByOrderID(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, &stubShipping{}, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.GetOrderByOrderID(context.Background(), tc.orderID)
@@ -695,7 +577,7 @@ func Test_userUC_ChangeOrderStatus(t *testing.T) {
 		name        string
 		userID      string
 		requestBody body.ChangeOrderStatusRequest
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase)
 		expectedErr error
 	}{
 		{
@@ -705,7 +587,7 @@ func Test_userUC_ChangeOrderStatus(t *testing.T) {
 				OrderID:       "123",
 				OrderStatusID: 7,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
 				tempBuyerID := "123456"
 				tempProductSold := make([]*body.ProductUnitSoldOrderQty, 0)
 				tempBody := &body.ProductUnitSoldOrderQty{
@@ -713,9 +595,10 @@ func Test_userUC_ChangeOrderStatus(t *testing.T) {
 				}
 				tempProductSold = append(tempProductSold, tempBody)
 				r.On("GetBuyerIDByOrderID", mock.Anything, mock.Anything).Return(tempBuyerID, nil)
-				r.On("ChangeOrderStatus", mock.Anything, mock.Anything).Return(nil)
+				r.On("ChangeOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("GetProductUnitSoldByOrderID", mock.Anything, mock.Anything, mock.Anything).Return(tempProductSold, nil)
 				r.On("UpdateProductUnitSold", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, mock.Anything, constant.OrderStatusCompleted).Return(nil)
 			},
 			expectedErr: nil,
 		},
@@ -723,7 +606,7 @@ func Test_userUC_ChangeOrderStatus(t *testing.T) {
 			name:        "Error userID != order buyer ID",
 			userID:      "123456",
 			requestBody: body.ChangeOrderStatusRequest{},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
 				r.On("GetBuyerIDByOrderID", mock.Anything, mock.Anything).Return("", errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
@@ -732,7 +615,7 @@ func Test_userUC_ChangeOrderStatus(t *testing.T) {
 			name:        "Error userID != order buyer ID",
 			userID:      "123456",
 			requestBody: body.ChangeOrderStatusRequest{},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
 				tempBuyerID := "111111"
 				r.On("GetBuyerIDByOrderID", mock.Anything, mock.Anything).Return(tempBuyerID, nil)
 			},
@@ -745,7 +628,7 @@ func Test_userUC_ChangeOrderStatus(t *testing.T) {
 				OrderID:       "123",
 				OrderStatusID: 1,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
 				tempBuyerID := "123456"
 				r.On("GetBuyerIDByOrderID", mock.Anything, mock.Anything).Return(tempBuyerID, nil)
 			},
@@ -758,10 +641,10 @@ func Test_userUC_ChangeOrderStatus(t *testing.T) {
 				OrderID:       "123",
 				OrderStatusID: 7,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
 				tempBuyerID := "123456"
 				r.On("GetBuyerIDByOrderID", mock.Anything, mock.Anything).Return(tempBuyerID, nil)
-				r.On("ChangeOrderStatus", mock.Anything, mock.Anything).Return(errors.New("test"))
+				r.On("ChangeOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
 		},
@@ -772,10 +655,10 @@ func Test_userUC_ChangeOrderStatus(t *testing.T) {
 				OrderID:       "123",
 				OrderStatusID: 7,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
 				tempBuyerID := "123456"
 				r.On("GetBuyerIDByOrderID", mock.Anything, mock.Anything).Return(tempBuyerID, nil)
-				r.On("ChangeOrderStatus", mock.Anything, mock.Anything).Return(nil)
+				r.On("ChangeOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("GetProductUnitSoldByOrderID", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
@@ -787,7 +670,7 @@ func Test_userUC_ChangeOrderStatus(t *testing.T) {
 				OrderID:       "123",
 				OrderStatusID: 7,
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
 				tempBuyerID := "123456"
 				tempProductSold := make([]*body.ProductUnitSoldOrderQty, 0)
 				tempBody := &body.ProductUnitSoldOrderQty{
@@ -795,21 +678,36 @@ func Test_userUC_ChangeOrderStatus(t *testing.T) {
 				}
 				tempProductSold = append(tempProductSold, tempBody)
 				r.On("GetBuyerIDByOrderID", mock.Anything, mock.Anything).Return(tempBuyerID, nil)
-				r.On("ChangeOrderStatus", mock.Anything, mock.Anything).Return(nil)
+				r.On("ChangeOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("GetProductUnitSoldByOrderID", mock.Anything, mock.Anything, mock.Anything).Return(tempProductSold, nil)
 				r.On("UpdateProductUnitSold", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
 		},
+		{
+			name:   "Error NotifyOrderStatus",
+			userID: "123456",
+			requestBody: body.ChangeOrderStatusRequest{
+				OrderID:       "123",
+				OrderStatusID: 6,
+			},
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
+				r.On("GetBuyerIDByOrderID", mock.Anything, mock.Anything).Return("123456", nil)
+				r.On("ChangeOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, "123", constant.OrderStatusReceived).Return(errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
 	}
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			n := notificationMocks.NewUseCase(t)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, nil, nil, nil, n, testKeys)
 
-			tc.mock(t, r)
+			tc.mock(t, r, n)
 			err := u.ChangeOrderStatus(context.Background(), tc.userID, tc.requestBody)
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
@@ -869,7 +767,7 @@ func Test_userUC_GetTransactionDetailByID(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.GetTransactionDetailByID(context.Background(), tc.transactionID, tc.userID)
@@ -941,7 +839,7 @@ func Test_userUC_GetAddressByID(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.GetAddressByID(context.Background(), tc.userID, tc.addressID)
@@ -1035,7 +933,7 @@ func Test_userUC_DeleteAddressByID(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			err := u.DeleteAddressByID(context.Background(), tc.userID, tc.addressID)
@@ -1048,13 +946,14 @@ func Test_userUC_DeleteAddressByID(t *testing.T) {
 
 func Test_userUC_CompletedRejectedRefund(t *testing.T) {
 	testCase := []struct {
-		name        string
-		mock        func(t *testing.T, r *mocks.Repository)
-		expectedErr error
+		name         string
+		mock         func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase)
+		expectedRows int64
+		expectedErr  error
 	}{
 		{
 			name: "success Completed Rejected Refund",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
 				tempBuyerID := uuid.Nil.String()
 				tempProductSold := make([]*body.ProductUnitSoldOrderQty, 0)
 				tempBody := &body.ProductUnitSoldOrderQty{
@@ -1067,15 +966,17 @@ func Test_userUC_CompletedRejectedRefund(t *testing.T) {
 				tempRefunds = append(tempRefunds, refund)
 				r.On("GetRejectedRefund", mock.Anything).Return(tempRefunds, nil)
 				r.On("GetBuyerIDByOrderID", mock.Anything, mock.Anything).Return(tempBuyerID, nil)
-				r.On("ChangeOrderStatus", mock.Anything, mock.Anything).Return(nil)
+				r.On("ChangeOrderStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("GetProductUnitSoldByOrderID", mock.Anything, mock.Anything, mock.Anything).Return(tempProductSold, nil)
 				r.On("UpdateProductUnitSold", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, mock.Anything, constant.OrderStatusCompleted).Return(nil)
 			},
-			expectedErr: nil,
+			expectedRows: 1,
+			expectedErr:  nil,
 		},
 		{
 			name: "Error Change Order Status ",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
 				tempRefunds := make([]*model.RefundOrder, 0)
 				refund := &model.RefundOrder{Order: &model.OrderModel{UserID: uuid.Nil, ID: uuid.Nil}}
 				tempRefunds = append(tempRefunds, refund)
@@ -1086,7 +987,7 @@ func Test_userUC_CompletedRejectedRefund(t *testing.T) {
 		},
 		{
 			name: "Error Repo GetRejectedRefund",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, n *notificationMocks.UseCase) {
 				r.On("GetRejectedRefund", mock.Anything).Return(nil, errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
@@ -1099,13 +1000,15 @@ func Test_userUC_CompletedRejectedRefund(t *testing.T) {
 			mock.ExpectBegin()
 
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			n := notificationMocks.NewUseCase(t)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, nil, nil, nil, n, testKeys)
 
-			tc.mock(t, r)
-			err := u.CompletedRejectedRefund(context.Background())
+			tc.mock(t, r, n)
+			report, err := u.CompletedRejectedRefund(context.Background())
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
 			}
+			assert.Equal(t, tc.expectedRows, report.RowsAffected)
 		})
 	}
 }
@@ -1245,7 +1148,7 @@ func Test_userUC_EditUser(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.EditUser(context.Background(), tc.userID, tc.requestBody)
@@ -1261,7 +1164,7 @@ func Test_userUC_EditEmail(t *testing.T) {
 		name        string
 		userID      string
 		requestBody body.EditEmailRequest
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase)
 		expectedErr error
 	}{
 		{
@@ -1270,10 +1173,13 @@ func Test_userUC_EditEmail(t *testing.T) {
 			requestBody: body.EditEmailRequest{
 				Email: "email@gmail.com",
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{}, nil)
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
 				r.On("InsertNewOTPKey", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				o.On("Enqueue", mock.Anything, mock.Anything, mock.MatchedBy(func(msg *mail.Message) bool {
+					return msg.Template == mail.TemplateChangeEmail
+				})).Return(nil)
 			},
 			expectedErr: nil,
 		},
@@ -1283,7 +1189,7 @@ func Test_userUC_EditEmail(t *testing.T) {
 			requestBody: body.EditEmailRequest{
 				Email: "email@gmail.com",
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{}, nil)
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
 				r.On("InsertNewOTPKey", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
@@ -1296,7 +1202,7 @@ func Test_userUC_EditEmail(t *testing.T) {
 			requestBody: body.EditEmailRequest{
 				Email: "email@gmail.com",
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email@gmail.com"}, nil)
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(&model.EmailHistory{Email: "email@gmail.com"}, nil)
 			},
@@ -1308,7 +1214,7 @@ func Test_userUC_EditEmail(t *testing.T) {
 			requestBody: body.EditEmailRequest{
 				Email: "email@gmail.com",
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email1@gmail.com"}, nil)
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(&model.EmailHistory{Email: "email@gmail.com"}, nil)
 			},
@@ -1320,7 +1226,7 @@ func Test_userUC_EditEmail(t *testing.T) {
 			requestBody: body.EditEmailRequest{
 				Email: "email@gmail.com",
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email1@gmail.com"}, nil)
 				r.On("CheckEmailHistory", mock.Anything, mock.Anything).Return(nil, errors.New("test"))
 			},
@@ -1332,7 +1238,7 @@ func Test_userUC_EditEmail(t *testing.T) {
 			requestBody: body.EditEmailRequest{
 				Email: "email@gmail.com",
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
 			},
 			expectedErr: errors.New(response.UnauthorizedMessage),
@@ -1342,9 +1248,10 @@ func Test_userUC_EditEmail(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			o := outboxMocks.NewUseCase(t)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, o, nil, testKeys)
 
-			tc.mock(t, r)
+			tc.mock(t, r, o)
 			_, err := u.EditEmail(context.Background(), tc.userID, tc.requestBody)
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
@@ -1358,7 +1265,7 @@ func Test_userUC_EditEmailUser(t *testing.T) {
 		name        string
 		userID      string
 		requestBody body.EditEmailUserRequest
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase)
 		expectedErr error
 	}{
 		{
@@ -1368,12 +1275,13 @@ func Test_userUC_EditEmailUser(t *testing.T) {
 				Email: "email@gmail.com",
 				Code:  "5694d08a2e53ffcae0c3103e5ad6f6076abd960eb1f8a56577040bc1028f702b",
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				tempnt64 := int64(1)
 				r.On("GetOTPValue", mock.Anything, mock.Anything).Return("code", nil)
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{}, nil)
 				r.On("UpdateUserEmail", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("CreateEmailHistory", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				a.On("Record", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("DeleteOTPValue", mock.Anything, mock.Anything).Return(tempnt64, nil)
 			},
 			expectedErr: nil,
@@ -1385,12 +1293,13 @@ func Test_userUC_EditEmailUser(t *testing.T) {
 				Email: "email@gmail.com",
 				Code:  "5694d08a2e53ffcae0c3103e5ad6f6076abd960eb1f8a56577040bc1028f702b",
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				tempnt64 := int64(1)
 				r.On("GetOTPValue", mock.Anything, mock.Anything).Return("code", nil)
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{}, nil)
 				r.On("UpdateUserEmail", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("CreateEmailHistory", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				a.On("Record", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("DeleteOTPValue", mock.Anything, mock.Anything).Return(tempnt64, errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
@@ -1402,7 +1311,7 @@ func Test_userUC_EditEmailUser(t *testing.T) {
 				Email: "email@gmail.com",
 				Code:  "5694d08a2e53ffcae0c3103e5ad6f6076abd960eb1f8a56577040bc1028f702b",
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("GetOTPValue", mock.Anything, mock.Anything).Return("code", nil)
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{}, nil)
 				r.On("UpdateUserEmail", mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -1417,7 +1326,7 @@ func Test_userUC_EditEmailUser(t *testing.T) {
 				Email: "email@gmail.com",
 				Code:  "5694d08a2e53ffcae0c3103e5ad6f6076abd960eb1f8a56577040bc1028f702b",
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("GetOTPValue", mock.Anything, mock.Anything).Return("code", nil)
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{}, nil)
 				r.On("UpdateUserEmail", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
@@ -1431,7 +1340,7 @@ func Test_userUC_EditEmailUser(t *testing.T) {
 				Email: "email@gmail.com",
 				Code:  "5694d08a2e53ffcae0c3103e5ad6f6076abd960eb1f8a56577040bc1028f702b",
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("GetOTPValue", mock.Anything, mock.Anything).Return("code", nil)
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
 			},
@@ -1444,7 +1353,7 @@ func Test_userUC_EditEmailUser(t *testing.T) {
 				Email: "email@gmail.com",
 				Code:  "5694d08a2e53ffcae0c3103e5ad6f6076abd960eb1f8a56577040bc1028f702b",
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("GetOTPValue", mock.Anything, mock.Anything).Return("code1", nil)
 			},
 			expectedErr: errors.New(response.OTPIsNotValidMessage),
@@ -1456,7 +1365,7 @@ func Test_userUC_EditEmailUser(t *testing.T) {
 				Email: "email@gmail.com",
 				Code:  "5694d08a2e53ffcae0c3103e5ad6f6076abd960eb1f8a56577040bc1028f702b",
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("GetOTPValue", mock.Anything, mock.Anything).Return("", errors.New("test"))
 			},
 			expectedErr: errors.New(response.OTPAlreadyExpiredMessage),
@@ -1469,10 +1378,11 @@ func Test_userUC_EditEmailUser(t *testing.T) {
 			mock.ExpectBegin()
 
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			a := auditMocks.NewUseCase(t)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, nil, a, nil, nil, testKeys)
 
-			tc.mock(t, r)
-			_, err := u.EditEmailUser(context.Background(), tc.userID, tc.requestBody)
+			tc.mock(t, r, a)
+			_, err := u.EditEmailUser(context.Background(), tc.userID, tc.requestBody, session.Client{})
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
 			}
@@ -1508,7 +1418,7 @@ func Test_userUC_GetSealabsPay(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.GetSealabsPay(context.Background(), tc.userID)
@@ -1524,17 +1434,18 @@ func Test_userUC_AddSealabsPay(t *testing.T) {
 		name        string
 		request     body.AddSealabsPayRequest
 		userid      string
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase)
 		expectedErr error
 	}{
 		{
 			name:    "success Edit User",
 			request: body.AddSealabsPayRequest{},
 			userid:  "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("CheckUserSealabsPay", mock.Anything, mock.Anything).Return(0, nil)
 				r.On("CheckDeletedSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
-				r.On("AddSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("AddSealabsPayTrans", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				a.On("Record", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			},
 			expectedErr: nil,
 		},
@@ -1542,21 +1453,34 @@ func Test_userUC_AddSealabsPay(t *testing.T) {
 			name:    "Error slp found",
 			request: body.AddSealabsPayRequest{CardNumber: "123456"},
 			userid:  "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				tempCardNumber := "222222"
 				r.On("CheckUserSealabsPay", mock.Anything, mock.Anything).Return(1, nil)
 				r.On("CheckDeletedSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
 				r.On("CheckDefaultSealabsPay", mock.Anything, mock.Anything).Return(&tempCardNumber, nil)
 				r.On("SetDefaultSealabsPayTrans", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("AddSealabsPayTrans", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				a.On("Record", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			},
 			expectedErr: nil,
 		},
+		{
+			name:    "Error audit Record",
+			request: body.AddSealabsPayRequest{CardNumber: "123456"},
+			userid:  "123456",
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
+				r.On("CheckUserSealabsPay", mock.Anything, mock.Anything).Return(0, nil)
+				r.On("CheckDeletedSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
+				r.On("AddSealabsPayTrans", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				a.On("Record", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
 		{
 			name:    "Error slp found and failed to save",
 			request: body.AddSealabsPayRequest{CardNumber: "123456"},
 			userid:  "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				tempCardNumber := "222222"
 				r.On("CheckUserSealabsPay", mock.Anything, mock.Anything).Return(1, nil)
 				r.On("CheckDeletedSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
@@ -1570,7 +1494,7 @@ func Test_userUC_AddSealabsPay(t *testing.T) {
 			name:    "Error slp found and failed to update",
 			request: body.AddSealabsPayRequest{CardNumber: "123456"},
 			userid:  "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				tempCardNumber := "222222"
 				r.On("CheckUserSealabsPay", mock.Anything, mock.Anything).Return(1, nil)
 				r.On("CheckDeletedSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(1, nil)
@@ -1584,7 +1508,7 @@ func Test_userUC_AddSealabsPay(t *testing.T) {
 			name:    "Error repo SetDefaultSealabsPayTrans",
 			request: body.AddSealabsPayRequest{CardNumber: "123456"},
 			userid:  "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				tempCardNumber := "222222"
 				r.On("CheckUserSealabsPay", mock.Anything, mock.Anything).Return(1, nil)
 				r.On("CheckDeletedSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(1, nil)
@@ -1597,7 +1521,7 @@ func Test_userUC_AddSealabsPay(t *testing.T) {
 			name:    "Error Card Number ",
 			request: body.AddSealabsPayRequest{CardNumber: "123456"},
 			userid:  "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				tempCardNumber := "123456"
 				r.On("CheckUserSealabsPay", mock.Anything, mock.Anything).Return(1, nil)
 				r.On("CheckDeletedSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(1, nil)
@@ -1609,7 +1533,7 @@ func Test_userUC_AddSealabsPay(t *testing.T) {
 			name:    "Error repo CheckDefaultSealabsPay",
 			request: body.AddSealabsPayRequest{CardNumber: "123456"},
 			userid:  "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("CheckUserSealabsPay", mock.Anything, mock.Anything).Return(1, nil)
 				r.On("CheckDeletedSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(1, nil)
 				r.On("CheckDefaultSealabsPay", mock.Anything, mock.Anything).Return(nil, errors.New("test"))
@@ -1620,10 +1544,10 @@ func Test_userUC_AddSealabsPay(t *testing.T) {
 			name:    "Error repo AddSealabsPay ",
 			request: body.AddSealabsPayRequest{CardNumber: "123456"},
 			userid:  "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("CheckUserSealabsPay", mock.Anything, mock.Anything).Return(0, nil)
 				r.On("CheckDeletedSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
-				r.On("AddSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+				r.On("AddSealabsPayTrans", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
 			},
 			expectedErr: errors.New(response.SealabsCardAlreadyExist),
 		},
@@ -1631,10 +1555,10 @@ func Test_userUC_AddSealabsPay(t *testing.T) {
 			name:    "Error repo UpdateUserSealabsPay ",
 			request: body.AddSealabsPayRequest{CardNumber: "123456"},
 			userid:  "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("CheckUserSealabsPay", mock.Anything, mock.Anything).Return(0, nil)
 				r.On("CheckDeletedSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(1, nil)
-				r.On("UpdateUserSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+				r.On("UpdateUserSealabsPayTrans", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
 			},
 			expectedErr: errors.New(response.SealabsCardAlreadyExist),
 		},
@@ -1642,7 +1566,7 @@ func Test_userUC_AddSealabsPay(t *testing.T) {
 			name:    "Error repo CheckDeletedSealabsPay ",
 			request: body.AddSealabsPayRequest{CardNumber: "123456"},
 			userid:  "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("CheckUserSealabsPay", mock.Anything, mock.Anything).Return(0, nil)
 				r.On("CheckDeletedSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(-1, errors.New("test"))
 			},
@@ -1653,7 +1577,7 @@ func Test_userUC_AddSealabsPay(t *testing.T) {
 			name:    "Error repo CheckUserSealabsPay ",
 			request: body.AddSealabsPayRequest{CardNumber: "123456"},
 			userid:  "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("CheckUserSealabsPay", mock.Anything, mock.Anything).Return(-1, errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
@@ -1666,10 +1590,11 @@ func Test_userUC_AddSealabsPay(t *testing.T) {
 			mock.ExpectBegin()
 
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			a := auditMocks.NewUseCase(t)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, nil, a, nil, nil, testKeys)
 
-			tc.mock(t, r)
-			err := u.AddSealabsPay(context.Background(), tc.request, tc.name)
+			tc.mock(t, r, a)
+			err := u.AddSealabsPay(context.Background(), tc.request, tc.name, session.Client{})
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
 			}
@@ -1719,7 +1644,7 @@ func Test_userUC_PatchSealabsPay(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			err := u.PatchSealabsPay(context.Background(), tc.cardNumber, tc.userid)
@@ -1735,16 +1660,17 @@ func Test_userUC_DeleteSealabsPay(t *testing.T) {
 		name        string
 		userID      string
 		cardNumber  string
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase)
 		expectedErr error
 	}{
 		{
 			name:       "success Delete Sealabs Pay",
 			userID:     "123456",
 			cardNumber: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("GetSealabsPayUser", mock.Anything, mock.Anything, mock.Anything).Return(&model.SealabsPay{}, nil)
-				r.On("DeleteSealabsPay", mock.Anything, mock.Anything).Return(nil)
+				r.On("DeleteSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				a.On("Record", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			},
 			expectedErr: nil,
 		},
@@ -1752,9 +1678,9 @@ func Test_userUC_DeleteSealabsPay(t *testing.T) {
 			name:       "error repo DeleteSealabsPay",
 			userID:     "123456",
 			cardNumber: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("GetSealabsPayUser", mock.Anything, mock.Anything, mock.Anything).Return(&model.SealabsPay{}, nil)
-				r.On("DeleteSealabsPay", mock.Anything, mock.Anything).Return(errors.New("test"))
+				r.On("DeleteSealabsPay", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
 		},
@@ -1762,7 +1688,7 @@ func Test_userUC_DeleteSealabsPay(t *testing.T) {
 			name:       "error slp is defult true",
 			userID:     "123456",
 			cardNumber: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("GetSealabsPayUser", mock.Anything, mock.Anything, mock.Anything).Return(&model.SealabsPay{IsDefault: true}, nil)
 			},
 			expectedErr: errors.New(response.SealabsCardIsDefault),
@@ -1771,7 +1697,7 @@ func Test_userUC_DeleteSealabsPay(t *testing.T) {
 			name:       "error repo GetSealabsPayUser",
 			userID:     "123456",
 			cardNumber: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("GetSealabsPayUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
@@ -1780,7 +1706,7 @@ func Test_userUC_DeleteSealabsPay(t *testing.T) {
 			name:       "error repo GetSealabsPayUser no sql row",
 			userID:     "123456",
 			cardNumber: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("GetSealabsPayUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
 			},
 			expectedErr: errors.New(response.SealabsCardNotFound),
@@ -1789,11 +1715,16 @@ func Test_userUC_DeleteSealabsPay(t *testing.T) {
 
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
+			sql, mock, _ := sqlmock.New()
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			a := auditMocks.NewUseCase(t)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, nil, a, nil, nil, testKeys)
 
-			tc.mock(t, r)
-			err := u.DeleteSealabsPay(context.Background(), tc.cardNumber, tc.userID)
+			tc.mock(t, r, a)
+			err := u.DeleteSealabsPay(context.Background(), tc.cardNumber, tc.userID, session.Client{})
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
 			}
@@ -1865,7 +1796,7 @@ func Test_userUC_ActivateWallet(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			err := u.ActivateWallet(context.Background(), tc.userID, tc.pin)
@@ -1995,7 +1926,7 @@ func Test_userUC_RegisterMerchant(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			err := u.RegisterMerchant(context.Background(), tc.userID, tc.shopName)
@@ -2042,7 +1973,7 @@ func Test_userUC_GetUserProfile(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.GetUserProfile(context.Background(), tc.userID)
@@ -2084,7 +2015,7 @@ func Test_userUC_UploadProfilePicture(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			err := u.UploadProfilePicture(context.Background(), tc.imgURL, tc.name)
@@ -2099,22 +2030,25 @@ func Test_userUC_VerifyPasswordChange(t *testing.T) {
 	testCase := []struct {
 		name        string
 		userID      string
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase)
 		expectedErr error
 	}{
 		{
 			name:   "success Verify Password Change",
 			userID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email@gmail.com"}, nil)
 				r.On("InsertNewOTPKey", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				o.On("Enqueue", mock.Anything, mock.Anything, mock.MatchedBy(func(msg *mail.Message) bool {
+					return msg.Template == mail.TemplateVerificationOTP
+				})).Return(nil)
 			},
 			expectedErr: nil,
 		},
 		{
 			name:   "error repo InsertNewOTPKey",
 			userID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email@gmail.com"}, nil)
 				r.On("InsertNewOTPKey", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
 			},
@@ -2123,7 +2057,7 @@ func Test_userUC_VerifyPasswordChange(t *testing.T) {
 		{
 			name:   "error repo GetUserByID",
 			userID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, o *outboxMocks.UseCase) {
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(nil, errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
@@ -2133,9 +2067,10 @@ func Test_userUC_VerifyPasswordChange(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			o := outboxMocks.NewUseCase(t)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, o, nil, testKeys)
 
-			tc.mock(t, r)
+			tc.mock(t, r, o)
 			err := u.VerifyPasswordChange(context.Background(), tc.userID)
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
@@ -2149,7 +2084,7 @@ func Test_userUC_VerifyOTP(t *testing.T) {
 		name        string
 		requestBody body.VerifyOTPRequest
 		userID      string
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase)
 		expectedErr error
 	}{
 		{
@@ -2158,9 +2093,12 @@ func Test_userUC_VerifyOTP(t *testing.T) {
 				OTP: "123456",
 			},
 			userID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", mock.Anything, changePasswordOTPPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).Return(nil)
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email@gmail.com"}, nil)
 				r.On("GetOTPValue", mock.Anything, mock.Anything).Return("123456", nil)
+				f.On("VerifyStepUp", mock.Anything, "123456", "").Return(nil)
+				a.On("Reset", mock.Anything, changePasswordOTPPolicy, attempt.Account("123456")).Return(nil)
 				r.On("DeleteOTPValue", mock.Anything, mock.Anything).Return(int64(1), nil)
 			},
 			expectedErr: nil,
@@ -2171,9 +2109,12 @@ func Test_userUC_VerifyOTP(t *testing.T) {
 				OTP: "123456",
 			},
 			userID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", mock.Anything, changePasswordOTPPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).Return(nil)
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email@gmail.com"}, nil)
 				r.On("GetOTPValue", mock.Anything, mock.Anything).Return("123456", nil)
+				f.On("VerifyStepUp", mock.Anything, "123456", "").Return(nil)
+				a.On("Reset", mock.Anything, changePasswordOTPPolicy, attempt.Account("123456")).Return(nil)
 				r.On("DeleteOTPValue", mock.Anything, mock.Anything).Return(int64(0), errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
@@ -2184,9 +2125,11 @@ func Test_userUC_VerifyOTP(t *testing.T) {
 				OTP: "123456",
 			},
 			userID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", mock.Anything, changePasswordOTPPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).Return(nil)
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email@gmail.com"}, nil)
 				r.On("GetOTPValue", mock.Anything, mock.Anything).Return("123451", nil)
+				a.On("Fail", mock.Anything, changePasswordOTPPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).Return(nil)
 			},
 			expectedErr: errors.New(response.OTPIsNotValidMessage),
 		},
@@ -2196,9 +2139,11 @@ func Test_userUC_VerifyOTP(t *testing.T) {
 				OTP: "123456",
 			},
 			userID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", mock.Anything, changePasswordOTPPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).Return(nil)
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email@gmail.com"}, nil)
 				r.On("GetOTPValue", mock.Anything, mock.Anything).Return("", errors.New("test"))
+				a.On("Fail", mock.Anything, changePasswordOTPPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).Return(nil)
 			},
 			expectedErr: errors.New(response.OTPAlreadyExpiredMessage),
 		},
@@ -2208,20 +2153,51 @@ func Test_userUC_VerifyOTP(t *testing.T) {
 				OTP: "123456",
 			},
 			userID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", mock.Anything, changePasswordOTPPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).Return(nil)
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(nil, errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
 		},
+		{
+			name: "error two-factor code required",
+			requestBody: body.VerifyOTPRequest{
+				OTP: "123456",
+			},
+			userID: "123456",
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", mock.Anything, changePasswordOTPPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).Return(nil)
+				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email@gmail.com"}, nil)
+				r.On("GetOTPValue", mock.Anything, mock.Anything).Return("123456", nil)
+				f.On("VerifyStepUp", mock.Anything, "123456", "").
+					Return(httperror.New(http.StatusBadRequest, response.TwoFactorCodeRequired))
+				a.On("Fail", mock.Anything, changePasswordOTPPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).Return(nil)
+			},
+			expectedErr: errors.New(response.TwoFactorCodeRequired),
+		},
+		{
+			name: "error locked out",
+			requestBody: body.VerifyOTPRequest{
+				OTP: "123456",
+			},
+			userID: "123456",
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				a.On("Check", mock.Anything, changePasswordOTPPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).
+					Return(&attempt.LockedError{RetryAfter: time.Minute})
+			},
+			expectedErr: &attempt.LockedError{RetryAfter: time.Minute},
+		},
 	}
 
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			a := attemptMocks.NewLimiter(t)
+			f := twoFactorMocks.NewUseCase(t)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, a, f, nil, nil, nil, nil, testKeys)
 
-			tc.mock(t, r)
-			_, err := u.VerifyOTP(context.Background(), tc.requestBody, tc.userID)
+			tc.mock(t, r, a, f)
+			_, err := u.VerifyOTP(context.Background(), tc.requestBody, tc.userID, "10.0.0.1")
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
 			}
@@ -2235,47 +2211,47 @@ func Test_userUC_ChangePassword(t *testing.T) {
 		name        string
 		userID      string
 		newPassword string
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *auditMocks.UseCase)
 		expectedErr error
 	}{
 		{
 			name:        "success ChangePassword",
 			userID:      "123456",
 			newPassword: "Tested7*",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *auditMocks.UseCase) {
 				tempUsername := "juww"
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email@gmail.com", Username: &tempUsername}, nil)
 				r.On("GetPasswordByID", mock.Anything, mock.Anything).Return(passwordHash, nil)
-				r.On("UpdatePasswordByID", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-				r.On("GetSessionKeyRedis", mock.Anything, mock.Anything, mock.Anything).Return([]string{"asd"}, nil)
-				r.On("InsertSessionRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("UpdatePasswordByID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				a.On("Record", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				s.On("RevokeAll", mock.Anything, "123456").Return(nil)
 			},
 			expectedErr: nil,
 		},
 		{
-			name:        "error repo InsertSessionRedis",
+			name:        "error revoke sessions",
 			userID:      "123456",
 			newPassword: "Tested7*",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *auditMocks.UseCase) {
 				tempUsername := "juww"
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email@gmail.com", Username: &tempUsername}, nil)
 				r.On("GetPasswordByID", mock.Anything, mock.Anything).Return(passwordHash, nil)
-				r.On("UpdatePasswordByID", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-				r.On("GetSessionKeyRedis", mock.Anything, mock.Anything, mock.Anything).Return([]string{"asd"}, nil)
-				r.On("InsertSessionRedis", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+				r.On("UpdatePasswordByID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				a.On("Record", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				s.On("RevokeAll", mock.Anything, "123456").Return(errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
 		},
 		{
-			name:        "error repo GetSessionKeyRedis",
+			name:        "error audit Record",
 			userID:      "123456",
 			newPassword: "Tested7*",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *auditMocks.UseCase) {
 				tempUsername := "juww"
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email@gmail.com", Username: &tempUsername}, nil)
 				r.On("GetPasswordByID", mock.Anything, mock.Anything).Return(passwordHash, nil)
-				r.On("UpdatePasswordByID", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-				r.On("GetSessionKeyRedis", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("test"))
+				r.On("UpdatePasswordByID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				a.On("Record", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
 		},
@@ -2283,11 +2259,11 @@ func Test_userUC_ChangePassword(t *testing.T) {
 			name:        "error repo UpdatePasswordByID",
 			userID:      "123456",
 			newPassword: "Tested7*",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *auditMocks.UseCase) {
 				tempUsername := "juww"
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email@gmail.com", Username: &tempUsername}, nil)
 				r.On("GetPasswordByID", mock.Anything, mock.Anything).Return(passwordHash, nil)
-				r.On("UpdatePasswordByID", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
+				r.On("UpdatePasswordByID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
 		},
@@ -2295,7 +2271,7 @@ func Test_userUC_ChangePassword(t *testing.T) {
 			name:        "error password contain",
 			userID:      "123456",
 			newPassword: "Tested7juww",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *auditMocks.UseCase) {
 				tempUsername := "juww"
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email@gmail.com", Username: &tempUsername}, nil)
 				r.On("GetPasswordByID", mock.Anything, mock.Anything).Return(passwordHash, nil)
@@ -2306,7 +2282,7 @@ func Test_userUC_ChangePassword(t *testing.T) {
 			name:        "error password same old password",
 			userID:      "123456",
 			newPassword: "Tested8*",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *auditMocks.UseCase) {
 				tempUsername := "juww"
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email@gmail.com", Username: &tempUsername}, nil)
 				r.On("GetPasswordByID", mock.Anything, mock.Anything).Return(passwordHash, nil)
@@ -2317,7 +2293,7 @@ func Test_userUC_ChangePassword(t *testing.T) {
 			name:        "error repo GetPasswordByID",
 			userID:      "123456",
 			newPassword: "Tested8*",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *auditMocks.UseCase) {
 				tempUsername := "juww"
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{Email: "email@gmail.com", Username: &tempUsername}, nil)
 				r.On("GetPasswordByID", mock.Anything, mock.Anything).Return("", errors.New("test"))
@@ -2328,7 +2304,7 @@ func Test_userUC_ChangePassword(t *testing.T) {
 			name:        "error repo GetUserByID",
 			userID:      "123456",
 			newPassword: "Tested8*",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, s *sessionMocks.Store, a *auditMocks.UseCase) {
 				r.On("GetUserByID", mock.Anything, mock.Anything).Return(nil, errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
@@ -2337,11 +2313,17 @@ func Test_userUC_ChangePassword(t *testing.T) {
 
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
+			sql, mock, _ := sqlmock.New()
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			s := sessionMocks.NewStore(t)
+			a := auditMocks.NewUseCase(t)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, s, nil, nil, nil, a, nil, nil, testKeys)
 
-			tc.mock(t, r)
-			err := u.ChangePassword(context.Background(), tc.userID, tc.newPassword)
+			tc.mock(t, r, s, a)
+			err := u.ChangePassword(context.Background(), tc.userID, tc.newPassword, session.Client{})
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
 			}
@@ -2349,6 +2331,70 @@ func Test_userUC_ChangePassword(t *testing.T) {
 	}
 }
 
+func Test_userUC_GetSessions(t *testing.T) {
+	now := time.Now()
+	s := sessionMocks.NewStore(t)
+	s.On("List", mock.Anything, "123456").Return([]*session.Session{
+		{ID: "old", LastSeenAt: now.Add(-time.Hour)},
+		{ID: "current", LastSeenAt: now},
+	}, nil)
+
+	u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, mocks.NewRepository(t), nil, s, nil, nil, nil, nil, nil, nil, testKeys)
+	sessions, err := u.GetSessions(context.Background(), "123456", "current")
+
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, "current", sessions[0].ID)
+	assert.True(t, sessions[0].IsCurrent)
+	assert.False(t, sessions[1].IsCurrent)
+}
+
+func Test_userUC_RevokeSession(t *testing.T) {
+	testCase := []struct {
+		name        string
+		mock        func(t *testing.T, s *sessionMocks.Store)
+		expectedErr error
+	}{
+		{
+			name: "success RevokeSession",
+			mock: func(t *testing.T, s *sessionMocks.Store) {
+				s.On("Revoke", mock.Anything, "123456", "session").Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error session not found",
+			mock: func(t *testing.T, s *sessionMocks.Store) {
+				s.On("Revoke", mock.Anything, "123456", "session").Return(session.ErrNotFound)
+			},
+			expectedErr: errors.New(response.SessionNotFound),
+		},
+		{
+			name: "error revoke session",
+			mock: func(t *testing.T, s *sessionMocks.Store) {
+				s.On("Revoke", mock.Anything, "123456", "session").Return(errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			s := sessionMocks.NewStore(t)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, mocks.NewRepository(t), nil, s, nil, nil, nil, nil, nil, nil, testKeys)
+
+			tc.mock(t, s)
+			err := u.RevokeSession(context.Background(), "123456", "session")
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Equal(t, err.Error(), tc.expectedErr.Error())
+		})
+	}
+}
+
 func Test_userUC_TopUpWallet(t *testing.T) {
 	testCase := []struct {
 		name        string
@@ -2438,7 +2484,7 @@ func Test_userUC_TopUpWallet(t *testing.T) {
 			mock.ExpectBegin()
 
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.TopUpWallet(context.Background(), tc.userID, tc.requestBody)
@@ -2449,41 +2495,101 @@ func Test_userUC_TopUpWallet(t *testing.T) {
 	}
 }
 
+// fakeSLPServer stands in for SeaLabs Pay: it accepts signed payment requests
+// and produces signed callbacks for the last payment it received.
+type fakeSLPServer struct {
+	server *httptest.Server
+	cfg    *config.Config
+	txnID  string
+	amount string
+	nonce  int
+}
+
+func newFakeSLPServer(t *testing.T) *fakeSLPServer {
+	f := &fakeSLPServer{cfg: &config.Config{}}
+	f.cfg.External.SlpMerchantCode = "merchant"
+	f.cfg.External.SlpAPIKey = "secret"
+
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/v1/transaction/pay" || req.ParseForm() != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		signFormat := fmt.Sprintf("%s:%s:%s", req.FormValue("card_number"), req.FormValue("amount"), req.FormValue("merchant_code"))
+		h := hmac.New(sha256.New, []byte(f.cfg.External.SlpAPIKey))
+		h.Write([]byte(signFormat))
+		if !hmac.Equal([]byte(hex.EncodeToString(h.Sum(nil))), []byte(req.FormValue("signature"))) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(body.SLPPaymentResponse{Message: "invalid signature"})
+			return
+		}
+
+		callbackURL := req.FormValue("callback_url")
+		f.txnID = callbackURL[strings.LastIndex(callbackURL, "/")+1:]
+		f.amount = req.FormValue("amount")
+		w.Header().Set("Location", f.server.URL+"/pay/"+f.txnID)
+		w.WriteHeader(http.StatusSeeOther)
+	}))
+	f.cfg.External.SlpURL = f.server.URL
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+func (f *fakeSLPServer) callback(status, message string, signedAt time.Time) body.SLPCallbackRequest {
+	f.nonce++
+	callback := body.SLPCallbackRequest{
+		TxnID:        f.txnID,
+		Amount:       f.amount,
+		MerchantCode: f.cfg.External.SlpMerchantCode,
+		Status:       status,
+		Message:      message,
+		Timestamp:    signedAt.Unix(),
+		Nonce:        fmt.Sprintf("nonce-%d", f.nonce),
+	}
+	callback.Signature = callback.Sign(f.cfg.External.SlpMerchantCode, f.cfg.External.SlpAPIKey)
+
+	return callback
+}
+
 func Test_userUC_CreateSLPPayment(t *testing.T) {
+	cardNumber := "123456"
 	testCase := []struct {
 		name          string
 		transactionID string
-		mock          func(t *testing.T, r *mocks.Repository)
+		transaction   *model.Transaction
+		apiKey        string
 		expectedErr   error
 	}{
-		// {
-		// 	name:          "success CreateSLPPayment",
-		// 	transactionID: "123456",
-		// 	mock: func(t *testing.T, r *mocks.Repository) {
-		// 		tempCardNumber := "123456"
-		// 		r.On("GetTransactionByID", mock.Anything, mock.Anything).Return(&model.Transaction{
-		// 			ExpiredAt: sql.NullTime{
-		// 				Valid: true,
-		//				Time:  time.Now().Add(time.Hour),
-		// 			},
-		// 			CardNumber: &tempCardNumber,
-		// 		}, nil)
-		// 		r.On("GetSealabsPayUser", mock.Anything, mock.Anything, mock.Anything).Return(&model.SealabsPay{CardNumber: "123456"}, nil)
-		// 		r.On("CreateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(&uuid.Nil, nil)
-		// 	},
-		// 	expectedErr: nil,
-		// },
+		{
+			name:          "success CreateSLPPayment",
+			transactionID: "123456",
+			transaction: &model.Transaction{
+				ID:         uuid.New(),
+				ExpiredAt:  sql.NullTime{Valid: true, Time: time.Now().Add(time.Hour)},
+				CardNumber: &cardNumber,
+				TotalPrice: model.NewMoney(100),
+			},
+			expectedErr: nil,
+		},
+		{
+			name:          "error SLP rejects signature",
+			transactionID: "123456",
+			transaction: &model.Transaction{
+				ID:         uuid.New(),
+				ExpiredAt:  sql.NullTime{Valid: true, Time: time.Now().Add(time.Hour)},
+				CardNumber: &cardNumber,
+				TotalPrice: model.NewMoney(100),
+			},
+			apiKey:      "wrong",
+			expectedErr: errors.New("invalid signature"),
+		},
 		{
 			name:          "error Card number nil",
 			transactionID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
-				r.On("GetTransactionByID", mock.Anything, mock.Anything).Return(&model.Transaction{
-					ExpiredAt: sql.NullTime{
-						Valid: true,
-						Time:  time.Now().Add(time.Hour),
-					},
-					CardNumber: nil,
-				}, nil)
+			transaction: &model.Transaction{
+				ExpiredAt: sql.NullTime{Valid: true, Time: time.Now().Add(time.Hour)},
 			},
 			expectedErr: errors.New(response.InvalidPaymentMethod),
 		},
@@ -2494,14 +2600,26 @@ func Test_userUC_CreateSLPPayment(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
 
+			slp := newFakeSLPServer(t)
+			cfg := *slp.cfg
+			if tc.apiKey != "" {
+				cfg.External.SlpAPIKey = tc.apiKey
+			}
+
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewUserUseCase(&cfg, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
-			tc.mock(t, r)
-			_, err := u.CreateSLPPayment(context.Background(), tc.transactionID)
-			if err != nil {
+			r.On("GetTransactionByID", context.Background(), tc.transactionID).Return(tc.transaction, nil)
+			redirectURL, err := u.CreateSLPPayment(context.Background(), tc.transactionID)
+			if tc.expectedErr != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+				return
 			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, slp.server.URL+"/pay/"+tc.transaction.ID.String(), redirectURL)
+			assert.Equal(t, tc.transaction.ID.String(), slp.txnID)
+			assert.Equal(t, "100", slp.amount)
 		})
 	}
 }
@@ -2510,13 +2628,13 @@ func Test_userUC_CreateWalletPayment(t *testing.T) {
 	testCase := []struct {
 		name          string
 		transactionID string
-		mock          func(t *testing.T, r *mocks.Repository)
+		mock          func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, n *notificationMocks.UseCase)
 		expectedErr   error
 	}{
 		{
 			name:          "success CreateSLPPayment",
 			transactionID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, n *notificationMocks.UseCase) {
 				tempCardNumber := "123456"
 				r.On("GetTransactionByID", mock.Anything, mock.Anything).Return(&model.Transaction{
 					ID: uuid.Nil,
@@ -2525,28 +2643,54 @@ func Test_userUC_CreateWalletPayment(t *testing.T) {
 						Time:  time.Now().Add(time.Hour),
 					},
 					CardNumber: &tempCardNumber,
-					TotalPrice: 100,
+					TotalPrice: model.NewMoney(100),
 					WalletID:   &uuid.Nil,
 				}, nil)
-				r.On("GetWalletUser", mock.Anything, mock.Anything).Return(&model.Wallet{Balance: 1000}, nil)
+				r.On("GetWalletUser", mock.Anything, mock.Anything).Return(&model.Wallet{Balance: model.NewMoney(1000)}, nil)
 				r.On("GetOrderByTransactionID", mock.Anything, mock.Anything).Return([]*model.OrderModel{{OrderStatusID: 1}}, nil)
 				r.On("UpdateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("UpdateOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, mock.Anything, constant.OrderStatusWaitingForSeller).Return(nil)
 				r.On("InsertWalletHistory", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-				r.On("UpdateWalletBalance", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything).Return(&model.Wallet{
 					ID: uuid.Nil,
 				}, nil)
-				r.On("InsertWalletHistory", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-				r.On("UpdateWalletBalance", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-
+				l.On("PostEntry", mock.Anything, mock.Anything, mock.MatchedBy(func(entry *model.JournalEntry) bool {
+					return entry.Lines[0].Account.AccountType == constant.LedgerAccountBuyerWallet &&
+						entry.Lines[1].Account.AccountType == constant.LedgerAccountEscrow &&
+						entry.Lines[1].Credit == model.NewMoney(100)
+				})).Return(nil)
 			},
 			expectedErr: nil,
 		},
+		{
+			name:          "error CreateWalletPayment balance taken by another payment",
+			transactionID: "123456",
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, n *notificationMocks.UseCase) {
+				r.On("GetTransactionByID", mock.Anything, mock.Anything).Return(&model.Transaction{
+					ID: uuid.Nil,
+					ExpiredAt: sql.NullTime{
+						Valid: true,
+						Time:  time.Now().Add(time.Hour),
+					},
+					TotalPrice: model.NewMoney(100),
+					WalletID:   &uuid.Nil,
+				}, nil)
+				r.On("GetWalletUser", mock.Anything, mock.Anything).Return(&model.Wallet{Balance: model.NewMoney(1000)}, nil)
+				r.On("GetOrderByTransactionID", mock.Anything, mock.Anything).Return([]*model.OrderModel{{OrderStatusID: 1}}, nil)
+				r.On("UpdateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("UpdateOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, mock.Anything, constant.OrderStatusWaitingForSeller).Return(nil)
+				r.On("InsertWalletHistory", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				r.On("GetWalletByUserID", mock.Anything, mock.Anything).Return(&model.Wallet{}, nil)
+				l.On("PostEntry", mock.Anything, mock.Anything, mock.Anything).Return(errors.New(response.WalletBalanceNotEnough))
+			},
+			expectedErr: errors.New(response.WalletBalanceNotEnough),
+		},
 		{
 			name:          "error GetTransactionByID",
 			transactionID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetTransactionByID", mock.Anything, mock.Anything).Return(nil, errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
@@ -2554,7 +2698,7 @@ func Test_userUC_CreateWalletPayment(t *testing.T) {
 		{
 			name:          "error GetTransactionByID sql no rows",
 			transactionID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetTransactionByID", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
 			},
 			expectedErr: errors.New(response.TransactionIDNotExist),
@@ -2562,7 +2706,7 @@ func Test_userUC_CreateWalletPayment(t *testing.T) {
 		{
 			name:          "error transaction expired",
 			transactionID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetTransactionByID", mock.Anything, mock.Anything).Return(&model.Transaction{
 					ExpiredAt: sql.NullTime{
 						Valid: true,
@@ -2576,7 +2720,7 @@ func Test_userUC_CreateWalletPayment(t *testing.T) {
 		{
 			name:          "error transaction has paid",
 			transactionID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetTransactionByID", mock.Anything, mock.Anything).Return(&model.Transaction{
 					ExpiredAt: sql.NullTime{
 						Valid: true,
@@ -2593,7 +2737,7 @@ func Test_userUC_CreateWalletPayment(t *testing.T) {
 		{
 			name:          "error Card number nil",
 			transactionID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, n *notificationMocks.UseCase) {
 				r.On("GetTransactionByID", mock.Anything, mock.Anything).Return(&model.Transaction{
 					ExpiredAt: sql.NullTime{
 						Valid: true,
@@ -2612,9 +2756,11 @@ func Test_userUC_CreateWalletPayment(t *testing.T) {
 			mock.ExpectBegin()
 
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			l := ledgerMocks.NewUseCase(t)
+			n := notificationMocks.NewUseCase(t)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, l, nil, nil, n, testKeys)
 
-			tc.mock(t, r)
+			tc.mock(t, r, l, n)
 			err := u.CreateWalletPayment(context.Background(), tc.transactionID)
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
@@ -2654,7 +2800,7 @@ func Test_userUC_GetTransactionByUserID(t *testing.T) {
 			mock.ExpectBegin()
 
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.GetTransactionByUserID(context.Background(), tc.userID, tc.status, tc.pgn)
@@ -2686,7 +2832,7 @@ func Test_userUC_GetTransactionByID(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.GetTransactionByID(context.Background(), tc.transactionID)
@@ -2698,41 +2844,111 @@ func Test_userUC_GetTransactionByID(t *testing.T) {
 }
 
 func Test_userUC_UpdateTransaction(t *testing.T) {
+	slp := newFakeSLPServer(t)
+	slp.txnID, slp.amount = "123456", "100"
+
+	tempCardNumber := "123456"
+	transaction := &model.Transaction{
+		ID: uuid.Nil,
+		ExpiredAt: sql.NullTime{
+			Valid: true,
+			Time:  time.Now().Add(time.Hour),
+		},
+		CardNumber: &tempCardNumber,
+		TotalPrice: model.NewMoney(100),
+		WalletID:   &uuid.Nil,
+	}
+	rejected := mock.MatchedBy(func(callback *model.PaymentCallback) bool { return !callback.IsAccepted })
+
 	testCase := []struct {
 		name          string
 		transactionID string
 		requestBody   body.SLPCallbackRequest
-		mock          func(t *testing.T, r *mocks.Repository)
+		mock          func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, n *notificationMocks.UseCase)
 		expectedErr   error
 	}{
 		{
 			name:          "success UpdateTransaction",
 			transactionID: "123456",
-			requestBody: body.SLPCallbackRequest{
-				Status:  constant.SLPStatusPaid,
-				Message: constant.SlPMessagePaid,
-			},
-			mock: func(t *testing.T, r *mocks.Repository) {
-				tempCardNumber := "123456"
-				r.On("GetTransactionByID", mock.Anything, mock.Anything).Return(&model.Transaction{
-					ID: uuid.Nil,
-					ExpiredAt: sql.NullTime{
-						Valid: true,
-						Time:  time.Now().Add(time.Hour),
-					},
-					CardNumber: &tempCardNumber,
-					TotalPrice: 100,
-					WalletID:   &uuid.Nil,
-				}, nil)
+			requestBody:   slp.callback(constant.SLPStatusPaid, constant.SlPMessagePaid, time.Now()),
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, n *notificationMocks.UseCase) {
+				r.On("GetTransactionByID", mock.Anything, mock.Anything).Return(transaction, nil)
+				r.On("InsertPaymentCallbackNonceRedis", mock.Anything, "nonce-1", constant.SLPCallbackWindowMin*2).Return(true, nil)
+				r.On("CreatePaymentCallback", mock.Anything, mock.MatchedBy(func(callback *model.PaymentCallback) bool {
+					return callback.IsAccepted && callback.CallbackType == constant.SLPCallbackTypePayment
+				})).Return(nil)
 				r.On("GetOrderByTransactionID", mock.Anything, mock.Anything).Return([]*model.OrderModel{{}}, nil)
 				r.On("UpdateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("UpdateOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				n.On("NotifyOrderStatus", mock.Anything, mock.Anything, mock.Anything, constant.OrderStatusWaitingForSeller).Return(nil)
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything).Return(&model.Wallet{}, nil)
 				r.On("InsertWalletHistory", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-				r.On("UpdateWalletBalance", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				l.On("PostEntry", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			},
 			expectedErr: nil,
 		},
+		{
+			name:          "error invalid signature",
+			transactionID: "123456",
+			requestBody: func() body.SLPCallbackRequest {
+				callback := slp.callback(constant.SLPStatusPaid, constant.SlPMessagePaid, time.Now())
+				callback.Amount = "1"
+				return callback
+			}(),
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, n *notificationMocks.UseCase) {
+				r.On("CreatePaymentCallback", mock.Anything, rejected).Return(nil)
+			},
+			expectedErr: errors.New(response.PaymentCallbackInvalid),
+		},
+		{
+			name:          "error callback of another transaction",
+			transactionID: "654321",
+			requestBody:   slp.callback(constant.SLPStatusPaid, constant.SlPMessagePaid, time.Now()),
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, n *notificationMocks.UseCase) {
+				r.On("CreatePaymentCallback", mock.Anything, rejected).Return(nil)
+			},
+			expectedErr: errors.New(response.PaymentCallbackInvalid),
+		},
+		{
+			name:          "error expired timestamp",
+			transactionID: "123456",
+			requestBody:   slp.callback(constant.SLPStatusPaid, constant.SlPMessagePaid, time.Now().Add(-time.Hour)),
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, n *notificationMocks.UseCase) {
+				r.On("CreatePaymentCallback", mock.Anything, rejected).Return(nil)
+			},
+			expectedErr: errors.New(response.PaymentCallbackExpired),
+		},
+		{
+			name:          "error replayed nonce",
+			transactionID: "123456",
+			requestBody:   slp.callback(constant.SLPStatusPaid, constant.SlPMessagePaid, time.Now()),
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, n *notificationMocks.UseCase) {
+				r.On("GetTransactionByID", mock.Anything, mock.Anything).Return(transaction, nil)
+				r.On("InsertPaymentCallbackNonceRedis", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+				r.On("CreatePaymentCallback", mock.Anything, rejected).Return(nil)
+			},
+			expectedErr: errors.New(response.PaymentCallbackReplayed),
+		},
+		{
+			name:          "error amount mismatch",
+			transactionID: "123456",
+			requestBody:   slp.callback(constant.SLPStatusPaid, constant.SlPMessagePaid, time.Now()),
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, n *notificationMocks.UseCase) {
+				r.On("GetTransactionByID", mock.Anything, mock.Anything).Return(&model.Transaction{TotalPrice: model.NewMoney(200)}, nil)
+				r.On("CreatePaymentCallback", mock.Anything, rejected).Return(nil)
+			},
+			expectedErr: errors.New(response.PaymentCallbackAmountMismatch),
+		},
+		{
+			name:          "error transaction not found",
+			transactionID: "123456",
+			requestBody:   slp.callback(constant.SLPStatusPaid, constant.SlPMessagePaid, time.Now()),
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase, n *notificationMocks.UseCase) {
+				r.On("GetTransactionByID", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+				r.On("CreatePaymentCallback", mock.Anything, rejected).Return(nil)
+			},
+			expectedErr: errors.New(response.TransactionIDNotExist),
+		},
 	}
 
 	for _, tc := range testCase {
@@ -2741,12 +2957,16 @@ func Test_userUC_UpdateTransaction(t *testing.T) {
 			mock.ExpectBegin()
 
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			l := ledgerMocks.NewUseCase(t)
+			n := notificationMocks.NewUseCase(t)
+			u := NewUserUseCase(slp.cfg, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, l, nil, nil, n, testKeys)
 
-			tc.mock(t, r)
+			tc.mock(t, r, l, n)
 			err := u.UpdateTransaction(context.Background(), tc.transactionID, tc.requestBody)
-			if err != nil {
+			if tc.expectedErr != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
 			}
 		})
 	}
@@ -2773,7 +2993,7 @@ func Test_userUC_UpdateTransactionPaymentMethod(t *testing.T) {
 						Time:  time.Now().Add(time.Hour),
 					},
 					CardNumber: &tempCardNumber,
-					TotalPrice: 100,
+					TotalPrice: model.NewMoney(100),
 					WalletID:   &uuid.Nil,
 				}, nil)
 				r.On("UpdateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -2788,7 +3008,7 @@ func Test_userUC_UpdateTransactionPaymentMethod(t *testing.T) {
 			mock.ExpectBegin()
 
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			err := u.UpdateTransactionPaymentMethod(context.Background(), tc.transactionID, tc.cardNumber)
@@ -2800,21 +3020,21 @@ func Test_userUC_UpdateTransactionPaymentMethod(t *testing.T) {
 }
 
 func Test_userUC_UpdateWalletTransaction(t *testing.T) {
+	slp := newFakeSLPServer(t)
+	slp.txnID, slp.amount = "123456", "100"
+
 	testCase := []struct {
 		name          string
 		transactionID string
 		requestBody   body.SLPCallbackRequest
-		mock          func(t *testing.T, r *mocks.Repository)
+		mock          func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase)
 		expectedErr   error
 	}{
 		{
 			name:          "success UpdateWalletTransaction cancel",
 			transactionID: "123456",
-			requestBody: body.SLPCallbackRequest{
-				Status:  constant.SLPStatusCanceled,
-				Message: constant.SLPMessageCanceled,
-			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			requestBody:   slp.callback(constant.SLPStatusCanceled, constant.SLPMessageCanceled, time.Now()),
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase) {
 				tempCardNumber := "123456"
 				r.On("GetTransactionByID", mock.Anything, mock.Anything).Return(&model.Transaction{
 					ID: uuid.Nil,
@@ -2823,9 +3043,13 @@ func Test_userUC_UpdateWalletTransaction(t *testing.T) {
 						Time:  time.Now().Add(time.Hour),
 					},
 					CardNumber: &tempCardNumber,
-					TotalPrice: 100,
+					TotalPrice: model.NewMoney(100),
 					WalletID:   &uuid.Nil,
 				}, nil)
+				r.On("InsertPaymentCallbackNonceRedis", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				r.On("CreatePaymentCallback", mock.Anything, mock.MatchedBy(func(callback *model.PaymentCallback) bool {
+					return callback.IsAccepted && callback.CallbackType == constant.SLPCallbackTypeWallet
+				})).Return(nil)
 				r.On("GetWalletUser", mock.Anything, mock.Anything).Return(&model.Wallet{}, nil)
 				r.On("UpdateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			},
@@ -2834,11 +3058,8 @@ func Test_userUC_UpdateWalletTransaction(t *testing.T) {
 		{
 			name:          "success UpdateWalletTransaction paid",
 			transactionID: "123456",
-			requestBody: body.SLPCallbackRequest{
-				Status:  constant.SLPStatusPaid,
-				Message: constant.SlPMessagePaid,
-			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			requestBody:   slp.callback(constant.SLPStatusPaid, constant.SlPMessagePaid, time.Now()),
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase) {
 				tempCardNumber := "123456"
 				r.On("GetTransactionByID", mock.Anything, mock.Anything).Return(&model.Transaction{
 					ID: uuid.Nil,
@@ -2847,16 +3068,46 @@ func Test_userUC_UpdateWalletTransaction(t *testing.T) {
 						Time:  time.Now().Add(time.Hour),
 					},
 					CardNumber: &tempCardNumber,
-					TotalPrice: 100,
+					TotalPrice: model.NewMoney(100),
 					WalletID:   &uuid.Nil,
 				}, nil)
+				r.On("InsertPaymentCallbackNonceRedis", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				r.On("CreatePaymentCallback", mock.Anything, mock.MatchedBy(func(callback *model.PaymentCallback) bool {
+					return callback.IsAccepted && callback.CallbackType == constant.SLPCallbackTypeWallet
+				})).Return(nil)
 				r.On("GetWalletUser", mock.Anything, mock.Anything).Return(&model.Wallet{}, nil)
 				r.On("UpdateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("InsertWalletHistory", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-				r.On("UpdateWalletBalance", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				l.On("PostEntry", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			},
 			expectedErr: nil,
 		},
+		{
+			name:          "error signed with another key",
+			transactionID: "123456",
+			requestBody: func() body.SLPCallbackRequest {
+				callback := slp.callback(constant.SLPStatusPaid, constant.SlPMessagePaid, time.Now())
+				callback.Signature = callback.Sign(slp.cfg.External.SlpMerchantCode, "another-key")
+				return callback
+			}(),
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase) {
+				r.On("CreatePaymentCallback", mock.Anything, mock.MatchedBy(func(callback *model.PaymentCallback) bool {
+					return !callback.IsAccepted && callback.RejectReason == response.PaymentCallbackInvalid
+				})).Return(nil)
+			},
+			expectedErr: errors.New(response.PaymentCallbackInvalid),
+		},
+		{
+			name:          "error callback of another transaction",
+			transactionID: "654321",
+			requestBody:   slp.callback(constant.SLPStatusPaid, constant.SlPMessagePaid, time.Now()),
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase) {
+				r.On("CreatePaymentCallback", mock.Anything, mock.MatchedBy(func(callback *model.PaymentCallback) bool {
+					return !callback.IsAccepted && callback.RejectReason == response.PaymentCallbackInvalid
+				})).Return(nil)
+			},
+			expectedErr: errors.New(response.PaymentCallbackInvalid),
+		},
 	}
 
 	for _, tc := range testCase {
@@ -2865,12 +3116,15 @@ func Test_userUC_UpdateWalletTransaction(t *testing.T) {
 			mock.ExpectBegin()
 
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			l := ledgerMocks.NewUseCase(t)
+			u := NewUserUseCase(slp.cfg, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, l, nil, nil, nil, testKeys)
 
-			tc.mock(t, r)
+			tc.mock(t, r, l)
 			err := u.UpdateWalletTransaction(context.Background(), tc.transactionID, tc.requestBody)
-			if err != nil {
+			if tc.expectedErr != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
 			}
 		})
 	}
@@ -2880,21 +3134,31 @@ func Test_userUC_GetWallet(t *testing.T) {
 	testCase := []struct {
 		name        string
 		userID      string
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase)
 		expectedErr error
 	}{
 		{
 			name:   "success GetWallet",
 			userID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase) {
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything).Return(&model.Wallet{}, nil)
+				l.On("GetWalletBalance", mock.Anything, uuid.Nil.String()).Return(model.NewMoney(1000), nil)
 			},
 			expectedErr: nil,
 		},
+		{
+			name:   "error GetWallet ledger balance",
+			userID: "123456",
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase) {
+				r.On("GetWalletByUserID", mock.Anything, mock.Anything).Return(&model.Wallet{}, nil)
+				l.On("GetWalletBalance", mock.Anything, uuid.Nil.String()).Return(model.Money(0), errors.New("test"))
+			},
+			expectedErr: errors.New("test"),
+		},
 		{
 			name:   "error GetWallet",
 			userID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase) {
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything).Return(nil, errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
@@ -2902,7 +3166,7 @@ func Test_userUC_GetWallet(t *testing.T) {
 		{
 			name:   "error GetWallet no sql row",
 			userID: "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, l *ledgerMocks.UseCase) {
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
 			},
 			expectedErr: errors.New(response.WalletIsNotActivated),
@@ -2912,9 +3176,10 @@ func Test_userUC_GetWallet(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			l := ledgerMocks.NewUseCase(t)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, l, nil, nil, nil, testKeys)
 
-			tc.mock(t, r)
+			tc.mock(t, r, l)
 			_, err := u.GetWallet(context.Background(), tc.userID)
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
@@ -2968,7 +3233,7 @@ func Test_userUC_GetWalletHistory(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.GetWalletHistory(context.Background(), tc.userID, tc.pgn)
@@ -3027,7 +3292,7 @@ func Test_userUC_GetDetailWalletHistory(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.GetDetailWalletHistory(context.Background(), tc.walletHistoryID, tc.userID)
@@ -3039,11 +3304,12 @@ func Test_userUC_GetDetailWalletHistory(t *testing.T) {
 }
 
 func Test_userUC_WalletStepUp(t *testing.T) {
+	pinHash := "$2a$10$haIhdlIHObH0yGMyCx5Zl.s5b7sV/x3GWact0Yd2xREXof3UAzUl6"
 	testCase := []struct {
 		name        string
 		userID      string
 		requestBody body.WalletStepUpRequest
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase)
 		expectedErr error
 	}{
 		{
@@ -3053,27 +3319,125 @@ func Test_userUC_WalletStepUp(t *testing.T) {
 				Amount: 1000,
 				Pin:    "123456",
 			},
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything).Return(&model.Wallet{
 					Balance: 100000,
 					PIN:     "123456",
 				}, nil)
-				r.On("UpdateWallet", mock.Anything, mock.Anything).Return(nil)
+				a.On("Check", mock.Anything, walletPinPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).Return(nil)
+				a.On("Fail", mock.Anything, walletPinPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).Return(nil)
 			},
 			expectedErr: errors.New(response.WalletPinIsInvalid),
 		},
+		{
+			name:   "error WalletStepUp wrong pin locks out",
+			userID: "123456",
+			requestBody: body.WalletStepUpRequest{
+				Amount: 1000,
+				Pin:    "123456",
+			},
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				r.On("GetWalletByUserID", mock.Anything, mock.Anything).Return(&model.Wallet{
+					Balance: 100000,
+					PIN:     "123456",
+				}, nil)
+				a.On("Check", mock.Anything, walletPinPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).Return(nil)
+				a.On("Fail", mock.Anything, walletPinPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).
+					Return(&attempt.LockedError{RetryAfter: 15 * time.Minute})
+			},
+			expectedErr: &attempt.LockedError{RetryAfter: 15 * time.Minute},
+		},
+		{
+			name:   "error WalletStepUp locked out",
+			userID: "123456",
+			requestBody: body.WalletStepUpRequest{
+				Amount: 1000,
+				Pin:    "123456",
+			},
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				r.On("GetWalletByUserID", mock.Anything, mock.Anything).Return(&model.Wallet{
+					Balance: 100000,
+					PIN:     pinHash,
+				}, nil)
+				a.On("Check", mock.Anything, walletPinPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).
+					Return(&attempt.LockedError{RetryAfter: time.Minute})
+			},
+			expectedErr: &attempt.LockedError{RetryAfter: time.Minute},
+		},
+		{
+			name:   "success WalletStepUp",
+			userID: "123456",
+			requestBody: body.WalletStepUpRequest{
+				Amount: 1000,
+				Pin:    "123456",
+			},
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				r.On("GetWalletByUserID", mock.Anything, mock.Anything).Return(&model.Wallet{
+					Balance: 100000,
+					PIN:     pinHash,
+				}, nil)
+				a.On("Check", mock.Anything, walletPinPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).Return(nil)
+				f.On("VerifyStepUp", mock.Anything, "123456", "").Return(nil)
+				a.On("Reset", mock.Anything, walletPinPolicy, attempt.Account("123456")).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:   "error WalletStepUp invalid two-factor code",
+			userID: "123456",
+			requestBody: body.WalletStepUpRequest{
+				Amount: 1000,
+				Pin:    "123456",
+				TOTP:   "000000",
+			},
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				r.On("GetWalletByUserID", mock.Anything, mock.Anything).Return(&model.Wallet{
+					Balance: 100000,
+					PIN:     pinHash,
+				}, nil)
+				a.On("Check", mock.Anything, walletPinPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).Return(nil)
+				f.On("VerifyStepUp", mock.Anything, "123456", "000000").
+					Return(httperror.New(http.StatusBadRequest, response.TwoFactorCodeInvalid))
+				a.On("Fail", mock.Anything, walletPinPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).Return(nil)
+			},
+			expectedErr: errors.New(response.TwoFactorCodeInvalid),
+		},
+		{
+			name:   "success WalletStepUp with two-factor code",
+			userID: "123456",
+			requestBody: body.WalletStepUpRequest{
+				Amount: 1000,
+				Pin:    "123456",
+				TOTP:   "287082",
+			},
+			mock: func(t *testing.T, r *mocks.Repository, a *attemptMocks.Limiter, f *twoFactorMocks.UseCase) {
+				r.On("GetWalletByUserID", mock.Anything, mock.Anything).Return(&model.Wallet{
+					Balance: 100000,
+					PIN:     pinHash,
+				}, nil)
+				a.On("Check", mock.Anything, walletPinPolicy, attempt.Account("123456"), attempt.IP("10.0.0.1")).Return(nil)
+				f.On("VerifyStepUp", mock.Anything, "123456", "287082").Return(nil)
+				a.On("Reset", mock.Anything, walletPinPolicy, attempt.Account("123456")).Return(nil)
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
-
-			tc.mock(t, r)
-			_, err := u.WalletStepUp(context.Background(), tc.userID, tc.requestBody)
-			if err != nil {
-				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			a := attemptMocks.NewLimiter(t)
+			f := twoFactorMocks.NewUseCase(t)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, a, f, nil, nil, nil, nil, testKeys)
+
+			tc.mock(t, r, a, f)
+			_, err := u.WalletStepUp(context.Background(), tc.userID, tc.requestBody, "10.0.0.1")
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
 			}
+
+			assert.Equal(t, err.Error(), tc.expectedErr.Error())
 		})
 	}
 }
@@ -3132,7 +3496,7 @@ func Test_userUC_ChangeWalletPinStepUp(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.ChangeWalletPinStepUp(context.Background(), tc.userID, tc.requestBody)
@@ -3148,20 +3512,21 @@ func Test_userUC_ChangeWalletPin(t *testing.T) {
 		name        string
 		userID      string
 		pin         string
-		mock        func(t *testing.T, r *mocks.Repository)
+		mock        func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase)
 		expectedErr error
 	}{
 		{
 			name:   "success ChangeWalletPin",
 			userID: "123456",
 			pin:    "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything).Return(&model.Wallet{
 					ID:      uuid.Nil,
 					Balance: 100000,
 					PIN:     "123456",
 				}, nil)
-				r.On("UpdateWalletPin", mock.Anything, mock.Anything).Return(nil)
+				r.On("UpdateWalletPin", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				a.On("Record", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			},
 			expectedErr: nil,
 		},
@@ -3169,7 +3534,7 @@ func Test_userUC_ChangeWalletPin(t *testing.T) {
 			name:   "error ChangeWalletPin",
 			userID: "123456",
 			pin:    "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything).Return(nil, errors.New("test"))
 			},
 			expectedErr: errors.New("test"),
@@ -3178,7 +3543,7 @@ func Test_userUC_ChangeWalletPin(t *testing.T) {
 			name:   "error ChangeWalletPin sql no rows",
 			userID: "123456",
 			pin:    "123456",
-			mock: func(t *testing.T, r *mocks.Repository) {
+			mock: func(t *testing.T, r *mocks.Repository, a *auditMocks.UseCase) {
 				r.On("GetWalletByUserID", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
 			},
 			expectedErr: errors.New(response.WalletIsNotActivated),
@@ -3187,11 +3552,16 @@ func Test_userUC_ChangeWalletPin(t *testing.T) {
 
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
+			sql, mock, _ := sqlmock.New()
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			a := auditMocks.NewUseCase(t)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, nil, nil, nil, nil, nil, a, nil, nil, testKeys)
 
-			tc.mock(t, r)
-			err := u.ChangeWalletPin(context.Background(), tc.userID, tc.pin)
+			tc.mock(t, r, a)
+			err := u.ChangeWalletPin(context.Background(), tc.userID, tc.pin, session.Client{})
 			if err != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
 			}
@@ -3199,11 +3569,62 @@ func Test_userUC_ChangeWalletPin(t *testing.T) {
 	}
 }
 
+type stubShipping struct {
+	service string
+	fee     int
+	err     error
+}
+
+func (s *stubShipping) Name() string {
+	return "stub"
+}
+
+func (s *stubShipping) GetProvinces(ctx context.Context) ([]shipping.Province, error) {
+	return nil, s.err
+}
+
+func (s *stubShipping) GetCities(ctx context.Context, provinceID int) ([]shipping.City, error) {
+	return nil, s.err
+}
+
+func (s *stubShipping) GetCost(ctx context.Context, origin, destination, weight int, courier string) (*shipping.Quote, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return newStubQuote(s.service, s.fee), nil
+}
+
+func newStubQuote(service string, fee int) *shipping.Quote {
+	return &shipping.Quote{Services: []shipping.Service{{Service: service, Fee: fee, ETD: "1-2"}}}
+}
+
+// mockCheckoutShippingQuote mocks a one shop checkout up to the shipping quote.
+func mockCheckoutShippingQuote(r *mocks.Repository) {
+	userID, _ := uuid.Parse("ab80c496-387b-4989-bf3b-a6f68a05940d")
+	shopID, _ := uuid.Parse("33ee7825-461b-40ca-8d6e-09ce7f2851fb")
+	r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{ID: userID}, nil)
+	r.On("GetWalletUser", mock.Anything, mock.Anything).Return(&model.Wallet{UserID: userID}, nil)
+	r.On("GetShopByID", mock.Anything, mock.Anything).Return(&model.Shop{ID: shopID, UserID: shopID}, nil)
+	r.On("GetCourierShopByID", mock.Anything, mock.Anything, mock.Anything).Return(&model.Courier{Code: "jne", Service: "REG"}, nil)
+	r.On("GetProductDetailByID", mock.Anything, mock.Anything, mock.Anything).Return(&model.ProductDetail{
+		ID:     uuid.New(),
+		Price:  10000,
+		Stock:  10,
+		Weight: 100,
+	}, nil)
+	r.On("GetCartItemUser", mock.Anything, mock.Anything, mock.Anything).Return(&model.CartItem{Quantity: 2}, nil)
+	r.On("GetProductPromotionByProductID", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+	r.On("GetAddressByBuyerID", mock.Anything, mock.Anything).Return(&model.Address{CityID: 114}, nil)
+	r.On("GetAddressBySellerID", mock.Anything, mock.Anything).Return(&model.Address{CityID: 501}, nil)
+}
+
 func Test_userUC_CreateTransaction(t *testing.T) {
 	testCase := []struct {
 		name        string
 		userID      string
 		requestBody body.CreateTransactionRequest
+		shipping    *stubShipping
 		mock        func(t *testing.T, r *mocks.Repository)
 		expectedErr error
 	}{
@@ -3219,7 +3640,7 @@ func Test_userUC_CreateTransaction(t *testing.T) {
 						ShopID:        "33ee7825-461b-40ca-8d6e-09ce7f2851fb",
 						VoucherShopID: "b558e7e0-a39b-420e-ada4-ce4b180e7e9a",
 						CourierID:     "1",
-						CourierFee:    100,
+						CourierFee:    model.NewMoney(100),
 						ProductDetails: []body.ProductDetail{
 							{
 								ID:       "c62f09d8-290d-496c-8413-e7d40ceaed05",
@@ -3247,9 +3668,9 @@ func Test_userUC_CreateTransaction(t *testing.T) {
 				tempVoucherID, _ := uuid.Parse("f483bf6b-6293-428b-b87a-5892aacb4efa")
 				tempVoucherID1, _ := uuid.Parse("f483bf6b-6293-428b-b87a-5892aacb4efa")
 				tempVDiscountPercentage := float64(0)
-				tempVDiscountFixPrice := float64(10)
-				tempVMinProductPrice := float64(1)
-				tempVMaxDiscountPrice := float64(100000)
+				tempVDiscountFixPrice := model.NewMoney(10)
+				tempVMinProductPrice := model.NewMoney(1)
+				tempVMaxDiscountPrice := model.NewMoney(100000)
 				r.On("GetVoucherMarketplaceByID", mock.Anything, mock.Anything).Return(&model.Voucher{
 					ID:                 tempVoucherID,
 					ShopID:             uuid.Nil,
@@ -3295,9 +3716,10 @@ func Test_userUC_CreateTransaction(t *testing.T) {
 				}, nil)
 
 				r.On("GetProductDetailByID", mock.Anything, mock.Anything, mock.Anything).Once().Return(&model.ProductDetail{
-					ID: tempProductDetailID,
+					ID:        tempProductDetailID,
+					ProductID: tempProductID,
 				}, nil)
-				r.On("GetCartItemUser", mock.Anything, mock.Anything, mock.Anything).Once().Return(&model.CartItem{}, nil)
+				r.On("GetCartItemUser", mock.Anything, mock.Anything, mock.Anything).Once().Return(&model.CartItem{Quantity: 1}, nil)
 
 				r.On("GetProductDetailByID", mock.Anything, mock.Anything, mock.Anything).Once().Return(&model.ProductDetail{
 					ID:        tempProductDetailID,
@@ -3313,7 +3735,7 @@ func Test_userUC_CreateTransaction(t *testing.T) {
 					UpdatedAt: sql.NullTime{},
 					DeletedAt: sql.NullTime{},
 				}, nil)
-				r.On("GetCartItemUser", mock.Anything, mock.Anything, mock.Anything).Once().Return(&model.CartItem{}, nil)
+				r.On("GetCartItemUser", mock.Anything, mock.Anything, mock.Anything).Once().Return(&model.CartItem{Quantity: 1}, nil)
 				r.On("GetProductPromotionByProductID", mock.Anything, mock.Anything).Once().Return(&model.Promotion{
 					ID:                 tempPromoID,
 					Name:               "asd",
@@ -3333,33 +3755,156 @@ func Test_userUC_CreateTransaction(t *testing.T) {
 
 				tempTransactionID, _ := uuid.Parse("b7938be2-0d48-4ba8-af6b-465b79eb0891")
 				tempOrderID, _ := uuid.Parse("ccbcbe3e-3cb1-4aae-abb8-e42d6bc587c0")
-				r.On("GetAddressByBuyerID", mock.Anything, mock.Anything).Once().Return(&model.Address{}, nil)
-				r.On("GetAddressBySellerID", mock.Anything, mock.Anything).Once().Return(&model.Address{}, nil)
+				r.On("GetAddressByBuyerID", mock.Anything, mock.Anything).Once().Return(&model.Address{CityID: 114}, nil)
+				r.On("GetAddressBySellerID", mock.Anything, mock.Anything).Once().Return(&model.Address{CityID: 501}, nil)
+				costKey := shipping.CostKey(&stubShipping{}, 501, 114, 100, "JNE")
+				r.On("GetCostRedis", mock.Anything, costKey).Return(nil, errors.New("redis: nil"))
+				r.On("InsertCostRedis", mock.Anything, costKey, mock.Anything).Return(nil)
 				r.On("CreateTransaction", mock.Anything, mock.Anything, mock.Anything).Once().Return(&tempTransactionID, nil)
-				r.On("UpdateVoucherQuota", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil)
-				r.On("UpdateVoucherQuota", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil)
-				r.On("UpdatePromotionQuota", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil)
+				r.On("GetVoucherForUpdate", mock.Anything, mock.Anything, mock.Anything).Return(&model.Voucher{
+					Quota:        1,
+					ActivedDate:  time.Now().Add(-time.Hour),
+					ExpiredDate:  time.Now().Add(time.Hour),
+					LimitPerUser: 1,
+				}, nil)
+				r.On("CountVoucherRedemptionByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
+				r.On("ClaimVoucherQuota", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				r.On("CreateVoucherRedemption", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				r.On("CreateOrder", mock.Anything, mock.Anything, mock.Anything).Once().Return(&tempOrderID, nil)
+				r.On("ClaimPromotionQuota", mock.Anything, mock.Anything, tempPromoID.String(), 1).Once().Return(true, nil)
+				r.On("CreatePromotionClaim", mock.Anything, mock.Anything, mock.MatchedBy(func(claim *model.PromotionClaim) bool {
+					return claim.PromotionID == tempPromoID && claim.TransactionID == tempTransactionID &&
+						claim.OrderID == tempOrderID && claim.Quantity == 1
+				})).Once().Return(nil)
 				r.On("CreateOrderItem", mock.Anything, mock.Anything, mock.Anything).Once().Return(&tempProductDetailID, nil)
-				r.On("UpdateProductDetailStock", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil)
+				r.On("ReserveProductDetailStock", mock.Anything, mock.Anything, tempOrderID.String(), mock.Anything, mock.Anything).
+					Once().Return(true, nil)
 				r.On("DeleteCartItemByID", mock.Anything, mock.Anything, mock.Anything).Once().Return(nil)
 			},
+			shipping:    &stubShipping{service: "sell", fee: 100},
 			expectedErr: nil,
 		},
+		{
+			name:   "error out of stock removes the cart item",
+			userID: "ab80c496-387b-4989-bf3b-a6f68a05940d",
+			requestBody: body.CreateTransactionRequest{
+				WalletID: "c737a0f0-00e0-4dd5-89eb-dc44a9ea3413",
+				CartItems: []body.CartItem{
+					{
+						ShopID:         "33ee7825-461b-40ca-8d6e-09ce7f2851fb",
+						CourierID:      "1",
+						ProductDetails: []body.ProductDetail{{ID: "c62f09d8-290d-496c-8413-e7d40ceaed05", Quantity: 2}},
+					},
+				},
+			},
+			shipping: &stubShipping{service: "REG", fee: 18000},
+			mock: func(t *testing.T, r *mocks.Repository) {
+				userID, _ := uuid.Parse("ab80c496-387b-4989-bf3b-a6f68a05940d")
+				shopID, _ := uuid.Parse("33ee7825-461b-40ca-8d6e-09ce7f2851fb")
+				cartItem := &model.CartItem{ID: uuid.New(), Quantity: 2}
+				r.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{ID: userID}, nil)
+				r.On("GetWalletUser", mock.Anything, mock.Anything).Return(&model.Wallet{UserID: userID}, nil)
+				r.On("GetShopByID", mock.Anything, mock.Anything).Return(&model.Shop{ID: shopID, UserID: shopID}, nil)
+				r.On("GetCourierShopByID", mock.Anything, mock.Anything, mock.Anything).Return(&model.Courier{Code: "jne", Service: "REG"}, nil)
+				r.On("GetProductDetailByID", mock.Anything, mock.Anything, mock.Anything).
+					Return(&model.ProductDetail{ID: uuid.New(), Price: 10000, Stock: 1}, nil)
+				r.On("GetCartItemUser", mock.Anything, mock.Anything, mock.Anything).Return(cartItem, nil)
+				r.On("GetProductPromotionByProductID", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+				r.On("DeleteCartItemByID", mock.Anything, mock.MatchedBy(func(tx postgre.Transaction) bool {
+					_, outsideTx := tx.(*sql.DB)
+					return outsideTx
+				}), cartItem).Once().Return(nil)
+			},
+			expectedErr: errors.New(response.ProductQuantityNotAvailable),
+		},
+		{
+			name:   "error shipping fee changed",
+			userID: "ab80c496-387b-4989-bf3b-a6f68a05940d",
+			requestBody: body.CreateTransactionRequest{
+				WalletID: "c737a0f0-00e0-4dd5-89eb-dc44a9ea3413",
+				CartItems: []body.CartItem{
+					{
+						ShopID:         "33ee7825-461b-40ca-8d6e-09ce7f2851fb",
+						CourierID:      "1",
+						CourierFee:     0,
+						ProductDetails: []body.ProductDetail{{ID: "c62f09d8-290d-496c-8413-e7d40ceaed05", Quantity: 2}},
+					},
+				},
+			},
+			shipping: &stubShipping{service: "REG", fee: 18000},
+			mock: func(t *testing.T, r *mocks.Repository) {
+				mockCheckoutShippingQuote(r)
+				r.On("GetCostRedis", mock.Anything, "stub:501:114:200:jne").Return(nil, errors.New("redis: nil"))
+				r.On("InsertCostRedis", mock.Anything, "stub:501:114:200:jne", mock.Anything).Return(nil)
+			},
+			expectedErr: errors.New(response.ShippingFeeChanged),
+		},
+		{
+			name:   "error shipping fee from cache changed",
+			userID: "ab80c496-387b-4989-bf3b-a6f68a05940d",
+			requestBody: body.CreateTransactionRequest{
+				WalletID: "c737a0f0-00e0-4dd5-89eb-dc44a9ea3413",
+				CartItems: []body.CartItem{
+					{
+						ShopID:         "33ee7825-461b-40ca-8d6e-09ce7f2851fb",
+						CourierID:      "1",
+						CourierFee:     model.NewMoney(18000),
+						ProductDetails: []body.ProductDetail{{ID: "c62f09d8-290d-496c-8413-e7d40ceaed05", Quantity: 2}},
+					},
+				},
+			},
+			shipping: &stubShipping{service: "REG", fee: 18000},
+			mock: func(t *testing.T, r *mocks.Repository) {
+				mockCheckoutShippingQuote(r)
+				cached, _ := json.Marshal(newStubQuote("REG", 21000))
+				cachedString := string(cached)
+				r.On("GetCostRedis", mock.Anything, "stub:501:114:200:jne").Return(&cachedString, nil)
+			},
+			expectedErr: errors.New(response.ShippingFeeChanged),
+		},
+		{
+			name:   "error shipping service unavailable",
+			userID: "ab80c496-387b-4989-bf3b-a6f68a05940d",
+			requestBody: body.CreateTransactionRequest{
+				WalletID: "c737a0f0-00e0-4dd5-89eb-dc44a9ea3413",
+				CartItems: []body.CartItem{
+					{
+						ShopID:         "33ee7825-461b-40ca-8d6e-09ce7f2851fb",
+						CourierID:      "1",
+						CourierFee:     model.NewMoney(18000),
+						ProductDetails: []body.ProductDetail{{ID: "c62f09d8-290d-496c-8413-e7d40ceaed05", Quantity: 2}},
+					},
+				},
+			},
+			shipping: &stubShipping{service: "YES", fee: 18000},
+			mock: func(t *testing.T, r *mocks.Repository) {
+				mockCheckoutShippingQuote(r)
+				r.On("GetCostRedis", mock.Anything, "stub:501:114:200:jne").Return(nil, errors.New("redis: nil"))
+				r.On("InsertCostRedis", mock.Anything, "stub:501:114:200:jne", mock.Anything).Return(nil)
+			},
+			expectedErr: errors.New(response.ShippingServiceUnavailable),
+		},
 	}
 
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			sql, mock, _ := sqlmock.New()
 			mock.ExpectBegin()
+			if tc.expectedErr == nil {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
 
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{PSQL: sql}, r, tc.shipping, nil, nil, nil, nil, nil, nil, nil, testKeys)
 
 			tc.mock(t, r)
 			_, err := u.CreateTransaction(context.Background(), tc.userID, tc.requestBody)
-			if err != nil {
+			if tc.expectedErr != nil {
 				assert.Equal(t, err.Error(), tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
 			}
 		})
 	}
@@ -3479,7 +4024,7 @@ func Test_userUC_GetRefundOrder(t *testing.T) {
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
 			r := mocks.NewRepository(t)
-			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r)
+			u := NewUserUseCase(&config.Config{}, &postgre.TxRepo{}, r, nil, nil, nil, nil, nil, nil, nil, nil, testKeys)
 			tc.mock(t, r)
 			_, err := u.GetRefundOrder(context.Background(), tc.userID, tc.orderID)
 			if tc.expectedErr {
@@ -3490,3 +4035,95 @@ func Test_userUC_GetRefundOrder(t *testing.T) {
 		})
 	}
 }
+
+func Test_userUC_claimVoucher(t *testing.T) {
+	minProductPrice := model.NewMoney(50000)
+	activeVoucher := func() *model.Voucher {
+		return &model.Voucher{
+			Quota:           1,
+			ActivedDate:     time.Now().Add(-time.Hour),
+			ExpiredDate:     time.Now().Add(time.Hour),
+			MinProductPrice: &minProductPrice,
+			LimitPerUser:    1,
+		}
+	}
+
+	testCase := []struct {
+		name        string
+		price       model.Money
+		mock        func(t *testing.T, r *mocks.Repository)
+		expectedErr error
+	}{
+		{
+			name:  "success claim voucher",
+			price: model.NewMoney(50000),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetVoucherForUpdate", mock.Anything, mock.Anything, mock.Anything).Return(activeVoucher(), nil)
+				r.On("CountVoucherRedemptionByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
+				r.On("ClaimVoucherQuota", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				r.On("CreateVoucherRedemption", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "error voucher deleted",
+			price: model.NewMoney(50000),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetVoucherForUpdate", mock.Anything, mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+			},
+			expectedErr: errors.New(response.VoucherNotActive),
+		},
+		{
+			name:  "error voucher expired",
+			price: model.NewMoney(50000),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				voucher := activeVoucher()
+				voucher.ExpiredDate = time.Now().Add(-time.Minute)
+				r.On("GetVoucherForUpdate", mock.Anything, mock.Anything, mock.Anything).Return(voucher, nil)
+			},
+			expectedErr: errors.New(response.VoucherNotActive),
+		},
+		{
+			name:  "error below min price",
+			price: model.NewMoney(49999),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetVoucherForUpdate", mock.Anything, mock.Anything, mock.Anything).Return(activeVoucher(), nil)
+			},
+			expectedErr: errors.New(response.VoucherMinPriceNotMet),
+		},
+		{
+			name:  "error usage limit reached",
+			price: model.NewMoney(50000),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetVoucherForUpdate", mock.Anything, mock.Anything, mock.Anything).Return(activeVoucher(), nil)
+				r.On("CountVoucherRedemptionByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(1, nil)
+			},
+			expectedErr: errors.New(response.VoucherUsageLimitReached),
+		},
+		{
+			name:  "error quota exhausted",
+			price: model.NewMoney(50000),
+			mock: func(t *testing.T, r *mocks.Repository) {
+				r.On("GetVoucherForUpdate", mock.Anything, mock.Anything, mock.Anything).Return(activeVoucher(), nil)
+				r.On("CountVoucherRedemptionByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
+				r.On("ClaimVoucherQuota", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+			},
+			expectedErr: errors.New(response.VoucherQuotaExhausted),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mocks.NewRepository(t)
+			u := &userUC{cfg: &config.Config{}, userRepo: r}
+
+			tc.mock(t, r)
+			err := u.claimVoucher(context.Background(), nil, &model.VoucherRedemption{}, tc.price)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
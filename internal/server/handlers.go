@@ -1,30 +1,54 @@
 package server
 
 import (
+	"fmt"
+	"murakali/internal/constant"
 	"murakali/internal/middleware"
 	adminDelivery "murakali/internal/module/admin/delivery"
 	adminRepository "murakali/internal/module/admin/repository"
 	adminUseCase "murakali/internal/module/admin/usecase"
+	auditDelivery "murakali/internal/module/audit/delivery"
+	auditRepository "murakali/internal/module/audit/repository"
+	auditUseCase "murakali/internal/module/audit/usecase"
 	authDelivery "murakali/internal/module/auth/delivery"
 	authRepository "murakali/internal/module/auth/repository"
 	authUseCase "murakali/internal/module/auth/usecase"
 	cartDelivery "murakali/internal/module/cart/delivery"
 	cartRepository "murakali/internal/module/cart/repository"
 	cartUseCase "murakali/internal/module/cart/usecase"
+	jobDelivery "murakali/internal/module/job/delivery"
+	jobRepository "murakali/internal/module/job/repository"
+	jobUseCase "murakali/internal/module/job/usecase"
+	ledgerRepository "murakali/internal/module/ledger/repository"
+	ledgerUseCase "murakali/internal/module/ledger/usecase"
 	locationDelivery "murakali/internal/module/location/delivery"
 	locationRepository "murakali/internal/module/location/repository"
 	locationUseCase "murakali/internal/module/location/usecase"
+	notificationDelivery "murakali/internal/module/notification/delivery"
+	notificationRepository "murakali/internal/module/notification/repository"
+	notificationUseCase "murakali/internal/module/notification/usecase"
+	outboxRepository "murakali/internal/module/outbox/repository"
+	outboxUseCase "murakali/internal/module/outbox/usecase"
 	productDelivery "murakali/internal/module/product/delivery"
 	productRepository "murakali/internal/module/product/repository"
 	productUseCase "murakali/internal/module/product/usecase"
 	sellerDelivery "murakali/internal/module/seller/delivery"
 	sellerRepository "murakali/internal/module/seller/repository"
 	sellerUseCase "murakali/internal/module/seller/usecase"
+	twoFactorDelivery "murakali/internal/module/twofactor/delivery"
+	twoFactorRepository "murakali/internal/module/twofactor/repository"
+	twoFactorUseCase "murakali/internal/module/twofactor/usecase"
 	userDelivery "murakali/internal/module/user/delivery"
 	userRepository "murakali/internal/module/user/repository"
 	userUseCase "murakali/internal/module/user/usecase"
+	"murakali/pkg/attempt"
+	"murakali/pkg/email"
+	"murakali/pkg/jwt"
+	"murakali/pkg/kodepos"
 	"murakali/pkg/postgre"
 	"murakali/pkg/response"
+	"murakali/pkg/session"
+	"murakali/pkg/shipping"
 	"net/http"
 	"time"
 
@@ -33,19 +57,60 @@ import (
 )
 
 func (s *Server) MapHandlers() error {
+	// c.ClientIP keys the rate limits and login attempts, so X-Forwarded-For
+	// is only read from the configured proxies
+	if err := s.gin.SetTrustedProxies(trustedProxies(s.cfg.Server.TrustedProxies)); err != nil {
+		return fmt.Errorf("trusted proxies: %w", err)
+	}
+
 	txRepo := postgre.NewTxRepository(s.db)
+	sessionStore := session.NewRedisStore(s.redisClient, time.Duration(s.cfg.JWT.RefreshExpMin)*time.Minute)
+	attemptLimiter := attempt.NewRedisLimiter(s.redisClient)
+	keyring, err := jwt.LoadKeyring(s.cfg.JWT.KeyDir, s.cfg.JWT.ActiveKeyID)
+	if err != nil {
+		return fmt.Errorf("jwt keyring: %w", err)
+	}
+	s.log.Infof("JWT keyring loaded, signing with key %s", keyring.ActiveKeyID())
+
+	shippingProvider, err := shipping.NewProvider(s.cfg, s.db)
+	if err != nil {
+		return err
+	}
+
+	mailer, err := email.NewMailer(s.cfg, s.log)
+	if err != nil {
+		return err
+	}
+
+	outboxRepo := outboxRepository.NewOutboxRepository(s.db)
+	outboxUC := outboxUseCase.NewOutboxUseCase(s.cfg, outboxRepo, mailer)
+
+	notificationRepo := notificationRepository.NewNotificationRepository(s.db)
+	notificationUC := notificationUseCase.NewNotificationUseCase(s.cfg, notificationRepo, outboxUC)
+	notificationHandlers := notificationDelivery.NewNotificationHandlers(s.cfg, notificationUC, s.log)
+
+	ledgerRepo := ledgerRepository.NewLedgerRepository(s.db)
+	ledgerUC := ledgerUseCase.NewLedgerUseCase(s.cfg, txRepo, ledgerRepo)
+
+	auditRepo := auditRepository.NewAuditRepository(s.db)
+	auditUC := auditUseCase.NewAuditUseCase(s.cfg, auditRepo)
+	auditHandlers := auditDelivery.NewAuditHandlers(s.cfg, auditUC, s.log)
+
+	twoFactorRepo := twoFactorRepository.NewTwoFactorRepository(s.db)
+	twoFactorUC := twoFactorUseCase.NewTwoFactorUseCase(s.cfg, txRepo, twoFactorRepo, attemptLimiter)
+	twoFactorHandlers := twoFactorDelivery.NewTwoFactorHandlers(s.cfg, twoFactorUC, s.log)
 
 	adminRepo := adminRepository.NewAdminRepository(s.db, s.redisClient)
-	adminUC := adminUseCase.NewAdminUseCase(s.cfg, txRepo, adminRepo)
+	adminUC := adminUseCase.NewAdminUseCase(s.cfg, txRepo, adminRepo, ledgerUC, auditUC, notificationUC)
 	adminHandlers := adminDelivery.NewAdminHandlers(s.cfg, adminUC, s.log)
 
 	authRepo := authRepository.NewAuthRepository(s.db, s.redisClient)
-	authUC := authUseCase.NewAuthUseCase(s.cfg, txRepo, authRepo)
-	authHandlers := authDelivery.NewAuthHandlers(s.cfg, authUC, s.log)
+	authUC := authUseCase.NewAuthUseCase(s.cfg, txRepo, authRepo, sessionStore, attemptLimiter, twoFactorUC, outboxUC, keyring)
+	authHandlers := authDelivery.NewAuthHandlers(s.cfg, authUC, keyring, s.log)
 
 	userRepo := userRepository.NewUserRepository(s.db, s.redisClient)
-	userUC := userUseCase.NewUserUseCase(s.cfg, txRepo, userRepo)
-	userHandlers := userDelivery.NewUserHandlers(s.cfg, userUC, s.log)
+	userUC := userUseCase.NewUserUseCase(s.cfg, txRepo, userRepo, shippingProvider, sessionStore, attemptLimiter, twoFactorUC, ledgerUC, auditUC, outboxUC, notificationUC, keyring)
+	userHandlers := userDelivery.NewUserHandlers(s.cfg, userUC, keyring, s.log)
 
 	productRepo := productRepository.NewProductRepository(s.db, s.redisClient)
 	productUC := productUseCase.NewProductUseCase(s.cfg, txRepo, productRepo)
@@ -56,18 +121,22 @@ func (s *Server) MapHandlers() error {
 	cartHandlers := cartDelivery.NewCartHandlers(s.cfg, cartUC, s.log)
 
 	locationRepo := locationRepository.NewLocationRepository(s.db, s.redisClient)
-	locationUC := locationUseCase.NewLocationUseCase(s.cfg, txRepo, locationRepo)
+	locationUC := locationUseCase.NewLocationUseCase(s.cfg, txRepo, locationRepo, shippingProvider, kodepos.NewClient(s.cfg))
 	locationHandlers := locationDelivery.NewLocationHandlers(s.cfg, locationUC, s.log)
 
 	sellerRepo := sellerRepository.NewSellerRepository(s.db, s.redisClient)
-	sellerUC := sellerUseCase.NewSellerUseCase(s.cfg, txRepo, sellerRepo)
+	sellerUC := sellerUseCase.NewSellerUseCase(s.cfg, txRepo, sellerRepo, shippingProvider, ledgerUC, notificationUC)
 	sellerHandlers := sellerDelivery.NewSellerHandlers(s.cfg, sellerUC, s.log)
 
+	jobRepo := jobRepository.NewJobRepository(s.db, s.redisClient)
+	jobUC := jobUseCase.NewJobUseCase(s.cfg, jobRepo, sellerUC, userUC, productUC, outboxUC)
+	jobHandlers := jobDelivery.NewJobHandlers(s.cfg, jobUC, s.log)
+
 	s.gin.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{s.cfg.Server.Origin},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
-		AllowHeaders:     []string{"Origin", "Content-type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-type", "Authorization", middleware.IdempotencyKeyHeader},
+		ExposeHeaders:    append([]string{"Content-Length", "Retry-After", middleware.IdempotencyReplayedHeader}, middleware.RateLimitHeaders...),
 		AllowCredentials: true,
 		AllowOriginFunc: func(origin string) bool {
 			return origin == s.cfg.Server.Origin
@@ -76,20 +145,39 @@ func (s *Server) MapHandlers() error {
 	}))
 
 	s.gin.Static("/docs", "dist/")
+	authDelivery.MapWellKnownRoutes(s.gin.Group("/.well-known"), authHandlers)
 	s.gin.NoRoute(func(c *gin.Context) {
 		response.ErrorResponse(c.Writer, response.NotFoundMessage, http.StatusNotFound)
 	})
 
-	v1 := s.gin.Group("/api/v1")
-	authGroup := v1.Group("/auth")
+	mw := middleware.NewMiddlewareManager(s.cfg, []string{"*"}, s.log, s.redisClient, sessionStore, keyring)
+	rateLimits := make(map[string]*middleware.RateLimit)
+	for group, value := range map[string][2]string{
+		"global":   {s.cfg.RateLimit.Global, constant.RateLimitGlobal},
+		"auth":     {s.cfg.RateLimit.Auth, constant.RateLimitAuth},
+		"product":  {s.cfg.RateLimit.Product, constant.RateLimitProduct},
+		"location": {s.cfg.RateLimit.Location, constant.RateLimitLocation},
+	} {
+		limit, err := middleware.ParseRateLimit(value[0], value[1])
+		if err != nil {
+			return fmt.Errorf("rate limit %s: %w", group, err)
+		}
+		rateLimits[group] = limit
+	}
+
+	v1 := s.gin.Group("/api/v1", mw.RateLimitMiddleware("global", rateLimits["global"]))
+	authGroup := v1.Group("/auth", mw.RateLimitMiddleware("auth", rateLimits["auth"]))
 	userGroup := v1.Group("/user")
-	productGroup := v1.Group("/product")
+	productGroup := v1.Group("/product", mw.RateLimitMiddleware("product", rateLimits["product"]))
 	cartGroup := v1.Group("/cart")
-	locationGroup := v1.Group("/location")
+	locationGroup := v1.Group("/location", mw.RateLimitMiddleware("location", rateLimits["location"]))
 	sellerGroup := v1.Group("/seller")
 	adminGroup := v1.Group("/admin")
+	jobGroup := v1.Group("/admin/job")
+	auditGroup := v1.Group("/admin/audit")
+	twoFactorGroup := v1.Group("/user/2fa")
+	notificationGroup := v1.Group("/user/notification")
 
-	mw := middleware.NewMiddlewareManager(s.cfg, []string{"*"}, s.log, s.redisClient)
 	authDelivery.MapAuthRoutes(authGroup, authHandlers)
 	userDelivery.MapUserRoutes(userGroup, userHandlers, mw)
 	productDelivery.MapProductRoutes(productGroup, productHandlers, mw)
@@ -97,6 +185,10 @@ func (s *Server) MapHandlers() error {
 	locationDelivery.MapAuthRoutes(locationGroup, locationHandlers)
 	sellerDelivery.MapSellerRoutes(sellerGroup, sellerHandlers, mw)
 	adminDelivery.MapAdminRoutes(adminGroup, adminHandlers, mw)
+	jobDelivery.MapJobRoutes(jobGroup, jobHandlers, mw)
+	auditDelivery.MapAuditRoutes(auditGroup, auditHandlers, mw)
+	twoFactorDelivery.MapTwoFactorRoutes(twoFactorGroup, twoFactorHandlers, mw)
+	notificationDelivery.MapNotificationRoutes(notificationGroup, notificationHandlers, mw)
 
-	return nil
+	return s.scheduleJobs(jobUC)
 }
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"murakali/internal/module/job"
+	"murakali/pkg/httperror"
+	"net/http"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// scheduleJobs registers every job on the in-process scheduler. Each replica
+// schedules all jobs; the schedule slot makes sure only one of them runs a tick.
+func (s *Server) scheduleJobs(jobUC job.UseCase) error {
+	s.scheduler = cron.New()
+	for _, definition := range jobUC.GetJobs() {
+		name := definition.Name
+		_, err := s.scheduler.AddFunc(definition.Schedule, func() {
+			run, err := jobUC.StartScheduledJob(context.Background(), name, time.Now())
+			if err != nil {
+				var e *httperror.Error
+				if errors.As(err, &e) && e.Status == http.StatusConflict {
+					s.log.Debugf("job %s skipped: %s", name, e.Err)
+					return
+				}
+
+				s.log.Errorf("job %s start error: %s", name, err)
+				return
+			}
+
+			s.log.Infof("job %s started, run %s", name, run.ID)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	s.scheduler.Start()
+	return nil
+}
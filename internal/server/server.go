@@ -5,11 +5,13 @@ import (
 	"database/sql"
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
+	"github.com/robfig/cron/v3"
 	"murakali/config"
 	"murakali/pkg/logger"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 )
@@ -25,6 +27,7 @@ type Server struct {
 	db          *sql.DB
 	redisClient *redis.Client
 	log         logger.Logger
+	scheduler   *cron.Cron
 }
 
 func NewServer(cfg *config.Config, db *sql.DB, redisClient *redis.Client, log logger.Logger) *Server {
@@ -56,6 +59,9 @@ func (s *Server) Run() error {
 
 	<-quit
 	s.log.Info("Shutdown Server ...")
+	if s.scheduler != nil {
+		s.scheduler.Stop()
+	}
 
 	ctx, shutdown := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
 	defer shutdown()
@@ -70,3 +76,16 @@ func (s *Server) Run() error {
 	s.log.Info("Server Exited Properly")
 	return nil
 }
+
+// trustedProxies splits the TRUSTED_PROXIES value. It returns nil, trusting no
+// proxy at all, when the value is empty.
+func trustedProxies(value string) []string {
+	var proxies []string
+	for _, proxy := range strings.Split(value, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+
+	return proxies
+}
@@ -2,8 +2,8 @@ package util
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
-	"math"
 	"mime/multipart"
 	"murakali/config"
 	"murakali/internal/model"
@@ -98,9 +98,33 @@ func SKUGenerator(productName string) string {
 	return productName + "-" + string(buffer)
 }
 
+// invoiceFlake is shared, a generator per invoice would hand out the same
+// id to invoices created within the same tick.
+var invoiceFlake = newInvoiceFlake()
+
+// newInvoiceFlake derives the machine id from the private IP address and
+// falls back to a random one on hosts that have none.
+func newInvoiceFlake() *sonyflake.Sonyflake {
+	if flake := sonyflake.NewSonyflake(sonyflake.Settings{}); flake != nil {
+		return flake
+	}
+
+	return sonyflake.NewSonyflake(sonyflake.Settings{MachineID: func() (uint16, error) {
+		var buffer [2]byte
+		if _, err := rand.Read(buffer[:]); err != nil {
+			return 0, err
+		}
+
+		return uint16(buffer[0])<<8 | uint16(buffer[1]), nil
+	}})
+}
+
 func GenerateInvoice() (string, error) {
-	flake := sonyflake.NewSonyflake(sonyflake.Settings{})
-	id, err := flake.NextID()
+	if invoiceFlake == nil {
+		return "", errors.New("invoice id generator is not available")
+	}
+
+	id, err := invoiceFlake.NextID()
 	if err != nil {
 		return "", err
 	}
@@ -109,46 +133,43 @@ func GenerateInvoice() (string, error) {
 	return invoice, nil
 }
 
-func CalculateDiscount(price float64, disc *model.Discount) (float64, float64) {
-	if disc.MaxDiscountPrice == nil {
-		return 0, price
-	}
-
-	var maxDiscountPrice float64
-	var minProductPrice float64
-	var discountPercentage float64
-	var discountFixPrice float64
-
-	var resultDiscount float64
-	tempPrice := price
-
-	maxDiscountPrice = *disc.MaxDiscountPrice
-	if maxDiscountPrice == 0 {
+// CalculateDiscount returns the discount applied to price and the price after it.
+// A percentage discount is rounded down to a whole rupiah, and the discount never
+// exceeds the max discount or the price itself.
+func CalculateDiscount(price model.Money, disc *model.Discount) (model.Money, model.Money) {
+	if disc.MaxDiscountPrice == nil || *disc.MaxDiscountPrice == 0 {
 		return 0, price
 	}
+	maxDiscountPrice := *disc.MaxDiscountPrice
 
+	var minProductPrice model.Money
 	if disc.MinProductPrice != nil {
 		minProductPrice = *disc.MinProductPrice
 	}
 
-	if disc.DiscountPercentage != nil {
-		discountPercentage = *disc.DiscountPercentage
-		if price >= minProductPrice && discountPercentage > 0 {
-			resultDiscount = math.Min(maxDiscountPrice, price*(discountPercentage/100.00))
-		}
+	var resultDiscount model.Money
+	if disc.DiscountPercentage != nil && price >= minProductPrice && *disc.DiscountPercentage > 0 {
+		resultDiscount = minMoney(maxDiscountPrice, price.Percent(*disc.DiscountPercentage))
 	}
 
-	if disc.DiscountFixPrice != nil {
-		discountFixPrice = *disc.DiscountFixPrice
-		if price >= minProductPrice && discountFixPrice > 0 {
-			resultDiscount = math.Max(resultDiscount, discountFixPrice)
-			resultDiscount = math.Min(resultDiscount, maxDiscountPrice)
+	if disc.DiscountFixPrice != nil && price >= minProductPrice && *disc.DiscountFixPrice > 0 {
+		if *disc.DiscountFixPrice > resultDiscount {
+			resultDiscount = *disc.DiscountFixPrice
 		}
+		resultDiscount = minMoney(resultDiscount, maxDiscountPrice)
+	}
+
+	if resultDiscount >= price {
+		return price, 0
 	}
-	price -= resultDiscount
-	if price <= 0 {
-		price = 0
-		resultDiscount = tempPrice
+
+	return resultDiscount, price - resultDiscount
+}
+
+func minMoney(a, b model.Money) model.Money {
+	if a < b {
+		return a
 	}
-	return resultDiscount, price
+
+	return b
 }
@@ -0,0 +1,137 @@
+package util
+
+import (
+	"murakali/internal/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateDiscount(t *testing.T) {
+	percentage := 15.0
+	fixPrice := model.NewMoney(2000)
+	minProductPrice := model.NewMoney(10000)
+	maxDiscountPrice := model.NewMoney(5000)
+	smallMaxDiscount := model.NewMoney(1000)
+
+	testCase := []struct {
+		name             string
+		price            model.Money
+		discount         *model.Discount
+		expectedDiscount model.Money
+		expectedPrice    model.Money
+	}{
+		{
+			name:  "percentage rounds down to a whole rupiah",
+			price: model.NewMoneyFromFloat(10333.33),
+			discount: &model.Discount{
+				DiscountPercentage: &percentage,
+				MaxDiscountPrice:   &maxDiscountPrice,
+			},
+			expectedDiscount: model.NewMoney(1549),
+			expectedPrice:    model.NewMoneyFromFloat(8784.33),
+		},
+		{
+			name:  "fix price wins over smaller percentage",
+			price: model.NewMoney(10000),
+			discount: &model.Discount{
+				DiscountPercentage: &percentage,
+				DiscountFixPrice:   &fixPrice,
+				MaxDiscountPrice:   &maxDiscountPrice,
+			},
+			expectedDiscount: model.NewMoney(2000),
+			expectedPrice:    model.NewMoney(8000),
+		},
+		{
+			name:  "capped at max discount",
+			price: model.NewMoney(10000),
+			discount: &model.Discount{
+				DiscountPercentage: &percentage,
+				MaxDiscountPrice:   &smallMaxDiscount,
+			},
+			expectedDiscount: model.NewMoney(1000),
+			expectedPrice:    model.NewMoney(9000),
+		},
+		{
+			name:  "below min product price",
+			price: model.NewMoney(9999),
+			discount: &model.Discount{
+				DiscountFixPrice: &fixPrice,
+				MinProductPrice:  &minProductPrice,
+				MaxDiscountPrice: &maxDiscountPrice,
+			},
+			expectedDiscount: 0,
+			expectedPrice:    model.NewMoney(9999),
+		},
+		{
+			name:  "never below zero",
+			price: model.NewMoney(1500),
+			discount: &model.Discount{
+				DiscountFixPrice: &fixPrice,
+				MaxDiscountPrice: &maxDiscountPrice,
+			},
+			expectedDiscount: model.NewMoney(1500),
+			expectedPrice:    0,
+		},
+		{
+			name:             "without max discount",
+			price:            model.NewMoney(10000),
+			discount:         &model.Discount{DiscountFixPrice: &fixPrice},
+			expectedDiscount: 0,
+			expectedPrice:    model.NewMoney(10000),
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			discount, price := CalculateDiscount(tc.price, tc.discount)
+
+			assert.Equal(t, tc.expectedDiscount, discount)
+			assert.Equal(t, tc.expectedPrice, price)
+		})
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	testCase := []struct {
+		name     string
+		data     string
+		expected model.Money
+		encoded  string
+	}{
+		{name: "whole rupiah", data: `15000`, expected: 1500000, encoded: `15000`},
+		{name: "one decimal", data: `15000.5`, expected: 1500050, encoded: `15000.5`},
+		{name: "rounds half away from zero", data: `0.005`, expected: 1, encoded: `0.01`},
+		{name: "quoted", data: `"100.25"`, expected: 10025, encoded: `100.25`},
+		{name: "negative", data: `-0.75`, expected: -75, encoded: `-0.75`},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			var m model.Money
+			err := m.UnmarshalJSON([]byte(tc.data))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, m)
+
+			encoded, _ := m.MarshalJSON()
+			assert.Equal(t, tc.encoded, string(encoded))
+		})
+	}
+}
+
+func TestMoneyRupiah(t *testing.T) {
+	assert.Equal(t, int64(100), model.Money(10049).Rupiah())
+	assert.Equal(t, int64(101), model.Money(10050).Rupiah())
+	assert.Equal(t, int64(-101), model.Money(-10050).Rupiah())
+}
+
+func TestGenerateInvoice(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		invoice, err := GenerateInvoice()
+		assert.NoError(t, err)
+		assert.False(t, seen[invoice])
+		seen[invoice] = true
+	}
+}
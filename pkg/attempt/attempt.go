@@ -0,0 +1,192 @@
+package attempt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+// Policy limits the failed attempts of one flow. A key that fails MaxAttempts
+// times within Window is locked out for Lockout, and every further lockout
+// within Decay doubles the previous one up to MaxLockout.
+type Policy struct {
+	Name          string
+	MaxAttempts   int
+	MaxIPAttempts int
+	Window        time.Duration
+	Lockout       time.Duration
+	MaxLockout    time.Duration
+	Decay         time.Duration
+}
+
+// Key is what failures are counted against, either an account or a client IP.
+type Key struct {
+	kind string
+	id   string
+}
+
+func Account(id string) Key {
+	return Key{kind: "account", id: id}
+}
+
+func IP(ip string) Key {
+	return Key{kind: "ip", id: ip}
+}
+
+func (k Key) String() string {
+	return k.kind + ":" + k.id
+}
+
+func (k Key) maxAttempts(policy Policy) int {
+	if k.kind == "ip" && policy.MaxIPAttempts > 0 {
+		return policy.MaxIPAttempts
+	}
+
+	return policy.MaxAttempts
+}
+
+// LockedError is returned while a key is locked out.
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("too many attempts, retry after %s", e.RetryAfter)
+}
+
+// RetryAfterSeconds is the value of the Retry-After header, rounded up so a
+// client never retries while still locked out.
+func (e *LockedError) RetryAfterSeconds() int {
+	seconds := int((e.RetryAfter + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+
+	return seconds
+}
+
+type Limiter interface {
+	// Check returns a *LockedError when any of the keys is locked out.
+	Check(ctx context.Context, policy Policy, keys ...Key) error
+	// Fail records a failed attempt for every key and returns a *LockedError
+	// when that locks one of them out.
+	Fail(ctx context.Context, policy Policy, keys ...Key) error
+	// Reset forgets the failures and lockouts of the keys after a success.
+	Reset(ctx context.Context, policy Policy, keys ...Key) error
+}
+
+// failScript records a failure in a sorted set scored by time, dropping the
+// ones that left the window. Reaching the limit clears the set, bumps the
+// strike counter and sets a lock key that expires with the lockout. It
+// returns the lockout in milliseconds, or 0 when the key is not locked out.
+var failScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+redis.call("ZADD", KEYS[1], now, ARGV[3])
+redis.call("PEXPIRE", KEYS[1], window)
+if redis.call("ZCARD", KEYS[1]) < tonumber(ARGV[4]) then
+	return 0
+end
+local strikes = redis.call("INCR", KEYS[3])
+redis.call("PEXPIRE", KEYS[3], ARGV[7])
+local lockout = tonumber(ARGV[5])
+local maxLockout = tonumber(ARGV[6])
+for i = 2, strikes do
+	lockout = lockout * 2
+	if lockout >= maxLockout then
+		break
+	end
+end
+if lockout > maxLockout then
+	lockout = maxLockout
+end
+redis.call("SET", KEYS[2], strikes, "PX", lockout)
+redis.call("DEL", KEYS[1])
+return lockout
+`)
+
+type redisLimiter struct {
+	client *redis.Client
+}
+
+// NewRedisLimiter keeps the failures of a key in an attempt:<policy>:<key>
+// sorted set, its lockout in an attempt_lock:<policy>:<key> key and the
+// number of lockouts in an attempt_strike:<policy>:<key> counter.
+func NewRedisLimiter(client *redis.Client) Limiter {
+	return &redisLimiter{client: client}
+}
+
+func failuresKey(policy Policy, key Key) string {
+	return fmt.Sprintf("attempt:%s:%s", policy.Name, key)
+}
+
+func lockKey(policy Policy, key Key) string {
+	return fmt.Sprintf("attempt_lock:%s:%s", policy.Name, key)
+}
+
+func strikeKey(policy Policy, key Key) string {
+	return fmt.Sprintf("attempt_strike:%s:%s", policy.Name, key)
+}
+
+func (l *redisLimiter) Check(ctx context.Context, policy Policy, keys ...Key) error {
+	cmds := make([]*redis.DurationCmd, len(keys))
+	_, err := l.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.PTTL(ctx, lockKey(policy, key))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var retryAfter time.Duration
+	for _, cmd := range cmds {
+		if ttl := cmd.Val(); ttl > retryAfter {
+			retryAfter = ttl
+		}
+	}
+
+	if retryAfter > 0 {
+		return &LockedError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+func (l *redisLimiter) Fail(ctx context.Context, policy Policy, keys ...Key) error {
+	now := time.Now().UnixMilli()
+	var retryAfter time.Duration
+	for _, key := range keys {
+		lockout, err := failScript.Run(ctx, l.client,
+			[]string{failuresKey(policy, key), lockKey(policy, key), strikeKey(policy, key)},
+			now, policy.Window.Milliseconds(), uuid.NewString(), key.maxAttempts(policy),
+			policy.Lockout.Milliseconds(), policy.MaxLockout.Milliseconds(), policy.Decay.Milliseconds()).Int64()
+		if err != nil {
+			return err
+		}
+
+		if d := time.Duration(lockout) * time.Millisecond; d > retryAfter {
+			retryAfter = d
+		}
+	}
+
+	if retryAfter > 0 {
+		return &LockedError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+func (l *redisLimiter) Reset(ctx context.Context, policy Policy, keys ...Key) error {
+	names := make([]string, 0, len(keys)*3)
+	for _, key := range keys {
+		names = append(names, failuresKey(policy, key), lockKey(policy, key), strikeKey(policy, key))
+	}
+
+	return l.client.Del(ctx, names...).Err()
+}
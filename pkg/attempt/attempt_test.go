@@ -0,0 +1,38 @@
+package attempt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockedError_RetryAfterSeconds(t *testing.T) {
+	testCase := []struct {
+		name       string
+		retryAfter time.Duration
+		expected   int
+	}{
+		{name: "whole seconds", retryAfter: time.Minute, expected: 60},
+		{name: "rounds up", retryAfter: 1500 * time.Millisecond, expected: 2},
+		{name: "at least one second", retryAfter: time.Millisecond, expected: 1},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			err := &LockedError{RetryAfter: tc.retryAfter}
+			assert.Equal(t, tc.expected, err.RetryAfterSeconds())
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	policy := Policy{Name: "login", MaxAttempts: 5, MaxIPAttempts: 30}
+
+	assert.Equal(t, "account:a@test.com", Account("a@test.com").String())
+	assert.Equal(t, "ip:10.0.0.1", IP("10.0.0.1").String())
+	assert.Equal(t, 5, Account("a@test.com").maxAttempts(policy))
+	assert.Equal(t, 30, IP("10.0.0.1").maxAttempts(policy))
+	assert.Equal(t, 5, IP("10.0.0.1").maxAttempts(Policy{MaxAttempts: 5}))
+	assert.Equal(t, "attempt_lock:login:ip:10.0.0.1", lockKey(policy, IP("10.0.0.1")))
+}
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	attempt "murakali/pkg/attempt"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Limiter is an autogenerated mock type for the Limiter type
+type Limiter struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: ctx, policy, keys
+func (_m *Limiter) Check(ctx context.Context, policy attempt.Policy, keys ...attempt.Key) error {
+	_va := make([]interface{}, len(keys))
+	for _i := range keys {
+		_va[_i] = keys[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, policy)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, attempt.Policy, ...attempt.Key) error); ok {
+		r0 = rf(ctx, policy, keys...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Fail provides a mock function with given fields: ctx, policy, keys
+func (_m *Limiter) Fail(ctx context.Context, policy attempt.Policy, keys ...attempt.Key) error {
+	_va := make([]interface{}, len(keys))
+	for _i := range keys {
+		_va[_i] = keys[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, policy)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, attempt.Policy, ...attempt.Key) error); ok {
+		r0 = rf(ctx, policy, keys...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reset provides a mock function with given fields: ctx, policy, keys
+func (_m *Limiter) Reset(ctx context.Context, policy attempt.Policy, keys ...attempt.Key) error {
+	_va := make([]interface{}, len(keys))
+	for _i := range keys {
+		_va[_i] = keys[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, policy)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, attempt.Policy, ...attempt.Key) error); ok {
+		r0 = rf(ctx, policy, keys...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewLimiter interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLimiter creates a new instance of Limiter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLimiter(t mockConstructorTestingTNewLimiter) *Limiter {
+	mock := &Limiter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
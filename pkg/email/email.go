@@ -1,34 +1,101 @@
 package email
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
 	"murakali/config"
-	"net/smtp"
+	"murakali/pkg/logger"
+	"net/textproto"
+	"strings"
+	"time"
 )
 
-type Email struct {
-	Auth smtp.Auth
-	Addr string
-	From string
+const (
+	MailerSMTP = "smtp"
+	MailerFile = "file"
+	MailerLog  = "log"
+)
+
+var ErrInvalidHeader = errors.New("email header must not contain a line break")
+
+// Message is an email with an HTML body and its plain-text alternative.
+// Template names the template it was rendered from, if any.
+type Message struct {
+	To       string
+	Subject  string
+	Template string
+	HTML     string
+	Text     string
 }
 
-func SendEmail(cfg *config.Config, toEmail, subject, msg string) {
-	email := &Email{
-		Auth: smtp.PlainAuth("", cfg.External.SMTPFrom, cfg.External.SMTPPassword, cfg.External.SMTPHost),
-		Addr: fmt.Sprintf("%s:%v", cfg.External.SMTPHost, cfg.External.SMTPPort),
-		From: cfg.External.SMTPFrom,
+// Mailer delivers a message or returns why it could not.
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewMailer builds the mailer chosen by MAILER, SMTP when it is not set. The
+// file mailer writes to MAILER_DIR.
+func NewMailer(cfg *config.Config, log logger.Logger) (Mailer, error) {
+	switch cfg.External.Mailer {
+	case "", MailerSMTP:
+		return NewSMTPMailer(cfg), nil
+	case MailerFile:
+		return NewFileMailer(cfg.External.MailerDir, cfg.External.SMTPFrom)
+	case MailerLog:
+		return NewLogMailer(log), nil
+	default:
+		return nil, fmt.Errorf("unknown mailer %q", cfg.External.Mailer)
 	}
+}
 
-	from := email.From
-	value := []byte("To: " + toEmail + "\r\n" +
-		"From: " + from + "\r\n" +
-		"Subject: " + subject + "\r\n" +
-		"mime := MIME-version: 1.0;\nContent-Type: text/html; charset=\"UTF-8\";\n\n" +
-		"\r\n" + msg + "\r\n")
+// Bytes encodes msg as a multipart/alternative MIME message, the plain-text
+// part first so clients prefer the HTML one.
+func (m *Message) Bytes(from string, date time.Time) ([]byte, error) {
+	for _, value := range []string{from, m.To, m.Subject} {
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, ErrInvalidHeader
+		}
+	}
 
-	err := smtp.SendMail(email.Addr, email.Auth, from, []string{toEmail}, value)
-	if err != nil {
-		fmt.Println(errors.New(err.Error()))
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", m.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", writer.Boundary())
+
+	for _, part := range []struct{ contentType, body string }{
+		{contentType: "text/plain", body: m.Text},
+		{contentType: "text/html", body: m.HTML},
+	} {
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType + "; charset=UTF-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
 	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
 }
@@ -0,0 +1,100 @@
+package email
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMessage(t *testing.T) {
+	msg, err := NewMessage("a@test.com", "Email Verification!", TemplateVerificationOTP, OTPData{OTP: "<123456>"})
+	require.NoError(t, err)
+
+	assert.Equal(t, TemplateVerificationOTP, msg.Template)
+	assert.Contains(t, msg.HTML, "&lt;123456&gt;")
+	assert.Contains(t, msg.HTML, "<title>Verify your email</title>")
+	assert.Contains(t, msg.Text, "Your verification code is <123456>")
+
+	msg, err = NewMessage("a@test.com", "Reset Password!", TemplateResetPassword, LinkData{Link: "https://murakali.test/verify?code=abc"})
+	require.NoError(t, err)
+	assert.Contains(t, msg.HTML, `href="https://murakali.test/verify?code=abc"`)
+	assert.Contains(t, msg.Text, "https://murakali.test/verify?code=abc")
+
+	msg, err = NewMessage("a@test.com", "New reply on your refund", TemplateRefundReply, RefundReplyData{
+		Name: "budi", OrderID: "order", From: "Toko <b>", Text: "barang rusak", Link: "https://murakali.test/order/order",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, msg.HTML, "Toko &lt;b&gt; replied to the refund of order order")
+	assert.Contains(t, msg.Text, "Hi budi, Toko <b> replied to the refund of order order:")
+
+	_, err = NewMessage("a@test.com", "", "unknown", nil)
+	assert.EqualError(t, err, `unknown email template "unknown"`)
+}
+
+func TestMessage_Bytes(t *testing.T) {
+	msg := &Message{To: "a@test.com", Subject: "Verifikasi akun ✓", HTML: "<p>kode</p>", Text: "kode"}
+	value, err := msg.Bytes("noreply@test.com", time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(value)))
+	require.NoError(t, err)
+	assert.Equal(t, "1.0", parsed.Header.Get("MIME-Version"))
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, msg.Subject, subject)
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	for _, expected := range []struct{ contentType, body string }{
+		{contentType: "text/plain; charset=UTF-8", body: msg.Text},
+		{contentType: "text/html; charset=UTF-8", body: msg.HTML},
+	} {
+		part, err := reader.NextRawPart()
+		require.NoError(t, err)
+		assert.Equal(t, expected.contentType, part.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(quotedprintable.NewReader(part))
+		require.NoError(t, err)
+		assert.Equal(t, expected.body, string(body))
+	}
+
+	_, err = reader.NextPart()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestMessage_BytesRejectsHeaderInjection(t *testing.T) {
+	msg := &Message{To: "a@test.com\r\nBcc: b@test.com", Subject: "hi"}
+	_, err := msg.Bytes("noreply@test.com", time.Now())
+	assert.ErrorIs(t, err, ErrInvalidHeader)
+}
+
+func TestFileMailer_Send(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	mailer, err := NewFileMailer(dir, "noreply@test.com")
+	require.NoError(t, err)
+
+	require.NoError(t, mailer.Send(context.Background(), &Message{To: "a@test.com", Subject: "hi", HTML: "<p>hi</p>", Text: "hi"}))
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.True(t, strings.HasSuffix(files[0].Name(), "-a@test.com.eml"))
+
+	_, err = NewFileMailer("", "noreply@test.com")
+	assert.Error(t, err)
+}
@@ -0,0 +1,56 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"murakali/pkg/logger"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type fileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer writes every message to dir as an .eml file instead of
+// sending it, for local development and tests.
+func NewFileMailer(dir, from string) (Mailer, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("file mailer needs a directory")
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &fileMailer{dir: dir, from: from}, nil
+}
+
+func (m *fileMailer) Send(_ context.Context, msg *Message) error {
+	now := time.Now()
+	value, err := msg.Bytes(m.from, now)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%d-%s.eml", now.UnixNano(), strings.NewReplacer("/", "_", "\\", "_").Replace(msg.To))
+	return os.WriteFile(filepath.Join(m.dir, name), value, 0o600)
+}
+
+type logMailer struct {
+	log logger.Logger
+}
+
+// NewLogMailer logs the plain-text part of every message instead of sending
+// it.
+func NewLogMailer(log logger.Logger) Mailer {
+	return &logMailer{log: log}
+}
+
+func (m *logMailer) Send(_ context.Context, msg *Message) error {
+	m.log.Infof("Mailer, To: %s, Subject: %s\n%s", msg.To, msg.Subject, msg.Text)
+	return nil
+}
@@ -0,0 +1,34 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"murakali/config"
+	"net/smtp"
+	"time"
+)
+
+type smtpMailer struct {
+	auth smtp.Auth
+	addr string
+	from string
+}
+
+// NewSMTPMailer sends through the SMTP server of the SMTP_* settings,
+// authenticating as the sender.
+func NewSMTPMailer(cfg *config.Config) Mailer {
+	return &smtpMailer{
+		auth: smtp.PlainAuth("", cfg.External.SMTPFrom, cfg.External.SMTPPassword, cfg.External.SMTPHost),
+		addr: fmt.Sprintf("%s:%v", cfg.External.SMTPHost, cfg.External.SMTPPort),
+		from: cfg.External.SMTPFrom,
+	}
+}
+
+func (m *smtpMailer) Send(_ context.Context, msg *Message) error {
+	value, err := msg.Bytes(m.from, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{msg.To}, value)
+}
@@ -0,0 +1,92 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+const (
+	TemplateVerificationOTP = "verification_otp"
+	TemplateChangeEmail     = "change_email"
+	TemplateResetPassword   = "reset_password"
+	TemplateOrderStatus     = "order_status"
+	TemplateRefundReply     = "refund_reply"
+)
+
+// OTPData fills TemplateVerificationOTP.
+type OTPData struct {
+	OTP string
+}
+
+// LinkData fills TemplateChangeEmail and TemplateResetPassword.
+type LinkData struct {
+	Link string
+}
+
+// OrderStatusData fills TemplateOrderStatus. Title and Message say what
+// happened to the order from the point of view of the recipient.
+type OrderStatusData struct {
+	Name     string
+	OrderID  string
+	ShopName string
+	Title    string
+	Message  string
+	Link     string
+}
+
+// RefundReplyData fills TemplateRefundReply. From is who replied, the buyer
+// or the shop.
+type RefundReplyData struct {
+	Name    string
+	OrderID string
+	From    string
+	Text    string
+	Link    string
+}
+
+//go:embed templates
+var templateFS embed.FS
+
+type emailTemplate struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+var templates = mustParseTemplates(TemplateVerificationOTP, TemplateChangeEmail, TemplateResetPassword,
+	TemplateOrderStatus, TemplateRefundReply)
+
+// mustParseTemplates pairs templates/<name>.html, rendered inside
+// templates/layout.html, with templates/<name>.txt.
+func mustParseTemplates(names ...string) map[string]*emailTemplate {
+	parsed := make(map[string]*emailTemplate, len(names))
+	for _, name := range names {
+		parsed[name] = &emailTemplate{
+			html: htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/layout.html", "templates/"+name+".html")),
+			text: texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/"+name+".txt")),
+		}
+	}
+
+	return parsed
+}
+
+// NewMessage renders the template called name with data into a message.
+func NewMessage(to, subject, name string, data interface{}) (*Message, error) {
+	t, ok := templates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+
+	var html, text bytes.Buffer
+	if err := t.html.ExecuteTemplate(&html, "layout.html", data); err != nil {
+		return nil, err
+	}
+
+	if err := t.text.Execute(&text, data); err != nil {
+		return nil, err
+	}
+
+	return &Message{To: to, Subject: subject, Template: name, HTML: html.String(), Text: text.String()}, nil
+}
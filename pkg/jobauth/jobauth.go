@@ -0,0 +1,89 @@
+package jobauth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TimestampHeader = "X-Job-Timestamp"
+	NonceHeader     = "X-Job-Nonce"
+	SignatureHeader = "X-Job-Signature"
+)
+
+var (
+	ErrMissingSignature = errors.New("job request is not signed")
+	ErrInvalidSignature = errors.New("job request signature is not valid")
+	ErrExpired          = errors.New("job request timestamp is outside the allowed window")
+)
+
+// Sign returns the hex HMAC-SHA256 of a job request. The signed message binds
+// the method, path, unix timestamp, nonce and a hash of the body together so
+// a captured signature cannot be reused for another job.
+func Sign(secret, method, path, timestamp, nonce string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	message := strings.Join([]string{
+		strings.ToUpper(method),
+		path,
+		timestamp,
+		nonce,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(message))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// SignRequest sets the job headers on req for the given body.
+func SignRequest(req *http.Request, secret string, body []byte, now time.Time) error {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	nonceHex := hex.EncodeToString(nonce)
+
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(NonceHeader, nonceHex)
+	req.Header.Set(SignatureHeader, Sign(secret, req.Method, req.URL.Path, timestamp, nonceHex, body))
+
+	return nil
+}
+
+// Verify checks the job headers of req against body and returns the nonce so
+// the caller can reject replays within the window.
+func Verify(req *http.Request, secret string, body []byte, now time.Time, window time.Duration) (string, error) {
+	timestamp := req.Header.Get(TimestampHeader)
+	nonce := req.Header.Get(NonceHeader)
+	signature := req.Header.Get(SignatureHeader)
+	if secret == "" || timestamp == "" || nonce == "" || signature == "" {
+		return "", ErrMissingSignature
+	}
+
+	expected := Sign(secret, req.Method, req.URL.Path, timestamp, nonce, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return "", ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+
+	signedAt := time.Unix(unix, 0)
+	if now.Sub(signedAt) > window || signedAt.Sub(now) > window {
+		return "", ErrExpired
+	}
+
+	return nonce, nil
+}
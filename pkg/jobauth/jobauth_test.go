@@ -0,0 +1,82 @@
+package jobauth
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2023, 1, 10, 8, 0, 0, 0, time.UTC)
+	window := 5 * time.Minute
+
+	testCase := []struct {
+		name        string
+		mutate      func(req *http.Request)
+		secret      string
+		now         time.Time
+		expectedErr error
+	}{
+		{
+			name:        "success",
+			mutate:      func(req *http.Request) {},
+			secret:      "secret",
+			now:         now,
+			expectedErr: nil,
+		},
+		{
+			name:        "error unsigned",
+			mutate:      func(req *http.Request) { req.Header.Del(SignatureHeader) },
+			secret:      "secret",
+			now:         now,
+			expectedErr: ErrMissingSignature,
+		},
+		{
+			name:        "error secret not configured",
+			mutate:      func(req *http.Request) {},
+			secret:      "",
+			now:         now,
+			expectedErr: ErrMissingSignature,
+		},
+		{
+			name:        "error wrong secret",
+			mutate:      func(req *http.Request) {},
+			secret:      "other",
+			now:         now,
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name:        "error signature for another job",
+			mutate:      func(req *http.Request) { req.URL.Path = "/api/v1/user/rejected-refund" },
+			secret:      "secret",
+			now:         now,
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name:        "error expired",
+			mutate:      func(req *http.Request) {},
+			secret:      "secret",
+			now:         now.Add(window + time.Second),
+			expectedErr: ErrExpired,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "https://murakali.test/api/v1/seller/expired", http.NoBody)
+			err := SignRequest(req, "secret", nil, now)
+			assert.NoError(t, err)
+
+			tc.mutate(req)
+			nonce, err := Verify(req, tc.secret, nil, tc.now, window)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, req.Header.Get(NonceHeader), nonce)
+			}
+		})
+	}
+}
@@ -2,11 +2,10 @@ package jwt
 
 import (
 	"errors"
-	"fmt"
-	"github.com/go-redis/redis/v8"
 	"murakali/config"
 	"murakali/internal/constant"
 	"murakali/internal/model"
+	"murakali/pkg/session"
 	"net/http"
 	"strings"
 	"time"
@@ -14,14 +13,32 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 )
 
+// Every token type is issued for its own audience, and extracting a token
+// requires the audience of the type expected, so a token of one type cannot
+// be passed off as another.
+const (
+	AudienceAccess         = "access"
+	AudienceRefresh        = "refresh"
+	AudienceRegister       = "register"
+	AudienceResetPassword  = "reset_password"
+	AudienceChangePassword = "change_password"
+	AudienceWallet         = "wallet"
+	AudienceTwoFactor      = "two_factor"
+)
+
+// AccessClaims carries the permissions of the role, so a permission granted
+// or taken away applies once the user refreshes the token.
 type AccessClaims struct {
-	ID     string `json:"id"`
-	RoleID int    `json:"role_id"`
+	ID          string   `json:"id"`
+	RoleID      int      `json:"role_id"`
+	Permissions []string `json:"perms"`
+	SessionID   string   `json:"sid"`
 	jwt.RegisteredClaims
 }
 
 type RefreshClaims struct {
-	ID string `json:"id"`
+	ID        string `json:"id"`
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -41,26 +58,41 @@ type ChangePasswordClaims struct {
 	jwt.RegisteredClaims
 }
 
+// TwoFactorClaims is the challenge handed out after the password of an
+// account with 2FA checks out. It has no session, so it cannot be used as an
+// access token.
+type TwoFactorClaims struct {
+	ID string `json:"id"`
+	jwt.RegisteredClaims
+}
+
 type WalletClaims struct {
 	ID    string `json:"id"`
 	Scope string `json:"scope"`
 	jwt.RegisteredClaims
 }
 
-func GenerateJWTAccessToken(userID string, userRole int, cfg *config.Config) (*model.AccessToken, error) {
-	claims := &AccessClaims{
-		ID:     userID,
-		RoleID: userRole,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(cfg.JWT.AccessExpMin) * time.Minute)),
-			Issuer:    cfg.JWT.JwtIssuer,
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+func registeredClaims(cfg *config.Config, audience string, exp time.Duration) jwt.RegisteredClaims {
+	now := time.Now()
+	return jwt.RegisteredClaims{
+		Audience:  jwt.ClaimStrings{audience},
+		ExpiresAt: jwt.NewNumericDate(now.Add(exp)),
+		Issuer:    cfg.JWT.JwtIssuer,
+		IssuedAt:  jwt.NewNumericDate(now),
 	}
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+func GenerateJWTAccessToken(userID string, userRole int, permissions []string, sessionID string, cfg *config.Config,
+	keys *Keyring) (*model.AccessToken, error) {
+	claims := &AccessClaims{
+		ID:               userID,
+		RoleID:           userRole,
+		Permissions:      permissions,
+		SessionID:        sessionID,
+		RegisteredClaims: registeredClaims(cfg, AudienceAccess, time.Duration(cfg.JWT.AccessExpMin)*time.Minute),
+	}
 
-	tokenString, err := token.SignedString([]byte(cfg.JWT.JwtSecretKey))
+	tokenString, err := keys.sign(claims)
 	if err != nil {
 		return nil, err
 	}
@@ -72,19 +104,17 @@ func GenerateJWTAccessToken(userID string, userRole int, cfg *config.Config) (*m
 	return accessToken, nil
 }
 
-func GenerateJWTRefreshToken(userID string, cfg *config.Config) (*model.RefreshToken, error) {
+// GenerateJWTRefreshToken issues a refresh token of a session. tokenID becomes
+// the jti claim, which the session store checks to rotate the token family.
+func GenerateJWTRefreshToken(userID, sessionID, tokenID string, cfg *config.Config, keys *Keyring) (*model.RefreshToken, error) {
 	claims := &RefreshClaims{
-		ID: userID,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(cfg.JWT.RefreshExpMin) * time.Minute)),
-			Issuer:    cfg.JWT.JwtIssuer,
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+		ID:               userID,
+		SessionID:        sessionID,
+		RegisteredClaims: registeredClaims(cfg, AudienceRefresh, time.Duration(cfg.JWT.RefreshExpMin)*time.Minute),
 	}
+	claims.RegisteredClaims.ID = tokenID
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	tokenString, err := token.SignedString([]byte(cfg.JWT.JwtSecretKey))
+	tokenString, err := keys.sign(claims)
 	if err != nil {
 		return nil, err
 	}
@@ -96,143 +126,74 @@ func GenerateJWTRefreshToken(userID string, cfg *config.Config) (*model.RefreshT
 	return refreshToken, nil
 }
 
-func GenerateJWTWalletToken(userID, scope string, cfg *config.Config) (string, error) {
+func GenerateJWTWalletToken(userID, scope string, cfg *config.Config, keys *Keyring) (string, error) {
 	claims := &WalletClaims{
-		ID:    userID,
-		Scope: scope,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(cfg.JWT.RefreshExpMin) * time.Minute)),
-			Issuer:    cfg.JWT.JwtIssuer,
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(cfg.JWT.JwtSecretKey))
-	if err != nil {
-		return "", err
+		ID:               userID,
+		Scope:            scope,
+		RegisteredClaims: registeredClaims(cfg, AudienceWallet, time.Duration(cfg.JWT.RefreshExpMin)*time.Minute),
 	}
 
-	return tokenString, nil
+	return keys.sign(claims)
 }
 
-func GenerateJWTRegisterToken(email string, cfg *config.Config) (string, error) {
-	claims := &RegisterClaims{
-		Email: email,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(cfg.JWT.RefreshExpMin) * time.Minute)),
-			Issuer:    cfg.JWT.JwtIssuer,
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+func GenerateJWTTwoFactorToken(userID string, cfg *config.Config, keys *Keyring) (string, error) {
+	claims := &TwoFactorClaims{
+		ID:               userID,
+		RegisteredClaims: registeredClaims(cfg, AudienceTwoFactor, constant.TwoFactorChallengeExpMin*time.Minute),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	tokenString, err := token.SignedString([]byte(cfg.JWT.JwtSecretKey))
-	if err != nil {
-		return "", err
-	}
-
-	return tokenString, nil
+	return keys.sign(claims)
 }
 
-func GenerateJWTChangePasswordToken(userID string, cfg *config.Config) (string, error) {
-	claims := &RefreshClaims{
-		ID: userID,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(cfg.JWT.RefreshExpMin) * time.Minute)),
-			Issuer:    cfg.JWT.JwtIssuer,
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+func GenerateJWTRegisterToken(email string, cfg *config.Config, keys *Keyring) (string, error) {
+	claims := &RegisterClaims{
+		Email:            email,
+		RegisteredClaims: registeredClaims(cfg, AudienceRegister, time.Duration(cfg.JWT.RefreshExpMin)*time.Minute),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return keys.sign(claims)
+}
 
-	tokenString, err := token.SignedString([]byte(cfg.JWT.JwtSecretKey))
-	if err != nil {
-		return "", err
+func GenerateJWTChangePasswordToken(userID string, cfg *config.Config, keys *Keyring) (string, error) {
+	claims := &ChangePasswordClaims{
+		ID:               userID,
+		RegisteredClaims: registeredClaims(cfg, AudienceChangePassword, time.Duration(cfg.JWT.RefreshExpMin)*time.Minute),
 	}
 
-	return tokenString, nil
+	return keys.sign(claims)
 }
 
-func GenerateJWTResetPasswordToken(email, otp string, cfg *config.Config) (string, error) {
+func GenerateJWTResetPasswordToken(email, otp string, cfg *config.Config, keys *Keyring) (string, error) {
 	claims := &ResetPasswordClaims{
-		Email: email,
-		OTP:   otp,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(cfg.JWT.RefreshExpMin) * time.Minute)),
-			Issuer:    cfg.JWT.JwtIssuer,
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	tokenString, err := token.SignedString([]byte(cfg.JWT.JwtSecretKey))
-	if err != nil {
-		return "", err
+		Email:            email,
+		OTP:              otp,
+		RegisteredClaims: registeredClaims(cfg, AudienceResetPassword, time.Duration(cfg.JWT.RefreshExpMin)*time.Minute),
 	}
 
-	return tokenString, nil
+	return keys.sign(claims)
 }
 
-func ExtractJWT(tokenString, jwtKey string) (map[string]interface{}, error) {
-	claims := jwt.MapClaims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(jwtKey), nil
-	})
-
-	if err != nil {
-		if errors.Is(err, jwt.ErrSignatureInvalid) {
-			return nil, errors.New("invalid token signature")
-		}
-		return nil, err
-	}
-
-	if !token.Valid {
-		return nil, errors.New("invalid token ")
-	}
-
-	return claims, nil
+// ExtractJWT verifies tokenString and returns its claims when it was issued
+// for audience.
+func ExtractJWT(tokenString string, keys *Keyring, audience string) (map[string]interface{}, error) {
+	return keys.parse(tokenString, audience)
 }
 
-func ExtractJWTFromRequest(r *http.Request, redisClient *redis.Client, jwtKey string) (map[string]interface{}, error) {
-	tokenString := ExtractBearerToken(r)
-
-	claims := jwt.MapClaims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(jwtKey), nil
-	})
-
+// ExtractJWTFromRequest verifies the bearer access token of r and that its
+// session is still active.
+func ExtractJWTFromRequest(r *http.Request, sessions session.Store, keys *Keyring) (map[string]interface{}, error) {
+	claims, err := keys.parse(ExtractBearerToken(r), AudienceAccess)
 	if err != nil {
-		if errors.Is(err, jwt.ErrSignatureInvalid) {
-			return nil, errors.New("invalid token signature")
-		}
 		return nil, err
 	}
 
-	if !token.Valid {
-		return nil, errors.New("invalid token")
-	}
-
-	res := redisClient.Get(r.Context(), fmt.Sprintf("session:%s:%s", claims["id"].(string), tokenString))
-	if res.Err() != nil {
-		return nil, errors.New("invalid session")
-	}
-
-	value, err := res.Result()
-	if err != nil {
+	userID, _ := claims["id"].(string)
+	sessionID, _ := claims["sid"].(string)
+	if userID == "" || sessionID == "" {
 		return nil, errors.New("invalid session")
 	}
 
-	if value != constant.TRUE {
+	if err := sessions.Touch(r.Context(), userID, sessionID); err != nil {
 		return nil, errors.New("invalid session")
 	}
 
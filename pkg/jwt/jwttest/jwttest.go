@@ -0,0 +1,21 @@
+// Package jwttest provides keyrings for tests that issue or check tokens.
+package jwttest
+
+import "murakali/pkg/jwt"
+
+// NewKeyring returns a keyring with one fresh EdDSA key whose id is also the
+// current key id. It panics on failure so it can set up package level test
+// variables.
+func NewKeyring(id string) *jwt.Keyring {
+	key, err := jwt.GenerateKey(id, jwt.AlgEdDSA)
+	if err != nil {
+		panic(err)
+	}
+
+	keys, err := jwt.NewKeyring(id, key)
+	if err != nil {
+		panic(err)
+	}
+
+	return keys
+}
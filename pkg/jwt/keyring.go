@@ -0,0 +1,285 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+
+	rsaKeyBits = 2048
+	keyFileExt = ".pem"
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// Key is one key of a Keyring. A retired key may only hold its public half,
+// which is still enough to verify the tokens it signed.
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	private crypto.PrivateKey
+	public  crypto.PublicKey
+}
+
+// GenerateKey creates a new private key for alg, either RS256 or EdDSA.
+func GenerateKey(id, alg string) (*Key, error) {
+	switch alg {
+	case AlgRS256:
+		private, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Key{ID: id, Method: jwt.SigningMethodRS256, private: private, public: &private.PublicKey}, nil
+	case AlgEdDSA:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Key{ID: id, Method: jwt.SigningMethodEdDSA, private: private, public: public}, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+}
+
+// ParseKey reads a PEM encoded RSA or Ed25519 key. Private keys may be PKCS #1
+// or PKCS #8, public keys PKIX.
+func ParseKey(id string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %s: no PEM data", id)
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %s: unsupported PEM type %q", id, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", id, err)
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		return &Key{ID: id, Method: jwt.SigningMethodRS256, private: k, public: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		return &Key{ID: id, Method: jwt.SigningMethodRS256, public: k}, nil
+	case ed25519.PrivateKey:
+		return &Key{ID: id, Method: jwt.SigningMethodEdDSA, private: k, public: k.Public()}, nil
+	case ed25519.PublicKey:
+		return &Key{ID: id, Method: jwt.SigningMethodEdDSA, public: k}, nil
+	default:
+		return nil, fmt.Errorf("key %s: unsupported key type %T", id, parsed)
+	}
+}
+
+// CanSign reports whether the key has its private half.
+func (k *Key) CanSign() bool {
+	return k.private != nil
+}
+
+// PrivatePEM encodes the private key as PKCS #8.
+func (k *Key) PrivatePEM() ([]byte, error) {
+	if !k.CanSign() {
+		return nil, fmt.Errorf("key %s has no private key", k.ID)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(k.private)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// Keyring signs tokens with its active key and verifies them with any of its
+// keys, found through the kid header.
+type Keyring struct {
+	active *Key
+	keys   map[string]*Key
+}
+
+// NewKeyring returns a keyring signing with the key activeID.
+func NewKeyring(activeID string, keys ...*Key) (*Keyring, error) {
+	keyring := &Keyring{keys: make(map[string]*Key, len(keys))}
+	for _, key := range keys {
+		if _, ok := keyring.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id %q", key.ID)
+		}
+		keyring.keys[key.ID] = key
+	}
+
+	active, ok := keyring.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("active key %q: %w", activeID, ErrUnknownKey)
+	}
+
+	if !active.CanSign() {
+		return nil, fmt.Errorf("active key %q has no private key", activeID)
+	}
+
+	keyring.active = active
+	return keyring, nil
+}
+
+// LoadKeyring reads every <kid>.pem file in dir. Without activeID the last
+// private key by name signs, so ids that sort by creation time, such as the
+// ones cmd/jwtkey writes, rotate by adding a file.
+//
+// To rotate, add the new key and make it active, then keep the old file until
+// the longest lived token it signed has expired. The old file can be replaced
+// by its public key alone.
+func LoadKeyring(dir, activeID string) (*Keyring, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+keyFileExt))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(paths)
+	keys := make([]*Key, 0, len(paths))
+	for _, path := range paths {
+		data, errRead := os.ReadFile(filepath.Clean(path))
+		if errRead != nil {
+			return nil, errRead
+		}
+
+		key, errParse := ParseKey(strings.TrimSuffix(filepath.Base(path), keyFileExt), data)
+		if errParse != nil {
+			return nil, errParse
+		}
+
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no %s keys in %s", keyFileExt, dir)
+	}
+
+	if activeID == "" {
+		for i := len(keys) - 1; i >= 0; i-- {
+			if keys[i].CanSign() {
+				activeID = keys[i].ID
+				break
+			}
+		}
+	}
+
+	return NewKeyring(activeID, keys...)
+}
+
+// ActiveKeyID is the kid of the tokens signed now.
+func (k *Keyring) ActiveKeyID() string {
+	return k.active.ID
+}
+
+func (k *Keyring) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.active.Method, claims)
+	token.Header["kid"] = k.active.ID
+	return token.SignedString(k.active.private)
+}
+
+// parse verifies the signature of tokenString against the key of its kid and
+// checks that the token was issued for audience.
+func (k *Keyring) parse(tokenString, audience string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{AlgRS256, AlgEdDSA}))
+	token, err := parser.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := k.keys[kid]
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return key.public, nil
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrSignatureInvalid) {
+			return nil, errors.New("invalid token signature")
+		}
+		return nil, err
+	}
+
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	if !claims.VerifyAudience(audience, true) {
+		return nil, errors.New("invalid token audience")
+	}
+
+	return claims, nil
+}
+
+// JWK is a public key in the JSON Web Key format of RFC 7517.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS publishes the public half of every key, so other services can verify
+// tokens, including the ones signed by a retired key.
+func (k *Keyring) JWKS() JWKS {
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	jwks := JWKS{Keys: make([]JWK, 0, len(ids))}
+	for _, id := range ids {
+		key := k.keys[id]
+		jwk := JWK{Kid: key.ID, Use: "sig", Alg: key.Method.Alg()}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
@@ -0,0 +1,135 @@
+package jwt
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newKey(t *testing.T, id, alg string) *Key {
+	key, err := GenerateKey(id, alg)
+	require.NoError(t, err)
+	return key
+}
+
+func writeKey(t *testing.T, dir string, key *Key) {
+	data, err := key.PrivatePEM()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, key.ID+keyFileExt), data, 0o600))
+}
+
+func TestKeyring_SignParse(t *testing.T) {
+	for _, alg := range []string{AlgRS256, AlgEdDSA} {
+		t.Run(alg, func(t *testing.T) {
+			keys, err := NewKeyring("a", newKey(t, "a", alg))
+			require.NoError(t, err)
+
+			tokenString, err := keys.sign(&RegisterClaims{Email: "a@test.com", RegisteredClaims: jwt.RegisteredClaims{Audience: []string{AudienceRegister}}})
+			require.NoError(t, err)
+
+			claims, err := keys.parse(tokenString, AudienceRegister)
+			require.NoError(t, err)
+			assert.Equal(t, "a@test.com", claims["email"])
+
+			_, err = keys.parse(tokenString, AudienceAccess)
+			assert.EqualError(t, err, "invalid token audience")
+		})
+	}
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	oldKey, newKeyPair := newKey(t, "old", AlgEdDSA), newKey(t, "new", AlgRS256)
+	oldKeys, err := NewKeyring("old", oldKey)
+	require.NoError(t, err)
+
+	tokenString, err := oldKeys.sign(&AccessClaims{ID: "user", RegisteredClaims: jwt.RegisteredClaims{Audience: []string{AudienceAccess}}})
+	require.NoError(t, err)
+
+	rotated, err := NewKeyring("new", oldKey, newKeyPair)
+	require.NoError(t, err)
+	assert.Equal(t, "new", rotated.ActiveKeyID())
+
+	claims, err := rotated.parse(tokenString, AudienceAccess)
+	require.NoError(t, err)
+	assert.Equal(t, "user", claims["id"])
+
+	unrelated, err := NewKeyring("new", newKeyPair)
+	require.NoError(t, err)
+	_, err = unrelated.parse(tokenString, AudienceAccess)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestKeyring_RejectsHMAC(t *testing.T) {
+	keys, err := NewKeyring("a", newKey(t, "a", AlgEdDSA))
+	require.NoError(t, err)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Audience: []string{AudienceAccess}}})
+	token.Header["kid"] = "a"
+	tokenString, err := token.SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	_, err = keys.parse(tokenString, AudienceAccess)
+	assert.Error(t, err)
+}
+
+func TestNewKeyring(t *testing.T) {
+	key := newKey(t, "a", AlgEdDSA)
+	public, err := ParseKey("b", mustPublicPEM(t, key))
+	require.NoError(t, err)
+
+	_, err = NewKeyring("missing", key)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	_, err = NewKeyring("a", key, key)
+	assert.EqualError(t, err, `duplicate key id "a"`)
+
+	_, err = NewKeyring("b", key, public)
+	assert.EqualError(t, err, `active key "b" has no private key`)
+}
+
+func TestLoadKeyring(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, newKey(t, "20230101T000000Z", AlgEdDSA))
+	writeKey(t, dir, newKey(t, "20230201T000000Z", AlgRS256))
+
+	keys, err := LoadKeyring(dir, "")
+	require.NoError(t, err)
+	assert.Equal(t, "20230201T000000Z", keys.ActiveKeyID())
+
+	keys, err = LoadKeyring(dir, "20230101T000000Z")
+	require.NoError(t, err)
+	assert.Equal(t, "20230101T000000Z", keys.ActiveKeyID())
+
+	_, err = LoadKeyring(t.TempDir(), "")
+	assert.Error(t, err)
+}
+
+func TestKeyring_JWKS(t *testing.T) {
+	keys, err := NewKeyring("b", newKey(t, "b", AlgRS256), newKey(t, "a", AlgEdDSA))
+	require.NoError(t, err)
+
+	jwks := keys.JWKS()
+	require.Len(t, jwks.Keys, 2)
+
+	assert.Equal(t, "a", jwks.Keys[0].Kid)
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Equal(t, "Ed25519", jwks.Keys[0].Crv)
+	assert.NotEmpty(t, jwks.Keys[0].X)
+
+	assert.Equal(t, "b", jwks.Keys[1].Kid)
+	assert.Equal(t, "RSA", jwks.Keys[1].Kty)
+	assert.Equal(t, AlgRS256, jwks.Keys[1].Alg)
+	assert.Equal(t, "AQAB", jwks.Keys[1].E)
+}
+
+func mustPublicPEM(t *testing.T, key *Key) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key.public)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
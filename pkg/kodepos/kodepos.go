@@ -0,0 +1,63 @@
+package kodepos
+
+import (
+	"context"
+	"encoding/json"
+	"murakali/config"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type Client interface {
+	Search(ctx context.Context, province, city, subDistrict string) (*SearchResponse, error)
+}
+
+type SearchResponse struct {
+	Code     int    `json:"code"`
+	Status   bool   `json:"status"`
+	Messages string `json:"messages"`
+	Data     []struct {
+		Province    string `json:"province"`
+		City        string `json:"city"`
+		Subdistrict string `json:"subdistrict"`
+		Urban       string `json:"urban"`
+		Postalcode  string `json:"postalcode"`
+	} `json:"data"`
+}
+
+type client struct {
+	url        string
+	httpClient *http.Client
+}
+
+func NewClient(cfg *config.Config) Client {
+	return &client{
+		url: strings.TrimSuffix(cfg.External.KodePosURL, "/"),
+		httpClient: &http.Client{
+			Timeout: time.Second * 30,
+		},
+	}
+}
+
+func (c *client) Search(ctx context.Context, province, city, subDistrict string) (*SearchResponse, error) {
+	query := url.Values{"q": {strings.Join([]string{province, city, subDistrict}, " ")}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"/search/?"+query.Encode(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var searchResponse SearchResponse
+	if err := json.NewDecoder(res.Body).Decode(&searchResponse); err != nil {
+		return nil, err
+	}
+
+	return &searchResponse, nil
+}
@@ -3,10 +3,7 @@ package postgre
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
-	"murakali/pkg/httperror"
-	"murakali/pkg/response"
 )
 
 type Transaction interface {
@@ -42,13 +39,7 @@ func (tr *TxRepo) WithTransactionReturnData(fn TxFnData) (data interface{}, err
 			}
 			panic(p)
 		} else if err != nil {
-			var e *httperror.Error
-			errors.As(err, &e)
-			if e.Error() != response.ProductQuantityNotAvailable {
-				errTx = tx.Rollback()
-			} else {
-				errTx = tx.Commit()
-			}
+			errTx = tx.Rollback()
 		} else {
 			errTx = tx.Commit()
 		}
@@ -58,7 +49,6 @@ func (tr *TxRepo) WithTransactionReturnData(fn TxFnData) (data interface{}, err
 	}()
 
 	data, err = fn(tx)
-	fmt.Println("here test 1")
 	return data, err
 }
 
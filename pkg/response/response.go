@@ -3,6 +3,7 @@ package response
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 )
 
 const (
@@ -53,7 +54,6 @@ const (
 	SealabsCardNotFound            = "Sealabs pay card not valid."
 	SealabsCardIsDefault           = "Default Sealabs card cannot be deleted."
 	SealabsCardAlreadyExist        = "Sealabs card already exist."
-	WalletIsBlocked                = "Wallet is temporarily blocked, please wait."
 	WalletPinIsInvalid             = "Wallet pin is invalid."
 	WalletBalanceNotEnough         = "Insufficient wallet balance, please top up!"
 	InvalidPaymentMethod           = "Invalid payment method."
@@ -75,6 +75,33 @@ const (
 	OrderHasAcceptedToRefund       = "Order Has Accepted to Refund"
 	OrderRefundHasBeenFinished     = "Order Refund Has Been Finished"
 	InvalidBuyOwnProducts          = "Invalid Buy Own Products."
+	PaymentCallbackInvalid         = "Payment callback signature is not valid."
+	PaymentCallbackExpired         = "Payment callback already expired."
+	PaymentCallbackReplayed        = "Payment callback already received."
+	PaymentCallbackAmountMismatch  = "Payment callback amount does not match transaction."
+	ShippingServiceUnavailable     = "Shipping service is not available for this address."
+	ShippingFeeChanged             = "Shipping fee has changed, please recheck your order."
+	JournalEntryUnbalanced         = "Journal entry debit and credit are not balanced."
+	LedgerAccountWithoutWallet     = "Ledger wallet account has no wallet."
+	JobNotFound                    = "Job not found."
+	JobAlreadyRunning              = "Job is already running."
+	JobSlotTaken                   = "Job already ran in this schedule slot."
+	IdempotencyKeyInvalid          = "Idempotency-Key header is not valid."
+	IdempotencyKeyReused           = "Idempotency-Key was already used with a different request."
+	IdempotencyKeyInProgress       = "A request with this Idempotency-Key is still being processed."
+	VoucherNotActive               = "Voucher is not active."
+	VoucherQuotaExhausted          = "Voucher quota has run out."
+	VoucherUsageLimitReached       = "Voucher usage limit has been reached."
+	VoucherMinPriceNotMet          = "Order price does not reach the voucher minimum price."
+	PromotionQuotaExhausted        = "Promotion quota has run out."
+	SessionNotFound                = "Session not found."
+	TooManyAttemptsMessage         = "Too many attempts, please try again later."
+	TooManyRequestsMessage         = "Too many requests, please slow down."
+	TwoFactorAlreadyEnabled        = "Two-factor authentication is already enabled."
+	TwoFactorNotEnabled            = "Two-factor authentication is not enabled."
+	TwoFactorNotEnrolled           = "Two-factor authentication enrollment not found."
+	TwoFactorCodeRequired          = "Two-factor code is required."
+	TwoFactorCodeInvalid           = "Two-factor code is not valid."
 )
 
 type JSONResponse struct {
@@ -117,3 +144,10 @@ func ErrorResponseData(w http.ResponseWriter, data interface{}, message string,
 
 	returnJSONResponse(w, message, data, code)
 }
+
+// TooManyRequestsResponse rejects a request that may be retried after
+// retryAfter seconds.
+func TooManyRequestsResponse(w http.ResponseWriter, message string, retryAfter int) {
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	returnJSONResponse(w, message, nil, http.StatusTooManyRequests)
+}
@@ -0,0 +1,308 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotFound           = errors.New("session not found")
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+)
+
+// Session is one signed in device. Its id is carried by the access and refresh
+// tokens issued for it, so removing the session invalidates both.
+//
+// A session is also the family of its refresh tokens. Only the token with
+// RefreshTokenID may be exchanged, and exchanging it rotates the id. Seeing an
+// older token again means it was copied, so the whole session is revoked.
+type Session struct {
+	ID             string    `json:"id"`
+	UserID         string    `json:"-"`
+	RefreshTokenID string    `json:"-"`
+	Device         string    `json:"device"`
+	IP             string    `json:"ip"`
+	UserAgent      string    `json:"user_agent"`
+	CreatedAt      time.Time `json:"created_at"`
+	LastSeenAt     time.Time `json:"last_seen_at"`
+}
+
+// Client describes where a sign in comes from.
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+type Store interface {
+	Create(ctx context.Context, userID string, client Client) (*Session, error)
+	Touch(ctx context.Context, userID, sessionID string) error
+	Rotate(ctx context.Context, userID, sessionID, refreshTokenID string) (string, error)
+	List(ctx context.Context, userID string) ([]*Session, error)
+	Revoke(ctx context.Context, userID, sessionID string) error
+	RevokeAll(ctx context.Context, userID string) error
+}
+
+const (
+	fieldRefreshTokenID = "refresh_token_id"
+	fieldDevice         = "device"
+	fieldIP             = "ip"
+	fieldUserAgent      = "user_agent"
+	fieldCreatedAt      = "created_at"
+	fieldLastSeenAt     = "last_seen_at"
+)
+
+// touchScript only updates a session that still exists, so a request racing a
+// revocation can not bring the session back without its expiry.
+var touchScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+redis.call("HSET", KEYS[1], "last_seen_at", ARGV[1])
+return 1
+`)
+
+// rotateScript swaps the refresh token id of a session when the presented id
+// is the current one and revokes the session when it is not. It returns 1 on
+// rotation, 0 for an unknown session and -1 for a reused token.
+var rotateScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+if redis.call("HGET", KEYS[1], "refresh_token_id") ~= ARGV[1] then
+	redis.call("DEL", KEYS[1])
+	redis.call("SREM", KEYS[2], ARGV[3])
+	return -1
+end
+redis.call("HSET", KEYS[1], "refresh_token_id", ARGV[2], "last_seen_at", ARGV[4])
+redis.call("EXPIRE", KEYS[1], ARGV[5])
+redis.call("EXPIRE", KEYS[2], ARGV[5])
+return 1
+`)
+
+// revokeAllScript removes every session in the index together with the index,
+// so a session created meanwhile is either removed or kept in a fresh index.
+var revokeAllScript = redis.NewScript(`
+local ids = redis.call("SMEMBERS", KEYS[1])
+for _, id in ipairs(ids) do
+	redis.call("DEL", ARGV[1] .. id)
+end
+redis.call("DEL", KEYS[1])
+return #ids
+`)
+
+type redisStore struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewRedisStore keeps every session in a session:<userID>:<sessionID> hash and
+// the ids of a user's sessions in a sessions:<userID> set, so listing and
+// revoking never have to scan the keyspace. Sessions expire ttl after their
+// last sign in or refresh.
+func NewRedisStore(client *redis.Client, ttl time.Duration) Store {
+	return &redisStore{client: client, ttl: ttl}
+}
+
+func sessionKey(userID, sessionID string) string {
+	return fmt.Sprintf("session:%s:%s", userID, sessionID)
+}
+
+func indexKey(userID string) string {
+	return fmt.Sprintf("sessions:%s", userID)
+}
+
+func (s *redisStore) Create(ctx context.Context, userID string, client Client) (*Session, error) {
+	now := time.Now()
+	session := &Session{
+		ID:             uuid.NewString(),
+		UserID:         userID,
+		RefreshTokenID: uuid.NewString(),
+		Device:         DeviceName(client.UserAgent),
+		IP:             client.IP,
+		UserAgent:      client.UserAgent,
+		CreatedAt:      now,
+		LastSeenAt:     now,
+	}
+
+	key := sessionKey(userID, session.ID)
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key,
+			fieldRefreshTokenID, session.RefreshTokenID,
+			fieldDevice, session.Device,
+			fieldIP, session.IP,
+			fieldUserAgent, session.UserAgent,
+			fieldCreatedAt, now.Unix(),
+			fieldLastSeenAt, now.Unix())
+		pipe.Expire(ctx, key, s.ttl)
+		pipe.SAdd(ctx, indexKey(userID), session.ID)
+		pipe.Expire(ctx, indexKey(userID), s.ttl)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (s *redisStore) Touch(ctx context.Context, userID, sessionID string) error {
+	touched, err := touchScript.Run(ctx, s.client, []string{sessionKey(userID, sessionID)}, time.Now().Unix()).Int()
+	if err != nil {
+		return err
+	}
+
+	if touched == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *redisStore) Rotate(ctx context.Context, userID, sessionID, refreshTokenID string) (string, error) {
+	nextID := uuid.NewString()
+	keys := []string{sessionKey(userID, sessionID), indexKey(userID)}
+	rotated, err := rotateScript.Run(ctx, s.client, keys,
+		refreshTokenID, nextID, sessionID, time.Now().Unix(), int64(s.ttl/time.Second)).Int()
+	if err != nil {
+		return "", err
+	}
+
+	switch rotated {
+	case 0:
+		return "", ErrNotFound
+	case -1:
+		return "", ErrRefreshTokenReused
+	default:
+		return nextID, nil
+	}
+}
+
+func (s *redisStore) List(ctx context.Context, userID string) ([]*Session, error) {
+	ids, err := s.client.SMembers(ctx, indexKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	cmds := make([]*redis.StringStringMapCmd, len(ids))
+	_, err = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, id := range ids {
+			cmds[i] = pipe.HGetAll(ctx, sessionKey(userID, id))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*Session, 0, len(ids))
+	expired := make([]interface{}, 0)
+	for i, cmd := range cmds {
+		fields := cmd.Val()
+		if len(fields) == 0 {
+			expired = append(expired, ids[i])
+			continue
+		}
+
+		sessions = append(sessions, &Session{
+			ID:         ids[i],
+			UserID:     userID,
+			Device:     fields[fieldDevice],
+			IP:         fields[fieldIP],
+			UserAgent:  fields[fieldUserAgent],
+			CreatedAt:  parseUnix(fields[fieldCreatedAt]),
+			LastSeenAt: parseUnix(fields[fieldLastSeenAt]),
+		})
+	}
+
+	if len(expired) > 0 {
+		if err := s.client.SRem(ctx, indexKey(userID), expired...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return sessions, nil
+}
+
+func (s *redisStore) Revoke(ctx context.Context, userID, sessionID string) error {
+	var del *redis.IntCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		del = pipe.Del(ctx, sessionKey(userID, sessionID))
+		pipe.SRem(ctx, indexKey(userID), sessionID)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if del.Val() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *redisStore) RevokeAll(ctx context.Context, userID string) error {
+	return revokeAllScript.Run(ctx, s.client, []string{indexKey(userID)}, sessionKey(userID, "")).Err()
+}
+
+func parseUnix(value string) time.Time {
+	sec, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.Unix(sec, 0)
+}
+
+var (
+	platforms = []struct{ token, name string }{
+		{"Android", "Android"},
+		{"iPhone", "iPhone"},
+		{"iPad", "iPad"},
+		{"Windows", "Windows"},
+		{"Macintosh", "macOS"},
+		{"Linux", "Linux"},
+	}
+	browsers = []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+	}
+)
+
+// DeviceName gives a short label such as "Chrome on Windows" for a user agent.
+func DeviceName(userAgent string) string {
+	platform, browser := "", ""
+	for _, p := range platforms {
+		if strings.Contains(userAgent, p.token) {
+			platform = p.name
+			break
+		}
+	}
+	for _, b := range browsers {
+		if strings.Contains(userAgent, b.token) {
+			browser = b.name
+			break
+		}
+	}
+
+	switch {
+	case browser != "" && platform != "":
+		return fmt.Sprintf("%s on %s", browser, platform)
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	default:
+		return "Unknown device"
+	}
+}
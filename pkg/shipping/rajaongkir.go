@@ -0,0 +1,153 @@
+package shipping
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"murakali/config"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type rajaOngkirStatus struct {
+	Code        int    `json:"code"`
+	Description string `json:"description"`
+}
+
+type rajaOngkirProvinceResponse struct {
+	RajaOngkir struct {
+		Status  rajaOngkirStatus `json:"status"`
+		Results []Province       `json:"results"`
+	} `json:"rajaongkir"`
+}
+
+type rajaOngkirCityResponse struct {
+	RajaOngkir struct {
+		Status  rajaOngkirStatus `json:"status"`
+		Results []City           `json:"results"`
+	} `json:"rajaongkir"`
+}
+
+type rajaOngkirCostResponse struct {
+	RajaOngkir struct {
+		Status  rajaOngkirStatus `json:"status"`
+		Results []struct {
+			Code  string `json:"code"`
+			Costs []struct {
+				Service     string `json:"service"`
+				Description string `json:"description"`
+				Cost        []struct {
+					Value int    `json:"value"`
+					Etd   string `json:"etd"`
+				} `json:"cost"`
+			} `json:"costs"`
+		} `json:"results"`
+	} `json:"rajaongkir"`
+}
+
+type rajaOngkirProvider struct {
+	url        string
+	key        string
+	httpClient *http.Client
+}
+
+func NewRajaOngkirProvider(cfg *config.Config) Provider {
+	return &rajaOngkirProvider{
+		url: cfg.External.OngkirAPIURL,
+		key: cfg.External.OngkirAPIKey,
+		httpClient: &http.Client{
+			Timeout: time.Second * 30,
+		},
+	}
+}
+
+func (p *rajaOngkirProvider) Name() string {
+	return ProviderRajaOngkir
+}
+
+func (p *rajaOngkirProvider) GetProvinces(ctx context.Context) ([]Province, error) {
+	var res rajaOngkirProvinceResponse
+	if err := p.do(ctx, http.MethodGet, "/province", nil, &res); err != nil {
+		return nil, err
+	}
+
+	if err := checkStatus(res.RajaOngkir.Status); err != nil {
+		return nil, err
+	}
+
+	return res.RajaOngkir.Results, nil
+}
+
+func (p *rajaOngkirProvider) GetCities(ctx context.Context, provinceID int) ([]City, error) {
+	var res rajaOngkirCityResponse
+	if err := p.do(ctx, http.MethodGet, fmt.Sprintf("/city?province=%d", provinceID), nil, &res); err != nil {
+		return nil, err
+	}
+
+	if err := checkStatus(res.RajaOngkir.Status); err != nil {
+		return nil, err
+	}
+
+	return res.RajaOngkir.Results, nil
+}
+
+func (p *rajaOngkirProvider) GetCost(ctx context.Context, origin, destination, weight int, courier string) (*Quote, error) {
+	payload := fmt.Sprintf("origin=%d&destination=%d&weight=%d&courier=%s", origin, destination, weight, courier)
+
+	var res rajaOngkirCostResponse
+	if err := p.do(ctx, http.MethodPost, "/cost", strings.NewReader(payload), &res); err != nil {
+		return nil, err
+	}
+
+	if err := checkStatus(res.RajaOngkir.Status); err != nil {
+		return nil, err
+	}
+
+	quote := &Quote{Courier: courier, Services: make([]Service, 0)}
+	for _, result := range res.RajaOngkir.Results {
+		for _, cost := range result.Costs {
+			if len(cost.Cost) == 0 {
+				continue
+			}
+
+			quote.Services = append(quote.Services, Service{
+				Service:     cost.Service,
+				Description: cost.Description,
+				Fee:         cost.Cost[0].Value,
+				ETD:         cost.Cost[0].Etd,
+			})
+		}
+	}
+
+	return quote, nil
+}
+
+func (p *rajaOngkirProvider) do(ctx context.Context, method, path string, body io.Reader, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, p.url+path, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("key", p.key)
+	if body != nil {
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+func checkStatus(status rajaOngkirStatus) error {
+	if status.Code != http.StatusOK {
+		return fmt.Errorf("rajaongkir: %d %s", status.Code, status.Description)
+	}
+
+	return nil
+}
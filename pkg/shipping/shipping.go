@@ -0,0 +1,79 @@
+package shipping
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"murakali/config"
+)
+
+const (
+	ProviderRajaOngkir = "rajaongkir"
+	ProviderTable      = "table"
+)
+
+type Province struct {
+	ProvinceID string `json:"province_id"`
+	Province   string `json:"province"`
+}
+
+type City struct {
+	CityID     string `json:"city_id"`
+	ProvinceID string `json:"province_id"`
+	Type       string `json:"type"`
+	CityName   string `json:"city_name"`
+	PostalCode string `json:"postal_code"`
+}
+
+type Service struct {
+	Service     string `json:"service"`
+	Description string `json:"description"`
+	Fee         int    `json:"fee"`
+	ETD         string `json:"etd"`
+}
+
+// Quote lists the services a courier offers for one route and weight. Fees
+// are whole rupiah.
+type Quote struct {
+	Courier  string    `json:"courier"`
+	Services []Service `json:"services"`
+}
+
+// ServiceCost returns the fee and etd of a courier service.
+func (q *Quote) ServiceCost(service string) (fee int, etd string, ok bool) {
+	for _, s := range q.Services {
+		if s.Service == service {
+			return s.Fee, s.ETD, true
+		}
+	}
+
+	return 0, "", false
+}
+
+// Provider answers the location and shipping rate questions of checkout.
+// Weights are in grams and locations are city and province ids.
+type Provider interface {
+	Name() string
+	GetProvinces(ctx context.Context) ([]Province, error)
+	GetCities(ctx context.Context, provinceID int) ([]City, error)
+	GetCost(ctx context.Context, origin, destination, weight int, courier string) (*Quote, error)
+}
+
+// NewProvider builds the provider chosen by SHIPPING_PROVIDER, RajaOngkir when
+// it is not set.
+func NewProvider(cfg *config.Config, db *sql.DB) (Provider, error) {
+	switch cfg.External.ShippingProvider {
+	case "", ProviderRajaOngkir:
+		return NewRajaOngkirProvider(cfg), nil
+	case ProviderTable:
+		return NewTableProvider(db), nil
+	default:
+		return nil, fmt.Errorf("unknown shipping provider %q", cfg.External.ShippingProvider)
+	}
+}
+
+// CostKey is the cache key of a quote. It carries the provider name so quotes
+// from one provider are never served after switching to another.
+func CostKey(p Provider, origin, destination, weight int, courier string) string {
+	return fmt.Sprintf("%s:%d:%d:%d:%s", p.Name(), origin, destination, weight, courier)
+}
@@ -0,0 +1,107 @@
+package shipping
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"murakali/config"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableProvider_GetCost(t *testing.T) {
+	testCase := []struct {
+		name        string
+		weight      int
+		expectedFee int
+	}{
+		{name: "below one kilogram", weight: 200, expectedFee: 9000},
+		{name: "exactly one kilogram", weight: 1000, expectedFee: 9000},
+		{name: "rounds up to the next kilogram", weight: 2100, expectedFee: 25000},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectQuery(`FROM "shipping_rate"`).WithArgs(327, 153, "jne").
+				WillReturnRows(sqlmock.NewRows([]string{"service", "description", "first_kg_fee", "next_kg_fee", "etd"}).
+					AddRow("REG", "Layanan Reguler", 9000, 8000, "2-3"))
+
+			quote, err := NewTableProvider(db).GetCost(context.Background(), 327, 153, tc.weight, "jne")
+			require.NoError(t, err)
+
+			fee, etd, ok := quote.ServiceCost("REG")
+			assert.True(t, ok)
+			assert.Equal(t, tc.expectedFee, fee)
+			assert.Equal(t, "2-3", etd)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRajaOngkirProvider_GetCost(t *testing.T) {
+	testCase := []struct {
+		name        string
+		response    string
+		expectedFee int
+		expectedErr bool
+	}{
+		{
+			name: "success",
+			response: `{"rajaongkir":{"status":{"code":200,"description":"OK"},"results":[{"code":"jne","costs":[
+				{"service":"OKE","cost":[{"value":38000,"etd":"4-5"}]},{"service":"REG","cost":[{"value":44000,"etd":"2-3"}]}]}]}}`,
+			expectedFee: 44000,
+		},
+		{
+			name:        "error invalid key",
+			response:    `{"rajaongkir":{"status":{"code":400,"description":"Invalid key."}}}`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/cost", r.URL.Path)
+				assert.Equal(t, "key", r.Header.Get("key"))
+				assert.NoError(t, r.ParseForm())
+				assert.Equal(t, "501", r.PostForm.Get("origin"))
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			p := NewRajaOngkirProvider(&config.Config{External: config.ExternalConfig{OngkirAPIURL: server.URL, OngkirAPIKey: "key"}})
+			quote, err := p.GetCost(context.Background(), 501, 114, 1700, "jne")
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			fee, _, ok := quote.ServiceCost("REG")
+			assert.True(t, ok)
+			assert.Equal(t, tc.expectedFee, fee)
+		})
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	p, err := NewProvider(&config.Config{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, ProviderRajaOngkir, p.Name())
+	assert.Equal(t, "rajaongkir:501:114:1700:jne", CostKey(p, 501, 114, 1700, "jne"))
+
+	p, err = NewProvider(&config.Config{External: config.ExternalConfig{ShippingProvider: ProviderTable}}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, ProviderTable, p.Name())
+
+	_, err = NewProvider(&config.Config{External: config.ExternalConfig{ShippingProvider: "unknown"}}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,110 @@
+package shipping
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	getProvincesQuery = `SELECT "id", "name" FROM "shipping_province" ORDER BY "id"`
+	getCitiesQuery    = `SELECT "id", "province_id", "type", "name", "postal_code" FROM "shipping_city"
+		WHERE "province_id" = $1 ORDER BY "name"`
+	getRatesQuery = `SELECT "r"."service", "r"."description", "r"."first_kg_fee", "r"."next_kg_fee", "r"."etd"
+	FROM "shipping_rate" AS "r"
+	INNER JOIN "shipping_city" AS "o" ON "o"."zone" = "r"."origin_zone"
+	INNER JOIN "shipping_city" AS "d" ON "d"."zone" = "r"."destination_zone"
+	WHERE "o"."id" = $1 AND "d"."id" = $2 AND "r"."courier_code" = $3
+	ORDER BY "r"."service"`
+
+	gramsPerKg = 1000
+)
+
+type tableProvider struct {
+	db *sql.DB
+}
+
+// NewTableProvider quotes from the rate cards in the shipping_* tables, so
+// environments without access to RajaOngkir can still check out. Every city
+// belongs to a zone and a rate card prices a courier service between two
+// zones: a fee for the first kilogram and a fee for every kilogram after it.
+func NewTableProvider(db *sql.DB) Provider {
+	return &tableProvider{db: db}
+}
+
+func (p *tableProvider) Name() string {
+	return ProviderTable
+}
+
+func (p *tableProvider) GetProvinces(ctx context.Context) ([]Province, error) {
+	res, err := p.db.QueryContext(ctx, getProvincesQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	provinces := make([]Province, 0)
+	for res.Next() {
+		var province Province
+		if err := res.Scan(&province.ProvinceID, &province.Province); err != nil {
+			return nil, err
+		}
+
+		provinces = append(provinces, province)
+	}
+
+	return provinces, res.Err()
+}
+
+func (p *tableProvider) GetCities(ctx context.Context, provinceID int) ([]City, error) {
+	res, err := p.db.QueryContext(ctx, getCitiesQuery, provinceID)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	cities := make([]City, 0)
+	for res.Next() {
+		var city City
+		if err := res.Scan(&city.CityID, &city.ProvinceID, &city.Type, &city.CityName, &city.PostalCode); err != nil {
+			return nil, err
+		}
+
+		cities = append(cities, city)
+	}
+
+	return cities, res.Err()
+}
+
+func (p *tableProvider) GetCost(ctx context.Context, origin, destination, weight int, courier string) (*Quote, error) {
+	res, err := p.db.QueryContext(ctx, getRatesQuery, origin, destination, courier)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	quote := &Quote{Courier: courier, Services: make([]Service, 0)}
+	for res.Next() {
+		var (
+			service               Service
+			firstKgFee, nextKgFee int
+		)
+		if err := res.Scan(&service.Service, &service.Description, &firstKgFee, &nextKgFee, &service.ETD); err != nil {
+			return nil, err
+		}
+
+		service.Fee = firstKgFee + nextKgFee*(chargeableKg(weight)-1)
+		quote.Services = append(quote.Services, service)
+	}
+
+	return quote, res.Err()
+}
+
+// chargeableKg rounds a weight in grams up to whole kilograms, at least one.
+func chargeableKg(weight int) int {
+	kg := (weight + gramsPerKg - 1) / gramsPerKg
+	if kg < 1 {
+		return 1
+	}
+
+	return kg
+}
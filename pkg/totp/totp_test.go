@@ -0,0 +1,87 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 test key of RFC 6238, "12345678901234567890".
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	// the RFC lists 8 digit codes, these are their last 6 digits
+	testCase := []struct {
+		name     string
+		time     time.Time
+		expected string
+	}{
+		{name: "59", time: time.Unix(59, 0), expected: "287082"},
+		{name: "1111111109", time: time.Unix(1111111109, 0), expected: "081804"},
+		{name: "1234567890", time: time.Unix(1234567890, 0), expected: "005924"},
+		{name: "2000000000", time: time.Unix(2000000000, 0), expected: "279037"},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			code, err := Code(rfcSecret, tc.time)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, code)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	testCase := []struct {
+		name         string
+		code         string
+		expectedStep int64
+		expectedOK   bool
+	}{
+		{name: "current period", code: "081804", expectedStep: Step(now), expectedOK: true},
+		{name: "previous period", code: mustCode(t, now.Add(-Period)), expectedStep: Step(now) - 1, expectedOK: true},
+		{name: "next period", code: mustCode(t, now.Add(Period)), expectedStep: Step(now) + 1, expectedOK: true},
+		{name: "too old", code: mustCode(t, now.Add(-2*Period)), expectedOK: false},
+		{name: "wrong code", code: "000000", expectedOK: false},
+		{name: "wrong length", code: "81804", expectedOK: false},
+	}
+
+	for _, tc := range testCase {
+		t.Run(tc.name, func(t *testing.T) {
+			step, ok := Validate(rfcSecret, tc.code, now)
+			assert.Equal(t, tc.expectedOK, ok)
+			if tc.expectedOK {
+				assert.Equal(t, tc.expectedStep, step)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	_, err = Code(secret, time.Now())
+	assert.NoError(t, err)
+}
+
+func TestURI(t *testing.T) {
+	uri := URI(rfcSecret, "Murakali", "a@test.com")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Murakali:a@test.com?"))
+	assert.Contains(t, uri, "secret="+rfcSecret)
+	assert.Contains(t, uri, "issuer=Murakali")
+	assert.Contains(t, uri, "digits=6")
+	assert.Contains(t, uri, "period=30")
+}
+
+func mustCode(t *testing.T, at time.Time) string {
+	code, err := Code(rfcSecret, at)
+	require.NoError(t, err)
+	return code
+}
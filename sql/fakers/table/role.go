@@ -13,7 +13,8 @@ func NewRoleFaker(name []string) ISeeder {
 }
 
 func (f *RoleFaker) GenerateData(tx postgre.Transaction) error {
-	const InsertRoleQuery = `INSERT INTO "role" (name) VALUES ($1)`
+	// the permission migration already inserts the roles it grants permissions to
+	const InsertRoleQuery = `INSERT INTO "role" (name) SELECT $1::varchar WHERE NOT EXISTS (SELECT 1 FROM "role" WHERE "name" = $1)`
 
 	for _, val := range f.Name {
 		_, err := tx.Exec(InsertRoleQuery, val)
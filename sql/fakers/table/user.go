@@ -18,8 +18,8 @@ const InsertUserQuery = `INSERT INTO "user"
 const InsertUserAddressQuery = `INSERT INTO "address" 
     (user_id, name, province_id, city_id, province, city, district, sub_district, address_detail, zip_code, is_default, is_shop_default)
 VALUES ($1, 'Home', 33, 327, 'Sumatera Selatan', 'Palembang', 'Ilir Timur II', '2 Ilir', 'no 91', '30118', $2, $3)`
-const CreateUserWallet = `INSERT INTO "wallet" (user_id, balance, pin, attempt_count, active_date)
-	VALUES ($1, 0, '$2a$10$haIhdlIHObH0yGMyCx5Zl.s5b7sV/x3GWact0Yd2xREXof3UAzUl6', 0, CURRENT_TIMESTAMP);`
+const CreateUserWallet = `INSERT INTO "wallet" (user_id, balance, pin, active_date)
+	VALUES ($1, 0, '$2a$10$haIhdlIHObH0yGMyCx5Zl.s5b7sV/x3GWact0Yd2xREXof3UAzUl6', CURRENT_TIMESTAMP);`
 const CreateUserSLP = `INSERT INTO "sealabs_pay" (card_number, user_id, name, is_default, active_date, created_at) VALUES ($1, $2, $3, $4, $5, $6)`
 
 type UserFaker struct {
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS "payment_callback"
+(
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "transaction_id" UUID,
+    "callback_type" varchar NOT NULL DEFAULT '',
+    "payload" text NOT NULL DEFAULT '',
+    "signature" varchar NOT NULL DEFAULT '',
+    "nonce" varchar NOT NULL DEFAULT '',
+    "signed_at" timestamptz,
+    "is_accepted" boolean NOT NULL DEFAULT FALSE,
+    "reject_reason" varchar NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX ON "payment_callback" ("transaction_id");
+
+CREATE INDEX ON "payment_callback" ("created_at" DESC);
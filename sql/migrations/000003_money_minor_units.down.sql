@@ -0,0 +1,26 @@
+ALTER TABLE "voucher" ALTER COLUMN "max_discount_price" TYPE float USING "max_discount_price" / 100.0;
+ALTER TABLE "voucher" ALTER COLUMN "min_product_price" TYPE float USING "min_product_price" / 100.0;
+ALTER TABLE "voucher" ALTER COLUMN "discount_fix_price" TYPE int USING ROUND("discount_fix_price" / 100.0)::int;
+
+ALTER TABLE "promotion" ALTER COLUMN "max_discount_price" TYPE float USING "max_discount_price" / 100.0;
+ALTER TABLE "promotion" ALTER COLUMN "min_product_price" TYPE float USING "min_product_price" / 100.0;
+ALTER TABLE "promotion" ALTER COLUMN "discount_fix_price" TYPE int USING ROUND("discount_fix_price" / 100.0)::int;
+
+ALTER TABLE "product" ALTER COLUMN "max_price" TYPE float USING "max_price" / 100.0;
+ALTER TABLE "product" ALTER COLUMN "min_price" TYPE float USING "min_price" / 100.0;
+
+ALTER TABLE "wallet_history" ALTER COLUMN "amount" TYPE float USING "amount" / 100.0;
+
+ALTER TABLE "wallet" ALTER COLUMN "balance" DROP DEFAULT;
+ALTER TABLE "wallet" ALTER COLUMN "balance" TYPE float USING "balance" / 100.0;
+ALTER TABLE "wallet" ALTER COLUMN "balance" SET DEFAULT 0;
+
+ALTER TABLE "transaction" ALTER COLUMN "total_price" TYPE float USING "total_price" / 100.0;
+
+ALTER TABLE "order" ALTER COLUMN "delivery_fee" TYPE float USING "delivery_fee" / 100.0;
+ALTER TABLE "order" ALTER COLUMN "total_price" TYPE float USING "total_price" / 100.0;
+
+ALTER TABLE "order_item" ALTER COLUMN "total_price" TYPE float USING "total_price" / 100.0;
+ALTER TABLE "order_item" ALTER COLUMN "item_price" TYPE float USING "item_price" / 100.0;
+
+ALTER TABLE "product_detail" ALTER COLUMN "price" TYPE float USING "price" / 100.0;
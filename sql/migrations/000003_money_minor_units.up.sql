@@ -0,0 +1,27 @@
+-- money columns hold rupiah in minor units (sen), see model.Money
+ALTER TABLE "product_detail" ALTER COLUMN "price" TYPE bigint USING ROUND("price" * 100)::bigint;
+
+ALTER TABLE "order_item" ALTER COLUMN "item_price" TYPE bigint USING ROUND("item_price" * 100)::bigint;
+ALTER TABLE "order_item" ALTER COLUMN "total_price" TYPE bigint USING ROUND("total_price" * 100)::bigint;
+
+ALTER TABLE "order" ALTER COLUMN "total_price" TYPE bigint USING ROUND("total_price" * 100)::bigint;
+ALTER TABLE "order" ALTER COLUMN "delivery_fee" TYPE bigint USING ROUND("delivery_fee" * 100)::bigint;
+
+ALTER TABLE "transaction" ALTER COLUMN "total_price" TYPE bigint USING ROUND("total_price" * 100)::bigint;
+
+ALTER TABLE "wallet" ALTER COLUMN "balance" DROP DEFAULT;
+ALTER TABLE "wallet" ALTER COLUMN "balance" TYPE bigint USING ROUND("balance" * 100)::bigint;
+ALTER TABLE "wallet" ALTER COLUMN "balance" SET DEFAULT 0;
+
+ALTER TABLE "wallet_history" ALTER COLUMN "amount" TYPE bigint USING ROUND("amount" * 100)::bigint;
+
+ALTER TABLE "product" ALTER COLUMN "min_price" TYPE bigint USING ROUND("min_price" * 100)::bigint;
+ALTER TABLE "product" ALTER COLUMN "max_price" TYPE bigint USING ROUND("max_price" * 100)::bigint;
+
+ALTER TABLE "promotion" ALTER COLUMN "discount_fix_price" TYPE bigint USING "discount_fix_price"::bigint * 100;
+ALTER TABLE "promotion" ALTER COLUMN "min_product_price" TYPE bigint USING ROUND("min_product_price" * 100)::bigint;
+ALTER TABLE "promotion" ALTER COLUMN "max_discount_price" TYPE bigint USING ROUND("max_discount_price" * 100)::bigint;
+
+ALTER TABLE "voucher" ALTER COLUMN "discount_fix_price" TYPE bigint USING "discount_fix_price"::bigint * 100;
+ALTER TABLE "voucher" ALTER COLUMN "min_product_price" TYPE bigint USING ROUND("min_product_price" * 100)::bigint;
+ALTER TABLE "voucher" ALTER COLUMN "max_discount_price" TYPE bigint USING ROUND("max_discount_price" * 100)::bigint;
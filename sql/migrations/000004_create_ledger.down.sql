@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "journal_line" CASCADE;
+
+DROP TABLE IF EXISTS "journal_entry" CASCADE;
+
+DROP TABLE IF EXISTS "ledger_account" CASCADE;
@@ -0,0 +1,85 @@
+CREATE TABLE IF NOT EXISTS "ledger_account"
+(
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "code" varchar UNIQUE NOT NULL,
+    "account_type" varchar NOT NULL,
+    "wallet_id" UUID,
+    "created_at" timestamptz NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE IF NOT EXISTS "journal_entry"
+(
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "transaction_id" UUID,
+    "description" varchar NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE IF NOT EXISTS "journal_line"
+(
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "journal_entry_id" UUID NOT NULL,
+    "account_id" UUID NOT NULL,
+    "debit" bigint NOT NULL DEFAULT 0,
+    "credit" bigint NOT NULL DEFAULT 0,
+    CHECK ("debit" >= 0 AND "credit" >= 0 AND ("debit" = 0) <> ("credit" = 0))
+);
+
+CREATE INDEX ON "ledger_account" ("wallet_id");
+
+CREATE INDEX ON "journal_entry" ("transaction_id");
+
+CREATE INDEX ON "journal_line" ("journal_entry_id");
+
+CREATE INDEX ON "journal_line" ("account_id");
+
+ALTER TABLE "ledger_account"
+    ADD FOREIGN KEY ("wallet_id") REFERENCES "wallet" ("id");
+
+ALTER TABLE "journal_entry"
+    ADD FOREIGN KEY ("transaction_id") REFERENCES "transaction" ("id");
+
+ALTER TABLE "journal_line"
+    ADD FOREIGN KEY ("journal_entry_id") REFERENCES "journal_entry" ("id");
+
+ALTER TABLE "journal_line"
+    ADD FOREIGN KEY ("account_id") REFERENCES "ledger_account" ("id");
+
+-- every existing wallet balance is carried into the ledger as one opening
+-- entry against the opening_balance account, so reconciliation starts clean
+INSERT INTO "ledger_account" ("code", "account_type")
+VALUES ('sealabs_pay', 'sealabs_pay'),
+       ('opening_balance', 'opening_balance');
+
+INSERT INTO "ledger_account" ("code", "account_type", "wallet_id")
+SELECT CASE
+           WHEN "w"."user_id" = '4df967a8-5b05-4d2a-bb72-da3921dce8fb' THEN 'escrow'
+           ELSE 'buyer_wallet:' || "w"."id" END,
+       CASE
+           WHEN "w"."user_id" = '4df967a8-5b05-4d2a-bb72-da3921dce8fb' THEN 'escrow'
+           ELSE 'buyer_wallet' END,
+       "w"."id"
+FROM "wallet" "w";
+
+WITH "entry" AS (
+    INSERT INTO "journal_entry" ("description")
+        VALUES ('Opening balance')
+        RETURNING "id"),
+     "wallet_line" AS (
+         INSERT INTO "journal_line" ("journal_entry_id", "account_id", "debit", "credit")
+             SELECT "entry"."id", "la"."id", GREATEST(-"w"."balance", 0), GREATEST("w"."balance", 0)
+             FROM "entry",
+                  "wallet" "w"
+                      JOIN "ledger_account" "la" ON "la"."wallet_id" = "w"."id"
+             WHERE "w"."balance" <> 0
+             RETURNING "debit", "credit")
+INSERT
+INTO "journal_line" ("journal_entry_id", "account_id", "debit", "credit")
+SELECT "entry"."id",
+       (SELECT "id" FROM "ledger_account" WHERE "code" = 'opening_balance'),
+       GREATEST(SUM("wallet_line"."credit") - SUM("wallet_line"."debit"), 0),
+       GREATEST(SUM("wallet_line"."debit") - SUM("wallet_line"."credit"), 0)
+FROM "entry",
+     "wallet_line"
+GROUP BY "entry"."id"
+HAVING SUM("wallet_line"."credit") <> SUM("wallet_line"."debit");
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS "job_run"
+(
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "job" varchar NOT NULL,
+    "status" varchar NOT NULL,
+    "triggered_by" varchar NOT NULL DEFAULT '',
+    "attempt" int NOT NULL DEFAULT 0,
+    "rows_affected" bigint NOT NULL DEFAULT 0,
+    "error" text NOT NULL DEFAULT '',
+    "started_at" timestamptz NOT NULL DEFAULT (NOW()),
+    "finished_at" timestamptz,
+    "duration_ms" bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX ON "job_run" ("job", "started_at" DESC);
+
+CREATE INDEX ON "job_run" ("started_at" DESC);
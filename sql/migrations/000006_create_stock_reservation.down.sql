@@ -0,0 +1,3 @@
+ALTER TABLE "product_detail" DROP CONSTRAINT IF EXISTS "product_detail_stock_non_negative";
+
+DROP TABLE IF EXISTS "stock_reservation" CASCADE;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS "stock_reservation"
+(
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "order_id" UUID NOT NULL,
+    "product_detail_id" UUID NOT NULL,
+    "quantity" int NOT NULL CHECK ("quantity" > 0),
+    "created_at" timestamptz NOT NULL DEFAULT (NOW()),
+    "released_at" timestamptz
+);
+
+ALTER TABLE "stock_reservation"
+    ADD FOREIGN KEY ("order_id") REFERENCES "order" ("id");
+
+ALTER TABLE "stock_reservation"
+    ADD FOREIGN KEY ("product_detail_id") REFERENCES "product_detail" ("id");
+
+CREATE INDEX ON "stock_reservation" ("order_id") WHERE "released_at" IS NULL;
+
+-- stock is only ever moved by relative updates from here on, so a negative
+-- value can only come from older data
+ALTER TABLE "product_detail"
+    ADD CONSTRAINT "product_detail_stock_non_negative" CHECK ("stock" >= 0) NOT VALID;
+
+-- orders that are still waiting for payment already took their stock, record
+-- it so expiring them gives it back
+INSERT INTO "stock_reservation" ("order_id", "product_detail_id", "quantity")
+SELECT "oi"."order_id", "oi"."product_detail_id", "oi"."quantity"
+FROM "order_item" AS "oi"
+INNER JOIN "order" AS "o" ON "o"."id" = "oi"."order_id"
+INNER JOIN "transaction" AS "t" ON "t"."id" = "o"."transaction_id"
+WHERE "t"."paid_at" IS NULL AND "t"."canceled_at" IS NULL
+  AND "oi"."product_detail_id" IS NOT NULL AND "oi"."quantity" > 0;
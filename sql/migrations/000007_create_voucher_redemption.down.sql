@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "voucher_redemption" CASCADE;
+
+ALTER TABLE "promotion" DROP CONSTRAINT IF EXISTS "promotion_quota_non_negative";
+
+ALTER TABLE "voucher" DROP CONSTRAINT IF EXISTS "voucher_quota_non_negative";
+
+ALTER TABLE "voucher" DROP COLUMN IF EXISTS "limit_per_user";
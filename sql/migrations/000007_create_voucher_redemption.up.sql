@@ -0,0 +1,50 @@
+ALTER TABLE "voucher"
+    ADD COLUMN IF NOT EXISTS "limit_per_user" int NOT NULL DEFAULT 1 CHECK ("limit_per_user" > 0);
+
+ALTER TABLE "voucher"
+    ADD CONSTRAINT "voucher_quota_non_negative" CHECK ("quota" >= 0) NOT VALID;
+
+ALTER TABLE "promotion"
+    ADD CONSTRAINT "promotion_quota_non_negative" CHECK ("quota" >= 0) NOT VALID;
+
+CREATE TABLE IF NOT EXISTS "voucher_redemption"
+(
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "voucher_id" UUID NOT NULL,
+    "user_id" UUID NOT NULL,
+    "transaction_id" UUID NOT NULL,
+    "order_id" UUID,
+    "created_at" timestamptz NOT NULL DEFAULT (NOW()),
+    "released_at" timestamptz
+);
+
+ALTER TABLE "voucher_redemption"
+    ADD FOREIGN KEY ("voucher_id") REFERENCES "voucher" ("id");
+
+ALTER TABLE "voucher_redemption"
+    ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id");
+
+ALTER TABLE "voucher_redemption"
+    ADD FOREIGN KEY ("transaction_id") REFERENCES "transaction" ("id");
+
+ALTER TABLE "voucher_redemption"
+    ADD FOREIGN KEY ("order_id") REFERENCES "order" ("id");
+
+CREATE INDEX ON "voucher_redemption" ("voucher_id", "user_id") WHERE "released_at" IS NULL;
+
+CREATE INDEX ON "voucher_redemption" ("transaction_id") WHERE "released_at" IS NULL;
+
+-- count vouchers already spent on transactions that are paid or still waiting
+-- for payment, so existing usage counts towards the per user limit
+INSERT INTO "voucher_redemption" ("voucher_id", "user_id", "transaction_id")
+SELECT DISTINCT ON ("t"."id") "t"."voucher_marketplace_id", "o"."user_id", "t"."id"
+FROM "transaction" AS "t"
+INNER JOIN "order" AS "o" ON "o"."transaction_id" = "t"."id"
+WHERE "t"."voucher_marketplace_id" IS NOT NULL AND "t"."canceled_at" IS NULL AND "o"."user_id" IS NOT NULL;
+
+INSERT INTO "voucher_redemption" ("voucher_id", "user_id", "transaction_id", "order_id")
+SELECT "o"."voucher_shop_id", "o"."user_id", "o"."transaction_id", "o"."id"
+FROM "order" AS "o"
+INNER JOIN "transaction" AS "t" ON "t"."id" = "o"."transaction_id"
+WHERE "o"."voucher_shop_id" IS NOT NULL AND "t"."canceled_at" IS NULL AND "o"."user_id" IS NOT NULL
+  AND "o"."order_status_id" <> 8;
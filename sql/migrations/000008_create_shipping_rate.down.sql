@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "shipping_rate" CASCADE;
+
+DROP TABLE IF EXISTS "shipping_city" CASCADE;
+
+DROP TABLE IF EXISTS "shipping_province" CASCADE;
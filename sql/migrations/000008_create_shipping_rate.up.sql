@@ -0,0 +1,77 @@
+-- location and rate data for SHIPPING_PROVIDER=table, used where RajaOngkir
+-- can not be reached. ids follow RajaOngkir so addresses stay valid when
+-- switching providers.
+CREATE TABLE IF NOT EXISTS "shipping_province"
+(
+    "id" int PRIMARY KEY,
+    "name" varchar NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "shipping_city"
+(
+    "id" int PRIMARY KEY,
+    "province_id" int NOT NULL,
+    "type" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "postal_code" varchar NOT NULL,
+    "zone" varchar NOT NULL
+);
+
+ALTER TABLE "shipping_city"
+    ADD FOREIGN KEY ("province_id") REFERENCES "shipping_province" ("id");
+
+CREATE INDEX ON "shipping_city" ("province_id");
+
+CREATE TABLE IF NOT EXISTS "shipping_rate"
+(
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "courier_code" varchar NOT NULL,
+    "service" varchar NOT NULL,
+    "description" varchar NOT NULL,
+    "origin_zone" varchar NOT NULL,
+    "destination_zone" varchar NOT NULL,
+    "first_kg_fee" int NOT NULL CHECK ("first_kg_fee" >= 0),
+    "next_kg_fee" int NOT NULL CHECK ("next_kg_fee" >= 0),
+    "etd" varchar NOT NULL,
+    UNIQUE ("courier_code", "service", "origin_zone", "destination_zone")
+);
+
+INSERT INTO "shipping_province" ("id", "name")
+VALUES (1, 'Bali'),
+       (5, 'DI Yogyakarta'),
+       (6, 'DKI Jakarta'),
+       (9, 'Jawa Barat'),
+       (33, 'Sumatera Selatan');
+
+INSERT INTO "shipping_city" ("id", "province_id", "type", "name", "postal_code", "zone")
+VALUES (114, 1, 'Kota', 'Denpasar', '80227', 'bali-nusra'),
+       (501, 5, 'Kota', 'Yogyakarta', '55111', 'jawa'),
+       (151, 6, 'Kota', 'Jakarta Barat', '11220', 'jawa'),
+       (152, 6, 'Kota', 'Jakarta Pusat', '10540', 'jawa'),
+       (153, 6, 'Kota', 'Jakarta Selatan', '12230', 'jawa'),
+       (154, 6, 'Kota', 'Jakarta Timur', '13330', 'jawa'),
+       (155, 6, 'Kota', 'Jakarta Utara', '14140', 'jawa'),
+       (23, 9, 'Kota', 'Bandung', '40111', 'jawa'),
+       (327, 33, 'Kota', 'Palembang', '30111', 'sumatera');
+
+-- one card per courier service, scaled by how far apart the zones are
+INSERT INTO "shipping_rate" ("courier_code", "service", "description", "origin_zone", "destination_zone",
+                             "first_kg_fee", "next_kg_fee", "etd")
+SELECT "s"."courier_code", "s"."service", "s"."description", "z"."origin_zone", "z"."destination_zone",
+       "s"."first_kg_fee" * "z"."factor", "s"."next_kg_fee" * "z"."factor", "s"."etd"
+FROM (VALUES ('jne', 'OKE', 'Ongkos Kirim Ekonomis', 7000, 6000, '3-5'),
+             ('jne', 'REG', 'Layanan Reguler', 9000, 8000, '2-3'),
+             ('jne', 'YES', 'Yakin Esok Sampai', 18000, 16000, '1-1'),
+             ('pos', 'Pos Reguler', 'Pos Reguler', 8000, 7000, '3-4'),
+             ('tiki', 'REG', 'Regular Service', 9000, 8000, '2-4'))
+         AS "s" ("courier_code", "service", "description", "first_kg_fee", "next_kg_fee", "etd")
+CROSS JOIN (VALUES ('jawa', 'jawa', 1),
+                   ('sumatera', 'sumatera', 1),
+                   ('bali-nusra', 'bali-nusra', 1),
+                   ('jawa', 'sumatera', 2),
+                   ('sumatera', 'jawa', 2),
+                   ('jawa', 'bali-nusra', 2),
+                   ('bali-nusra', 'jawa', 2),
+                   ('sumatera', 'bali-nusra', 3),
+                   ('bali-nusra', 'sumatera', 3))
+         AS "z" ("origin_zone", "destination_zone", "factor");
@@ -0,0 +1,4 @@
+ALTER TABLE "wallet"
+    ADD COLUMN IF NOT EXISTS "attempt_count" int,
+    ADD COLUMN IF NOT EXISTS "attempt_at" timestamptz,
+    ADD COLUMN IF NOT EXISTS "unlocked_at" timestamptz;
@@ -0,0 +1,4 @@
+ALTER TABLE "wallet"
+    DROP COLUMN IF EXISTS "attempt_count",
+    DROP COLUMN IF EXISTS "attempt_at",
+    DROP COLUMN IF EXISTS "unlocked_at";
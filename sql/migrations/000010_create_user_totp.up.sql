@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS "user_totp"
+(
+    "user_id" UUID PRIMARY KEY,
+    "secret" varchar NOT NULL,
+    "last_used_step" bigint NOT NULL DEFAULT 0,
+    "confirmed_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (NOW()),
+    "updated_at" timestamptz
+);
+
+ALTER TABLE "user_totp"
+    ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE CASCADE;
+
+CREATE TABLE IF NOT EXISTS "user_recovery_code"
+(
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "user_id" UUID NOT NULL,
+    "code_hash" varchar NOT NULL,
+    "used_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (NOW())
+);
+
+ALTER TABLE "user_recovery_code"
+    ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX ON "user_recovery_code" ("user_id", "code_hash");
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "role_permission";
+DROP TABLE IF EXISTS "permission";
+
+DELETE
+FROM "role"
+WHERE "name" = 'refund_staff'
+  AND NOT EXISTS (SELECT 1 FROM "user" WHERE "user"."role_id" = "role"."id");
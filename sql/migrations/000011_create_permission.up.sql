@@ -0,0 +1,63 @@
+CREATE TABLE IF NOT EXISTS "permission"
+(
+    "id" serial PRIMARY KEY,
+    "name" varchar UNIQUE NOT NULL,
+    "description" varchar NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE IF NOT EXISTS "role_permission"
+(
+    "role_id" int NOT NULL,
+    "permission_id" int NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (NOW()),
+    PRIMARY KEY ("role_id", "permission_id")
+);
+
+ALTER TABLE "role_permission"
+    ADD FOREIGN KEY ("role_id") REFERENCES "role" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "role_permission"
+    ADD FOREIGN KEY ("permission_id") REFERENCES "permission" ("id") ON DELETE CASCADE;
+
+-- the role ids are the constant.Role* values the code registers users with
+INSERT INTO "role" ("id", "name")
+VALUES (1, 'user'),
+       (2, 'seller'),
+       (3, 'admin'),
+       (4, 'refund_staff')
+ON CONFLICT ("id") DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('"role"', 'id'), (SELECT MAX("id") FROM "role"));
+
+INSERT INTO "permission" ("name", "description")
+VALUES ('shop:manage', 'Manage the own shop, its couriers and withdrawals'),
+       ('product:manage', 'Create and update the own products'),
+       ('order:manage', 'Process the orders of the own shop'),
+       ('promotion:manage', 'Manage the vouchers and promotions of the own shop'),
+       ('refund:respond', 'Accept, reject and discuss refunds of the own shop'),
+       ('refund:read', 'List the refunds of every shop'),
+       ('refund:approve', 'Pay out accepted refunds'),
+       ('voucher:manage', 'Manage marketplace vouchers'),
+       ('category:manage', 'Manage product categories'),
+       ('banner:manage', 'Manage marketplace banners'),
+       ('media:upload', 'Upload banner and category pictures'),
+       ('job:manage', 'List and run background jobs');
+
+-- buyers only need to be signed in, so the user role has no permissions and
+-- sellers keep shopping with the same account
+INSERT INTO "role_permission" ("role_id", "permission_id")
+SELECT 2, "id"
+FROM "permission"
+WHERE "name" IN ('shop:manage', 'product:manage', 'order:manage', 'promotion:manage', 'refund:respond');
+
+INSERT INTO "role_permission" ("role_id", "permission_id")
+SELECT 3, "id"
+FROM "permission"
+WHERE "name" IN ('refund:read', 'refund:approve', 'voucher:manage', 'category:manage', 'banner:manage',
+                 'media:upload', 'job:manage');
+
+INSERT INTO "role_permission" ("role_id", "permission_id")
+SELECT 4, "id"
+FROM "permission"
+WHERE "name" IN ('refund:read', 'refund:approve');
@@ -0,0 +1,7 @@
+DELETE
+FROM "permission"
+WHERE "name" = 'audit:read';
+
+DROP TABLE IF EXISTS "audit_event";
+
+DROP FUNCTION IF EXISTS "audit_event_append_only"();
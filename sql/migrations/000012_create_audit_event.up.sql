@@ -0,0 +1,45 @@
+-- actor_id has no foreign key, the events of a user outlive the account
+CREATE TABLE IF NOT EXISTS "audit_event"
+(
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "actor_id" UUID,
+    "action" varchar NOT NULL,
+    "target_type" varchar NOT NULL,
+    "target_id" varchar NOT NULL,
+    "ip" varchar NOT NULL DEFAULT '',
+    "user_agent" varchar NOT NULL DEFAULT '',
+    "diff" jsonb NOT NULL DEFAULT '{}',
+    "created_at" timestamptz NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX ON "audit_event" ("created_at");
+CREATE INDEX ON "audit_event" ("actor_id", "created_at");
+CREATE INDEX ON "audit_event" ("target_type", "target_id", "created_at");
+CREATE INDEX ON "audit_event" ("action", "created_at");
+
+CREATE OR REPLACE FUNCTION "audit_event_append_only"() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_event is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "audit_event_no_change"
+    BEFORE UPDATE OR DELETE
+    ON "audit_event"
+    FOR EACH ROW
+EXECUTE FUNCTION "audit_event_append_only"();
+
+CREATE TRIGGER "audit_event_no_truncate"
+    BEFORE TRUNCATE
+    ON "audit_event"
+    FOR EACH STATEMENT
+EXECUTE FUNCTION "audit_event_append_only"();
+
+INSERT INTO "permission" ("name", "description")
+VALUES ('audit:read', 'Search the security audit log');
+
+INSERT INTO "role_permission" ("role_id", "permission_id")
+SELECT 3, "id"
+FROM "permission"
+WHERE "name" = 'audit:read';
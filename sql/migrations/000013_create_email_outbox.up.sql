@@ -0,0 +1,22 @@
+-- Emails are rendered when the change that sends them commits and delivered
+-- later by the email-outbox job. A row is pending until it is sent, or dead
+-- once it ran out of attempts.
+CREATE TABLE IF NOT EXISTS "email_outbox"
+(
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "recipient" varchar NOT NULL,
+    "subject" varchar NOT NULL,
+    "template" varchar NOT NULL,
+    "html_body" text NOT NULL,
+    "text_body" text NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'pending',
+    "attempts" int NOT NULL DEFAULT 0,
+    "last_error" varchar NOT NULL DEFAULT '',
+    "next_attempt_at" timestamptz NOT NULL DEFAULT (NOW()),
+    "sent_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (NOW()),
+    "updated_at" timestamptz NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX ON "email_outbox" ("next_attempt_at") WHERE "status" = 'pending';
+CREATE INDEX ON "email_outbox" ("status", "created_at");
@@ -0,0 +1,9 @@
+-- A user without a row gets every notification, rows are only written once
+-- the user changes a preference.
+CREATE TABLE IF NOT EXISTS "notification_preference"
+(
+    "user_id" UUID PRIMARY KEY REFERENCES "user" ("id") ON DELETE CASCADE,
+    "order_email" boolean NOT NULL DEFAULT TRUE,
+    "refund_email" boolean NOT NULL DEFAULT TRUE,
+    "updated_at" timestamptz NOT NULL DEFAULT (NOW())
+);
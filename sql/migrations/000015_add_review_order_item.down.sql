@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "review_order_item_id_key";
+
+ALTER TABLE "review"
+    DROP COLUMN IF EXISTS "order_item_id";
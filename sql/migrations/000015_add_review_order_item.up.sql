@@ -0,0 +1,7 @@
+-- A review is a verified purchase when it points at the order item it
+-- reviews. Reviews written before this have no order item and stay
+-- unverified.
+ALTER TABLE "review"
+    ADD COLUMN IF NOT EXISTS "order_item_id" uuid REFERENCES "order_item" ("id");
+
+CREATE UNIQUE INDEX IF NOT EXISTS "review_order_item_id_key" ON "review" ("order_item_id") WHERE "deleted_at" IS NULL;
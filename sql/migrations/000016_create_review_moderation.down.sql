@@ -0,0 +1,15 @@
+DELETE
+FROM "permission"
+WHERE "name" = 'review:moderate';
+
+DROP TABLE IF EXISTS "review_report";
+
+DROP TABLE IF EXISTS "review_vote";
+
+DROP TABLE IF EXISTS "review_reply";
+
+ALTER TABLE "review"
+    DROP COLUMN IF EXISTS "moderated_at",
+    DROP COLUMN IF EXISTS "hidden_at",
+    DROP COLUMN IF EXISTS "unhelpful_count",
+    DROP COLUMN IF EXISTS "helpful_count";
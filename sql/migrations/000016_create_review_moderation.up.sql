@@ -0,0 +1,51 @@
+-- helpful_count and unhelpful_count mirror review_vote so reviews can be
+-- sorted by them. moderated_at is the last time an admin hid or restored
+-- the review, the product metadata job uses it to recompute rating_avg.
+ALTER TABLE "review"
+    ADD COLUMN IF NOT EXISTS "helpful_count" int NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "unhelpful_count" int NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "hidden_at" timestamptz,
+    ADD COLUMN IF NOT EXISTS "moderated_at" timestamptz;
+
+CREATE TABLE IF NOT EXISTS "review_reply"
+(
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "review_id" UUID UNIQUE NOT NULL REFERENCES "review" ("id") ON DELETE CASCADE,
+    "shop_id" UUID NOT NULL REFERENCES "shop" ("id"),
+    "comment" text NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (NOW()),
+    "updated_at" timestamptz NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE IF NOT EXISTS "review_vote"
+(
+    "review_id" UUID NOT NULL REFERENCES "review" ("id") ON DELETE CASCADE,
+    "user_id" UUID NOT NULL REFERENCES "user" ("id") ON DELETE CASCADE,
+    "is_helpful" boolean NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (NOW()),
+    "updated_at" timestamptz NOT NULL DEFAULT (NOW()),
+    PRIMARY KEY ("review_id", "user_id")
+);
+
+-- a report is resolved once an admin hides or restores the review
+CREATE TABLE IF NOT EXISTS "review_report"
+(
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "review_id" UUID NOT NULL REFERENCES "review" ("id") ON DELETE CASCADE,
+    "user_id" UUID NOT NULL REFERENCES "user" ("id") ON DELETE CASCADE,
+    "reason" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (NOW()),
+    "resolved_at" timestamptz,
+    UNIQUE ("review_id", "user_id")
+);
+
+CREATE INDEX ON "review_report" ("review_id") WHERE "resolved_at" IS NULL;
+CREATE INDEX ON "review" ("hidden_at") WHERE "hidden_at" IS NOT NULL;
+
+INSERT INTO "permission" ("name", "description")
+VALUES ('review:moderate', 'Hide and restore reported product reviews');
+
+INSERT INTO "role_permission" ("role_id", "permission_id")
+SELECT 3, "id"
+FROM "permission"
+WHERE "name" = 'review:moderate';
@@ -0,0 +1,18 @@
+DROP MATERIALIZED VIEW IF EXISTS "product_search_word";
+
+DROP TRIGGER IF EXISTS "shop_search_vector_refresh" ON "shop";
+
+DROP TRIGGER IF EXISTS "category_search_vector_refresh" ON "category";
+
+DROP TRIGGER IF EXISTS "product_search_vector_update" ON "product";
+
+DROP FUNCTION IF EXISTS "product_search_vector_refresh"();
+
+DROP FUNCTION IF EXISTS "product_search_vector_update"();
+
+DROP FUNCTION IF EXISTS "product_search_vector"(varchar, varchar, uuid, uuid);
+
+DROP INDEX IF EXISTS "product_title_trgm_idx";
+
+ALTER TABLE "product"
+    DROP COLUMN IF EXISTS "search_vector";
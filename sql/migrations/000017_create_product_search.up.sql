@@ -0,0 +1,74 @@
+-- search_vector weighs the title above the category, the shop name and the
+-- description. The 'simple' configuration does not stem, titles mix
+-- Indonesian and English.
+ALTER TABLE "product"
+    ADD COLUMN IF NOT EXISTS "search_vector" tsvector;
+
+CREATE OR REPLACE FUNCTION "product_search_vector"(p_title varchar, p_description varchar, p_category_id uuid,
+                                                   p_shop_id uuid) RETURNS tsvector AS
+$$
+SELECT setweight(to_tsvector('simple', COALESCE(p_title, '')), 'A') ||
+       setweight(to_tsvector('simple', COALESCE((SELECT "name" FROM "category" WHERE "id" = p_category_id), '')), 'B') ||
+       setweight(to_tsvector('simple', COALESCE((SELECT "name" FROM "shop" WHERE "id" = p_shop_id), '')), 'C') ||
+       setweight(to_tsvector('simple', COALESCE(p_description, '')), 'D');
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION "product_search_vector_update"() RETURNS trigger AS
+$$
+BEGIN
+    NEW."search_vector" := "product_search_vector"(NEW."title", NEW."description", NEW."category_id", NEW."shop_id");
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "product_search_vector_update"
+    BEFORE INSERT OR UPDATE OF "title", "description", "category_id", "shop_id"
+    ON "product"
+    FOR EACH ROW
+EXECUTE FUNCTION "product_search_vector_update"();
+
+-- renaming a category or a shop changes the vector of its products
+CREATE OR REPLACE FUNCTION "product_search_vector_refresh"() RETURNS trigger AS
+$$
+BEGIN
+    IF TG_TABLE_NAME = 'category' THEN
+        UPDATE "product"
+        SET "search_vector" = "product_search_vector"("title", "description", "category_id", "shop_id")
+        WHERE "category_id" = NEW."id";
+    ELSE
+        UPDATE "product"
+        SET "search_vector" = "product_search_vector"("title", "description", "category_id", "shop_id")
+        WHERE "shop_id" = NEW."id";
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "category_search_vector_refresh"
+    AFTER UPDATE OF "name"
+    ON "category"
+    FOR EACH ROW
+    WHEN (OLD."name" IS DISTINCT FROM NEW."name")
+EXECUTE FUNCTION "product_search_vector_refresh"();
+
+CREATE TRIGGER "shop_search_vector_refresh"
+    AFTER UPDATE OF "name"
+    ON "shop"
+    FOR EACH ROW
+    WHEN (OLD."name" IS DISTINCT FROM NEW."name")
+EXECUTE FUNCTION "product_search_vector_refresh"();
+
+UPDATE "product"
+SET "search_vector" = "product_search_vector"("title", "description", "category_id", "shop_id");
+
+CREATE INDEX IF NOT EXISTS "product_search_vector_idx" ON "product" USING GIN ("search_vector");
+CREATE INDEX IF NOT EXISTS "product_title_trgm_idx" ON "product" USING GIN ("title" gin_trgm_ops);
+
+-- the words of listed products, "did you mean" picks the closest one for
+-- each word of a search. The product metadata job refreshes it.
+CREATE MATERIALIZED VIEW IF NOT EXISTS "product_search_word" AS
+SELECT "word", "ndoc"
+FROM ts_stat('SELECT "search_vector" FROM "product" WHERE "deleted_at" IS NULL AND "listed_status" = true');
+
+CREATE UNIQUE INDEX IF NOT EXISTS "product_search_word_word_key" ON "product_search_word" ("word");
+CREATE INDEX IF NOT EXISTS "product_search_word_trgm_idx" ON "product_search_word" USING GIN ("word" gin_trgm_ops);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "shop_name_trgm_idx";
+
+DROP INDEX IF EXISTS "category_name_trgm_idx";
+
+DROP VIEW IF EXISTS "search_zero_result";
+
+DROP TABLE IF EXISTS "search_query";
@@ -0,0 +1,22 @@
+-- every search of the product listing, a query without results is kept for
+-- merchandising
+CREATE TABLE IF NOT EXISTS "search_query"
+(
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "query" varchar NOT NULL,
+    "result_count" bigint NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (NOW())
+);
+
+CREATE INDEX ON "search_query" ("created_at");
+CREATE INDEX "search_query_zero_result_idx" ON "search_query" ("query", "created_at") WHERE "result_count" = 0;
+
+CREATE OR REPLACE VIEW "search_zero_result" AS
+SELECT "query", count(*) as "search_count", max("created_at") as "last_searched_at"
+FROM "search_query"
+WHERE "result_count" = 0
+GROUP BY "query";
+
+-- product titles already have product_title_trgm_idx
+CREATE INDEX IF NOT EXISTS "category_name_trgm_idx" ON "category" USING GIN ("name" gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS "shop_name_trgm_idx" ON "shop" USING GIN ("name" gin_trgm_ops);
@@ -0,0 +1,26 @@
+-- promotion quota taken by an order, given back when the order is canceled
+-- or its transaction expires. Quota taken before this table existed has no
+-- claim and is not given back.
+CREATE TABLE IF NOT EXISTS "promotion_claim"
+(
+    "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    "promotion_id" UUID NOT NULL,
+    "transaction_id" UUID NOT NULL,
+    "order_id" UUID NOT NULL,
+    "quantity" int NOT NULL CHECK ("quantity" > 0),
+    "created_at" timestamptz NOT NULL DEFAULT (NOW()),
+    "released_at" timestamptz
+);
+
+ALTER TABLE "promotion_claim"
+    ADD FOREIGN KEY ("promotion_id") REFERENCES "promotion" ("id");
+
+ALTER TABLE "promotion_claim"
+    ADD FOREIGN KEY ("transaction_id") REFERENCES "transaction" ("id");
+
+ALTER TABLE "promotion_claim"
+    ADD FOREIGN KEY ("order_id") REFERENCES "order" ("id");
+
+CREATE INDEX ON "promotion_claim" ("transaction_id") WHERE "released_at" IS NULL;
+
+CREATE INDEX ON "promotion_claim" ("order_id") WHERE "released_at" IS NULL;